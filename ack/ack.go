@@ -0,0 +1,252 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ack stores the acknowledgements of alerts and gossips them to the
+// other peers of the cluster.
+package ack
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/coder/quartz"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+
+	"github.com/prometheus/alertmanager/cluster"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// ErrNotFiring is returned when acknowledging an alert which isn't firing.
+var ErrNotFiring = errors.New("alert is not firing")
+
+// entry is the acknowledgement of an alert as gossiped between peers.
+type entry struct {
+	Fingerprint model.Fingerprint `json:"fingerprint"`
+	// StartsAt identifies the firing instance of the alert which was
+	// acknowledged. Once the alert is resolved, it fires again with a new
+	// start time and the acknowledgement no longer applies.
+	StartsAt time.Time `json:"startsAt"`
+	types.Ack
+}
+
+// Options configures Acks.
+type Options struct {
+	// DefaultDuration is how long acknowledgements without an expiry time
+	// last.
+	DefaultDuration time.Duration
+
+	Logger  *slog.Logger
+	Metrics prometheus.Registerer
+}
+
+// Acks holds the acknowledgements of alerts. It implements cluster.State.
+type Acks struct {
+	clock           quartz.Clock
+	defaultDuration time.Duration
+	logger          *slog.Logger
+
+	mtx       sync.RWMutex
+	st        map[model.Fingerprint]*entry
+	broadcast func([]byte)
+
+	acksTotal prometheus.Counter
+}
+
+// New returns a new Acks.
+func New(o Options) *Acks {
+	if o.Logger == nil {
+		o.Logger = promslog.NewNopLogger()
+	}
+	a := &Acks{
+		clock:           quartz.NewReal(),
+		defaultDuration: o.DefaultDuration,
+		logger:          o.Logger,
+		st:              map[model.Fingerprint]*entry{},
+		broadcast:       func([]byte) {},
+		acksTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_alert_acks_total",
+			Help: "Number of alerts acknowledged on this peer.",
+		}),
+	}
+	if o.Metrics != nil {
+		o.Metrics.MustRegister(
+			a.acksTotal,
+			prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Name: "alertmanager_alert_acks",
+				Help: "Number of unexpired alert acknowledgements.",
+			}, func() float64 {
+				a.mtx.RLock()
+				defer a.mtx.RUnlock()
+				return float64(len(a.st))
+			}),
+		)
+	}
+	return a
+}
+
+// SetBroadcast sets the function used to gossip new acknowledgements.
+func (a *Acks) SetBroadcast(f func([]byte)) {
+	a.mtx.Lock()
+	a.broadcast = f
+	a.mtx.Unlock()
+}
+
+// Ack acknowledges the firing alert until expiresAt. If expiresAt is zero,
+// the acknowledgement lasts for the default duration. Acknowledging an alert
+// again replaces the previous acknowledgement.
+func (a *Acks) Ack(alert *types.Alert, by, note string, expiresAt time.Time) (*types.Ack, error) {
+	if by == "" {
+		return nil, errors.New("missing acknowledger")
+	}
+	now := a.clock.Now()
+	if alert.ResolvedAt(now) {
+		return nil, ErrNotFiring
+	}
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(a.defaultDuration)
+	}
+	if !expiresAt.After(now) {
+		return nil, errors.New("expiry time must be in the future")
+	}
+
+	e := &entry{
+		Fingerprint: alert.Fingerprint(),
+		StartsAt:    alert.StartsAt,
+		Ack: types.Ack{
+			AcknowledgedBy: by,
+			Note:           note,
+			AcknowledgedAt: now,
+			ExpiresAt:      expiresAt,
+		},
+	}
+	b, err := json.Marshal([]*entry{e})
+	if err != nil {
+		return nil, err
+	}
+
+	a.mtx.Lock()
+	a.st[e.Fingerprint] = e
+	broadcast := a.broadcast
+	a.mtx.Unlock()
+
+	a.acksTotal.Inc()
+	broadcast(b)
+
+	ack := e.Ack
+	return &ack, nil
+}
+
+// Get returns the acknowledgement of the alert, or nil if the alert isn't
+// acknowledged. Acknowledgements don't apply to resolved alerts and alerts
+// which fired again after being resolved.
+func (a *Acks) Get(alert *types.Alert) *types.Ack {
+	now := a.clock.Now()
+	if alert.ResolvedAt(now) {
+		return nil
+	}
+
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+	e, ok := a.st[alert.Fingerprint()]
+	if !ok || !e.StartsAt.Equal(alert.StartsAt) || !e.ExpiresAt.After(now) {
+		return nil
+	}
+	ack := e.Ack
+	return &ack
+}
+
+// Acked returns true if the alert is acknowledged.
+func (a *Acks) Acked(alert *types.Alert) bool {
+	return a.Get(alert) != nil
+}
+
+// GC removes expired acknowledgements and returns how many were removed.
+func (a *Acks) GC() int {
+	now := a.clock.Now()
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	var n int
+	for fp, e := range a.st {
+		if !e.ExpiresAt.After(now) {
+			delete(a.st, fp)
+			n++
+		}
+	}
+	return n
+}
+
+// Maintenance garbage collects expired acknowledgements at the given interval
+// until stopc is closed.
+func (a *Acks) Maintenance(interval time.Duration, stopc <-chan struct{}) {
+	t := a.clock.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stopc:
+			return
+		case <-t.C:
+			if n := a.GC(); n > 0 {
+				a.logger.Debug("Garbage collected expired acknowledgements", "count", n)
+			}
+		}
+	}
+}
+
+// MarshalBinary implements cluster.State.
+func (a *Acks) MarshalBinary() ([]byte, error) {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+	entries := make([]*entry, 0, len(a.st))
+	for _, e := range a.st {
+		entries = append(entries, e)
+	}
+	return json.Marshal(entries)
+}
+
+// Merge implements cluster.State. Of two acknowledgements of the same alert,
+// the most recent one wins.
+func (a *Acks) Merge(b []byte) error {
+	var entries []*entry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return err
+	}
+	now := a.clock.Now()
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	var merged bool
+	for _, e := range entries {
+		if !e.ExpiresAt.After(now) {
+			continue
+		}
+		if prev, ok := a.st[e.Fingerprint]; ok && !e.AcknowledgedAt.After(prev.AcknowledgedAt) {
+			continue
+		}
+		a.st[e.Fingerprint] = e
+		merged = true
+	}
+	// Gossip acknowledgements seen for the first time to the other peers
+	// unless the message is oversized, in which case it was sent to all
+	// peers already.
+	if merged && !cluster.OversizedMessage(b) {
+		a.broadcast(b)
+	}
+	return nil
+}
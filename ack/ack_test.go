@@ -0,0 +1,121 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ack
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coder/quartz"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newTestAcks(t *testing.T) (*Acks, *quartz.Mock) {
+	clock := quartz.NewMock(t)
+	a := New(Options{DefaultDuration: time.Hour})
+	a.clock = clock
+	return a, clock
+}
+
+func firingAlert(startsAt time.Time) *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "test"},
+			StartsAt: startsAt,
+		},
+	}
+}
+
+func TestAck(t *testing.T) {
+	a, clock := newTestAcks(t)
+	var broadcasts int
+	a.SetBroadcast(func([]byte) { broadcasts++ })
+
+	alert := firingAlert(clock.Now().Add(-time.Minute))
+	require.Nil(t, a.Get(alert))
+
+	ack, err := a.Ack(alert, "alice", "looking into it", time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, &types.Ack{
+		AcknowledgedBy: "alice",
+		Note:           "looking into it",
+		AcknowledgedAt: clock.Now(),
+		ExpiresAt:      clock.Now().Add(time.Hour),
+	}, ack)
+	require.Equal(t, ack, a.Get(alert))
+	require.True(t, a.Acked(alert))
+	require.Equal(t, 1, broadcasts)
+
+	// The acknowledgement doesn't apply once the alert fired again.
+	require.False(t, a.Acked(firingAlert(clock.Now())))
+
+	// The acknowledgement doesn't apply to the resolved alert.
+	resolved := firingAlert(alert.StartsAt)
+	resolved.EndsAt = clock.Now()
+	require.False(t, a.Acked(resolved))
+	_, err = a.Ack(resolved, "alice", "", time.Time{})
+	require.ErrorIs(t, err, ErrNotFiring)
+
+	_, err = a.Ack(alert, "", "", time.Time{})
+	require.Error(t, err)
+	_, err = a.Ack(alert, "alice", "", clock.Now().Add(-time.Second))
+	require.Error(t, err)
+
+	// The acknowledgement expires.
+	clock.Advance(time.Hour)
+	require.False(t, a.Acked(alert))
+	require.Equal(t, 1, a.GC())
+	require.Empty(t, a.st)
+}
+
+func TestAcksMerge(t *testing.T) {
+	a1, clock := newTestAcks(t)
+	a2, _ := newTestAcks(t)
+	a2.clock = clock
+	var broadcasts int
+	a2.SetBroadcast(func([]byte) { broadcasts++ })
+
+	alert := firingAlert(clock.Now())
+	_, err := a1.Ack(alert, "alice", "", time.Time{})
+	require.NoError(t, err)
+	clock.Advance(time.Minute)
+	_, err = a2.Ack(alert, "bob", "", time.Time{})
+	require.NoError(t, err)
+	broadcasts = 0
+
+	// The older acknowledgement of alice is ignored.
+	b, err := a1.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, a2.Merge(b))
+	require.Equal(t, "bob", a2.Get(alert).AcknowledgedBy)
+	require.Equal(t, 0, broadcasts)
+
+	// The newer acknowledgement of bob replaces the one of alice and is
+	// gossiped further.
+	b, err = a2.MarshalBinary()
+	require.NoError(t, err)
+	a1.SetBroadcast(func([]byte) { broadcasts++ })
+	require.NoError(t, a1.Merge(b))
+	require.Equal(t, "bob", a1.Get(alert).AcknowledgedBy)
+	require.Equal(t, 1, broadcasts)
+
+	// Expired acknowledgements are not merged.
+	a3, clock3 := newTestAcks(t)
+	clock3.Set(clock.Now().Add(2 * time.Hour))
+	require.NoError(t, a3.Merge(b))
+	require.Empty(t, a3.st)
+}
@@ -14,6 +14,7 @@
 package api
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -26,19 +27,28 @@ import (
 	"github.com/prometheus/common/promslog"
 	"github.com/prometheus/common/route"
 
+	"github.com/prometheus/alertmanager/ack"
 	apiv2 "github.com/prometheus/alertmanager/api/v2"
 	"github.com/prometheus/alertmanager/cluster"
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/inhibit"
+	"github.com/prometheus/alertmanager/maintenance"
+	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/silence"
+	"github.com/prometheus/alertmanager/template"
 	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/alertmanager/wal"
 )
 
 // API represents all APIs of Alertmanager.
 type API struct {
 	v2                *apiv2.API
 	deprecationRouter *V1DeprecationRouter
+	silences          *silence.Silences
+	wal               *wal.Log
+	ingest            *http.ServeMux
 
 	requestsInFlight         prometheus.Gauge
 	concurrencyLimitExceeded prometheus.Counter
@@ -60,8 +70,33 @@ type Options struct {
 	// GroupMutedFunc is used be the API to know if an alert is muted.
 	// Mandatory.
 	GroupMutedFunc func(routeID, groupKey string) ([]string, bool)
+	// Acks to store the acknowledgements of alerts. If nil, alerts can't be
+	// acknowledged.
+	Acks *ack.Acks
+	// DisabledReceivers to store the receivers disabled for maintenance. If
+	// nil, receivers can't be disabled.
+	DisabledReceivers *maintenance.Receivers
+	// AlertsWAL records the received alerts to be replayed with
+	// POST /-/replay. If nil, the alerts aren't recorded.
+	AlertsWAL *wal.Log
 	// Peer from the gossip cluster. If nil, no clustering will be used.
 	Peer cluster.ClusterPeer
+	// DivergentPeersFunc returns the names of the peers making different
+	// suppression decisions. If nil, they are not reported.
+	DivergentPeersFunc func() []string
+	// HealthChecksFunc returns the state of the dependencies of
+	// Alertmanager reported by the status endpoint. If nil, it is not
+	// reported.
+	HealthChecksFunc func() []apiv2.HealthCheck
+	// ReceiverStatsFunc returns the statistics of the notifications of the
+	// receivers. If nil, none are reported.
+	ReceiverStatsFunc func() []notify.ReceiverStats
+	// EnablePreview enables the API rendering the notifications of
+	// integrations without sending them.
+	EnablePreview bool
+	// PayloadLog holds the requests of the failed notifications of the
+	// receivers with debug_log_payloads enabled. If nil, none are exposed.
+	PayloadLog *notify.PayloadLog
 	// Timeout for all HTTP connections. The zero value (and negative
 	// values) result in no timeout.
 	Timeout time.Duration
@@ -124,7 +159,15 @@ func New(opts Options) (*API, error) {
 		opts.AlertStatusFunc,
 		opts.GroupMutedFunc,
 		opts.Silences,
+		opts.Acks,
+		opts.DisabledReceivers,
+		opts.AlertsWAL,
 		opts.Peer,
+		opts.DivergentPeersFunc,
+		opts.HealthChecksFunc,
+		opts.ReceiverStatsFunc,
+		opts.EnablePreview,
+		opts.PayloadLog,
 		l.With("version", "v2"),
 		opts.Registry,
 	)
@@ -156,6 +199,9 @@ func New(opts Options) (*API, error) {
 	return &API{
 		deprecationRouter:        NewV1DeprecationRouter(l.With("version", "v1")),
 		v2:                       v2,
+		silences:                 opts.Silences,
+		wal:                      opts.AlertsWAL,
+		ingest:                   http.NewServeMux(),
 		requestsInFlight:         requestsInFlight,
 		concurrencyLimitExceeded: concurrencyLimitExceeded,
 		timeout:                  opts.Timeout,
@@ -173,6 +219,10 @@ func New(opts Options) (*API, error) {
 func (api *API) Register(r *route.Router, routePrefix string) *http.ServeMux {
 	// TODO(gotjosh) API V1 was removed as of version 0.27, when we reach 1.0.0 we should removed these deprecation warnings.
 	api.deprecationRouter.Register(r.WithPrefix("/api/v1"))
+	r.Get("/-/silences/conflicts", api.silenceConflicts)
+	if api.wal != nil {
+		r.Post("/-/replay", api.replay)
+	}
 
 	mux := http.NewServeMux()
 	mux.Handle("/", api.limitHandler(r))
@@ -189,14 +239,70 @@ func (api *API) Register(r *route.Router, routePrefix string) *http.ServeMux {
 		apiPrefix+"/api/v2/",
 		api.limitHandler(http.StripPrefix(apiPrefix, api.v2.Handler)),
 	)
+	mux.Handle(
+		apiPrefix+"/api/v2/ingest/",
+		api.limitHandler(http.StripPrefix(apiPrefix+"/api/v2/ingest", api.ingest)),
+	)
 
 	return mux
 }
 
-// Update config and resolve timeout of each API. APIv2 also needs
-// setAlertStatus to be updated.
-func (api *API) Update(cfg *config.Config, setAlertStatus func(model.LabelSet)) {
-	api.v2.Update(cfg, setAlertStatus)
+// HandleIngest registers a handler receiving alerts in the given format under
+// /api/v2/ingest/<format>. It must be called before Register.
+func (api *API) HandleIngest(format string, h http.Handler) {
+	api.ingest.Handle("/"+format, h)
+}
+
+// InsertAlerts inserts alerts the same way as alerts posted to the API.
+func (api *API) InsertAlerts(alerts []*types.Alert) error {
+	return api.v2.InsertAlerts(alerts)
+}
+
+// Update config and resolve timeout of each API. APIv2 also needs the
+// template, setAlertStatus and inhibitor to be updated.
+func (api *API) Update(cfg *config.Config, tmpl *template.Template, setAlertStatus func(model.LabelSet), inhibitor *inhibit.Inhibitor) {
+	api.v2.Update(cfg, tmpl, setAlertStatus, inhibitor)
+}
+
+// silenceConflicts reports the local silence edits that were discarded in
+// favor of a peer's version timestamped ahead of the local clock.
+func (api *API) silenceConflicts(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(api.silences.Conflicts()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// replay inserts the alerts received between the from and to query
+// parameters again from the write-ahead log, to recover the notifications
+// missed in the meantime.
+func (api *API) replay(w http.ResponseWriter, r *http.Request) {
+	from, err := time.Parse(time.RFC3339, r.URL.Query().Get("from"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid from parameter: %v", err), http.StatusBadRequest)
+		return
+	}
+	to := time.Now()
+	if v := r.URL.Query().Get("to"); v != "" {
+		if to, err = time.Parse(time.RFC3339, v); err != nil {
+			http.Error(w, fmt.Sprintf("invalid to parameter: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+	if to.Before(from) {
+		http.Error(w, "to must not be before from", http.StatusBadRequest)
+		return
+	}
+
+	res, err := api.wal.Replay(from, to, api.v2.ReplayAlerts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
 }
 
 func (api *API) limitHandler(h http.Handler) http.Handler {
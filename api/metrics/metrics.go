@@ -20,6 +20,9 @@ type Alerts struct {
 	firing   prometheus.Counter
 	resolved prometheus.Counter
 	invalid  prometheus.Counter
+
+	rateLimitedRequests prometheus.Counter
+	rateLimitedAlerts   prometheus.Counter
 }
 
 // NewAlerts returns an *Alerts struct for the given API version.
@@ -35,13 +38,25 @@ func NewAlerts(r prometheus.Registerer) *Alerts {
 		Help:        "The total number of received alerts that were invalid.",
 		ConstLabels: prometheus.Labels{"version": "v2"},
 	})
+	numRateLimitedRequests := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "alertmanager_alerts_rate_limited_requests_total",
+		Help:        "The total number of requests posting alerts rejected by the ingestion rate limit.",
+		ConstLabels: prometheus.Labels{"version": "v2"},
+	})
+	numRateLimitedAlerts := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "alertmanager_alerts_rate_limited_total",
+		Help:        "The total number of alerts of the requests rejected by the ingestion rate limit.",
+		ConstLabels: prometheus.Labels{"version": "v2"},
+	})
 	if r != nil {
-		r.MustRegister(numReceivedAlerts, numInvalidAlerts)
+		r.MustRegister(numReceivedAlerts, numInvalidAlerts, numRateLimitedRequests, numRateLimitedAlerts)
 	}
 	return &Alerts{
-		firing:   numReceivedAlerts.WithLabelValues("firing"),
-		resolved: numReceivedAlerts.WithLabelValues("resolved"),
-		invalid:  numInvalidAlerts,
+		firing:              numReceivedAlerts.WithLabelValues("firing"),
+		resolved:            numReceivedAlerts.WithLabelValues("resolved"),
+		invalid:             numInvalidAlerts,
+		rateLimitedRequests: numRateLimitedRequests,
+		rateLimitedAlerts:   numRateLimitedAlerts,
 	}
 }
 
@@ -53,3 +68,11 @@ func (a *Alerts) Resolved() prometheus.Counter { return a.resolved }
 
 // Invalid returns a counter of invalid alerts.
 func (a *Alerts) Invalid() prometheus.Counter { return a.invalid }
+
+// RateLimitedRequests returns a counter of the requests posting alerts
+// rejected by the ingestion rate limit.
+func (a *Alerts) RateLimitedRequests() prometheus.Counter { return a.rateLimitedRequests }
+
+// RateLimitedAlerts returns a counter of the alerts of the requests rejected
+// by the ingestion rate limit.
+func (a *Alerts) RateLimitedAlerts() prometheus.Counter { return a.rateLimitedAlerts }
@@ -14,9 +14,11 @@
 package v2
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
 	"regexp"
 	"sort"
@@ -32,6 +34,7 @@ import (
 	"github.com/prometheus/common/version"
 	"github.com/rs/cors"
 
+	"github.com/prometheus/alertmanager/ack"
 	"github.com/prometheus/alertmanager/api/metrics"
 	open_api_models "github.com/prometheus/alertmanager/api/v2/models"
 	"github.com/prometheus/alertmanager/api/v2/restapi"
@@ -41,34 +44,56 @@ import (
 	general_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/general"
 	receiver_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/receiver"
 	silence_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/silence"
+	template_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/template"
+	timeinterval_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/timeinterval"
 	"github.com/prometheus/alertmanager/cluster"
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/inhibit"
+	"github.com/prometheus/alertmanager/maintenance"
 	"github.com/prometheus/alertmanager/matcher/compat"
+	"github.com/prometheus/alertmanager/matcher/parse"
+	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/pkg/labels"
 	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/silence"
+	silenceconvert "github.com/prometheus/alertmanager/silence/convert"
 	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/store"
+	"github.com/prometheus/alertmanager/template"
 	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/alertmanager/wal"
 )
 
 // API represents an Alertmanager API v2.
 type API struct {
 	peer           cluster.ClusterPeer
 	silences       *silence.Silences
+	acks           *ack.Acks
+	disabled       *maintenance.Receivers
 	alerts         provider.Alerts
+	ingestLog      *wal.Log
 	alertGroups    groupsFn
 	getAlertStatus getAlertStatusFn
 	groupMutedFunc groupMutedFunc
+	divergentPeers divergentPeersFn
+	healthChecks   healthChecksFn
+	receiverStats  receiverStatsFn
+	previewEnabled bool
+	payloads       *notify.PayloadLog
 	uptime         time.Time
 
-	// mtx protects alertmanagerConfig, setAlertStatus and route.
+	// mtx protects alertmanagerConfig, template, setAlertStatus, inhibitor,
+	// route and rateLimiter.
 	mtx sync.RWMutex
 	// resolveTimeout represents the default resolve timeout that an alert is
 	// assigned if no end time is specified.
 	alertmanagerConfig *config.Config
+	template           *template.Template
 	route              *dispatch.Route
 	setAlertStatus     setAlertStatusFn
+	inhibitor          *inhibit.Inhibitor
+	rateLimiter        *rateLimiter
 
 	logger *slog.Logger
 	m      *metrics.Alerts
@@ -81,8 +106,24 @@ type (
 	groupMutedFunc   func(routeID, groupKey string) ([]string, bool)
 	getAlertStatusFn func(prometheus_model.Fingerprint) types.AlertStatus
 	setAlertStatusFn func(prometheus_model.LabelSet)
+	divergentPeersFn func() []string
+	healthChecksFn   func() []HealthCheck
+	receiverStatsFn  func() []notify.ReceiverStats
 )
 
+// HealthCheck is the state of a dependency of Alertmanager reported by the
+// status endpoint.
+type HealthCheck struct {
+	Name    string
+	Healthy bool
+	// Message details the state of the dependency, such as the error of the
+	// last failure.
+	Message string
+	// LastSuccess is the time of the last success of the dependency, zero if
+	// unknown.
+	LastSuccess time.Time
+}
+
 // NewAPI returns a new Alertmanager API v2.
 func NewAPI(
 	alerts provider.Alerts,
@@ -90,7 +131,15 @@ func NewAPI(
 	asf getAlertStatusFn,
 	gmf groupMutedFunc,
 	silences *silence.Silences,
+	acks *ack.Acks,
+	disabled *maintenance.Receivers,
+	ingestLog *wal.Log,
 	peer cluster.ClusterPeer,
+	dpf divergentPeersFn,
+	hcf healthChecksFn,
+	rsf receiverStatsFn,
+	enablePreview bool,
+	payloads *notify.PayloadLog,
 	l *slog.Logger,
 	r prometheus.Registerer,
 ) (*API, error) {
@@ -100,7 +149,15 @@ func NewAPI(
 		alertGroups:    gf,
 		groupMutedFunc: gmf,
 		peer:           peer,
+		divergentPeers: dpf,
+		healthChecks:   hcf,
+		receiverStats:  rsf,
+		previewEnabled: enablePreview,
+		payloads:       payloads,
 		silences:       silences,
+		acks:           acks,
+		disabled:       disabled,
+		ingestLog:      ingestLog,
 		logger:         l,
 		m:              metrics.NewAlerts(r),
 		uptime:         time.Now(),
@@ -126,13 +183,28 @@ func NewAPI(
 
 	openAPI.AlertGetAlertsHandler = alert_ops.GetAlertsHandlerFunc(api.getAlertsHandler)
 	openAPI.AlertPostAlertsHandler = alert_ops.PostAlertsHandlerFunc(api.postAlertsHandler)
+	openAPI.AlertPostAlertAckHandler = alert_ops.PostAlertAckHandlerFunc(api.postAlertAckHandler)
 	openAPI.AlertgroupGetAlertGroupsHandler = alertgroup_ops.GetAlertGroupsHandlerFunc(api.getAlertGroupsHandler)
 	openAPI.GeneralGetStatusHandler = general_ops.GetStatusHandlerFunc(api.getStatusHandler)
+	openAPI.GeneralGetClusterEventsHandler = general_ops.GetClusterEventsHandlerFunc(api.getClusterEventsHandler)
+	openAPI.GeneralGetEffectiveConfigHandler = general_ops.GetEffectiveConfigHandlerFunc(api.getEffectiveConfigHandler)
+	openAPI.AlertGetDebugCardinalityHandler = alert_ops.GetDebugCardinalityHandlerFunc(api.getDebugCardinalityHandler)
+	openAPI.AlertPostDebugInhibitionHandler = alert_ops.PostDebugInhibitionHandlerFunc(api.postDebugInhibitionHandler)
+	openAPI.ReceiverGetDebugNotificationsHandler = receiver_ops.GetDebugNotificationsHandlerFunc(api.getDebugNotificationsHandler)
+	openAPI.ReceiverPutReceiverDisableHandler = receiver_ops.PutReceiverDisableHandlerFunc(api.putReceiverDisableHandler)
+	openAPI.ReceiverDeleteReceiverDisableHandler = receiver_ops.DeleteReceiverDisableHandlerFunc(api.deleteReceiverDisableHandler)
 	openAPI.ReceiverGetReceiversHandler = receiver_ops.GetReceiversHandlerFunc(api.getReceiversHandler)
+	openAPI.ReceiverGetReceiverStatsHandler = receiver_ops.GetReceiverStatsHandlerFunc(api.getReceiverStatsHandler)
+	openAPI.ReceiverPostPreviewHandler = receiver_ops.PostPreviewHandlerFunc(api.postPreviewHandler)
 	openAPI.SilenceDeleteSilenceHandler = silence_ops.DeleteSilenceHandlerFunc(api.deleteSilenceHandler)
 	openAPI.SilenceGetSilenceHandler = silence_ops.GetSilenceHandlerFunc(api.getSilenceHandler)
 	openAPI.SilenceGetSilencesHandler = silence_ops.GetSilencesHandlerFunc(api.getSilencesHandler)
 	openAPI.SilencePostSilencesHandler = silence_ops.PostSilencesHandlerFunc(api.postSilencesHandler)
+	openAPI.SilencePostSilencesBatchHandler = silence_ops.PostSilencesBatchHandlerFunc(api.postSilencesBatchHandler)
+	openAPI.SilencePostSilencesImportHandler = silence_ops.PostSilencesImportHandlerFunc(api.postSilencesImportHandler)
+	openAPI.TemplateLintTemplatesHandler = template_ops.LintTemplatesHandlerFunc(api.lintTemplatesHandler)
+	openAPI.TimeintervalGetTimeIntervalsHandler = timeinterval_ops.GetTimeIntervalsHandlerFunc(api.getTimeIntervalsHandler)
+	openAPI.TimeintervalTestTimeIntervalHandler = timeinterval_ops.TestTimeIntervalHandlerFunc(api.testTimeIntervalHandler)
 
 	handleCORS := cors.Default().Handler
 	api.Handler = handleCORS(setResponseHeaders(openAPI.Serve(nil)))
@@ -158,13 +230,23 @@ func (api *API) requestLogger(req *http.Request) *slog.Logger {
 }
 
 // Update sets the API struct members that may change between reloads of alertmanager.
-func (api *API) Update(cfg *config.Config, setAlertStatus setAlertStatusFn) {
+func (api *API) Update(cfg *config.Config, tmpl *template.Template, setAlertStatus setAlertStatusFn, inhibitor *inhibit.Inhibitor) {
 	api.mtx.Lock()
 	defer api.mtx.Unlock()
 
 	api.alertmanagerConfig = cfg
+	api.template = tmpl
 	api.route = dispatch.NewRoute(cfg.Route, nil)
 	api.setAlertStatus = setAlertStatus
+	api.inhibitor = inhibitor
+	// The rate limiter is kept if unchanged so that reloading the
+	// configuration doesn't reset the limits of the clients.
+	switch {
+	case cfg.IngestionRateLimit == nil:
+		api.rateLimiter = nil
+	case api.rateLimiter == nil || api.rateLimiter.conf != *cfg.IngestionRateLimit:
+		api.rateLimiter = newRateLimiter(*cfg.IngestionRateLimit)
+	}
 }
 
 func (api *API) getStatusHandler(params general_ops.GetStatusParams) middleware.Responder {
@@ -218,23 +300,185 @@ func (api *API) getStatusHandler(params general_ops.GetStatusParams) middleware.
 			Status: &status,
 			Peers:  peers,
 		}
+		if api.divergentPeers != nil {
+			resp.Cluster.DivergentPeers = api.divergentPeers()
+		}
+	}
+
+	if api.healthChecks != nil {
+		resp.Health = healthStatus(api.healthChecks())
 	}
 
 	return general_ops.NewGetStatusOK().WithPayload(&resp)
 }
 
+func healthStatus(checks []HealthCheck) *open_api_models.HealthStatus {
+	healthy := true
+	res := &open_api_models.HealthStatus{
+		Healthy: &healthy,
+		Checks:  make([]*open_api_models.HealthCheck, 0, len(checks)),
+	}
+	for _, c := range checks {
+		c := c
+		healthy = healthy && c.Healthy
+		hc := &open_api_models.HealthCheck{
+			Name:    &c.Name,
+			Healthy: &c.Healthy,
+			Message: c.Message,
+		}
+		if !c.LastSuccess.IsZero() {
+			lastSuccess := strfmt.DateTime(c.LastSuccess)
+			hc.LastSuccess = &lastSuccess
+		}
+		res.Checks = append(res.Checks, hc)
+	}
+	return res
+}
+
+func (api *API) getClusterEventsHandler(params general_ops.GetClusterEventsParams) middleware.Responder {
+	res := open_api_models.ClusterEvents{}
+	// If alertmanager cluster feature is disabled, then api.peers == nil.
+	if api.peer == nil {
+		return general_ops.NewGetClusterEventsOK().WithPayload(res)
+	}
+	for _, e := range api.peer.Events() {
+		var (
+			t       = strfmt.DateTime(e.Time)
+			typ     = string(e.Type)
+			name    = e.Name
+			address = e.Address
+		)
+		res = append(res, &open_api_models.ClusterEvent{
+			Time:     &t,
+			Type:     &typ,
+			Name:     &name,
+			Address:  &address,
+			Reporter: e.Reporter,
+		})
+	}
+	return general_ops.NewGetClusterEventsOK().WithPayload(res)
+}
+
+func (api *API) getEffectiveConfigHandler(params general_ops.GetEffectiveConfigParams) middleware.Responder {
+	api.mtx.RLock()
+	defer api.mtx.RUnlock()
+
+	// The global defaults are already set when loading the configuration,
+	// the options of the routes are computed from the routing tree.
+	cfg := *api.alertmanagerConfig
+	cfg.Route = dispatch.EffectiveConfig(cfg.Route)
+	original := cfg.String()
+
+	return general_ops.NewGetEffectiveConfigOK().WithPayload(&open_api_models.AlertmanagerConfig{
+		Original: &original,
+	})
+}
+
 func (api *API) getReceiversHandler(params receiver_ops.GetReceiversParams) middleware.Responder {
 	api.mtx.RLock()
 	defer api.mtx.RUnlock()
 
 	receivers := make([]*open_api_models.Receiver, 0, len(api.alertmanagerConfig.Receivers))
 	for i := range api.alertmanagerConfig.Receivers {
-		receivers = append(receivers, &open_api_models.Receiver{Name: &api.alertmanagerConfig.Receivers[i].Name})
+		r := &open_api_models.Receiver{Name: &api.alertmanagerConfig.Receivers[i].Name}
+		if api.disabled != nil {
+			if d := api.disabled.Get(*r.Name); d != nil {
+				r.Disabled = ReceiverDisableToOpenAPIReceiverDisable(d)
+			}
+		}
+		receivers = append(receivers, r)
 	}
 
 	return receiver_ops.NewGetReceiversOK().WithPayload(receivers)
 }
 
+func (api *API) putReceiverDisableHandler(params receiver_ops.PutReceiverDisableParams) middleware.Responder {
+	logger := api.requestLogger(params.HTTPRequest)
+
+	if api.disabled == nil {
+		return receiver_ops.NewPutReceiverDisableInternalServerError().WithPayload("disabling receivers is not enabled")
+	}
+	if !api.receiverExists(params.Name) {
+		return receiver_ops.NewPutReceiverDisableNotFound()
+	}
+
+	d, err := prometheus_model.ParseDuration(*params.Disable.Duration)
+	if err != nil {
+		logger.Debug("Failed to parse duration", "err", err, "duration", *params.Disable.Duration)
+		return receiver_ops.NewPutReceiverDisableBadRequest().WithPayload(err.Error())
+	}
+
+	disable, err := api.disabled.Disable(params.Name, *params.Disable.CreatedBy, params.Disable.Comment, time.Duration(d))
+	if err != nil {
+		logger.Debug("Failed to disable receiver", "err", err, "receiver", params.Name)
+		return receiver_ops.NewPutReceiverDisableBadRequest().WithPayload(err.Error())
+	}
+	logger.Info("Receiver disabled", "receiver", params.Name, "created_by", disable.CreatedBy, "ends_at", disable.EndsAt)
+
+	return receiver_ops.NewPutReceiverDisableOK().WithPayload(ReceiverDisableToOpenAPIReceiverDisable(disable))
+}
+
+func (api *API) deleteReceiverDisableHandler(params receiver_ops.DeleteReceiverDisableParams) middleware.Responder {
+	logger := api.requestLogger(params.HTTPRequest)
+
+	if api.disabled == nil {
+		return receiver_ops.NewDeleteReceiverDisableInternalServerError().WithPayload("disabling receivers is not enabled")
+	}
+
+	if err := api.disabled.Enable(params.Name); err != nil {
+		if errors.Is(err, maintenance.ErrNotDisabled) {
+			return receiver_ops.NewDeleteReceiverDisableNotFound()
+		}
+		logger.Error("Failed to enable receiver", "err", err, "receiver", params.Name)
+		return receiver_ops.NewDeleteReceiverDisableInternalServerError().WithPayload(err.Error())
+	}
+	logger.Info("Receiver enabled", "receiver", params.Name)
+
+	return receiver_ops.NewDeleteReceiverDisableOK()
+}
+
+// receiverExists returns true if the receiver is defined in the current
+// configuration.
+func (api *API) receiverExists(name string) bool {
+	api.mtx.RLock()
+	defer api.mtx.RUnlock()
+
+	if api.alertmanagerConfig == nil {
+		return false
+	}
+	for _, r := range api.alertmanagerConfig.Receivers {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (api *API) getReceiverStatsHandler(params receiver_ops.GetReceiverStatsParams) middleware.Responder {
+	res := []*open_api_models.ReceiverStats{}
+	if api.receiverStats == nil {
+		return receiver_ops.NewGetReceiverStatsOK().WithPayload(res)
+	}
+	for _, st := range api.receiverStats() {
+		var (
+			name          = st.Receiver
+			notifications = int64(st.Notifications)
+			failed        = int64(st.Failed)
+		)
+		rs := &open_api_models.ReceiverStats{
+			Name:          &name,
+			Notifications: &notifications,
+			Failed:        &failed,
+		}
+		if !st.LastSent.IsZero() {
+			lastSent := strfmt.DateTime(st.LastSent)
+			rs.LastSent = &lastSent
+		}
+		res = append(res, rs)
+	}
+	return receiver_ops.NewGetReceiverStatsOK().WithPayload(res)
+}
+
 func (api *API) getAlertsHandler(params alert_ops.GetAlertsParams) middleware.Responder {
 	var (
 		receiverFilter *regexp.Regexp
@@ -246,7 +490,7 @@ func (api *API) getAlertsHandler(params alert_ops.GetAlertsParams) middleware.Re
 		logger = api.requestLogger(params.HTTPRequest)
 	)
 
-	matchers, err := parseFilter(params.Filter)
+	matchers, pseudoMatchers, err := parseAlertFilter(params.Filter)
 	if err != nil {
 		logger.Debug("Failed to parse matchers", "err", err)
 		return alertgroup_ops.NewGetAlertGroupsBadRequest().WithPayload(err.Error())
@@ -264,11 +508,23 @@ func (api *API) getAlertsHandler(params alert_ops.GetAlertsParams) middleware.Re
 		}
 	}
 
+	now := time.Now()
+	if params.State != nil && *params.State == "resolved" {
+		var since time.Time
+		if params.Since != nil {
+			since = time.Time(*params.Since)
+		}
+		res = api.getResolvedAlerts(matchers, pseudoMatchers, receiverFilter, since, now)
+		sort.Slice(res, func(i, j int) bool {
+			return *res[i].Fingerprint < *res[j].Fingerprint
+		})
+		return alert_ops.NewGetAlertsOK().WithPayload(res)
+	}
+
 	alerts := api.alerts.GetPending()
 	defer alerts.Close()
 
-	alertFilter := api.alertFilter(matchers, *params.Silenced, *params.Inhibited, *params.Active)
-	now := time.Now()
+	alertFilter := api.alertFilter(matchers, pseudoMatchers, *params.Silenced, *params.Inhibited, *params.Active)
 
 	api.mtx.RLock()
 	for a := range alerts.Next() {
@@ -282,7 +538,7 @@ func (api *API) getAlertsHandler(params alert_ops.GetAlertsParams) middleware.Re
 		routes := api.route.Match(a.Labels)
 		receivers := make([]string, 0, len(routes))
 		for _, r := range routes {
-			receivers = append(receivers, r.RouteOpts.Receiver)
+			receivers = append(receivers, r.RouteOpts.ReceiverFor(a.Labels))
 		}
 
 		if receiverFilter != nil && !receiversMatchFilter(receivers, receiverFilter) {
@@ -294,6 +550,7 @@ func (api *API) getAlertsHandler(params alert_ops.GetAlertsParams) middleware.Re
 		}
 
 		alert := AlertToOpenAPIAlert(a, api.getAlertStatus(a.Fingerprint()), receivers, nil)
+		api.setAcknowledgement(alert, a)
 
 		res = append(res, alert)
 	}
@@ -310,19 +567,118 @@ func (api *API) getAlertsHandler(params alert_ops.GetAlertsParams) middleware.Re
 	return alert_ops.NewGetAlertsOK().WithPayload(res)
 }
 
+// getResolvedAlerts returns the alerts matching the filters which resolved
+// at or after since and are still kept in memory.
+func (api *API) getResolvedAlerts(matchers []*labels.Matcher, pseudoMatchers []*parse.PseudoMatcher, receiverFilter *regexp.Regexp, since, now time.Time) open_api_models.GettableAlerts {
+	var alerts []*types.Alert
+	if ra, ok := api.alerts.(provider.ResolvedAlerts); ok {
+		alerts = ra.GetResolved(since, now)
+	} else {
+		it := api.alerts.GetPending()
+		for a := range it.Next() {
+			if a.ResolvedAt(now) && !a.EndsAt.Before(since) {
+				alerts = append(alerts, a)
+			}
+		}
+		it.Close()
+	}
+
+	res := open_api_models.GettableAlerts{}
+	api.mtx.RLock()
+	defer api.mtx.RUnlock()
+	for _, a := range alerts {
+		if !alertMatchesFilterLabels(&a.Alert, matchers) {
+			continue
+		}
+		routes := api.route.Match(a.Labels)
+		receivers := make([]string, 0, len(routes))
+		for _, r := range routes {
+			receivers = append(receivers, r.RouteOpts.ReceiverFor(a.Labels))
+		}
+		if receiverFilter != nil && !receiversMatchFilter(receivers, receiverFilter) {
+			continue
+		}
+		status := api.getAlertStatus(a.Fingerprint())
+		if !alertMatchesPseudoMatchers(a, status, pseudoMatchers, now) {
+			continue
+		}
+		res = append(res, AlertToOpenAPIAlert(a, status, receivers, nil))
+	}
+	return res
+}
+
 func (api *API) postAlertsHandler(params alert_ops.PostAlertsParams) middleware.Responder {
 	logger := api.requestLogger(params.HTTPRequest)
 
+	api.mtx.RLock()
+	origin := api.alertmanagerConfig.AlertOrigin
+	limiter := api.rateLimiter
+	api.mtx.RUnlock()
+
+	if limiter != nil {
+		if ok, wait := limiter.allow(limiter.key(params.HTTPRequest), time.Now()); !ok {
+			api.m.RateLimitedRequests().Inc()
+			api.m.RateLimitedAlerts().Add(float64(len(params.Alerts)))
+			logger.Debug("Rate limited request posting alerts", "alerts", len(params.Alerts), "retry_after", wait)
+			return alert_ops.NewPostAlertsTooManyRequests().
+				WithRetryAfter(int64(math.Ceil(wait.Seconds()))).
+				WithPayload(fmt.Sprintf("rate limit of %g requests per second exceeded", limiter.conf.Rate))
+		}
+	}
+
 	alerts := OpenAPIAlertsToAlerts(params.Alerts)
+	if origin != nil && origin.Header != "" {
+		if v := params.HTTPRequest.Header.Get(origin.Header); v != "" {
+			for _, a := range alerts {
+				a.Origins = []string{v}
+			}
+		}
+	}
+
+	err := api.InsertAlerts(alerts)
+	var validationErrs *types.MultiError
+	if errors.As(err, &validationErrs) {
+		logger.Error("Failed to validate alerts", "err", validationErrs.Error())
+		return alert_ops.NewPostAlertsBadRequest().WithPayload(validationErrs.Error())
+	}
+	if err != nil {
+		logger.Error("Failed to create alerts", "err", err)
+		return alert_ops.NewPostAlertsInternalServerError().WithPayload(err.Error())
+	}
+
+	return alert_ops.NewPostAlertsOK()
+}
+
+// InsertAlerts inserts alerts the same way as alerts posted to the API: it
+// sets their update time, defaults their start and end times and makes a best
+// effort to insert all alerts that are valid. If some alerts are invalid, a
+// *types.MultiError holding the validation errors is returned.
+func (api *API) InsertAlerts(alerts []*types.Alert) error {
 	now := time.Now()
 
 	api.mtx.RLock()
 	resolveTimeout := time.Duration(api.alertmanagerConfig.Global.ResolveTimeout)
+	rewrites := api.alertmanagerConfig.GeneratorURLRewrites
+	origin := api.alertmanagerConfig.AlertOrigin
 	api.mtx.RUnlock()
 
 	for _, alert := range alerts {
 		alert.UpdatedAt = now
 
+		if origin != nil && origin.Label != "" {
+			if v, ok := alert.Labels[origin.Label]; ok {
+				alert.Origins = types.MergeOrigins(alert.Origins, []string{string(v)})
+				delete(alert.Labels, origin.Label)
+			}
+		}
+
+		for _, r := range rewrites {
+			if u, ok := r.Rewrite(alert.GeneratorURL); ok {
+				alert.GeneratorURL = u
+				break
+			}
+		}
+
 		// Ensure StartsAt is set.
 		if alert.StartsAt.IsZero() {
 			if alert.EndsAt.IsZero() {
@@ -359,23 +715,39 @@ func (api *API) postAlertsHandler(params alert_ops.PostAlertsParams) middleware.
 		}
 		validAlerts = append(validAlerts, a)
 	}
+	if api.ingestLog != nil {
+		api.ingestLog.Append(validAlerts)
+	}
 	if err := api.alerts.Put(validAlerts...); err != nil {
-		logger.Error("Failed to create alerts", "err", err)
-		return alert_ops.NewPostAlertsInternalServerError().WithPayload(err.Error())
+		return err
 	}
 
 	if validationErrs.Len() > 0 {
-		logger.Error("Failed to validate alerts", "err", validationErrs.Error())
-		return alert_ops.NewPostAlertsBadRequest().WithPayload(validationErrs.Error())
+		return validationErrs
 	}
+	return nil
+}
 
-	return alert_ops.NewPostAlertsOK()
+// ReplayAlerts inserts alerts replayed from the write-ahead log of the
+// received alerts. Their start, end and update times are kept as received and
+// they aren't written to the log again. The alerts ending before the stored
+// alert with the same fingerprint started are skipped, as they would replace
+// it instead of being merged into it.
+func (api *API) ReplayAlerts(alerts []*types.Alert) error {
+	replayed := make([]*types.Alert, 0, len(alerts))
+	for _, a := range alerts {
+		if old, err := api.alerts.Get(a.Fingerprint()); err == nil && !a.EndsAt.IsZero() && !a.EndsAt.After(old.StartsAt) {
+			continue
+		}
+		replayed = append(replayed, a)
+	}
+	return api.alerts.Put(replayed...)
 }
 
 func (api *API) getAlertGroupsHandler(params alertgroup_ops.GetAlertGroupsParams) middleware.Responder {
 	logger := api.requestLogger(params.HTTPRequest)
 
-	matchers, err := parseFilter(params.Filter)
+	matchers, pseudoMatchers, err := parseAlertFilter(params.Filter)
 	if err != nil {
 		logger.Debug("Failed to parse matchers", "err", err)
 		return alertgroup_ops.NewGetAlertGroupsBadRequest().WithPayload(err.Error())
@@ -396,29 +768,56 @@ func (api *API) getAlertGroupsHandler(params alertgroup_ops.GetAlertGroupsParams
 
 	rf := func(receiverFilter *regexp.Regexp) func(r *dispatch.Route) bool {
 		return func(r *dispatch.Route) bool {
-			receiver := r.RouteOpts.Receiver
-			if receiverFilter != nil && !receiverFilter.MatchString(receiver) {
-				return false
+			if receiverFilter == nil {
+				return true
 			}
-			return true
+			for _, receiver := range r.RouteOpts.Receivers() {
+				if receiverFilter.MatchString(receiver) {
+					return true
+				}
+			}
+			return false
 		}
 	}(receiverFilter)
 
-	af := api.alertFilter(matchers, *params.Silenced, *params.Inhibited, *params.Active)
+	af := api.alertFilter(matchers, pseudoMatchers, *params.Silenced, *params.Inhibited, *params.Active)
 	alertGroups, allReceivers := api.alertGroups(rf, af)
 
-	res := make(open_api_models.AlertGroups, 0, len(alertGroups))
+	var (
+		res = make(open_api_models.AlertGroups, 0, len(alertGroups))
+		// The number of groups matching the filters, before the pagination.
+		total int64
+	)
 
 	for _, alertGroup := range alertGroups {
+		// Routes selecting their receiver from a label have groups of
+		// several receivers.
+		if receiverFilter != nil && !receiverFilter.MatchString(alertGroup.Receiver) {
+			continue
+		}
 		mutedBy, isMuted := api.groupMutedFunc(alertGroup.RouteID, alertGroup.GroupKey)
 		if !*params.Muted && isMuted {
 			continue
 		}
+		if !*params.Unmuted && !isMuted {
+			continue
+		}
+
+		total++
+		if total <= *params.Offset || (params.Limit != nil && int64(len(res)) >= *params.Limit) {
+			continue
+		}
 
 		ag := &open_api_models.AlertGroup{
-			Receiver: &open_api_models.Receiver{Name: &alertGroup.Receiver},
-			Labels:   ModelLabelSetToAPILabelSet(alertGroup.Labels),
-			Alerts:   make([]*open_api_models.GettableAlert, 0, len(alertGroup.Alerts)),
+			Receiver:     &open_api_models.Receiver{Name: &alertGroup.Receiver},
+			Labels:       ModelLabelSetToAPILabelSet(alertGroup.Labels),
+			Alerts:       make([]*open_api_models.GettableAlert, 0, len(alertGroup.Alerts)),
+			AlertCount:   int64(len(alertGroup.Alerts)),
+			RouteOptions: RouteOptsToOpenAPIRouteOptions(alertGroup.RouteID, alertGroup.RouteOpts),
+		}
+		if !*params.Alerts {
+			res = append(res, ag)
+			continue
 		}
 
 		for _, alert := range alertGroup.Alerts {
@@ -426,15 +825,61 @@ func (api *API) getAlertGroupsHandler(params alertgroup_ops.GetAlertGroupsParams
 			receivers := allReceivers[fp]
 			status := api.getAlertStatus(fp)
 			apiAlert := AlertToOpenAPIAlert(alert, status, receivers, mutedBy)
+			api.setAcknowledgement(apiAlert, alert)
 			ag.Alerts = append(ag.Alerts, apiAlert)
 		}
 		res = append(res, ag)
 	}
 
-	return alertgroup_ops.NewGetAlertGroupsOK().WithPayload(res)
+	return alertgroup_ops.NewGetAlertGroupsOK().WithXTotalCount(total).WithPayload(res)
 }
 
-func (api *API) alertFilter(matchers []*labels.Matcher, silenced, inhibited, active bool) func(a *types.Alert, now time.Time) bool {
+func (api *API) postAlertAckHandler(params alert_ops.PostAlertAckParams) middleware.Responder {
+	logger := api.requestLogger(params.HTTPRequest)
+
+	if api.acks == nil {
+		return alert_ops.NewPostAlertAckInternalServerError().WithPayload("acknowledgements are not enabled")
+	}
+
+	fp, err := prometheus_model.ParseFingerprint(params.Fingerprint)
+	if err != nil {
+		logger.Debug("Failed to parse fingerprint", "err", err, "fingerprint", params.Fingerprint)
+		return alert_ops.NewPostAlertAckBadRequest().WithPayload(err.Error())
+	}
+
+	alert, err := api.alerts.Get(fp)
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) || errors.Is(err, store.ErrNotFound) {
+			return alert_ops.NewPostAlertAckNotFound()
+		}
+		logger.Error("Failed to get alert", "err", err, "fingerprint", fp)
+		return alert_ops.NewPostAlertAckInternalServerError().WithPayload(err.Error())
+	}
+
+	a, err := api.acks.Ack(alert, *params.Ack.AcknowledgedBy, params.Ack.Note, time.Time(params.Ack.ExpiresAt))
+	if err != nil {
+		logger.Debug("Failed to acknowledge alert", "err", err, "fingerprint", fp)
+		if errors.Is(err, ack.ErrNotFiring) {
+			return alert_ops.NewPostAlertAckNotFound()
+		}
+		return alert_ops.NewPostAlertAckBadRequest().WithPayload(err.Error())
+	}
+
+	return alert_ops.NewPostAlertAckOK().WithPayload(AckToOpenAPIAck(a))
+}
+
+// setAcknowledgement adds the acknowledgement of the alert, if any, to its
+// status.
+func (api *API) setAcknowledgement(aa *open_api_models.GettableAlert, a *types.Alert) {
+	if api.acks == nil {
+		return
+	}
+	if acked := api.acks.Get(a); acked != nil {
+		aa.Status.Acknowledgement = AckToOpenAPIAck(acked)
+	}
+}
+
+func (api *API) alertFilter(matchers []*labels.Matcher, pseudoMatchers []*parse.PseudoMatcher, silenced, inhibited, active bool) func(a *types.Alert, now time.Time) bool {
 	return func(a *types.Alert, now time.Time) bool {
 		if !a.EndsAt.IsZero() && a.EndsAt.Before(now) {
 			return false
@@ -458,10 +903,36 @@ func (api *API) alertFilter(matchers []*labels.Matcher, silenced, inhibited, act
 			return false
 		}
 
+		if !alertMatchesPseudoMatchers(a, status, pseudoMatchers, now) {
+			return false
+		}
+
 		return alertMatchesFilterLabels(&a.Alert, matchers)
 	}
 }
 
+// alertMatchesPseudoMatchers returns true if the properties of the alert
+// match all the pseudo-matchers.
+func alertMatchesPseudoMatchers(a *types.Alert, status types.AlertStatus, pseudoMatchers []*parse.PseudoMatcher, now time.Time) bool {
+	for _, m := range pseudoMatchers {
+		var matches bool
+		switch m.Name {
+		case parse.PseudoAge:
+			matches = m.MatchesDuration(now.Sub(a.StartsAt))
+		case parse.PseudoStartsAt:
+			matches = m.MatchesTime(a.StartsAt)
+		case parse.PseudoUpdatedAt:
+			matches = m.MatchesTime(a.UpdatedAt)
+		case parse.PseudoState:
+			matches = m.MatchesValue(string(status.State))
+		}
+		if !matches {
+			return false
+		}
+	}
+	return true
+}
+
 func removeEmptyLabels(ls prometheus_model.LabelSet) {
 	for k, v := range ls {
 		if string(v) == "" {
@@ -521,7 +992,22 @@ func (api *API) getSilencesHandler(params silence_ops.GetSilencesParams) middlew
 		return silence_ops.NewGetSilencesBadRequest().WithPayload(err.Error())
 	}
 
-	psils, _, err := api.silences.Query()
+	var qparams []silence.QueryParam
+	if len(params.Matches) > 0 || len(params.Overlaps) > 0 {
+		lset, err := parseLabelSet(params.Matches)
+		if err != nil {
+			logger.Debug("Failed to parse label set", "err", err)
+			return silence_ops.NewGetSilencesBadRequest().WithPayload(err.Error())
+		}
+		overlaps, err := parseFilter(params.Overlaps)
+		if err != nil {
+			logger.Debug("Failed to parse matchers", "err", err)
+			return silence_ops.NewGetSilencesBadRequest().WithPayload(err.Error())
+		}
+		qparams = append(qparams, silence.QIntersects(lset, overlaps))
+	}
+
+	psils, _, err := api.silences.Query(qparams...)
 	if err != nil {
 		logger.Error("Failed to get silences", "err", err)
 		return silence_ops.NewGetSilencesInternalServerError().WithPayload(err.Error())
@@ -648,37 +1134,124 @@ func (api *API) deleteSilenceHandler(params silence_ops.DeleteSilenceParams) mid
 func (api *API) postSilencesHandler(params silence_ops.PostSilencesParams) middleware.Responder {
 	logger := api.requestLogger(params.HTTPRequest)
 
-	sil, err := PostableSilenceToProto(params.Silence)
+	id, err := api.setSilence(logger, params.Silence)
+	if err != nil {
+		if errors.Is(err, silence.ErrNotFound) {
+			return silence_ops.NewPostSilencesNotFound().WithPayload(err.Error())
+		}
+		return silence_ops.NewPostSilencesBadRequest().WithPayload(err.Error())
+	}
+
+	return silence_ops.NewPostSilencesOK().WithPayload(&silence_ops.PostSilencesOKBody{
+		SilenceID: id,
+	})
+}
+
+// setSilence creates or updates the silence and returns its ID.
+func (api *API) setSilence(logger *slog.Logger, ps *open_api_models.PostableSilence) (string, error) {
+	sil, err := PostableSilenceToProto(ps)
 	if err != nil {
 		logger.Error("Failed to marshal silence to proto", "err", err)
-		return silence_ops.NewPostSilencesBadRequest().WithPayload(
-			fmt.Sprintf("failed to convert API silence to internal silence: %v", err.Error()),
-		)
+		return "", fmt.Errorf("failed to convert API silence to internal silence: %v", err.Error())
 	}
+	return api.setProtoSilence(logger, sil)
+}
 
+// setProtoSilence creates or updates a silence and returns its ID.
+func (api *API) setProtoSilence(logger *slog.Logger, sil *silencepb.Silence) (string, error) {
 	if sil.StartsAt.After(sil.EndsAt) || sil.StartsAt.Equal(sil.EndsAt) {
 		msg := "Failed to create silence: start time must be before end time"
 		logger.Error(msg, "starts_at", sil.StartsAt, "ends_at", sil.EndsAt)
-		return silence_ops.NewPostSilencesBadRequest().WithPayload(msg)
+		return "", errors.New(msg)
 	}
 
 	if sil.EndsAt.Before(time.Now()) {
 		msg := "Failed to create silence: end time can't be in the past"
 		logger.Error(msg, "ends_at", sil.EndsAt)
-		return silence_ops.NewPostSilencesBadRequest().WithPayload(msg)
+		return "", errors.New(msg)
 	}
 
-	if err = api.silences.Set(sil); err != nil {
+	if err := api.silences.Set(sil); err != nil {
 		logger.Error("Failed to create silence", "err", err)
-		if errors.Is(err, silence.ErrNotFound) {
-			return silence_ops.NewPostSilencesNotFound().WithPayload(err.Error())
+		return "", err
+	}
+	return sil.Id, nil
+}
+
+func (api *API) postSilencesBatchHandler(params silence_ops.PostSilencesBatchParams) middleware.Responder {
+	logger := api.requestLogger(params.HTTPRequest)
+
+	// The operations are independent, the results report the failure of each
+	// of them instead of failing the whole request.
+	res := &open_api_models.SilenceBatchResults{
+		Silences: make([]*open_api_models.SilenceBatchResult, 0, len(params.Batch.Silences)),
+		Expire:   make([]*open_api_models.SilenceBatchResult, 0, len(params.Batch.Expire)),
+	}
+	for _, ps := range params.Batch.Silences {
+		r := &open_api_models.SilenceBatchResult{}
+		if ps == nil {
+			r.Error = "missing silence"
+		} else if id, err := api.setSilence(logger, ps); err != nil {
+			r.SilenceID = ps.ID
+			r.Error = err.Error()
+		} else {
+			r.SilenceID = id
 		}
-		return silence_ops.NewPostSilencesBadRequest().WithPayload(err.Error())
+		res.Silences = append(res.Silences, r)
+	}
+	for _, id := range params.Batch.Expire {
+		r := &open_api_models.SilenceBatchResult{SilenceID: id}
+		if err := api.silences.Expire(id); err != nil {
+			logger.Error("Failed to expire silence", "id", id, "err", err)
+			r.Error = err.Error()
+		}
+		res.Expire = append(res.Expire, r)
 	}
 
-	return silence_ops.NewPostSilencesOK().WithPayload(&silence_ops.PostSilencesOKBody{
-		SilenceID: sil.Id,
-	})
+	return silence_ops.NewPostSilencesBatchOK().WithPayload(res)
+}
+
+func (api *API) postSilencesImportHandler(params silence_ops.PostSilencesImportParams) middleware.Responder {
+	logger := api.requestLogger(params.HTTPRequest)
+
+	convert, ok := silenceconvert.Converters[*params.SilenceImport.Format]
+	if !ok {
+		return silence_ops.NewPostSilencesImportBadRequest().WithPayload(fmt.Sprintf("unknown format %q", *params.SilenceImport.Format))
+	}
+	data, err := json.Marshal(params.SilenceImport.Data)
+	if err != nil {
+		return silence_ops.NewPostSilencesImportBadRequest().WithPayload(err.Error())
+	}
+	converted, err := convert(data, time.Now())
+	if err != nil {
+		logger.Debug("Failed to convert silences", "format", *params.SilenceImport.Format, "err", err)
+		return silence_ops.NewPostSilencesImportBadRequest().WithPayload(err.Error())
+	}
+
+	// Like the batch operations, the silences are imported independently.
+	res := &open_api_models.SilenceImportResults{
+		Silences: make([]*open_api_models.SilenceImportResult, 0, len(converted)),
+	}
+	for _, c := range converted {
+		r := &open_api_models.SilenceImportResult{
+			Source: c.Source,
+			Lossy:  c.Lossy,
+		}
+		switch {
+		case c.Err != nil:
+			r.Error = c.Err.Error()
+		case params.SilenceImport.DryRun:
+		default:
+			if id, err := api.setProtoSilence(logger, c.Silence); err != nil {
+				r.Error = err.Error()
+			} else {
+				r.SilenceID = id
+			}
+		}
+		res.Silences = append(res.Silences, r)
+	}
+
+	return silence_ops.NewPostSilencesImportOK().WithPayload(res)
 }
 
 func parseFilter(filter []string) ([]*labels.Matcher, error) {
@@ -694,6 +1267,49 @@ func parseFilter(filter []string) ([]*labels.Matcher, error) {
 	return matchers, nil
 }
 
+// parseAlertFilter parses the filter of the alerts, split into the label
+// matchers and the pseudo-matchers which match the properties of the alerts,
+// such as @age>30m.
+func parseAlertFilter(filter []string) ([]*labels.Matcher, []*parse.PseudoMatcher, error) {
+	var (
+		labelFilter    = make([]string, 0, len(filter))
+		pseudoMatchers []*parse.PseudoMatcher
+	)
+	for _, s := range filter {
+		if !parse.IsPseudoMatcher(s) {
+			labelFilter = append(labelFilter, s)
+			continue
+		}
+		m, err := parse.ParsePseudoMatcher(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		pseudoMatchers = append(pseudoMatchers, m)
+	}
+	matchers, err := parseFilter(labelFilter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return matchers, pseudoMatchers, nil
+}
+
+// parseLabelSet returns the label set of a list of equality matchers, nil if
+// the list is empty.
+func parseLabelSet(matchers []string) (prometheus_model.LabelSet, error) {
+	ms, err := parseFilter(matchers)
+	if err != nil || len(ms) == 0 {
+		return nil, err
+	}
+	lset := make(prometheus_model.LabelSet, len(ms))
+	for _, m := range ms {
+		if m.Type != labels.MatchEqual {
+			return nil, fmt.Errorf("matcher %s isn't an equality matcher", m)
+		}
+		lset[prometheus_model.LabelName(m.Name)] = prometheus_model.LabelValue(m.Value)
+	}
+	return lset, nil
+}
+
 var (
 	swaggerSpecCacheMx       sync.Mutex
 	swaggerSpecCache         *loads.Document
@@ -46,8 +46,14 @@ type ClientOption func(*runtime.ClientOperation)
 type ClientService interface {
 	GetAlerts(params *GetAlertsParams, opts ...ClientOption) (*GetAlertsOK, error)
 
+	GetDebugCardinality(params *GetDebugCardinalityParams, opts ...ClientOption) (*GetDebugCardinalityOK, error)
+
+	PostAlertAck(params *PostAlertAckParams, opts ...ClientOption) (*PostAlertAckOK, error)
+
 	PostAlerts(params *PostAlertsParams, opts ...ClientOption) (*PostAlertsOK, error)
 
+	PostDebugInhibition(params *PostDebugInhibitionParams, opts ...ClientOption) (*PostDebugInhibitionOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
@@ -89,6 +95,82 @@ func (a *Client) GetAlerts(params *GetAlertsParams, opts ...ClientOption) (*GetA
 	panic(msg)
 }
 
+/*
+GetDebugCardinality Get the cardinality of the labels of the active alerts and the aggregation groups with the most alerts
+*/
+func (a *Client) GetDebugCardinality(params *GetDebugCardinalityParams, opts ...ClientOption) (*GetDebugCardinalityOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetDebugCardinalityParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "getDebugCardinality",
+		Method:             "GET",
+		PathPattern:        "/debug/cardinality",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetDebugCardinalityReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetDebugCardinalityOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for getDebugCardinality: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+PostAlertAck Acknowledge a firing alert
+*/
+func (a *Client) PostAlertAck(params *PostAlertAckParams, opts ...ClientOption) (*PostAlertAckOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPostAlertAckParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "postAlertAck",
+		Method:             "POST",
+		PathPattern:        "/alerts/{fingerprint}/ack",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PostAlertAckReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PostAlertAckOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for postAlertAck: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 PostAlerts Create new Alerts
 */
@@ -127,6 +209,44 @@ func (a *Client) PostAlerts(params *PostAlertsParams, opts ...ClientOption) (*Po
 	panic(msg)
 }
 
+/*
+PostDebugInhibition Evaluate every inhibition rule for a label set and report why the alert would or wouldn't be inhibited
+*/
+func (a *Client) PostDebugInhibition(params *PostDebugInhibitionParams, opts ...ClientOption) (*PostDebugInhibitionOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPostDebugInhibitionParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "postDebugInhibition",
+		Method:             "POST",
+		PathPattern:        "/debug/inhibition",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PostDebugInhibitionReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PostDebugInhibitionOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for postDebugInhibition: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
@@ -86,7 +86,7 @@ type GetAlertsParams struct {
 
 	/* Filter.
 
-	   A list of matchers to filter alerts by
+	   A list of matchers to filter alerts by. Pseudo-matchers such as @age>30m, @startsAt>=2024-01-01T00:00:00Z or @state=suppressed match the properties of the alerts rather than their labels
 	*/
 	Filter []string
 
@@ -100,7 +100,7 @@ type GetAlertsParams struct {
 
 	/* Receiver.
 
-	   A regex matching receivers to filter alerts by
+	   A regex matching receivers to filter alerts by. The receivers of an alert are resolved by matching its labels against the routing tree
 	*/
 	Receiver *string
 
@@ -112,6 +112,22 @@ type GetAlertsParams struct {
 	*/
 	Silenced *bool
 
+	/* Since.
+
+	   Only show resolved alerts which resolved at or after this time
+
+	   Format: date-time
+	*/
+	Since *strfmt.DateTime
+
+	/* State.
+
+	   Show firing alerts, or alerts resolved recently which are still kept in memory
+
+	   Default: "firing"
+	*/
+	State *string
+
 	/* Unprocessed.
 
 	   Show unprocessed alerts
@@ -144,6 +160,8 @@ func (o *GetAlertsParams) SetDefaults() {
 
 		silencedDefault = bool(true)
 
+		stateDefault = string("firing")
+
 		unprocessedDefault = bool(true)
 	)
 
@@ -151,6 +169,7 @@ func (o *GetAlertsParams) SetDefaults() {
 		Active:      &activeDefault,
 		Inhibited:   &inhibitedDefault,
 		Silenced:    &silencedDefault,
+		State:       &stateDefault,
 		Unprocessed: &unprocessedDefault,
 	}
 
@@ -248,6 +267,28 @@ func (o *GetAlertsParams) SetSilenced(silenced *bool) {
 	o.Silenced = silenced
 }
 
+// WithSince adds the since to the get alerts params
+func (o *GetAlertsParams) WithSince(since *strfmt.DateTime) *GetAlertsParams {
+	o.SetSince(since)
+	return o
+}
+
+// SetSince adds the since to the get alerts params
+func (o *GetAlertsParams) SetSince(since *strfmt.DateTime) {
+	o.Since = since
+}
+
+// WithState adds the state to the get alerts params
+func (o *GetAlertsParams) WithState(state *string) *GetAlertsParams {
+	o.SetState(state)
+	return o
+}
+
+// SetState adds the state to the get alerts params
+func (o *GetAlertsParams) SetState(state *string) {
+	o.State = state
+}
+
 // WithUnprocessed adds the unprocessed to the get alerts params
 func (o *GetAlertsParams) WithUnprocessed(unprocessed *bool) *GetAlertsParams {
 	o.SetUnprocessed(unprocessed)
@@ -346,6 +387,40 @@ func (o *GetAlertsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Reg
 		}
 	}
 
+	if o.Since != nil {
+
+		// query param since
+		var qrSince strfmt.DateTime
+
+		if o.Since != nil {
+			qrSince = *o.Since
+		}
+		qSince := qrSince.String()
+		if qSince != "" {
+
+			if err := r.SetQueryParam("since", qSince); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.State != nil {
+
+		// query param state
+		var qrState string
+
+		if o.State != nil {
+			qrState = *o.State
+		}
+		qState := qrState
+		if qState != "" {
+
+			if err := r.SetQueryParam("state", qState); err != nil {
+				return err
+			}
+		}
+	}
+
 	if o.Unprocessed != nil {
 
 		// query param unprocessed
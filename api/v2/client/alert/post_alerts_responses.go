@@ -23,8 +23,10 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // PostAlertsReader is a Reader for the PostAlerts structure.
@@ -47,6 +49,12 @@ func (o *PostAlertsReader) ReadResponse(response runtime.ClientResponse, consume
 			return nil, err
 		}
 		return nil, result
+	case 429:
+		result := NewPostAlertsTooManyRequests()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 500:
 		result := NewPostAlertsInternalServerError()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -180,6 +188,88 @@ func (o *PostAlertsBadRequest) readResponse(response runtime.ClientResponse, con
 	return nil
 }
 
+// NewPostAlertsTooManyRequests creates a PostAlertsTooManyRequests with default headers values
+func NewPostAlertsTooManyRequests() *PostAlertsTooManyRequests {
+	return &PostAlertsTooManyRequests{}
+}
+
+/*
+PostAlertsTooManyRequests describes a response with status code 429, with default header values.
+
+Too many requests
+*/
+type PostAlertsTooManyRequests struct {
+
+	/* The number of seconds after which the request can be retried
+	 */
+	RetryAfter int64
+
+	Payload string
+}
+
+// IsSuccess returns true when this post alerts too many requests response has a 2xx status code
+func (o *PostAlertsTooManyRequests) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this post alerts too many requests response has a 3xx status code
+func (o *PostAlertsTooManyRequests) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this post alerts too many requests response has a 4xx status code
+func (o *PostAlertsTooManyRequests) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this post alerts too many requests response has a 5xx status code
+func (o *PostAlertsTooManyRequests) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this post alerts too many requests response a status code equal to that given
+func (o *PostAlertsTooManyRequests) IsCode(code int) bool {
+	return code == 429
+}
+
+// Code gets the status code for the post alerts too many requests response
+func (o *PostAlertsTooManyRequests) Code() int {
+	return 429
+}
+
+func (o *PostAlertsTooManyRequests) Error() string {
+	return fmt.Sprintf("[POST /alerts][%d] postAlertsTooManyRequests  %+v", 429, o.Payload)
+}
+
+func (o *PostAlertsTooManyRequests) String() string {
+	return fmt.Sprintf("[POST /alerts][%d] postAlertsTooManyRequests  %+v", 429, o.Payload)
+}
+
+func (o *PostAlertsTooManyRequests) GetPayload() string {
+	return o.Payload
+}
+
+func (o *PostAlertsTooManyRequests) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// hydrates response header Retry-After
+	hdrRetryAfter := response.GetHeader("Retry-After")
+
+	if hdrRetryAfter != "" {
+		valretryAfter, err := swag.ConvertInt64(hdrRetryAfter)
+		if err != nil {
+			return errors.InvalidType("Retry-After", "header", "int64", hdrRetryAfter)
+		}
+		o.RetryAfter = valretryAfter
+	}
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewPostAlertsInternalServerError creates a PostAlertsInternalServerError with default headers values
 func NewPostAlertsInternalServerError() *PostAlertsInternalServerError {
 	return &PostAlertsInternalServerError{}
@@ -84,9 +84,17 @@ type GetAlertGroupsParams struct {
 	*/
 	Active *bool
 
+	/* Alerts.
+
+	   Include the alerts of the groups, only their number is reported otherwise
+
+	   Default: true
+	*/
+	Alerts *bool
+
 	/* Filter.
 
-	   A list of matchers to filter alerts by
+	   A list of matchers to filter alerts by. Pseudo-matchers such as @age>30m, @startsAt>=2024-01-01T00:00:00Z or @state=suppressed match the properties of the alerts rather than their labels
 	*/
 	Filter []string
 
@@ -98,6 +106,14 @@ type GetAlertGroupsParams struct {
 	*/
 	Inhibited *bool
 
+	/* Limit.
+
+	   The maximum number of groups to return
+
+	   Format: int64
+	*/
+	Limit *int64
+
 	/* Muted.
 
 	   Show muted alerts
@@ -106,6 +122,14 @@ type GetAlertGroupsParams struct {
 	*/
 	Muted *bool
 
+	/* Offset.
+
+	   The number of groups to skip
+
+	   Format: int64
+	*/
+	Offset *int64
+
 	/* Receiver.
 
 	   A regex matching receivers to filter alerts by
@@ -120,6 +144,14 @@ type GetAlertGroupsParams struct {
 	*/
 	Silenced *bool
 
+	/* Unmuted.
+
+	   Show groups which are not muted
+
+	   Default: true
+	*/
+	Unmuted *bool
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -140,18 +172,27 @@ func (o *GetAlertGroupsParams) SetDefaults() {
 	var (
 		activeDefault = bool(true)
 
+		alertsDefault = bool(true)
+
 		inhibitedDefault = bool(true)
 
 		mutedDefault = bool(true)
 
+		offsetDefault = int64(0)
+
 		silencedDefault = bool(true)
+
+		unmutedDefault = bool(true)
 	)
 
 	val := GetAlertGroupsParams{
 		Active:    &activeDefault,
+		Alerts:    &alertsDefault,
 		Inhibited: &inhibitedDefault,
 		Muted:     &mutedDefault,
+		Offset:    &offsetDefault,
 		Silenced:  &silencedDefault,
+		Unmuted:   &unmutedDefault,
 	}
 
 	val.timeout = o.timeout
@@ -204,6 +245,17 @@ func (o *GetAlertGroupsParams) SetActive(active *bool) {
 	o.Active = active
 }
 
+// WithAlerts adds the alerts to the get alert groups params
+func (o *GetAlertGroupsParams) WithAlerts(alerts *bool) *GetAlertGroupsParams {
+	o.SetAlerts(alerts)
+	return o
+}
+
+// SetAlerts adds the alerts to the get alert groups params
+func (o *GetAlertGroupsParams) SetAlerts(alerts *bool) {
+	o.Alerts = alerts
+}
+
 // WithFilter adds the filter to the get alert groups params
 func (o *GetAlertGroupsParams) WithFilter(filter []string) *GetAlertGroupsParams {
 	o.SetFilter(filter)
@@ -226,6 +278,17 @@ func (o *GetAlertGroupsParams) SetInhibited(inhibited *bool) {
 	o.Inhibited = inhibited
 }
 
+// WithLimit adds the limit to the get alert groups params
+func (o *GetAlertGroupsParams) WithLimit(limit *int64) *GetAlertGroupsParams {
+	o.SetLimit(limit)
+	return o
+}
+
+// SetLimit adds the limit to the get alert groups params
+func (o *GetAlertGroupsParams) SetLimit(limit *int64) {
+	o.Limit = limit
+}
+
 // WithMuted adds the muted to the get alert groups params
 func (o *GetAlertGroupsParams) WithMuted(muted *bool) *GetAlertGroupsParams {
 	o.SetMuted(muted)
@@ -237,6 +300,17 @@ func (o *GetAlertGroupsParams) SetMuted(muted *bool) {
 	o.Muted = muted
 }
 
+// WithOffset adds the offset to the get alert groups params
+func (o *GetAlertGroupsParams) WithOffset(offset *int64) *GetAlertGroupsParams {
+	o.SetOffset(offset)
+	return o
+}
+
+// SetOffset adds the offset to the get alert groups params
+func (o *GetAlertGroupsParams) SetOffset(offset *int64) {
+	o.Offset = offset
+}
+
 // WithReceiver adds the receiver to the get alert groups params
 func (o *GetAlertGroupsParams) WithReceiver(receiver *string) *GetAlertGroupsParams {
 	o.SetReceiver(receiver)
@@ -259,6 +333,17 @@ func (o *GetAlertGroupsParams) SetSilenced(silenced *bool) {
 	o.Silenced = silenced
 }
 
+// WithUnmuted adds the unmuted to the get alert groups params
+func (o *GetAlertGroupsParams) WithUnmuted(unmuted *bool) *GetAlertGroupsParams {
+	o.SetUnmuted(unmuted)
+	return o
+}
+
+// SetUnmuted adds the unmuted to the get alert groups params
+func (o *GetAlertGroupsParams) SetUnmuted(unmuted *bool) {
+	o.Unmuted = unmuted
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *GetAlertGroupsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -284,6 +369,23 @@ func (o *GetAlertGroupsParams) WriteToRequest(r runtime.ClientRequest, reg strfm
 		}
 	}
 
+	if o.Alerts != nil {
+
+		// query param alerts
+		var qrAlerts bool
+
+		if o.Alerts != nil {
+			qrAlerts = *o.Alerts
+		}
+		qAlerts := swag.FormatBool(qrAlerts)
+		if qAlerts != "" {
+
+			if err := r.SetQueryParam("alerts", qAlerts); err != nil {
+				return err
+			}
+		}
+	}
+
 	if o.Filter != nil {
 
 		// binding items for filter
@@ -312,6 +414,23 @@ func (o *GetAlertGroupsParams) WriteToRequest(r runtime.ClientRequest, reg strfm
 		}
 	}
 
+	if o.Limit != nil {
+
+		// query param limit
+		var qrLimit int64
+
+		if o.Limit != nil {
+			qrLimit = *o.Limit
+		}
+		qLimit := swag.FormatInt64(qrLimit)
+		if qLimit != "" {
+
+			if err := r.SetQueryParam("limit", qLimit); err != nil {
+				return err
+			}
+		}
+	}
+
 	if o.Muted != nil {
 
 		// query param muted
@@ -329,6 +448,23 @@ func (o *GetAlertGroupsParams) WriteToRequest(r runtime.ClientRequest, reg strfm
 		}
 	}
 
+	if o.Offset != nil {
+
+		// query param offset
+		var qrOffset int64
+
+		if o.Offset != nil {
+			qrOffset = *o.Offset
+		}
+		qOffset := swag.FormatInt64(qrOffset)
+		if qOffset != "" {
+
+			if err := r.SetQueryParam("offset", qOffset); err != nil {
+				return err
+			}
+		}
+	}
+
 	if o.Receiver != nil {
 
 		// query param receiver
@@ -363,6 +499,23 @@ func (o *GetAlertGroupsParams) WriteToRequest(r runtime.ClientRequest, reg strfm
 		}
 	}
 
+	if o.Unmuted != nil {
+
+		// query param unmuted
+		var qrUnmuted bool
+
+		if o.Unmuted != nil {
+			qrUnmuted = *o.Unmuted
+		}
+		qUnmuted := swag.FormatBool(qrUnmuted)
+		if qUnmuted != "" {
+
+			if err := r.SetQueryParam("unmuted", qUnmuted); err != nil {
+				return err
+			}
+		}
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
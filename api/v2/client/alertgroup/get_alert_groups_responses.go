@@ -23,8 +23,10 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 
 	"github.com/prometheus/alertmanager/api/v2/models"
 )
@@ -71,6 +73,11 @@ GetAlertGroupsOK describes a response with status code 200, with default header
 Get alert groups response
 */
 type GetAlertGroupsOK struct {
+
+	/* The number of groups matching the filters, regardless of the pagination
+	 */
+	XTotalCount int64
+
 	Payload models.AlertGroups
 }
 
@@ -118,6 +125,17 @@ func (o *GetAlertGroupsOK) GetPayload() models.AlertGroups {
 
 func (o *GetAlertGroupsOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	// hydrates response header X-Total-Count
+	hdrXTotalCount := response.GetHeader("X-Total-Count")
+
+	if hdrXTotalCount != "" {
+		valxTotalCount, err := swag.ConvertInt64(hdrXTotalCount)
+		if err != nil {
+			return errors.InvalidType("X-Total-Count", "header", "int64", hdrXTotalCount)
+		}
+		o.XTotalCount = valxTotalCount
+	}
+
 	// response payload
 	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
 		return err
@@ -29,6 +29,8 @@ import (
 	"github.com/prometheus/alertmanager/api/v2/client/general"
 	"github.com/prometheus/alertmanager/api/v2/client/receiver"
 	"github.com/prometheus/alertmanager/api/v2/client/silence"
+	"github.com/prometheus/alertmanager/api/v2/client/template"
+	"github.com/prometheus/alertmanager/api/v2/client/timeinterval"
 )
 
 // Default alertmanager API HTTP client.
@@ -78,6 +80,8 @@ func New(transport runtime.ClientTransport, formats strfmt.Registry) *Alertmanag
 	cli.General = general.New(transport, formats)
 	cli.Receiver = receiver.New(transport, formats)
 	cli.Silence = silence.New(transport, formats)
+	cli.Template = template.New(transport, formats)
+	cli.Timeinterval = timeinterval.New(transport, formats)
 	return cli
 }
 
@@ -132,6 +136,10 @@ type AlertmanagerAPI struct {
 
 	Silence silence.ClientService
 
+	Template template.ClientService
+
+	Timeinterval timeinterval.ClientService
+
 	Transport runtime.ClientTransport
 }
 
@@ -143,4 +151,6 @@ func (c *AlertmanagerAPI) SetTransport(transport runtime.ClientTransport) {
 	c.General.SetTransport(transport)
 	c.Receiver.SetTransport(transport)
 	c.Silence.SetTransport(transport)
+	c.Template.SetTransport(transport)
+	c.Timeinterval.SetTransport(transport)
 }
@@ -44,11 +44,91 @@ type ClientOption func(*runtime.ClientOperation)
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	GetClusterEvents(params *GetClusterEventsParams, opts ...ClientOption) (*GetClusterEventsOK, error)
+
+	GetEffectiveConfig(params *GetEffectiveConfigParams, opts ...ClientOption) (*GetEffectiveConfigOK, error)
+
 	GetStatus(params *GetStatusParams, opts ...ClientOption) (*GetStatusOK, error)
 
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+GetClusterEvents Get the last peers which joined, left or died in the cluster, most recent first
+*/
+func (a *Client) GetClusterEvents(params *GetClusterEventsParams, opts ...ClientOption) (*GetClusterEventsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetClusterEventsParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "getClusterEvents",
+		Method:             "GET",
+		PathPattern:        "/cluster/events",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetClusterEventsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetClusterEventsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for getClusterEvents: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+GetEffectiveConfig Get the running configuration with the defaults and the inherited route options set explicitly, secrets redacted
+*/
+func (a *Client) GetEffectiveConfig(params *GetEffectiveConfigParams, opts ...ClientOption) (*GetEffectiveConfigOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetEffectiveConfigParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "getEffectiveConfig",
+		Method:             "GET",
+		PathPattern:        "/config/effective",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetEffectiveConfigReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetEffectiveConfigOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for getEffectiveConfig: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 GetStatus Get current status of an Alertmanager instance and its cluster
 */
@@ -44,11 +44,135 @@ type ClientOption func(*runtime.ClientOperation)
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	DeleteReceiverDisable(params *DeleteReceiverDisableParams, opts ...ClientOption) (*DeleteReceiverDisableOK, error)
+
+	GetDebugNotifications(params *GetDebugNotificationsParams, opts ...ClientOption) (*GetDebugNotificationsOK, error)
+
+	GetReceiverStats(params *GetReceiverStatsParams, opts ...ClientOption) (*GetReceiverStatsOK, error)
+
 	GetReceivers(params *GetReceiversParams, opts ...ClientOption) (*GetReceiversOK, error)
 
+	PostPreview(params *PostPreviewParams, opts ...ClientOption) (*PostPreviewOK, error)
+
+	PutReceiverDisable(params *PutReceiverDisableParams, opts ...ClientOption) (*PutReceiverDisableOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+DeleteReceiverDisable Enable a disabled receiver again
+*/
+func (a *Client) DeleteReceiverDisable(params *DeleteReceiverDisableParams, opts ...ClientOption) (*DeleteReceiverDisableOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDeleteReceiverDisableParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "deleteReceiverDisable",
+		Method:             "DELETE",
+		PathPattern:        "/receivers/{name}/disable",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &DeleteReceiverDisableReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*DeleteReceiverDisableOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for deleteReceiverDisable: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+GetDebugNotifications Get the requests of the last failed notifications of the receivers with debug_log_payloads enabled
+*/
+func (a *Client) GetDebugNotifications(params *GetDebugNotificationsParams, opts ...ClientOption) (*GetDebugNotificationsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetDebugNotificationsParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "getDebugNotifications",
+		Method:             "GET",
+		PathPattern:        "/debug/notifications",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetDebugNotificationsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetDebugNotificationsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for getDebugNotifications: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+GetReceiverStats Get the notification statistics of the receivers over the last 24 hours
+*/
+func (a *Client) GetReceiverStats(params *GetReceiverStatsParams, opts ...ClientOption) (*GetReceiverStatsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetReceiverStatsParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "getReceiverStats",
+		Method:             "GET",
+		PathPattern:        "/receivers/stats",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetReceiverStatsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetReceiverStatsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for getReceiverStats: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 GetReceivers Get list of all receivers (name of notification integrations)
 */
@@ -87,6 +211,82 @@ func (a *Client) GetReceivers(params *GetReceiversParams, opts ...ClientOption)
 	panic(msg)
 }
 
+/*
+PostPreview Render the notifications of an integration without sending them
+*/
+func (a *Client) PostPreview(params *PostPreviewParams, opts ...ClientOption) (*PostPreviewOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPostPreviewParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "postPreview",
+		Method:             "POST",
+		PathPattern:        "/preview/{integration}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PostPreviewReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PostPreviewOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for postPreview: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+PutReceiverDisable Disable the integrations of a receiver for a while
+*/
+func (a *Client) PutReceiverDisable(params *PutReceiverDisableParams, opts ...ClientOption) (*PutReceiverDisableOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPutReceiverDisableParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "putReceiverDisable",
+		Method:             "PUT",
+		PathPattern:        "/receivers/{name}/disable",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PutReceiverDisableReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PutReceiverDisableOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for putReceiverDisable: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
@@ -82,6 +82,18 @@ type GetSilencesParams struct {
 	*/
 	Filter []string
 
+	/* Matches.
+
+	   A list of equality matchers forming a label set, to return the silences matching it
+	*/
+	Matches []string
+
+	/* Overlaps.
+
+	   A list of matchers, to return the silences whose matchers overlap them
+	*/
+	Overlaps []string
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -146,6 +158,28 @@ func (o *GetSilencesParams) SetFilter(filter []string) {
 	o.Filter = filter
 }
 
+// WithMatches adds the matches to the get silences params
+func (o *GetSilencesParams) WithMatches(matches []string) *GetSilencesParams {
+	o.SetMatches(matches)
+	return o
+}
+
+// SetMatches adds the matches to the get silences params
+func (o *GetSilencesParams) SetMatches(matches []string) {
+	o.Matches = matches
+}
+
+// WithOverlaps adds the overlaps to the get silences params
+func (o *GetSilencesParams) WithOverlaps(overlaps []string) *GetSilencesParams {
+	o.SetOverlaps(overlaps)
+	return o
+}
+
+// SetOverlaps adds the overlaps to the get silences params
+func (o *GetSilencesParams) SetOverlaps(overlaps []string) {
+	o.Overlaps = overlaps
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *GetSilencesParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -165,6 +199,28 @@ func (o *GetSilencesParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.R
 		}
 	}
 
+	if o.Matches != nil {
+
+		// binding items for matches
+		joinedMatches := o.bindParamMatches(reg)
+
+		// query array param matches
+		if err := r.SetQueryParam("matches", joinedMatches...); err != nil {
+			return err
+		}
+	}
+
+	if o.Overlaps != nil {
+
+		// binding items for overlaps
+		joinedOverlaps := o.bindParamOverlaps(reg)
+
+		// query array param overlaps
+		if err := r.SetQueryParam("overlaps", joinedOverlaps...); err != nil {
+			return err
+		}
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -187,3 +243,37 @@ func (o *GetSilencesParams) bindParamFilter(formats strfmt.Registry) []string {
 
 	return filterIS
 }
+
+// bindParamGetSilences binds the parameter matches
+func (o *GetSilencesParams) bindParamMatches(formats strfmt.Registry) []string {
+	matchesIR := o.Matches
+
+	var matchesIC []string
+	for _, matchesIIR := range matchesIR { // explode []string
+
+		matchesIIV := matchesIIR // string as string
+		matchesIC = append(matchesIC, matchesIIV)
+	}
+
+	// items.CollectionFormat: "multi"
+	matchesIS := swag.JoinByFormat(matchesIC, "multi")
+
+	return matchesIS
+}
+
+// bindParamGetSilences binds the parameter overlaps
+func (o *GetSilencesParams) bindParamOverlaps(formats strfmt.Registry) []string {
+	overlapsIR := o.Overlaps
+
+	var overlapsIC []string
+	for _, overlapsIIR := range overlapsIR { // explode []string
+
+		overlapsIIV := overlapsIIR // string as string
+		overlapsIC = append(overlapsIC, overlapsIIV)
+	}
+
+	// items.CollectionFormat: "multi"
+	overlapsIS := swag.JoinByFormat(overlapsIC, "multi")
+
+	return overlapsIS
+}
@@ -52,6 +52,10 @@ type ClientService interface {
 
 	PostSilences(params *PostSilencesParams, opts ...ClientOption) (*PostSilencesOK, error)
 
+	PostSilencesBatch(params *PostSilencesBatchParams, opts ...ClientOption) (*PostSilencesBatchOK, error)
+
+	PostSilencesImport(params *PostSilencesImportParams, opts ...ClientOption) (*PostSilencesImportOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
@@ -207,6 +211,82 @@ func (a *Client) PostSilences(params *PostSilencesParams, opts ...ClientOption)
 	panic(msg)
 }
 
+/*
+PostSilencesBatch Create, update and expire many silences at once. The operations are applied independently, the failure of one doesn't prevent the others.
+*/
+func (a *Client) PostSilencesBatch(params *PostSilencesBatchParams, opts ...ClientOption) (*PostSilencesBatchOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPostSilencesBatchParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "postSilencesBatch",
+		Method:             "POST",
+		PathPattern:        "/silences/batch",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PostSilencesBatchReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PostSilencesBatchOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for postSilencesBatch: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+PostSilencesImport Import the silences exported by Grafana Alerting or karma. The silences are converted and created independently, the failure of one doesn't prevent the others.
+*/
+func (a *Client) PostSilencesImport(params *PostSilencesImportParams, opts ...ClientOption) (*PostSilencesImportOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPostSilencesImportParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "postSilencesImport",
+		Method:             "POST",
+		PathPattern:        "/silences/import",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PostSilencesImportReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PostSilencesImportOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for postSilencesImport: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
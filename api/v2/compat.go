@@ -15,12 +15,15 @@ package v2
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/go-openapi/strfmt"
 	prometheus_model "github.com/prometheus/common/model"
 
 	open_api_models "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/maintenance"
 	"github.com/prometheus/alertmanager/silence/silencepb"
 	"github.com/prometheus/alertmanager/types"
 )
@@ -33,10 +36,11 @@ func GettableSilenceFromProto(s *silencepb.Silence) (open_api_models.GettableSil
 	state := string(types.CalcSilenceState(s.StartsAt, s.EndsAt))
 	sil := open_api_models.GettableSilence{
 		Silence: open_api_models.Silence{
-			StartsAt:  &start,
-			EndsAt:    &end,
-			Comment:   &s.Comment,
-			CreatedBy: &s.CreatedBy,
+			StartsAt:        &start,
+			EndsAt:          &end,
+			Comment:         &s.Comment,
+			CreatedBy:       &s.CreatedBy,
+			ExpireOnResolve: s.ExpireOnResolve,
 		},
 		ID:        &s.Id,
 		UpdatedAt: &updated,
@@ -81,11 +85,12 @@ func GettableSilenceFromProto(s *silencepb.Silence) (open_api_models.GettableSil
 // PostableSilenceToProto converts *open_api_models.PostableSilenc to *silencepb.Silence.
 func PostableSilenceToProto(s *open_api_models.PostableSilence) (*silencepb.Silence, error) {
 	sil := &silencepb.Silence{
-		Id:        s.ID,
-		StartsAt:  time.Time(*s.StartsAt),
-		EndsAt:    time.Time(*s.EndsAt),
-		Comment:   *s.Comment,
-		CreatedBy: *s.CreatedBy,
+		Id:              s.ID,
+		StartsAt:        time.Time(*s.StartsAt),
+		EndsAt:          time.Time(*s.EndsAt),
+		Comment:         *s.Comment,
+		CreatedBy:       *s.CreatedBy,
+		ExpireOnResolve: s.ExpireOnResolve,
 	}
 	for _, m := range s.Matchers {
 		matcher := &silencepb.Matcher{
@@ -116,6 +121,61 @@ func PostableSilenceToProto(s *open_api_models.PostableSilence) (*silencepb.Sile
 	return sil, nil
 }
 
+// AckToOpenAPIAck converts *types.Ack to *open_api_models.Acknowledgement.
+func AckToOpenAPIAck(a *types.Ack) *open_api_models.Acknowledgement {
+	acknowledgedAt := strfmt.DateTime(a.AcknowledgedAt)
+	expiresAt := strfmt.DateTime(a.ExpiresAt)
+	return &open_api_models.Acknowledgement{
+		AcknowledgedBy: &a.AcknowledgedBy,
+		AcknowledgedAt: &acknowledgedAt,
+		ExpiresAt:      &expiresAt,
+		Note:           a.Note,
+	}
+}
+
+// ReceiverDisableToOpenAPIReceiverDisable converts *maintenance.Disable to *open_api_models.ReceiverDisable.
+func ReceiverDisableToOpenAPIReceiverDisable(d *maintenance.Disable) *open_api_models.ReceiverDisable {
+	disabledAt := strfmt.DateTime(d.DisabledAt)
+	endsAt := strfmt.DateTime(d.EndsAt)
+	return &open_api_models.ReceiverDisable{
+		CreatedBy:  &d.CreatedBy,
+		Comment:    d.Comment,
+		DisabledAt: &disabledAt,
+		EndsAt:     &endsAt,
+	}
+}
+
+// RouteOptsToOpenAPIRouteOptions converts *dispatch.RouteOpts to *open_api_models.RouteOptions.
+func RouteOptsToOpenAPIRouteOptions(routeID string, opts *dispatch.RouteOpts) *open_api_models.RouteOptions {
+	if opts == nil {
+		return nil
+	}
+	groupBy := []string{"..."}
+	if !opts.GroupByAll {
+		groupBy = make([]string, 0, len(opts.GroupBy))
+		for ln := range opts.GroupBy {
+			groupBy = append(groupBy, string(ln))
+		}
+		sort.Strings(groupBy)
+	}
+	var (
+		groupWait      = prometheus_model.Duration(opts.GroupWait).String()
+		groupInterval  = prometheus_model.Duration(opts.GroupInterval).String()
+		repeatInterval = prometheus_model.Duration(opts.RepeatInterval).String()
+	)
+	return &open_api_models.RouteOptions{
+		RouteID:             &routeID,
+		Receiver:            &opts.Receiver,
+		ReceiverFromLabel:   string(opts.ReceiverFromLabel),
+		GroupBy:             groupBy,
+		GroupWait:           &groupWait,
+		GroupInterval:       &groupInterval,
+		RepeatInterval:      &repeatInterval,
+		MuteTimeIntervals:   append([]string{}, opts.MuteTimeIntervals...),
+		ActiveTimeIntervals: append([]string{}, opts.ActiveTimeIntervals...),
+	}
+}
+
 // AlertToOpenAPIAlert converts internal alerts, alert types, and receivers to *open_api_models.GettableAlert.
 func AlertToOpenAPIAlert(alert *types.Alert, status types.AlertStatus, receivers, mutedBy []string) *open_api_models.GettableAlert {
 	startsAt := strfmt.DateTime(alert.StartsAt)
@@ -0,0 +1,218 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"sort"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	prometheus_model "github.com/prometheus/common/model"
+
+	open_api_models "github.com/prometheus/alertmanager/api/v2/models"
+	alert_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/alert"
+	receiver_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/receiver"
+	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/inhibit"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func (api *API) getDebugNotificationsHandler(params receiver_ops.GetDebugNotificationsParams) middleware.Responder {
+	res := open_api_models.NotificationPayloads{}
+	if api.payloads == nil {
+		return receiver_ops.NewGetDebugNotificationsOK().WithPayload(res)
+	}
+	for _, e := range api.payloads.Entries() {
+		res = append(res, notificationPayload(e))
+	}
+	return receiver_ops.NewGetDebugNotificationsOK().WithPayload(res)
+}
+
+func notificationPayload(e notify.PayloadLogEntry) *open_api_models.NotificationPayload {
+	t := strfmt.DateTime(e.Time.UTC())
+	return &open_api_models.NotificationPayload{
+		Time:         &t,
+		Receiver:     &e.Receiver,
+		Integration:  &e.Integration,
+		GroupKey:     e.GroupKey,
+		Attempt:      int64(e.Attempt),
+		Method:       e.Method,
+		URL:          e.URL,
+		StatusCode:   int64(e.StatusCode),
+		RequestBody:  e.RequestBody,
+		ResponseBody: e.ResponseBody,
+		Error:        e.Error,
+	}
+}
+
+func (api *API) getDebugCardinalityHandler(params alert_ops.GetDebugCardinalityParams) middleware.Responder {
+	logger := api.requestLogger(params.HTTPRequest)
+
+	limit := 10
+	if params.Limit != nil {
+		limit = int(*params.Limit)
+	}
+	now := time.Now()
+	// Resolved alerts are kept until the next garbage collection, only the
+	// firing ones are counted.
+	firing := func(a *types.Alert, now time.Time) bool {
+		return !a.ResolvedAt(now)
+	}
+
+	var (
+		total  int64
+		values = map[prometheus_model.LabelName]map[prometheus_model.LabelValue]int64{}
+	)
+	alerts := api.alerts.GetPending()
+	defer alerts.Close()
+	for a := range alerts.Next() {
+		if err := alerts.Err(); err != nil {
+			logger.Error("Failed to iterate alerts", "err", err)
+			break
+		}
+		if !firing(a, now) {
+			continue
+		}
+		total++
+		for name, value := range a.Labels {
+			if values[name] == nil {
+				values[name] = map[prometheus_model.LabelValue]int64{}
+			}
+			values[name][value]++
+		}
+	}
+
+	res := &open_api_models.AlertCardinality{
+		Alerts: &total,
+		Labels: make([]*open_api_models.LabelCardinality, 0, len(values)),
+		Groups: []*open_api_models.GroupCardinality{},
+	}
+	for name, vs := range values {
+		var n int64
+		topValues := make([]*open_api_models.LabelValueCardinality, 0, len(vs))
+		for value, count := range vs {
+			n += count
+			v := string(value)
+			topValues = append(topValues, &open_api_models.LabelValueCardinality{
+				Value:  &v,
+				Alerts: &count,
+			})
+		}
+		sort.Slice(topValues, func(i, j int) bool {
+			if *topValues[i].Alerts != *topValues[j].Alerts {
+				return *topValues[i].Alerts > *topValues[j].Alerts
+			}
+			return *topValues[i].Value < *topValues[j].Value
+		})
+		labelName, distinct := string(name), int64(len(vs))
+		res.Labels = append(res.Labels, &open_api_models.LabelCardinality{
+			Name:      &labelName,
+			Values:    &distinct,
+			Alerts:    &n,
+			TopValues: topValues[:min(limit, len(topValues))],
+		})
+	}
+	sort.Slice(res.Labels, func(i, j int) bool {
+		if *res.Labels[i].Values != *res.Labels[j].Values {
+			return *res.Labels[i].Values > *res.Labels[j].Values
+		}
+		return *res.Labels[i].Name < *res.Labels[j].Name
+	})
+	res.Labels = res.Labels[:min(limit, len(res.Labels))]
+
+	groups, _ := api.alertGroups(func(*dispatch.Route) bool { return true }, firing)
+	for _, g := range groups {
+		n := int64(len(g.Alerts))
+		res.Groups = append(res.Groups, &open_api_models.GroupCardinality{
+			Labels:   ModelLabelSetToAPILabelSet(g.Labels),
+			Receiver: &g.Receiver,
+			Alerts:   &n,
+		})
+	}
+	sort.SliceStable(res.Groups, func(i, j int) bool {
+		return *res.Groups[i].Alerts > *res.Groups[j].Alerts
+	})
+	res.Groups = res.Groups[:min(limit, len(res.Groups))]
+
+	return alert_ops.NewGetDebugCardinalityOK().WithPayload(res)
+}
+
+func (api *API) postDebugInhibitionHandler(params alert_ops.PostDebugInhibitionParams) middleware.Responder {
+	lset := APILabelSetToModelLabelSet(params.Labels)
+	if err := lset.Validate(); err != nil {
+		return alert_ops.NewPostDebugInhibitionBadRequest().WithPayload(err.Error())
+	}
+
+	api.mtx.RLock()
+	inhibitor := api.inhibitor
+	api.mtx.RUnlock()
+
+	res := open_api_models.InhibitRuleExplanations{}
+	if inhibitor == nil {
+		return alert_ops.NewPostDebugInhibitionOK().WithPayload(res)
+	}
+	for i, e := range inhibitor.Explain(lset) {
+		res = append(res, inhibitRuleExplanation(i, e))
+	}
+	return alert_ops.NewPostDebugInhibitionOK().WithPayload(res)
+}
+
+func inhibitRuleExplanation(i int, e inhibit.RuleExplanation) *open_api_models.InhibitRuleExplanation {
+	index := int64(i)
+	res := &open_api_models.InhibitRuleExplanation{
+		Index:          &index,
+		SourceMatchers: []string{},
+		TargetMatchers: []string{},
+		Equal:          []string{},
+		TargetMatched:  &e.TargetMatched,
+		SourceMatched:  &e.SourceMatched,
+		Inhibited:      &e.Inhibited,
+		Sources:        []*open_api_models.InhibitSourceExplanation{},
+	}
+	for _, m := range e.Rule.SourceMatchers {
+		res.SourceMatchers = append(res.SourceMatchers, m.String())
+	}
+	for _, m := range e.Rule.TargetMatchers {
+		res.TargetMatchers = append(res.TargetMatchers, m.String())
+	}
+	for n := range e.Rule.Equal {
+		res.Equal = append(res.Equal, string(n))
+	}
+	sort.Strings(res.Equal)
+	if e.Inhibited {
+		res.InhibitedBy = e.InhibitedBy.String()
+	}
+
+	for _, s := range e.Sources {
+		fp := s.Fingerprint.String()
+		src := &open_api_models.InhibitSourceExplanation{
+			Fingerprint: &fp,
+			Labels:      ModelLabelSetToAPILabelSet(s.Labels),
+			TwoSided:    &s.TwoSided,
+			Mismatches:  []*open_api_models.InhibitEqualMismatch{},
+		}
+		for _, m := range s.Mismatches {
+			name, source, target := string(m.Name), string(m.Source), string(m.Target)
+			src.Mismatches = append(src.Mismatches, &open_api_models.InhibitEqualMismatch{
+				Name:        &name,
+				SourceValue: &source,
+				TargetValue: &target,
+			})
+		}
+		res.Sources = append(res.Sources, src)
+	}
+	return res
+}
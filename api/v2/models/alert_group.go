@@ -34,6 +34,9 @@ import (
 // swagger:model alertGroup
 type AlertGroup struct {
 
+	// The number of alerts of the group matching the filters
+	AlertCount int64 `json:"alertCount,omitempty"`
+
 	// alerts
 	// Required: true
 	Alerts []*GettableAlert `json:"alerts"`
@@ -45,6 +48,9 @@ type AlertGroup struct {
 	// receiver
 	// Required: true
 	Receiver *Receiver `json:"receiver"`
+
+	// route options
+	RouteOptions *RouteOptions `json:"routeOptions,omitempty"`
 }
 
 // Validate validates this alert group
@@ -63,6 +69,10 @@ func (m *AlertGroup) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateRouteOptions(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -136,6 +146,25 @@ func (m *AlertGroup) validateReceiver(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *AlertGroup) validateRouteOptions(formats strfmt.Registry) error {
+	if swag.IsZero(m.RouteOptions) { // not required
+		return nil
+	}
+
+	if m.RouteOptions != nil {
+		if err := m.RouteOptions.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("routeOptions")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("routeOptions")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ContextValidate validate this alert group based on the context it is used
 func (m *AlertGroup) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
@@ -152,6 +181,10 @@ func (m *AlertGroup) ContextValidate(ctx context.Context, formats strfmt.Registr
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateRouteOptions(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -214,6 +247,27 @@ func (m *AlertGroup) contextValidateReceiver(ctx context.Context, formats strfmt
 	return nil
 }
 
+func (m *AlertGroup) contextValidateRouteOptions(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.RouteOptions != nil {
+
+		if swag.IsZero(m.RouteOptions) { // not required
+			return nil
+		}
+
+		if err := m.RouteOptions.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("routeOptions")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("routeOptions")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *AlertGroup) MarshalBinary() ([]byte, error) {
 	if m == nil {
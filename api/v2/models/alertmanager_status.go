@@ -41,6 +41,9 @@ type AlertmanagerStatus struct {
 	// Required: true
 	Config *AlertmanagerConfig `json:"config"`
 
+	// health
+	Health *HealthStatus `json:"health,omitempty"`
+
 	// uptime
 	// Required: true
 	// Format: date-time
@@ -63,6 +66,10 @@ func (m *AlertmanagerStatus) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateHealth(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateUptime(formats); err != nil {
 		res = append(res, err)
 	}
@@ -117,6 +124,25 @@ func (m *AlertmanagerStatus) validateConfig(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *AlertmanagerStatus) validateHealth(formats strfmt.Registry) error {
+	if swag.IsZero(m.Health) { // not required
+		return nil
+	}
+
+	if m.Health != nil {
+		if err := m.Health.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("health")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("health")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *AlertmanagerStatus) validateUptime(formats strfmt.Registry) error {
 
 	if err := validate.Required("uptime", "body", m.Uptime); err != nil {
@@ -162,6 +188,10 @@ func (m *AlertmanagerStatus) ContextValidate(ctx context.Context, formats strfmt
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateHealth(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateVersionInfo(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -206,6 +236,27 @@ func (m *AlertmanagerStatus) contextValidateConfig(ctx context.Context, formats
 	return nil
 }
 
+func (m *AlertmanagerStatus) contextValidateHealth(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Health != nil {
+
+		if swag.IsZero(m.Health) { // not required
+			return nil
+		}
+
+		if err := m.Health.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("health")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("health")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *AlertmanagerStatus) contextValidateVersionInfo(ctx context.Context, formats strfmt.Registry) error {
 
 	if m.VersionInfo != nil {
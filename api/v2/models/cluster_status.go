@@ -35,6 +35,9 @@ import (
 // swagger:model clusterStatus
 type ClusterStatus struct {
 
+	// Names of the peers whose silenced and inhibited alerts differed from the local ones at the last consistency check.
+	DivergentPeers []string `json:"divergentPeers"`
+
 	// name
 	Name string `json:"name,omitempty"`
 
@@ -33,6 +33,9 @@ import (
 // swagger:model receiver
 type Receiver struct {
 
+	// disabled
+	Disabled *ReceiverDisable `json:"disabled,omitempty"`
+
 	// name
 	// Required: true
 	Name *string `json:"name"`
@@ -42,6 +45,10 @@ type Receiver struct {
 func (m *Receiver) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateDisabled(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateName(formats); err != nil {
 		res = append(res, err)
 	}
@@ -52,6 +59,25 @@ func (m *Receiver) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Receiver) validateDisabled(formats strfmt.Registry) error {
+	if swag.IsZero(m.Disabled) { // not required
+		return nil
+	}
+
+	if m.Disabled != nil {
+		if err := m.Disabled.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("disabled")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("disabled")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Receiver) validateName(formats strfmt.Registry) error {
 
 	if err := validate.Required("name", "body", m.Name); err != nil {
@@ -61,8 +87,38 @@ func (m *Receiver) validateName(formats strfmt.Registry) error {
 	return nil
 }
 
-// ContextValidate validates this receiver based on context it is used
+// ContextValidate validate this receiver based on the context it is used
 func (m *Receiver) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateDisabled(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Receiver) contextValidateDisabled(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Disabled != nil {
+
+		if swag.IsZero(m.Disabled) { // not required
+			return nil
+		}
+
+		if err := m.Disabled.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("disabled")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("disabled")
+			}
+			return err
+		}
+	}
+
 	return nil
 }
 
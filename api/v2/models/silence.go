@@ -46,6 +46,9 @@ type Silence struct {
 	// Format: date-time
 	EndsAt *strfmt.DateTime `json:"endsAt"`
 
+	// Expire the silence once no firing alerts match it for a grace period.
+	ExpireOnResolve bool `json:"expireOnResolve,omitempty"`
+
 	// matchers
 	// Required: true
 	Matchers Matchers `json:"matchers"`
@@ -0,0 +1,237 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	prometheus_model "github.com/prometheus/common/model"
+	"gopkg.in/yaml.v2"
+
+	open_api_models "github.com/prometheus/alertmanager/api/v2/models"
+	receiver_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/receiver"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/config/receiver"
+	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func (api *API) postPreviewHandler(params receiver_ops.PostPreviewParams) middleware.Responder {
+	logger := api.requestLogger(params.HTTPRequest)
+
+	if !api.previewEnabled {
+		return receiver_ops.NewPostPreviewForbidden().WithPayload("the preview API is disabled")
+	}
+
+	api.mtx.RLock()
+	conf, tmpl := api.alertmanagerConfig, api.template
+	api.mtx.RUnlock()
+	if tmpl == nil {
+		return receiver_ops.NewPostPreviewForbidden().WithPayload("no configuration loaded yet")
+	}
+
+	// The templates of the given configuration are ignored, notifications
+	// are rendered with the templates of the running configuration.
+	if params.Preview.Config != "" {
+		if err := checkNoFileReferences(params.Preview.Config); err != nil {
+			return receiver_ops.NewPostPreviewBadRequest().WithPayload(err.Error())
+		}
+		c, err := config.Load(params.Preview.Config)
+		if err != nil {
+			return receiver_ops.NewPostPreviewBadRequest().WithPayload(err.Error())
+		}
+		conf = c
+	}
+
+	var rcv *config.Receiver
+	for i := range conf.Receivers {
+		if conf.Receivers[i].Name == *params.Preview.Receiver {
+			rcv = &conf.Receivers[i]
+			break
+		}
+	}
+	if rcv == nil {
+		return receiver_ops.NewPostPreviewNotFound().WithPayload(fmt.Sprintf("receiver %q not found", *params.Preview.Receiver))
+	}
+	integrations, err := receiver.BuildReceiverIntegrations(*rcv, tmpl, logger)
+	if err != nil {
+		return receiver_ops.NewPostPreviewBadRequest().WithPayload(err.Error())
+	}
+	integrations = filterIntegrations(integrations, params.Integration)
+	if len(integrations) == 0 {
+		return receiver_ops.NewPostPreviewNotFound().WithPayload(fmt.Sprintf("receiver %q has no %s integration", rcv.Name, params.Integration))
+	}
+
+	now := time.Now()
+	alerts := OpenAPIAlertsToAlerts(params.Preview.Alerts)
+	validationErrs := &types.MultiError{}
+	for _, a := range alerts {
+		if a.StartsAt.IsZero() {
+			if a.EndsAt.IsZero() {
+				a.StartsAt = now
+			} else {
+				a.StartsAt = a.EndsAt
+			}
+		}
+		if a.EndsAt.IsZero() {
+			a.EndsAt = now.Add(time.Duration(conf.Global.ResolveTimeout))
+		}
+		removeEmptyLabels(a.Labels)
+		if err := a.Validate(); err != nil {
+			validationErrs.Add(err)
+		}
+	}
+	if validationErrs.Len() > 0 {
+		return receiver_ops.NewPostPreviewBadRequest().WithPayload(validationErrs.Error())
+	}
+
+	ctx := previewContext(params.HTTPRequest.Context(), dispatch.NewRoute(conf.Route, nil), rcv.Name, alerts, now)
+	res := open_api_models.IntegrationPreviews{}
+	for _, i := range integrations {
+		res = append(res, previewIntegration(ctx, i, alerts))
+	}
+	return receiver_ops.NewPostPreviewOK().WithPayload(res)
+}
+
+// filterIntegrations returns the integrations with the given name.
+func filterIntegrations(integrations []notify.Integration, name string) []notify.Integration {
+	var res []notify.Integration
+	for _, i := range integrations {
+		if i.Name() == name {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// previewContext returns the context the notifications of the alerts are
+// rendered with. The group is the one the first alert would be grouped in on a
+// route of the receiver, or on the root route if no such route matches.
+func previewContext(ctx context.Context, root *dispatch.Route, receiverName string, alerts []*types.Alert, now time.Time) context.Context {
+	route := root
+	groupLabels := prometheus_model.LabelSet{}
+	if len(alerts) > 0 {
+		for _, r := range root.Match(alerts[0].Labels) {
+			if r.RouteOpts.ReceiverFor(alerts[0].Labels) == receiverName {
+				route = r
+				break
+			}
+		}
+		for ln, lv := range alerts[0].Labels {
+			if _, ok := route.RouteOpts.GroupBy[ln]; ok || route.RouteOpts.GroupByAll || ln == route.RouteOpts.ReceiverFromLabel {
+				groupLabels[ln] = lv
+			}
+		}
+	}
+
+	ctx = notify.WithNow(ctx, now)
+	ctx = notify.WithGroupKey(ctx, fmt.Sprintf("%s:%s", route.Key(), groupLabels))
+	ctx = notify.WithGroupLabels(ctx, groupLabels)
+	ctx = notify.WithReceiverName(ctx, receiverName)
+	ctx = notify.WithRepeatInterval(ctx, route.RouteOpts.RepeatInterval)
+	ctx = notify.WithRouteID(ctx, route.ID())
+	return ctx
+}
+
+// previewIntegration renders the notifications of the integration for the
+// alerts without sending them.
+func previewIntegration(ctx context.Context, i notify.Integration, alerts []*types.Alert) *open_api_models.IntegrationPreview {
+	var (
+		name  = i.Name()
+		index = int64(i.Index())
+		res   = &open_api_models.IntegrationPreview{
+			Integration:   &name,
+			Index:         &index,
+			Notifications: []*open_api_models.NotificationPreview{},
+		}
+	)
+
+	// Like the notification pipeline, don't notify integrations about
+	// resolved alerts unless they are configured to.
+	if !i.SendResolved() {
+		firing := make([]*types.Alert, 0, len(alerts))
+		for _, a := range alerts {
+			if a.Status() != prometheus_model.AlertResolved {
+				firing = append(firing, a)
+			}
+		}
+		alerts = firing
+	}
+	if len(alerts) == 0 {
+		return res
+	}
+
+	p := &notify.Preview{}
+	if _, err := i.Notify(notify.WithPreview(ctx, p), alerts...); err != nil {
+		res.Error = err.Error()
+	}
+	for _, r := range p.Requests() {
+		body := r.Body
+		n := &open_api_models.NotificationPreview{
+			Method: r.Method,
+			URL:    r.URL,
+			Body:   &body,
+		}
+		if len(r.Header) > 0 {
+			n.Headers = make(map[string]string, len(r.Header))
+			for k, v := range r.Header {
+				n.Headers[k] = strings.Join(v, ", ")
+			}
+		}
+		res.Notifications = append(res.Notifications, n)
+	}
+	return res
+}
+
+// checkNoFileReferences returns an error if the configuration references
+// files, which the preview would disclose.
+func checkNoFileReferences(s string) error {
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return err
+	}
+	var keys []string
+	walkYAMLKeys(v, func(k string) {
+		if strings.HasSuffix(k, "_file") || strings.HasSuffix(k, "_files") {
+			keys = append(keys, k)
+		}
+	})
+	if len(keys) > 0 {
+		sort.Strings(keys)
+		return fmt.Errorf("configurations referencing files can't be previewed: %s", strings.Join(keys, ", "))
+	}
+	return nil
+}
+
+func walkYAMLKeys(v interface{}, f func(string)) {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		for k, vv := range v {
+			if s, ok := k.(string); ok {
+				f(s)
+			}
+			walkYAMLKeys(vv, f)
+		}
+	case []interface{}:
+		for _, vv := range v {
+			walkYAMLKeys(vv, f)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/alertmanager/config"
+)
+
+// rateLimiter limits the rate of the requests posting alerts with a token
+// bucket per key, e.g. per client IP address.
+type rateLimiter struct {
+	conf config.IngestionRateLimit
+
+	mtx       sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastPrune time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(conf config.IngestionRateLimit) *rateLimiter {
+	return &rateLimiter{
+		conf:    conf,
+		buckets: map[string]*tokenBucket{},
+	}
+}
+
+// key returns the key by which the request is limited. The credentials of
+// the Authorization header are hashed so that they aren't kept in memory.
+func (l *rateLimiter) key(r *http.Request) string {
+	if l.conf.Key == config.IngestionRateLimitKeyToken {
+		auth := r.Header.Get("Authorization")
+		if auth == "" {
+			return ""
+		}
+		sum := sha256.Sum256([]byte(auth))
+		return hex.EncodeToString(sum[:])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// allow takes a token from the bucket of the key. If the bucket is empty, it
+// returns false and the time until the next token is available.
+func (l *rateLimiter) allow(key string, now time.Time) (bool, time.Duration) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.prune(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(l.conf.Burst), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / l.conf.Rate * float64(time.Second))
+}
+
+// refill returns the tokens of the bucket at the given time.
+func (l *rateLimiter) refill(b *tokenBucket, now time.Time) float64 {
+	tokens := b.tokens
+	if now.After(b.last) {
+		tokens += now.Sub(b.last).Seconds() * l.conf.Rate
+	}
+	return min(tokens, float64(l.conf.Burst))
+}
+
+// prune removes the buckets which are full again, as they are the same as new
+// ones, at most once per minute.
+func (l *rateLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < time.Minute {
+		return
+	}
+	l.lastPrune = now
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= float64(l.conf.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
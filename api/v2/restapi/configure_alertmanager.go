@@ -30,6 +30,8 @@ import (
 	"github.com/prometheus/alertmanager/api/v2/restapi/operations/general"
 	"github.com/prometheus/alertmanager/api/v2/restapi/operations/receiver"
 	"github.com/prometheus/alertmanager/api/v2/restapi/operations/silence"
+	"github.com/prometheus/alertmanager/api/v2/restapi/operations/template"
+	"github.com/prometheus/alertmanager/api/v2/restapi/operations/timeinterval"
 )
 
 //go:generate swagger generate server --target ../../v2 --name Alertmanager --spec ../openapi.yaml --principal interface{} --exclude-main
@@ -56,6 +58,11 @@ func configureAPI(api *operations.AlertmanagerAPI) http.Handler {
 
 	api.JSONProducer = runtime.JSONProducer()
 
+	if api.ReceiverDeleteReceiverDisableHandler == nil {
+		api.ReceiverDeleteReceiverDisableHandler = receiver.DeleteReceiverDisableHandlerFunc(func(params receiver.DeleteReceiverDisableParams) middleware.Responder {
+			return middleware.NotImplemented("operation receiver.DeleteReceiverDisable has not yet been implemented")
+		})
+	}
 	if api.SilenceDeleteSilenceHandler == nil {
 		api.SilenceDeleteSilenceHandler = silence.DeleteSilenceHandlerFunc(func(params silence.DeleteSilenceParams) middleware.Responder {
 			return middleware.NotImplemented("operation silence.DeleteSilence has not yet been implemented")
@@ -71,6 +78,31 @@ func configureAPI(api *operations.AlertmanagerAPI) http.Handler {
 			return middleware.NotImplemented("operation alert.GetAlerts has not yet been implemented")
 		})
 	}
+	if api.GeneralGetClusterEventsHandler == nil {
+		api.GeneralGetClusterEventsHandler = general.GetClusterEventsHandlerFunc(func(params general.GetClusterEventsParams) middleware.Responder {
+			return middleware.NotImplemented("operation general.GetClusterEvents has not yet been implemented")
+		})
+	}
+	if api.AlertGetDebugCardinalityHandler == nil {
+		api.AlertGetDebugCardinalityHandler = alert.GetDebugCardinalityHandlerFunc(func(params alert.GetDebugCardinalityParams) middleware.Responder {
+			return middleware.NotImplemented("operation alert.GetDebugCardinality has not yet been implemented")
+		})
+	}
+	if api.ReceiverGetDebugNotificationsHandler == nil {
+		api.ReceiverGetDebugNotificationsHandler = receiver.GetDebugNotificationsHandlerFunc(func(params receiver.GetDebugNotificationsParams) middleware.Responder {
+			return middleware.NotImplemented("operation receiver.GetDebugNotifications has not yet been implemented")
+		})
+	}
+	if api.GeneralGetEffectiveConfigHandler == nil {
+		api.GeneralGetEffectiveConfigHandler = general.GetEffectiveConfigHandlerFunc(func(params general.GetEffectiveConfigParams) middleware.Responder {
+			return middleware.NotImplemented("operation general.GetEffectiveConfig has not yet been implemented")
+		})
+	}
+	if api.ReceiverGetReceiverStatsHandler == nil {
+		api.ReceiverGetReceiverStatsHandler = receiver.GetReceiverStatsHandlerFunc(func(params receiver.GetReceiverStatsParams) middleware.Responder {
+			return middleware.NotImplemented("operation receiver.GetReceiverStats has not yet been implemented")
+		})
+	}
 	if api.ReceiverGetReceiversHandler == nil {
 		api.ReceiverGetReceiversHandler = receiver.GetReceiversHandlerFunc(func(params receiver.GetReceiversParams) middleware.Responder {
 			return middleware.NotImplemented("operation receiver.GetReceivers has not yet been implemented")
@@ -91,16 +123,56 @@ func configureAPI(api *operations.AlertmanagerAPI) http.Handler {
 			return middleware.NotImplemented("operation general.GetStatus has not yet been implemented")
 		})
 	}
+	if api.TimeintervalGetTimeIntervalsHandler == nil {
+		api.TimeintervalGetTimeIntervalsHandler = timeinterval.GetTimeIntervalsHandlerFunc(func(params timeinterval.GetTimeIntervalsParams) middleware.Responder {
+			return middleware.NotImplemented("operation timeinterval.GetTimeIntervals has not yet been implemented")
+		})
+	}
+	if api.TemplateLintTemplatesHandler == nil {
+		api.TemplateLintTemplatesHandler = template.LintTemplatesHandlerFunc(func(params template.LintTemplatesParams) middleware.Responder {
+			return middleware.NotImplemented("operation template.LintTemplates has not yet been implemented")
+		})
+	}
+	if api.AlertPostAlertAckHandler == nil {
+		api.AlertPostAlertAckHandler = alert.PostAlertAckHandlerFunc(func(params alert.PostAlertAckParams) middleware.Responder {
+			return middleware.NotImplemented("operation alert.PostAlertAck has not yet been implemented")
+		})
+	}
 	if api.AlertPostAlertsHandler == nil {
 		api.AlertPostAlertsHandler = alert.PostAlertsHandlerFunc(func(params alert.PostAlertsParams) middleware.Responder {
 			return middleware.NotImplemented("operation alert.PostAlerts has not yet been implemented")
 		})
 	}
+	if api.ReceiverPostPreviewHandler == nil {
+		api.ReceiverPostPreviewHandler = receiver.PostPreviewHandlerFunc(func(params receiver.PostPreviewParams) middleware.Responder {
+			return middleware.NotImplemented("operation receiver.PostPreview has not yet been implemented")
+		})
+	}
 	if api.SilencePostSilencesHandler == nil {
 		api.SilencePostSilencesHandler = silence.PostSilencesHandlerFunc(func(params silence.PostSilencesParams) middleware.Responder {
 			return middleware.NotImplemented("operation silence.PostSilences has not yet been implemented")
 		})
 	}
+	if api.SilencePostSilencesBatchHandler == nil {
+		api.SilencePostSilencesBatchHandler = silence.PostSilencesBatchHandlerFunc(func(params silence.PostSilencesBatchParams) middleware.Responder {
+			return middleware.NotImplemented("operation silence.PostSilencesBatch has not yet been implemented")
+		})
+	}
+	if api.SilencePostSilencesImportHandler == nil {
+		api.SilencePostSilencesImportHandler = silence.PostSilencesImportHandlerFunc(func(params silence.PostSilencesImportParams) middleware.Responder {
+			return middleware.NotImplemented("operation silence.PostSilencesImport has not yet been implemented")
+		})
+	}
+	if api.ReceiverPutReceiverDisableHandler == nil {
+		api.ReceiverPutReceiverDisableHandler = receiver.PutReceiverDisableHandlerFunc(func(params receiver.PutReceiverDisableParams) middleware.Responder {
+			return middleware.NotImplemented("operation receiver.PutReceiverDisable has not yet been implemented")
+		})
+	}
+	if api.TimeintervalTestTimeIntervalHandler == nil {
+		api.TimeintervalTestTimeIntervalHandler = timeinterval.TestTimeIntervalHandlerFunc(func(params timeinterval.TestTimeIntervalParams) middleware.Responder {
+			return middleware.NotImplemented("operation timeinterval.TestTimeInterval has not yet been implemented")
+		})
+	}
 
 	api.PreServerShutdown = func() {}
 
@@ -92,15 +92,33 @@ func init() {
               "type": "string"
             },
             "collectionFormat": "multi",
-            "description": "A list of matchers to filter alerts by",
+            "description": "A list of matchers to filter alerts by. Pseudo-matchers such as @age\u003e30m, @startsAt\u003e=2024-01-01T00:00:00Z or @state=suppressed match the properties of the alerts rather than their labels",
             "name": "filter",
             "in": "query"
           },
           {
             "type": "string",
-            "description": "A regex matching receivers to filter alerts by",
+            "description": "A regex matching receivers to filter alerts by. The receivers of an alert are resolved by matching its labels against the routing tree",
             "name": "receiver",
             "in": "query"
+          },
+          {
+            "enum": [
+              "firing",
+              "resolved"
+            ],
+            "type": "string",
+            "default": "firing",
+            "description": "Show firing alerts, or alerts resolved recently which are still kept in memory",
+            "name": "state",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only show resolved alerts which resolved at or after this time",
+            "name": "since",
+            "in": "query"
           }
         ],
         "responses": {
@@ -142,6 +160,18 @@ func init() {
           "400": {
             "$ref": "#/responses/BadRequest"
           },
+          "429": {
+            "description": "Too many requests",
+            "schema": {
+              "type": "string"
+            },
+            "headers": {
+              "Retry-After": {
+                "type": "integer",
+                "description": "The number of seconds after which the request can be retried"
+              }
+            }
+          },
           "500": {
             "$ref": "#/responses/InternalServerError"
           }
@@ -190,7 +220,7 @@ func init() {
               "type": "string"
             },
             "collectionFormat": "multi",
-            "description": "A list of matchers to filter alerts by",
+            "description": "A list of matchers to filter alerts by. Pseudo-matchers such as @age\u003e30m, @startsAt\u003e=2024-01-01T00:00:00Z or @state=suppressed match the properties of the alerts rather than their labels",
             "name": "filter",
             "in": "query"
           },
@@ -199,6 +229,35 @@ func init() {
             "description": "A regex matching receivers to filter alerts by",
             "name": "receiver",
             "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Show groups which are not muted",
+            "name": "unmuted",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Include the alerts of the groups, only their number is reported otherwise",
+            "name": "alerts",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The maximum number of groups to return",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "default": 0,
+            "description": "The number of groups to skip",
+            "name": "offset",
+            "in": "query"
           }
         ],
         "responses": {
@@ -206,17 +265,226 @@ func init() {
             "description": "Get alert groups response",
             "schema": {
               "$ref": "#/definitions/alertGroups"
+            },
+            "headers": {
+              "X-Total-Count": {
+                "type": "integer",
+                "description": "The number of groups matching the filters, regardless of the pagination"
+              }
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadRequest"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
+    "/alerts/{fingerprint}/ack": {
+      "post": {
+        "description": "Acknowledge a firing alert",
+        "tags": [
+          "alert"
+        ],
+        "operationId": "postAlertAck",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Fingerprint of the alert to acknowledge",
+            "name": "fingerprint",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "The acknowledgement",
+            "name": "ack",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/postableAck"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Acknowledge alert response",
+            "schema": {
+              "$ref": "#/definitions/acknowledgement"
             }
           },
           "400": {
             "$ref": "#/responses/BadRequest"
           },
+          "404": {
+            "description": "A firing alert with the specified fingerprint was not found"
+          },
           "500": {
             "$ref": "#/responses/InternalServerError"
           }
         }
       }
     },
+    "/cluster/events": {
+      "get": {
+        "description": "Get the last peers which joined, left or died in the cluster, most recent first",
+        "tags": [
+          "general"
+        ],
+        "operationId": "getClusterEvents",
+        "responses": {
+          "200": {
+            "description": "Get cluster events response",
+            "schema": {
+              "$ref": "#/definitions/clusterEvents"
+            }
+          }
+        }
+      }
+    },
+    "/config/effective": {
+      "get": {
+        "description": "Get the running configuration with the defaults and the inherited route options set explicitly, secrets redacted",
+        "tags": [
+          "general"
+        ],
+        "operationId": "getEffectiveConfig",
+        "responses": {
+          "200": {
+            "description": "Get effective config response",
+            "schema": {
+              "$ref": "#/definitions/alertmanagerConfig"
+            }
+          }
+        }
+      }
+    },
+    "/debug/cardinality": {
+      "get": {
+        "description": "Get the cardinality of the labels of the active alerts and the aggregation groups with the most alerts",
+        "tags": [
+          "alert"
+        ],
+        "operationId": "getDebugCardinality",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "default": 10,
+            "description": "The number of label names, values per label name and groups to report",
+            "name": "limit",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Debug cardinality response",
+            "schema": {
+              "$ref": "#/definitions/alertCardinality"
+            }
+          }
+        }
+      }
+    },
+    "/debug/inhibition": {
+      "post": {
+        "description": "Evaluate every inhibition rule for a label set and report why the alert would or wouldn't be inhibited",
+        "tags": [
+          "alert"
+        ],
+        "operationId": "postDebugInhibition",
+        "parameters": [
+          {
+            "description": "The labels of the alert to evaluate",
+            "name": "labels",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/labelSet"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Debug inhibition response",
+            "schema": {
+              "$ref": "#/definitions/inhibitRuleExplanations"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadRequest"
+          }
+        }
+      }
+    },
+    "/debug/notifications": {
+      "get": {
+        "description": "Get the requests of the last failed notifications of the receivers with debug_log_payloads enabled",
+        "tags": [
+          "receiver"
+        ],
+        "operationId": "getDebugNotifications",
+        "responses": {
+          "200": {
+            "description": "Debug notifications response",
+            "schema": {
+              "$ref": "#/definitions/notificationPayloads"
+            }
+          }
+        }
+      }
+    },
+    "/preview/{integration}": {
+      "post": {
+        "description": "Render the notifications of an integration without sending them",
+        "tags": [
+          "receiver"
+        ],
+        "operationId": "postPreview",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Integration to render, e.g. slack",
+            "name": "integration",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "The receiver and alerts to render the notifications for",
+            "name": "preview",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/postablePreview"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Preview notifications response",
+            "schema": {
+              "$ref": "#/definitions/integrationPreviews"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadRequest"
+          },
+          "403": {
+            "description": "The preview API is disabled",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "404": {
+            "description": "The receiver or integration was not found",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
     "/receivers": {
       "get": {
         "description": "Get list of all receivers (name of notification integrations)",
@@ -237,6 +505,97 @@ func init() {
         }
       }
     },
+    "/receivers/stats": {
+      "get": {
+        "description": "Get the notification statistics of the receivers over the last 24 hours",
+        "tags": [
+          "receiver"
+        ],
+        "operationId": "getReceiverStats",
+        "responses": {
+          "200": {
+            "description": "Get receiver stats response",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/receiverStats"
+              }
+            }
+          }
+        }
+      }
+    },
+    "/receivers/{name}/disable": {
+      "put": {
+        "description": "Disable the integrations of a receiver for a while",
+        "tags": [
+          "receiver"
+        ],
+        "operationId": "putReceiverDisable",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Name of the receiver to disable",
+            "name": "name",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "How long to disable the receiver for",
+            "name": "disable",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/postableReceiverDisable"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Disable receiver response",
+            "schema": {
+              "$ref": "#/definitions/receiverDisable"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadRequest"
+          },
+          "404": {
+            "description": "A receiver with the specified name was not found"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      },
+      "delete": {
+        "description": "Enable a disabled receiver again",
+        "tags": [
+          "receiver"
+        ],
+        "operationId": "deleteReceiverDisable",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Name of the receiver to enable",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Enable receiver response"
+          },
+          "404": {
+            "description": "A disabled receiver with the specified name was not found"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
     "/silence/{silenceID}": {
       "get": {
         "description": "Get a silence by its ID",
@@ -315,13 +674,33 @@ func init() {
             "description": "A list of matchers to filter silences by",
             "name": "filter",
             "in": "query"
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "Get silences response",
-            "schema": {
-              "$ref": "#/definitions/gettableSilences"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "A list of equality matchers forming a label set, to return the silences matching it",
+            "name": "matches",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "A list of matchers, to return the silences whose matchers overlap them",
+            "name": "overlaps",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Get silences response",
+            "schema": {
+              "$ref": "#/definitions/gettableSilences"
             }
           },
           "400": {
@@ -373,6 +752,68 @@ func init() {
         }
       }
     },
+    "/silences/batch": {
+      "post": {
+        "description": "Create, update and expire many silences at once. The operations are applied independently, the failure of one doesn't prevent the others.",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "postSilencesBatch",
+        "parameters": [
+          {
+            "description": "The silences to create or update and the IDs of the silences to expire",
+            "name": "batch",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/postableSilenceBatch"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The results of the operations, in the order of the request",
+            "schema": {
+              "$ref": "#/definitions/silenceBatchResults"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadRequest"
+          }
+        }
+      }
+    },
+    "/silences/import": {
+      "post": {
+        "description": "Import the silences exported by Grafana Alerting or karma. The silences are converted and created independently, the failure of one doesn't prevent the others.",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "postSilencesImport",
+        "parameters": [
+          {
+            "description": "The exported silences to import",
+            "name": "silenceImport",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/silenceImport"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The results of the conversions, in the order of the exported data",
+            "schema": {
+              "$ref": "#/definitions/silenceImportResults"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadRequest"
+          }
+        }
+      }
+    },
     "/status": {
       "get": {
         "description": "Get current status of an Alertmanager instance and its cluster",
@@ -389,9 +830,121 @@ func init() {
           }
         }
       }
+    },
+    "/templates/lint": {
+      "post": {
+        "description": "Lint template files and report their problems",
+        "tags": [
+          "template"
+        ],
+        "operationId": "lintTemplates",
+        "parameters": [
+          {
+            "description": "The template files to lint",
+            "name": "lint",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/templateLintRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Template lint response",
+            "schema": {
+              "$ref": "#/definitions/templateDiagnostics"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadRequest"
+          },
+          "500": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
+    "/timeintervals": {
+      "get": {
+        "description": "Get list of time intervals with their next activation and deactivation, looked for up to a year ahead",
+        "tags": [
+          "timeinterval"
+        ],
+        "operationId": "getTimeIntervals",
+        "responses": {
+          "200": {
+            "description": "Time intervals response",
+            "schema": {
+              "$ref": "#/definitions/timeIntervalStatuses"
+            }
+          }
+        }
+      }
+    },
+    "/timeintervals/test": {
+      "post": {
+        "description": "Test whether a time interval is active at the given times",
+        "tags": [
+          "timeinterval"
+        ],
+        "operationId": "testTimeInterval",
+        "parameters": [
+          {
+            "description": "The time interval and the times to test",
+            "name": "test",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/timeIntervalTestRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Time interval test response",
+            "schema": {
+              "$ref": "#/definitions/timeIntervalTestResults"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadRequest"
+          },
+          "404": {
+            "description": "The time interval was not found",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
     }
   },
   "definitions": {
+    "acknowledgement": {
+      "type": "object",
+      "required": [
+        "acknowledgedBy",
+        "acknowledgedAt",
+        "expiresAt"
+      ],
+      "properties": {
+        "acknowledgedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "acknowledgedBy": {
+          "type": "string"
+        },
+        "expiresAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "note": {
+          "type": "string"
+        }
+      }
+    },
     "alert": {
       "type": "object",
       "required": [
@@ -407,6 +960,34 @@ func init() {
         }
       }
     },
+    "alertCardinality": {
+      "type": "object",
+      "required": [
+        "alerts",
+        "labels",
+        "groups"
+      ],
+      "properties": {
+        "alerts": {
+          "description": "Number of active alerts.",
+          "type": "integer"
+        },
+        "groups": {
+          "description": "The aggregation groups with the most alerts.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/groupCardinality"
+          }
+        },
+        "labels": {
+          "description": "The label names with the most distinct values.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/labelCardinality"
+          }
+        }
+      }
+    },
     "alertGroup": {
       "type": "object",
       "required": [
@@ -415,6 +996,10 @@ func init() {
         "alerts"
       ],
       "properties": {
+        "alertCount": {
+          "description": "The number of alerts of the group matching the filters",
+          "type": "integer"
+        },
         "alerts": {
           "type": "array",
           "items": {
@@ -426,6 +1011,9 @@ func init() {
         },
         "receiver": {
           "$ref": "#/definitions/receiver"
+        },
+        "routeOptions": {
+          "$ref": "#/definitions/routeOptions"
         }
       }
     },
@@ -444,6 +1032,9 @@ func init() {
         "mutedBy"
       ],
       "properties": {
+        "acknowledgement": {
+          "$ref": "#/definitions/acknowledgement"
+        },
         "inhibitedBy": {
           "type": "array",
           "items": {
@@ -498,6 +1089,9 @@ func init() {
         "config": {
           "$ref": "#/definitions/alertmanagerConfig"
         },
+        "health": {
+          "$ref": "#/definitions/healthStatus"
+        },
         "uptime": {
           "type": "string",
           "format": "date-time"
@@ -507,12 +1101,58 @@ func init() {
         }
       }
     },
+    "clusterEvent": {
+      "type": "object",
+      "required": [
+        "time",
+        "type",
+        "name",
+        "address"
+      ],
+      "properties": {
+        "address": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "reporter": {
+          "description": "Name of the peer which observed the event.",
+          "type": "string"
+        },
+        "time": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "type": {
+          "type": "string",
+          "enum": [
+            "join",
+            "leave",
+            "dead"
+          ]
+        }
+      }
+    },
+    "clusterEvents": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/clusterEvent"
+      }
+    },
     "clusterStatus": {
       "type": "object",
       "required": [
         "status"
       ],
       "properties": {
+        "divergentPeers": {
+          "description": "Names of the peers whose silenced and inhibited alerts differed from the local ones at the last consistency check.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
         "name": {
           "type": "string"
         },
@@ -619,579 +1259,1238 @@ func init() {
         "$ref": "#/definitions/gettableSilence"
       }
     },
-    "labelSet": {
+    "groupCardinality": {
       "type": "object",
-      "additionalProperties": {
-        "type": "string"
+      "required": [
+        "labels",
+        "receiver",
+        "alerts"
+      ],
+      "properties": {
+        "alerts": {
+          "description": "Number of active alerts in the group.",
+          "type": "integer"
+        },
+        "labels": {
+          "$ref": "#/definitions/labelSet"
+        },
+        "receiver": {
+          "type": "string"
+        }
       }
     },
-    "matcher": {
+    "healthCheck": {
       "type": "object",
       "required": [
         "name",
-        "value",
-        "isRegex"
+        "healthy"
       ],
       "properties": {
-        "isEqual": {
-          "type": "boolean",
-          "default": true
-        },
-        "isRegex": {
+        "healthy": {
           "type": "boolean"
         },
-        "name": {
+        "lastSuccess": {
+          "description": "Time of the last success of the checked dependency, if known.",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "message": {
+          "description": "Details about the state of the dependency, such as the error of the last failure.",
           "type": "string"
         },
-        "value": {
+        "name": {
           "type": "string"
         }
       }
     },
-    "matchers": {
-      "type": "array",
-      "minItems": 1,
-      "items": {
-        "$ref": "#/definitions/matcher"
+    "healthStatus": {
+      "type": "object",
+      "required": [
+        "healthy",
+        "checks"
+      ],
+      "properties": {
+        "checks": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/healthCheck"
+          }
+        },
+        "healthy": {
+          "description": "Whether all the checks are healthy.",
+          "type": "boolean"
+        }
       }
     },
-    "peerStatus": {
+    "inhibitEqualMismatch": {
       "type": "object",
       "required": [
         "name",
-        "address"
+        "sourceValue",
+        "targetValue"
       ],
       "properties": {
-        "address": {
+        "name": {
           "type": "string"
         },
-        "name": {
+        "sourceValue": {
+          "type": "string"
+        },
+        "targetValue": {
           "type": "string"
         }
       }
     },
-    "postableAlert": {
-      "allOf": [
-        {
-          "type": "object",
-          "properties": {
-            "annotations": {
-              "$ref": "#/definitions/labelSet"
-            },
-            "endsAt": {
-              "type": "string",
-              "format": "date-time"
-            },
-            "startsAt": {
-              "type": "string",
-              "format": "date-time"
-            }
+    "inhibitRuleExplanation": {
+      "type": "object",
+      "required": [
+        "index",
+        "sourceMatchers",
+        "targetMatchers",
+        "equal",
+        "targetMatched",
+        "sourceMatched",
+        "inhibited",
+        "sources"
+      ],
+      "properties": {
+        "equal": {
+          "type": "array",
+          "items": {
+            "type": "string"
           }
         },
-        {
-          "$ref": "#/definitions/alert"
+        "index": {
+          "description": "Position of the rule in the configuration.",
+          "type": "integer"
+        },
+        "inhibited": {
+          "type": "boolean"
+        },
+        "inhibitedBy": {
+          "description": "Fingerprint of the source alert inhibiting the labels.",
+          "type": "string"
+        },
+        "sourceMatched": {
+          "description": "Whether the labels match the source side of the rule, in which case the sources matching the target side as well are disregarded.",
+          "type": "boolean"
+        },
+        "sourceMatchers": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "sources": {
+          "description": "The active source alerts of the rule.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/inhibitSourceExplanation"
+          }
+        },
+        "targetMatched": {
+          "description": "Whether the labels match the target side of the rule. The sources are only evaluated if they do.",
+          "type": "boolean"
+        },
+        "targetMatchers": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         }
-      ]
+      }
     },
-    "postableAlerts": {
+    "inhibitRuleExplanations": {
       "type": "array",
       "items": {
-        "$ref": "#/definitions/postableAlert"
+        "$ref": "#/definitions/inhibitRuleExplanation"
       }
     },
-    "postableSilence": {
-      "allOf": [
-        {
-          "type": "object",
-          "properties": {
-            "id": {
-              "type": "string"
-            }
+    "inhibitSourceExplanation": {
+      "type": "object",
+      "required": [
+        "fingerprint",
+        "labels",
+        "twoSided",
+        "mismatches"
+      ],
+      "properties": {
+        "fingerprint": {
+          "type": "string"
+        },
+        "labels": {
+          "$ref": "#/definitions/labelSet"
+        },
+        "mismatches": {
+          "description": "The equal labels whose values differ between the source and the labels.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/inhibitEqualMismatch"
           }
         },
-        {
-          "$ref": "#/definitions/silence"
+        "twoSided": {
+          "description": "Whether the source is disregarded as it matches both sides of the rule.",
+          "type": "boolean"
         }
-      ]
+      }
     },
-    "receiver": {
+    "integrationPreview": {
       "type": "object",
       "required": [
-        "name"
+        "integration",
+        "index",
+        "notifications"
       ],
       "properties": {
-        "name": {
+        "error": {
+          "type": "string"
+        },
+        "index": {
+          "type": "integer"
+        },
+        "integration": {
           "type": "string"
+        },
+        "notifications": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/notificationPreview"
+          }
         }
       }
     },
-    "silence": {
+    "integrationPreviews": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/integrationPreview"
+      }
+    },
+    "labelCardinality": {
       "type": "object",
       "required": [
-        "matchers",
-        "startsAt",
-        "endsAt",
-        "createdBy",
-        "comment"
+        "name",
+        "values",
+        "alerts",
+        "topValues"
       ],
       "properties": {
-        "comment": {
-          "type": "string"
+        "alerts": {
+          "description": "Number of active alerts with the label.",
+          "type": "integer"
         },
-        "createdBy": {
+        "name": {
           "type": "string"
         },
-        "endsAt": {
-          "type": "string",
-          "format": "date-time"
+        "topValues": {
+          "description": "The values of the label with the most alerts.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/labelValueCardinality"
+          }
         },
-        "matchers": {
-          "$ref": "#/definitions/matchers"
+        "values": {
+          "description": "Number of distinct values of the label.",
+          "type": "integer"
+        }
+      }
+    },
+    "labelSet": {
+      "type": "object",
+      "additionalProperties": {
+        "type": "string"
+      }
+    },
+    "labelValueCardinality": {
+      "type": "object",
+      "required": [
+        "value",
+        "alerts"
+      ],
+      "properties": {
+        "alerts": {
+          "description": "Number of active alerts with the value.",
+          "type": "integer"
         },
-        "startsAt": {
-          "type": "string",
-          "format": "date-time"
+        "value": {
+          "type": "string"
         }
       }
     },
-    "silenceStatus": {
+    "matcher": {
       "type": "object",
       "required": [
-        "state"
+        "name",
+        "value",
+        "isRegex"
       ],
       "properties": {
-        "state": {
-          "type": "string",
-          "enum": [
-            "expired",
-            "active",
-            "pending"
-          ]
+        "isEqual": {
+          "type": "boolean",
+          "default": true
+        },
+        "isRegex": {
+          "type": "boolean"
+        },
+        "name": {
+          "type": "string"
+        },
+        "value": {
+          "type": "string"
         }
       }
     },
-    "versionInfo": {
+    "matchers": {
+      "type": "array",
+      "minItems": 1,
+      "items": {
+        "$ref": "#/definitions/matcher"
+      }
+    },
+    "notificationPayload": {
       "type": "object",
       "required": [
-        "version",
-        "revision",
-        "branch",
-        "buildUser",
-        "buildDate",
-        "goVersion"
+        "time",
+        "receiver",
+        "integration"
       ],
       "properties": {
-        "branch": {
+        "attempt": {
+          "type": "integer"
+        },
+        "error": {
           "type": "string"
         },
-        "buildDate": {
+        "groupKey": {
           "type": "string"
         },
-        "buildUser": {
+        "integration": {
           "type": "string"
         },
-        "goVersion": {
+        "method": {
           "type": "string"
         },
-        "revision": {
+        "receiver": {
           "type": "string"
         },
-        "version": {
+        "requestBody": {
+          "type": "string"
+        },
+        "responseBody": {
+          "type": "string"
+        },
+        "statusCode": {
+          "type": "integer"
+        },
+        "time": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "url": {
           "type": "string"
         }
       }
-    }
-  },
-  "responses": {
-    "BadRequest": {
-      "description": "Bad request",
-      "schema": {
-        "type": "string"
+    },
+    "notificationPayloads": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/notificationPayload"
       }
     },
-    "InternalServerError": {
-      "description": "Internal server error",
-      "schema": {
-        "type": "string"
+    "notificationPreview": {
+      "type": "object",
+      "required": [
+        "body"
+      ],
+      "properties": {
+        "body": {
+          "type": "string"
+        },
+        "headers": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "method": {
+          "type": "string"
+        },
+        "url": {
+          "type": "string"
+        }
       }
-    }
-  },
-  "tags": [
-    {
-      "description": "General Alertmanager operations",
-      "name": "general"
     },
-    {
-      "description": "Everything related to Alertmanager receivers",
-      "name": "receiver"
+    "peerStatus": {
+      "type": "object",
+      "required": [
+        "name",
+        "address"
+      ],
+      "properties": {
+        "address": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        }
+      }
     },
-    {
-      "description": "Everything related to Alertmanager silences",
-      "name": "silence"
+    "postableAck": {
+      "type": "object",
+      "required": [
+        "acknowledgedBy"
+      ],
+      "properties": {
+        "acknowledgedBy": {
+          "type": "string"
+        },
+        "expiresAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "note": {
+          "type": "string"
+        }
+      }
     },
-    {
-      "description": "Everything related to Alertmanager alerts",
-      "name": "alert"
-    }
-  ]
-}`))
-	FlatSwaggerJSON = json.RawMessage([]byte(`{
-  "consumes": [
-    "application/json"
-  ],
-  "produces": [
-    "application/json"
-  ],
-  "swagger": "2.0",
-  "info": {
-    "description": "API of the Prometheus Alertmanager (https://github.com/prometheus/alertmanager)",
-    "title": "Alertmanager API",
-    "license": {
-      "name": "Apache 2.0",
-      "url": "http://www.apache.org/licenses/LICENSE-2.0.html"
-    },
-    "version": "0.0.1"
-  },
-  "basePath": "/api/v2/",
-  "paths": {
-    "/alerts": {
-      "get": {
-        "description": "Get a list of alerts",
-        "tags": [
-          "alert"
-        ],
-        "operationId": "getAlerts",
-        "parameters": [
-          {
-            "type": "boolean",
-            "default": true,
-            "description": "Show active alerts",
-            "name": "active",
-            "in": "query"
-          },
-          {
-            "type": "boolean",
-            "default": true,
-            "description": "Show silenced alerts",
-            "name": "silenced",
-            "in": "query"
-          },
-          {
-            "type": "boolean",
-            "default": true,
-            "description": "Show inhibited alerts",
-            "name": "inhibited",
-            "in": "query"
-          },
-          {
-            "type": "boolean",
-            "default": true,
-            "description": "Show unprocessed alerts",
-            "name": "unprocessed",
-            "in": "query"
-          },
-          {
-            "type": "array",
-            "items": {
-              "type": "string"
+    "postableAlert": {
+      "allOf": [
+        {
+          "type": "object",
+          "properties": {
+            "annotations": {
+              "$ref": "#/definitions/labelSet"
             },
-            "collectionFormat": "multi",
-            "description": "A list of matchers to filter alerts by",
-            "name": "filter",
-            "in": "query"
-          },
-          {
-            "type": "string",
-            "description": "A regex matching receivers to filter alerts by",
-            "name": "receiver",
-            "in": "query"
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "Get alerts response",
-            "schema": {
-              "$ref": "#/definitions/gettableAlerts"
-            }
-          },
-          "400": {
-            "description": "Bad request",
-            "schema": {
-              "type": "string"
-            }
-          },
-          "500": {
-            "description": "Internal server error",
-            "schema": {
-              "type": "string"
+            "endsAt": {
+              "type": "string",
+              "format": "date-time"
+            },
+            "startsAt": {
+              "type": "string",
+              "format": "date-time"
             }
           }
+        },
+        {
+          "$ref": "#/definitions/alert"
         }
-      },
-      "post": {
-        "description": "Create new Alerts",
-        "tags": [
-          "alert"
-        ],
-        "operationId": "postAlerts",
-        "parameters": [
-          {
-            "description": "The alerts to create",
-            "name": "alerts",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/postableAlerts"
-            }
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "Create alerts response"
-          },
-          "400": {
-            "description": "Bad request",
-            "schema": {
-              "type": "string"
-            }
-          },
-          "500": {
-            "description": "Internal server error",
-            "schema": {
-              "type": "string"
-            }
-          }
+      ]
+    },
+    "postableAlerts": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/postableAlert"
+      }
+    },
+    "postablePreview": {
+      "type": "object",
+      "required": [
+        "receiver",
+        "alerts"
+      ],
+      "properties": {
+        "alerts": {
+          "$ref": "#/definitions/postableAlerts"
+        },
+        "config": {
+          "type": "string"
+        },
+        "receiver": {
+          "type": "string"
         }
       }
     },
-    "/alerts/groups": {
-      "get": {
-        "description": "Get a list of alert groups",
-        "tags": [
-          "alertgroup"
-        ],
-        "operationId": "getAlertGroups",
-        "parameters": [
-          {
-            "type": "boolean",
-            "default": true,
-            "description": "Show active alerts",
-            "name": "active",
-            "in": "query"
-          },
-          {
-            "type": "boolean",
-            "default": true,
-            "description": "Show silenced alerts",
-            "name": "silenced",
-            "in": "query"
-          },
-          {
-            "type": "boolean",
-            "default": true,
-            "description": "Show inhibited alerts",
-            "name": "inhibited",
-            "in": "query"
-          },
-          {
-            "type": "boolean",
-            "default": true,
-            "description": "Show muted alerts",
-            "name": "muted",
-            "in": "query"
-          },
-          {
-            "type": "array",
-            "items": {
-              "type": "string"
-            },
-            "collectionFormat": "multi",
-            "description": "A list of matchers to filter alerts by",
-            "name": "filter",
-            "in": "query"
-          },
-          {
-            "type": "string",
-            "description": "A regex matching receivers to filter alerts by",
-            "name": "receiver",
-            "in": "query"
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "Get alert groups response",
-            "schema": {
-              "$ref": "#/definitions/alertGroups"
-            }
-          },
-          "400": {
-            "description": "Bad request",
-            "schema": {
-              "type": "string"
-            }
-          },
-          "500": {
-            "description": "Internal server error",
-            "schema": {
+    "postableReceiverDisable": {
+      "type": "object",
+      "required": [
+        "duration",
+        "createdBy"
+      ],
+      "properties": {
+        "comment": {
+          "type": "string"
+        },
+        "createdBy": {
+          "type": "string"
+        },
+        "duration": {
+          "description": "How long to disable the receiver for, e.g. 2h",
+          "type": "string"
+        }
+      }
+    },
+    "postableSilence": {
+      "allOf": [
+        {
+          "type": "object",
+          "properties": {
+            "id": {
               "type": "string"
             }
           }
+        },
+        {
+          "$ref": "#/definitions/silence"
         }
-      }
+      ]
     },
-    "/receivers": {
-      "get": {
-        "description": "Get list of all receivers (name of notification integrations)",
-        "tags": [
-          "receiver"
-        ],
-        "operationId": "getReceivers",
-        "responses": {
-          "200": {
-            "description": "Get receivers response",
-            "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/receiver"
-              }
-            }
+    "postableSilenceBatch": {
+      "type": "object",
+      "properties": {
+        "expire": {
+          "description": "The IDs of the silences to expire.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "silences": {
+          "description": "The silences to create, or update if they have an ID.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/postableSilence"
           }
         }
       }
     },
-    "/silence/{silenceID}": {
-      "get": {
-        "description": "Get a silence by its ID",
-        "tags": [
-          "silence"
-        ],
-        "operationId": "getSilence",
-        "responses": {
-          "200": {
-            "description": "Get silence response",
-            "schema": {
-              "$ref": "#/definitions/gettableSilence"
-            }
-          },
-          "404": {
-            "description": "A silence with the specified ID was not found"
-          },
-          "500": {
-            "description": "Internal server error",
-            "schema": {
-              "type": "string"
-            }
-          }
+    "receiver": {
+      "type": "object",
+      "required": [
+        "name"
+      ],
+      "properties": {
+        "disabled": {
+          "$ref": "#/definitions/receiverDisable"
+        },
+        "name": {
+          "type": "string"
         }
-      },
-      "delete": {
-        "description": "Delete a silence by its ID",
-        "tags": [
-          "silence"
-        ],
-        "operationId": "deleteSilence",
-        "parameters": [
-          {
-            "type": "string",
-            "format": "uuid",
-            "description": "ID of the silence to get",
-            "name": "silenceID",
-            "in": "path",
-            "required": true
+      }
+    },
+    "receiverDisable": {
+      "type": "object",
+      "required": [
+        "createdBy",
+        "disabledAt",
+        "endsAt"
+      ],
+      "properties": {
+        "comment": {
+          "type": "string"
+        },
+        "createdBy": {
+          "type": "string"
+        },
+        "disabledAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "endsAt": {
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "receiverStats": {
+      "type": "object",
+      "required": [
+        "name",
+        "notifications",
+        "failed"
+      ],
+      "properties": {
+        "failed": {
+          "description": "Number of failed notifications within the window.",
+          "type": "integer"
+        },
+        "lastSent": {
+          "description": "Time of the last successful notification, if any since the start of Alertmanager.",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "name": {
+          "type": "string"
+        },
+        "notifications": {
+          "description": "Number of notifications within the window, successful or not.",
+          "type": "integer"
+        }
+      }
+    },
+    "routeOptions": {
+      "description": "The effective options of the route of an alert group.",
+      "type": "object",
+      "required": [
+        "routeId",
+        "receiver",
+        "groupBy",
+        "groupWait",
+        "groupInterval",
+        "repeatInterval",
+        "muteTimeIntervals",
+        "activeTimeIntervals"
+      ],
+      "properties": {
+        "activeTimeIntervals": {
+          "description": "The time intervals during which the route is active.",
+          "type": "array",
+          "items": {
+            "type": "string"
           }
-        ],
-        "responses": {
-          "200": {
-            "description": "Delete silence response"
-          },
-          "404": {
-            "description": "A silence with the specified ID was not found"
-          },
-          "500": {
-            "description": "Internal server error",
-            "schema": {
-              "type": "string"
-            }
+        },
+        "groupBy": {
+          "description": "The labels the alerts are grouped by, [\"...\"] for all of them.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "groupInterval": {
+          "description": "How long to wait before sending a notification about new alerts added to the group.",
+          "type": "string"
+        },
+        "groupWait": {
+          "description": "How long to wait before sending the first notification of the group.",
+          "type": "string"
+        },
+        "muteTimeIntervals": {
+          "description": "The time intervals during which the route is muted.",
+          "type": "array",
+          "items": {
+            "type": "string"
           }
+        },
+        "receiver": {
+          "description": "The receiver of the route, empty if it is selected by the label in receiverFromLabel.",
+          "type": "string"
+        },
+        "receiverFromLabel": {
+          "description": "The label whose value selects the receiver of the group.",
+          "type": "string"
+        },
+        "repeatInterval": {
+          "description": "How long to wait before sending a notification again.",
+          "type": "string"
+        },
+        "routeId": {
+          "description": "The identifier of the route of the group.",
+          "type": "string"
         }
-      },
-      "parameters": [
-        {
+      }
+    },
+    "silence": {
+      "type": "object",
+      "required": [
+        "matchers",
+        "startsAt",
+        "endsAt",
+        "createdBy",
+        "comment"
+      ],
+      "properties": {
+        "comment": {
+          "type": "string"
+        },
+        "createdBy": {
+          "type": "string"
+        },
+        "endsAt": {
           "type": "string",
-          "format": "uuid",
-          "description": "ID of the silence to get",
-          "name": "silenceID",
-          "in": "path",
-          "required": true
+          "format": "date-time"
+        },
+        "expireOnResolve": {
+          "description": "Expire the silence once no firing alerts match it for a grace period.",
+          "type": "boolean"
+        },
+        "matchers": {
+          "$ref": "#/definitions/matchers"
+        },
+        "startsAt": {
+          "type": "string",
+          "format": "date-time"
         }
-      ]
+      }
     },
-    "/silences": {
-      "get": {
-        "description": "Get a list of silences",
-        "tags": [
-          "silence"
-        ],
-        "operationId": "getSilences",
-        "parameters": [
-          {
-            "type": "array",
-            "items": {
-              "type": "string"
-            },
-            "collectionFormat": "multi",
-            "description": "A list of matchers to filter silences by",
-            "name": "filter",
-            "in": "query"
+    "silenceBatchResult": {
+      "type": "object",
+      "properties": {
+        "error": {
+          "description": "The reason of the failure of the operation, empty if it succeeded.",
+          "type": "string"
+        },
+        "silenceID": {
+          "description": "The ID of the silence, empty if it couldn't be created.",
+          "type": "string"
+        }
+      }
+    },
+    "silenceBatchResults": {
+      "type": "object",
+      "required": [
+        "silences",
+        "expire"
+      ],
+      "properties": {
+        "expire": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/silenceBatchResult"
           }
-        ],
-        "responses": {
-          "200": {
-            "description": "Get silences response",
-            "schema": {
-              "$ref": "#/definitions/gettableSilences"
-            }
-          },
-          "400": {
-            "description": "Bad request",
-            "schema": {
-              "type": "string"
-            }
-          },
-          "500": {
-            "description": "Internal server error",
-            "schema": {
-              "type": "string"
-            }
+        },
+        "silences": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/silenceBatchResult"
           }
         }
-      },
-      "post": {
-        "description": "Post a new silence or update an existing one",
-        "tags": [
-          "silence"
-        ],
-        "operationId": "postSilences",
-        "parameters": [
-          {
-            "description": "The silence to create",
-            "name": "silence",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/postableSilence"
-            }
+      }
+    },
+    "silenceImport": {
+      "type": "object",
+      "required": [
+        "format",
+        "data"
+      ],
+      "properties": {
+        "data": {
+          "description": "The exported silences, in the format of the response of the silences endpoint of the Alertmanager API of Grafana Alerting, or of the alerts.json endpoint of karma."
+        },
+        "dryRun": {
+          "description": "Only convert the silences, without creating them.",
+          "type": "boolean"
+        },
+        "format": {
+          "description": "The format of the exported silences.",
+          "type": "string",
+          "enum": [
+            "grafana",
+            "karma"
+          ]
+        }
+      }
+    },
+    "silenceImportResult": {
+      "type": "object",
+      "properties": {
+        "error": {
+          "description": "The reason why the silence couldn't be converted or created, empty if it succeeded.",
+          "type": "string"
+        },
+        "lossy": {
+          "description": "The differences between the exported silence and the created one, empty if the conversion is exact.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "silenceID": {
+          "description": "The ID of the created silence, empty if it wasn't created.",
+          "type": "string"
+        },
+        "source": {
+          "description": "The identifier of the silence in the exported data, usually its ID.",
+          "type": "string"
+        }
+      }
+    },
+    "silenceImportResults": {
+      "type": "object",
+      "required": [
+        "silences"
+      ],
+      "properties": {
+        "silences": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/silenceImportResult"
+          }
+        }
+      }
+    },
+    "silenceStatus": {
+      "type": "object",
+      "required": [
+        "state"
+      ],
+      "properties": {
+        "state": {
+          "type": "string",
+          "enum": [
+            "expired",
+            "active",
+            "pending"
+          ]
+        }
+      }
+    },
+    "templateDiagnostic": {
+      "type": "object",
+      "required": [
+        "file",
+        "line",
+        "column",
+        "severity",
+        "message"
+      ],
+      "properties": {
+        "column": {
+          "type": "integer"
+        },
+        "file": {
+          "type": "string"
+        },
+        "line": {
+          "type": "integer"
+        },
+        "message": {
+          "type": "string"
+        },
+        "severity": {
+          "type": "string",
+          "enum": [
+            "error",
+            "warning"
+          ]
+        }
+      }
+    },
+    "templateDiagnostics": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/templateDiagnostic"
+      }
+    },
+    "templateFile": {
+      "type": "object",
+      "required": [
+        "name",
+        "content"
+      ],
+      "properties": {
+        "content": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        }
+      }
+    },
+    "templateLintRequest": {
+      "type": "object",
+      "required": [
+        "files"
+      ],
+      "properties": {
+        "entrypoints": {
+          "description": "Names of the templates used by the configuration. If empty, the templates called by the running configuration are used.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "files": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/templateFile"
+          }
+        }
+      }
+    },
+    "timeIntervalStatus": {
+      "type": "object",
+      "required": [
+        "name",
+        "active"
+      ],
+      "properties": {
+        "active": {
+          "type": "boolean"
+        },
+        "name": {
+          "type": "string"
+        },
+        "nextActivation": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "nextDeactivation": {
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "timeIntervalStatuses": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/timeIntervalStatus"
+      }
+    },
+    "timeIntervalTestRequest": {
+      "type": "object",
+      "required": [
+        "name",
+        "times"
+      ],
+      "properties": {
+        "name": {
+          "type": "string"
+        },
+        "times": {
+          "type": "array",
+          "items": {
+            "type": "string",
+            "format": "date-time"
           }
+        }
+      }
+    },
+    "timeIntervalTestResult": {
+      "type": "object",
+      "required": [
+        "time",
+        "active"
+      ],
+      "properties": {
+        "active": {
+          "type": "boolean"
+        },
+        "time": {
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "timeIntervalTestResults": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/timeIntervalTestResult"
+      }
+    },
+    "versionInfo": {
+      "type": "object",
+      "required": [
+        "version",
+        "revision",
+        "branch",
+        "buildUser",
+        "buildDate",
+        "goVersion"
+      ],
+      "properties": {
+        "branch": {
+          "type": "string"
+        },
+        "buildDate": {
+          "type": "string"
+        },
+        "buildUser": {
+          "type": "string"
+        },
+        "goVersion": {
+          "type": "string"
+        },
+        "revision": {
+          "type": "string"
+        },
+        "version": {
+          "type": "string"
+        }
+      }
+    }
+  },
+  "responses": {
+    "BadRequest": {
+      "description": "Bad request",
+      "schema": {
+        "type": "string"
+      }
+    },
+    "InternalServerError": {
+      "description": "Internal server error",
+      "schema": {
+        "type": "string"
+      }
+    }
+  },
+  "tags": [
+    {
+      "description": "General Alertmanager operations",
+      "name": "general"
+    },
+    {
+      "description": "Everything related to Alertmanager receivers",
+      "name": "receiver"
+    },
+    {
+      "description": "Everything related to Alertmanager silences",
+      "name": "silence"
+    },
+    {
+      "description": "Everything related to Alertmanager alerts",
+      "name": "alert"
+    },
+    {
+      "description": "Everything related to Alertmanager templates",
+      "name": "template"
+    },
+    {
+      "description": "Everything related to Alertmanager time intervals",
+      "name": "timeinterval"
+    }
+  ]
+}`))
+	FlatSwaggerJSON = json.RawMessage([]byte(`{
+  "consumes": [
+    "application/json"
+  ],
+  "produces": [
+    "application/json"
+  ],
+  "swagger": "2.0",
+  "info": {
+    "description": "API of the Prometheus Alertmanager (https://github.com/prometheus/alertmanager)",
+    "title": "Alertmanager API",
+    "license": {
+      "name": "Apache 2.0",
+      "url": "http://www.apache.org/licenses/LICENSE-2.0.html"
+    },
+    "version": "0.0.1"
+  },
+  "basePath": "/api/v2/",
+  "paths": {
+    "/alerts": {
+      "get": {
+        "description": "Get a list of alerts",
+        "tags": [
+          "alert"
         ],
-        "responses": {
-          "200": {
-            "description": "Create / update silence response",
-            "schema": {
-              "type": "object",
-              "properties": {
-                "silenceID": {
-                  "type": "string"
-                }
+        "operationId": "getAlerts",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Show active alerts",
+            "name": "active",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Show silenced alerts",
+            "name": "silenced",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Show inhibited alerts",
+            "name": "inhibited",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Show unprocessed alerts",
+            "name": "unprocessed",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "A list of matchers to filter alerts by. Pseudo-matchers such as @age\u003e30m, @startsAt\u003e=2024-01-01T00:00:00Z or @state=suppressed match the properties of the alerts rather than their labels",
+            "name": "filter",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "A regex matching receivers to filter alerts by. The receivers of an alert are resolved by matching its labels against the routing tree",
+            "name": "receiver",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "firing",
+              "resolved"
+            ],
+            "type": "string",
+            "default": "firing",
+            "description": "Show firing alerts, or alerts resolved recently which are still kept in memory",
+            "name": "state",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "format": "date-time",
+            "description": "Only show resolved alerts which resolved at or after this time",
+            "name": "since",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Get alerts response",
+            "schema": {
+              "$ref": "#/definitions/gettableAlerts"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Create new Alerts",
+        "tags": [
+          "alert"
+        ],
+        "operationId": "postAlerts",
+        "parameters": [
+          {
+            "description": "The alerts to create",
+            "name": "alerts",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/postableAlerts"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Create alerts response"
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "429": {
+            "description": "Too many requests",
+            "schema": {
+              "type": "string"
+            },
+            "headers": {
+              "Retry-After": {
+                "type": "integer",
+                "description": "The number of seconds after which the request can be retried"
+              }
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
+    "/alerts/groups": {
+      "get": {
+        "description": "Get a list of alert groups",
+        "tags": [
+          "alertgroup"
+        ],
+        "operationId": "getAlertGroups",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Show active alerts",
+            "name": "active",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Show silenced alerts",
+            "name": "silenced",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Show inhibited alerts",
+            "name": "inhibited",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Show muted alerts",
+            "name": "muted",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "A list of matchers to filter alerts by. Pseudo-matchers such as @age\u003e30m, @startsAt\u003e=2024-01-01T00:00:00Z or @state=suppressed match the properties of the alerts rather than their labels",
+            "name": "filter",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "A regex matching receivers to filter alerts by",
+            "name": "receiver",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Show groups which are not muted",
+            "name": "unmuted",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Include the alerts of the groups, only their number is reported otherwise",
+            "name": "alerts",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The maximum number of groups to return",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "default": 0,
+            "description": "The number of groups to skip",
+            "name": "offset",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Get alert groups response",
+            "schema": {
+              "$ref": "#/definitions/alertGroups"
+            },
+            "headers": {
+              "X-Total-Count": {
+                "type": "integer",
+                "description": "The number of groups matching the filters, regardless of the pagination"
               }
             }
           },
@@ -1201,8 +2500,58 @@ func init() {
               "type": "string"
             }
           },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
+    "/alerts/{fingerprint}/ack": {
+      "post": {
+        "description": "Acknowledge a firing alert",
+        "tags": [
+          "alert"
+        ],
+        "operationId": "postAlertAck",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Fingerprint of the alert to acknowledge",
+            "name": "fingerprint",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "The acknowledgement",
+            "name": "ack",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/postableAck"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Acknowledge alert response",
+            "schema": {
+              "$ref": "#/definitions/acknowledgement"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          },
           "404": {
-            "description": "A silence with the specified ID was not found",
+            "description": "A firing alert with the specified fingerprint was not found"
+          },
+          "500": {
+            "description": "Internal server error",
             "schema": {
               "type": "string"
             }
@@ -1210,296 +2559,1794 @@ func init() {
         }
       }
     },
-    "/status": {
-      "get": {
-        "description": "Get current status of an Alertmanager instance and its cluster",
-        "tags": [
-          "general"
-        ],
-        "operationId": "getStatus",
-        "responses": {
-          "200": {
-            "description": "Get status response",
-            "schema": {
-              "$ref": "#/definitions/alertmanagerStatus"
-            }
-          }
+    "/cluster/events": {
+      "get": {
+        "description": "Get the last peers which joined, left or died in the cluster, most recent first",
+        "tags": [
+          "general"
+        ],
+        "operationId": "getClusterEvents",
+        "responses": {
+          "200": {
+            "description": "Get cluster events response",
+            "schema": {
+              "$ref": "#/definitions/clusterEvents"
+            }
+          }
+        }
+      }
+    },
+    "/config/effective": {
+      "get": {
+        "description": "Get the running configuration with the defaults and the inherited route options set explicitly, secrets redacted",
+        "tags": [
+          "general"
+        ],
+        "operationId": "getEffectiveConfig",
+        "responses": {
+          "200": {
+            "description": "Get effective config response",
+            "schema": {
+              "$ref": "#/definitions/alertmanagerConfig"
+            }
+          }
+        }
+      }
+    },
+    "/debug/cardinality": {
+      "get": {
+        "description": "Get the cardinality of the labels of the active alerts and the aggregation groups with the most alerts",
+        "tags": [
+          "alert"
+        ],
+        "operationId": "getDebugCardinality",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "default": 10,
+            "description": "The number of label names, values per label name and groups to report",
+            "name": "limit",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Debug cardinality response",
+            "schema": {
+              "$ref": "#/definitions/alertCardinality"
+            }
+          }
+        }
+      }
+    },
+    "/debug/inhibition": {
+      "post": {
+        "description": "Evaluate every inhibition rule for a label set and report why the alert would or wouldn't be inhibited",
+        "tags": [
+          "alert"
+        ],
+        "operationId": "postDebugInhibition",
+        "parameters": [
+          {
+            "description": "The labels of the alert to evaluate",
+            "name": "labels",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/labelSet"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Debug inhibition response",
+            "schema": {
+              "$ref": "#/definitions/inhibitRuleExplanations"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
+    "/debug/notifications": {
+      "get": {
+        "description": "Get the requests of the last failed notifications of the receivers with debug_log_payloads enabled",
+        "tags": [
+          "receiver"
+        ],
+        "operationId": "getDebugNotifications",
+        "responses": {
+          "200": {
+            "description": "Debug notifications response",
+            "schema": {
+              "$ref": "#/definitions/notificationPayloads"
+            }
+          }
+        }
+      }
+    },
+    "/preview/{integration}": {
+      "post": {
+        "description": "Render the notifications of an integration without sending them",
+        "tags": [
+          "receiver"
+        ],
+        "operationId": "postPreview",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Integration to render, e.g. slack",
+            "name": "integration",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "The receiver and alerts to render the notifications for",
+            "name": "preview",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/postablePreview"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Preview notifications response",
+            "schema": {
+              "$ref": "#/definitions/integrationPreviews"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "403": {
+            "description": "The preview API is disabled",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "404": {
+            "description": "The receiver or integration was not found",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
+    "/receivers": {
+      "get": {
+        "description": "Get list of all receivers (name of notification integrations)",
+        "tags": [
+          "receiver"
+        ],
+        "operationId": "getReceivers",
+        "responses": {
+          "200": {
+            "description": "Get receivers response",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/receiver"
+              }
+            }
+          }
+        }
+      }
+    },
+    "/receivers/stats": {
+      "get": {
+        "description": "Get the notification statistics of the receivers over the last 24 hours",
+        "tags": [
+          "receiver"
+        ],
+        "operationId": "getReceiverStats",
+        "responses": {
+          "200": {
+            "description": "Get receiver stats response",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/receiverStats"
+              }
+            }
+          }
+        }
+      }
+    },
+    "/receivers/{name}/disable": {
+      "put": {
+        "description": "Disable the integrations of a receiver for a while",
+        "tags": [
+          "receiver"
+        ],
+        "operationId": "putReceiverDisable",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Name of the receiver to disable",
+            "name": "name",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "How long to disable the receiver for",
+            "name": "disable",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/postableReceiverDisable"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Disable receiver response",
+            "schema": {
+              "$ref": "#/definitions/receiverDisable"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "404": {
+            "description": "A receiver with the specified name was not found"
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Enable a disabled receiver again",
+        "tags": [
+          "receiver"
+        ],
+        "operationId": "deleteReceiverDisable",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Name of the receiver to enable",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Enable receiver response"
+          },
+          "404": {
+            "description": "A disabled receiver with the specified name was not found"
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
+    "/silence/{silenceID}": {
+      "get": {
+        "description": "Get a silence by its ID",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "getSilence",
+        "responses": {
+          "200": {
+            "description": "Get silence response",
+            "schema": {
+              "$ref": "#/definitions/gettableSilence"
+            }
+          },
+          "404": {
+            "description": "A silence with the specified ID was not found"
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Delete a silence by its ID",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "deleteSilence",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "description": "ID of the silence to get",
+            "name": "silenceID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Delete silence response"
+          },
+          "404": {
+            "description": "A silence with the specified ID was not found"
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "format": "uuid",
+          "description": "ID of the silence to get",
+          "name": "silenceID",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/silences": {
+      "get": {
+        "description": "Get a list of silences",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "getSilences",
+        "parameters": [
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "A list of matchers to filter silences by",
+            "name": "filter",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "A list of equality matchers forming a label set, to return the silences matching it",
+            "name": "matches",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "A list of matchers, to return the silences whose matchers overlap them",
+            "name": "overlaps",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Get silences response",
+            "schema": {
+              "$ref": "#/definitions/gettableSilences"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Post a new silence or update an existing one",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "postSilences",
+        "parameters": [
+          {
+            "description": "The silence to create",
+            "name": "silence",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/postableSilence"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Create / update silence response",
+            "schema": {
+              "type": "object",
+              "properties": {
+                "silenceID": {
+                  "type": "string"
+                }
+              }
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "404": {
+            "description": "A silence with the specified ID was not found",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
+    "/silences/batch": {
+      "post": {
+        "description": "Create, update and expire many silences at once. The operations are applied independently, the failure of one doesn't prevent the others.",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "postSilencesBatch",
+        "parameters": [
+          {
+            "description": "The silences to create or update and the IDs of the silences to expire",
+            "name": "batch",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/postableSilenceBatch"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The results of the operations, in the order of the request",
+            "schema": {
+              "$ref": "#/definitions/silenceBatchResults"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
+    "/silences/import": {
+      "post": {
+        "description": "Import the silences exported by Grafana Alerting or karma. The silences are converted and created independently, the failure of one doesn't prevent the others.",
+        "tags": [
+          "silence"
+        ],
+        "operationId": "postSilencesImport",
+        "parameters": [
+          {
+            "description": "The exported silences to import",
+            "name": "silenceImport",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/silenceImport"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The results of the conversions, in the order of the exported data",
+            "schema": {
+              "$ref": "#/definitions/silenceImportResults"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
+    "/status": {
+      "get": {
+        "description": "Get current status of an Alertmanager instance and its cluster",
+        "tags": [
+          "general"
+        ],
+        "operationId": "getStatus",
+        "responses": {
+          "200": {
+            "description": "Get status response",
+            "schema": {
+              "$ref": "#/definitions/alertmanagerStatus"
+            }
+          }
+        }
+      }
+    },
+    "/templates/lint": {
+      "post": {
+        "description": "Lint template files and report their problems",
+        "tags": [
+          "template"
+        ],
+        "operationId": "lintTemplates",
+        "parameters": [
+          {
+            "description": "The template files to lint",
+            "name": "lint",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/templateLintRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Template lint response",
+            "schema": {
+              "$ref": "#/definitions/templateDiagnostics"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "500": {
+            "description": "Internal server error",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    },
+    "/timeintervals": {
+      "get": {
+        "description": "Get list of time intervals with their next activation and deactivation, looked for up to a year ahead",
+        "tags": [
+          "timeinterval"
+        ],
+        "operationId": "getTimeIntervals",
+        "responses": {
+          "200": {
+            "description": "Time intervals response",
+            "schema": {
+              "$ref": "#/definitions/timeIntervalStatuses"
+            }
+          }
+        }
+      }
+    },
+    "/timeintervals/test": {
+      "post": {
+        "description": "Test whether a time interval is active at the given times",
+        "tags": [
+          "timeinterval"
+        ],
+        "operationId": "testTimeInterval",
+        "parameters": [
+          {
+            "description": "The time interval and the times to test",
+            "name": "test",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/timeIntervalTestRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Time interval test response",
+            "schema": {
+              "$ref": "#/definitions/timeIntervalTestResults"
+            }
+          },
+          "400": {
+            "description": "Bad request",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "404": {
+            "description": "The time interval was not found",
+            "schema": {
+              "type": "string"
+            }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "acknowledgement": {
+      "type": "object",
+      "required": [
+        "acknowledgedBy",
+        "acknowledgedAt",
+        "expiresAt"
+      ],
+      "properties": {
+        "acknowledgedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "acknowledgedBy": {
+          "type": "string"
+        },
+        "expiresAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "note": {
+          "type": "string"
+        }
+      }
+    },
+    "alert": {
+      "type": "object",
+      "required": [
+        "labels"
+      ],
+      "properties": {
+        "generatorURL": {
+          "type": "string",
+          "format": "uri"
+        },
+        "labels": {
+          "$ref": "#/definitions/labelSet"
+        }
+      }
+    },
+    "alertCardinality": {
+      "type": "object",
+      "required": [
+        "alerts",
+        "labels",
+        "groups"
+      ],
+      "properties": {
+        "alerts": {
+          "description": "Number of active alerts.",
+          "type": "integer"
+        },
+        "groups": {
+          "description": "The aggregation groups with the most alerts.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/groupCardinality"
+          }
+        },
+        "labels": {
+          "description": "The label names with the most distinct values.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/labelCardinality"
+          }
+        }
+      }
+    },
+    "alertGroup": {
+      "type": "object",
+      "required": [
+        "labels",
+        "receiver",
+        "alerts"
+      ],
+      "properties": {
+        "alertCount": {
+          "description": "The number of alerts of the group matching the filters",
+          "type": "integer"
+        },
+        "alerts": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/gettableAlert"
+          }
+        },
+        "labels": {
+          "$ref": "#/definitions/labelSet"
+        },
+        "receiver": {
+          "$ref": "#/definitions/receiver"
+        },
+        "routeOptions": {
+          "$ref": "#/definitions/routeOptions"
+        }
+      }
+    },
+    "alertGroups": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/alertGroup"
+      }
+    },
+    "alertStatus": {
+      "type": "object",
+      "required": [
+        "state",
+        "silencedBy",
+        "inhibitedBy",
+        "mutedBy"
+      ],
+      "properties": {
+        "acknowledgement": {
+          "$ref": "#/definitions/acknowledgement"
+        },
+        "inhibitedBy": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "mutedBy": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "silencedBy": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "state": {
+          "type": "string",
+          "enum": [
+            "unprocessed",
+            "active",
+            "suppressed"
+          ]
+        }
+      }
+    },
+    "alertmanagerConfig": {
+      "type": "object",
+      "required": [
+        "original"
+      ],
+      "properties": {
+        "original": {
+          "type": "string"
+        }
+      }
+    },
+    "alertmanagerStatus": {
+      "type": "object",
+      "required": [
+        "cluster",
+        "versionInfo",
+        "config",
+        "uptime"
+      ],
+      "properties": {
+        "cluster": {
+          "$ref": "#/definitions/clusterStatus"
+        },
+        "config": {
+          "$ref": "#/definitions/alertmanagerConfig"
+        },
+        "health": {
+          "$ref": "#/definitions/healthStatus"
+        },
+        "uptime": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "versionInfo": {
+          "$ref": "#/definitions/versionInfo"
+        }
+      }
+    },
+    "clusterEvent": {
+      "type": "object",
+      "required": [
+        "time",
+        "type",
+        "name",
+        "address"
+      ],
+      "properties": {
+        "address": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "reporter": {
+          "description": "Name of the peer which observed the event.",
+          "type": "string"
+        },
+        "time": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "type": {
+          "type": "string",
+          "enum": [
+            "join",
+            "leave",
+            "dead"
+          ]
+        }
+      }
+    },
+    "clusterEvents": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/clusterEvent"
+      }
+    },
+    "clusterStatus": {
+      "type": "object",
+      "required": [
+        "status"
+      ],
+      "properties": {
+        "divergentPeers": {
+          "description": "Names of the peers whose silenced and inhibited alerts differed from the local ones at the last consistency check.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "name": {
+          "type": "string"
+        },
+        "peers": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/peerStatus"
+          }
+        },
+        "status": {
+          "type": "string",
+          "enum": [
+            "ready",
+            "settling",
+            "disabled"
+          ]
+        }
+      }
+    },
+    "gettableAlert": {
+      "allOf": [
+        {
+          "type": "object",
+          "required": [
+            "receivers",
+            "fingerprint",
+            "startsAt",
+            "updatedAt",
+            "endsAt",
+            "annotations",
+            "status"
+          ],
+          "properties": {
+            "annotations": {
+              "$ref": "#/definitions/labelSet"
+            },
+            "endsAt": {
+              "type": "string",
+              "format": "date-time"
+            },
+            "fingerprint": {
+              "type": "string"
+            },
+            "receivers": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/receiver"
+              }
+            },
+            "startsAt": {
+              "type": "string",
+              "format": "date-time"
+            },
+            "status": {
+              "$ref": "#/definitions/alertStatus"
+            },
+            "updatedAt": {
+              "type": "string",
+              "format": "date-time"
+            }
+          }
+        },
+        {
+          "$ref": "#/definitions/alert"
+        }
+      ]
+    },
+    "gettableAlerts": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/gettableAlert"
+      }
+    },
+    "gettableSilence": {
+      "allOf": [
+        {
+          "type": "object",
+          "required": [
+            "id",
+            "status",
+            "updatedAt"
+          ],
+          "properties": {
+            "id": {
+              "type": "string"
+            },
+            "status": {
+              "$ref": "#/definitions/silenceStatus"
+            },
+            "updatedAt": {
+              "type": "string",
+              "format": "date-time"
+            }
+          }
+        },
+        {
+          "$ref": "#/definitions/silence"
+        }
+      ]
+    },
+    "gettableSilences": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/gettableSilence"
+      }
+    },
+    "groupCardinality": {
+      "type": "object",
+      "required": [
+        "labels",
+        "receiver",
+        "alerts"
+      ],
+      "properties": {
+        "alerts": {
+          "description": "Number of active alerts in the group.",
+          "type": "integer"
+        },
+        "labels": {
+          "$ref": "#/definitions/labelSet"
+        },
+        "receiver": {
+          "type": "string"
+        }
+      }
+    },
+    "healthCheck": {
+      "type": "object",
+      "required": [
+        "name",
+        "healthy"
+      ],
+      "properties": {
+        "healthy": {
+          "type": "boolean"
+        },
+        "lastSuccess": {
+          "description": "Time of the last success of the checked dependency, if known.",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "message": {
+          "description": "Details about the state of the dependency, such as the error of the last failure.",
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        }
+      }
+    },
+    "healthStatus": {
+      "type": "object",
+      "required": [
+        "healthy",
+        "checks"
+      ],
+      "properties": {
+        "checks": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/healthCheck"
+          }
+        },
+        "healthy": {
+          "description": "Whether all the checks are healthy.",
+          "type": "boolean"
+        }
+      }
+    },
+    "inhibitEqualMismatch": {
+      "type": "object",
+      "required": [
+        "name",
+        "sourceValue",
+        "targetValue"
+      ],
+      "properties": {
+        "name": {
+          "type": "string"
+        },
+        "sourceValue": {
+          "type": "string"
+        },
+        "targetValue": {
+          "type": "string"
+        }
+      }
+    },
+    "inhibitRuleExplanation": {
+      "type": "object",
+      "required": [
+        "index",
+        "sourceMatchers",
+        "targetMatchers",
+        "equal",
+        "targetMatched",
+        "sourceMatched",
+        "inhibited",
+        "sources"
+      ],
+      "properties": {
+        "equal": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "index": {
+          "description": "Position of the rule in the configuration.",
+          "type": "integer"
+        },
+        "inhibited": {
+          "type": "boolean"
+        },
+        "inhibitedBy": {
+          "description": "Fingerprint of the source alert inhibiting the labels.",
+          "type": "string"
+        },
+        "sourceMatched": {
+          "description": "Whether the labels match the source side of the rule, in which case the sources matching the target side as well are disregarded.",
+          "type": "boolean"
+        },
+        "sourceMatchers": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "sources": {
+          "description": "The active source alerts of the rule.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/inhibitSourceExplanation"
+          }
+        },
+        "targetMatched": {
+          "description": "Whether the labels match the target side of the rule. The sources are only evaluated if they do.",
+          "type": "boolean"
+        },
+        "targetMatchers": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "inhibitRuleExplanations": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/inhibitRuleExplanation"
+      }
+    },
+    "inhibitSourceExplanation": {
+      "type": "object",
+      "required": [
+        "fingerprint",
+        "labels",
+        "twoSided",
+        "mismatches"
+      ],
+      "properties": {
+        "fingerprint": {
+          "type": "string"
+        },
+        "labels": {
+          "$ref": "#/definitions/labelSet"
+        },
+        "mismatches": {
+          "description": "The equal labels whose values differ between the source and the labels.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/inhibitEqualMismatch"
+          }
+        },
+        "twoSided": {
+          "description": "Whether the source is disregarded as it matches both sides of the rule.",
+          "type": "boolean"
+        }
+      }
+    },
+    "integrationPreview": {
+      "type": "object",
+      "required": [
+        "integration",
+        "index",
+        "notifications"
+      ],
+      "properties": {
+        "error": {
+          "type": "string"
+        },
+        "index": {
+          "type": "integer"
+        },
+        "integration": {
+          "type": "string"
+        },
+        "notifications": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/notificationPreview"
+          }
+        }
+      }
+    },
+    "integrationPreviews": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/integrationPreview"
+      }
+    },
+    "labelCardinality": {
+      "type": "object",
+      "required": [
+        "name",
+        "values",
+        "alerts",
+        "topValues"
+      ],
+      "properties": {
+        "alerts": {
+          "description": "Number of active alerts with the label.",
+          "type": "integer"
+        },
+        "name": {
+          "type": "string"
+        },
+        "topValues": {
+          "description": "The values of the label with the most alerts.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/labelValueCardinality"
+          }
+        },
+        "values": {
+          "description": "Number of distinct values of the label.",
+          "type": "integer"
+        }
+      }
+    },
+    "labelSet": {
+      "type": "object",
+      "additionalProperties": {
+        "type": "string"
+      }
+    },
+    "labelValueCardinality": {
+      "type": "object",
+      "required": [
+        "value",
+        "alerts"
+      ],
+      "properties": {
+        "alerts": {
+          "description": "Number of active alerts with the value.",
+          "type": "integer"
+        },
+        "value": {
+          "type": "string"
+        }
+      }
+    },
+    "matcher": {
+      "type": "object",
+      "required": [
+        "name",
+        "value",
+        "isRegex"
+      ],
+      "properties": {
+        "isEqual": {
+          "type": "boolean",
+          "default": true
+        },
+        "isRegex": {
+          "type": "boolean"
+        },
+        "name": {
+          "type": "string"
+        },
+        "value": {
+          "type": "string"
+        }
+      }
+    },
+    "matchers": {
+      "type": "array",
+      "minItems": 1,
+      "items": {
+        "$ref": "#/definitions/matcher"
+      }
+    },
+    "notificationPayload": {
+      "type": "object",
+      "required": [
+        "time",
+        "receiver",
+        "integration"
+      ],
+      "properties": {
+        "attempt": {
+          "type": "integer"
+        },
+        "error": {
+          "type": "string"
+        },
+        "groupKey": {
+          "type": "string"
+        },
+        "integration": {
+          "type": "string"
+        },
+        "method": {
+          "type": "string"
+        },
+        "receiver": {
+          "type": "string"
+        },
+        "requestBody": {
+          "type": "string"
+        },
+        "responseBody": {
+          "type": "string"
+        },
+        "statusCode": {
+          "type": "integer"
+        },
+        "time": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "url": {
+          "type": "string"
+        }
+      }
+    },
+    "notificationPayloads": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/notificationPayload"
+      }
+    },
+    "notificationPreview": {
+      "type": "object",
+      "required": [
+        "body"
+      ],
+      "properties": {
+        "body": {
+          "type": "string"
+        },
+        "headers": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "method": {
+          "type": "string"
+        },
+        "url": {
+          "type": "string"
+        }
+      }
+    },
+    "peerStatus": {
+      "type": "object",
+      "required": [
+        "name",
+        "address"
+      ],
+      "properties": {
+        "address": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
         }
       }
-    }
-  },
-  "definitions": {
-    "alert": {
+    },
+    "postableAck": {
       "type": "object",
       "required": [
-        "labels"
+        "acknowledgedBy"
       ],
       "properties": {
-        "generatorURL": {
+        "acknowledgedBy": {
+          "type": "string"
+        },
+        "expiresAt": {
           "type": "string",
-          "format": "uri"
+          "format": "date-time"
         },
-        "labels": {
-          "$ref": "#/definitions/labelSet"
+        "note": {
+          "type": "string"
         }
       }
     },
-    "alertGroup": {
+    "postableAlert": {
+      "allOf": [
+        {
+          "type": "object",
+          "properties": {
+            "annotations": {
+              "$ref": "#/definitions/labelSet"
+            },
+            "endsAt": {
+              "type": "string",
+              "format": "date-time"
+            },
+            "startsAt": {
+              "type": "string",
+              "format": "date-time"
+            }
+          }
+        },
+        {
+          "$ref": "#/definitions/alert"
+        }
+      ]
+    },
+    "postableAlerts": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/postableAlert"
+      }
+    },
+    "postablePreview": {
       "type": "object",
       "required": [
-        "labels",
         "receiver",
         "alerts"
       ],
       "properties": {
         "alerts": {
+          "$ref": "#/definitions/postableAlerts"
+        },
+        "config": {
+          "type": "string"
+        },
+        "receiver": {
+          "type": "string"
+        }
+      }
+    },
+    "postableReceiverDisable": {
+      "type": "object",
+      "required": [
+        "duration",
+        "createdBy"
+      ],
+      "properties": {
+        "comment": {
+          "type": "string"
+        },
+        "createdBy": {
+          "type": "string"
+        },
+        "duration": {
+          "description": "How long to disable the receiver for, e.g. 2h",
+          "type": "string"
+        }
+      }
+    },
+    "postableSilence": {
+      "allOf": [
+        {
+          "type": "object",
+          "properties": {
+            "id": {
+              "type": "string"
+            }
+          }
+        },
+        {
+          "$ref": "#/definitions/silence"
+        }
+      ]
+    },
+    "postableSilenceBatch": {
+      "type": "object",
+      "properties": {
+        "expire": {
+          "description": "The IDs of the silences to expire.",
           "type": "array",
           "items": {
-            "$ref": "#/definitions/gettableAlert"
+            "type": "string"
           }
         },
-        "labels": {
-          "$ref": "#/definitions/labelSet"
+        "silences": {
+          "description": "The silences to create, or update if they have an ID.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/postableSilence"
+          }
+        }
+      }
+    },
+    "receiver": {
+      "type": "object",
+      "required": [
+        "name"
+      ],
+      "properties": {
+        "disabled": {
+          "$ref": "#/definitions/receiverDisable"
         },
-        "receiver": {
-          "$ref": "#/definitions/receiver"
+        "name": {
+          "type": "string"
         }
       }
     },
-    "alertGroups": {
-      "type": "array",
-      "items": {
-        "$ref": "#/definitions/alertGroup"
+    "receiverDisable": {
+      "type": "object",
+      "required": [
+        "createdBy",
+        "disabledAt",
+        "endsAt"
+      ],
+      "properties": {
+        "comment": {
+          "type": "string"
+        },
+        "createdBy": {
+          "type": "string"
+        },
+        "disabledAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "endsAt": {
+          "type": "string",
+          "format": "date-time"
+        }
       }
     },
-    "alertStatus": {
+    "receiverStats": {
       "type": "object",
       "required": [
-        "state",
-        "silencedBy",
-        "inhibitedBy",
-        "mutedBy"
+        "name",
+        "notifications",
+        "failed"
       ],
       "properties": {
-        "inhibitedBy": {
+        "failed": {
+          "description": "Number of failed notifications within the window.",
+          "type": "integer"
+        },
+        "lastSent": {
+          "description": "Time of the last successful notification, if any since the start of Alertmanager.",
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "name": {
+          "type": "string"
+        },
+        "notifications": {
+          "description": "Number of notifications within the window, successful or not.",
+          "type": "integer"
+        }
+      }
+    },
+    "routeOptions": {
+      "description": "The effective options of the route of an alert group.",
+      "type": "object",
+      "required": [
+        "routeId",
+        "receiver",
+        "groupBy",
+        "groupWait",
+        "groupInterval",
+        "repeatInterval",
+        "muteTimeIntervals",
+        "activeTimeIntervals"
+      ],
+      "properties": {
+        "activeTimeIntervals": {
+          "description": "The time intervals during which the route is active.",
           "type": "array",
           "items": {
             "type": "string"
           }
         },
-        "mutedBy": {
+        "groupBy": {
+          "description": "The labels the alerts are grouped by, [\"...\"] for all of them.",
           "type": "array",
           "items": {
             "type": "string"
           }
         },
-        "silencedBy": {
+        "groupInterval": {
+          "description": "How long to wait before sending a notification about new alerts added to the group.",
+          "type": "string"
+        },
+        "groupWait": {
+          "description": "How long to wait before sending the first notification of the group.",
+          "type": "string"
+        },
+        "muteTimeIntervals": {
+          "description": "The time intervals during which the route is muted.",
           "type": "array",
           "items": {
             "type": "string"
           }
         },
-        "state": {
-          "type": "string",
-          "enum": [
-            "unprocessed",
-            "active",
-            "suppressed"
-          ]
+        "receiver": {
+          "description": "The receiver of the route, empty if it is selected by the label in receiverFromLabel.",
+          "type": "string"
+        },
+        "receiverFromLabel": {
+          "description": "The label whose value selects the receiver of the group.",
+          "type": "string"
+        },
+        "repeatInterval": {
+          "description": "How long to wait before sending a notification again.",
+          "type": "string"
+        },
+        "routeId": {
+          "description": "The identifier of the route of the group.",
+          "type": "string"
         }
       }
     },
-    "alertmanagerConfig": {
+    "silence": {
       "type": "object",
       "required": [
-        "original"
+        "matchers",
+        "startsAt",
+        "endsAt",
+        "createdBy",
+        "comment"
       ],
       "properties": {
-        "original": {
+        "comment": {
+          "type": "string"
+        },
+        "createdBy": {
+          "type": "string"
+        },
+        "endsAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "expireOnResolve": {
+          "description": "Expire the silence once no firing alerts match it for a grace period.",
+          "type": "boolean"
+        },
+        "matchers": {
+          "$ref": "#/definitions/matchers"
+        },
+        "startsAt": {
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "silenceBatchResult": {
+      "type": "object",
+      "properties": {
+        "error": {
+          "description": "The reason of the failure of the operation, empty if it succeeded.",
+          "type": "string"
+        },
+        "silenceID": {
+          "description": "The ID of the silence, empty if it couldn't be created.",
           "type": "string"
         }
       }
     },
-    "alertmanagerStatus": {
+    "silenceBatchResults": {
       "type": "object",
       "required": [
-        "cluster",
-        "versionInfo",
-        "config",
-        "uptime"
+        "silences",
+        "expire"
       ],
       "properties": {
-        "cluster": {
-          "$ref": "#/definitions/clusterStatus"
-        },
-        "config": {
-          "$ref": "#/definitions/alertmanagerConfig"
-        },
-        "uptime": {
-          "type": "string",
-          "format": "date-time"
+        "expire": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/silenceBatchResult"
+          }
         },
-        "versionInfo": {
-          "$ref": "#/definitions/versionInfo"
+        "silences": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/silenceBatchResult"
+          }
         }
       }
     },
-    "clusterStatus": {
+    "silenceImport": {
       "type": "object",
       "required": [
-        "status"
+        "format",
+        "data"
       ],
       "properties": {
-        "name": {
-          "type": "string"
+        "data": {
+          "description": "The exported silences, in the format of the response of the silences endpoint of the Alertmanager API of Grafana Alerting, or of the alerts.json endpoint of karma."
         },
-        "peers": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/peerStatus"
-          }
+        "dryRun": {
+          "description": "Only convert the silences, without creating them.",
+          "type": "boolean"
         },
-        "status": {
+        "format": {
+          "description": "The format of the exported silences.",
           "type": "string",
           "enum": [
-            "ready",
-            "settling",
-            "disabled"
+            "grafana",
+            "karma"
           ]
         }
       }
     },
-    "gettableAlert": {
-      "allOf": [
-        {
-          "type": "object",
-          "required": [
-            "receivers",
-            "fingerprint",
-            "startsAt",
-            "updatedAt",
-            "endsAt",
-            "annotations",
-            "status"
-          ],
-          "properties": {
-            "annotations": {
-              "$ref": "#/definitions/labelSet"
-            },
-            "endsAt": {
-              "type": "string",
-              "format": "date-time"
-            },
-            "fingerprint": {
-              "type": "string"
-            },
-            "receivers": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/receiver"
-              }
-            },
-            "startsAt": {
-              "type": "string",
-              "format": "date-time"
-            },
-            "status": {
-              "$ref": "#/definitions/alertStatus"
-            },
-            "updatedAt": {
-              "type": "string",
-              "format": "date-time"
-            }
+    "silenceImportResult": {
+      "type": "object",
+      "properties": {
+        "error": {
+          "description": "The reason why the silence couldn't be converted or created, empty if it succeeded.",
+          "type": "string"
+        },
+        "lossy": {
+          "description": "The differences between the exported silence and the created one, empty if the conversion is exact.",
+          "type": "array",
+          "items": {
+            "type": "string"
           }
         },
-        {
-          "$ref": "#/definitions/alert"
+        "silenceID": {
+          "description": "The ID of the created silence, empty if it wasn't created.",
+          "type": "string"
+        },
+        "source": {
+          "description": "The identifier of the silence in the exported data, usually its ID.",
+          "type": "string"
         }
-      ]
-    },
-    "gettableAlerts": {
-      "type": "array",
-      "items": {
-        "$ref": "#/definitions/gettableAlert"
       }
     },
-    "gettableSilence": {
-      "allOf": [
-        {
-          "type": "object",
-          "required": [
-            "id",
-            "status",
-            "updatedAt"
-          ],
-          "properties": {
-            "id": {
-              "type": "string"
-            },
-            "status": {
-              "$ref": "#/definitions/silenceStatus"
-            },
-            "updatedAt": {
-              "type": "string",
-              "format": "date-time"
-            }
+    "silenceImportResults": {
+      "type": "object",
+      "required": [
+        "silences"
+      ],
+      "properties": {
+        "silences": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/silenceImportResult"
           }
-        },
-        {
-          "$ref": "#/definitions/silence"
         }
-      ]
-    },
-    "gettableSilences": {
-      "type": "array",
-      "items": {
-        "$ref": "#/definitions/gettableSilence"
       }
     },
-    "labelSet": {
+    "silenceStatus": {
       "type": "object",
-      "additionalProperties": {
-        "type": "string"
+      "required": [
+        "state"
+      ],
+      "properties": {
+        "state": {
+          "type": "string",
+          "enum": [
+            "expired",
+            "active",
+            "pending"
+          ]
+        }
       }
     },
-    "matcher": {
+    "templateDiagnostic": {
       "type": "object",
       "required": [
-        "name",
-        "value",
-        "isRegex"
+        "file",
+        "line",
+        "column",
+        "severity",
+        "message"
       ],
       "properties": {
-        "isEqual": {
-          "type": "boolean",
-          "default": true
-        },
-        "isRegex": {
-          "type": "boolean"
+        "column": {
+          "type": "integer"
         },
-        "name": {
+        "file": {
           "type": "string"
         },
-        "value": {
+        "line": {
+          "type": "integer"
+        },
+        "message": {
           "type": "string"
+        },
+        "severity": {
+          "type": "string",
+          "enum": [
+            "error",
+            "warning"
+          ]
         }
       }
     },
-    "matchers": {
+    "templateDiagnostics": {
       "type": "array",
-      "minItems": 1,
       "items": {
-        "$ref": "#/definitions/matcher"
+        "$ref": "#/definitions/templateDiagnostic"
       }
     },
-    "peerStatus": {
+    "templateFile": {
       "type": "object",
       "required": [
         "name",
-        "address"
+        "content"
       ],
       "properties": {
-        "address": {
+        "content": {
           "type": "string"
         },
         "name": {
@@ -1507,106 +4354,97 @@ func init() {
         }
       }
     },
-    "postableAlert": {
-      "allOf": [
-        {
-          "type": "object",
-          "properties": {
-            "annotations": {
-              "$ref": "#/definitions/labelSet"
-            },
-            "endsAt": {
-              "type": "string",
-              "format": "date-time"
-            },
-            "startsAt": {
-              "type": "string",
-              "format": "date-time"
-            }
+    "templateLintRequest": {
+      "type": "object",
+      "required": [
+        "files"
+      ],
+      "properties": {
+        "entrypoints": {
+          "description": "Names of the templates used by the configuration. If empty, the templates called by the running configuration are used.",
+          "type": "array",
+          "items": {
+            "type": "string"
           }
         },
-        {
-          "$ref": "#/definitions/alert"
-        }
-      ]
-    },
-    "postableAlerts": {
-      "type": "array",
-      "items": {
-        "$ref": "#/definitions/postableAlert"
-      }
-    },
-    "postableSilence": {
-      "allOf": [
-        {
-          "type": "object",
-          "properties": {
-            "id": {
-              "type": "string"
-            }
+        "files": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/templateFile"
           }
-        },
-        {
-          "$ref": "#/definitions/silence"
         }
-      ]
+      }
     },
-    "receiver": {
+    "timeIntervalStatus": {
       "type": "object",
       "required": [
-        "name"
+        "name",
+        "active"
       ],
       "properties": {
+        "active": {
+          "type": "boolean"
+        },
         "name": {
           "type": "string"
+        },
+        "nextActivation": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "nextDeactivation": {
+          "type": "string",
+          "format": "date-time"
         }
       }
     },
-    "silence": {
+    "timeIntervalStatuses": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/timeIntervalStatus"
+      }
+    },
+    "timeIntervalTestRequest": {
       "type": "object",
       "required": [
-        "matchers",
-        "startsAt",
-        "endsAt",
-        "createdBy",
-        "comment"
+        "name",
+        "times"
       ],
       "properties": {
-        "comment": {
-          "type": "string"
-        },
-        "createdBy": {
+        "name": {
           "type": "string"
         },
-        "endsAt": {
-          "type": "string",
-          "format": "date-time"
-        },
-        "matchers": {
-          "$ref": "#/definitions/matchers"
-        },
-        "startsAt": {
-          "type": "string",
-          "format": "date-time"
+        "times": {
+          "type": "array",
+          "items": {
+            "type": "string",
+            "format": "date-time"
+          }
         }
       }
     },
-    "silenceStatus": {
+    "timeIntervalTestResult": {
       "type": "object",
       "required": [
-        "state"
+        "time",
+        "active"
       ],
       "properties": {
-        "state": {
+        "active": {
+          "type": "boolean"
+        },
+        "time": {
           "type": "string",
-          "enum": [
-            "expired",
-            "active",
-            "pending"
-          ]
+          "format": "date-time"
         }
       }
     },
+    "timeIntervalTestResults": {
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/timeIntervalTestResult"
+      }
+    },
     "versionInfo": {
       "type": "object",
       "required": [
@@ -1669,6 +4507,14 @@ func init() {
     {
       "description": "Everything related to Alertmanager alerts",
       "name": "alert"
+    },
+    {
+      "description": "Everything related to Alertmanager templates",
+      "name": "template"
+    },
+    {
+      "description": "Everything related to Alertmanager time intervals",
+      "name": "timeinterval"
     }
   ]
 }`))
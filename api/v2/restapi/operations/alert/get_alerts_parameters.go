@@ -27,6 +27,7 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // NewGetAlertsParams creates a new GetAlertsParams object
@@ -40,7 +41,10 @@ func NewGetAlertsParams() GetAlertsParams {
 
 		inhibitedDefault = bool(true)
 
-		silencedDefault    = bool(true)
+		silencedDefault = bool(true)
+
+		stateDefault = string("firing")
+
 		unprocessedDefault = bool(true)
 	)
 
@@ -51,6 +55,8 @@ func NewGetAlertsParams() GetAlertsParams {
 
 		Silenced: &silencedDefault,
 
+		State: &stateDefault,
+
 		Unprocessed: &unprocessedDefault,
 	}
 }
@@ -69,7 +75,7 @@ type GetAlertsParams struct {
 	  Default: true
 	*/
 	Active *bool
-	/*A list of matchers to filter alerts by
+	/*A list of matchers to filter alerts by. Pseudo-matchers such as @age>30m, @startsAt>=2024-01-01T00:00:00Z or @state=suppressed match the properties of the alerts rather than their labels
 	  In: query
 	  Collection Format: multi
 	*/
@@ -79,7 +85,7 @@ type GetAlertsParams struct {
 	  Default: true
 	*/
 	Inhibited *bool
-	/*A regex matching receivers to filter alerts by
+	/*A regex matching receivers to filter alerts by. The receivers of an alert are resolved by matching its labels against the routing tree
 	  In: query
 	*/
 	Receiver *string
@@ -88,6 +94,15 @@ type GetAlertsParams struct {
 	  Default: true
 	*/
 	Silenced *bool
+	/*Only show resolved alerts which resolved at or after this time
+	  In: query
+	*/
+	Since *strfmt.DateTime
+	/*Show firing alerts, or alerts resolved recently which are still kept in memory
+	  In: query
+	  Default: "firing"
+	*/
+	State *string
 	/*Show unprocessed alerts
 	  In: query
 	  Default: true
@@ -131,6 +146,16 @@ func (o *GetAlertsParams) BindRequest(r *http.Request, route *middleware.Matched
 		res = append(res, err)
 	}
 
+	qSince, qhkSince, _ := qs.GetOK("since")
+	if err := o.bindSince(qSince, qhkSince, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qState, qhkState, _ := qs.GetOK("state")
+	if err := o.bindState(qState, qhkState, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qUnprocessed, qhkUnprocessed, _ := qs.GetOK("unprocessed")
 	if err := o.bindUnprocessed(qUnprocessed, qhkUnprocessed, route.Formats); err != nil {
 		res = append(res, err)
@@ -253,6 +278,76 @@ func (o *GetAlertsParams) bindSilenced(rawData []string, hasKey bool, formats st
 	return nil
 }
 
+// bindSince binds and validates parameter Since from query.
+func (o *GetAlertsParams) bindSince(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	// Format: date-time
+	value, err := formats.Parse("date-time", raw)
+	if err != nil {
+		return errors.InvalidType("since", "query", "strfmt.DateTime", raw)
+	}
+	o.Since = (value.(*strfmt.DateTime))
+
+	if err := o.validateSince(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateSince carries on validations for parameter Since
+func (o *GetAlertsParams) validateSince(formats strfmt.Registry) error {
+
+	if err := validate.FormatOf("since", "query", "date-time", o.Since.String(), formats); err != nil {
+		return err
+	}
+	return nil
+}
+
+// bindState binds and validates parameter State from query.
+func (o *GetAlertsParams) bindState(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetAlertsParams()
+		return nil
+	}
+	o.State = &raw
+
+	if err := o.validateState(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateState carries on validations for parameter State
+func (o *GetAlertsParams) validateState(formats strfmt.Registry) error {
+
+	if err := validate.EnumCase("state", "query", *o.State, []interface{}{"firing", "resolved"}, true); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // bindUnprocessed binds and validates parameter Unprocessed from query.
 func (o *GetAlertsParams) bindUnprocessed(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -24,6 +24,7 @@ import (
 	"net/url"
 	golangswaggerpaths "path"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 )
 
@@ -34,6 +35,8 @@ type GetAlertsURL struct {
 	Inhibited   *bool
 	Receiver    *string
 	Silenced    *bool
+	Since       *strfmt.DateTime
+	State       *string
 	Unprocessed *bool
 
 	_basePath string
@@ -116,6 +119,22 @@ func (o *GetAlertsURL) Build() (*url.URL, error) {
 		qs.Set("silenced", silencedQ)
 	}
 
+	var sinceQ string
+	if o.Since != nil {
+		sinceQ = o.Since.String()
+	}
+	if sinceQ != "" {
+		qs.Set("since", sinceQ)
+	}
+
+	var stateQ string
+	if o.State != nil {
+		stateQ = *o.State
+	}
+	if stateQ != "" {
+		qs.Set("state", stateQ)
+	}
+
 	var unprocessedQ string
 	if o.Unprocessed != nil {
 		unprocessedQ = swag.FormatBool(*o.Unprocessed)
@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
 )
 
 // PostAlertsOKCode is the HTTP code returned for type PostAlertsOK
@@ -93,6 +94,71 @@ func (o *PostAlertsBadRequest) WriteResponse(rw http.ResponseWriter, producer ru
 	}
 }
 
+// PostAlertsTooManyRequestsCode is the HTTP code returned for type PostAlertsTooManyRequests
+const PostAlertsTooManyRequestsCode int = 429
+
+/*
+PostAlertsTooManyRequests Too many requests
+
+swagger:response postAlertsTooManyRequests
+*/
+type PostAlertsTooManyRequests struct {
+	/*The number of seconds after which the request can be retried
+
+	 */
+	RetryAfter int64 `json:"Retry-After"`
+
+	/*
+	  In: Body
+	*/
+	Payload string `json:"body,omitempty"`
+}
+
+// NewPostAlertsTooManyRequests creates PostAlertsTooManyRequests with default headers values
+func NewPostAlertsTooManyRequests() *PostAlertsTooManyRequests {
+
+	return &PostAlertsTooManyRequests{}
+}
+
+// WithRetryAfter adds the retryAfter to the post alerts too many requests response
+func (o *PostAlertsTooManyRequests) WithRetryAfter(retryAfter int64) *PostAlertsTooManyRequests {
+	o.RetryAfter = retryAfter
+	return o
+}
+
+// SetRetryAfter sets the retryAfter to the post alerts too many requests response
+func (o *PostAlertsTooManyRequests) SetRetryAfter(retryAfter int64) {
+	o.RetryAfter = retryAfter
+}
+
+// WithPayload adds the payload to the post alerts too many requests response
+func (o *PostAlertsTooManyRequests) WithPayload(payload string) *PostAlertsTooManyRequests {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post alerts too many requests response
+func (o *PostAlertsTooManyRequests) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PostAlertsTooManyRequests) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	// response header Retry-After
+
+	retryAfter := swag.FormatInt64(o.RetryAfter)
+	if retryAfter != "" {
+		rw.Header().Set("Retry-After", retryAfter)
+	}
+
+	rw.WriteHeader(429)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
 // PostAlertsInternalServerErrorCode is the HTTP code returned for type PostAlertsInternalServerError
 const PostAlertsInternalServerErrorCode int = 500
 
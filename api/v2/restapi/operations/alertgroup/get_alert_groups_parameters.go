@@ -27,6 +27,7 @@ import (
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // NewGetAlertGroupsParams creates a new GetAlertGroupsParams object
@@ -37,21 +38,31 @@ func NewGetAlertGroupsParams() GetAlertGroupsParams {
 		// initialize parameters with default values
 
 		activeDefault = bool(true)
+		alertsDefault = bool(true)
 
 		inhibitedDefault = bool(true)
-		mutedDefault     = bool(true)
+
+		mutedDefault  = bool(true)
+		offsetDefault = int64(0)
 
 		silencedDefault = bool(true)
+		unmutedDefault  = bool(true)
 	)
 
 	return GetAlertGroupsParams{
 		Active: &activeDefault,
 
+		Alerts: &alertsDefault,
+
 		Inhibited: &inhibitedDefault,
 
 		Muted: &mutedDefault,
 
+		Offset: &offsetDefault,
+
 		Silenced: &silencedDefault,
+
+		Unmuted: &unmutedDefault,
 	}
 }
 
@@ -69,7 +80,12 @@ type GetAlertGroupsParams struct {
 	  Default: true
 	*/
 	Active *bool
-	/*A list of matchers to filter alerts by
+	/*Include the alerts of the groups, only their number is reported otherwise
+	  In: query
+	  Default: true
+	*/
+	Alerts *bool
+	/*A list of matchers to filter alerts by. Pseudo-matchers such as @age>30m, @startsAt>=2024-01-01T00:00:00Z or @state=suppressed match the properties of the alerts rather than their labels
 	  In: query
 	  Collection Format: multi
 	*/
@@ -79,11 +95,22 @@ type GetAlertGroupsParams struct {
 	  Default: true
 	*/
 	Inhibited *bool
+	/*The maximum number of groups to return
+	  Minimum: 1
+	  In: query
+	*/
+	Limit *int64
 	/*Show muted alerts
 	  In: query
 	  Default: true
 	*/
 	Muted *bool
+	/*The number of groups to skip
+	  Minimum: 0
+	  In: query
+	  Default: 0
+	*/
+	Offset *int64
 	/*A regex matching receivers to filter alerts by
 	  In: query
 	*/
@@ -93,6 +120,11 @@ type GetAlertGroupsParams struct {
 	  Default: true
 	*/
 	Silenced *bool
+	/*Show groups which are not muted
+	  In: query
+	  Default: true
+	*/
+	Unmuted *bool
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -111,6 +143,11 @@ func (o *GetAlertGroupsParams) BindRequest(r *http.Request, route *middleware.Ma
 		res = append(res, err)
 	}
 
+	qAlerts, qhkAlerts, _ := qs.GetOK("alerts")
+	if err := o.bindAlerts(qAlerts, qhkAlerts, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qFilter, qhkFilter, _ := qs.GetOK("filter")
 	if err := o.bindFilter(qFilter, qhkFilter, route.Formats); err != nil {
 		res = append(res, err)
@@ -121,11 +158,21 @@ func (o *GetAlertGroupsParams) BindRequest(r *http.Request, route *middleware.Ma
 		res = append(res, err)
 	}
 
+	qLimit, qhkLimit, _ := qs.GetOK("limit")
+	if err := o.bindLimit(qLimit, qhkLimit, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qMuted, qhkMuted, _ := qs.GetOK("muted")
 	if err := o.bindMuted(qMuted, qhkMuted, route.Formats); err != nil {
 		res = append(res, err)
 	}
 
+	qOffset, qhkOffset, _ := qs.GetOK("offset")
+	if err := o.bindOffset(qOffset, qhkOffset, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qReceiver, qhkReceiver, _ := qs.GetOK("receiver")
 	if err := o.bindReceiver(qReceiver, qhkReceiver, route.Formats); err != nil {
 		res = append(res, err)
@@ -135,6 +182,11 @@ func (o *GetAlertGroupsParams) BindRequest(r *http.Request, route *middleware.Ma
 	if err := o.bindSilenced(qSilenced, qhkSilenced, route.Formats); err != nil {
 		res = append(res, err)
 	}
+
+	qUnmuted, qhkUnmuted, _ := qs.GetOK("unmuted")
+	if err := o.bindUnmuted(qUnmuted, qhkUnmuted, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -165,6 +217,30 @@ func (o *GetAlertGroupsParams) bindActive(rawData []string, hasKey bool, formats
 	return nil
 }
 
+// bindAlerts binds and validates parameter Alerts from query.
+func (o *GetAlertGroupsParams) bindAlerts(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetAlertGroupsParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("alerts", "query", "bool", raw)
+	}
+	o.Alerts = &value
+
+	return nil
+}
+
 // bindFilter binds and validates array parameter Filter from query.
 //
 // Arrays are parsed according to CollectionFormat: "multi" (defaults to "csv" when empty).
@@ -211,6 +287,43 @@ func (o *GetAlertGroupsParams) bindInhibited(rawData []string, hasKey bool, form
 	return nil
 }
 
+// bindLimit binds and validates parameter Limit from query.
+func (o *GetAlertGroupsParams) bindLimit(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("limit", "query", "int64", raw)
+	}
+	o.Limit = &value
+
+	if err := o.validateLimit(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateLimit carries on validations for parameter Limit
+func (o *GetAlertGroupsParams) validateLimit(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("limit", "query", *o.Limit, 1, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // bindMuted binds and validates parameter Muted from query.
 func (o *GetAlertGroupsParams) bindMuted(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -235,6 +348,44 @@ func (o *GetAlertGroupsParams) bindMuted(rawData []string, hasKey bool, formats
 	return nil
 }
 
+// bindOffset binds and validates parameter Offset from query.
+func (o *GetAlertGroupsParams) bindOffset(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetAlertGroupsParams()
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("offset", "query", "int64", raw)
+	}
+	o.Offset = &value
+
+	if err := o.validateOffset(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateOffset carries on validations for parameter Offset
+func (o *GetAlertGroupsParams) validateOffset(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("offset", "query", *o.Offset, 0, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // bindReceiver binds and validates parameter Receiver from query.
 func (o *GetAlertGroupsParams) bindReceiver(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -276,3 +427,27 @@ func (o *GetAlertGroupsParams) bindSilenced(rawData []string, hasKey bool, forma
 
 	return nil
 }
+
+// bindUnmuted binds and validates parameter Unmuted from query.
+func (o *GetAlertGroupsParams) bindUnmuted(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetAlertGroupsParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("unmuted", "query", "bool", raw)
+	}
+	o.Unmuted = &value
+
+	return nil
+}
@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
 
 	"github.com/prometheus/alertmanager/api/v2/models"
 )
@@ -36,6 +37,10 @@ GetAlertGroupsOK Get alert groups response
 swagger:response getAlertGroupsOK
 */
 type GetAlertGroupsOK struct {
+	/*The number of groups matching the filters, regardless of the pagination
+
+	 */
+	XTotalCount int64 `json:"X-Total-Count"`
 
 	/*
 	  In: Body
@@ -49,6 +54,17 @@ func NewGetAlertGroupsOK() *GetAlertGroupsOK {
 	return &GetAlertGroupsOK{}
 }
 
+// WithXTotalCount adds the xTotalCount to the get alert groups o k response
+func (o *GetAlertGroupsOK) WithXTotalCount(xTotalCount int64) *GetAlertGroupsOK {
+	o.XTotalCount = xTotalCount
+	return o
+}
+
+// SetXTotalCount sets the xTotalCount to the get alert groups o k response
+func (o *GetAlertGroupsOK) SetXTotalCount(xTotalCount int64) {
+	o.XTotalCount = xTotalCount
+}
+
 // WithPayload adds the payload to the get alert groups o k response
 func (o *GetAlertGroupsOK) WithPayload(payload models.AlertGroups) *GetAlertGroupsOK {
 	o.Payload = payload
@@ -63,6 +79,13 @@ func (o *GetAlertGroupsOK) SetPayload(payload models.AlertGroups) {
 // WriteResponse to the client
 func (o *GetAlertGroupsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header X-Total-Count
+
+	xTotalCount := swag.FormatInt64(o.XTotalCount)
+	if xTotalCount != "" {
+		rw.Header().Set("X-Total-Count", xTotalCount)
+	}
+
 	rw.WriteHeader(200)
 	payload := o.Payload
 	if payload == nil {
@@ -30,11 +30,15 @@ import (
 // GetAlertGroupsURL generates an URL for the get alert groups operation
 type GetAlertGroupsURL struct {
 	Active    *bool
+	Alerts    *bool
 	Filter    []string
 	Inhibited *bool
+	Limit     *int64
 	Muted     *bool
+	Offset    *int64
 	Receiver  *string
 	Silenced  *bool
+	Unmuted   *bool
 
 	_basePath string
 	// avoid unkeyed usage
@@ -78,6 +82,14 @@ func (o *GetAlertGroupsURL) Build() (*url.URL, error) {
 		qs.Set("active", activeQ)
 	}
 
+	var alertsQ string
+	if o.Alerts != nil {
+		alertsQ = swag.FormatBool(*o.Alerts)
+	}
+	if alertsQ != "" {
+		qs.Set("alerts", alertsQ)
+	}
+
 	var filterIR []string
 	for _, filterI := range o.Filter {
 		filterIS := filterI
@@ -100,6 +112,14 @@ func (o *GetAlertGroupsURL) Build() (*url.URL, error) {
 		qs.Set("inhibited", inhibitedQ)
 	}
 
+	var limitQ string
+	if o.Limit != nil {
+		limitQ = swag.FormatInt64(*o.Limit)
+	}
+	if limitQ != "" {
+		qs.Set("limit", limitQ)
+	}
+
 	var mutedQ string
 	if o.Muted != nil {
 		mutedQ = swag.FormatBool(*o.Muted)
@@ -108,6 +128,14 @@ func (o *GetAlertGroupsURL) Build() (*url.URL, error) {
 		qs.Set("muted", mutedQ)
 	}
 
+	var offsetQ string
+	if o.Offset != nil {
+		offsetQ = swag.FormatInt64(*o.Offset)
+	}
+	if offsetQ != "" {
+		qs.Set("offset", offsetQ)
+	}
+
 	var receiverQ string
 	if o.Receiver != nil {
 		receiverQ = *o.Receiver
@@ -124,6 +152,14 @@ func (o *GetAlertGroupsURL) Build() (*url.URL, error) {
 		qs.Set("silenced", silencedQ)
 	}
 
+	var unmutedQ string
+	if o.Unmuted != nil {
+		unmutedQ = swag.FormatBool(*o.Unmuted)
+	}
+	if unmutedQ != "" {
+		qs.Set("unmuted", unmutedQ)
+	}
+
 	_result.RawQuery = qs.Encode()
 
 	return &_result, nil
@@ -38,6 +38,8 @@ import (
 	"github.com/prometheus/alertmanager/api/v2/restapi/operations/general"
 	"github.com/prometheus/alertmanager/api/v2/restapi/operations/receiver"
 	"github.com/prometheus/alertmanager/api/v2/restapi/operations/silence"
+	"github.com/prometheus/alertmanager/api/v2/restapi/operations/template"
+	"github.com/prometheus/alertmanager/api/v2/restapi/operations/timeinterval"
 )
 
 // NewAlertmanagerAPI creates a new Alertmanager instance
@@ -62,6 +64,9 @@ func NewAlertmanagerAPI(spec *loads.Document) *AlertmanagerAPI {
 
 		JSONProducer: runtime.JSONProducer(),
 
+		ReceiverDeleteReceiverDisableHandler: receiver.DeleteReceiverDisableHandlerFunc(func(params receiver.DeleteReceiverDisableParams) middleware.Responder {
+			return middleware.NotImplemented("operation receiver.DeleteReceiverDisable has not yet been implemented")
+		}),
 		SilenceDeleteSilenceHandler: silence.DeleteSilenceHandlerFunc(func(params silence.DeleteSilenceParams) middleware.Responder {
 			return middleware.NotImplemented("operation silence.DeleteSilence has not yet been implemented")
 		}),
@@ -71,6 +76,21 @@ func NewAlertmanagerAPI(spec *loads.Document) *AlertmanagerAPI {
 		AlertGetAlertsHandler: alert.GetAlertsHandlerFunc(func(params alert.GetAlertsParams) middleware.Responder {
 			return middleware.NotImplemented("operation alert.GetAlerts has not yet been implemented")
 		}),
+		GeneralGetClusterEventsHandler: general.GetClusterEventsHandlerFunc(func(params general.GetClusterEventsParams) middleware.Responder {
+			return middleware.NotImplemented("operation general.GetClusterEvents has not yet been implemented")
+		}),
+		AlertGetDebugCardinalityHandler: alert.GetDebugCardinalityHandlerFunc(func(params alert.GetDebugCardinalityParams) middleware.Responder {
+			return middleware.NotImplemented("operation alert.GetDebugCardinality has not yet been implemented")
+		}),
+		ReceiverGetDebugNotificationsHandler: receiver.GetDebugNotificationsHandlerFunc(func(params receiver.GetDebugNotificationsParams) middleware.Responder {
+			return middleware.NotImplemented("operation receiver.GetDebugNotifications has not yet been implemented")
+		}),
+		GeneralGetEffectiveConfigHandler: general.GetEffectiveConfigHandlerFunc(func(params general.GetEffectiveConfigParams) middleware.Responder {
+			return middleware.NotImplemented("operation general.GetEffectiveConfig has not yet been implemented")
+		}),
+		ReceiverGetReceiverStatsHandler: receiver.GetReceiverStatsHandlerFunc(func(params receiver.GetReceiverStatsParams) middleware.Responder {
+			return middleware.NotImplemented("operation receiver.GetReceiverStats has not yet been implemented")
+		}),
 		ReceiverGetReceiversHandler: receiver.GetReceiversHandlerFunc(func(params receiver.GetReceiversParams) middleware.Responder {
 			return middleware.NotImplemented("operation receiver.GetReceivers has not yet been implemented")
 		}),
@@ -83,12 +103,39 @@ func NewAlertmanagerAPI(spec *loads.Document) *AlertmanagerAPI {
 		GeneralGetStatusHandler: general.GetStatusHandlerFunc(func(params general.GetStatusParams) middleware.Responder {
 			return middleware.NotImplemented("operation general.GetStatus has not yet been implemented")
 		}),
+		TimeintervalGetTimeIntervalsHandler: timeinterval.GetTimeIntervalsHandlerFunc(func(params timeinterval.GetTimeIntervalsParams) middleware.Responder {
+			return middleware.NotImplemented("operation timeinterval.GetTimeIntervals has not yet been implemented")
+		}),
+		TemplateLintTemplatesHandler: template.LintTemplatesHandlerFunc(func(params template.LintTemplatesParams) middleware.Responder {
+			return middleware.NotImplemented("operation template.LintTemplates has not yet been implemented")
+		}),
+		AlertPostAlertAckHandler: alert.PostAlertAckHandlerFunc(func(params alert.PostAlertAckParams) middleware.Responder {
+			return middleware.NotImplemented("operation alert.PostAlertAck has not yet been implemented")
+		}),
 		AlertPostAlertsHandler: alert.PostAlertsHandlerFunc(func(params alert.PostAlertsParams) middleware.Responder {
 			return middleware.NotImplemented("operation alert.PostAlerts has not yet been implemented")
 		}),
+		AlertPostDebugInhibitionHandler: alert.PostDebugInhibitionHandlerFunc(func(params alert.PostDebugInhibitionParams) middleware.Responder {
+			return middleware.NotImplemented("operation alert.PostDebugInhibition has not yet been implemented")
+		}),
+		ReceiverPostPreviewHandler: receiver.PostPreviewHandlerFunc(func(params receiver.PostPreviewParams) middleware.Responder {
+			return middleware.NotImplemented("operation receiver.PostPreview has not yet been implemented")
+		}),
 		SilencePostSilencesHandler: silence.PostSilencesHandlerFunc(func(params silence.PostSilencesParams) middleware.Responder {
 			return middleware.NotImplemented("operation silence.PostSilences has not yet been implemented")
 		}),
+		SilencePostSilencesBatchHandler: silence.PostSilencesBatchHandlerFunc(func(params silence.PostSilencesBatchParams) middleware.Responder {
+			return middleware.NotImplemented("operation silence.PostSilencesBatch has not yet been implemented")
+		}),
+		SilencePostSilencesImportHandler: silence.PostSilencesImportHandlerFunc(func(params silence.PostSilencesImportParams) middleware.Responder {
+			return middleware.NotImplemented("operation silence.PostSilencesImport has not yet been implemented")
+		}),
+		ReceiverPutReceiverDisableHandler: receiver.PutReceiverDisableHandlerFunc(func(params receiver.PutReceiverDisableParams) middleware.Responder {
+			return middleware.NotImplemented("operation receiver.PutReceiverDisable has not yet been implemented")
+		}),
+		TimeintervalTestTimeIntervalHandler: timeinterval.TestTimeIntervalHandlerFunc(func(params timeinterval.TestTimeIntervalParams) middleware.Responder {
+			return middleware.NotImplemented("operation timeinterval.TestTimeInterval has not yet been implemented")
+		}),
 	}
 }
 
@@ -125,12 +172,24 @@ type AlertmanagerAPI struct {
 	//   - application/json
 	JSONProducer runtime.Producer
 
+	// ReceiverDeleteReceiverDisableHandler sets the operation handler for the delete receiver disable operation
+	ReceiverDeleteReceiverDisableHandler receiver.DeleteReceiverDisableHandler
 	// SilenceDeleteSilenceHandler sets the operation handler for the delete silence operation
 	SilenceDeleteSilenceHandler silence.DeleteSilenceHandler
 	// AlertgroupGetAlertGroupsHandler sets the operation handler for the get alert groups operation
 	AlertgroupGetAlertGroupsHandler alertgroup.GetAlertGroupsHandler
 	// AlertGetAlertsHandler sets the operation handler for the get alerts operation
 	AlertGetAlertsHandler alert.GetAlertsHandler
+	// GeneralGetClusterEventsHandler sets the operation handler for the get cluster events operation
+	GeneralGetClusterEventsHandler general.GetClusterEventsHandler
+	// AlertGetDebugCardinalityHandler sets the operation handler for the get debug cardinality operation
+	AlertGetDebugCardinalityHandler alert.GetDebugCardinalityHandler
+	// ReceiverGetDebugNotificationsHandler sets the operation handler for the get debug notifications operation
+	ReceiverGetDebugNotificationsHandler receiver.GetDebugNotificationsHandler
+	// GeneralGetEffectiveConfigHandler sets the operation handler for the get effective config operation
+	GeneralGetEffectiveConfigHandler general.GetEffectiveConfigHandler
+	// ReceiverGetReceiverStatsHandler sets the operation handler for the get receiver stats operation
+	ReceiverGetReceiverStatsHandler receiver.GetReceiverStatsHandler
 	// ReceiverGetReceiversHandler sets the operation handler for the get receivers operation
 	ReceiverGetReceiversHandler receiver.GetReceiversHandler
 	// SilenceGetSilenceHandler sets the operation handler for the get silence operation
@@ -139,10 +198,28 @@ type AlertmanagerAPI struct {
 	SilenceGetSilencesHandler silence.GetSilencesHandler
 	// GeneralGetStatusHandler sets the operation handler for the get status operation
 	GeneralGetStatusHandler general.GetStatusHandler
+	// TimeintervalGetTimeIntervalsHandler sets the operation handler for the get time intervals operation
+	TimeintervalGetTimeIntervalsHandler timeinterval.GetTimeIntervalsHandler
+	// TemplateLintTemplatesHandler sets the operation handler for the lint templates operation
+	TemplateLintTemplatesHandler template.LintTemplatesHandler
+	// AlertPostAlertAckHandler sets the operation handler for the post alert ack operation
+	AlertPostAlertAckHandler alert.PostAlertAckHandler
 	// AlertPostAlertsHandler sets the operation handler for the post alerts operation
 	AlertPostAlertsHandler alert.PostAlertsHandler
+	// AlertPostDebugInhibitionHandler sets the operation handler for the post debug inhibition operation
+	AlertPostDebugInhibitionHandler alert.PostDebugInhibitionHandler
+	// ReceiverPostPreviewHandler sets the operation handler for the post preview operation
+	ReceiverPostPreviewHandler receiver.PostPreviewHandler
 	// SilencePostSilencesHandler sets the operation handler for the post silences operation
 	SilencePostSilencesHandler silence.PostSilencesHandler
+	// SilencePostSilencesBatchHandler sets the operation handler for the post silences batch operation
+	SilencePostSilencesBatchHandler silence.PostSilencesBatchHandler
+	// SilencePostSilencesImportHandler sets the operation handler for the post silences import operation
+	SilencePostSilencesImportHandler silence.PostSilencesImportHandler
+	// ReceiverPutReceiverDisableHandler sets the operation handler for the put receiver disable operation
+	ReceiverPutReceiverDisableHandler receiver.PutReceiverDisableHandler
+	// TimeintervalTestTimeIntervalHandler sets the operation handler for the test time interval operation
+	TimeintervalTestTimeIntervalHandler timeinterval.TestTimeIntervalHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -220,6 +297,9 @@ func (o *AlertmanagerAPI) Validate() error {
 		unregistered = append(unregistered, "JSONProducer")
 	}
 
+	if o.ReceiverDeleteReceiverDisableHandler == nil {
+		unregistered = append(unregistered, "receiver.DeleteReceiverDisableHandler")
+	}
 	if o.SilenceDeleteSilenceHandler == nil {
 		unregistered = append(unregistered, "silence.DeleteSilenceHandler")
 	}
@@ -229,6 +309,21 @@ func (o *AlertmanagerAPI) Validate() error {
 	if o.AlertGetAlertsHandler == nil {
 		unregistered = append(unregistered, "alert.GetAlertsHandler")
 	}
+	if o.GeneralGetClusterEventsHandler == nil {
+		unregistered = append(unregistered, "general.GetClusterEventsHandler")
+	}
+	if o.AlertGetDebugCardinalityHandler == nil {
+		unregistered = append(unregistered, "alert.GetDebugCardinalityHandler")
+	}
+	if o.ReceiverGetDebugNotificationsHandler == nil {
+		unregistered = append(unregistered, "receiver.GetDebugNotificationsHandler")
+	}
+	if o.GeneralGetEffectiveConfigHandler == nil {
+		unregistered = append(unregistered, "general.GetEffectiveConfigHandler")
+	}
+	if o.ReceiverGetReceiverStatsHandler == nil {
+		unregistered = append(unregistered, "receiver.GetReceiverStatsHandler")
+	}
 	if o.ReceiverGetReceiversHandler == nil {
 		unregistered = append(unregistered, "receiver.GetReceiversHandler")
 	}
@@ -241,12 +336,39 @@ func (o *AlertmanagerAPI) Validate() error {
 	if o.GeneralGetStatusHandler == nil {
 		unregistered = append(unregistered, "general.GetStatusHandler")
 	}
+	if o.TimeintervalGetTimeIntervalsHandler == nil {
+		unregistered = append(unregistered, "timeinterval.GetTimeIntervalsHandler")
+	}
+	if o.TemplateLintTemplatesHandler == nil {
+		unregistered = append(unregistered, "template.LintTemplatesHandler")
+	}
+	if o.AlertPostAlertAckHandler == nil {
+		unregistered = append(unregistered, "alert.PostAlertAckHandler")
+	}
 	if o.AlertPostAlertsHandler == nil {
 		unregistered = append(unregistered, "alert.PostAlertsHandler")
 	}
+	if o.AlertPostDebugInhibitionHandler == nil {
+		unregistered = append(unregistered, "alert.PostDebugInhibitionHandler")
+	}
+	if o.ReceiverPostPreviewHandler == nil {
+		unregistered = append(unregistered, "receiver.PostPreviewHandler")
+	}
 	if o.SilencePostSilencesHandler == nil {
 		unregistered = append(unregistered, "silence.PostSilencesHandler")
 	}
+	if o.SilencePostSilencesBatchHandler == nil {
+		unregistered = append(unregistered, "silence.PostSilencesBatchHandler")
+	}
+	if o.SilencePostSilencesImportHandler == nil {
+		unregistered = append(unregistered, "silence.PostSilencesImportHandler")
+	}
+	if o.ReceiverPutReceiverDisableHandler == nil {
+		unregistered = append(unregistered, "receiver.PutReceiverDisableHandler")
+	}
+	if o.TimeintervalTestTimeIntervalHandler == nil {
+		unregistered = append(unregistered, "timeinterval.TestTimeIntervalHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -335,6 +457,10 @@ func (o *AlertmanagerAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/receivers/{name}/disable"] = receiver.NewDeleteReceiverDisable(o.context, o.ReceiverDeleteReceiverDisableHandler)
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
@@ -350,6 +476,26 @@ func (o *AlertmanagerAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/cluster/events"] = general.NewGetClusterEvents(o.context, o.GeneralGetClusterEventsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/debug/cardinality"] = alert.NewGetDebugCardinality(o.context, o.AlertGetDebugCardinalityHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/debug/notifications"] = receiver.NewGetDebugNotifications(o.context, o.ReceiverGetDebugNotificationsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/config/effective"] = general.NewGetEffectiveConfig(o.context, o.GeneralGetEffectiveConfigHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/receivers/stats"] = receiver.NewGetReceiverStats(o.context, o.ReceiverGetReceiverStatsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/receivers"] = receiver.NewGetReceivers(o.context, o.ReceiverGetReceiversHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -363,6 +509,18 @@ func (o *AlertmanagerAPI) initHandlerCache() {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/status"] = general.NewGetStatus(o.context, o.GeneralGetStatusHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/timeintervals"] = timeinterval.NewGetTimeIntervals(o.context, o.TimeintervalGetTimeIntervalsHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/templates/lint"] = template.NewLintTemplates(o.context, o.TemplateLintTemplatesHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/alerts/{fingerprint}/ack"] = alert.NewPostAlertAck(o.context, o.AlertPostAlertAckHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -370,7 +528,31 @@ func (o *AlertmanagerAPI) initHandlerCache() {
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
+	o.handlers["POST"]["/debug/inhibition"] = alert.NewPostDebugInhibition(o.context, o.AlertPostDebugInhibitionHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/preview/{integration}"] = receiver.NewPostPreview(o.context, o.ReceiverPostPreviewHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
 	o.handlers["POST"]["/silences"] = silence.NewPostSilences(o.context, o.SilencePostSilencesHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/silences/batch"] = silence.NewPostSilencesBatch(o.context, o.SilencePostSilencesBatchHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/silences/import"] = silence.NewPostSilencesImport(o.context, o.SilencePostSilencesImportHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/receivers/{name}/disable"] = receiver.NewPutReceiverDisable(o.context, o.ReceiverPutReceiverDisableHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/timeintervals/test"] = timeinterval.NewTestTimeInterval(o.context, o.TimeintervalTestTimeIntervalHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
@@ -50,6 +50,18 @@ type GetSilencesParams struct {
 	  Collection Format: multi
 	*/
 	Filter []string
+
+	/*A list of equality matchers forming a label set, to return the silences matching it
+	  In: query
+	  Collection Format: multi
+	*/
+	Matches []string
+
+	/*A list of matchers, to return the silences whose matchers overlap them
+	  In: query
+	  Collection Format: multi
+	*/
+	Overlaps []string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -67,6 +79,14 @@ func (o *GetSilencesParams) BindRequest(r *http.Request, route *middleware.Match
 	if err := o.bindFilter(qFilter, qhkFilter, route.Formats); err != nil {
 		res = append(res, err)
 	}
+	qMatches, qhkMatches, _ := qs.GetOK("matches")
+	if err := o.bindMatches(qMatches, qhkMatches, route.Formats); err != nil {
+		res = append(res, err)
+	}
+	qOverlaps, qhkOverlaps, _ := qs.GetOK("overlaps")
+	if err := o.bindOverlaps(qOverlaps, qhkOverlaps, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -94,3 +114,47 @@ func (o *GetSilencesParams) bindFilter(rawData []string, hasKey bool, formats st
 
 	return nil
 }
+
+// bindMatches binds and validates array parameter Matches from query.
+//
+// Arrays are parsed according to CollectionFormat: "multi" (defaults to "csv" when empty).
+func (o *GetSilencesParams) bindMatches(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	// CollectionFormat: multi
+	matchesIC := rawData
+	if len(matchesIC) == 0 {
+		return nil
+	}
+
+	var matchesIR []string
+	for _, matchesIV := range matchesIC {
+		matchesI := matchesIV
+
+		matchesIR = append(matchesIR, matchesI)
+	}
+
+	o.Matches = matchesIR
+
+	return nil
+}
+
+// bindOverlaps binds and validates array parameter Overlaps from query.
+//
+// Arrays are parsed according to CollectionFormat: "multi" (defaults to "csv" when empty).
+func (o *GetSilencesParams) bindOverlaps(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	// CollectionFormat: multi
+	overlapsIC := rawData
+	if len(overlapsIC) == 0 {
+		return nil
+	}
+
+	var overlapsIR []string
+	for _, overlapsIV := range overlapsIC {
+		overlapsI := overlapsIV
+
+		overlapsIR = append(overlapsIR, overlapsI)
+	}
+
+	o.Overlaps = overlapsIR
+
+	return nil
+}
@@ -29,7 +29,9 @@ import (
 
 // GetSilencesURL generates an URL for the get silences operation
 type GetSilencesURL struct {
-	Filter []string
+	Filter   []string
+	Matches  []string
+	Overlaps []string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -79,6 +81,34 @@ func (o *GetSilencesURL) Build() (*url.URL, error) {
 		qs.Add("filter", qsv)
 	}
 
+	var matchesIR []string
+	for _, matchesI := range o.Matches {
+		matchesIS := matchesI
+		if matchesIS != "" {
+			matchesIR = append(matchesIR, matchesIS)
+		}
+	}
+
+	matches := swag.JoinByFormat(matchesIR, "multi")
+
+	for _, qsv := range matches {
+		qs.Add("matches", qsv)
+	}
+
+	var overlapsIR []string
+	for _, overlapsI := range o.Overlaps {
+		overlapsIS := overlapsI
+		if overlapsIS != "" {
+			overlapsIR = append(overlapsIR, overlapsIS)
+		}
+	}
+
+	overlaps := swag.JoinByFormat(overlapsIR, "multi")
+
+	for _, qsv := range overlaps {
+		qs.Add("overlaps", qsv)
+	}
+
 	_result.RawQuery = qs.Encode()
 
 	return &_result, nil
@@ -0,0 +1,56 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"github.com/go-openapi/runtime/middleware"
+
+	open_api_models "github.com/prometheus/alertmanager/api/v2/models"
+	template_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/template"
+	"github.com/prometheus/alertmanager/template"
+)
+
+func (api *API) lintTemplatesHandler(params template_ops.LintTemplatesParams) middleware.Responder {
+	logger := api.requestLogger(params.HTTPRequest)
+
+	files := make([]template.LintFile, 0, len(params.Lint.Files))
+	for _, f := range params.Lint.Files {
+		files = append(files, template.LintFile{Name: *f.Name, Content: *f.Content})
+	}
+	entrypoints := params.Lint.Entrypoints
+	if len(entrypoints) == 0 {
+		api.mtx.RLock()
+		entrypoints = template.Called(api.alertmanagerConfig.String())
+		api.mtx.RUnlock()
+	}
+
+	diags, err := template.Lint(files, entrypoints)
+	if err != nil {
+		logger.Error("Failed to lint templates", "err", err)
+		return template_ops.NewLintTemplatesInternalServerError().WithPayload(err.Error())
+	}
+
+	res := make(open_api_models.TemplateDiagnostics, 0, len(diags))
+	for _, d := range diags {
+		line, column := int64(d.Line), int64(d.Column)
+		res = append(res, &open_api_models.TemplateDiagnostic{
+			File:     &d.File,
+			Line:     &line,
+			Column:   &column,
+			Severity: &d.Severity,
+			Message:  &d.Message,
+		})
+	}
+	return template_ops.NewLintTemplatesOK().WithPayload(res)
+}
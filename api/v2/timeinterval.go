@@ -0,0 +1,108 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	open_api_models "github.com/prometheus/alertmanager/api/v2/models"
+	timeinterval_ops "github.com/prometheus/alertmanager/api/v2/restapi/operations/timeinterval"
+	"github.com/prometheus/alertmanager/timeinterval"
+)
+
+// timeIntervalTransitionHorizon is how far ahead the next activation and
+// deactivation of time intervals are looked for.
+const timeIntervalTransitionHorizon = 366 * 24 * time.Hour
+
+func (api *API) getTimeIntervalsHandler(params timeinterval_ops.GetTimeIntervalsParams) middleware.Responder {
+	api.mtx.RLock()
+	intervals := api.alertmanagerConfig.TimeIntervalsByName()
+	api.mtx.RUnlock()
+
+	return timeinterval_ops.NewGetTimeIntervalsOK().WithPayload(timeIntervalStatuses(intervals, time.Now()))
+}
+
+// timeIntervalStatuses returns whether the time intervals are active at now
+// and when they are next activated and deactivated, ordered by name.
+func timeIntervalStatuses(intervals map[string][]timeinterval.TimeInterval, now time.Time) open_api_models.TimeIntervalStatuses {
+	names := make([]string, 0, len(intervals))
+	for name := range intervals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	intervener := timeinterval.NewIntervener(intervals)
+	limit := now.Add(timeIntervalTransitionHorizon)
+	res := make(open_api_models.TimeIntervalStatuses, 0, len(names))
+	for _, name := range names {
+		// The names come from the intervals, the intervener can't fail.
+		active, _, _ := intervener.Mutes([]string{name}, now)
+		status := &open_api_models.TimeIntervalStatus{
+			Name:   &name,
+			Active: &active,
+		}
+
+		// The first transition is the deactivation of an active interval and
+		// the activation of an inactive one, the second one is the other.
+		first, ok, _ := intervener.NextTransition(name, now, limit)
+		if ok {
+			second, ok, _ := intervener.NextTransition(name, first, limit)
+			if active {
+				status.NextDeactivation = strfmt.DateTime(first)
+				if ok {
+					status.NextActivation = strfmt.DateTime(second)
+				}
+			} else {
+				status.NextActivation = strfmt.DateTime(first)
+				if ok {
+					status.NextDeactivation = strfmt.DateTime(second)
+				}
+			}
+		}
+		res = append(res, status)
+	}
+	return res
+}
+
+func (api *API) testTimeIntervalHandler(params timeinterval_ops.TestTimeIntervalParams) middleware.Responder {
+	api.mtx.RLock()
+	intervals := api.alertmanagerConfig.TimeIntervalsByName()
+	api.mtx.RUnlock()
+
+	name := *params.Test.Name
+	if _, ok := intervals[name]; !ok {
+		return timeinterval_ops.NewTestTimeIntervalNotFound().WithPayload(fmt.Sprintf("time interval %q not found", name))
+	}
+
+	// Evaluate the interval the same way as the time mute and active stages
+	// of the notification pipeline.
+	intervener := timeinterval.NewIntervener(intervals)
+	res := make(open_api_models.TimeIntervalTestResults, 0, len(params.Test.Times))
+	for _, t := range params.Test.Times {
+		active, _, err := intervener.Mutes([]string{name}, time.Time(t))
+		if err != nil {
+			return timeinterval_ops.NewTestTimeIntervalBadRequest().WithPayload(err.Error())
+		}
+		res = append(res, &open_api_models.TimeIntervalTestResult{
+			Time:   &t,
+			Active: &active,
+		})
+	}
+	return timeinterval_ops.NewTestTimeIntervalOK().WithPayload(res)
+}
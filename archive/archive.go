@@ -0,0 +1,384 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package archive exports resolved alerts and notification records to object
+// storage for long-term analytics.
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// DefaultPartition is the default template of the partition of the archived
+// records, laid out as Hive-style partitions by day and hour.
+const DefaultPartition = `{{ .Kind }}/dt={{ .Time.Format "2006-01-02" }}/hour={{ .Time.Format "15" }}`
+
+const (
+	// KindAlerts is the kind of the records of resolved alerts.
+	KindAlerts = "alerts"
+	// KindNotifications is the kind of the records of sent notifications.
+	KindNotifications = "notifications"
+)
+
+// AlertRecord is the archived record of a resolved alert.
+type AlertRecord struct {
+	Fingerprint  string         `json:"fingerprint"`
+	Labels       model.LabelSet `json:"labels"`
+	Annotations  model.LabelSet `json:"annotations,omitempty"`
+	StartsAt     time.Time      `json:"startsAt"`
+	EndsAt       time.Time      `json:"endsAt"`
+	GeneratorURL string         `json:"generatorURL,omitempty"`
+	Instance     string         `json:"instance,omitempty"`
+}
+
+// NotificationRecord is the archived record of a notification sent by an
+// integration.
+type NotificationRecord struct {
+	Time        time.Time      `json:"time"`
+	Receiver    string         `json:"receiver"`
+	Integration string         `json:"integration"`
+	GroupKey    string         `json:"groupKey"`
+	GroupLabels model.LabelSet `json:"groupLabels,omitempty"`
+	Firing      []string       `json:"firing,omitempty"`
+	Resolved    []string       `json:"resolved,omitempty"`
+	Instance    string         `json:"instance,omitempty"`
+}
+
+// record is a buffered record with the partition it is written to.
+type record struct {
+	partition string
+	data      []byte
+}
+
+// Options configures an Archiver.
+type Options struct {
+	// Partition is the template of the prefix of the objects the records
+	// are written to. It is executed with the Kind of the records and the
+	// Time they happened at. Defaults to DefaultPartition.
+	Partition string
+	// Instance identifies this Alertmanager in the records and object
+	// names, so that the objects written by the peers of a cluster don't
+	// overwrite each other.
+	Instance string
+	// MaxRecords is the maximum number of buffered records. The oldest
+	// records are dropped once it is exceeded, e.g. while the bucket is
+	// unavailable. Zero means no limit.
+	MaxRecords int
+}
+
+type metrics struct {
+	records  *prometheus.CounterVec
+	dropped  prometheus.Counter
+	uploads  prometheus.Counter
+	failures prometheus.Counter
+	buffered prometheus.GaugeFunc
+}
+
+// Archiver buffers resolved alerts and notification records and periodically
+// writes them to a Bucket as gzipped JSON lines, one object per kind and
+// partition.
+type Archiver struct {
+	bucket     Bucket
+	partition  *template.Template
+	instance   string
+	maxRecords int
+
+	logger  *slog.Logger
+	metrics *metrics
+	now     func() time.Time
+
+	mtx     sync.Mutex
+	records []record
+	// flushMtx serializes flushes.
+	flushMtx sync.Mutex
+}
+
+// New returns a new Archiver writing to the given bucket.
+func New(b Bucket, o Options, l *slog.Logger, r prometheus.Registerer) (*Archiver, error) {
+	if o.Partition == "" {
+		o.Partition = DefaultPartition
+	}
+	tmpl, err := template.New("partition").Option("missingkey=error").Parse(o.Partition)
+	if err != nil {
+		return nil, fmt.Errorf("invalid partition template: %w", err)
+	}
+	if o.MaxRecords < 0 {
+		return nil, errors.New("maximum number of records must not be negative")
+	}
+	a := &Archiver{
+		bucket:     b,
+		partition:  tmpl,
+		instance:   o.Instance,
+		maxRecords: o.MaxRecords,
+		logger:     l,
+		now:        time.Now,
+	}
+	// Check the template against a dummy record up front.
+	if _, err := a.partitionFor(KindAlerts, time.Time{}); err != nil {
+		return nil, err
+	}
+	a.metrics = newMetrics(r, a)
+	return a, nil
+}
+
+func newMetrics(r prometheus.Registerer, a *Archiver) *metrics {
+	m := &metrics{
+		records: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_archive_records_total",
+			Help: "The total number of records added to the archive.",
+		}, []string{"kind"}),
+		dropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_archive_records_dropped_total",
+			Help: "The total number of buffered records dropped before they were archived.",
+		}),
+		uploads: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_archive_uploads_total",
+			Help: "The total number of objects written to the archive.",
+		}),
+		failures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_archive_upload_failures_total",
+			Help: "The total number of failed writes of objects to the archive.",
+		}),
+		buffered: prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "alertmanager_archive_records_buffered",
+			Help: "The number of records waiting to be archived.",
+		}, func() float64 {
+			a.mtx.Lock()
+			defer a.mtx.Unlock()
+			return float64(len(a.records))
+		}),
+	}
+	m.records.WithLabelValues(KindAlerts)
+	m.records.WithLabelValues(KindNotifications)
+	if r != nil {
+		r.MustRegister(m.records, m.dropped, m.uploads, m.failures, m.buffered)
+	}
+	return m
+}
+
+func (a *Archiver) partitionFor(kind string, t time.Time) (string, error) {
+	var buf bytes.Buffer
+	err := a.partition.Execute(&buf, struct {
+		Kind string
+		Time time.Time
+	}{kind, t.UTC()})
+	if err != nil {
+		return "", fmt.Errorf("executing partition template: %w", err)
+	}
+	return strings.Trim(buf.String(), "/"), nil
+}
+
+func (a *Archiver) add(kind string, t time.Time, v interface{}) {
+	p, err := a.partitionFor(kind, t)
+	if err != nil {
+		a.logger.Error("Failed to archive record", "kind", kind, "err", err)
+		return
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		a.logger.Error("Failed to archive record", "kind", kind, "err", err)
+		return
+	}
+	a.metrics.records.WithLabelValues(kind).Inc()
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.records = append(a.records, record{partition: p, data: b})
+	a.truncate()
+}
+
+// truncate drops the oldest buffered records above the maximum number of
+// records. The caller must hold a.mtx.
+func (a *Archiver) truncate() {
+	if a.maxRecords > 0 && len(a.records) > a.maxRecords {
+		n := len(a.records) - a.maxRecords
+		a.records = append(a.records[:0:0], a.records[n:]...)
+		a.metrics.dropped.Add(float64(n))
+	}
+}
+
+// AddAlert archives the alert if it is resolved.
+func (a *Archiver) AddAlert(alert *types.Alert) {
+	now := a.now()
+	if !alert.ResolvedAt(now) {
+		return
+	}
+	a.add(KindAlerts, alert.EndsAt, AlertRecord{
+		Fingerprint:  alert.Fingerprint().String(),
+		Labels:       alert.Labels,
+		Annotations:  alert.Annotations,
+		StartsAt:     alert.StartsAt,
+		EndsAt:       alert.EndsAt,
+		GeneratorURL: alert.GeneratorURL,
+		Instance:     a.instance,
+	})
+}
+
+// PreStore implements mem.AlertStoreCallback.
+func (a *Archiver) PreStore(_ *types.Alert, _ bool) error { return nil }
+
+// PostStore implements mem.AlertStoreCallback.
+func (a *Archiver) PostStore(_ *types.Alert, _ bool) {}
+
+// PostDelete implements mem.AlertStoreCallback. It archives the resolved
+// alerts garbage collected from the alert store.
+func (a *Archiver) PostDelete(alert *types.Alert) { a.AddAlert(alert) }
+
+// Stage is a notify.StageFactory of stages archiving the notifications sent by
+// the integrations. It is meant to be registered at
+// notify.StagePositionPostNotify.
+func (a *Archiver) Stage(receiver string, integration *notify.Integration) notify.Stage {
+	if integration == nil {
+		return nil
+	}
+	name := integration.String()
+	return notify.StageFunc(func(ctx context.Context, _ *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+		now, ok := notify.Now(ctx)
+		if !ok {
+			now = a.now()
+		}
+		rec := NotificationRecord{
+			Time:        now,
+			Receiver:    receiver,
+			Integration: name,
+			Instance:    a.instance,
+		}
+		rec.GroupKey, _ = notify.GroupKey(ctx)
+		rec.GroupLabels, _ = notify.GroupLabels(ctx)
+		for _, alert := range alerts {
+			if alert.ResolvedAt(now) {
+				rec.Resolved = append(rec.Resolved, alert.Fingerprint().String())
+			} else {
+				rec.Firing = append(rec.Firing, alert.Fingerprint().String())
+			}
+		}
+		a.add(KindNotifications, now, rec)
+		return ctx, alerts, nil
+	})
+}
+
+// Flush writes the buffered records to the bucket. The records of the objects
+// which couldn't be written are kept for the next flush.
+func (a *Archiver) Flush(ctx context.Context) error {
+	a.flushMtx.Lock()
+	defer a.flushMtx.Unlock()
+
+	a.mtx.Lock()
+	records := a.records
+	a.records = nil
+	a.mtx.Unlock()
+	if len(records) == 0 {
+		return nil
+	}
+
+	partitions := map[string][]record{}
+	for _, r := range records {
+		partitions[r.partition] = append(partitions[r.partition], r)
+	}
+	keys := make([]string, 0, len(partitions))
+	for p := range partitions {
+		keys = append(keys, p)
+	}
+	sort.Strings(keys)
+
+	var (
+		failed []record
+		errs   []error
+		ts     = a.now().UTC().Format("20060102T150405.000000000Z")
+	)
+	for _, p := range keys {
+		name := ts + ".jsonl.gz"
+		if a.instance != "" {
+			name = a.instance + "-" + name
+		}
+		key := name
+		if p != "" {
+			key = p + "/" + name
+		}
+		b, err := encode(partitions[p])
+		if err == nil {
+			err = a.bucket.Put(ctx, key, b)
+		}
+		if err != nil {
+			a.metrics.failures.Inc()
+			failed = append(failed, partitions[p]...)
+			errs = append(errs, fmt.Errorf("writing %q: %w", key, err))
+			continue
+		}
+		a.metrics.uploads.Inc()
+	}
+
+	if len(failed) > 0 {
+		a.mtx.Lock()
+		a.records = append(failed, a.records...)
+		a.truncate()
+		a.mtx.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// encode returns the records as gzipped JSON lines.
+func encode(records []record) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	for _, r := range records {
+		if _, err := zw.Write(append(r.data, '\n')); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Run flushes the buffered records at the given interval until stopc is
+// closed, and a last time before returning.
+func (a *Archiver) Run(interval time.Duration, stopc <-chan struct{}) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	flush := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		defer cancel()
+		if err := a.Flush(ctx); err != nil {
+			a.logger.Error("Archiving records failed", "err", err)
+		}
+	}
+	for {
+		select {
+		case <-stopc:
+			flush()
+			return
+		case <-t.C:
+			flush()
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+type memBucket struct {
+	objects map[string][]byte
+	err     error
+}
+
+func (b *memBucket) Put(_ context.Context, key string, data []byte) error {
+	if b.err != nil {
+		return b.err
+	}
+	if b.objects == nil {
+		b.objects = map[string][]byte{}
+	}
+	b.objects[key] = data
+	return nil
+}
+
+func (b *memBucket) keys() []string {
+	var keys []string
+	for k := range b.objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func decode(t *testing.T, data []byte) []map[string]interface{} {
+	t.Helper()
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	var records []map[string]interface{}
+	s := bufio.NewScanner(zr)
+	for s.Scan() {
+		var r map[string]interface{}
+		require.NoError(t, json.Unmarshal(s.Bytes(), &r))
+		records = append(records, r)
+	}
+	require.NoError(t, s.Err())
+	return records
+}
+
+func TestArchiverFlush(t *testing.T) {
+	b := &memBucket{}
+	a, err := New(b, Options{Instance: "am-0"}, promslog.NewNopLogger(), prometheus.NewRegistry())
+	require.NoError(t, err)
+	now := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+	a.now = func() time.Time { return now }
+
+	resolved := &types.Alert{Alert: model.Alert{
+		Labels:   model.LabelSet{"alertname": "a"},
+		StartsAt: now.Add(-2 * time.Hour),
+		EndsAt:   now.Add(-90 * time.Minute),
+	}}
+	firing := &types.Alert{Alert: model.Alert{
+		Labels:   model.LabelSet{"alertname": "b"},
+		StartsAt: now.Add(-time.Hour),
+		EndsAt:   now.Add(time.Hour),
+	}}
+	a.PostDelete(resolved)
+	// Firing alerts aren't archived.
+	a.AddAlert(firing)
+
+	integration := notify.NewIntegration(nil, nil, "webhook", 0, "team")
+	stage := a.Stage("team", &integration)
+	ctx := notify.WithNow(context.Background(), now)
+	ctx = notify.WithGroupKey(ctx, "{}:{alertname=\"a\"}")
+	_, alerts, err := stage.Exec(ctx, promslog.NewNopLogger(), resolved, firing)
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+	require.Nil(t, a.Stage("team", nil))
+
+	require.NoError(t, a.Flush(context.Background()))
+	require.Equal(t, []string{
+		"alerts/dt=2024-03-01/hour=09/am-0-20240301T103000.000000000Z.jsonl.gz",
+		"notifications/dt=2024-03-01/hour=10/am-0-20240301T103000.000000000Z.jsonl.gz",
+	}, b.keys())
+
+	alertRecords := decode(t, b.objects[b.keys()[0]])
+	require.Len(t, alertRecords, 1)
+	require.Equal(t, map[string]interface{}{"alertname": "a"}, alertRecords[0]["labels"])
+	require.Equal(t, resolved.Fingerprint().String(), alertRecords[0]["fingerprint"])
+	require.Equal(t, "am-0", alertRecords[0]["instance"])
+
+	notificationRecords := decode(t, b.objects[b.keys()[1]])
+	require.Len(t, notificationRecords, 1)
+	require.Equal(t, "team", notificationRecords[0]["receiver"])
+	require.Equal(t, "webhook[0]", notificationRecords[0]["integration"])
+	require.Equal(t, []interface{}{firing.Fingerprint().String()}, notificationRecords[0]["firing"])
+	require.Equal(t, []interface{}{resolved.Fingerprint().String()}, notificationRecords[0]["resolved"])
+
+	// Nothing is written without new records.
+	b.objects = nil
+	require.NoError(t, a.Flush(context.Background()))
+	require.Empty(t, b.objects)
+}
+
+func TestArchiverFlushFailure(t *testing.T) {
+	b := &memBucket{err: errors.New("unavailable")}
+	a, err := New(b, Options{Partition: "{{ .Kind }}", MaxRecords: 2}, promslog.NewNopLogger(), prometheus.NewRegistry())
+	require.NoError(t, err)
+	now := time.Now()
+	a.now = func() time.Time { return now }
+
+	for _, name := range []string{"a", "b", "c"} {
+		a.AddAlert(&types.Alert{Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": model.LabelValue(name)},
+			StartsAt: now.Add(-time.Hour),
+			EndsAt:   now.Add(-time.Minute),
+		}})
+	}
+	require.Equal(t, 1.0, testutil.ToFloat64(a.metrics.dropped))
+
+	// The records are kept until they are written.
+	require.Error(t, a.Flush(context.Background()))
+	require.Equal(t, 1.0, testutil.ToFloat64(a.metrics.failures))
+	require.Equal(t, 2.0, testutil.ToFloat64(a.metrics.buffered))
+
+	b.err = nil
+	require.NoError(t, a.Flush(context.Background()))
+	require.Equal(t, 0.0, testutil.ToFloat64(a.metrics.buffered))
+	require.Len(t, b.objects, 1)
+	for _, data := range b.objects {
+		records := decode(t, data)
+		require.Len(t, records, 2)
+		require.Equal(t, map[string]interface{}{"alertname": "b"}, records[0]["labels"])
+		require.Equal(t, map[string]interface{}{"alertname": "c"}, records[1]["labels"])
+	}
+}
+
+func TestNewInvalidPartition(t *testing.T) {
+	for _, p := range []string{"{{ .Kind", "{{ .Missing }}"} {
+		_, err := New(&memBucket{}, Options{Partition: p}, promslog.NewNopLogger(), nil)
+		require.Error(t, err, p)
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"golang.org/x/oauth2/jwt"
+)
+
+const contentType = "application/gzip"
+
+// Bucket is an object storage the archived records are written to.
+type Bucket interface {
+	// Put writes the object with the given key.
+	Put(ctx context.Context, key string, data []byte) error
+}
+
+// BucketOptions configures the access to the bucket of an archive URL.
+type BucketOptions struct {
+	// S3Region is the region of an S3 bucket. If empty, the region is
+	// taken from the environment or the shared configuration.
+	S3Region string
+	// S3Profile is the name of the shared configuration profile to use.
+	S3Profile string
+	// S3RoleARN is assumed to write to an S3 bucket if set.
+	S3RoleARN string
+	// S3Endpoint overrides the endpoint of S3, e.g. for S3-compatible
+	// storages.
+	S3Endpoint string
+	// GCSCredentialsFile is the path of the service account key used to
+	// write to a GCS bucket. Defaults to the file of the
+	// GOOGLE_APPLICATION_CREDENTIALS environment variable.
+	GCSCredentialsFile string
+	// AzureSASTokenFile is the path of the file holding the shared access
+	// signature used to write to an Azure Blob Storage container.
+	AzureSASTokenFile string
+}
+
+// NewBucket returns the bucket of the given URL, which is one of:
+//
+//	s3://<bucket>/<prefix>
+//	gs://<bucket>/<prefix>
+//	azblob://<account>/<container>/<prefix>
+//	file:///<directory>
+func NewBucket(ctx context.Context, rawURL string, o BucketOptions) (Bucket, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	prefix := strings.Trim(u.Path, "/")
+	switch u.Scheme {
+	case "s3":
+		if u.Host == "" {
+			return nil, errors.New("missing S3 bucket")
+		}
+		return newS3Bucket(u.Host, prefix, o)
+	case "gs":
+		if u.Host == "" {
+			return nil, errors.New("missing GCS bucket")
+		}
+		return newGCSBucket(ctx, u.Host, prefix, o.GCSCredentialsFile)
+	case "azblob":
+		container, prefix, _ := strings.Cut(prefix, "/")
+		if u.Host == "" || container == "" {
+			return nil, errors.New("missing Azure storage account or container")
+		}
+		return newAzureBucket(u.Host, container, prefix, o.AzureSASTokenFile)
+	case "file":
+		if u.Path == "" {
+			return nil, errors.New("missing directory")
+		}
+		return &fileBucket{dir: u.Path}, nil
+	default:
+		return nil, fmt.Errorf("unsupported archive URL scheme %q", u.Scheme)
+	}
+}
+
+type s3Bucket struct {
+	client s3iface.S3API
+	bucket string
+	prefix string
+}
+
+func newS3Bucket(bucket, prefix string, o BucketOptions) (*s3Bucket, error) {
+	cfg := aws.Config{Region: aws.String(o.S3Region)}
+	if o.S3Endpoint != "" {
+		cfg.Endpoint = aws.String(o.S3Endpoint)
+		cfg.S3ForcePathStyle = aws.Bool(true)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            cfg,
+		Profile:           o.S3Profile,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var cfgs []*aws.Config
+	if o.S3RoleARN != "" {
+		cfgs = append(cfgs, &aws.Config{Credentials: stscreds.NewCredentials(sess, o.S3RoleARN)})
+	}
+	return &s3Bucket{client: s3.New(sess, cfgs...), bucket: bucket, prefix: prefix}, nil
+}
+
+func (b *s3Bucket) Put(ctx context.Context, key string, data []byte) error {
+	_, err := b.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(b.bucket),
+		Key:         aws.String(path.Join(b.prefix, key)),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	return err
+}
+
+// gcsScope is the OAuth2 scope needed to write objects to GCS.
+const gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+type gcsBucket struct {
+	client   *http.Client
+	endpoint string
+	bucket   string
+	prefix   string
+}
+
+func newGCSBucket(ctx context.Context, bucket, prefix, credentialsFile string) (*gcsBucket, error) {
+	if credentialsFile == "" {
+		credentialsFile = os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	}
+	if credentialsFile == "" {
+		return nil, errors.New("missing GCS credentials file")
+	}
+	b, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, err
+	}
+	var key struct {
+		Type         string `json:"type"`
+		ClientEmail  string `json:"client_email"`
+		PrivateKey   string `json:"private_key"`
+		PrivateKeyID string `json:"private_key_id"`
+		TokenURI     string `json:"token_uri"`
+	}
+	if err := json.Unmarshal(b, &key); err != nil {
+		return nil, fmt.Errorf("parsing GCS credentials: %w", err)
+	}
+	if key.Type != "service_account" {
+		return nil, fmt.Errorf("unsupported GCS credentials type %q", key.Type)
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+	cfg := &jwt.Config{
+		Email:        key.ClientEmail,
+		PrivateKey:   []byte(key.PrivateKey),
+		PrivateKeyID: key.PrivateKeyID,
+		TokenURL:     key.TokenURI,
+		Scopes:       []string{gcsScope},
+	}
+	return &gcsBucket{
+		client:   cfg.Client(ctx),
+		endpoint: "https://storage.googleapis.com",
+		bucket:   bucket,
+		prefix:   prefix,
+	}, nil
+}
+
+func (b *gcsBucket) Put(ctx context.Context, key string, data []byte) error {
+	u := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?%s", b.endpoint, url.PathEscape(b.bucket), url.Values{
+		"uploadType": {"media"},
+		"name":       {path.Join(b.prefix, key)},
+	}.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return do(b.client, req)
+}
+
+type azureBucket struct {
+	client    *http.Client
+	endpoint  string
+	container string
+	prefix    string
+	sasToken  string
+}
+
+func newAzureBucket(account, container, prefix, sasTokenFile string) (*azureBucket, error) {
+	if sasTokenFile == "" {
+		return nil, errors.New("missing Azure SAS token file")
+	}
+	b, err := os.ReadFile(sasTokenFile)
+	if err != nil {
+		return nil, err
+	}
+	return &azureBucket{
+		client:    http.DefaultClient,
+		endpoint:  fmt.Sprintf("https://%s.blob.core.windows.net", account),
+		container: container,
+		prefix:    prefix,
+		sasToken:  strings.TrimPrefix(strings.TrimSpace(string(b)), "?"),
+	}, nil
+}
+
+func (b *azureBucket) Put(ctx context.Context, key string, data []byte) error {
+	u := fmt.Sprintf("%s/%s/%s?%s", b.endpoint, b.container, path.Join(b.prefix, key), b.sasToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	req.Header.Set("x-ms-version", "2021-08-06")
+	return do(b.client, req)
+}
+
+// do sends the request and returns an error unless it succeeded.
+func do(c *http.Client, req *http.Request) error {
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// fileBucket writes the objects to a local directory.
+type fileBucket struct {
+	dir string
+}
+
+func (b *fileBucket) Put(_ context.Context, key string, data []byte) error {
+	p := filepath.Join(b.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p)
+}
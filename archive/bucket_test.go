@@ -0,0 +1,167 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBucket(t *testing.T) {
+	dir := t.TempDir()
+	sasFile := filepath.Join(dir, "sas")
+	require.NoError(t, os.WriteFile(sasFile, []byte("?sv=2021&sig=abc\n"), 0o600))
+
+	for _, tc := range []struct {
+		url  string
+		o    BucketOptions
+		err  bool
+		test func(*testing.T, Bucket)
+	}{
+		{
+			url: "s3://bucket/some/prefix/",
+			o:   BucketOptions{S3Region: "eu-west-1"},
+			test: func(t *testing.T, b Bucket) {
+				require.Equal(t, "bucket", b.(*s3Bucket).bucket)
+				require.Equal(t, "some/prefix", b.(*s3Bucket).prefix)
+			},
+		},
+		{url: "s3:///prefix", err: true},
+		{url: "gs://bucket", err: true},
+		{
+			url: "azblob://account/container/prefix",
+			o:   BucketOptions{AzureSASTokenFile: sasFile},
+			test: func(t *testing.T, b Bucket) {
+				ab := b.(*azureBucket)
+				require.Equal(t, "https://account.blob.core.windows.net", ab.endpoint)
+				require.Equal(t, "container", ab.container)
+				require.Equal(t, "prefix", ab.prefix)
+				require.Equal(t, "sv=2021&sig=abc", ab.sasToken)
+			},
+		},
+		{url: "azblob://account", o: BucketOptions{AzureSASTokenFile: sasFile}, err: true},
+		{url: "azblob://account/container", err: true},
+		{url: "file:///var/lib/archive"},
+		{url: "http://example.com", err: true},
+	} {
+		t.Run(tc.url, func(t *testing.T) {
+			t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+			b, err := NewBucket(context.Background(), tc.url, tc.o)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tc.test != nil {
+				tc.test(t, b)
+			}
+		})
+	}
+}
+
+func TestFileBucket(t *testing.T) {
+	dir := t.TempDir()
+	b, err := NewBucket(context.Background(), "file://"+dir, BucketOptions{})
+	require.NoError(t, err)
+
+	require.NoError(t, b.Put(context.Background(), "alerts/dt=2024-03-01/a.jsonl.gz", []byte("data")))
+	data, err := os.ReadFile(filepath.Join(dir, "alerts", "dt=2024-03-01", "a.jsonl.gz"))
+	require.NoError(t, err)
+	require.Equal(t, "data", string(data))
+}
+
+func TestAzureBucket(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	b := &azureBucket{
+		client:    srv.Client(),
+		endpoint:  srv.URL,
+		container: "container",
+		prefix:    "prefix",
+		sasToken:  "sig=abc",
+	}
+	require.NoError(t, b.Put(context.Background(), "alerts/a.jsonl.gz", []byte("data")))
+	require.Equal(t, http.MethodPut, req.Method)
+	require.Equal(t, "/container/prefix/alerts/a.jsonl.gz", req.URL.Path)
+	require.Equal(t, "abc", req.URL.Query().Get("sig"))
+	require.Equal(t, "BlockBlob", req.Header.Get("x-ms-blob-type"))
+	require.Equal(t, "data", string(body))
+
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "AuthenticationFailed", http.StatusForbidden)
+	})
+	require.ErrorContains(t, b.Put(context.Background(), "a", nil), "AuthenticationFailed")
+}
+
+func TestGCSBucket(t *testing.T) {
+	var (
+		upload *http.Request
+		body   []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"access_token":"secret","token_type":"Bearer","expires_in":3600}`)
+			return
+		}
+		upload = r
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	creds, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "archive@project.iam.gserviceaccount.com",
+		"private_key": string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		})),
+		"token_uri": srv.URL + "/token",
+	})
+	require.NoError(t, err)
+	credsFile := filepath.Join(t.TempDir(), "creds.json")
+	require.NoError(t, os.WriteFile(credsFile, creds, 0o600))
+
+	b, err := newGCSBucket(context.Background(), "bucket", "prefix", credsFile)
+	require.NoError(t, err)
+	b.endpoint = srv.URL
+
+	require.NoError(t, b.Put(context.Background(), "alerts/a.jsonl.gz", []byte("data")))
+	require.Equal(t, http.MethodPost, upload.Method)
+	require.Equal(t, "/upload/storage/v1/b/bucket/o", upload.URL.Path)
+	require.Equal(t, "prefix/alerts/a.jsonl.gz", upload.URL.Query().Get("name"))
+	require.Equal(t, "Bearer secret", upload.Header.Get("Authorization"))
+	require.Equal(t, "data", string(body))
+}
@@ -163,9 +163,9 @@ var Assets = func() http.FileSystem {
 		"/templates/default.tmpl": &vfsgen۰CompressedFileInfo{
 			name:             "default.tmpl",
 			modTime:          time.Date(1970, 1, 1, 0, 0, 1, 0, time.UTC),
-			uncompressedSize: 8101,
+			uncompressedSize: 8752,
 
-			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xec\x59\xcf\x6f\xeb\x36\x0c\xbe\xe7\xaf\x20\xfc\x76\x68\x0e\xf5\x1b\x76\x2c\x50\x0c\x0f\xc3\x7e\x1c\xba\x61\x68\xd1\x5d\x86\x21\x50\x6d\xc6\x55\x2b\x4b\xae\x44\x27\x0d\xd2\xfc\xef\x83\x6c\xc7\x91\x2d\x27\x91\xd3\xec\xb4\xdc\x12\x99\xfc\x48\x7f\x1f\x4d\xca\xf2\x7a\x0d\x29\xce\xb9\x44\x88\x66\x33\x26\x50\x53\xce\x24\xcb\x50\x47\xb0\xd9\x7c\x73\xfe\xaf\xd7\x80\x32\x85\xcd\x66\xb2\xd7\xe5\xf1\xfe\xce\x7a\xad\xd7\x10\xff\xfc\x4e\xa8\x25\x13\x8f\xf7\x77\xb0\xd9\x7c\xfd\xf2\xb5\xb2\x33\x3f\x6a\x4c\x90\x2f\x50\xdf\x5a\xa3\xfb\xe6\x0f\x7c\x40\xa9\xc5\x5b\x89\x7a\x55\xbb\x37\x81\xba\x91\x4c\xf9\xf4\x82\x09\xd9\x08\x7f\x5b\xef\x07\x62\x54\x1a\xf8\x00\x52\x8f\x45\x81\xba\x76\xe5\x73\xc0\xb7\xf6\x62\x34\xe7\x9a\xcb\xcc\xfa\xdc\x58\x9f\xea\x86\x4c\xfc\x4b\xb5\x0a\x1f\x20\x50\xba\x11\xff\x01\x6b\xf4\xab\x56\x65\x71\xc7\x9e\x50\x98\xf8\x41\x69\xc2\xf4\x4f\xc6\xb5\x89\xff\x62\xa2\x44\x1b\xf0\x45\x71\x09\x11\x58\x54\xa8\x43\x66\x04\x57\x16\x2b\xfe\x49\xe5\xb9\x92\xb5\xf3\xb4\x59\x73\xf0\xa6\xb0\xd9\x5c\xad\xd7\xb0\xe4\xf4\xdc\x35\x8e\xef\x31\x57\x0b\xec\x46\xff\x83\xe5\x68\x1a\x46\x87\xa2\xb7\x89\x4f\xdb\x5f\x7b\x64\x4a\xd1\x24\x9a\x17\xc4\x95\x8c\x0e\x70\x4c\xf8\x4e\xb5\xa4\x33\xc1\x0d\x35\xa6\x9a\xc9\x0c\x21\x86\xcd\xa6\xce\xeb\x66\xb2\x5b\xf4\x79\xb2\xac\x5c\x57\x44\xda\xf4\xed\xbf\x5b\x68\x6f\xa0\x49\xac\x0e\xfe\x4d\x4a\x45\xcc\xe6\xd4\x81\x74\x96\x4f\xc3\x7d\x50\xa5\x4e\xf0\xa6\x16\x13\x25\x6a\x46\x4a\xd7\x95\x38\x19\x20\xea\x20\x05\xb3\x9c\xe9\xd7\x54\x2d\xa5\xc7\xc5\x24\x94\x8c\xc0\xac\x27\xe3\xe9\x08\x45\x0e\x22\x64\x32\xcc\x88\x11\x2c\x79\x8d\x53\x9c\xb3\x52\x50\x4c\x9c\x04\x36\x54\x10\xe6\x85\x60\xd4\x7d\x38\xe3\x7d\x35\xd8\xc5\x29\x8d\x6d\x0f\xf9\x10\x54\xb7\x09\x05\xe2\xcd\x99\x10\x4f\x2c\x79\xf5\xf0\x06\xd3\xb7\xa0\xf0\x01\xc7\x0c\x05\x97\xaf\xc1\x19\x24\x4d\x06\x3c\x8d\xc2\x1c\x0a\x8d\xb6\xd6\x02\xad\x9d\x84\x0e\x32\x56\xf5\xe0\xc0\x94\x79\xa2\x24\xe6\xea\x85\x47\xe1\xf6\xa5\x16\xa1\x19\x87\xdf\xdc\x5c\x29\xaa\x27\x8e\x53\x83\xae\x79\x61\x6f\x2d\x2d\x69\xd5\xba\xf8\x0d\x6d\x5c\x39\xfa\x88\x89\xe0\x28\xe9\xf4\x82\xdc\x87\xb8\x9b\x8a\xa7\x69\xe6\xe3\x72\x69\x88\xc9\x04\xcd\x00\xae\xd7\xc1\xe3\xfd\xac\xaa\xc2\x64\x28\x39\xb6\xc0\x39\x1a\xc3\xb2\xd3\x9e\x6f\x0f\xcc\x57\xa8\x19\x78\x7b\x1a\xda\xe0\x84\x9b\xf4\xe6\x6b\x67\x80\x4f\xe1\x7b\xb8\xb6\x8d\xb3\x5a\x84\x7a\xb1\x6a\x9d\x87\x19\xe9\xee\x02\xaa\x20\xd7\xce\x1d\x0d\xc4\xbb\x47\xa3\xc4\x02\xd3\x5e\xc4\xed\x72\x78\xcc\xad\x87\x17\xf5\x3a\x84\x52\x53\xf5\xf1\xf1\xd5\xd4\x51\x7d\x89\xc9\x33\xa3\xb1\x9a\x4f\x2e\xfa\x1d\xd0\xcf\xdd\x28\x3f\x6a\xe1\xe1\x0d\xea\xb3\x47\xf5\x9e\x3e\xa4\x66\x76\x58\xee\xed\xa4\xbe\x79\xc1\x34\xad\x46\xd8\x13\xcb\x42\xad\x59\x86\x92\x66\xfd\x11\xd7\xad\xaf\x05\x4f\x48\x69\x55\x98\x5d\xd9\x12\x23\x9c\x75\x0b\xed\x52\x4b\xe3\x7a\x81\xcf\x2a\x4a\xe2\xb4\x9a\xa5\xdc\x14\x82\xad\x66\x7b\x76\x53\xc7\x1b\xb7\x8f\x9c\x2b\xc9\x49\x59\x42\x66\xa4\x94\x18\x39\x12\x3b\xb3\xab\x34\xcf\x6a\x81\xfa\x0c\xfb\x47\x0f\xea\xbf\xaf\xa7\xf3\x94\x53\x78\x35\x9d\xaf\x98\xfc\x2d\xfd\x21\x26\x77\x7b\xba\x31\x33\xc5\xdd\xcd\x49\xe7\x61\xdf\xbd\xa6\x8f\x7f\x47\x70\x70\x2e\xf2\x8e\x91\xd7\x65\x91\x50\x60\xa6\x59\x3e\x44\xe5\xff\x96\x94\x94\x9b\x44\xe9\x74\xb7\x37\x57\x92\x76\xdb\x7d\xbf\x14\xfb\xf6\xa7\x37\xae\x3e\xd2\x45\x0d\xbb\xad\x78\xc2\xf7\xcb\xa3\xfe\x69\x1e\x73\x43\xc8\x72\xb7\xf9\xe6\x39\xd3\xab\x93\xea\xb4\x8f\x75\x7a\xc5\x7b\x48\xcd\x49\x40\x88\x4c\x5f\x60\x94\x50\xce\xf1\xdc\xa7\x15\x6b\x43\x87\x6a\x36\x10\xfc\x04\xf1\x16\x3f\x9c\x8f\x72\x17\xeb\x42\xfa\x10\xe9\x2f\x5c\xb3\xb3\x3c\x2e\x1d\xa0\xde\x59\xc7\x85\xf3\x49\xf5\x1a\x33\xc8\x55\xa1\xb9\xd2\xdc\xbe\xa1\x5e\x37\x6f\x3b\xdf\x6d\x97\xe0\xe6\x16\xa2\x68\xfb\x12\xb4\x3d\xff\xee\xdc\xad\xf5\x01\x00\xa8\xfc\x0c\x2e\x70\xeb\xc7\x65\x8a\xef\xdb\x23\x78\x88\xb6\x97\xa2\x8e\x07\x9f\xc3\x15\xbe\x39\x8e\x51\xa2\x39\xf1\x84\x89\x68\xda\x1a\xb6\xf0\x6d\x5a\xb7\x10\xfd\xc6\xb3\xe7\x2e\x16\x0a\x83\x15\x20\x93\x69\x1f\x75\xc9\xb4\xe4\x32\x8b\xa6\x70\x25\xd1\x01\xaa\x61\xa6\x47\x62\xfd\x8e\x29\x2f\xf3\xf0\x68\x5c\xce\x95\x0d\x65\x57\x77\xa1\x8e\x86\xb9\x53\xcb\x5e\x0c\x99\xb6\x9a\xb8\xbf\xeb\x6f\x6a\x2e\x74\xc7\xad\xab\x53\x5b\x18\x5e\xec\x51\x6a\x8d\x56\x2c\x40\xb5\xb3\x2b\x17\xa4\xde\xf9\x14\x3c\xae\x62\x5f\xc9\x63\xca\xee\x90\xfa\x57\xdd\x56\xa7\x55\xf2\x8a\xd4\x3d\x36\x3a\x79\x52\x0d\x80\x31\xc1\x99\x39\xfd\xe0\x7d\x5f\x7a\x9f\xfe\x5a\x32\x00\x7c\xf8\x73\xc9\x80\xc3\xb1\x6f\x26\x43\xc9\x7b\x1f\x4e\xfe\x0d\x00\x00\xff\xff\x74\x5d\xc4\xb5\xa5\x1f\x00\x00"),
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xec\x5a\x4d\x6f\xe3\x36\x10\xbd\xfb\x57\x0c\xb4\x3d\xc4\x28\xa2\x6d\xf7\x18\x20\x28\x16\x8b\x7e\x01\x69\x51\x24\xcd\x5e\x8a\xc2\x60\xa4\xb1\xc3\x84\x22\x15\x72\x64\xc7\x50\xf4\xdf\x0b\x4a\xb2\x2c\x8a\xb2\x2d\x39\x6e\x2f\xf5\xcd\xa6\x66\xde\x0c\xdf\x1b\x71\x28\x51\x79\x0e\x31\xce\xb9\x44\x08\x66\x33\x26\x50\x53\xc2\x24\x5b\xa0\x0e\xa0\x28\x3e\xb7\xfe\xe7\x39\xa0\x8c\xa1\x28\x26\x3b\x5d\xee\x6f\x6f\xac\x57\x9e\x43\xf8\xe3\x2b\xa1\x96\x4c\xdc\xdf\xde\x40\x51\x7c\xfc\xf0\xb1\xb4\x33\x3f\x68\x8c\x90\x2f\x51\x5f\x5b\xa3\xdb\xfa\x0f\xbc\x41\xa6\xc5\x4b\x86\x7a\x5d\xb9\xd7\x81\xdc\x48\x26\x7b\x78\xc2\x88\x6c\x84\xbf\xac\xf7\x1d\x31\xca\x0c\xbc\x01\xa9\xfb\x34\x45\x5d\xb9\xf2\x39\xe0\x4b\x73\x31\x98\x73\xcd\xe5\xc2\xfa\x5c\x59\x9f\x72\x42\x26\xfc\xa9\x1c\x85\x37\x10\x28\xdb\x11\xff\x06\x6b\xf4\xb3\x56\x59\x7a\xc3\x1e\x50\x98\xf0\x4e\x69\xc2\xf8\x0f\xc6\xb5\x09\xbf\x32\x91\xa1\x0d\xf8\xa4\xb8\x84\x00\x2c\x2a\x54\x21\x17\x04\x17\x16\x2b\xfc\xa2\x92\x44\xc9\xca\x79\x5a\x8f\xb5\xf0\xa6\x50\x14\x17\x79\x0e\x2b\x4e\x8f\xae\x71\x78\x8b\x89\x5a\xa2\x1b\xfd\x77\x96\xa0\xa9\x19\xed\x8b\xde\x24\x3e\x6d\x7e\x55\xf9\x84\x7f\xea\x4c\x46\x8c\x30\xae\x66\x6c\x33\xbd\xf8\x36\xcf\x7b\x2f\x54\xd2\x80\x54\x04\xe6\x51\xad\xa4\x03\xd6\xa7\x79\x8c\x26\xd2\x3c\x25\xae\x64\xb0\x47\x30\xc2\x57\xaa\xea\x63\x26\xb8\xa1\xda\x54\x33\xb9\x40\x08\xa1\x28\xaa\x49\x5e\x4d\xb6\x83\x3e\xe9\x36\xbf\xcb\x52\x15\xcb\x85\xfd\x77\x0d\x0d\x1b\x75\x62\x55\xf0\xcf\x52\x2a\x62\x36\x27\x07\xb2\x35\x7c\x1c\xee\x9d\xca\x74\x84\x57\x55\x65\xa0\x44\xcd\x48\xe9\xaa\xac\x27\x35\xd9\x5f\x94\x10\x2c\x35\x68\xcd\x7f\x8d\x51\x12\x8f\x98\xa8\x69\xad\x1c\xdb\x16\x13\x9f\xde\x81\x0c\xce\x12\xa6\x9f\x63\xb5\x92\x1e\x95\x93\xa1\x5c\x0e\x9c\xf4\x64\x3c\x9b\x43\x91\xff\x0b\x3e\x27\xfd\x84\x1a\xc1\xa2\xe7\x30\xc6\x39\xcb\x04\x85\xc4\x49\x60\xcd\x24\x61\x92\x0a\x46\xee\x3a\x13\xee\xba\x03\x5c\x9c\xcc\xd8\x95\x2e\xe9\x83\x72\xd7\xd3\x81\x78\x73\x26\xc4\x03\x8b\x9e\x3d\xbc\xde\xf4\x2d\x28\xbc\xc1\x21\x43\xc1\xe5\xf3\xe0\x0c\xa2\x3a\x03\x1e\x07\xc3\x1c\x52\x8d\xb6\x54\x07\x5a\xb7\x12\xda\xcb\x58\xd9\x4e\x06\xa6\xcc\x23\x25\x31\x51\x4f\x3c\x18\x6e\x9f\x69\x31\x34\xe3\xe1\x93\x9b\x2b\x45\xa8\x5d\x63\xa7\x08\x53\x3b\xb5\x38\xa3\x75\xe3\xe2\x2f\xa7\xe3\xca\xd1\x47\x8c\x04\x47\x49\xc7\x17\xe4\x2e\xc4\x6d\x83\x3f\x4e\x33\x1f\x97\x4b\x43\x4c\x46\x68\x7a\x70\xbd\xfe\x11\xee\x66\x55\xa5\x66\x81\x92\x63\x03\x9c\xa0\x31\x6c\x71\xdc\xfd\xed\x81\xf9\x0a\xd5\xbd\x7b\xc7\x7a\xd8\xdb\xac\x27\x9d\xad\x82\xb3\x17\x99\xc2\x77\x70\x69\xd7\xdd\x72\x10\xaa\xc1\xab\x49\x27\x75\x9f\x11\x07\xa4\x0a\x72\xd9\x9a\x51\x4f\xbc\x5b\x34\x4a\x2c\x31\xee\x44\xdc\x0c\x0f\x8f\xb9\xf1\xf0\xa2\x5e\x0e\xa1\xd4\x94\x6d\x60\x7c\x35\x39\xaa\xaf\x30\x7a\x64\x34\x56\xf3\xc9\x59\xbf\x3d\xfa\xb5\xf7\xfc\xf7\x5a\x78\x78\xbd\xfa\xec\x50\xbd\xa3\x0f\xa9\x99\x6d\x96\x3b\x57\x52\xdf\x3c\x65\x9a\xd6\x23\xec\x89\x2d\x86\x5a\xb3\x05\x4a\x9a\x75\x5b\x9c\x5b\x5f\x4b\x1e\x91\xd2\x2a\x35\xdb\xb2\x25\x46\x38\x73\x0b\xed\x5c\x4b\xe3\xd6\x02\x9f\x55\x94\xc4\x69\x3d\x8b\xb9\x49\x05\x5b\xcf\x76\xec\xa6\x0e\x2f\xdc\x3e\x72\xa2\x24\x27\x65\x09\x99\x91\x52\x62\x64\x4b\x74\x7a\x57\x66\x1e\xd5\x12\xf5\x09\xf6\x8f\x1e\xd4\xbf\x5f\x4f\xa7\x29\xa7\xe1\xd5\x74\xba\x62\xf2\xb7\xf4\xfb\x98\xdc\xee\xe9\xc6\xf4\x94\x16\xa2\x91\xad\x9b\x7d\xfb\xc6\x61\xfc\x33\x82\x34\x67\x79\x8f\x92\xb7\xcd\x22\xa1\xc0\x85\x66\x49\x1f\x95\xff\x5b\x52\x62\x6e\x22\xa5\xe3\xed\xde\x5c\x49\xda\x6e\xf7\xfd\x52\xec\xda\x1f\xbf\x70\x75\x91\xce\x6a\xd8\x6d\xc5\x03\xbe\x9e\x6f\xf5\x77\xf3\x98\x18\x42\x96\xb4\x17\xdf\x24\x61\x7a\x7d\x54\x9d\x76\xb1\x8e\xaf\x78\x0f\xa9\x7e\x13\x30\x44\xa6\x0f\x30\x4a\xa8\xd6\xdb\xbd\x77\x2b\xd6\x84\x1e\xaa\x59\x4f\xf0\xf7\x88\xc7\x22\x5b\xed\xb3\x86\xf7\xaf\x1c\x57\xc0\x25\x1c\x3c\x50\xd8\x01\xf4\xee\xb6\x5e\xe3\x2e\x3f\x9d\xae\x26\x96\x9f\xce\x55\xb1\xbf\x2a\x9e\xb8\x66\x27\xb9\x9f\x1d\xa0\xce\xcb\x98\x33\xe7\x93\xf2\x39\xab\x97\xab\x54\x73\xa5\xb9\x7d\x84\xbe\xac\x1f\xc7\xbe\xd9\x0c\xc1\xd5\x35\x04\xc1\xe6\x29\x6d\xf3\x7e\xdf\x99\xad\xf5\x01\x00\x28\xfd\x0c\x2e\x71\xe3\xc7\x65\x8c\xaf\x9b\x23\x06\x08\x36\x97\x02\xc7\x83\xcf\xe1\x02\x5f\x5a\x8e\x41\xa4\x79\xf9\x2a\x3f\x98\x36\x86\x0d\x7c\x93\xd6\x35\x04\xbf\xf0\xc5\xa3\x8b\x85\xc2\x60\x09\xc8\x64\xdc\x45\x5d\x31\x2d\xed\x19\xdf\x14\x2e\x24\xb6\x80\x2a\x98\xe9\x81\x58\xbf\x61\xcc\xb3\x64\x78\x34\x2e\xe7\x2a\x98\x56\xa3\xdb\x50\x07\xc3\xdc\xa8\x55\x27\x86\x8c\x1b\x4d\xda\xbf\xab\xf3\xcb\x36\xb4\xe3\xe6\xea\xd4\x14\x86\x17\x7b\x94\x5a\xa3\x15\x1b\xa0\xda\xc9\x95\x1b\xa4\xde\xe9\x14\x3c\xac\x62\x57\xc9\x43\xca\x6e\x91\xba\x57\xdb\x4b\x9d\x56\xd1\x33\x92\xfb\x5e\xeb\xe8\x4e\xd5\x03\xc6\x04\x67\xe6\xf8\x93\x81\x5d\xe9\xbd\xfb\x38\xa7\x07\x78\xff\x79\x4e\x8f\xc3\xa1\x43\x9d\xbe\xe4\xbd\x93\x9d\xb6\xc3\x52\xf1\x08\xed\x69\xd8\xae\xfd\x7d\xff\xf7\x04\x50\x7d\x75\x50\x9d\x58\xee\x6f\x4f\xdf\x43\x51\x98\xee\xe1\xbd\xf3\x5d\x40\x09\x22\xeb\x53\xd5\xb9\xd2\x90\xe7\x7b\x2c\x1a\xa8\xd0\xc1\x6a\x3f\x81\xd4\x8d\xb8\xfe\x78\x61\xaf\x41\xcf\xc9\xea\x3f\x03\x00\x21\x9d\x57\xda\x30\x22\x00\x00"),
 		},
 		"/templates/email.tmpl": &vfsgen۰CompressedFileInfo{
 			name:             "email.tmpl",
@@ -18,7 +18,8 @@ import (
 )
 
 func configureAlertCmd(app *kingpin.Application) {
-	alertCmd := app.Command("alert", "Add or query alerts.").PreAction(requireAlertManagerURL)
+	alertCmd := app.Command("alert", "Add, query or resolve alerts.").PreAction(requireAlertManagerURL)
 	configureQueryAlertsCmd(alertCmd)
 	configureAddAlertCmd(alertCmd)
+	configureResolveAlertCmd(alertCmd)
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/prometheus/alertmanager/api/v2/client/alert"
 	"github.com/prometheus/alertmanager/cli/format"
 	"github.com/prometheus/alertmanager/matcher/compat"
+	"github.com/prometheus/alertmanager/matcher/parse"
 )
 
 type alertQueryCmd struct {
@@ -55,9 +56,17 @@ amtool alert query 'alertname=~foo.*'
 	(similar to prometheus) is used to represent a regex match. Regex matching
 	can be used in combination with a direct match.
 
+amtool alert query '@age>30m' '@age<1d' team=foo
+
+	Pseudo-matchers, prefixed with '@', match the properties of the alerts
+	rather than their labels: @age (the duration since the alert started),
+	@startsAt and @updatedAt (RFC3339 times) support the =, !=, >, >=, < and <=
+	operators, and @state (active, suppressed or unprocessed) supports = and !=.
+	They are evaluated by Alertmanager.
+
 Amtool supports several flags for filtering the returned alerts by state
 (inhibited, silenced, active, unprocessed). If none of these flags is given,
-only active alerts are returned.
+only active alerts are returned, unless the @state pseudo-matcher is used.
 `
 
 func configureQueryAlertsCmd(cc *kingpin.CmdClause) {
@@ -69,8 +78,8 @@ func configureQueryAlertsCmd(cc *kingpin.CmdClause) {
 	queryCmd.Flag("silenced", "Show silenced alerts").Short('s').BoolVar(&a.silenced)
 	queryCmd.Flag("active", "Show active alerts").Short('a').BoolVar(&a.active)
 	queryCmd.Flag("unprocessed", "Show unprocessed alerts").Short('u').BoolVar(&a.unprocessed)
-	queryCmd.Flag("receiver", "Show alerts matching receiver (Supports regex syntax)").Short('r').StringVar(&a.receiver)
-	queryCmd.Arg("matcher-groups", "Query filter").StringsVar(&a.matcherGroups)
+	queryCmd.Flag("receiver", "Show alerts matching receiver (Supports regex syntax)").Short('r').HintAction(completeReceivers).StringVar(&a.receiver)
+	queryCmd.Arg("matcher-groups", "Query filter").HintAction(completeMatchers).StringsVar(&a.matcherGroups)
 	queryCmd.Action(execWithTimeout(a.queryAlerts))
 }
 
@@ -82,14 +91,21 @@ func (a *alertQueryCmd) queryAlerts(ctx context.Context, _ *kingpin.ParseContext
 		// the front.
 		m := a.matcherGroups[0]
 		_, err := compat.Matcher(m, "cli")
-		if err != nil {
+		if err != nil && !parse.IsPseudoMatcher(m) {
 			a.matcherGroups[0] = fmt.Sprintf("alertname=%s", strconv.Quote(m))
 		}
 	}
 
-	// If no selector was passed, default to showing active alerts.
+	// If no selector was passed, default to showing active alerts, or all
+	// of them when the state is filtered by a pseudo-matcher.
 	if !a.silenced && !a.inhibited && !a.active && !a.unprocessed {
 		a.active = true
+		for _, m := range a.matcherGroups {
+			if pm, err := parse.ParsePseudoMatcher(m); err == nil && pm.Name == parse.PseudoState {
+				a.silenced, a.inhibited, a.unprocessed = true, true, true
+				break
+			}
+		}
 	}
 
 	alertParams := alert.NewGetAlertsParams().WithContext(ctx).
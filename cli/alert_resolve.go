@@ -0,0 +1,156 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/prometheus/alertmanager/api/v2/client/alert"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/cli/format"
+	"github.com/prometheus/alertmanager/matcher/compat"
+)
+
+type alertResolveCmd struct {
+	dryRun        bool
+	yes           bool
+	matcherGroups []string
+}
+
+const alertResolveHelp = `Resolve alerts.
+
+This command resolves all alerts matching the given filter by posting them back
+to Alertmanager with their end time set to now. This is useful for clearing
+alerts from sources that push alerts but have stopped doing so before sending
+the resolved state.
+
+	amtool alert resolve alertname=foo node=bar
+
+If alertname is omitted and the first argument does not contain a '=' or a
+'=~' then it will be assumed to be the value of the alertname pair.
+
+The matching alerts are shown and confirmation is requested before resolving
+them. Use --dry-run to only show the alerts, or --yes to skip the confirmation.
+`
+
+func configureResolveAlertCmd(cc *kingpin.CmdClause) {
+	var (
+		a          = &alertResolveCmd{}
+		resolveCmd = cc.Command("resolve", alertResolveHelp)
+	)
+	resolveCmd.Flag("dry-run", "Show the alerts that would be resolved without resolving them").BoolVar(&a.dryRun)
+	resolveCmd.Flag("yes", "Do not ask for confirmation").Short('y').BoolVar(&a.yes)
+	resolveCmd.Arg("matcher-groups", "Query filter").HintAction(completeMatchers).StringsVar(&a.matcherGroups)
+	resolveCmd.Action(execWithTimeout(a.resolveAlerts))
+}
+
+func (a *alertResolveCmd) resolveAlerts(ctx context.Context, _ *kingpin.ParseContext) error {
+	if len(a.matcherGroups) == 0 {
+		return errors.New("no matchers specified")
+	}
+	// Allow the alertname label to be defined implicitly as the first argument rather
+	// than explicitly as a key=value pair.
+	if _, err := compat.Matcher(a.matcherGroups[0], "cli"); err != nil {
+		a.matcherGroups[0] = fmt.Sprintf("alertname=%s", strconv.Quote(a.matcherGroups[0]))
+	}
+
+	yes := true
+	alertParams := alert.NewGetAlertsParams().WithContext(ctx).
+		WithActive(&yes).
+		WithInhibited(&yes).
+		WithSilenced(&yes).
+		WithUnprocessed(&yes).
+		WithFilter(a.matcherGroups)
+
+	amclient := NewAlertmanagerClient(alertmanagerURL)
+
+	getOk, err := amclient.Alert.GetAlerts(alertParams)
+	if err != nil {
+		return err
+	}
+	if len(getOk.Payload) == 0 {
+		fmt.Println("No matching alerts found")
+		return nil
+	}
+
+	formatter, found := format.Formatters[output]
+	if !found {
+		return errors.New("unknown output formatter")
+	}
+	if err := formatter.FormatAlerts(getOk.Payload); err != nil {
+		return err
+	}
+
+	if a.dryRun {
+		return nil
+	}
+	if !a.yes {
+		ok, err := confirm(os.Stdin, os.Stdout, fmt.Sprintf("Resolve %d alert(s)?", len(getOk.Payload)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("aborted")
+		}
+	}
+
+	postParams := alert.NewPostAlertsParams().WithContext(ctx).
+		WithAlerts(resolvedAlerts(getOk.Payload, time.Now()))
+	_, err = amclient.Alert.PostAlerts(postParams)
+	return err
+}
+
+// resolvedAlerts converts the alerts into postable alerts ending at the given time.
+func resolvedAlerts(alerts models.GettableAlerts, now time.Time) models.PostableAlerts {
+	endsAt := strfmt.DateTime(now.UTC())
+	res := make(models.PostableAlerts, 0, len(alerts))
+	for _, a := range alerts {
+		pa := &models.PostableAlert{
+			Alert:       a.Alert,
+			Annotations: a.Annotations,
+			EndsAt:      endsAt,
+		}
+		if a.StartsAt != nil {
+			pa.StartsAt = *a.StartsAt
+		}
+		res = append(res, pa)
+	}
+	return res
+}
+
+// confirm asks the given yes/no question and reports whether it was
+// answered with yes. An empty answer means no.
+func confirm(r io.Reader, w io.Writer, question string) (bool, error) {
+	fmt.Fprintf(w, "%s [y/N] ", question)
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		return false, scanner.Err()
+	}
+	switch strings.ToLower(strings.TrimSpace(scanner.Text())) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
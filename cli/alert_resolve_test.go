@@ -0,0 +1,64 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/api/v2/models"
+)
+
+func TestResolvedAlerts(t *testing.T) {
+	var (
+		now      = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		startsAt = strfmt.DateTime(now.Add(-time.Hour))
+		endsAt   = strfmt.DateTime(now.Add(time.Hour))
+	)
+	alerts := models.GettableAlerts{
+		{
+			Alert:       models.Alert{Labels: models.LabelSet{"alertname": "foo"}, GeneratorURL: "http://example.com"},
+			Annotations: models.LabelSet{"summary": "bar"},
+			StartsAt:    &startsAt,
+			EndsAt:      &endsAt,
+		},
+	}
+
+	res := resolvedAlerts(alerts, now)
+	require.Len(t, res, 1)
+	require.Equal(t, models.LabelSet{"alertname": "foo"}, res[0].Labels)
+	require.Equal(t, strfmt.URI("http://example.com"), res[0].GeneratorURL)
+	require.Equal(t, models.LabelSet{"summary": "bar"}, res[0].Annotations)
+	require.Equal(t, startsAt, res[0].StartsAt)
+	require.Equal(t, strfmt.DateTime(now), res[0].EndsAt)
+}
+
+func TestConfirm(t *testing.T) {
+	for input, expected := range map[string]bool{
+		"y\n":   true,
+		"YES\n": true,
+		"\n":    false,
+		"n\n":   false,
+		"":      false,
+	} {
+		ok, err := confirm(strings.NewReader(input), io.Discard, "Continue?")
+		require.NoError(t, err)
+		require.Equal(t, expected, ok, "input %q", input)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"os"
 
+	"filippo.io/age"
 	"github.com/alecthomas/kingpin/v2"
 
 	"github.com/prometheus/alertmanager/config"
@@ -25,7 +26,8 @@ import (
 
 // TODO: This can just be a type that is []string, doesn't have to be a struct.
 type checkConfigCmd struct {
-	files []string
+	files           []string
+	ageIdentityFile string
 }
 
 const checkConfigHelp = `Validate alertmanager config files
@@ -41,14 +43,26 @@ func configureCheckConfigCmd(app *kingpin.Application) {
 		checkCmd = app.Command("check-config", checkConfigHelp)
 	)
 	checkCmd.Arg("check-files", "Files to be validated").ExistingFilesVar(&c.files)
+	checkCmd.Flag("age-identity-file", "File of the age identities decrypting the values encrypted with age.").ExistingFileVar(&c.ageIdentityFile)
 	checkCmd.Action(c.checkConfig)
 }
 
 func (c *checkConfigCmd) checkConfig(ctx *kingpin.ParseContext) error {
-	return CheckConfig(c.files)
+	var identities []age.Identity
+	if c.ageIdentityFile != "" {
+		var err error
+		if identities, err = config.LoadAgeIdentities(c.ageIdentityFile); err != nil {
+			kingpin.Fatalf("Failed to load age identities: %v", err)
+		}
+	}
+	return checkConfig(c.files, identities)
 }
 
 func CheckConfig(args []string) error {
+	return checkConfig(args, nil)
+}
+
+func checkConfig(args []string, identities []age.Identity) error {
 	if len(args) == 0 {
 		stat, err := os.Stdin.Stat()
 		if err != nil {
@@ -64,7 +78,7 @@ func CheckConfig(args []string) error {
 
 	for _, arg := range args {
 		fmt.Printf("Checking '%s'", arg)
-		cfg, err := config.LoadFile(arg)
+		cfg, err := config.LoadEncryptedFile(arg, identities)
 		if err != nil {
 			fmt.Printf("  FAILED: %s\n", err)
 			failed++
@@ -0,0 +1,164 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+
+	"github.com/prometheus/alertmanager/api/v2/client"
+	"github.com/prometheus/alertmanager/api/v2/client/alert"
+	"github.com/prometheus/alertmanager/api/v2/client/receiver"
+	"github.com/prometheus/alertmanager/api/v2/client/silence"
+	"github.com/prometheus/alertmanager/api/v2/models"
+)
+
+const completionHelp = `Generate the completion script of amtool for the given shell.
+
+The completions of the silence IDs, the receivers and the label names of the
+matchers are queried from the Alertmanager given by --alertmanager.url or the
+config file.
+
+bash:
+	source <(amtool completion bash)
+
+zsh:
+	amtool completion zsh > "${fpath[1]}/_amtool"
+
+fish:
+	amtool completion fish > ~/.config/fish/completions/amtool.fish
+`
+
+// fishCompletionTemplate completes the arguments like the bash and zsh
+// completion scripts of kingpin, with the hidden --completion-bash flag.
+const fishCompletionTemplate = `function __{{.App.Name}}_complete
+    set -l args (commandline -opc)[2..-1] (commandline -ct)
+    {{.App.Name}} --completion-bash $args
+end
+
+complete -c {{.App.Name}} -f -a '(__{{.App.Name}}_complete)'
+`
+
+// completionTimeout bounds the requests of the dynamic completions, which
+// must not hang the shell.
+const completionTimeout = 2 * time.Second
+
+type completionCmd struct {
+	shell string
+}
+
+func configureCompletionCmd(app *kingpin.Application) {
+	var (
+		c             = &completionCmd{}
+		completionCmd = app.Command("completion", completionHelp)
+	)
+	completionCmd.Arg("shell", "The shell to generate the completion script for (bash, zsh, fish).").Required().EnumVar(&c.shell, "bash", "zsh", "fish")
+	completionCmd.Action(func(pc *kingpin.ParseContext) error {
+		return c.script(app, pc)
+	})
+}
+
+func (c *completionCmd) script(app *kingpin.Application, pc *kingpin.ParseContext) error {
+	tmpl := kingpin.BashCompletionTemplate
+	switch c.shell {
+	case "zsh":
+		tmpl = kingpin.ZshCompletionTemplate
+	case "fish":
+		tmpl = fishCompletionTemplate
+	}
+	return app.UsageForContextWithTemplate(pc, 0, tmpl)
+}
+
+// withCompletionClient calls f with a client of the Alertmanager, if one is
+// configured, and returns its completions. Failures return no completions.
+func withCompletionClient(f func(context.Context, *client.AlertmanagerAPI) ([]string, error)) []string {
+	if alertmanagerURL == nil {
+		return nil
+	}
+	// The version check would slow down every completion.
+	versionCheck = false
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	res, err := f(ctx, NewAlertmanagerClient(alertmanagerURL))
+	if err != nil {
+		return nil
+	}
+	return res
+}
+
+// completeSilenceIDs returns the IDs of the active and pending silences.
+func completeSilenceIDs() []string {
+	return withCompletionClient(func(ctx context.Context, amclient *client.AlertmanagerAPI) ([]string, error) {
+		res, err := amclient.Silence.GetSilences(silence.NewGetSilencesParams().WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		var ids []string
+		for _, s := range res.Payload {
+			if s.ID == nil || s.Status == nil || s.Status.State == nil || *s.Status.State == models.SilenceStatusStateExpired {
+				continue
+			}
+			ids = append(ids, *s.ID)
+		}
+		return ids, nil
+	})
+}
+
+// completeReceivers returns the names of the receivers.
+func completeReceivers() []string {
+	return withCompletionClient(func(ctx context.Context, amclient *client.AlertmanagerAPI) ([]string, error) {
+		res, err := amclient.Receiver.GetReceivers(receiver.NewGetReceiversParams().WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, r := range res.Payload {
+			if r.Name != nil {
+				names = append(names, *r.Name)
+			}
+		}
+		return names, nil
+	})
+}
+
+// completeMatchers returns the beginning of the equality matchers of the
+// label names of the current alerts.
+func completeMatchers() []string {
+	return withCompletionClient(func(ctx context.Context, amclient *client.AlertmanagerAPI) ([]string, error) {
+		all := true
+		res, err := amclient.Alert.GetAlerts(alert.NewGetAlertsParams().WithContext(ctx).
+			WithActive(&all).
+			WithSilenced(&all).
+			WithInhibited(&all).
+			WithUnprocessed(&all))
+		if err != nil {
+			return nil, err
+		}
+		seen := map[string]struct{}{}
+		for _, a := range res.Payload {
+			for name := range a.Labels {
+				seen[name] = struct{}{}
+			}
+		}
+		matchers := make([]string, 0, len(seen))
+		for name := range seen {
+			matchers = append(matchers, name+"=")
+		}
+		sort.Strings(matchers)
+		return matchers, nil
+	})
+}
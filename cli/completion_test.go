@@ -0,0 +1,65 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/silences", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"id": "active", "status": {"state": "active"}},
+			{"id": "pending", "status": {"state": "pending"}},
+			{"id": "expired", "status": {"state": "expired"}}
+		]`))
+	})
+	mux.HandleFunc("/api/v2/receivers", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"name": "team-X"}, {"name": "team-Y"}]`))
+	})
+	mux.HandleFunc("/api/v2/alerts", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"labels": {"alertname": "DiskFull", "instance": "a"}},
+			{"labels": {"alertname": "NodeDown", "job": "node"}}
+		]`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	defer func(u *url.URL, v bool) { alertmanagerURL, versionCheck = u, v }(alertmanagerURL, versionCheck)
+
+	// Without Alertmanager, there are no completions.
+	alertmanagerURL = nil
+	require.Empty(t, completeSilenceIDs())
+
+	var err error
+	alertmanagerURL, err = url.Parse(srv.URL)
+	require.NoError(t, err)
+	require.Equal(t, []string{"active", "pending"}, completeSilenceIDs())
+	require.Equal(t, []string{"team-X", "team-Y"}, completeReceivers())
+	require.Equal(t, []string{"alertname=", "instance=", "job="}, completeMatchers())
+
+	// Failures return no completions.
+	srv.Close()
+	require.Empty(t, completeReceivers())
+}
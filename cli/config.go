@@ -19,6 +19,7 @@ import (
 
 	kingpin "github.com/alecthomas/kingpin/v2"
 
+	"github.com/prometheus/alertmanager/api/v2/client/general"
 	"github.com/prometheus/alertmanager/cli/format"
 )
 
@@ -28,20 +29,42 @@ The amount of output is controlled by the output selection flag:
 	- Simple: Print just the running config
 	- Extended: Print the running config as well as uptime and all version info
 	- Json: Print entire config object as json
+
+With --effective, the config is fetched with the defaults and the options
+inherited by the routes set explicitly, as used by the Alertmanager.
 `
 
+type configShowCmd struct {
+	effective bool
+}
+
 // configureConfigCmd represents the config command.
 func configureConfigCmd(app *kingpin.Application) {
 	configCmd := app.Command("config", configHelp)
-	configCmd.Command("show", configHelp).Default().Action(execWithTimeout(queryConfig)).PreAction(requireAlertManagerURL)
+	var (
+		c       = &configShowCmd{}
+		showCmd = configCmd.Command("show", configHelp).Default()
+	)
+	showCmd.Flag("effective", "Show the effective config with the computed defaults.").BoolVar(&c.effective)
+	showCmd.Action(execWithTimeout(c.queryConfig)).PreAction(requireAlertManagerURL)
 	configureRoutingCmd(configCmd)
+	configureEncryptSecretCmd(configCmd)
+	configureConfigTestCmd(configCmd)
 }
 
-func queryConfig(ctx context.Context, _ *kingpin.ParseContext) error {
+func (c *configShowCmd) queryConfig(ctx context.Context, _ *kingpin.ParseContext) error {
 	status, err := getRemoteAlertmanagerConfigStatus(ctx, alertmanagerURL)
 	if err != nil {
 		return err
 	}
+	if c.effective {
+		amclient := NewAlertmanagerClient(alertmanagerURL)
+		getOk, err := amclient.General.GetEffectiveConfig(general.NewGetEffectiveConfigParams().WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		status.Config = getOk.Payload
+	}
 
 	formatter, found := format.Formatters[output]
 	if !found {
@@ -76,9 +76,11 @@ func (c *Resolver) setDefault(v getFlagger) {
 
 // Bind sets active flags with their default values from the configuration file(s).
 func (c *Resolver) Bind(app *kingpin.Application, args []string) error {
-	// Parse the command line arguments to get the selected command.
+	// Parse the command line arguments to get the selected command. The
+	// parse errors are reported when parsing the arguments for good, and
+	// partial command lines are parsed for completions.
 	pc, err := app.ParseContext(args)
-	if err != nil {
+	if pc == nil {
 		return err
 	}
 
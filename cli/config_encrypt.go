@@ -0,0 +1,75 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+
+	"github.com/prometheus/alertmanager/config"
+)
+
+const encryptSecretHelp = `Encrypt a secret value of the configuration with age.
+
+The encrypted value replaces the plaintext in the configuration file, e.g.:
+
+	amtool config encrypt-secret --recipient=age1... 'xoxb-token'
+
+The value is read from standard input if not given. The Alertmanager decrypts
+it at load time with the identity set by --config.age-identity-file.
+`
+
+type encryptSecretCmd struct {
+	recipients []string
+	value      string
+}
+
+func configureEncryptSecretCmd(app *kingpin.CmdClause) {
+	var (
+		c   = &encryptSecretCmd{}
+		cmd = app.Command("encrypt-secret", encryptSecretHelp)
+	)
+	cmd.Flag("recipient", "age recipient the value is encrypted for, can be repeated.").Required().StringsVar(&c.recipients)
+	cmd.Arg("value", "Value to encrypt, read from standard input if not given.").StringVar(&c.value)
+	cmd.Action(c.encrypt)
+}
+
+func (c *encryptSecretCmd) encrypt(_ *kingpin.ParseContext) error {
+	recipients, err := config.LoadAgeRecipients(c.recipients)
+	if err != nil {
+		return err
+	}
+	value := c.value
+	if value == "" {
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("read value: %w", err)
+		}
+		value = strings.TrimSuffix(string(b), "\n")
+	}
+	if value == "" {
+		return errors.New("empty value")
+	}
+	encrypted, err := config.EncryptSecret(value, recipients)
+	if err != nil {
+		return err
+	}
+	fmt.Println(encrypted)
+	return nil
+}
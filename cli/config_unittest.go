@@ -0,0 +1,221 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v2"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/simulation"
+)
+
+const configTestHelp = `Unit test the routing of a configuration
+
+Each test file references a configuration file and lists tests. A test gives
+alerts sent at times relative to its start and the notifications expected to
+be sent to the receivers at these times:
+
+	config_file: alertmanager.yml
+	tests:
+	- name: database alerts page the DBA team
+	  alerts:
+	  - labels: {alertname: DatabaseDown, service: database}
+	    at: 0s
+	    resolve_at: 10m
+	  expected_notifications:
+	  - at: 30s
+	    receiver: team-DB-pager
+	    firing: [{alertname: DatabaseDown, service: database}]
+	  - at: 10m30s
+	    receiver: team-DB-pager
+	    resolved: [{alertname: DatabaseDown, service: database}]
+
+The alerts are routed by an in-process dispatcher on a simulated clock,
+honoring group_wait, group_interval, repeat_interval, send_resolved and the
+time intervals of the routes. Like in the Alertmanager, reminders are sent at
+the first flush after repeat_interval has passed. Inhibition rules and
+silences are not applied, so inhibited alerts are expected to be notified.
+Alerts without resolve_at keep firing until the end of the test. The test
+fails if any expected notification is missing or any other notification is
+sent until the last time of the test, or until its duration if longer.
+`
+
+type configTestCmd struct {
+	files []string
+}
+
+func configureConfigTestCmd(app *kingpin.CmdClause) {
+	var (
+		c   = &configTestCmd{}
+		cmd = app.Command("test", configTestHelp)
+	)
+	cmd.Arg("test-files", "Test files to run.").Required().ExistingFilesVar(&c.files)
+	cmd.Action(c.test)
+}
+
+func (c *configTestCmd) test(_ *kingpin.ParseContext) error {
+	failed := 0
+	for _, f := range c.files {
+		fmt.Printf("Unit testing '%s'\n", f)
+		errs, err := runRoutesTestFile(f)
+		if err != nil {
+			fmt.Printf("  FAILED: %s\n", err)
+			failed++
+			continue
+		}
+		if len(errs) > 0 {
+			fmt.Printf("  FAILED:\n")
+			for _, e := range errs {
+				fmt.Printf("    %s\n", e)
+			}
+			failed++
+			continue
+		}
+		fmt.Printf("  SUCCESS\n")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d test file(s) failed", failed)
+	}
+	return nil
+}
+
+// routesTestFile is a file of unit tests of the routing of a configuration.
+type routesTestFile struct {
+	// ConfigFile is the configuration to test, relative paths are relative
+	// to the test file.
+	ConfigFile string       `yaml:"config_file"`
+	Tests      []routesTest `yaml:"tests"`
+}
+
+type routesTest struct {
+	Name                  string                 `yaml:"name"`
+	Scenario              simulation.Scenario    `yaml:",inline"`
+	ExpectedNotifications []expectedNotification `yaml:"expected_notifications"`
+}
+
+type expectedNotification struct {
+	At       model.Duration `yaml:"at"`
+	Receiver string         `yaml:"receiver"`
+	// GroupLabels are only checked if set.
+	GroupLabels model.LabelSet   `yaml:"group_labels,omitempty"`
+	Firing      []model.LabelSet `yaml:"firing,omitempty"`
+	Resolved    []model.LabelSet `yaml:"resolved,omitempty"`
+}
+
+// runRoutesTestFile runs the tests of the file and returns the failures.
+func runRoutesTestFile(filename string) ([]string, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var tf routesTestFile
+	if err := yaml.UnmarshalStrict(b, &tf); err != nil {
+		return nil, err
+	}
+	if tf.ConfigFile == "" {
+		return nil, errors.New("missing config_file")
+	}
+	configFile := tf.ConfigFile
+	if !filepath.IsAbs(configFile) {
+		configFile = filepath.Join(filepath.Dir(filename), configFile)
+	}
+	cfg, err := config.LoadFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var failures []string
+	for i, t := range tf.Tests {
+		name := t.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		// Run the scenario until the last expected notification, at least,
+		// to catch unexpected notifications before it.
+		for _, en := range t.ExpectedNotifications {
+			t.Scenario.Duration = max(t.Scenario.Duration, en.At)
+		}
+		sent, err := simulation.Run(cfg, &t.Scenario)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range compareNotifications(t.Scenario.Start(), t.ExpectedNotifications, sent) {
+			failures = append(failures, fmt.Sprintf("test %q: %s", name, f))
+		}
+	}
+	return failures, nil
+}
+
+// compareNotifications returns the expected notifications which weren't sent
+// and the sent notifications which weren't expected.
+func compareNotifications(start time.Time, expected []expectedNotification, sent []*simulation.Notification) []string {
+	var (
+		failures []string
+		matched  = make([]bool, len(sent))
+	)
+	for _, en := range expected {
+		found := false
+		for i, n := range sent {
+			if matched[i] || !notificationMatches(start, en, n) {
+				continue
+			}
+			matched[i], found = true, true
+			break
+		}
+		if !found {
+			failures = append(failures, fmt.Sprintf("missing notification at %s to %q: %s",
+				en.At, en.Receiver, formatAlertLabels(en.Firing, en.Resolved)))
+		}
+	}
+	for i, n := range sent {
+		if matched[i] {
+			continue
+		}
+		failures = append(failures, fmt.Sprintf("unexpected notification at %s to %q for group %s: %s",
+			model.Duration(n.Time.Sub(start)), n.Receiver, n.GroupLabels, formatAlertLabels(n.Firing, n.Resolved)))
+	}
+	return failures
+}
+
+func notificationMatches(start time.Time, en expectedNotification, n *simulation.Notification) bool {
+	if !n.Time.Equal(start.Add(time.Duration(en.At))) || n.Receiver != en.Receiver {
+		return false
+	}
+	if en.GroupLabels != nil && !n.GroupLabels.Equal(en.GroupLabels) {
+		return false
+	}
+	return formatAlertLabels(n.Firing, n.Resolved) == formatAlertLabels(en.Firing, en.Resolved)
+}
+
+func formatAlertLabels(firing, resolved []model.LabelSet) string {
+	format := func(lsets []model.LabelSet) string {
+		s := make([]string, 0, len(lsets))
+		for _, ls := range lsets {
+			s = append(s, ls.String())
+		}
+		sort.Strings(s)
+		return "[" + strings.Join(s, ", ") + "]"
+	}
+	return fmt.Sprintf("firing %s, resolved %s", format(firing), format(resolved))
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunRoutesTestFile(t *testing.T) {
+	failures, err := runRoutesTestFile("testdata/routes.test.yml")
+	require.NoError(t, err)
+	require.Empty(t, failures)
+}
+
+func TestRunRoutesTestFileFailures(t *testing.T) {
+	conf, err := filepath.Abs("testdata/conf.unittest.yml")
+	require.NoError(t, err)
+	f := filepath.Join(t.TempDir(), "routes.test.yml")
+	require.NoError(t, os.WriteFile(f, []byte(`
+config_file: `+conf+`
+tests:
+  - name: wrong receiver
+    alerts:
+      - labels: {alertname: DatabaseDown, service: database}
+    expected_notifications:
+      - at: 30s
+        receiver: default
+        firing: [{alertname: DatabaseDown, service: database}]
+`), 0o644))
+
+	failures, err := runRoutesTestFile(f)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`test "wrong receiver": missing notification at 30s to "default": firing [{alertname="DatabaseDown", service="database"}], resolved []`,
+		`test "wrong receiver": unexpected notification at 30s to "team-DB-pager" for group {alertname="DatabaseDown"}: firing [{alertname="DatabaseDown", service="database"}], resolved []`,
+	}, failures)
+}
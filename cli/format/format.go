@@ -39,6 +39,7 @@ type Formatter interface {
 	FormatAlerts([]*models.GettableAlert) error
 	FormatConfig(*models.AlertmanagerStatus) error
 	FormatClusterStatus(status *models.ClusterStatus) error
+	FormatTimeIntervalTest(results models.TimeIntervalTestResults) error
 }
 
 // Formatters is a map of cli argument names to formatter interface object.
@@ -48,6 +49,11 @@ func FormatDate(input strfmt.DateTime) string {
 	return time.Time(input).Format(*dateFormat)
 }
 
+// FormatMatchers formats the matchers like in the simple output.
+func FormatMatchers(matchers models.Matchers) string {
+	return simpleFormatMatchers(matchers)
+}
+
 func labelsMatcher(m models.Matcher) *labels.Matcher {
 	var t labels.MatchType
 	// Support for older alertmanager releases, which did not support isEqual.
@@ -20,6 +20,7 @@ import (
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/prometheus/alertmanager/api/v2/models"
 	"github.com/prometheus/alertmanager/pkg/labels"
@@ -99,14 +100,39 @@ func (formatter *ExtendedFormatter) FormatClusterStatus(status *models.ClusterSt
 		*status.Status,
 		status.Name,
 	)
-	fmt.Fprintln(w, "Address\tName")
+	divergent := make(map[string]struct{}, len(status.DivergentPeers))
+	for _, name := range status.DivergentPeers {
+		divergent[name] = struct{}{}
+	}
+	fmt.Fprintln(w, "Address\tName\tDivergent")
 	sort.Sort(ByAddress(status.Peers))
 	for _, peer := range status.Peers {
+		_, ok := divergent[*peer.Name]
 		fmt.Fprintf(
 			w,
-			"%s\t%s\t\n",
+			"%s\t%s\t%t\t\n",
 			*peer.Address,
 			*peer.Name,
+			ok,
+		)
+	}
+	return w.Flush()
+}
+
+// FormatTimeIntervalTest formats the results of a time interval test with
+// the weekday of each time, in its own time zone and in UTC.
+func (formatter *ExtendedFormatter) FormatTimeIntervalTest(results models.TimeIntervalTestResults) error {
+	w := tabwriter.NewWriter(formatter.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Time\tWeekday\tUTC\tActive\t")
+	for _, r := range results {
+		t := time.Time(*r.Time)
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%t\t\n",
+			FormatDate(*r.Time),
+			t.Weekday(),
+			t.UTC().Format(time.RFC3339),
+			*r.Active,
 		)
 	}
 	return w.Flush()
@@ -86,6 +86,15 @@ func (formatter *SimpleFormatter) FormatClusterStatus(status *models.ClusterStat
 	return w.Flush()
 }
 
+func (formatter *SimpleFormatter) FormatTimeIntervalTest(results models.TimeIntervalTestResults) error {
+	w := tabwriter.NewWriter(formatter.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Time\tActive\t")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%t\t\n", FormatDate(*r.Time), *r.Active)
+	}
+	return w.Flush()
+}
+
 func simpleFormatMatchers(matchers models.Matchers) string {
 	output := []string{}
 	for _, matcher := range matchers {
@@ -0,0 +1,252 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/common/promslog"
+
+	"github.com/prometheus/alertmanager/matcher/compat"
+	"github.com/prometheus/alertmanager/matcher/parse"
+	"github.com/prometheus/alertmanager/pkg/labels"
+)
+
+const matcherHelp = `Parse and explain label matchers.`
+
+const matcherParseHelp = `Parse label matchers.
+
+The matchers are parsed the way the Alertmanager parses them, depending on the
+utf8-strict-mode and classic-mode feature flags, and printed in their
+normalized form. With --file, the matchers of the file are validated instead,
+one list of matchers per line, ignoring empty lines and lines starting with #.
+Only the invalid lines are printed.
+
+	amtool matcher parse 'foo=bar' '{env=~"prod|staging"}'
+	amtool matcher parse --file=matchers.txt
+`
+
+const matcherExplainHelp = `Explain how label matchers are parsed.
+
+Prints the tokens of the input, the matchers parsed by the UTF-8 and the
+classic parsers, and how their results differ. The Alertmanager falls back to
+the classic parser when the input is only valid with it, or when the parsers
+disagree, unless the utf8-strict-mode feature flag is enabled.
+
+	amtool matcher explain '{foo="bar", env=~prod.*}'
+`
+
+type matcherParseCmd struct {
+	inputs []string
+	file   string
+}
+
+type matcherExplainCmd struct {
+	input string
+}
+
+// configureMatcherCmd represents the matcher command.
+func configureMatcherCmd(app *kingpin.Application) {
+	var (
+		parseCmd   = &matcherParseCmd{}
+		explainCmd = &matcherExplainCmd{}
+		matcherCmd = app.Command("matcher", matcherHelp)
+		pc         = matcherCmd.Command("parse", matcherParseHelp)
+		ec         = matcherCmd.Command("explain", matcherExplainHelp)
+	)
+	pc.Arg("matchers", "Lists of matchers to parse.").StringsVar(&parseCmd.inputs)
+	pc.Flag("file", "File with a list of matchers per line to validate.").ExistingFileVar(&parseCmd.file)
+	pc.Action(parseCmd.parse)
+
+	ec.Arg("matchers", "List of matchers to explain.").Required().StringVar(&explainCmd.input)
+	ec.Action(explainCmd.explain)
+}
+
+// matcherInput is a list of matchers to parse and where it comes from.
+type matcherInput struct {
+	origin string
+	input  string
+}
+
+func (c *matcherParseCmd) parse(_ *kingpin.ParseContext) error {
+	if c.file != "" {
+		f, err := os.Open(c.file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		inputs, err := readMatcherInputs(f, c.file)
+		if err != nil {
+			return err
+		}
+		return parseMatcherInputs(os.Stdout, inputs, false)
+	}
+	if len(c.inputs) == 0 {
+		return fmt.Errorf("no matchers to parse")
+	}
+	inputs := make([]matcherInput, 0, len(c.inputs))
+	for _, in := range c.inputs {
+		inputs = append(inputs, matcherInput{origin: fmt.Sprintf("%q", in), input: in})
+	}
+	return parseMatcherInputs(os.Stdout, inputs, true)
+}
+
+// readMatcherInputs reads a list of matchers per line, ignoring empty lines
+// and comments.
+func readMatcherInputs(r io.Reader, name string) ([]matcherInput, error) {
+	var (
+		inputs []matcherInput
+		s      = bufio.NewScanner(r)
+	)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		inputs = append(inputs, matcherInput{origin: fmt.Sprintf("%s:%d", name, n), input: line})
+	}
+	return inputs, s.Err()
+}
+
+// parseMatcherInputs parses the inputs and prints the errors, and the
+// normalized matchers if printValid is true.
+func parseMatcherInputs(w io.Writer, inputs []matcherInput, printValid bool) error {
+	invalid := 0
+	for _, in := range inputs {
+		ms, err := compat.Matchers(in.input, "amtool")
+		if err != nil {
+			invalid++
+			fmt.Fprintf(w, "%s: %s\n", in.origin, err)
+			continue
+		}
+		if printValid {
+			fmt.Fprintln(w, ms)
+		}
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d lists of matchers are invalid", invalid, len(inputs))
+	}
+	return nil
+}
+
+// matcherExplanation is how a list of matchers is parsed.
+type matcherExplanation struct {
+	Input      string        `json:"input"`
+	Tokens     []parse.Token `json:"tokens"`
+	TokenError string        `json:"tokenError,omitempty"`
+	UTF8       parserResult  `json:"utf8"`
+	Classic    parserResult  `json:"classic"`
+	Summary    string        `json:"summary"`
+}
+
+// parserResult is the result of a matchers parser.
+type parserResult struct {
+	Matchers []parsedMatcher `json:"matchers"`
+	String   string          `json:"string,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+type parsedMatcher struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+func newParserResult(ms labels.Matchers, err error) parserResult {
+	res := parserResult{Matchers: []parsedMatcher{}}
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	for _, m := range ms {
+		res.Matchers = append(res.Matchers, parsedMatcher{Name: m.Name, Type: m.Type.String(), Value: m.Value})
+	}
+	res.String = ms.String()
+	return res
+}
+
+func explainMatchers(input string) matcherExplanation {
+	res := matcherExplanation{Input: input}
+	tokens, err := parse.Tokens(input)
+	res.Tokens = tokens
+	if res.Tokens == nil {
+		res.Tokens = []parse.Token{}
+	}
+	if err != nil {
+		res.TokenError = err.Error()
+	}
+
+	logger := promslog.NewNopLogger()
+	res.UTF8 = newParserResult(compat.UTF8MatchersParser(logger)(input, "amtool"))
+	res.Classic = newParserResult(compat.ClassicMatchersParser(logger)(input, "amtool"))
+
+	switch {
+	case res.UTF8.Error != "" && res.Classic.Error != "":
+		res.Summary = "The input is invalid with both parsers."
+	case res.UTF8.Error != "":
+		res.Summary = fmt.Sprintf("The input is only valid with the classic parser. Double-quote the values and regular expressions and escape the backslashes to make it compatible with the UTF-8 parser, such as: %s", res.Classic.String)
+	case res.Classic.Error != "":
+		res.Summary = "The input is only valid with the UTF-8 parser."
+	case res.UTF8.String != res.Classic.String:
+		res.Summary = "The parsers disagree on the meaning of the input. The classic parser is used unless the utf8-strict-mode feature flag is enabled."
+	default:
+		res.Summary = "The parsers agree."
+	}
+	return res
+}
+
+func (c *matcherExplainCmd) explain(_ *kingpin.ParseContext) error {
+	return printMatcherExplanation(os.Stdout, explainMatchers(c.input))
+}
+
+func printMatcherExplanation(w io.Writer, e matcherExplanation) error {
+	if output == "json" {
+		return json.NewEncoder(w).Encode(e)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Tokens:")
+	for _, t := range e.Tokens {
+		fmt.Fprintf(tw, "  %d:%d\t%s\t%s\n", t.ColumnStart, t.ColumnEnd, t.Kind, t.Value)
+	}
+	if e.TokenError != "" {
+		fmt.Fprintf(tw, "  error: %s\n", e.TokenError)
+	}
+	for _, p := range []struct {
+		name string
+		res  parserResult
+	}{
+		{"UTF-8 parser", e.UTF8},
+		{"Classic parser", e.Classic},
+	} {
+		fmt.Fprintf(tw, "\n%s:\n", p.name)
+		if p.res.Error != "" {
+			fmt.Fprintf(tw, "  error: %s\n", p.res.Error)
+			continue
+		}
+		fmt.Fprintf(tw, "  %s\n", p.res.String)
+		for _, m := range p.res.Matchers {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\n", m.Name, m.Type, m.Value)
+		}
+	}
+	fmt.Fprintf(tw, "\n%s\n", e.Summary)
+	return tw.Flush()
+}
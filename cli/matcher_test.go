@@ -0,0 +1,102 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainMatchers(t *testing.T) {
+	for _, tc := range []struct {
+		input   string
+		summary string
+	}{
+		{
+			input:   `{foo="bar", env=~"prod.*"}`,
+			summary: "The parsers agree.",
+		},
+		{
+			input:   `foo=bar\`,
+			summary: `The input is only valid with the classic parser. Double-quote the values and regular expressions and escape the backslashes to make it compatible with the UTF-8 parser, such as: {foo="bar\\"}`,
+		},
+		{
+			input:   `"foo🙂"="bar"`,
+			summary: "The input is only valid with the UTF-8 parser.",
+		},
+		{
+			input:   `=bar`,
+			summary: "The input is invalid with both parsers.",
+		},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			require.Equal(t, tc.summary, explainMatchers(tc.input).Summary)
+		})
+	}
+
+	e := explainMatchers(`{foo="bar", env=~prod.*}`)
+	var buf bytes.Buffer
+	require.NoError(t, printMatcherExplanation(&buf, e))
+	require.Equal(t, `Tokens:
+  0:1    OpenBrace   {
+  1:4    Unquoted    foo
+  4:5    Equals      =
+  5:10   Quoted      "bar"
+  10:11  Comma       ,
+  12:15  Unquoted    env
+  15:17  Matches     =~
+  17:23  Unquoted    prod.*
+  23:24  CloseBrace  }
+
+UTF-8 parser:
+  {foo="bar",env=~"prod.*"}
+  foo  =   bar
+  env  =~  prod.*
+
+Classic parser:
+  {foo="bar",env=~"prod.*"}
+  foo  =   bar
+  env  =~  prod.*
+
+The parsers agree.
+`, buf.String())
+}
+
+func TestParseMatcherInputs(t *testing.T) {
+	inputs, err := readMatcherInputs(strings.NewReader(`# Matchers of the team.
+foo=bar
+
+{env=~"prod|staging"}
+foo=~"unterminated
+`), "matchers.txt")
+	require.NoError(t, err)
+	require.Equal(t, []matcherInput{
+		{origin: "matchers.txt:2", input: "foo=bar"},
+		{origin: "matchers.txt:4", input: `{env=~"prod|staging"}`},
+		{origin: "matchers.txt:5", input: `foo=~"unterminated`},
+	}, inputs)
+
+	var buf bytes.Buffer
+	err = parseMatcherInputs(&buf, inputs, false)
+	require.EqualError(t, err, "1 of 3 lists of matchers are invalid")
+	require.True(t, strings.HasPrefix(buf.String(), "matchers.txt:5: "), buf.String())
+	require.Equal(t, 1, strings.Count(buf.String(), "\n"))
+
+	buf.Reset()
+	require.NoError(t, parseMatcherInputs(&buf, inputs[:2], true))
+	require.Equal(t, "{foo=\"bar\"}\n{env=~\"prod|staging\"}\n", buf.String())
+}
@@ -172,6 +172,11 @@ func Execute() {
 	configureClusterCmd(app)
 	configureConfigCmd(app)
 	configureTemplateCmd(app)
+	configureTimeIntervalCmd(app)
+	configureMatcherCmd(app)
+	configureStorageCmd(app)
+	configureCompletionCmd(app)
+	configureTUICmd(app)
 
 	app.Action(initMatchersCompat)
 
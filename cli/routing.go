@@ -17,6 +17,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/xlab/treeprint"
@@ -90,6 +91,9 @@ func getRouteTreeSlug(route *dispatch.Route, showContinue, showReceiver bool) st
 		branchSlug.WriteString(branchSlugSeparator)
 		branchSlug.WriteString("receiver: ")
 		branchSlug.WriteString(route.RouteOpts.Receiver)
+		if ln := route.RouteOpts.ReceiverFromLabel; ln != "" {
+			fmt.Fprintf(&branchSlug, " or %s from %s", strings.Join(route.RouteOpts.AllowedReceivers, ", "), ln)
+		}
 	}
 	return branchSlug.String()
 }
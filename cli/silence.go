@@ -23,6 +23,7 @@ func configureSilenceCmd(app *kingpin.Application) {
 	configureSilenceAddCmd(silenceCmd)
 	configureSilenceExpireCmd(silenceCmd)
 	configureSilenceImportCmd(silenceCmd)
+	configureSilenceMigrateCmd(silenceCmd)
 	configureSilenceQueryCmd(silenceCmd)
 	configureSilenceUpdateCmd(silenceCmd)
 }
@@ -14,17 +14,24 @@
 package cli
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/user"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/go-openapi/strfmt"
 	"github.com/prometheus/common/model"
 
+	"github.com/prometheus/alertmanager/api/v2/client/alert"
 	"github.com/prometheus/alertmanager/api/v2/client/silence"
 	"github.com/prometheus/alertmanager/api/v2/models"
 	"github.com/prometheus/alertmanager/matcher/compat"
@@ -40,15 +47,21 @@ func username() string {
 }
 
 type silenceAddCmd struct {
-	author         string
-	requireComment bool
-	duration       string
-	start          string
-	end            string
-	comment        string
-	matchers       []string
+	author          string
+	requireComment  bool
+	duration        string
+	start           string
+	end             string
+	comment         string
+	matchers        []string
+	fromAlert       string
+	labels          []string
+	interactive     bool
+	expireOnResolve bool
 }
 
+var fingerprintRegexp = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
 const silenceAddHelp = `Add a new alertmanager silence
 
   Amtool uses a simplified Prometheus syntax to represent silences. The
@@ -71,6 +84,18 @@ const silenceAddHelp = `Add a new alertmanager silence
 	As well as direct equality, regex matching is also supported. The '=~' syntax
 	(similar to Prometheus) is used to represent a regex match. Regex matching
 	can be used in combination with a direct match.
+
+  amtool silence add --from-alert 5e6fa6a0cc1d7c0b
+
+	Instead of typing matchers by hand, they can be derived from the labels of
+	a currently firing alert. The argument is either an alert fingerprint or a
+	filter such as '{alertname="foo",node="bar"}'. If the filter selects
+	several alerts, only the labels they have in common are used.
+
+  amtool silence add --from-alert 'alertname=foo' --labels alertname --labels cluster
+
+	The --labels flag restricts the derived matchers to the given label names.
+	Alternatively, --interactive prompts for each label in turn.
 `
 
 func configureSilenceAddCmd(cc *kingpin.CmdClause) {
@@ -84,7 +109,11 @@ func configureSilenceAddCmd(cc *kingpin.CmdClause) {
 	addCmd.Flag("start", "Set when the silence should start. RFC3339 format 2006-01-02T15:04:05-07:00").StringVar(&c.start)
 	addCmd.Flag("end", "Set when the silence should end (overwrites duration). RFC3339 format 2006-01-02T15:04:05-07:00").StringVar(&c.end)
 	addCmd.Flag("comment", "A comment to help describe the silence").Short('c').StringVar(&c.comment)
-	addCmd.Arg("matcher-groups", "Query filter").StringsVar(&c.matchers)
+	addCmd.Flag("from-alert", "Derive matchers from the labels of the alerts selected by a fingerprint or filter").StringVar(&c.fromAlert)
+	addCmd.Flag("labels", "Label names to derive matchers from when using --from-alert").StringsVar(&c.labels)
+	addCmd.Flag("interactive", "Prompt for each label when using --from-alert").Short('i').BoolVar(&c.interactive)
+	addCmd.Flag("expire-on-resolve", "Expire the silence once no firing alerts match it for a grace period").BoolVar(&c.expireOnResolve)
+	addCmd.Arg("matcher-groups", "Query filter").HintAction(completeMatchers).StringsVar(&c.matchers)
 	addCmd.Action(execWithTimeout(c.add))
 }
 
@@ -109,6 +138,15 @@ func (c *silenceAddCmd) add(ctx context.Context, _ *kingpin.ParseContext) error
 		}
 		matchers = append(matchers, *m)
 	}
+	if c.fromAlert != "" {
+		derived, err := c.matchersFromAlert(ctx)
+		if err != nil {
+			return err
+		}
+		matchers = append(derived, matchers...)
+	} else if len(c.labels) > 0 || c.interactive {
+		return errors.New("--labels and --interactive require --from-alert")
+	}
 	if len(matchers) < 1 {
 		return fmt.Errorf("no matchers specified")
 	}
@@ -153,11 +191,12 @@ func (c *silenceAddCmd) add(ctx context.Context, _ *kingpin.ParseContext) error
 	end := strfmt.DateTime(endsAt)
 	ps := &models.PostableSilence{
 		Silence: models.Silence{
-			Matchers:  TypeMatchers(matchers),
-			StartsAt:  &start,
-			EndsAt:    &end,
-			CreatedBy: &c.author,
-			Comment:   &c.comment,
+			Matchers:        TypeMatchers(matchers),
+			StartsAt:        &start,
+			EndsAt:          &end,
+			CreatedBy:       &c.author,
+			Comment:         &c.comment,
+			ExpireOnResolve: c.expireOnResolve,
 		},
 	}
 	silenceParams := silence.NewPostSilencesParams().WithContext(ctx).
@@ -172,3 +211,127 @@ func (c *silenceAddCmd) add(ctx context.Context, _ *kingpin.ParseContext) error
 	_, err = fmt.Println(postOk.Payload.SilenceID)
 	return err
 }
+
+// matchersFromAlert fetches the alerts selected by the --from-alert flag and
+// derives equality matchers from their labels.
+func (c *silenceAddCmd) matchersFromAlert(ctx context.Context) ([]labels.Matcher, error) {
+	alerts, err := fetchAlertsForSilence(ctx, c.fromAlert)
+	if err != nil {
+		return nil, err
+	}
+	if len(alerts) == 0 {
+		return nil, fmt.Errorf("no alerts found matching %q", c.fromAlert)
+	}
+
+	matchers, err := commonLabelMatchers(alerts, c.labels)
+	if err != nil {
+		return nil, err
+	}
+	if c.interactive {
+		return promptMatchers(os.Stdin, os.Stdout, matchers)
+	}
+	return matchers, nil
+}
+
+// fetchAlertsForSilence returns all alerts, regardless of their state, that
+// are selected by the given fingerprint or matcher filter.
+func fetchAlertsForSilence(ctx context.Context, selector string) (models.GettableAlerts, error) {
+	var (
+		yes    = true
+		params = alert.NewGetAlertsParams().WithContext(ctx).
+			WithActive(&yes).
+			WithSilenced(&yes).
+			WithInhibited(&yes).
+			WithUnprocessed(&yes)
+		byFingerprint = fingerprintRegexp.MatchString(selector)
+	)
+	if !byFingerprint {
+		ms, err := compat.Matchers(selector, "cli")
+		if err != nil {
+			return nil, fmt.Errorf("invalid alert filter %q: %w", selector, err)
+		}
+		filter := make([]string, 0, len(ms))
+		for _, m := range ms {
+			filter = append(filter, m.String())
+		}
+		params = params.WithFilter(filter)
+	}
+
+	amclient := NewAlertmanagerClient(alertmanagerURL)
+	getOk, err := amclient.Alert.GetAlerts(params)
+	if err != nil {
+		return nil, err
+	}
+	if !byFingerprint {
+		return getOk.Payload, nil
+	}
+	for _, a := range getOk.Payload {
+		if a.Fingerprint != nil && *a.Fingerprint == selector {
+			return models.GettableAlerts{a}, nil
+		}
+	}
+	return nil, nil
+}
+
+// commonLabelMatchers returns equality matchers for the labels that all
+// alerts share with identical values, sorted by label name. If names is not
+// empty, only those labels are considered and each of them must be shared.
+func commonLabelMatchers(alerts models.GettableAlerts, names []string) ([]labels.Matcher, error) {
+	common := make(models.LabelSet, len(alerts[0].Labels))
+	for ln, lv := range alerts[0].Labels {
+		common[ln] = lv
+	}
+	for _, a := range alerts[1:] {
+		for ln, lv := range common {
+			if v, ok := a.Labels[ln]; !ok || v != lv {
+				delete(common, ln)
+			}
+		}
+	}
+
+	if len(names) > 0 {
+		selected := make(models.LabelSet, len(names))
+		for _, ln := range names {
+			lv, ok := common[ln]
+			if !ok {
+				return nil, fmt.Errorf("label %q is not shared by all selected alerts", ln)
+			}
+			selected[ln] = lv
+		}
+		common = selected
+	}
+
+	matchers := make([]labels.Matcher, 0, len(common))
+	for ln, lv := range common {
+		m, err := labels.NewMatcher(labels.MatchEqual, ln, lv)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, *m)
+	}
+	sort.Slice(matchers, func(i, j int) bool { return matchers[i].Name < matchers[j].Name })
+	return matchers, nil
+}
+
+// promptMatchers asks whether each of the matchers should be kept and returns
+// the accepted ones. An empty answer keeps the matcher.
+func promptMatchers(r io.Reader, w io.Writer, matchers []labels.Matcher) ([]labels.Matcher, error) {
+	var (
+		scanner  = bufio.NewScanner(r)
+		accepted = make([]labels.Matcher, 0, len(matchers))
+	)
+	for _, m := range matchers {
+		fmt.Fprintf(w, "Include %s? [Y/n] ", m.String())
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return nil, err
+			}
+			return nil, errors.New("unexpected end of input")
+		}
+		switch strings.ToLower(strings.TrimSpace(scanner.Text())) {
+		case "", "y", "yes":
+			accepted = append(accepted, m)
+		}
+	}
+	return accepted, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/pkg/labels"
+)
+
+func matcherStrings(ms []labels.Matcher) []string {
+	res := make([]string, 0, len(ms))
+	for _, m := range ms {
+		res = append(res, m.String())
+	}
+	return res
+}
+
+func TestCommonLabelMatchers(t *testing.T) {
+	alerts := models.GettableAlerts{
+		{Alert: models.Alert{Labels: models.LabelSet{"alertname": "foo", "cluster": "a", "instance": "1"}}},
+		{Alert: models.Alert{Labels: models.LabelSet{"alertname": "foo", "cluster": "a", "instance": "2"}}},
+	}
+
+	ms, err := commonLabelMatchers(alerts, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{`alertname="foo"`, `cluster="a"`}, matcherStrings(ms))
+
+	ms, err = commonLabelMatchers(alerts, []string{"cluster"})
+	require.NoError(t, err)
+	require.Equal(t, []string{`cluster="a"`}, matcherStrings(ms))
+
+	_, err = commonLabelMatchers(alerts, []string{"instance"})
+	require.EqualError(t, err, `label "instance" is not shared by all selected alerts`)
+
+	ms, err = commonLabelMatchers(alerts[:1], []string{"instance"})
+	require.NoError(t, err)
+	require.Equal(t, []string{`instance="1"`}, matcherStrings(ms))
+}
+
+func TestPromptMatchers(t *testing.T) {
+	ms, err := commonLabelMatchers(models.GettableAlerts{
+		{Alert: models.Alert{Labels: models.LabelSet{"alertname": "foo", "cluster": "a", "instance": "1"}}},
+	}, nil)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	accepted, err := promptMatchers(strings.NewReader("\nn\ny\n"), &out, ms)
+	require.NoError(t, err)
+	require.Equal(t, []string{`alertname="foo"`, `instance="1"`}, matcherStrings(accepted))
+	require.Contains(t, out.String(), `Include cluster="a"? [Y/n]`)
+
+	_, err = promptMatchers(strings.NewReader("y\n"), &out, ms)
+	require.EqualError(t, err, "unexpected end of input")
+}
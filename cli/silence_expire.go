@@ -16,11 +16,13 @@ package cli
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
 
 	"github.com/alecthomas/kingpin/v2"
-	"github.com/go-openapi/strfmt"
 
 	"github.com/prometheus/alertmanager/api/v2/client/silence"
+	"github.com/prometheus/alertmanager/api/v2/models"
 )
 
 type silenceExpireCmd struct {
@@ -32,7 +34,7 @@ func configureSilenceExpireCmd(cc *kingpin.CmdClause) {
 		c         = &silenceExpireCmd{}
 		expireCmd = cc.Command("expire", "expire an alertmanager silence")
 	)
-	expireCmd.Arg("silence-ids", "Ids of silences to expire").StringsVar(&c.ids)
+	expireCmd.Arg("silence-ids", "Ids of silences to expire").HintAction(completeSilenceIDs).StringsVar(&c.ids)
 	expireCmd.Action(execWithTimeout(c.expire))
 }
 
@@ -43,14 +45,22 @@ func (c *silenceExpireCmd) expire(ctx context.Context, _ *kingpin.ParseContext)
 
 	amclient := NewAlertmanagerClient(alertmanagerURL)
 
-	for _, id := range c.ids {
-		params := silence.NewDeleteSilenceParams().WithContext(ctx)
-		params.SilenceID = strfmt.UUID(id)
-		_, err := amclient.Silence.DeleteSilence(params)
-		if err != nil {
-			return err
-		}
+	// All the silences are expired with a single request.
+	params := silence.NewPostSilencesBatchParams().WithContext(ctx).WithBatch(&models.PostableSilenceBatch{Expire: c.ids})
+	res, err := amclient.Silence.PostSilencesBatch(params)
+	if err != nil {
+		return err
 	}
 
+	errCount := 0
+	for _, r := range res.Payload.Expire {
+		if r.Error != "" {
+			fmt.Fprintf(os.Stderr, "Error expiring silence id='%v': %v\n", r.SilenceID, r.Error)
+			errCount++
+		}
+	}
+	if errCount > 0 {
+		return fmt.Errorf("couldn't expire %v out of %v silences", errCount, len(c.ids))
+	}
 	return nil
 }
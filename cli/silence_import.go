@@ -25,12 +25,14 @@ import (
 
 	"github.com/prometheus/alertmanager/api/v2/client/silence"
 	"github.com/prometheus/alertmanager/api/v2/models"
+	silencepkg "github.com/prometheus/alertmanager/silence"
 )
 
 type silenceImportCmd struct {
-	force   bool
-	workers int
-	file    string
+	force     bool
+	workers   int
+	batchSize int
+	file      string
 }
 
 const silenceImportHelp = `Import alertmanager silences from JSON file or stdin
@@ -43,6 +45,9 @@ amtool silence query -o json foo > foo.json
 amtool silence import foo.json
 
 JSON data can also come from stdin if no param is specified.
+
+With --batch-size, the silences are sent in batches of the given size, each
+batch in a single request.
 `
 
 func configureSilenceImportCmd(cc *kingpin.CmdClause) {
@@ -53,6 +58,7 @@ func configureSilenceImportCmd(cc *kingpin.CmdClause) {
 
 	importCmd.Flag("force", "Force adding new silences even if it already exists").Short('f').BoolVar(&c.force)
 	importCmd.Flag("worker", "Number of concurrent workers to use for import").Short('w').Default("8").IntVar(&c.workers)
+	importCmd.Flag("batch-size", "Number of silences to send per request, 0 sending each silence in its own request").Default("0").IntVar(&c.batchSize)
 	importCmd.Arg("input-file", "JSON file with silences").ExistingFileVar(&c.file)
 	importCmd.Action(execWithTimeout(c.bulkImport))
 }
@@ -78,6 +84,49 @@ func addSilenceWorker(ctx context.Context, sclient silence.ClientService, silenc
 	}
 }
 
+func addSilenceBatchWorker(ctx context.Context, sclient silence.ClientService, batchc <-chan []*models.PostableSilence, errc chan<- error) {
+	for batch := range batchc {
+		retry := postSilenceBatch(ctx, sclient, batch, errc, true)
+		if len(retry) > 0 {
+			// silences don't exist yet, retry to create them as new ones
+			for _, s := range retry {
+				s.ID = ""
+			}
+			postSilenceBatch(ctx, sclient, retry, errc, false)
+		}
+	}
+}
+
+// postSilenceBatch adds the silences with a single request and reports the
+// result of each of them to errc. If notFound is true, the silences which
+// don't exist are returned instead of being reported.
+func postSilenceBatch(ctx context.Context, sclient silence.ClientService, batch []*models.PostableSilence, errc chan<- error, notFound bool) []*models.PostableSilence {
+	params := silence.NewPostSilencesBatchParams().WithContext(ctx).WithBatch(&models.PostableSilenceBatch{Silences: batch})
+	res, err := sclient.PostSilencesBatch(params)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error adding %v silences: %v\n", len(batch), err)
+		for range batch {
+			errc <- err
+		}
+		return nil
+	}
+
+	var missing []*models.PostableSilence
+	for i, r := range res.Payload.Silences {
+		switch {
+		case r.Error == "":
+			fmt.Println(r.SilenceID)
+			errc <- nil
+		case notFound && batch[i].ID != "" && r.Error == silencepkg.ErrNotFound.Error():
+			missing = append(missing, batch[i])
+		default:
+			fmt.Fprintf(os.Stderr, "Error adding silence id='%v': %v\n", batch[i].ID, r.Error)
+			errc <- errors.New(r.Error)
+		}
+	}
+	return missing
+}
+
 func (c *silenceImportCmd) bulkImport(ctx context.Context, _ *kingpin.ParseContext) error {
 	input := os.Stdin
 	var err error
@@ -98,12 +147,17 @@ func (c *silenceImportCmd) bulkImport(ctx context.Context, _ *kingpin.ParseConte
 
 	amclient := NewAlertmanagerClient(alertmanagerURL)
 	silencec := make(chan *models.PostableSilence, 100)
+	batchc := make(chan []*models.PostableSilence, c.workers)
 	errc := make(chan error, 100)
 	var wg sync.WaitGroup
 	for w := 0; w < c.workers; w++ {
 		wg.Add(1)
 		go func() {
-			addSilenceWorker(ctx, amclient.Silence, silencec, errc)
+			if c.batchSize > 0 {
+				addSilenceBatchWorker(ctx, amclient.Silence, batchc, errc)
+			} else {
+				addSilenceWorker(ctx, amclient.Silence, silencec, errc)
+			}
 			wg.Done()
 		}()
 	}
@@ -118,6 +172,7 @@ func (c *silenceImportCmd) bulkImport(ctx context.Context, _ *kingpin.ParseConte
 	}()
 
 	count := 0
+	var batch []*models.PostableSilence
 	for dec.More() {
 		var s models.PostableSilence
 		err := dec.Decode(&s)
@@ -130,11 +185,23 @@ func (c *silenceImportCmd) bulkImport(ctx context.Context, _ *kingpin.ParseConte
 			s.ID = ""
 		}
 
-		silencec <- &s
 		count++
+		if c.batchSize <= 0 {
+			silencec <- &s
+			continue
+		}
+		batch = append(batch, &s)
+		if len(batch) == c.batchSize {
+			batchc <- batch
+			batch = nil
+		}
+	}
+	if len(batch) > 0 {
+		batchc <- batch
 	}
 
 	close(silencec)
+	close(batchc)
 	wg.Wait()
 	close(errc)
 
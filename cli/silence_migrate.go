@@ -0,0 +1,116 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alecthomas/kingpin/v2"
+
+	"github.com/prometheus/alertmanager/api/v2/client/silence"
+	"github.com/prometheus/alertmanager/api/v2/models"
+)
+
+type silenceMigrateCmd struct {
+	format string
+	dryRun bool
+	file   string
+}
+
+const silenceMigrateHelp = `Import silences exported by Grafana Alerting or karma
+
+The silences are converted by Alertmanager, which reports those which couldn't
+be converted and the differences of the lossy conversions. Expired silences
+aren't imported.
+
+For Grafana Alerting, the input is the response of the silences endpoint of
+its Alertmanager API:
+
+curl -H "Authorization: Bearer $TOKEN" https://grafana.example.com/api/alertmanager/grafana/api/v2/silences > grafana.json
+
+amtool silence migrate --format=grafana grafana.json
+
+For karma, the input is the response of its alerts.json endpoint:
+
+curl https://karma.example.com/alerts.json > karma.json
+
+amtool silence migrate --format=karma karma.json
+
+JSON data can also come from stdin if no param is specified. With --dry-run,
+the silences are only converted.
+`
+
+func configureSilenceMigrateCmd(cc *kingpin.CmdClause) {
+	var (
+		c          = &silenceMigrateCmd{}
+		migrateCmd = cc.Command("migrate", silenceMigrateHelp)
+	)
+
+	migrateCmd.Flag("format", "Format of the exported silences").Required().EnumVar(&c.format, models.SilenceImportFormatGrafana, models.SilenceImportFormatKarma)
+	migrateCmd.Flag("dry-run", "Only convert the silences, without creating them").BoolVar(&c.dryRun)
+	migrateCmd.Arg("input-file", "JSON file with the exported silences").ExistingFileVar(&c.file)
+	migrateCmd.Action(execWithTimeout(c.migrate))
+}
+
+func (c *silenceMigrateCmd) migrate(ctx context.Context, _ *kingpin.ParseContext) error {
+	var input io.Reader = os.Stdin
+	if c.file != "" {
+		f, err := os.Open(c.file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	var data interface{}
+	if err := json.NewDecoder(input).Decode(&data); err != nil {
+		return fmt.Errorf("couldn't unmarshal input data, is it JSON?: %w", err)
+	}
+
+	amclient := NewAlertmanagerClient(alertmanagerURL)
+	params := silence.NewPostSilencesImportParams().WithContext(ctx).WithSilenceImport(&models.SilenceImport{
+		Format: &c.format,
+		Data:   data,
+		DryRun: c.dryRun,
+	})
+	res, err := amclient.Silence.PostSilencesImport(params)
+	if err != nil {
+		return err
+	}
+
+	errCount := 0
+	for _, r := range res.Payload.Silences {
+		for _, l := range r.Lossy {
+			fmt.Fprintf(os.Stderr, "Lossy conversion of silence source='%v': %v\n", r.Source, l)
+		}
+		switch {
+		case r.Error != "":
+			fmt.Fprintf(os.Stderr, "Error importing silence source='%v': %v\n", r.Source, r.Error)
+			errCount++
+		case c.dryRun:
+			fmt.Printf("Silence source='%v' can be imported\n", r.Source)
+		default:
+			fmt.Println(r.SilenceID)
+		}
+	}
+	if errCount > 0 {
+		return fmt.Errorf("couldn't import %v out of %v silences", errCount, len(res.Payload.Silences))
+	}
+	return nil
+}
@@ -34,6 +34,8 @@ type silenceQueryCmd struct {
 	createdBy string
 	ID        string
 	matchers  []string
+	matches   []string
+	overlaps  []string
 	within    time.Duration
 }
 
@@ -77,6 +79,16 @@ preceding duration.
 amtool silence query --within 2h --expired
 
 returns all silences that expired within the preceding 2 hours.
+
+The "--matches" and "--overlaps" parameters find the silences duplicating or
+overlapping a silence, by the labels they match or by their matchers:
+
+amtool silence query --matches job=node --matches instance=host1
+amtool silence query --overlaps 'job=~"api.*"'
+
+returns the silences matching the labels job=node and instance=host1, and the
+silences whose matchers may match the same labels as job=~"api.*". Used
+together, the silences found by either parameter are returned.
 `
 
 func configureSilenceQueryCmd(cc *kingpin.CmdClause) {
@@ -89,7 +101,9 @@ func configureSilenceQueryCmd(cc *kingpin.CmdClause) {
 	queryCmd.Flag("quiet", "Only show silence ids").Short('q').BoolVar(&c.quiet)
 	queryCmd.Flag("created-by", "Show silences that belong to this creator").StringVar(&c.createdBy)
 	queryCmd.Flag("id", "Get a single silence by its ID").StringVar(&c.ID)
-	queryCmd.Arg("matcher-groups", "Query filter").StringsVar(&c.matchers)
+	queryCmd.Arg("matcher-groups", "Query filter").HintAction(completeMatchers).StringsVar(&c.matchers)
+	queryCmd.Flag("matches", "Show silences matching this label, as name=value").StringsVar(&c.matches)
+	queryCmd.Flag("overlaps", "Show silences whose matchers overlap this matcher").StringsVar(&c.overlaps)
 	queryCmd.Flag("within", "Show silences that will expire or have expired within a duration").DurationVar(&c.within)
 	queryCmd.Action(execWithTimeout(c.query))
 }
@@ -105,7 +119,7 @@ func (c *silenceQueryCmd) query(ctx context.Context, _ *kingpin.ParseContext) er
 		}
 	}
 
-	silenceParams := silence.NewGetSilencesParams().WithContext(ctx).WithFilter(c.matchers)
+	silenceParams := silence.NewGetSilencesParams().WithContext(ctx).WithFilter(c.matchers).WithMatches(c.matches).WithOverlaps(c.overlaps)
 
 	amclient := NewAlertmanagerClient(alertmanagerURL)
 
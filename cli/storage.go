@@ -0,0 +1,127 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+
+	"github.com/prometheus/alertmanager/encryption"
+	"github.com/prometheus/alertmanager/nflog"
+	"github.com/prometheus/alertmanager/silence"
+	"github.com/prometheus/alertmanager/snapshot"
+)
+
+type storageVerifyCmd struct {
+	files             []string
+	snapshotType      string
+	repair            bool
+	encryptionKeyFile string
+}
+
+const storageVerifyHelp = `Verify Alertmanager snapshot files
+
+Checks that all entries of the silences and notification log snapshots can be
+decoded and reports the number of entries, entries written by older versions
+and entries past their retention. The snapshot type is derived from the file
+name ("silences" or "nflog") unless --type is given.
+
+With --repair, undecodable entries are dropped and the original file is kept
+with a .bak suffix. Alertmanager must not be running while repairing.
+`
+
+func configureStorageCmd(app *kingpin.Application) {
+	var (
+		c          = &storageVerifyCmd{}
+		storageCmd = app.Command("storage", "Inspect the local Alertmanager storage")
+		verifyCmd  = storageCmd.Command("verify", storageVerifyHelp)
+	)
+	verifyCmd.Flag("type", "Type of the snapshot files").EnumVar(&c.snapshotType, "silences", "nflog")
+	verifyCmd.Flag("repair", "Drop undecodable entries from the snapshots").BoolVar(&c.repair)
+	verifyCmd.Flag("encryption-key-file", "File containing the hex-encoded key the snapshots are encrypted with").ExistingFileVar(&c.encryptionKeyFile)
+	verifyCmd.Arg("snapshot-files", "Snapshot files to verify").Required().ExistingFilesVar(&c.files)
+	verifyCmd.Action(c.verify)
+}
+
+func (c *storageVerifyCmd) verify(_ *kingpin.ParseContext) error {
+	var (
+		cipher *encryption.Cipher
+		err    error
+		now    = time.Now().UTC()
+		failed int
+	)
+	if c.encryptionKeyFile != "" {
+		if cipher, err = encryption.LoadKeyFile(c.encryptionKeyFile); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range c.files {
+		typ := c.snapshotType
+		if typ == "" {
+			typ = filepath.Base(file)
+		}
+		var verify snapshot.VerifyFunc
+		switch typ {
+		case "silences":
+			verify = func(r io.Reader, w io.Writer) (snapshot.Report, error) {
+				return silence.VerifySnapshot(r, cipher, now, w)
+			}
+		case "nflog":
+			verify = func(r io.Reader, w io.Writer) (snapshot.Report, error) {
+				return nflog.VerifySnapshot(r, cipher, now, w)
+			}
+		default:
+			return fmt.Errorf("cannot derive the snapshot type of %s, use --type", file)
+		}
+
+		fmt.Printf("Checking '%s'", file)
+		rep, err := snapshot.VerifyFile(file, c.repair, verify)
+		if err != nil {
+			fmt.Printf("  FAILED: %s\n", err)
+			failed++
+			continue
+		}
+		if rep.OK() {
+			fmt.Printf("  SUCCESS\n")
+		} else {
+			fmt.Printf("  CORRUPTED\n")
+			if !c.repair {
+				failed++
+			}
+		}
+		fmt.Printf(" - %d entries\n", rep.Entries)
+		fmt.Printf(" - %d entries written by older versions\n", rep.Legacy)
+		fmt.Printf(" - %d expired entries\n", rep.Expired)
+		if !rep.OK() {
+			fmt.Printf(" - %d undecodable entries\n", rep.Dropped)
+			fmt.Printf(" - %d truncated bytes\n", rep.TruncatedBytes)
+			for _, err := range rep.Errors {
+				fmt.Printf("   %v\n", err)
+			}
+			if c.repair {
+				fmt.Printf(" - repaired, original kept as %s.bak\n", file)
+			}
+		}
+		fmt.Printf("\n")
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to verify %d file(s)", failed)
+	}
+	return nil
+}
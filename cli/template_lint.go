@@ -0,0 +1,122 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/alecthomas/kingpin/v2"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/template"
+)
+
+const templateLintHelp = `Lint template files.
+
+Reports the syntax errors, the unknown functions, the fields which don't exist
+in the data they are evaluated on and the calls of undefined templates. The
+templates not called by other templates are checked against the data of
+notifications. With --config.file, the templates of the configuration are
+linted if no files are given, and the templates defined but not used by the
+configuration are reported as well.
+
+	amtool template lint ./templates/*.tmpl
+	amtool template lint --config.file=alertmanager.yml
+`
+
+type templateLintCmd struct {
+	files       []string
+	configFile  string
+	entrypoints []string
+}
+
+func configureTemplateLintCmd(cc *kingpin.CmdClause) {
+	var (
+		c       = &templateLintCmd{}
+		lintCmd = cc.Command("lint", templateLintHelp)
+	)
+	lintCmd.Arg("files", "Template files or globs to lint.").StringsVar(&c.files)
+	lintCmd.Flag("config.file", "Alertmanager configuration file using the templates.").ExistingFileVar(&c.configFile)
+	lintCmd.Flag("entrypoint", "Name of a template used by the configuration, can be repeated.").StringsVar(&c.entrypoints)
+	lintCmd.Action(c.lint)
+}
+
+func (c *templateLintCmd) lint(_ *kingpin.ParseContext) error {
+	globs, entrypoints := c.files, c.entrypoints
+	if c.configFile != "" {
+		conf, err := config.LoadFile(c.configFile)
+		if err != nil {
+			return err
+		}
+		if len(globs) == 0 {
+			globs = conf.Templates
+		}
+		entrypoints = append(entrypoints, template.Called(conf.String())...)
+	}
+	if len(globs) == 0 {
+		return fmt.Errorf("no template files to lint")
+	}
+
+	var files []template.LintFile
+	for _, glob := range globs {
+		paths, err := filepath.Glob(glob)
+		if err != nil {
+			return err
+		}
+		for _, p := range paths {
+			b, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			files = append(files, template.LintFile{Name: p, Content: string(b)})
+		}
+	}
+
+	diags, err := template.Lint(files, entrypoints)
+	if err != nil {
+		return err
+	}
+	if err := printDiagnostics(os.Stdout, diags); err != nil {
+		return err
+	}
+	errs := 0
+	for _, d := range diags {
+		if d.Severity == template.SeverityError {
+			errs++
+		}
+	}
+	if errs > 0 {
+		return fmt.Errorf("%d errors found in %d files", errs, len(files))
+	}
+	return nil
+}
+
+func printDiagnostics(w io.Writer, diags []template.Diagnostic) error {
+	if output == "json" {
+		if diags == nil {
+			diags = []template.Diagnostic{}
+		}
+		return json.NewEncoder(w).Encode(diags)
+	}
+	for _, d := range diags {
+		if _, err := fmt.Fprintln(w, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -57,9 +57,10 @@ func resolveAlertReceivers(mainRoute *dispatch.Route, labels *models.LabelSet) (
 		finalRoutes []*dispatch.Route
 		receivers   []string
 	)
-	finalRoutes = mainRoute.Match(convertClientToCommonLabelSet(*labels))
+	lset := convertClientToCommonLabelSet(*labels)
+	finalRoutes = mainRoute.Match(lset)
 	for _, r := range finalRoutes {
-		receivers = append(receivers, r.RouteOpts.Receiver)
+		receivers = append(receivers, r.RouteOpts.ReceiverFor(lset))
 	}
 	return receivers, nil
 }
@@ -0,0 +1,106 @@
+// Copyright 2020 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/prometheus/alertmanager/api/v2/client/timeinterval"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/cli/format"
+)
+
+const timeIntervalHelp = `Inspect the time intervals of the Alertmanager configuration.`
+
+const timeIntervalTestHelp = `Test whether a time interval is active at the given times.
+
+The time interval is evaluated by the Alertmanager the same way it is when
+muting or activating routes, in the time zone of the interval. Times are in
+RFC3339 format, the seconds and the time zone offset can be left out. Times
+without offset are in the local time zone. Without --time, the interval is
+tested for the current time.
+
+	amtool timeinterval test --name business_hours --time 2024-03-01T12:00 --time 2024-03-02T12:00
+`
+
+// timeLayouts are the layouts accepted for times, in order.
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+}
+
+type timeIntervalTestCmd struct {
+	name  string
+	times []string
+}
+
+// configureTimeIntervalCmd represents the timeinterval command.
+func configureTimeIntervalCmd(app *kingpin.Application) {
+	var (
+		c               = &timeIntervalTestCmd{}
+		timeIntervalCmd = app.Command("timeinterval", timeIntervalHelp)
+		testCmd         = timeIntervalCmd.Command("test", timeIntervalTestHelp)
+	)
+	testCmd.Flag("name", "Name of the time interval").Required().StringVar(&c.name)
+	testCmd.Flag("time", "Time to test, can be repeated").StringsVar(&c.times)
+	testCmd.Action(execWithTimeout(c.test)).PreAction(requireAlertManagerURL)
+}
+
+func (c *timeIntervalTestCmd) test(ctx context.Context, _ *kingpin.ParseContext) error {
+	times := make([]strfmt.DateTime, 0, len(c.times))
+	for _, s := range c.times {
+		t, err := parseTime(s)
+		if err != nil {
+			return err
+		}
+		times = append(times, strfmt.DateTime(t))
+	}
+	if len(times) == 0 {
+		times = append(times, strfmt.DateTime(time.Now()))
+	}
+
+	params := timeinterval.NewTestTimeIntervalParams().WithContext(ctx).WithTest(&models.TimeIntervalTestRequest{
+		Name:  &c.name,
+		Times: times,
+	})
+	amclient := NewAlertmanagerClient(alertmanagerURL)
+	res, err := amclient.Timeinterval.TestTimeInterval(params)
+	if err != nil {
+		return err
+	}
+
+	formatter, found := format.Formatters[output]
+	if !found {
+		return errors.New("unknown output formatter")
+	}
+	return formatter.FormatTimeIntervalTest(res.Payload)
+}
+
+// parseTime parses a time in one of the timeLayouts. Times without time
+// zone offset are in the local time zone.
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, expected RFC3339 format such as 2024-03-01T12:00:00Z or 2024-03-01T12:00", s)
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTime(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		exp time.Time
+		err bool
+	}{
+		{
+			in:  "2024-03-01T12:00:00Z",
+			exp: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			in:  "2024-03-01T12:00:00+01:00",
+			exp: time.Date(2024, 3, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			in:  "2024-03-01T12:00:30",
+			exp: time.Date(2024, 3, 1, 12, 0, 30, 0, time.Local),
+		},
+		{
+			in:  "2024-03-01T12:00",
+			exp: time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local),
+		},
+		{
+			in:  "2024-03-01",
+			err: true,
+		},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := parseTime(tc.in)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tc.exp.Equal(got), "expected %s, got %s", tc.exp, got)
+		})
+	}
+}
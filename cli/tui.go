@@ -0,0 +1,394 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+
+	"github.com/prometheus/alertmanager/api/v2/client"
+	"github.com/prometheus/alertmanager/api/v2/client/alert"
+	"github.com/prometheus/alertmanager/api/v2/client/silence"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/cli/format"
+)
+
+const tuiHelp = `Browse the alerts and silences interactively.
+
+The alerts and silences matching the given matchers are refreshed periodically.
+
+Keys:
+	tab         switch between the alerts and the silences
+	up, k       select the previous item
+	down, j     select the next item
+	enter       show or hide the details of the selected item
+	r           refresh
+	q           quit
+`
+
+// The escape sequences of the terminal.
+const (
+	escAltScreen    = "\x1b[?1049h\x1b[?25l"
+	escNormalScreen = "\x1b[?25h\x1b[?1049l"
+	escClear        = "\x1b[H\x1b[2J"
+	escReverse      = "\x1b[7m"
+	escBold         = "\x1b[1m"
+	escReset        = "\x1b[0m"
+)
+
+type tuiCmd struct {
+	matchers []string
+	refresh  time.Duration
+}
+
+func configureTUICmd(app *kingpin.Application) {
+	var (
+		c      = &tuiCmd{}
+		tuiCmd = app.Command("tui", tuiHelp).PreAction(requireAlertManagerURL)
+	)
+	tuiCmd.Flag("refresh", "Interval at which the alerts and silences are refreshed.").Default("10s").DurationVar(&c.refresh)
+	tuiCmd.Arg("matcher-groups", "Query filter").HintAction(completeMatchers).StringsVar(&c.matchers)
+	tuiCmd.Action(c.run)
+}
+
+func (c *tuiCmd) run(_ *kingpin.ParseContext) error {
+	term, err := openTerminal()
+	if err != nil {
+		return err
+	}
+	defer term.restore()
+
+	fmt.Fprint(os.Stdout, escAltScreen)
+	defer fmt.Fprint(os.Stdout, escNormalScreen)
+
+	keys := make(chan []byte)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				close(keys)
+				return
+			}
+			keys <- append([]byte(nil), buf[:n]...)
+		}
+	}()
+
+	ticker := time.NewTicker(c.refresh)
+	defer ticker.Stop()
+
+	amclient := NewAlertmanagerClient(alertmanagerURL)
+	m := &tuiModel{}
+	m.update(c.fetch(amclient))
+	for {
+		var out bytes.Buffer
+		width, height := term.size()
+		m.render(&out, width, height)
+		os.Stdout.Write(out.Bytes())
+
+		select {
+		case b, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			quit, refresh := m.handleKeys(parseKeys(b))
+			if quit {
+				return nil
+			}
+			if refresh {
+				m.update(c.fetch(amclient))
+			}
+		case <-ticker.C:
+			m.update(c.fetch(amclient))
+		case <-term.resize:
+		}
+	}
+}
+
+func (c *tuiCmd) fetch(amclient *client.AlertmanagerAPI) ([]*models.GettableAlert, []models.GettableSilence, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	all := true
+	alerts, err := amclient.Alert.GetAlerts(alert.NewGetAlertsParams().WithContext(ctx).
+		WithActive(&all).
+		WithSilenced(&all).
+		WithInhibited(&all).
+		WithFilter(c.matchers))
+	if err != nil {
+		return nil, nil, err
+	}
+	silences, err := amclient.Silence.GetSilences(silence.NewGetSilencesParams().WithContext(ctx).WithFilter(c.matchers))
+	if err != nil {
+		return nil, nil, err
+	}
+	res := make([]models.GettableSilence, 0, len(silences.Payload))
+	for _, s := range silences.Payload {
+		res = append(res, *s)
+	}
+	return alerts.Payload, res, nil
+}
+
+type tuiView int
+
+const (
+	tuiAlerts tuiView = iota
+	tuiSilences
+)
+
+type tuiKey int
+
+const (
+	keyUp tuiKey = iota
+	keyDown
+	keyTab
+	keyEnter
+	keyRefresh
+	keyQuit
+)
+
+// parseKeys returns the keys of the bytes read from the terminal in raw mode.
+func parseKeys(b []byte) []tuiKey {
+	var keys []tuiKey
+	for len(b) > 0 {
+		switch {
+		case bytes.HasPrefix(b, []byte("\x1b[A")), bytes.HasPrefix(b, []byte("\x1bOA")):
+			keys, b = append(keys, keyUp), b[3:]
+			continue
+		case bytes.HasPrefix(b, []byte("\x1b[B")), bytes.HasPrefix(b, []byte("\x1bOB")):
+			keys, b = append(keys, keyDown), b[3:]
+			continue
+		}
+		switch b[0] {
+		case 'k':
+			keys = append(keys, keyUp)
+		case 'j':
+			keys = append(keys, keyDown)
+		case '\t':
+			keys = append(keys, keyTab)
+		case '\r', '\n':
+			keys = append(keys, keyEnter)
+		case 'r':
+			keys = append(keys, keyRefresh)
+		case 'q', 0x03, 0x04:
+			keys = append(keys, keyQuit)
+		}
+		b = b[1:]
+	}
+	return keys
+}
+
+// tuiModel is the state of the interactive mode.
+type tuiModel struct {
+	view     tuiView
+	alerts   []*models.GettableAlert
+	silences []models.GettableSilence
+	selected [2]int
+	details  bool
+	updated  time.Time
+	err      error
+}
+
+// update sets the fetched alerts and silences, keeping the previous ones on
+// errors.
+func (m *tuiModel) update(alerts []*models.GettableAlert, silences []models.GettableSilence, err error) {
+	m.err = err
+	if err != nil {
+		return
+	}
+	sort.Sort(format.ByStartsAt(alerts))
+	sort.Sort(format.ByEndAt(silences))
+	m.alerts, m.silences = alerts, silences
+	m.updated = time.Now()
+	for v, n := range []int{len(alerts), len(silences)} {
+		if m.selected[v] >= n {
+			m.selected[v] = max(n-1, 0)
+		}
+	}
+}
+
+func (m *tuiModel) len() int {
+	if m.view == tuiAlerts {
+		return len(m.alerts)
+	}
+	return len(m.silences)
+}
+
+// handleKeys updates the model with the keys and returns whether to quit and
+// whether to refresh.
+func (m *tuiModel) handleKeys(keys []tuiKey) (quit, refresh bool) {
+	for _, k := range keys {
+		switch k {
+		case keyUp:
+			if m.selected[m.view] > 0 {
+				m.selected[m.view]--
+			}
+		case keyDown:
+			if m.selected[m.view] < m.len()-1 {
+				m.selected[m.view]++
+			}
+		case keyTab:
+			m.view = (m.view + 1) % 2
+		case keyEnter:
+			m.details = !m.details
+		case keyRefresh:
+			refresh = true
+		case keyQuit:
+			return true, false
+		}
+	}
+	return false, refresh
+}
+
+// render writes the screen of the given size.
+func (m *tuiModel) render(w io.Writer, width, height int) {
+	var lines []string
+	tabs := []string{fmt.Sprintf(" Alerts (%d) ", len(m.alerts)), fmt.Sprintf(" Silences (%d) ", len(m.silences))}
+	tabs[m.view] = escReverse + tabs[m.view] + escReset
+	header := strings.Join(tabs, " ")
+	if !m.updated.IsZero() {
+		header += "  updated " + m.updated.Format(time.TimeOnly)
+	}
+	lines = append(lines, header)
+	if m.err != nil {
+		lines = append(lines, "error: "+m.err.Error())
+	}
+
+	var details []string
+	if m.details && m.len() > 0 {
+		details = append([]string{""}, m.detailLines()...)
+	}
+	// Scroll the list to keep the selected item visible.
+	list := m.listLines()
+	room := max(height-len(lines)-len(details)-1, 2)
+	first := 0
+	if sel := m.selected[m.view] + 1; sel >= room {
+		first = sel - room + 1
+	}
+	lines = append(lines, escBold+list[0]+escReset)
+	for i, l := range list[1:] {
+		if i < first || i >= first+room-1 {
+			continue
+		}
+		if i == m.selected[m.view] {
+			l = escReverse + l + escReset
+		}
+		lines = append(lines, l)
+	}
+	lines = append(lines, details...)
+
+	fmt.Fprint(w, escClear)
+	for i, l := range lines {
+		if i >= height {
+			break
+		}
+		if i > 0 {
+			fmt.Fprint(w, "\r\n")
+		}
+		fmt.Fprint(w, truncate(l, width))
+	}
+}
+
+// listLines returns the header and a line for each item of the current view.
+func (m *tuiModel) listLines() []string {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	if m.view == tuiAlerts {
+		fmt.Fprintln(tw, "Alertname\tStarts At\tSummary\tState\t")
+		for _, a := range m.alerts {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t\n", a.Labels["alertname"], format.FormatDate(*a.StartsAt), a.Annotations["summary"], *a.Status.State)
+		}
+	} else {
+		fmt.Fprintln(tw, "ID\tMatchers\tEnds At\tCreated By\tState\t")
+		for _, s := range m.silences {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t\n", *s.ID, format.FormatMatchers(s.Matchers), format.FormatDate(*s.EndsAt), *s.CreatedBy, *s.Status.State)
+		}
+	}
+	tw.Flush()
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+}
+
+// detailLines returns the details of the selected item.
+func (m *tuiModel) detailLines() []string {
+	var lines []string
+	kv := func(lset models.LabelSet) {
+		names := make([]string, 0, len(lset))
+		for n := range lset {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			lines = append(lines, fmt.Sprintf("  %s: %s", n, lset[n]))
+		}
+	}
+	if m.view == tuiAlerts {
+		a := m.alerts[m.selected[tuiAlerts]]
+		lines = append(lines, escBold+"Labels"+escReset)
+		kv(a.Labels)
+		lines = append(lines, escBold+"Annotations"+escReset)
+		kv(a.Annotations)
+		lines = append(lines,
+			"Fingerprint: "+*a.Fingerprint,
+			"Ends At: "+format.FormatDate(*a.EndsAt),
+			"Generator URL: "+a.GeneratorURL.String(),
+		)
+		if len(a.Status.SilencedBy) > 0 {
+			lines = append(lines, "Silenced By: "+strings.Join(a.Status.SilencedBy, ", "))
+		}
+		if len(a.Status.InhibitedBy) > 0 {
+			lines = append(lines, "Inhibited By: "+strings.Join(a.Status.InhibitedBy, ", "))
+		}
+		return lines
+	}
+	s := m.silences[m.selected[tuiSilences]]
+	return append(lines,
+		"Matchers: "+format.FormatMatchers(s.Matchers),
+		"Starts At: "+format.FormatDate(*s.StartsAt),
+		"Ends At: "+format.FormatDate(*s.EndsAt),
+		"Created By: "+*s.CreatedBy,
+		"Comment: "+*s.Comment,
+	)
+}
+
+// truncate cuts the line to the width, not counting the escape sequences.
+func truncate(l string, width int) string {
+	var (
+		b   strings.Builder
+		n   int
+		esc bool
+	)
+	for _, r := range l {
+		switch {
+		case esc:
+			esc = r != 'm'
+		case r == '\x1b':
+			esc = true
+		case n >= width:
+			continue
+		default:
+			n++
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,33 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package cli
+
+import (
+	"errors"
+	"os"
+)
+
+type terminal struct {
+	resize chan os.Signal
+}
+
+func openTerminal() (*terminal, error) {
+	return nil, errors.New("the interactive mode is not supported on this platform")
+}
+
+func (t *terminal) size() (int, int) { return 80, 24 }
+
+func (t *terminal) restore() {}
@@ -0,0 +1,116 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/cli/format"
+)
+
+func TestParseKeys(t *testing.T) {
+	require.Equal(t,
+		[]tuiKey{keyUp, keyDown, keyUp, keyDown, keyTab, keyEnter, keyRefresh, keyQuit, keyQuit},
+		parseKeys([]byte("\x1b[A\x1b[Bkj\t\rrxq\x03")),
+	)
+}
+
+func TestTUIModel(t *testing.T) {
+	app := kingpin.New("amtool", "")
+	format.InitFormatFlags(app)
+	_, err := app.Parse(nil)
+	require.NoError(t, err)
+
+	str := func(s string) *string { return &s }
+	date := func(d time.Duration) *strfmt.DateTime {
+		t := strfmt.DateTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(d))
+		return &t
+	}
+	alert := func(name string, start time.Duration) *models.GettableAlert {
+		return &models.GettableAlert{
+			Alert:       models.Alert{Labels: models.LabelSet{"alertname": name}},
+			Annotations: models.LabelSet{"summary": name + " is firing"},
+			StartsAt:    date(start),
+			EndsAt:      date(time.Hour),
+			Fingerprint: str(name),
+			Status:      &models.AlertStatus{State: str(models.AlertStatusStateActive)},
+		}
+	}
+	isRegex := false
+	silences := []models.GettableSilence{{
+		ID:     str("s1"),
+		Status: &models.SilenceStatus{State: str(models.SilenceStatusStateActive)},
+		Silence: models.Silence{
+			Matchers:  models.Matchers{{Name: str("alertname"), Value: str("DiskFull"), IsRegex: &isRegex}},
+			StartsAt:  date(0),
+			EndsAt:    date(time.Hour),
+			CreatedBy: str("alice"),
+			Comment:   str("maintenance"),
+		},
+	}}
+
+	m := &tuiModel{}
+	m.update([]*models.GettableAlert{alert("NodeDown", time.Minute), alert("DiskFull", 0)}, silences, nil)
+	// The alerts are sorted by start time.
+	require.Equal(t, "DiskFull", m.alerts[0].Labels["alertname"])
+
+	render := func() string {
+		var buf bytes.Buffer
+		m.render(&buf, 200, 24)
+		return buf.String()
+	}
+
+	out := render()
+	require.Contains(t, out, escReverse+" Alerts (2) "+escReset)
+	require.Contains(t, out, escReverse+"DiskFull")
+	require.NotContains(t, out, "Annotations")
+
+	quit, refresh := m.handleKeys([]tuiKey{keyDown, keyDown, keyEnter})
+	require.False(t, quit)
+	require.False(t, refresh)
+	out = render()
+	require.Contains(t, out, escReverse+"NodeDown")
+	require.Contains(t, out, "  summary: NodeDown is firing")
+
+	m.handleKeys([]tuiKey{keyTab})
+	out = render()
+	require.Contains(t, out, escReverse+" Silences (1) "+escReset)
+	require.Contains(t, out, "Matchers: alertname=\"DiskFull\"")
+	require.Contains(t, out, "Comment: maintenance")
+
+	// Errors keep the previous alerts and silences.
+	m.update(nil, nil, errors.New("connection refused"))
+	out = render()
+	require.Contains(t, out, "error: connection refused")
+	require.Contains(t, out, "Silences (1)")
+
+	// The lines are cut to the width of the terminal.
+	var buf bytes.Buffer
+	m.render(&buf, 10, 24)
+	for _, l := range strings.Split(strings.TrimPrefix(buf.String(), escClear), "\r\n") {
+		require.LessOrEqual(t, len([]rune(strings.NewReplacer(escReverse, "", escBold, "", escReset, "").Replace(l))), 10)
+	}
+
+	quit, _ = m.handleKeys([]tuiKey{keyRefresh, keyQuit})
+	require.True(t, quit)
+}
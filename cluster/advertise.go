@@ -14,28 +14,90 @@
 package cluster
 
 import (
-	"errors"
 	"fmt"
 	"net"
 
 	"github.com/hashicorp/go-sockaddr"
 )
 
-type getIPFunc func() (string, error)
+// AddressFamily is the family of the IP addresses considered when
+// discovering the advertise address.
+type AddressFamily string
+
+const (
+	// AddressFamilyAny considers both IPv4 and IPv6 addresses.
+	AddressFamilyAny AddressFamily = "any"
+	// AddressFamilyIPv4 only considers IPv4 addresses.
+	AddressFamilyIPv4 AddressFamily = "ipv4"
+	// AddressFamilyIPv6 only considers IPv6 addresses.
+	AddressFamilyIPv6 AddressFamily = "ipv6"
+)
+
+type getIPFunc func(AddressFamily) (string, error)
 
 // These are overridden in unit tests to mock the sockaddr functions.
 var (
-	getPrivateAddress getIPFunc = sockaddr.GetPrivateIP
-	getPublicAddress  getIPFunc = sockaddr.GetPublicIP
+	getPrivateAddress getIPFunc = getPrivateIP
+	getPublicAddress  getIPFunc = getPublicIP
 )
 
+// getPrivateIP is like sockaddr.GetPrivateIP but only returns addresses of
+// the family.
+func getPrivateIP(family AddressFamily) (string, error) {
+	ifAddrs, err := sockaddr.GetPrivateInterfaces()
+	if err != nil {
+		return "", err
+	}
+	return firstIP(ifAddrs, family), nil
+}
+
+// getPublicIP is like sockaddr.GetPublicIP but only returns addresses of the
+// family.
+func getPublicIP(family AddressFamily) (string, error) {
+	ifAddrs, err := sockaddr.GetPublicInterfaces()
+	if err != nil {
+		return "", err
+	}
+	return firstIP(ifAddrs, family), nil
+}
+
+// firstIP returns the first IP address of the family of the interface
+// addresses, or an empty string if there is none.
+func firstIP(ifAddrs sockaddr.IfAddrs, family AddressFamily) string {
+	for _, ifAddr := range ifAddrs {
+		ip := sockaddr.ToIPAddr(ifAddr.SockAddr)
+		if ip == nil {
+			continue
+		}
+		switch t := (*ip).Type(); {
+		case family == AddressFamilyIPv4 && t&sockaddr.TypeIPv4 == 0,
+			family == AddressFamilyIPv6 && t&sockaddr.TypeIPv6 == 0:
+			continue
+		}
+		return (*ip).NetIP().String()
+	}
+	return ""
+}
+
+// ipName returns the name of the IP addresses of the family in error
+// messages.
+func ipName(family AddressFamily) string {
+	switch family {
+	case AddressFamilyIPv4:
+		return "IPv4 address"
+	case AddressFamilyIPv6:
+		return "IPv6 address"
+	}
+	return "IP"
+}
+
 // calculateAdvertiseAddress attempts to clone logic from deep within memberlist
 // (NetTransport.FinalAdvertiseAddr) in order to surface its conclusions to the
 // application, so we can provide more actionable error messages if the user has
 // inadvertently misconfigured their cluster.
 //
 // https://github.com/hashicorp/memberlist/blob/022f081/net_transport.go#L126
-func calculateAdvertiseAddress(bindAddr, advertiseAddr string, allowInsecureAdvertise bool) (net.IP, error) {
+func calculateAdvertiseAddress(bindAddr, advertiseAddr string, family AddressFamily, allowInsecureAdvertise bool) (net.IP, error) {
 	if advertiseAddr != "" {
 		ip := net.ParseIP(advertiseAddr)
 		if ip == nil {
@@ -48,7 +110,7 @@ func calculateAdvertiseAddress(bindAddr, advertiseAddr string, allowInsecureAdve
 	}
 
 	if isAny(bindAddr) {
-		return discoverAdvertiseAddress(allowInsecureAdvertise)
+		return discoverAdvertiseAddress(family, allowInsecureAdvertise)
 	}
 
 	ip := net.ParseIP(bindAddr)
@@ -58,26 +120,26 @@ func calculateAdvertiseAddress(bindAddr, advertiseAddr string, allowInsecureAdve
 	return ip, nil
 }
 
-// discoverAdvertiseAddress will attempt to get a single IP address to use as
-// the advertise address when one is not explicitly provided. It defaults to
-// using a private IP address, and if not found then using a public IP if
-// insecure advertising is allowed.
-func discoverAdvertiseAddress(allowInsecureAdvertise bool) (net.IP, error) {
-	addr, err := getPrivateAddress()
+// discoverAdvertiseAddress will attempt to get a single IP address of the
+// family to use as the advertise address when one is not explicitly provided.
+// It defaults to using a private IP address, and if not found then using a
+// public IP if insecure advertising is allowed.
+func discoverAdvertiseAddress(family AddressFamily, allowInsecureAdvertise bool) (net.IP, error) {
+	addr, err := getPrivateAddress(family)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get private IP: %w", err)
+		return nil, fmt.Errorf("failed to get private %s: %w", ipName(family), err)
 	}
 	if addr == "" && !allowInsecureAdvertise {
-		return nil, errors.New("no private IP found, explicit advertise addr not provided")
+		return nil, fmt.Errorf("no private %s found, explicit advertise addr not provided", ipName(family))
 	}
 
 	if addr == "" {
-		addr, err = getPublicAddress()
+		addr, err = getPublicAddress(family)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get public IP: %w", err)
+			return nil, fmt.Errorf("failed to get public %s: %w", ipName(family), err)
 		}
 		if addr == "" {
-			return nil, errors.New("no private/public IP found, explicit advertise addr not provided")
+			return nil, fmt.Errorf("no private/public %s found, explicit advertise addr not provided", ipName(family))
 		}
 	}
 
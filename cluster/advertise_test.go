@@ -18,6 +18,7 @@ import (
 	"net"
 	"testing"
 
+	"github.com/hashicorp/go-sockaddr"
 	"github.com/stretchr/testify/require"
 )
 
@@ -55,7 +56,7 @@ func TestCalculateAdvertiseAddress(t *testing.T) {
 		},
 		{
 			name:        "discover private ip address",
-			privateIPFn: func() (string, error) { return "192.0.2.1", nil },
+			privateIPFn: func(AddressFamily) (string, error) { return "192.0.2.1", nil },
 			bind:        "0.0.0.0",
 			advertise:   "",
 
@@ -64,7 +65,7 @@ func TestCalculateAdvertiseAddress(t *testing.T) {
 		},
 		{
 			name:        "error if getPrivateAddress errors",
-			privateIPFn: func() (string, error) { return "", errors.New("some error") },
+			privateIPFn: func(AddressFamily) (string, error) { return "", errors.New("some error") },
 			bind:        "0.0.0.0",
 			advertise:   "",
 
@@ -72,7 +73,7 @@ func TestCalculateAdvertiseAddress(t *testing.T) {
 		},
 		{
 			name:        "error if getPrivateAddress returns an invalid address",
-			privateIPFn: func() (string, error) { return "invalid", nil },
+			privateIPFn: func(AddressFamily) (string, error) { return "invalid", nil },
 			bind:        "0.0.0.0",
 			advertise:   "",
 
@@ -80,7 +81,7 @@ func TestCalculateAdvertiseAddress(t *testing.T) {
 		},
 		{
 			name:        "error if getPrivateAddress returns an empty address",
-			privateIPFn: func() (string, error) { return "", nil },
+			privateIPFn: func(AddressFamily) (string, error) { return "", nil },
 			bind:        "0.0.0.0",
 			advertise:   "",
 
@@ -89,8 +90,8 @@ func TestCalculateAdvertiseAddress(t *testing.T) {
 
 		{
 			name:                   "discover public advertise address",
-			privateIPFn:            func() (string, error) { return "", nil },
-			publicIPFn:             func() (string, error) { return "192.0.2.1", nil },
+			privateIPFn:            func(AddressFamily) (string, error) { return "", nil },
+			publicIPFn:             func(AddressFamily) (string, error) { return "192.0.2.1", nil },
 			bind:                   "0.0.0.0",
 			advertise:              "",
 			allowInsecureAdvertise: true,
@@ -100,8 +101,8 @@ func TestCalculateAdvertiseAddress(t *testing.T) {
 		},
 		{
 			name:                   "error if getPublicAddress errors",
-			privateIPFn:            func() (string, error) { return "", nil },
-			publicIPFn:             func() (string, error) { return "", errors.New("some error") },
+			privateIPFn:            func(AddressFamily) (string, error) { return "", nil },
+			publicIPFn:             func(AddressFamily) (string, error) { return "", errors.New("some error") },
 			bind:                   "0.0.0.0",
 			advertise:              "",
 			allowInsecureAdvertise: true,
@@ -110,8 +111,8 @@ func TestCalculateAdvertiseAddress(t *testing.T) {
 		},
 		{
 			name:                   "error if getPublicAddress returns an invalid address",
-			privateIPFn:            func() (string, error) { return "", nil },
-			publicIPFn:             func() (string, error) { return "invalid", nil },
+			privateIPFn:            func(AddressFamily) (string, error) { return "", nil },
+			publicIPFn:             func(AddressFamily) (string, error) { return "invalid", nil },
 			bind:                   "0.0.0.0",
 			advertise:              "",
 			allowInsecureAdvertise: true,
@@ -120,8 +121,8 @@ func TestCalculateAdvertiseAddress(t *testing.T) {
 		},
 		{
 			name:                   "error if getPublicAddress returns an empty address",
-			privateIPFn:            func() (string, error) { return "", nil },
-			publicIPFn:             func() (string, error) { return "", nil },
+			privateIPFn:            func(AddressFamily) (string, error) { return "", nil },
+			publicIPFn:             func(AddressFamily) (string, error) { return "", nil },
 			bind:                   "0.0.0.0",
 			advertise:              "",
 			allowInsecureAdvertise: true,
@@ -134,7 +135,7 @@ func TestCalculateAdvertiseAddress(t *testing.T) {
 		t.Run(c.name, func(t *testing.T) {
 			getPrivateAddress = c.privateIPFn
 			getPublicAddress = c.publicIPFn
-			got, err := calculateAdvertiseAddress(c.bind, c.advertise, c.allowInsecureAdvertise)
+			got, err := calculateAdvertiseAddress(c.bind, c.advertise, AddressFamilyAny, c.allowInsecureAdvertise)
 			if c.err {
 				require.Error(t, err)
 				return
@@ -144,3 +145,35 @@ func TestCalculateAdvertiseAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestFirstIP(t *testing.T) {
+	ifAddrs := sockaddr.IfAddrs{
+		{SockAddr: sockaddr.MustIPv4Addr("192.0.2.1/24")},
+		{SockAddr: sockaddr.MustIPv6Addr("2001:db8::1/64")},
+	}
+	require.Equal(t, "192.0.2.1", firstIP(ifAddrs, AddressFamilyAny))
+	require.Equal(t, "192.0.2.1", firstIP(ifAddrs, AddressFamilyIPv4))
+	require.Equal(t, "2001:db8::1", firstIP(ifAddrs, AddressFamilyIPv6))
+	require.Equal(t, "", firstIP(ifAddrs[:1], AddressFamilyIPv6))
+}
+
+func TestDiscoverAdvertiseAddressFamily(t *testing.T) {
+	oldPrivate, oldPublic := getPrivateAddress, getPublicAddress
+	defer func() {
+		getPrivateAddress, getPublicAddress = oldPrivate, oldPublic
+	}()
+	getPrivateAddress = func(family AddressFamily) (string, error) {
+		if family == AddressFamilyIPv6 {
+			return "", nil
+		}
+		return "192.0.2.1", nil
+	}
+	getPublicAddress = func(AddressFamily) (string, error) { return "", nil }
+
+	ip, err := calculateAdvertiseAddress("::", "", AddressFamilyIPv4, false)
+	require.NoError(t, err)
+	require.Equal(t, "192.0.2.1", ip.String())
+
+	_, err = calculateAdvertiseAddress("::", "", AddressFamilyIPv6, false)
+	require.EqualError(t, err, "no private IPv6 address found, explicit advertise addr not provided")
+}
@@ -39,6 +39,9 @@ type ClusterPeer interface {
 	Status() string
 	// Peers returns the peer nodes in the cluster.
 	Peers() []ClusterMember
+	// Events returns the last changes of the topology of the cluster, most
+	// recent first.
+	Events() []Event
 }
 
 // ClusterMember interface that represents node peers in a cluster.
@@ -73,6 +76,10 @@ type Peer struct {
 	knownPeers    []string
 	advertiseAddr string
 
+	name       string
+	events     *EventLog
+	eventHooks []func(Event)
+
 	failedReconnectionsCounter prometheus.Counter
 	reconnectionsCounter       prometheus.Counter
 	failedRefreshCounter       prometheus.Counter
@@ -130,8 +137,9 @@ const (
 func Create(
 	l *slog.Logger,
 	reg prometheus.Registerer,
-	bindAddr string,
+	bindAddrs []string,
 	advertiseAddr string,
+	advertiseFamily AddressFamily,
 	knownPeers []string,
 	waitIfEmpty bool,
 	pushPullInterval time.Duration,
@@ -143,14 +151,13 @@ func Create(
 	allowInsecureAdvertise bool,
 	label string,
 ) (*Peer, error) {
-	bindHost, bindPortStr, err := net.SplitHostPort(bindAddr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid listen address: %w", err)
-	}
-	bindPort, err := strconv.Atoi(bindPortStr)
+	bindHosts, bindPort, err := parseListenAddresses(bindAddrs)
 	if err != nil {
-		return nil, fmt.Errorf("address %s: invalid port: %w", bindAddr, err)
+		return nil, err
 	}
+	// The first listen address is the one used to deduce the advertise
+	// address.
+	bindAddr, bindHost := bindAddrs[0], bindHosts[0]
 
 	var advertiseHost string
 	var advertisePort int
@@ -158,7 +165,7 @@ func Create(
 		var advertisePortStr string
 		advertiseHost, advertisePortStr, err = net.SplitHostPort(advertiseAddr)
 		if err != nil {
-			return nil, fmt.Errorf("invalid advertise address: %w", err)
+			return nil, fmt.Errorf("invalid advertise address %q: %w", advertiseAddr, err)
 		}
 		advertisePort, err = strconv.Atoi(advertisePortStr)
 		if err != nil {
@@ -166,6 +173,14 @@ func Create(
 		}
 	}
 
+	switch advertiseFamily {
+	case "":
+		advertiseFamily = AddressFamilyAny
+	case AddressFamilyAny, AddressFamilyIPv4, AddressFamilyIPv6:
+	default:
+		return nil, fmt.Errorf("invalid advertise address family %q", advertiseFamily)
+	}
+
 	resolvedPeers, err := resolvePeers(context.Background(), knownPeers, advertiseAddr, &net.Resolver{}, waitIfEmpty)
 	if err != nil {
 		return nil, fmt.Errorf("resolve peers: %w", err)
@@ -173,7 +188,7 @@ func Create(
 	l.Debug("resolved peers to following addresses", "peers", strings.Join(resolvedPeers, ","))
 
 	// Initial validation of user-specified advertise address.
-	addr, err := calculateAdvertiseAddress(bindHost, advertiseHost, allowInsecureAdvertise)
+	addr, err := calculateAdvertiseAddress(bindHost, advertiseHost, advertiseFamily, allowInsecureAdvertise)
 	if err != nil {
 		l.Warn("couldn't deduce an advertise address: " + err.Error())
 	} else if hasNonlocal(resolvedPeers) && isUnroutable(addr.String()) {
@@ -201,6 +216,8 @@ func Create(
 		peers:         map[string]peer{},
 		resolvedPeers: resolvedPeers,
 		knownPeers:    knownPeers,
+		name:          name.String(),
+		events:        NewEventLog(DefaultEventLogSize),
 	}
 
 	p.register(reg, name.String())
@@ -232,17 +249,39 @@ func Create(
 	if advertiseHost != "" {
 		cfg.AdvertiseAddr = advertiseHost
 		cfg.AdvertisePort = advertisePort
-		p.setInitialFailed(resolvedPeers, fmt.Sprintf("%s:%d", advertiseHost, advertisePort))
+		p.setInitialFailed(resolvedPeers, net.JoinHostPort(advertiseHost, strconv.Itoa(advertisePort)))
 	} else {
 		p.setInitialFailed(resolvedPeers, bindAddr)
 	}
 
-	if tlsTransportConfig != nil {
+	switch {
+	case tlsTransportConfig != nil:
+		if len(bindHosts) > 1 {
+			return nil, fmt.Errorf("tls transport: only one listen address is supported, got %d", len(bindHosts))
+		}
 		l.Info("using TLS for gossip")
 		cfg.Transport, err = NewTLSTransport(context.Background(), l, reg, cfg.BindAddr, cfg.BindPort, tlsTransportConfig)
 		if err != nil {
 			return nil, fmt.Errorf("tls transport: %w", err)
 		}
+	case len(bindHosts) > 1:
+		// memberlist only creates a transport listening on one address.
+		l.Info("listening on multiple addresses", "addrs", strings.Join(bindAddrs, ","))
+		nt, err := memberlist.NewNetTransport(&memberlist.NetTransportConfig{
+			BindAddrs: bindHosts,
+			BindPort:  bindPort,
+			Logger:    cfg.Logger,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("net transport: %w", err)
+		}
+		if bindPort == 0 {
+			cfg.BindPort = nt.GetAutoBindPort()
+			if cfg.AdvertisePort == 0 {
+				cfg.AdvertisePort = cfg.BindPort
+			}
+		}
+		cfg.Transport = nt
 	}
 
 	ml, err := memberlist.Create(cfg)
@@ -253,6 +292,38 @@ func Create(
 	return p, nil
 }
 
+// parseListenAddresses returns the hosts and the port of the listen
+// addresses, which must all be IP addresses with the same port.
+func parseListenAddresses(addrs []string) ([]string, int, error) {
+	if len(addrs) == 0 {
+		return nil, 0, errors.New("no listen address")
+	}
+	var (
+		hosts = make([]string, 0, len(addrs))
+		port  int
+	)
+	for i, addr := range addrs {
+		host, portStr, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid listen address %q: %w", addr, err)
+		}
+		p, err := strconv.Atoi(portStr)
+		if err != nil {
+			return nil, 0, fmt.Errorf("address %s: invalid port: %w", addr, err)
+		}
+		if host != "" && net.ParseIP(host) == nil {
+			return nil, 0, fmt.Errorf("invalid listen address %q: host must be an IP address", addr)
+		}
+		if i == 0 {
+			port = p
+		} else if p != port {
+			return nil, 0, fmt.Errorf("invalid listen address %q: port must be the same as the port of %q", addr, addrs[0])
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, port, nil
+}
+
 func (p *Peer) Join(
 	reconnectInterval time.Duration,
 	reconnectTimeout time.Duration,
@@ -496,6 +567,7 @@ func (p *Peer) peerJoin(n *memberlist.Node) {
 		p.logger.Debug("peer rejoined", "peer", pr.Node)
 		p.failedPeers = removeOldPeer(p.failedPeers, pr.Address())
 	}
+	p.emit(newEvent(EventJoin, n, p.name))
 }
 
 func (p *Peer) peerLeave(n *memberlist.Node) {
@@ -516,6 +588,37 @@ func (p *Peer) peerLeave(n *memberlist.Node) {
 
 	p.peerLeaveCounter.Inc()
 	p.logger.Debug("peer left", "peer", pr.Node)
+
+	t := EventLeave
+	if n.State == memberlist.StateDead {
+		t = EventDead
+	}
+	p.emit(newEvent(t, n, p.name))
+}
+
+// OnEvent registers a function called with the changes of the topology of
+// the cluster. The function must not block.
+func (p *Peer) OnEvent(f func(Event)) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.eventHooks = append(p.eventHooks, f)
+}
+
+// Events returns the last changes of the topology of the cluster, most recent
+// first.
+func (p *Peer) Events() []Event {
+	return p.events.Events()
+}
+
+func (p *Peer) emit(e Event) {
+	p.logger.Info("cluster event", "type", e.Type, "peer", e.Name, "addr", e.Address)
+	p.events.Add(e)
+
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	for _, f := range p.eventHooks {
+		f(e)
+	}
 }
 
 func (p *Peer) peerUpdate(n *memberlist.Node) {
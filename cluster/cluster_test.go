@@ -15,6 +15,7 @@ package cluster
 
 import (
 	"context"
+	"net"
 	"testing"
 	"time"
 
@@ -43,8 +44,9 @@ func testJoinLeave(t *testing.T) {
 	p, err := Create(
 		logger,
 		prometheus.NewRegistry(),
-		"127.0.0.1:0",
+		[]string{"127.0.0.1:0"},
 		"",
+		AddressFamilyAny,
 		[]string{},
 		true,
 		DefaultPushPullInterval,
@@ -78,8 +80,9 @@ func testJoinLeave(t *testing.T) {
 	p2, err := Create(
 		logger,
 		prometheus.NewRegistry(),
-		"127.0.0.1:0",
+		[]string{"127.0.0.1:0"},
 		"",
+		AddressFamilyAny,
 		[]string{p.Self().Address()},
 		true,
 		DefaultPushPullInterval,
@@ -114,8 +117,9 @@ func testReconnect(t *testing.T) {
 	p, err := Create(
 		logger,
 		prometheus.NewRegistry(),
-		"127.0.0.1:0",
+		[]string{"127.0.0.1:0"},
 		"",
+		AddressFamilyAny,
 		[]string{},
 		true,
 		DefaultPushPullInterval,
@@ -140,8 +144,9 @@ func testReconnect(t *testing.T) {
 	p2, err := Create(
 		logger,
 		prometheus.NewRegistry(),
-		"127.0.0.1:0",
+		[]string{"127.0.0.1:0"},
 		"",
+		AddressFamilyAny,
 		[]string{},
 		true,
 		DefaultPushPullInterval,
@@ -181,8 +186,9 @@ func testRemoveFailedPeers(t *testing.T) {
 	p, err := Create(
 		logger,
 		prometheus.NewRegistry(),
-		"127.0.0.1:0",
+		[]string{"127.0.0.1:0"},
 		"",
+		AddressFamilyAny,
 		[]string{},
 		true,
 		DefaultPushPullInterval,
@@ -233,8 +239,9 @@ func testInitiallyFailingPeers(t *testing.T) {
 	p, err := Create(
 		logger,
 		prometheus.NewRegistry(),
-		"127.0.0.1:0",
+		[]string{"127.0.0.1:0"},
 		"",
+		AddressFamilyAny,
 		[]string{},
 		true,
 		DefaultPushPullInterval,
@@ -281,8 +288,9 @@ func testTLSConnection(t *testing.T) {
 	p1, err := Create(
 		logger,
 		prometheus.NewRegistry(),
-		"127.0.0.1:0",
+		[]string{"127.0.0.1:0"},
 		"",
+		AddressFamilyAny,
 		[]string{},
 		true,
 		DefaultPushPullInterval,
@@ -313,8 +321,9 @@ func testTLSConnection(t *testing.T) {
 	p2, err := Create(
 		logger,
 		prometheus.NewRegistry(),
-		"127.0.0.1:0",
+		[]string{"127.0.0.1:0"},
 		"",
+		AddressFamilyAny,
 		[]string{p1.Self().Address()},
 		true,
 		DefaultPushPullInterval,
@@ -344,3 +353,54 @@ func testTLSConnection(t *testing.T) {
 	require.Equal(t, p2.Self().Address(), p1.peers[p2.Self().Address()].Node.Address())
 	require.Equal(t, p2.Name(), p1.failedPeers[0].Name)
 }
+
+func TestParseListenAddresses(t *testing.T) {
+	hosts, port, err := parseListenAddresses([]string{"10.0.0.1:9094", "[fd00::1]:9094", ":9094"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.1", "fd00::1", ""}, hosts)
+	require.Equal(t, 9094, port)
+
+	for _, tc := range []struct {
+		addrs []string
+		err   string
+	}{
+		{addrs: nil, err: "no listen address"},
+		{addrs: []string{"10.0.0.1:9094", "fd00::1"}, err: `invalid listen address "fd00::1": address fd00::1: too many colons in address`},
+		{addrs: []string{"localhost:9094"}, err: `invalid listen address "localhost:9094": host must be an IP address`},
+		{addrs: []string{"10.0.0.1:9094", "[fd00::1]:9095"}, err: `invalid listen address "[fd00::1]:9095": port must be the same as the port of "10.0.0.1:9094"`},
+	} {
+		_, _, err := parseListenAddresses(tc.addrs)
+		require.EqualError(t, err, tc.err)
+	}
+}
+
+func TestCreateMultipleListenAddresses(t *testing.T) {
+	p, err := Create(
+		promslog.NewNopLogger(),
+		prometheus.NewRegistry(),
+		[]string{"127.0.0.1:0", "127.0.0.2:0"},
+		"",
+		AddressFamilyAny,
+		[]string{},
+		true,
+		DefaultPushPullInterval,
+		DefaultGossipInterval,
+		DefaultTCPTimeout,
+		DefaultProbeTimeout,
+		DefaultProbeInterval,
+		nil,
+		false,
+		"",
+	)
+	require.NoError(t, err)
+	defer p.Leave(0)
+
+	// The peer is reachable on every listen address.
+	_, port, err := net.SplitHostPort(p.Self().Address())
+	require.NoError(t, err)
+	for _, host := range []string{"127.0.0.1", "127.0.0.2"} {
+		conn, err := net.Dial("tcp", net.JoinHostPort(host, port))
+		require.NoError(t, err)
+		conn.Close()
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+)
+
+// DefaultEventLogSize is the number of cluster events kept by a peer.
+const DefaultEventLogSize = 100
+
+// EventType is the type of a cluster event.
+type EventType string
+
+const (
+	// EventJoin is emitted when a peer joins the cluster.
+	EventJoin EventType = "join"
+	// EventLeave is emitted when a peer leaves the cluster gracefully.
+	EventLeave EventType = "leave"
+	// EventDead is emitted when a peer is marked dead after failing to
+	// respond to probes.
+	EventDead EventType = "dead"
+)
+
+// Event is a change of the topology of the cluster as seen by a peer.
+type Event struct {
+	Time time.Time `json:"time"`
+	Type EventType `json:"type"`
+	// Name and Address are the name and address of the peer which joined,
+	// left or died.
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	// Reporter is the name of the peer which observed the event.
+	Reporter string `json:"reporter"`
+}
+
+func newEvent(t EventType, n *memberlist.Node, reporter string) Event {
+	return Event{
+		Time:     time.Now().UTC(),
+		Type:     t,
+		Name:     n.Name,
+		Address:  n.Address(),
+		Reporter: reporter,
+	}
+}
+
+// EventLog keeps the last cluster events.
+type EventLog struct {
+	mtx    sync.Mutex
+	events []Event
+	next   int
+	full   bool
+}
+
+// NewEventLog returns a new EventLog keeping the given number of events.
+func NewEventLog(size int) *EventLog {
+	if size <= 0 {
+		size = DefaultEventLogSize
+	}
+	return &EventLog{events: make([]Event, size)}
+}
+
+// Add adds an event, replacing the oldest one if the log is full.
+func (l *EventLog) Add(e Event) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.events[l.next] = e
+	l.next = (l.next + 1) % len(l.events)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// Events returns the events, most recent first.
+func (l *EventLog) Events() []Event {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	n := l.next
+	if l.full {
+		n = len(l.events)
+	}
+	res := make([]Event, 0, n)
+	for i := 1; i <= n; i++ {
+		res = append(res, l.events[(l.next-i+len(l.events))%len(l.events)])
+	}
+	return res
+}
+
+// EventWebhook sends the cluster events as JSON to a webhook.
+type EventWebhook struct {
+	url    string
+	client *http.Client
+	logger *slog.Logger
+	events chan Event
+}
+
+// NewEventWebhook returns a new EventWebhook posting to the URL. Events are
+// queued and dropped if too many are pending.
+func NewEventWebhook(url string, client *http.Client, l *slog.Logger) *EventWebhook {
+	return &EventWebhook{
+		url:    url,
+		client: client,
+		logger: l,
+		events: make(chan Event, DefaultEventLogSize),
+	}
+}
+
+// Notify queues the event to be sent. It doesn't block.
+func (w *EventWebhook) Notify(e Event) {
+	select {
+	case w.events <- e:
+	default:
+		w.logger.Warn("Dropping cluster event, too many events pending", "type", e.Type, "peer", e.Name)
+	}
+}
+
+// Run sends the queued events until the context is canceled.
+func (w *EventWebhook) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-w.events:
+			if err := w.send(ctx, e); err != nil {
+				w.logger.Warn("Sending cluster event to webhook failed", "type", e.Type, "peer", e.Name, "err", err)
+			}
+		}
+	}
+}
+
+func (w *EventWebhook) send(ctx context.Context, e Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventLog(t *testing.T) {
+	l := NewEventLog(2)
+	require.Empty(t, l.Events())
+
+	l.Add(Event{Name: "a"})
+	l.Add(Event{Name: "b"})
+	l.Add(Event{Name: "c"})
+	events := l.Events()
+	require.Len(t, events, 2)
+	require.Equal(t, "c", events[0].Name)
+	require.Equal(t, "b", events[1].Name)
+}
+
+func TestPeerEvents(t *testing.T) {
+	p, err := Create(
+		promslog.NewNopLogger(),
+		prometheus.NewRegistry(),
+		[]string{"127.0.0.1:0"},
+		"",
+		AddressFamilyAny,
+		[]string{},
+		true,
+		DefaultPushPullInterval,
+		DefaultGossipInterval,
+		DefaultTCPTimeout,
+		DefaultProbeTimeout,
+		DefaultProbeInterval,
+		nil,
+		false,
+		"",
+	)
+	require.NoError(t, err)
+	defer p.Leave(0)
+
+	var hooked []Event
+	p.OnEvent(func(e Event) { hooked = append(hooked, e) })
+
+	n := &memberlist.Node{Name: "other", Addr: net.ParseIP("10.0.0.1"), Port: 9094}
+	p.peerJoin(n)
+	n.State = memberlist.StateLeft
+	p.peerLeave(n)
+	n.State = memberlist.StateAlive
+	p.peerJoin(n)
+	n.State = memberlist.StateDead
+	p.peerLeave(n)
+
+	require.Len(t, hooked, 4)
+	var types []EventType
+	for _, e := range hooked {
+		require.Equal(t, "other", e.Name)
+		require.Equal(t, "10.0.0.1:9094", e.Address)
+		require.Equal(t, p.Name(), e.Reporter)
+		types = append(types, e.Type)
+	}
+	require.Equal(t, []EventType{EventJoin, EventLeave, EventJoin, EventDead}, types)
+
+	// The log also holds the join of the peer itself, most recent first.
+	events := p.Events()
+	require.Len(t, events, 5)
+	require.Equal(t, EventDead, events[0].Type)
+	require.Equal(t, p.Name(), events[4].Name)
+	require.Equal(t, EventJoin, events[4].Type)
+}
+
+func TestEventWebhook(t *testing.T) {
+	received := make(chan Event, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var e Event
+		if err := json.NewDecoder(r.Body).Decode(&e); err == nil {
+			received <- e
+		}
+	}))
+	defer srv.Close()
+
+	w := NewEventWebhook(srv.URL, srv.Client(), promslog.NewNopLogger())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	w.Notify(Event{Time: now, Type: EventDead, Name: "other", Address: "10.0.0.1:9094", Reporter: "self"})
+	select {
+	case e := <-received:
+		require.Equal(t, Event{Time: now, Type: EventDead, Name: "other", Address: "10.0.0.1:9094", Reporter: "self"}, e)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not received")
+	}
+}
@@ -14,9 +14,13 @@
 package cluster
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/prometheus/common/config"
 	"github.com/prometheus/exporter-toolkit/web"
@@ -24,8 +28,49 @@ import (
 )
 
 type TLSTransportConfig struct {
-	TLSServerConfig *web.TLSConfig    `yaml:"tls_server_config"`
-	TLSClientConfig *config.TLSConfig `yaml:"tls_client_config"`
+	TLSServerConfig   *web.TLSConfig     `yaml:"tls_server_config"`
+	TLSClientConfig   *config.TLSConfig  `yaml:"tls_client_config"`
+	PeerAuthorization *PeerAuthorization `yaml:"peer_authorization"`
+}
+
+// PeerAuthorization restricts the peers of the cluster to those whose
+// certificates have one of the allowed subject alternative names, on top of
+// chaining to the trusted CA.
+type PeerAuthorization struct {
+	// AllowedDNSNames are the allowed DNS names.
+	AllowedDNSNames []string `yaml:"allowed_dns_names"`
+	// AllowedURIs are the allowed URIs, e.g. SPIFFE IDs such as
+	// spiffe://example.org/ns/monitoring/sa/alertmanager.
+	AllowedURIs []string `yaml:"allowed_uris"`
+}
+
+// authorized returns whether the certificate has one of the allowed subject
+// alternative names.
+func (a *PeerAuthorization) authorized(cert *x509.Certificate) bool {
+	for _, name := range cert.DNSNames {
+		if slices.Contains(a.AllowedDNSNames, name) {
+			return true
+		}
+	}
+	for _, uri := range cert.URIs {
+		if slices.Contains(a.AllowedURIs, uri.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyConnection checks that the peer of a verified TLS connection is
+// authorized.
+func (a *PeerAuthorization) verifyConnection(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("peer did not present a certificate")
+	}
+	cert := cs.PeerCertificates[0]
+	if !a.authorized(cert) {
+		return fmt.Errorf("peer certificate %q is not authorized", cert.Subject)
+	}
+	return nil
 }
 
 func GetTLSTransportConfig(configPath string) (*TLSTransportConfig, error) {
@@ -48,6 +93,14 @@ func GetTLSTransportConfig(configPath string) (*TLSTransportConfig, error) {
 	if cfg.TLSServerConfig == nil {
 		return nil, fmt.Errorf("missing 'tls_server_config' entry in the TLS configuration")
 	}
+	if a := cfg.PeerAuthorization; a != nil {
+		if len(a.AllowedDNSNames) == 0 && len(a.AllowedURIs) == 0 {
+			return nil, errors.New("'peer_authorization' must allow at least one DNS name or URI")
+		}
+		if cfg.TLSServerConfig.ClientAuth != "RequireAndVerifyClientCert" {
+			return nil, errors.New("'peer_authorization' requires the 'RequireAndVerifyClientCert' client_auth_type in 'tls_server_config'")
+		}
+	}
 
 	cfg.TLSServerConfig.SetDirectory(filepath.Dir(configPath))
 	cfg.TLSClientConfig.SetDirectory(filepath.Dir(configPath))
@@ -62,6 +62,9 @@ type TLSTransport struct {
 	streamsRcvd prometheus.Counter
 	readErrs    prometheus.Counter
 	writeErrs   *prometheus.CounterVec
+	// unauthorizedPeers counts the connections rejected by the peer
+	// authorization.
+	unauthorizedPeers prometheus.Counter
 }
 
 // NewTLSTransport returns a TLS transport with the given configuration.
@@ -124,6 +127,19 @@ func NewTLSTransport(
 
 	t.registerMetrics(reg)
 
+	if a := cfg.PeerAuthorization; a != nil {
+		verify := func(cs tls.ConnectionState) error {
+			if err := a.verifyConnection(cs); err != nil {
+				t.unauthorizedPeers.Inc()
+				t.logger.Warn("rejected unauthorized peer", "err", err)
+				return err
+			}
+			return nil
+		}
+		tlsServerCfg.VerifyConnection = verify
+		tlsClientCfg.VerifyConnection = verify
+	}
+
 	go func() {
 		t.listen()
 		close(t.done)
@@ -340,7 +356,17 @@ func (t *TLSTransport) registerMetrics(reg prometheus.Registerer) {
 		[]string{"connection_type"},
 	)
 
+	t.unauthorizedPeers = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Subsystem: metricSubsystem,
+			Name:      "unauthorized_peers_total",
+			Help:      "The number of connections rejected because the certificate of the peer is not authorized.",
+		},
+	)
+
 	if reg != nil {
+		reg.MustRegister(t.unauthorizedPeers)
 		reg.MustRegister(t.packetsSent)
 		reg.MustRegister(t.packetsRcvd)
 		reg.MustRegister(t.streamsSent)
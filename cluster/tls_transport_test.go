@@ -17,13 +17,24 @@ import (
 	"bufio"
 	"bytes"
 	context2 "context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
 	"io"
+	"math/big"
 	"net"
+	"net/url"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prometheus/common/promslog"
 	"github.com/stretchr/testify/require"
 )
@@ -88,6 +99,14 @@ func TestNewTLSTransport(t *testing.T) {
 			tlsConfFile: "testdata/tls_config_with_missing_client.yml",
 			bindAddr:    localhost,
 		},
+		{
+			tlsConfFile: "testdata/tls_config_peer_authorization.yml",
+			bindAddr:    localhost,
+		},
+		{
+			tlsConfFile: "testdata/tls_config_peer_authorization_without_client_cert.yml",
+			err:         "'peer_authorization' requires the 'RequireAndVerifyClientCert' client_auth_type in 'tls_server_config'",
+		},
 	} {
 		t.Run("", func(t *testing.T) {
 			transport, err := newTLSTransport(tc.tlsConfFile, tc.bindAddr, tc.bindPort)
@@ -247,6 +266,142 @@ func TestShutdown(t *testing.T) {
 	require.Contains(t, buf.String(), "shutting down tls transport")
 }
 
+// writePeerAuthorizationConfig writes a TLS transport configuration with a
+// new CA and a certificate for localhost to dir, as the certificates of
+// testdata expire.
+func writePeerAuthorizationConfig(t *testing.T, dir string) string {
+	t.Helper()
+
+	newCert := func(tmpl, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*ecdsa.PrivateKey, *x509.Certificate, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		if parent == nil {
+			parent, parentKey = tmpl, key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		return key, cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+	writeKey := func(name string, key *ecdsa.PrivateKey) {
+		der, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600))
+	}
+
+	now := time.Now()
+	caKey, ca, caPEM := newCert(&x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+	nodeKey, _, nodePEM := newCert(&x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "node"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ca.pem"), caPEM, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "node.pem"), nodePEM, 0o600))
+	writeKey("node-key.pem", nodeKey)
+
+	cfg := filepath.Join(dir, "tls_config.yml")
+	require.NoError(t, os.WriteFile(cfg, []byte(`
+tls_server_config:
+  cert_file: node.pem
+  key_file: node-key.pem
+  client_ca_file: ca.pem
+  client_auth_type: RequireAndVerifyClientCert
+tls_client_config:
+  cert_file: node.pem
+  key_file: node-key.pem
+  ca_file: ca.pem
+peer_authorization:
+  allowed_dns_names: [localhost]
+`), 0o600))
+	return cfg
+}
+
+func TestPeerAuthorization(t *testing.T) {
+	file := writePeerAuthorizationConfig(t, t.TempDir())
+	newTransport := func(allowed string) *TLSTransport {
+		t.Helper()
+		cfg := loadTLSTransportConfig(t, file)
+		cfg.PeerAuthorization.AllowedDNSNames = []string{allowed}
+		tr, err := NewTLSTransport(context2.Background(), logger, nil, "127.0.0.1", 0, cfg)
+		require.NoError(t, err)
+		t.Cleanup(func() { tr.Shutdown() })
+		return tr
+	}
+	addr := func(tr *TLSTransport) string {
+		return fmt.Sprintf("%s:%d", tr.bindAddr, tr.GetAutoBindPort())
+	}
+
+	var (
+		allowing  = newTransport("localhost")
+		rejecting = newTransport("alertmanager.example.com")
+	)
+
+	// The peer certificates of both transports have the localhost DNS name.
+	conn, err := allowing.DialTimeout(addr(allowing), 5*time.Second)
+	require.NoError(t, err)
+	conn.Close()
+	require.Equal(t, 0.0, testutil.ToFloat64(allowing.unauthorizedPeers))
+
+	// The client rejects the server.
+	_, err = rejecting.DialTimeout(addr(allowing), 5*time.Second)
+	require.ErrorContains(t, err, "is not authorized")
+	require.Equal(t, 1.0, testutil.ToFloat64(rejecting.unauthorizedPeers))
+
+	// The server rejects the client.
+	conn, err = allowing.DialTimeout(addr(rejecting), 5*time.Second)
+	if err == nil {
+		conn.Close()
+	}
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(rejecting.unauthorizedPeers) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	select {
+	case <-rejecting.StreamCh():
+		t.Fatal("stream of an unauthorized peer accepted")
+	default:
+	}
+}
+
+func TestPeerAuthorizationURIs(t *testing.T) {
+	a := &PeerAuthorization{
+		AllowedDNSNames: []string{"alertmanager.example.com"},
+		AllowedURIs:     []string{"spiffe://example.org/ns/monitoring/sa/alertmanager"},
+	}
+	uri := func(s string) *url.URL {
+		u, err := url.Parse(s)
+		require.NoError(t, err)
+		return u
+	}
+	for _, tc := range []struct {
+		cert       *x509.Certificate
+		authorized bool
+	}{
+		{cert: &x509.Certificate{DNSNames: []string{"alertmanager.example.com"}}, authorized: true},
+		{cert: &x509.Certificate{URIs: []*url.URL{uri("spiffe://example.org/ns/monitoring/sa/alertmanager")}}, authorized: true},
+		{cert: &x509.Certificate{URIs: []*url.URL{uri("spiffe://example.org/ns/default/sa/alertmanager")}}, authorized: false},
+		{cert: &x509.Certificate{DNSNames: []string{"prometheus.example.com"}}, authorized: false},
+		{cert: &x509.Certificate{}, authorized: false},
+	} {
+		require.Equal(t, tc.authorized, a.authorized(tc.cert), "%v %v", tc.cert.DNSNames, tc.cert.URIs)
+	}
+}
+
 func loadTLSTransportConfig(tb testing.TB, filename string) *TLSTransportConfig {
 	tb.Helper()
 
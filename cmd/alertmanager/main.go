@@ -17,6 +17,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"net/http"
@@ -35,6 +36,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	versioncollector "github.com/prometheus/client_golang/prometheus/collectors/version"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	commoncfg "github.com/prometheus/common/config"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/common/promslog"
 	promslogflag "github.com/prometheus/common/promslog/flag"
@@ -42,25 +44,40 @@ import (
 	"github.com/prometheus/common/version"
 	"github.com/prometheus/exporter-toolkit/web"
 	webflag "github.com/prometheus/exporter-toolkit/web/kingpinflag"
+	"go.uber.org/atomic"
 	"go.uber.org/automaxprocs/maxprocs"
 
+	"github.com/prometheus/alertmanager/ack"
 	"github.com/prometheus/alertmanager/api"
+	apiv2 "github.com/prometheus/alertmanager/api/v2"
+	"github.com/prometheus/alertmanager/archive"
 	"github.com/prometheus/alertmanager/cluster"
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/config/receiver"
+	"github.com/prometheus/alertmanager/consistency"
 	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/encryption"
 	"github.com/prometheus/alertmanager/featurecontrol"
+	"github.com/prometheus/alertmanager/ingest"
 	"github.com/prometheus/alertmanager/inhibit"
+	"github.com/prometheus/alertmanager/maintenance"
 	"github.com/prometheus/alertmanager/matcher/compat"
 	"github.com/prometheus/alertmanager/nflog"
 	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/provider/mem"
+	"github.com/prometheus/alertmanager/retry"
+	"github.com/prometheus/alertmanager/selfmonitor"
 	"github.com/prometheus/alertmanager/silence"
+	"github.com/prometheus/alertmanager/slackapp"
+	"github.com/prometheus/alertmanager/snapshot"
 	"github.com/prometheus/alertmanager/template"
 	"github.com/prometheus/alertmanager/timeinterval"
 	"github.com/prometheus/alertmanager/types"
 	"github.com/prometheus/alertmanager/ui"
 	reactapp "github.com/prometheus/alertmanager/ui/react-app"
+	"github.com/prometheus/alertmanager/wal"
 )
 
 var (
@@ -136,34 +153,82 @@ func main() {
 	os.Exit(run())
 }
 
-func run() int {
+func run() (code int) {
 	if os.Getenv("DEBUG") != "" {
 		runtime.SetBlockProfileRate(20)
 		runtime.SetMutexProfileFraction(20)
 	}
 
+	var configFileSet bool
 	var (
-		configFile          = kingpin.Flag("config.file", "Alertmanager configuration file name.").Default("alertmanager.yml").String()
+		configFile          = kingpin.Flag("config.file", "Alertmanager configuration file name. If unset and alertmanager.yml doesn't exist, the default configuration built into Alertmanager is loaded.").Default("alertmanager.yml").IsSetByUser(&configFileSet).String()
+		printDefaultConfig  = kingpin.Flag("config.print-default", "Print the default configuration built into Alertmanager and exit.").Bool()
+		ageIdentityFile     = kingpin.Flag("config.age-identity-file", "File of the age identities decrypting the values of the configuration file encrypted with age.").String()
+		secretsInterval     = kingpin.Flag("config.secrets-reload-interval", "Interval between checks of the secret files referenced by the receivers, the integrations whose files changed being rebuilt without reloading the configuration. If zero, the files are not checked.").Default("1m").Duration()
 		dataDir             = kingpin.Flag("storage.path", "Base path for data storage.").Default("data/").String()
 		retention           = kingpin.Flag("data.retention", "How long to keep data for.").Default("120h").Duration()
 		maintenanceInterval = kingpin.Flag("data.maintenance-interval", "Interval between garbage collection and snapshotting to disk of the silences and the notification logs.").Default("15m").Duration()
 		maxSilences         = kingpin.Flag("silences.max-silences", "Maximum number of silences, including expired silences. If negative or zero, no limit is set.").Default("0").Int()
 		maxSilenceSizeBytes = kingpin.Flag("silences.max-silence-size-bytes", "Maximum silence size in bytes. If negative or zero, no limit is set.").Default("0").Int()
+		silenceResolveGrace = kingpin.Flag("silences.expire-on-resolve-grace-period", "How long no firing alerts must match a silence created with expireOnResolve before it is expired.").Default("5m").Duration()
+		silenceHybridClock  = kingpin.Flag("silences.hybrid-clock", "Version silence edits with a hybrid logical clock so that edits made after receiving a peer's version always supersede it, even if the peer's clock is ahead.").Bool()
+		silenceReallocRatio = kingpin.Flag("silences.gc-realloc-ratio", "Fraction of the silences a garbage collection must remove for the silence indexes to be reallocated, releasing the memory of the removed silences. If zero, the indexes are never reallocated.").Default("0.5").Float64()
 		alertGCInterval     = kingpin.Flag("alerts.gc-interval", "Interval between alert GC.").Default("30m").Duration()
-
-		webConfig      = webflag.AddFlags(kingpin.CommandLine, ":9093")
-		externalURL    = kingpin.Flag("web.external-url", "The URL under which Alertmanager is externally reachable (for example, if Alertmanager is served via a reverse proxy). Used for generating relative and absolute links back to Alertmanager itself. If the URL has a path portion, it will be used to prefix all HTTP endpoints served by Alertmanager. If omitted, relevant URL components will be derived automatically.").String()
-		routePrefix    = kingpin.Flag("web.route-prefix", "Prefix for the internal routes of web endpoints. Defaults to path of --web.external-url.").String()
-		getConcurrency = kingpin.Flag("web.get-concurrency", "Maximum number of GET requests processed concurrently. If negative or zero, the limit is GOMAXPROC or 8, whichever is larger.").Default("0").Int()
-		httpTimeout    = kingpin.Flag("web.timeout", "Timeout for HTTP requests. If negative or zero, no timeout is set.").Default("0").Duration()
+		alertDedupWindow    = kingpin.Flag("alerts.dedup-window", "Window in which identical re-posts of an alert, such as those of HA Prometheus replicas, are ignored. 0 disables deduplication.").Default("0s").Duration()
+		inhibitWarmUp       = kingpin.Flag("inhibit.warm-up", "How long after startup the notifications of the target alerts of the inhibition rules are delayed, unless their source alerts are received, so that the source alerts have time to be resent. If zero, notifications are not delayed.").Default("0s").Duration()
+		resolvedRetention   = kingpin.Flag("alerts.resolved-retention", "How long resolved alerts are kept in memory after the alert GC, to be queried with GET /api/v2/alerts?state=resolved. 0 drops them with the alert GC.").Default("0s").Duration()
+		walRetention        = kingpin.Flag("alerts.wal-retention", "How long the received alerts are kept in a write-ahead log under the storage path, to be replayed with POST /-/replay. 0 disables the log.").Default("0s").Duration()
+		walMaxSize          = kingpin.Flag("alerts.wal-max-size-bytes", "Maximum size of the write-ahead log of the received alerts. The oldest alerts are dropped beyond it. 0 means unlimited.").Default("268435456").Int64()
+		ackDuration         = kingpin.Flag("alerts.ack-duration", "How long acknowledgements of alerts last if no expiry time is given.").Default("24h").Duration()
+		ackSuppressRepeat   = kingpin.Flag("alerts.ack-suppress-repeat", "Don't re-notify about groups every repeat_interval while all their firing alerts are acknowledged.").Bool()
+		storageVerify       = kingpin.Flag("storage.verify", "Verify the silences and notification log snapshots in the storage path, print a report and exit.").Bool()
+		storageRepair       = kingpin.Flag("storage.repair", "Together with --storage.verify, drop undecodable entries from the snapshots. The original files are kept with a .bak suffix.").Bool()
+		encryptionKeyFile   = kingpin.Flag("storage.encryption-key-file", "File containing a hex-encoded 128, 192 or 256 bit AES key used to encrypt the silences and notification log snapshots. Unencrypted snapshots are still loaded.").String()
+		selfMonInterval     = kingpin.Flag("self-monitoring.interval", "Interval between evaluations of the health of Alertmanager, which generates alerts labeled with source=\"alertmanager\" about its own failures. If zero, no alerts are generated.").Default("0s").Duration()
+		selfMonThreshold    = kingpin.Flag("self-monitoring.notification-failure-threshold", "Ratio of failed notifications of an integration between two self-monitoring evaluations above which an alert is generated.").Default("0.5").Float64()
+
+		webConfig        = webflag.AddFlags(kingpin.CommandLine, ":9093")
+		externalURL      = kingpin.Flag("web.external-url", "The URL under which Alertmanager is externally reachable (for example, if Alertmanager is served via a reverse proxy). Used for generating relative and absolute links back to Alertmanager itself. If the URL has a path portion, it will be used to prefix all HTTP endpoints served by Alertmanager. If omitted, relevant URL components will be derived automatically.").String()
+		routePrefix      = kingpin.Flag("web.route-prefix", "Prefix for the internal routes of web endpoints. Defaults to path of --web.external-url.").String()
+		getConcurrency   = kingpin.Flag("web.get-concurrency", "Maximum number of GET requests processed concurrently. If negative or zero, the limit is GOMAXPROC or 8, whichever is larger.").Default("0").Int()
+		httpTimeout      = kingpin.Flag("web.timeout", "Timeout for HTTP requests. If negative or zero, no timeout is set.").Default("0").Duration()
+		enableQuit       = kingpin.Flag("web.enable-quit", "Enable the /-/quit endpoint to shut down Alertmanager via HTTP.").Bool()
+		enablePreviewAPI = kingpin.Flag("web.enable-preview-api", "Enable the /api/v2/preview endpoint rendering the notifications of integrations without sending them. The rendered notifications may contain secrets of the configuration.").Bool()
+		maxFlushes       = kingpin.Flag("dispatch.max-concurrent-flushes", "Maximum number of aggregation groups flushing their notifications at once. The other flushes wait in a queue until the next flush of their group is due. If zero, the number is unlimited.").Default("0").Int()
+		drainPeriod      = kingpin.Flag("dispatch.drain-period", "Maximum time to wait on shutdown for in-flight notifications to finish. No new notifications are started during this period. If zero, in-flight notifications are canceled immediately.").Default("0s").Duration()
+		maxRenders       = kingpin.Flag("template.max-concurrent-renders", "Maximum number of notification templates rendered at once. The other renderings wait for their turn, unless their notification is canceled. If zero, the number is unlimited.").Default("0").Int()
+
+		sqsQueueURL  = kingpin.Flag("ingest.sqs.queue-url", "URL of an SQS queue to receive alerts from. The messages hold alerts in the format of the POST /api/v2/alerts request body, optionally wrapped in an SNS notification. If empty, no queue is polled.").String()
+		sqsRegion    = kingpin.Flag("ingest.sqs.region", "AWS region of the SQS queue. If empty, the region is taken from the environment.").String()
+		sqsProfile   = kingpin.Flag("ingest.sqs.profile", "AWS shared configuration profile used to receive from the SQS queue.").String()
+		sqsRoleARN   = kingpin.Flag("ingest.sqs.role-arn", "AWS role to assume to receive from the SQS queue.").String()
+		snsEnabled   = kingpin.Flag("ingest.sns.enabled", "Receive alerts from SNS HTTP(S) subscriptions at /api/v2/ingest/sns.").Bool()
+		snsTopicARNs = kingpin.Flag("ingest.sns.topic-arn", "SNS topic to accept messages from and confirm subscriptions to (may be repeated). If omitted, messages from all topics are accepted but no subscription is confirmed.").Strings()
+
+		slackAppTokenFile = kingpin.Flag("slack.app-token-file", "File holding the app-level token of a Slack app in socket mode, which handles the buttons added to the notifications of Slack receivers with interactive enabled. If empty, no Slack app is connected.").String()
+		slackSilenceDur   = kingpin.Flag("slack.silence-duration", "How long the silences created with the buttons of Slack notifications last.").Default("2h").Duration()
+
+		archiveURL          = kingpin.Flag("archive.url", "URL of the bucket resolved alerts and notification records are archived to, one of s3://<bucket>/<prefix>, gs://<bucket>/<prefix>, azblob://<account>/<container>/<prefix> or file:///<directory>. If empty, nothing is archived.").String()
+		archiveInterval     = kingpin.Flag("archive.interval", "Interval at which the buffered records are written to the archive.").Default("15m").Duration()
+		archivePartition    = kingpin.Flag("archive.partition", "Go template of the prefix of the archived objects, executed with the .Kind of the records and the .Time they happened at.").Default(archive.DefaultPartition).String()
+		archiveMaxRecords   = kingpin.Flag("archive.max-records", "Maximum number of records buffered for the archive. The oldest records are dropped above it. If zero, the number is unlimited.").Default("100000").Int()
+		archiveS3Region     = kingpin.Flag("archive.s3.region", "AWS region of the S3 archive bucket. If empty, the region is taken from the environment.").String()
+		archiveS3Profile    = kingpin.Flag("archive.s3.profile", "AWS shared configuration profile used to write to the S3 archive bucket.").String()
+		archiveS3RoleARN    = kingpin.Flag("archive.s3.role-arn", "AWS role to assume to write to the S3 archive bucket.").String()
+		archiveS3Endpoint   = kingpin.Flag("archive.s3.endpoint", "Endpoint of S3-compatible storages to use instead of AWS S3.").String()
+		archiveGCSCredsFile = kingpin.Flag("archive.gcs.credentials-file", "Service account key used to write to the GCS archive bucket. Defaults to the file of the GOOGLE_APPLICATION_CREDENTIALS environment variable.").String()
+		archiveAzureSASFile = kingpin.Flag("archive.azure.sas-token-file", "File holding the shared access signature used to write to the Azure Blob Storage archive container.").String()
 
 		memlimitRatio = kingpin.Flag("auto-gomemlimit.ratio", "The ratio of reserved GOMEMLIMIT memory to the detected maximum container or system memory. The value must be greater than 0 and less than or equal to 1.").
 				Default("0.9").Float64()
 
-		clusterBindAddr = kingpin.Flag("cluster.listen-address", "Listen address for cluster. Set to empty string to disable HA mode.").
-				Default(defaultClusterAddr).String()
+		clusterBindAddrs = kingpin.Flag("cluster.listen-address", "Listen address for cluster. Can be repeated to listen on multiple addresses with the same port, such as an IPv4 and an IPv6 address. Set to empty string to disable HA mode.").
+					Default(defaultClusterAddr).Strings()
 		clusterAdvertiseAddr   = kingpin.Flag("cluster.advertise-address", "Explicit address to advertise in cluster.").String()
+		clusterAdvertiseFamily = kingpin.Flag("cluster.advertise-address-family", "Family of the addresses considered when discovering the address to advertise in cluster, if not set explicitly. One of: [any, ipv4, ipv6].").
+					Default(string(cluster.AddressFamilyAny)).Enum(string(cluster.AddressFamilyAny), string(cluster.AddressFamilyIPv4), string(cluster.AddressFamilyIPv6))
 		peers                  = kingpin.Flag("cluster.peer", "Initial peers (may be repeated).").Strings()
+		clusterEventsWebhook   = kingpin.Flag("cluster.events-webhook-url", "URL to post the peers which join, leave or die in the cluster to, as JSON.").String()
 		peerTimeout            = kingpin.Flag("cluster.peer-timeout", "Time to wait between peers to send notifications.").Default("15s").Duration()
 		gossipInterval         = kingpin.Flag("cluster.gossip-interval", "Interval between sending gossip messages. By lowering this value (more frequent) gossip messages are propagated across the cluster more quickly at the expense of increased bandwidth.").Default(cluster.DefaultGossipInterval.String()).Duration()
 		pushPullInterval       = kingpin.Flag("cluster.pushpull-interval", "Interval for gossip state syncs. Setting this interval lower (more frequent) will increase convergence speeds across larger clusters at the expense of increased bandwidth usage.").Default(cluster.DefaultPushPullInterval.String()).Duration()
@@ -175,18 +240,49 @@ func run() int {
 		peerReconnectTimeout   = kingpin.Flag("cluster.reconnect-timeout", "Length of time to attempt to reconnect to a lost peer.").Default(cluster.DefaultReconnectTimeout.String()).Duration()
 		tlsConfigFile          = kingpin.Flag("cluster.tls-config", "[EXPERIMENTAL] Path to config yaml file that can enable mutual TLS within the gossip protocol.").Default("").String()
 		allowInsecureAdvertise = kingpin.Flag("cluster.allow-insecure-public-advertise-address-discovery", "[EXPERIMENTAL] Allow alertmanager to discover and listen on a public IP address.").Bool()
+		consistencyInterval    = kingpin.Flag("cluster.consistency-check-interval", "Interval between comparisons of the silenced and inhibited alerts with the other peers. If zero, no comparison is made.").Default("1m").Duration()
 		label                  = kingpin.Flag("cluster.label", "The cluster label is an optional string to include on each packet and stream. It uniquely identifies the cluster and prevents cross-communication issues when sending gossip messages.").Default("").String()
 		featureFlags           = kingpin.Flag("enable-feature", fmt.Sprintf("Experimental features to enable. The flag can be repeated to enable multiple features. Valid options: %s", strings.Join(featurecontrol.AllowedFlags, ", "))).Default("").String()
 	)
 
+	// The server runs unless another command is given.
+	kingpin.Command("server", "Run the Alertmanager server.").Default()
+	simulateCmd := kingpin.Command("simulate", "Replay a scenario of alerts through the routing tree of the configuration on a simulated clock and print the schedule of the notifications.")
+	var (
+		scenarioFile   = simulateCmd.Flag("scenario", "Scenario file of the alerts to replay.").Required().ExistingFile()
+		simulateOutput = simulateCmd.Flag("output", "File to write the simulated notifications to as JSON.").String()
+	)
+
 	promslogflag.AddFlags(kingpin.CommandLine, &promslogConfig)
 	kingpin.CommandLine.UsageWriter(os.Stdout)
 
 	kingpin.Version(version.Print("alertmanager"))
 	kingpin.CommandLine.GetFlag("help").Short('h')
-	kingpin.Parse()
+	cmd := kingpin.Parse()
+
+	if *printDefaultConfig {
+		content, err := config.DefaultContent()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading the default configuration:", err)
+			return 1
+		}
+		fmt.Print(content)
+		return 0
+	}
 
-	logger := promslog.New(&promslogConfig)
+	logger, err := newLogger(&promslogConfig)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error creating the logger:", err)
+		return 1
+	}
+
+	var (
+		hup  = make(chan os.Signal, 1)
+		term = make(chan os.Signal, 1)
+	)
+	// Stopping the service comes last, once everything has shut down.
+	stopService := runService(logger, hup, term)
+	defer func() { stopService(code) }()
 
 	logger.Info("Starting Alertmanager", "version", version.Info())
 	logger.Info("Build context", "build_context", version.BuildContext())
@@ -198,6 +294,14 @@ func run() int {
 	}
 	compat.InitFromFlags(logger, ff)
 
+	if cmd == simulateCmd.FullCommand() {
+		if err := simulate(os.Stdout, *configFile, *ageIdentityFile, *scenarioFile, *simulateOutput); err != nil {
+			logger.Error("Simulation failed", "err", err)
+			return 1
+		}
+		return 0
+	}
+
 	if ff.EnableAutoGOMEMLIMIT() {
 		if *memlimitRatio <= 0.0 || *memlimitRatio > 1.0 {
 			logger.Error("--auto-gomemlimit.ratio must be greater than 0 and less than or equal to 1.")
@@ -232,18 +336,46 @@ func run() int {
 		return 1
 	}
 
+	var snapshotCipher *encryption.Cipher
+	if *encryptionKeyFile != "" {
+		snapshotCipher, err = encryption.LoadKeyFile(*encryptionKeyFile)
+		if err != nil {
+			logger.Error("unable to load storage encryption key", "err", err)
+			return 1
+		}
+	}
+
+	if *storageVerify {
+		ok, err := verifyStorage(os.Stdout, *dataDir, snapshotCipher, *storageRepair)
+		if err != nil {
+			logger.Error("unable to verify storage", "err", err)
+			return 1
+		}
+		if !ok && !*storageRepair {
+			return 1
+		}
+		return 0
+	}
+
 	tlsTransportConfig, err := cluster.GetTLSTransportConfig(*tlsConfigFile)
 	if err != nil {
 		logger.Error("unable to initialize TLS transport configuration for gossip mesh", "err", err)
 		return 1
 	}
 	var peer *cluster.Peer
-	if *clusterBindAddr != "" {
+	var clusterAddrs []string
+	for _, addr := range *clusterBindAddrs {
+		if addr != "" {
+			clusterAddrs = append(clusterAddrs, addr)
+		}
+	}
+	if len(clusterAddrs) > 0 {
 		peer, err = cluster.Create(
 			logger.With("component", "cluster"),
 			prometheus.DefaultRegisterer,
-			*clusterBindAddr,
+			clusterAddrs,
 			*clusterAdvertiseAddr,
+			cluster.AddressFamily(*clusterAdvertiseFamily),
 			*peers,
 			true,
 			*pushPullInterval,
@@ -259,6 +391,11 @@ func run() int {
 			logger.Error("unable to initialize gossip mesh", "err", err)
 			return 1
 		}
+		if *clusterEventsWebhook != "" {
+			w := cluster.NewEventWebhook(*clusterEventsWebhook, &http.Client{Timeout: *tcpTimeout}, logger.With("component", "cluster"))
+			peer.OnEvent(w.Notify)
+			go w.Run(context.Background())
+		}
 		clusterEnabled.Set(1)
 	}
 
@@ -268,13 +405,14 @@ func run() int {
 	notificationLogOpts := nflog.Options{
 		SnapshotFile: filepath.Join(*dataDir, "nflog"),
 		Retention:    *retention,
+		Cipher:       snapshotCipher,
 		Logger:       logger.With("component", "nflog"),
 		Metrics:      prometheus.DefaultRegisterer,
 	}
 
 	notificationLog, err := nflog.New(notificationLogOpts)
 	if err != nil {
-		logger.Error("error creating notification log", "err", err)
+		logger.Error("error creating notification log", "err", err, "hint", "run with --storage.verify to check the snapshot")
 		return 1
 	}
 	if peer != nil {
@@ -288,11 +426,32 @@ func run() int {
 		wg.Done()
 	}()
 
+	retries, err := retry.New(retry.Options{
+		SnapshotFile: filepath.Join(*dataDir, "retries"),
+		Retention:    *retention,
+		Cipher:       snapshotCipher,
+		Logger:       logger.With("component", "retry"),
+		Metrics:      prometheus.DefaultRegisterer,
+	})
+	if err != nil {
+		logger.Error("error creating retry queue", "err", err)
+		return 1
+	}
+
+	wg.Add(1)
+	go func() {
+		retries.Maintenance(*maintenanceInterval, filepath.Join(*dataDir, "retries"), stopc)
+		wg.Done()
+	}()
+
 	marker := types.NewMarker(prometheus.DefaultRegisterer)
 
 	silenceOpts := silence.Options{
 		SnapshotFile: filepath.Join(*dataDir, "silences"),
 		Retention:    *retention,
+		Cipher:       snapshotCipher,
+		HybridClock:  *silenceHybridClock,
+		ReallocRatio: *silenceReallocRatio,
 		Limits: silence.Limits{
 			MaxSilences:         func() int { return *maxSilences },
 			MaxSilenceSizeBytes: func() int { return *maxSilenceSizeBytes },
@@ -303,7 +462,7 @@ func run() int {
 
 	silences, err := silence.New(silenceOpts)
 	if err != nil {
-		logger.Error("error creating silence", "err", err)
+		logger.Error("error creating silence", "err", err, "hint", "run with --storage.verify to check the snapshot")
 		return 1
 	}
 	if peer != nil {
@@ -318,6 +477,51 @@ func run() int {
 		wg.Done()
 	}()
 
+	acks := ack.New(ack.Options{
+		DefaultDuration: *ackDuration,
+		Logger:          logger.With("component", "ack"),
+		Metrics:         prometheus.DefaultRegisterer,
+	})
+	if peer != nil {
+		c := peer.AddState("ack", acks, prometheus.DefaultRegisterer)
+		acks.SetBroadcast(c.Broadcast)
+	}
+
+	wg.Add(1)
+	go func() {
+		acks.Maintenance(*maintenanceInterval, stopc)
+		wg.Done()
+	}()
+
+	disabledReceivers := maintenance.New(maintenance.Options{
+		Logger:  logger.With("component", "maintenance"),
+		Metrics: prometheus.DefaultRegisterer,
+	})
+	if peer != nil {
+		c := peer.AddState("dis", disabledReceivers, prometheus.DefaultRegisterer)
+		disabledReceivers.SetBroadcast(c.Broadcast)
+	}
+
+	wg.Add(1)
+	go func() {
+		disabledReceivers.Maintenance(*maintenanceInterval, stopc)
+		wg.Done()
+	}()
+
+	var divergentPeers func() []string
+	if peer != nil && *consistencyInterval > 0 {
+		checker := consistency.New(peer.Name(), marker.SuppressionHash, logger.With("component", "consistency"), prometheus.DefaultRegisterer)
+		c := peer.AddState("cns", checker, prometheus.DefaultRegisterer)
+		checker.SetBroadcast(c.Broadcast)
+		divergentPeers = checker.DivergentPeers
+
+		wg.Add(1)
+		go func() {
+			checker.Run(*consistencyInterval, stopc)
+			wg.Done()
+		}()
+	}
+
 	defer func() {
 		close(stopc)
 		wg.Wait()
@@ -342,16 +546,113 @@ func run() int {
 		go peer.Settle(ctx, *gossipInterval*10)
 	}
 
-	alerts, err := mem.NewAlerts(context.Background(), marker, *alertGCInterval, nil, logger, prometheus.DefaultRegisterer)
+	var (
+		archiver      *archive.Archiver
+		alertCallback mem.AlertStoreCallback
+	)
+	if *archiveURL != "" {
+		bucket, err := archive.NewBucket(context.Background(), *archiveURL, archive.BucketOptions{
+			S3Region:           *archiveS3Region,
+			S3Profile:          *archiveS3Profile,
+			S3RoleARN:          *archiveS3RoleARN,
+			S3Endpoint:         *archiveS3Endpoint,
+			GCSCredentialsFile: *archiveGCSCredsFile,
+			AzureSASTokenFile:  *archiveAzureSASFile,
+		})
+		if err != nil {
+			logger.Error("error creating archive bucket", "err", err)
+			return 1
+		}
+		instance, _ := os.Hostname()
+		if peer != nil {
+			instance = peer.Name()
+		}
+		archiver, err = archive.New(bucket, archive.Options{
+			Partition:  *archivePartition,
+			Instance:   instance,
+			MaxRecords: *archiveMaxRecords,
+		}, logger.With("component", "archive"), prometheus.DefaultRegisterer)
+		if err != nil {
+			logger.Error("error creating archiver", "err", err)
+			return 1
+		}
+		alertCallback = archiver
+
+		wg.Add(1)
+		go func() {
+			archiver.Run(*archiveInterval, stopc)
+			wg.Done()
+		}()
+	}
+
+	alerts, err := mem.NewAlerts(context.Background(), marker, *alertGCInterval, alertCallback, logger, prometheus.DefaultRegisterer)
 	if err != nil {
 		logger.Error("error creating memory provider", "err", err)
 		return 1
 	}
 	defer alerts.Close()
+	alerts.SetDedupWindow(*alertDedupWindow)
+	alerts.SetResolvedRetention(*resolvedRetention)
+
+	var alertsWAL *wal.Log
+	if *walRetention > 0 {
+		alertsWAL, err = wal.New(wal.Options{
+			Dir:       filepath.Join(*dataDir, "alerts-wal"),
+			MaxSize:   *walMaxSize,
+			Retention: *walRetention,
+			Logger:    logger.With("component", "wal"),
+			Metrics:   prometheus.DefaultRegisterer,
+		})
+		if err != nil {
+			logger.Error("error opening alerts write-ahead log", "err", err)
+			return 1
+		}
+		defer alertsWAL.Close()
+
+		wg.Add(1)
+		go func() {
+			alertsWAL.Maintenance(*maintenanceInterval, stopc)
+			wg.Done()
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		silences.ExpireOnResolve(*silenceResolveGrace, func() []model.LabelSet {
+			it := alerts.GetPending()
+			defer it.Close()
+			var firing []model.LabelSet
+			for a := range it.Next() {
+				if !a.Resolved() {
+					firing = append(firing, a.Labels)
+				}
+			}
+			return firing
+		}, stopc)
+		wg.Done()
+	}()
 
-	var disp *dispatch.Dispatcher
+	// dispatcherStarted is set once the dispatcher of the first configuration
+	// is running.
+	var dispatcherStarted atomic.Bool
+	var (
+		disp              *dispatch.Dispatcher
+		pipelineBuilder   *notify.PipelineBuilder
+		configCoordinator *config.Coordinator
+	)
 	defer func() {
-		disp.Stop()
+		if *drainPeriod <= 0 {
+			disp.Stop()
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), *drainPeriod)
+		defer cancel()
+		disp.Drain(ctx)
+		// Give the notification log entries written during the drain a
+		// couple of gossip rounds to reach the other peers before leaving.
+		if peer != nil {
+			time.Sleep(2 * *gossipInterval)
+		}
 	}()
 
 	groupFn := func(routeFilter func(*dispatch.Route) bool, alertFilter func(*types.Alert, time.Time) bool) (dispatch.AlertGroups, map[model.Fingerprint][]string) {
@@ -366,17 +667,38 @@ func run() int {
 		clusterPeer = peer
 	}
 
+	payloadLog := notify.NewPayloadLog(notify.DefaultPayloadLogSize)
+
+	start := time.Now()
+	healthChecksFn := func() []apiv2.HealthCheck {
+		return healthChecks(*dataDir, *maintenanceInterval, start, peer, configCoordinator, pipelineBuilder)
+	}
+	receiverStatsFn := func() []notify.ReceiverStats {
+		if pipelineBuilder == nil {
+			return nil
+		}
+		return pipelineBuilder.ReceiverStats()
+	}
+
 	api, err := api.New(api.Options{
-		Alerts:          alerts,
-		Silences:        silences,
-		AlertStatusFunc: marker.Status,
-		GroupMutedFunc:  marker.Muted,
-		Peer:            clusterPeer,
-		Timeout:         *httpTimeout,
-		Concurrency:     *getConcurrency,
-		Logger:          logger.With("component", "api"),
-		Registry:        prometheus.DefaultRegisterer,
-		GroupFunc:       groupFn,
+		Alerts:             alerts,
+		Silences:           silences,
+		Acks:               acks,
+		DisabledReceivers:  disabledReceivers,
+		AlertsWAL:          alertsWAL,
+		AlertStatusFunc:    marker.Status,
+		GroupMutedFunc:     marker.Muted,
+		Peer:               clusterPeer,
+		DivergentPeersFunc: divergentPeers,
+		HealthChecksFunc:   healthChecksFn,
+		ReceiverStatsFunc:  receiverStatsFn,
+		EnablePreview:      *enablePreviewAPI,
+		PayloadLog:         payloadLog,
+		Timeout:            *httpTimeout,
+		Concurrency:        *getConcurrency,
+		Logger:             logger.With("component", "api"),
+		Registry:           prometheus.DefaultRegisterer,
+		GroupFunc:          groupFn,
 	})
 	if err != nil {
 		logger.Error("failed to create API", "err", err)
@@ -390,46 +712,130 @@ func run() int {
 	}
 	logger.Debug("external url", "externalUrl", amURL.String())
 
-	waitFunc := func() time.Duration { return 0 }
+	if *selfMonInterval > 0 {
+		monitor := selfmonitor.New(
+			prometheus.DefaultGatherer,
+			alerts,
+			model.LabelSet{"instance": model.LabelValue(amURL.Host)},
+			*selfMonThreshold,
+			logger.With("component", "selfmonitor"),
+			prometheus.DefaultRegisterer,
+		)
+		wg.Add(1)
+		go func() {
+			monitor.Run(*selfMonInterval, stopc)
+			wg.Done()
+		}()
+	}
+
+	waitFunc := func(context.Context) time.Duration { return 0 }
 	if peer != nil {
 		waitFunc = clusterWait(peer, *peerTimeout)
 	}
-	timeoutFunc := func(d time.Duration) time.Duration {
+	timeoutFunc := func(ctx context.Context, d time.Duration) time.Duration {
 		if d < notify.MinTimeout {
 			d = notify.MinTimeout
 		}
-		return d + waitFunc()
+		return d + waitFunc(ctx)
 	}
 
-	var (
-		inhibitor *inhibit.Inhibitor
-		tmpl      *template.Template
-	)
+	var inhibitor *inhibit.Inhibitor
+	warmUp := inhibit.NewWarmUp(*inhibitWarmUp, prometheus.DefaultRegisterer)
 
 	dispMetrics := dispatch.NewDispatcherMetrics(false, prometheus.DefaultRegisterer)
-	pipelineBuilder := notify.NewPipelineBuilder(prometheus.DefaultRegisterer, ff)
+	pipelineBuilder = notify.NewPipelineBuilder(prometheus.DefaultRegisterer, ff)
+	pipelineBuilder.PersistRetries(retries)
+	pipelineBuilder.LogFailedPayloads(payloadLog)
+	if *ackSuppressRepeat {
+		pipelineBuilder.SuppressAckedRepeats(acks.Acked)
+	}
+	pipelineBuilder.SkipDisabledReceivers(disabledReceivers.Disabled)
+	if archiver != nil {
+		pipelineBuilder.RegisterStage(notify.StagePositionPostNotify, archiver.Stage)
+	}
 	configLogger := logger.With("component", "configuration")
-	configCoordinator := config.NewCoordinator(
+	secretsWatcher := receiver.NewSecretsWatcher(configLogger, prometheus.DefaultRegisterer)
+	if *secretsInterval > 0 {
+		wg.Add(1)
+		go func() {
+			secretsWatcher.Run(*secretsInterval, stopc)
+			wg.Done()
+		}()
+	}
+	configCoordinator = config.NewCoordinator(
 		*configFile,
 		prometheus.DefaultRegisterer,
 		configLogger,
 	)
-	configCoordinator.Subscribe(func(conf *config.Config) error {
-		tmpl, err = template.FromGlobs(conf.Templates)
+	configCoordinator.SetTemplateCacheDir(filepath.Join(*dataDir, "templates"))
+	if !configFileSet {
+		configCoordinator.UseDefaultConfig()
+	}
+	if *ageIdentityFile != "" {
+		identities, err := config.LoadAgeIdentities(*ageIdentityFile)
 		if err != nil {
-			return fmt.Errorf("failed to parse templates: %w", err)
+			logger.Error("failed to load age identities", "err", err)
+			return 1
+		}
+		configCoordinator.SetAgeIdentities(identities)
+	}
+	egressFilter := notify.NewEgressFilter(logger, prometheus.DefaultRegisterer)
+	configCoordinator.SubscribePrepare(func(conf *config.Config) (func(), error) {
+		policy, err := egressPolicy(conf.EgressPolicy)
+		if err != nil {
+			return nil, err
+		}
+		var ttl, staleTTL time.Duration
+		if conf.DNSCache != nil {
+			ttl, staleTTL = time.Duration(conf.DNSCache.TTL), time.Duration(conf.DNSCache.StaleTTL)
+		}
+		return func() {
+			egressFilter.SetPolicy(policy)
+			egressFilter.SetDNSCache(ttl, staleTTL)
+		}, nil
+	})
+
+	// The new pipeline and dispatcher are fully built before the running ones
+	// are stopped so that a configuration which fails to apply leaves the
+	// previous one in place.
+	configCoordinator.SubscribePrepare(func(conf *config.Config) (func(), error) {
+		tmpl, err := template.FromGlobs(conf.Templates)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse templates: %w", err)
 		}
 		tmpl.ExternalURL = amURL
+		tmpl.ClusterLabels = conf.Global.ClusterLabels
+		tmpl.Acks = acks.Get
+		tmpl.SetMaxConcurrentRenders(*maxRenders)
+		tmpl.FiringAlerts = func(f func(*types.Alert)) {
+			it := alerts.GetPending()
+			defer it.Close()
+			now := time.Now()
+			for a := range it.Next() {
+				if !a.ResolvedAt(now) {
+					f(a)
+				}
+			}
+		}
 
 		// Build the routing tree and record which receivers are used.
 		routes := dispatch.NewRoute(conf.Route, nil)
 		activeReceivers := make(map[string]struct{})
 		routes.Walk(func(r *dispatch.Route) {
-			activeReceivers[r.RouteOpts.Receiver] = struct{}{}
+			for _, name := range r.RouteOpts.Receivers() {
+				activeReceivers[name] = struct{}{}
+			}
+			for _, e := range r.RouteOpts.Escalations {
+				activeReceivers[e.Receiver] = struct{}{}
+			}
 		})
+		if conf.Global.DeadLetterReceiver != "" {
+			activeReceivers[conf.Global.DeadLetterReceiver] = struct{}{}
+		}
 
 		// Build the map of receiver to integrations.
 		receivers := make(map[string][]notify.Integration, len(activeReceivers))
+		secrets := &receiver.SecretFiles{}
 		var integrationsNum int
 		for _, rcv := range conf.Receivers {
 			if _, found := activeReceivers[rcv.Name]; !found {
@@ -437,31 +843,19 @@ func run() int {
 				configLogger.Info("skipping creation of receiver not referenced by any route", "receiver", rcv.Name)
 				continue
 			}
-			integrations, err := receiver.BuildReceiverIntegrations(rcv, tmpl, logger)
+			integrations, err := receiver.BuildWatchedReceiverIntegrations(rcv, tmpl, logger, secrets, commoncfg.WithDialContextFunc(egressFilter.DialContext))
 			if err != nil {
-				return err
+				return nil, err
 			}
 			// rcv.Name is guaranteed to be unique across all receivers.
 			receivers[rcv.Name] = integrations
 			integrationsNum += len(integrations)
 		}
 
-		// Build the map of time interval names to time interval definitions.
-		timeIntervals := make(map[string][]timeinterval.TimeInterval, len(conf.MuteTimeIntervals)+len(conf.TimeIntervals))
-		for _, ti := range conf.MuteTimeIntervals {
-			timeIntervals[ti.Name] = ti.TimeIntervals
-		}
-
-		for _, ti := range conf.TimeIntervals {
-			timeIntervals[ti.Name] = ti.TimeIntervals
-		}
-
-		intervener := timeinterval.NewIntervener(timeIntervals)
-
-		inhibitor.Stop()
-		disp.Stop()
+		intervener := timeinterval.NewIntervener(conf.TimeIntervalsByName())
 
-		inhibitor = inhibit.NewInhibitor(alerts, conf.InhibitRules, marker, logger)
+		newInhibitor := inhibit.NewInhibitor(alerts, conf.InhibitRules, marker, logger)
+		newInhibitor.SetWarmUp(warmUp)
 		silencer := silence.NewSilencer(silences, marker, logger)
 
 		// An interface value that holds a nil concrete value is non-nil.
@@ -472,10 +866,36 @@ func run() int {
 			pipelinePeer = peer
 		}
 
+		pipelineBuilder.SendDeadLetters(conf.Global.DeadLetterReceiver)
+		quietTimeIntervals := make(map[string][]string)
+		annotationFilters := make(map[string]notify.AnnotationFilter)
+		var leaderOnly []string
+		for _, rcv := range conf.Receivers {
+			if rcv.HAMode == config.HAModeLeaderOnly {
+				leaderOnly = append(leaderOnly, rcv.Name)
+			}
+			if len(rcv.QuietTimeIntervals) > 0 {
+				quietTimeIntervals[rcv.Name] = rcv.QuietTimeIntervals
+			}
+			if len(rcv.IncludeAnnotations) > 0 || len(rcv.ExcludeAnnotations) > 0 {
+				annotationFilters[rcv.Name] = notify.AnnotationFilter{
+					Include: rcv.IncludeAnnotations,
+					Exclude: rcv.ExcludeAnnotations,
+				}
+			}
+		}
+		pipelineBuilder.QueueDuringQuietTimes(quietTimeIntervals)
+		pipelineBuilder.FilterAnnotations(annotationFilters)
+		// Without cluster, this Alertmanager is the leader.
+		var isLeader func() bool
+		if peer != nil {
+			isLeader = func() bool { return peer.Position() == 0 }
+		}
+		pipelineBuilder.SendFromLeaderOnly(leaderOnly, isLeader)
 		pipeline := pipelineBuilder.New(
 			receivers,
 			waitFunc,
-			inhibitor,
+			newInhibitor,
 			silencer,
 			intervener,
 			marker,
@@ -483,16 +903,9 @@ func run() int {
 			pipelinePeer,
 		)
 
-		configuredReceivers.Set(float64(len(activeReceivers)))
-		configuredIntegrations.Set(float64(integrationsNum))
-		configuredInhibitionRules.Set(float64(len(conf.InhibitRules)))
-
-		api.Update(conf, func(labels model.LabelSet) {
-			inhibitor.Mutes(labels)
-			silencer.Mutes(labels)
-		})
-
-		disp = dispatch.NewDispatcher(alerts, routes, pipeline, marker, timeoutFunc, nil, logger, dispMetrics)
+		newDisp := dispatch.NewDispatcher(alerts, routes, pipeline, marker, timeoutFunc, nil, logger, dispMetrics)
+		newDisp.SetMaxConcurrentFlushes(*maxFlushes)
+		newDisp.SetGroupLabelNormalizers(conf.GroupLabelNormalizers)
 		routes.Walk(func(r *dispatch.Route) {
 			if r.RouteOpts.RepeatInterval > *retention {
 				configLogger.Warn(
@@ -519,16 +932,119 @@ func run() int {
 			}
 		})
 
-		go disp.Run()
-		go inhibitor.Run()
+		return func() {
+			configuredReceivers.Set(float64(len(activeReceivers)))
+			configuredIntegrations.Set(float64(integrationsNum))
+			configuredInhibitionRules.Set(float64(len(conf.InhibitRules)))
 
-		return nil
+			inhibitor.Stop()
+			disp.Stop()
+
+			inhibitor = newInhibitor
+			disp = newDisp
+			secretsWatcher.Watch(secrets)
+
+			api.Update(conf, tmpl, func(labels model.LabelSet) {
+				newInhibitor.Mutes(labels)
+				silencer.Mutes(labels)
+			}, newInhibitor)
+
+			go disp.Run()
+			go inhibitor.Run()
+			dispatcherStarted.Store(true)
+		}, nil
+	})
+
+	ingestMetrics := ingest.NewMetrics(prometheus.DefaultRegisterer)
+	snsOptions := ingest.SNSOptions{TopicARNs: *snsTopicARNs}
+	ingestAdapters := ingest.NewAdapters(snsOptions, api.InsertAlerts, logger.With("component", "ingest"), ingestMetrics)
+	configCoordinator.SubscribePrepare(ingestAdapters.Prepare)
+	for _, format := range ingest.Formats() {
+		api.HandleIngest(format, ingestAdapters.Handler(format))
+	}
+
+	configCoordinator.SubscribePrepare(func(conf *config.Config) (func(), error) {
+		policy := silencePolicy(conf.SilencePolicy)
+		return func() { silences.SetPolicy(policy) }, nil
+	})
+
+	// Once the first configuration is applied, restore the alerts of the
+	// notifications which were being retried before the restart so that their
+	// groups are flushed again.
+	var restoreRetries sync.Once
+	configCoordinator.SubscribePrepare(func(conf *config.Config) (func(), error) {
+		return func() {
+			restoreRetries.Do(func() {
+				restorePendingRetries(alerts, retries, time.Duration(conf.Global.ResolveTimeout), logger)
+			})
+		}, nil
 	})
 
 	if err := configCoordinator.Reload(); err != nil {
 		return 1
 	}
 
+	wg.Add(1)
+	go func() {
+		configCoordinator.RefreshTemplates(stopc)
+		wg.Done()
+	}()
+
+	if *snsEnabled {
+		api.HandleIngest("sns", ingest.NewSNSHandler(
+			snsOptions,
+			api.InsertAlerts,
+			logger.With("component", "ingest", "source", "sns"),
+			ingestMetrics,
+		))
+	}
+	if *sqsQueueURL != "" {
+		sqsReceiver, err := ingest.NewSQSReceiver(
+			ingest.SQSOptions{
+				QueueURL: *sqsQueueURL,
+				Region:   *sqsRegion,
+				Profile:  *sqsProfile,
+				RoleARN:  *sqsRoleARN,
+			},
+			api.InsertAlerts,
+			logger.With("component", "ingest", "source", "sqs"),
+			ingestMetrics,
+		)
+		if err != nil {
+			logger.Error("failed to create SQS receiver", "err", err)
+			return 1
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go sqsReceiver.Run(ctx)
+	}
+	if *slackAppTokenFile != "" {
+		token, err := os.ReadFile(*slackAppTokenFile)
+		if err != nil {
+			logger.Error("failed to read Slack app token", "err", err)
+			return 1
+		}
+		slackApp, err := slackapp.New(
+			slackapp.Options{
+				Token:           strings.TrimSpace(string(token)),
+				SilenceDuration: *slackSilenceDur,
+				ExternalURL:     amURL,
+			},
+			silences,
+			acks,
+			alerts,
+			logger.With("component", "slackapp"),
+			prometheus.DefaultRegisterer,
+		)
+		if err != nil {
+			logger.Error("failed to create Slack app", "err", err)
+			return 1
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go slackApp.Run(ctx)
+	}
+
 	// Make routePrefix default to externalURL path if empty string.
 	if *routePrefix == "" {
 		*routePrefix = amURL.Path
@@ -546,7 +1062,25 @@ func run() int {
 
 	webReload := make(chan chan error)
 
-	ui.Register(router, webReload, logger)
+	// The instance isn't ready before the cluster settled, otherwise a load
+	// balancer could route alerts to it while it doesn't know the
+	// notifications sent by its peers yet, resulting in duplicates.
+	ready := func() error {
+		if peer != nil && !peer.Ready() {
+			return errors.New("waiting for the cluster to settle")
+		}
+		if !dispatcherStarted.Load() {
+			return errors.New("waiting for the configuration to be loaded")
+		}
+		return nil
+	}
+
+	ui.Register(router, webReload, ready, logger)
+
+	webQuit := make(chan struct{}, 1)
+	if *enableQuit {
+		ui.RegisterQuit(router, webQuit)
+	}
 	reactapp.Register(router, logger)
 
 	mux := api.Register(router, *routePrefix)
@@ -566,10 +1100,6 @@ func run() int {
 		}()
 	}()
 
-	var (
-		hup  = make(chan os.Signal, 1)
-		term = make(chan os.Signal, 1)
-	)
 	signal.Notify(hup, syscall.SIGHUP)
 	signal.Notify(term, os.Interrupt, syscall.SIGTERM)
 
@@ -583,16 +1113,203 @@ func run() int {
 		case <-term:
 			logger.Info("Received SIGTERM, exiting gracefully...")
 			return 0
+		case <-webQuit:
+			logger.Info("Received quit request via web, exiting gracefully...")
+			return 0
 		case <-srvc:
 			return 1
 		}
 	}
 }
 
+// egressPolicy returns the egress policy of the configuration, nil if none.
+func egressPolicy(c *config.EgressPolicy) (*notify.EgressPolicy, error) {
+	if c == nil {
+		return nil, nil
+	}
+	prefixes, err := c.Prefixes()
+	if err != nil {
+		return nil, err
+	}
+	return &notify.EgressPolicy{Hosts: c.AllowedHosts, Prefixes: prefixes}, nil
+}
+
+// silencePolicy returns the policy of the silences of the configuration, nil
+// if none.
+func silencePolicy(c *config.SilencePolicy) *silence.Policy {
+	if c == nil {
+		return nil
+	}
+	return &silence.Policy{
+		MaxDuration:    time.Duration(c.MaxDuration),
+		Comment:        c.CommentRegex.Regexp,
+		DeniedMatchers: labels.Matchers(c.DeniedMatchers),
+	}
+}
+
+// restorePendingRetries puts the alerts of the notifications being retried
+// back into the provider. The notification log prevents notifying the
+// integrations which already succeeded again. Alerts which were firing are
+// restored with the resolve timeout as end time since their end time isn't
+// recorded.
+func restorePendingRetries(alerts provider.Alerts, retries *retry.Queue, resolveTimeout time.Duration, logger *slog.Logger) {
+	pending := retries.Pending()
+	if len(pending) == 0 {
+		return
+	}
+	logger.Info("Resuming notifications being retried", "count", len(pending))
+	now := time.Now()
+	for _, e := range pending {
+		restored := make([]*types.Alert, 0, len(e.Alerts))
+		for _, a := range e.Alerts {
+			a := *a
+			if a.EndsAt.IsZero() {
+				a.EndsAt = now.Add(resolveTimeout)
+				a.Timeout = true
+			}
+			restored = append(restored, &a)
+		}
+		if err := alerts.Put(restored...); err != nil {
+			logger.Error("Failed to restore alerts of notification being retried", "aggrGroup", e.GroupKey, "err", err)
+		}
+	}
+}
+
+// healthChecks returns the state of the dependencies of Alertmanager: the
+// writability of the data directory, the age of the snapshots, the settle
+// state of the cluster, the last reload of the configuration, templates
+// included, and the last notifications of the integrations.
+func healthChecks(dataDir string, maintenanceInterval time.Duration, start time.Time, peer *cluster.Peer, cc *config.Coordinator, pb *notify.PipelineBuilder) []apiv2.HealthCheck {
+	now := time.Now()
+	checks := []apiv2.HealthCheck{dataDirCheck(dataDir)}
+	if maintenanceInterval > 0 {
+		for _, name := range []string{"nflog", "silences"} {
+			checks = append(checks, snapshotCheck(name, filepath.Join(dataDir, name), maintenanceInterval, start, now))
+		}
+	}
+	if peer != nil {
+		checks = append(checks, apiv2.HealthCheck{
+			Name:    "cluster",
+			Healthy: peer.Ready(),
+			Message: peer.Status(),
+		})
+	}
+	if cc != nil {
+		c := apiv2.HealthCheck{Name: "config", Healthy: true}
+		t, err := cc.LastReload()
+		c.LastSuccess = t
+		if err != nil {
+			c.Healthy, c.Message = false, err.Error()
+		}
+		checks = append(checks, c)
+	}
+	if pb != nil {
+		for _, st := range pb.IntegrationStatuses() {
+			c := apiv2.HealthCheck{
+				Name:        "integration/" + st.Receiver + "/" + st.Integration,
+				Healthy:     !st.LastFailure.After(st.LastSuccess),
+				LastSuccess: st.LastSuccess,
+			}
+			if !c.Healthy {
+				c.Message = st.LastError
+			}
+			checks = append(checks, c)
+		}
+	}
+	return checks
+}
+
+// dataDirCheck checks that files can be written in the data directory.
+func dataDirCheck(dataDir string) apiv2.HealthCheck {
+	c := apiv2.HealthCheck{Name: "data_dir", Healthy: true}
+	f, err := os.CreateTemp(dataDir, ".healthcheck")
+	if err != nil {
+		c.Healthy, c.Message = false, err.Error()
+		return c
+	}
+	f.Close()
+	if err := os.Remove(f.Name()); err != nil {
+		c.Healthy, c.Message = false, err.Error()
+	}
+	return c
+}
+
+// snapshotCheck checks that the snapshot was written within the last two
+// maintenance intervals. A missing snapshot is healthy until two maintenance
+// intervals elapsed since the start.
+func snapshotCheck(name, path string, maintenanceInterval time.Duration, start, now time.Time) apiv2.HealthCheck {
+	c := apiv2.HealthCheck{Name: "snapshot/" + name, Healthy: true}
+	fi, err := os.Stat(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if now.Sub(start) > 2*maintenanceInterval {
+			c.Healthy, c.Message = false, "no snapshot written"
+		}
+	case err != nil:
+		c.Healthy, c.Message = false, err.Error()
+	default:
+		c.LastSuccess = fi.ModTime()
+		if age := now.Sub(fi.ModTime()); age > 2*maintenanceInterval {
+			c.Healthy = false
+			c.Message = fmt.Sprintf("last snapshot written %s ago", age.Round(time.Second))
+		}
+	}
+	return c
+}
+
+// verifyStorage verifies the snapshots in the data directory and writes a
+// report to w. It returns false if any snapshot is corrupted.
+func verifyStorage(w io.Writer, dataDir string, c *encryption.Cipher, repair bool) (bool, error) {
+	now := time.Now().UTC()
+	ok := true
+	for _, snap := range []struct {
+		name   string
+		verify snapshot.VerifyFunc
+	}{
+		{
+			name: "nflog",
+			verify: func(r io.Reader, w io.Writer) (snapshot.Report, error) {
+				return nflog.VerifySnapshot(r, c, now, w)
+			},
+		},
+		{
+			name: "silences",
+			verify: func(r io.Reader, w io.Writer) (snapshot.Report, error) {
+				return silence.VerifySnapshot(r, c, now, w)
+			},
+		},
+	} {
+		filename := filepath.Join(dataDir, snap.name)
+		rep, err := snapshot.VerifyFile(filename, repair, snap.verify)
+		if os.IsNotExist(err) {
+			fmt.Fprintf(w, "%s: no snapshot\n", filename)
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", filename, err)
+		}
+		fmt.Fprintf(w, "%s: %s\n", filename, rep)
+		for _, err := range rep.Errors {
+			fmt.Fprintf(w, "  %v\n", err)
+		}
+		if !rep.OK() {
+			ok = false
+			if repair {
+				fmt.Fprintf(w, "  repaired, original kept as %s.bak\n", filename)
+			}
+		}
+	}
+	return ok, nil
+}
+
 // clusterWait returns a function that inspects the current peer state and returns
 // a duration of one base timeout for each peer with a higher ID than ourselves.
-func clusterWait(p *cluster.Peer, timeout time.Duration) func() time.Duration {
-	return func() time.Duration {
+// The peer timeout of the route of the notification overrides the base timeout.
+func clusterWait(p *cluster.Peer, timeout time.Duration) func(context.Context) time.Duration {
+	return func(ctx context.Context) time.Duration {
+		if d, ok := notify.PeerTimeout(ctx); ok {
+			return time.Duration(p.Position()) * d
+		}
 		return time.Duration(p.Position()) * timeout
 	}
 }
@@ -15,7 +15,11 @@ package main
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/prometheus/common/promslog"
 	"github.com/stretchr/testify/require"
@@ -96,3 +100,99 @@ func TestExternalURL(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotCheck(t *testing.T) {
+	const interval = 15 * time.Minute
+
+	var (
+		dir   = t.TempDir()
+		path  = filepath.Join(dir, "nflog")
+		now   = time.Now()
+		start = now.Add(-time.Minute)
+	)
+
+	// A missing snapshot is healthy right after the start only.
+	require.True(t, snapshotCheck("nflog", path, interval, start, now).Healthy)
+	c := snapshotCheck("nflog", path, interval, now.Add(-3*interval), now)
+	require.False(t, c.Healthy)
+	require.Equal(t, "no snapshot written", c.Message)
+
+	require.NoError(t, os.WriteFile(path, nil, 0o666))
+	modTime := now.Add(-interval)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	c = snapshotCheck("nflog", path, interval, start, now)
+	require.True(t, c.Healthy)
+	require.Equal(t, "snapshot/nflog", c.Name)
+	require.True(t, modTime.Equal(c.LastSuccess))
+
+	modTime = now.Add(-3 * interval)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	c = snapshotCheck("nflog", path, interval, start, now)
+	require.False(t, c.Healthy)
+	require.Equal(t, "last snapshot written 45m0s ago", c.Message)
+}
+
+func TestDataDirCheck(t *testing.T) {
+	dir := t.TempDir()
+	require.True(t, dataDirCheck(dir).Healthy)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	require.False(t, dataDirCheck(filepath.Join(dir, "missing")).Healthy)
+}
+
+func TestSimulate(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "alertmanager.yml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+route:
+  receiver: default
+  group_by: [alertname]
+receivers:
+- name: default
+`), 0o644))
+	scenarioFile := filepath.Join(dir, "scenario.yml")
+	require.NoError(t, os.WriteFile(scenarioFile, []byte(`
+alerts:
+- labels: {alertname: HighLatency}
+  at: 1m
+  resolve_at: 5m
+`), 0o644))
+	output := filepath.Join(dir, "notifications.json")
+
+	var buf strings.Builder
+	require.NoError(t, simulate(&buf, configFile, "", scenarioFile, output))
+	require.Equal(t, `TIME                  OFFSET  RECEIVER  FIRING  RESOLVED  GROUP
+1970-01-01T00:01:30Z  1m30s   default   1       0         {alertname="HighLatency"}
+
+RECEIVER  NOTIFICATIONS  GROUPS
+default   1              1
+`, buf.String())
+
+	b, err := os.ReadFile(output)
+	require.NoError(t, err)
+	require.Contains(t, string(b), `"receiver": "default"`)
+}
+
+func TestSimulateInhibitRules(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "alertmanager.yml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+route:
+  receiver: default
+receivers:
+- name: default
+inhibit_rules:
+- source_matchers: [severity="critical"]
+  target_matchers: [severity="warning"]
+`), 0o644))
+	scenarioFile := filepath.Join(dir, "scenario.yml")
+	require.NoError(t, os.WriteFile(scenarioFile, []byte(`
+alerts: []
+`), 0o644))
+
+	var buf strings.Builder
+	require.NoError(t, simulate(&buf, configFile, "", scenarioFile, ""))
+	require.True(t, strings.HasPrefix(buf.String(), "Inhibition rules aren't applied: inhibited alerts are notified like the others.\n\n"))
+}
@@ -0,0 +1,33 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package main
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/prometheus/common/promslog"
+)
+
+// newLogger returns the logger of the configuration.
+func newLogger(c *promslog.Config) (*slog.Logger, error) {
+	return promslog.New(c), nil
+}
+
+// runService does nothing, Alertmanager only runs as a service on Windows.
+func runService(*slog.Logger, chan<- os.Signal, chan<- os.Signal) func(code int) {
+	return func(int) {}
+}
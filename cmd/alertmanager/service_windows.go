@@ -0,0 +1,171 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus/common/promslog"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+// eventID is the identifier of the events written to the Windows Event Log.
+const eventID = 1
+
+var eventLogSource = kingpin.Flag("log.eventlog-source", "Write the logs to the Windows Event Log with this source instead of standard error. The source must be registered beforehand.").String()
+
+// newLogger returns the logger of the configuration, writing to the Windows
+// Event Log if an event log source is set.
+func newLogger(c *promslog.Config) (*slog.Logger, error) {
+	if *eventLogSource == "" {
+		return promslog.New(c), nil
+	}
+	el, err := eventlog.Open(*eventLogSource)
+	if err != nil {
+		return nil, err
+	}
+	w := &eventLogWriter{log: el}
+	c.Writer = w
+	return slog.New(&eventLogHandler{Handler: promslog.New(c).Handler(), w: w}), nil
+}
+
+// eventLogWriter writes each log line as an event of the level of the
+// record being handled.
+type eventLogWriter struct {
+	mtx   sync.Mutex
+	log   *eventlog.Log
+	level slog.Level
+}
+
+func (w *eventLogWriter) Write(b []byte) (int, error) {
+	msg := strings.TrimSuffix(string(b), "\n")
+	var err error
+	switch {
+	case w.level >= slog.LevelError:
+		err = w.log.Error(eventID, msg)
+	case w.level >= slog.LevelWarn:
+		err = w.log.Warning(eventID, msg)
+	default:
+		err = w.log.Info(eventID, msg)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// eventLogHandler passes the level of the records to the eventLogWriter the
+// wrapped handler writes to.
+type eventLogHandler struct {
+	slog.Handler
+	w *eventLogWriter
+}
+
+func (h *eventLogHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.w.mtx.Lock()
+	defer h.w.mtx.Unlock()
+	h.w.level = r.Level
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *eventLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &eventLogHandler{Handler: h.Handler.WithAttrs(attrs), w: h.w}
+}
+
+func (h *eventLogHandler) WithGroup(name string) slog.Handler {
+	return &eventLogHandler{Handler: h.Handler.WithGroup(name), w: h.w}
+}
+
+// runService reports the stop and reload requests of the Windows service
+// control manager as signals on term and hup if Alertmanager runs as a
+// Windows service. The returned function reports the exit code of
+// Alertmanager to the service control manager once it has shut down.
+func runService(logger *slog.Logger, hup, term chan<- os.Signal) func(code int) {
+	isService, err := svc.IsWindowsService()
+	if err != nil {
+		logger.Error("Failed to detect the Windows service", "err", err)
+	}
+	if !isService {
+		return func(int) {}
+	}
+
+	s := &service{
+		hup:   hup,
+		term:  term,
+		exitc: make(chan int),
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// The name is ignored for services running in their own process.
+		if err := svc.Run("alertmanager", s); err != nil {
+			logger.Error("Failed to run the Windows service", "err", err)
+		}
+	}()
+	return func(code int) {
+		select {
+		case s.exitc <- code:
+			<-done
+		case <-done:
+		}
+	}
+}
+
+// service implements svc.Handler.
+type service struct {
+	hup, term chan<- os.Signal
+	exitc     chan int
+}
+
+func (s *service) Execute(_ []string, r <-chan svc.ChangeRequest, status chan<- svc.Status) (bool, uint32) {
+	status <- svc.Status{
+		State:   svc.Running,
+		Accepts: svc.AcceptStop | svc.AcceptShutdown | svc.AcceptParamChange,
+	}
+	for {
+		select {
+		case c := <-r:
+			switch c.Cmd {
+			case svc.Interrogate:
+				status <- c.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				// The snapshots are written while stopping.
+				status <- svc.Status{State: svc.StopPending}
+				notifySignal(s.term, os.Interrupt)
+			case svc.ParamChange:
+				notifySignal(s.hup, syscall.SIGHUP)
+			}
+		case code := <-s.exitc:
+			return false, uint32(code)
+		}
+	}
+}
+
+// notifySignal sends the signal unless one is already pending, like
+// signal.Notify.
+func notifySignal(c chan<- os.Signal, sig os.Signal) {
+	select {
+	case c <- sig:
+	default:
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"filippo.io/age"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/simulation"
+)
+
+// simulate replays the scenario file through the configuration file and
+// writes the schedule of the notifications with a summary by receiver to w
+// and, if output is set, the notifications as JSON to the output file.
+func simulate(w io.Writer, configFile, ageIdentityFile, scenarioFile, output string) error {
+	var identities []age.Identity
+	if ageIdentityFile != "" {
+		var err error
+		if identities, err = config.LoadAgeIdentities(ageIdentityFile); err != nil {
+			return err
+		}
+	}
+	cfg, err := config.LoadEncryptedFile(configFile, identities)
+	if err != nil {
+		return fmt.Errorf("load configuration: %w", err)
+	}
+	scenario, err := simulation.LoadScenarioFile(scenarioFile)
+	if err != nil {
+		return fmt.Errorf("load scenario: %w", err)
+	}
+	notifications, err := simulation.Run(cfg, scenario)
+	if err != nil {
+		return err
+	}
+	if len(cfg.InhibitRules) > 0 {
+		fmt.Fprint(w, "Inhibition rules aren't applied: inhibited alerts are notified like the others.\n\n")
+	}
+
+	if output != "" {
+		b, err := json.MarshalIndent(notifications, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(output, b, 0o644); err != nil {
+			return err
+		}
+	}
+
+	type summary struct {
+		notifications int
+		groups        map[string]struct{}
+	}
+	var (
+		tw        = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		start     = scenario.Start()
+		receivers = map[string]*summary{}
+	)
+	fmt.Fprintln(tw, "TIME\tOFFSET\tRECEIVER\tFIRING\tRESOLVED\tGROUP")
+	for _, n := range notifications {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%s\n",
+			n.Time.UTC().Format(time.RFC3339), n.Time.Sub(start), n.Receiver, len(n.Firing), len(n.Resolved), n.GroupLabels)
+		s, ok := receivers[n.Receiver]
+		if !ok {
+			s = &summary{groups: map[string]struct{}{}}
+			receivers[n.Receiver] = s
+		}
+		s.notifications++
+		s.groups[n.GroupKey] = struct{}{}
+	}
+	fmt.Fprintln(tw)
+
+	names := make([]string, 0, len(receivers))
+	for name := range receivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(tw, "RECEIVER\tNOTIFICATIONS\tGROUPS")
+	for _, name := range names {
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", name, receivers[name].notifications, len(receivers[name].groups))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,172 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"gopkg.in/yaml.v2"
+)
+
+// Values of the configuration encrypted with age are written as
+// ENC[AGE,<base64 of the age ciphertext>].
+const (
+	encryptedPrefix = "ENC[AGE,"
+	encryptedSuffix = "]"
+)
+
+// LoadAgeIdentities reads the age identities of the file, in the format of
+// age-keygen.
+func LoadAgeIdentities(filename string) ([]age.Identity, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	ids, err := age.ParseIdentities(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse age identities from %s: %w", filename, err)
+	}
+	return ids, nil
+}
+
+// LoadAgeRecipients parses the age recipients, in the format of age-keygen.
+func LoadAgeRecipients(recipients []string) ([]age.Recipient, error) {
+	res, err := age.ParseRecipients(strings.NewReader(strings.Join(recipients, "\n")))
+	if err != nil {
+		return nil, fmt.Errorf("parse age recipients: %w", err)
+	}
+	return res, nil
+}
+
+// EncryptSecret returns the value encrypted for the recipients, to be
+// written in the configuration in place of the plaintext.
+func EncryptSecret(plaintext string, recipients []age.Recipient) (string, error) {
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, recipients...)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.WriteString(w, plaintext); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(buf.Bytes()) + encryptedSuffix, nil
+}
+
+// LoadEncryptedFile parses the given YAML file into a Config, decrypting the
+// values encrypted with age with the identities first.
+func LoadEncryptedFile(filename string, identities []age.Identity) (*Config, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	s, err := decryptSecrets(string(content), identities)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := Load(s)
+	if err != nil {
+		return nil, err
+	}
+
+	resolveFilepaths(filepath.Dir(filename), cfg)
+	return cfg, nil
+}
+
+// decryptSecrets returns the configuration with the values encrypted with age
+// replaced by their plaintext.
+func decryptSecrets(s string, identities []age.Identity) (string, error) {
+	if !strings.Contains(s, encryptedPrefix) {
+		return s, nil
+	}
+	var doc yaml.MapSlice
+	if err := yaml.Unmarshal([]byte(s), &doc); err != nil {
+		return "", err
+	}
+	var found bool
+	decrypted, err := decryptValue(doc, identities, &found)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		// The marker is only part of larger strings or comments.
+		return s, nil
+	}
+	b, err := yaml.Marshal(decrypted)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func decryptValue(v interface{}, identities []age.Identity, found *bool) (interface{}, error) {
+	switch v := v.(type) {
+	case yaml.MapSlice:
+		for i := range v {
+			dv, err := decryptValue(v[i].Value, identities, found)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", v[i].Key, err)
+			}
+			v[i].Value = dv
+		}
+		return v, nil
+	case []interface{}:
+		for i := range v {
+			dv, err := decryptValue(v[i], identities, found)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = dv
+		}
+		return v, nil
+	case string:
+		if !strings.HasPrefix(v, encryptedPrefix) || !strings.HasSuffix(v, encryptedSuffix) {
+			return v, nil
+		}
+		*found = true
+		return decryptSecret(v, identities)
+	default:
+		return v, nil
+	}
+}
+
+func decryptSecret(s string, identities []age.Identity) (string, error) {
+	if len(identities) == 0 {
+		return "", errors.New("encrypted value found but no age identity configured")
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(s, encryptedPrefix), encryptedSuffix))
+	if err != nil {
+		return "", fmt.Errorf("decode encrypted value: %w", err)
+	}
+	r, err := age.Decrypt(bytes.NewReader(ciphertext), identities...)
+	if err != nil {
+		return "", fmt.Errorf("decrypt value: %w", err)
+	}
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("decrypt value: %w", err)
+	}
+	return string(plaintext), nil
+}
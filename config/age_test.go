@@ -0,0 +1,65 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadEncryptedFile(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	other, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	recipients, err := LoadAgeRecipients([]string{identity.Recipient().String()})
+	require.NoError(t, err)
+	encrypted, err := EncryptSecret("s3cr3t: key", recipients)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "alertmanager.yml")
+	require.NoError(t, os.WriteFile(filename, []byte(`
+# Values such as ENC[AGE,...] are decrypted.
+global:
+  opsgenie_api_key: '`+encrypted+`'
+route:
+  receiver: team-X
+receivers:
+- name: team-X
+  opsgenie_configs:
+  - api_key: '`+encrypted+`'
+`), 0o666))
+
+	cfg, err := LoadEncryptedFile(filename, []age.Identity{identity})
+	require.NoError(t, err)
+	require.Equal(t, Secret("s3cr3t: key"), cfg.Global.OpsGenieAPIKey)
+	require.Equal(t, Secret("s3cr3t: key"), cfg.Receivers[0].OpsGenieConfigs[0].APIKey)
+
+	_, err = LoadFile(filename)
+	require.EqualError(t, err, "global: opsgenie_api_key: encrypted value found but no age identity configured")
+
+	_, err = LoadEncryptedFile(filename, []age.Identity{other})
+	require.ErrorContains(t, err, "decrypt value")
+
+	// Files without encrypted values are loaded as is.
+	cfg, err = LoadEncryptedFile("testdata/conf.good.yml", nil)
+	require.NoError(t, err)
+	require.NotNil(t, cfg.Route)
+}
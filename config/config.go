@@ -14,20 +14,25 @@
 package config
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net"
+	"net/http"
 	"net/url"
-	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	commoncfg "github.com/prometheus/common/config"
 	"github.com/prometheus/common/model"
+	"golang.org/x/net/http/httpguts"
 	"gopkg.in/yaml.v2"
 
 	"github.com/prometheus/alertmanager/matcher/compat"
@@ -196,19 +201,10 @@ func Load(s string) (*Config, error) {
 	return cfg, nil
 }
 
-// LoadFile parses the given YAML file into a Config.
+// LoadFile parses the given YAML file into a Config. It fails if the file has
+// values encrypted with age, see LoadEncryptedFile.
 func LoadFile(filename string) (*Config, error) {
-	content, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, err
-	}
-	cfg, err := Load(string(content))
-	if err != nil {
-		return nil, err
-	}
-
-	resolveFilepaths(filepath.Dir(filename), cfg)
-	return cfg, nil
+	return LoadEncryptedFile(filename, nil)
 }
 
 // resolveFilepaths joins all relative paths in a configuration
@@ -222,7 +218,9 @@ func resolveFilepaths(baseDir string, cfg *Config) {
 	}
 
 	for i, tf := range cfg.Templates {
-		cfg.Templates[i] = join(tf)
+		if !isTemplateURL(tf) {
+			cfg.Templates[i] = join(tf)
+		}
 	}
 
 	cfg.Global.HTTPConfig.SetDirectory(baseDir)
@@ -272,6 +270,15 @@ func resolveFilepaths(baseDir string, cfg *Config) {
 		for _, cfg := range receiver.RocketchatConfigs {
 			cfg.HTTPConfig.SetDirectory(baseDir)
 		}
+		for _, cfg := range receiver.VoiceCallConfigs {
+			cfg.HTTPConfig.SetDirectory(baseDir)
+			if cfg.Twilio != nil {
+				cfg.Twilio.AuthTokenFile = join(cfg.Twilio.AuthTokenFile)
+			}
+			if cfg.Vonage != nil {
+				cfg.Vonage.PrivateKeyFile = join(cfg.Vonage.PrivateKeyFile)
+			}
+		}
 	}
 }
 
@@ -318,9 +325,34 @@ type Config struct {
 	InhibitRules []InhibitRule `yaml:"inhibit_rules,omitempty" json:"inhibit_rules,omitempty"`
 	Receivers    []Receiver    `yaml:"receivers,omitempty" json:"receivers,omitempty"`
 	Templates    []string      `yaml:"templates" json:"templates"`
+	// TemplateRefreshInterval is the interval at which templates referenced
+	// by URL are fetched again. Zero disables refreshing, the templates are
+	// then only fetched when the configuration is reloaded.
+	TemplateRefreshInterval model.Duration `yaml:"template_refresh_interval,omitempty" json:"template_refresh_interval,omitempty"`
 	// Deprecated. Remove before v1.0 release.
 	MuteTimeIntervals []MuteTimeInterval `yaml:"mute_time_intervals,omitempty" json:"mute_time_intervals,omitempty"`
 	TimeIntervals     []TimeInterval     `yaml:"time_intervals,omitempty" json:"time_intervals,omitempty"`
+	IngestAdapters    []IngestAdapter    `yaml:"ingest_adapters,omitempty" json:"ingest_adapters,omitempty"`
+	// GeneratorURLRewrites rewrite the generator URL of the alerts at
+	// ingestion. The first matching rewrite applies.
+	GeneratorURLRewrites []GeneratorURLRewrite `yaml:"generator_url_rewrites,omitempty" json:"generator_url_rewrites,omitempty"`
+	// GroupLabelNormalizers normalize the values of the labels of the alerts
+	// before they are grouped, so that the alerts of different senders
+	// meaning the same are grouped together.
+	GroupLabelNormalizers []GroupLabelNormalizer `yaml:"group_label_normalizers,omitempty" json:"group_label_normalizers,omitempty"`
+	// AlertOrigin identifies the senders of the alerts, propagated to the
+	// notifications and the notification log.
+	AlertOrigin *AlertOrigin `yaml:"alert_origin,omitempty" json:"alert_origin,omitempty"`
+	// IngestionRateLimit limits the rate of the requests posting alerts.
+	IngestionRateLimit *IngestionRateLimit `yaml:"ingestion_rate_limit,omitempty" json:"ingestion_rate_limit,omitempty"`
+	// SilencePolicy restricts the silences which can be created or updated.
+	SilencePolicy *SilencePolicy `yaml:"silence_policy,omitempty" json:"silence_policy,omitempty"`
+	// EgressPolicy restricts the destinations of the HTTP requests of the
+	// integrations.
+	EgressPolicy *EgressPolicy `yaml:"egress_policy,omitempty" json:"egress_policy,omitempty"`
+	// DNSCache caches the IP addresses of the host names the integrations
+	// connect to.
+	DNSCache *DNSCache `yaml:"dns_cache,omitempty" json:"dns_cache,omitempty"`
 
 	// original is the input from which the config was parsed.
 	original string
@@ -334,6 +366,19 @@ func (c Config) String() string {
 	return string(b)
 }
 
+// TimeIntervalsByName returns the time intervals of the configuration,
+// including the deprecated mute time intervals, by name.
+func (c *Config) TimeIntervalsByName() map[string][]timeinterval.TimeInterval {
+	res := make(map[string][]timeinterval.TimeInterval, len(c.MuteTimeIntervals)+len(c.TimeIntervals))
+	for _, ti := range c.MuteTimeIntervals {
+		res[ti.Name] = ti.TimeIntervals
+	}
+	for _, ti := range c.TimeIntervals {
+		res[ti.Name] = ti.TimeIntervals
+	}
+	return res
+}
+
 // UnmarshalYAML implements the yaml.Unmarshaler interface for Config.
 func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	// We want to set c to the defaults and then overwrite it with the input.
@@ -351,6 +396,14 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		*c.Global = DefaultGlobalConfig()
 	}
 
+	for _, t := range c.Templates {
+		if isTemplateURL(t) {
+			if _, err := parseRemoteTemplate(t); err != nil {
+				return err
+			}
+		}
+	}
+
 	if c.Global.SlackAPIURL != nil && len(c.Global.SlackAPIURLFile) > 0 {
 		return errors.New("at most one of slack_api_url & slack_api_url_file must be configured")
 	}
@@ -375,6 +428,24 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return errors.New("at most one of rocketchat_token_id & rocketchat_token_id_file must be configured")
 	}
 
+	if err := validateProxyConfig(c.Global.HTTPConfig); err != nil {
+		return fmt.Errorf("global http_config: %w", err)
+	}
+
+	// The global headers apply to the receivers which don't set them.
+	for i := range c.Receivers {
+		rcv := &c.Receivers[i]
+		for k, v := range c.Global.HTTPHeaders {
+			if _, ok := rcv.Headers[k]; ok {
+				continue
+			}
+			if rcv.Headers == nil {
+				rcv.Headers = make(map[string]Secret, len(c.Global.HTTPHeaders))
+			}
+			rcv.Headers[k] = v
+		}
+	}
+
 	names := map[string]struct{}{}
 
 	for _, rcv := range c.Receivers {
@@ -390,6 +461,12 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			if ec.TLSConfig == nil {
 				ec.TLSConfig = c.Global.SMTPTLSConfig
 			}
+			if ec.TLSCipherSuites == nil {
+				ec.TLSCipherSuites = c.Global.SMTPTLSCipherSuites
+			}
+			if ec.ForceImplicitTLS == nil {
+				ec.ForceImplicitTLS = c.Global.SMTPForceImplicitTLS
+			}
 			if ec.Smarthost.String() == "" {
 				if c.Global.SMTPSmarthost.String() == "" {
 					return errors.New("no global SMTP smarthost set")
@@ -444,6 +521,9 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			if pdc.HTTPConfig == nil {
 				pdc.HTTPConfig = c.Global.HTTPConfig
 			}
+			if pdc.URL == nil && pdc.Region != "" {
+				pdc.URL = mustParseURL("https://" + PagerdutyRegionHosts[pdc.Region] + "/v2/enqueue")
+			}
 			if pdc.URL == nil {
 				if c.Global.PagerdutyURL == nil {
 					return errors.New("no global PagerDuty URL set")
@@ -455,6 +535,9 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			if ogc.HTTPConfig == nil {
 				ogc.HTTPConfig = c.Global.HTTPConfig
 			}
+			if ogc.APIURL == nil && ogc.Region != "" {
+				ogc.APIURL = mustParseURL(OpsGenieRegionAPIURLs[ogc.Region])
+			}
 			if ogc.APIURL == nil {
 				if c.Global.OpsGenieAPIURL == nil {
 					return errors.New("no global OpsGenie URL set")
@@ -506,6 +589,9 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			if voc.HTTPConfig == nil {
 				voc.HTTPConfig = c.Global.HTTPConfig
 			}
+			if voc.APIURL == nil && voc.Region != "" {
+				voc.APIURL = mustParseURL(VictorOpsRegionAPIURLs[voc.Region])
+			}
 			if voc.APIURL == nil {
 				if c.Global.VictorOpsAPIURL == nil {
 					return errors.New("no global VictorOps URL set")
@@ -584,6 +670,11 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 				jira.APIURL = c.Global.JiraAPIURL
 			}
 		}
+		for _, vc := range rcv.VoiceCallConfigs {
+			if vc.HTTPConfig == nil {
+				vc.HTTPConfig = c.Global.HTTPConfig
+			}
+		}
 		for _, rocketchat := range rcv.RocketchatConfigs {
 			if rocketchat.HTTPConfig == nil {
 				rocketchat.HTTPConfig = c.Global.HTTPConfig
@@ -607,6 +698,10 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			}
 		}
 
+		if err := validateReceiverProxies(&rcv); err != nil {
+			return err
+		}
+
 		names[rcv.Name] = struct{}{}
 	}
 
@@ -633,6 +728,19 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if err := checkReceiver(c.Route, names); err != nil {
 		return err
 	}
+	if c.Global.DeadLetterReceiver != "" {
+		if _, ok := names[c.Global.DeadLetterReceiver]; !ok {
+			return fmt.Errorf("undefined dead_letter_receiver %q", c.Global.DeadLetterReceiver)
+		}
+	}
+	for _, rcv := range c.Receivers {
+		integrations := rcv.integrations()
+		for _, name := range c.Global.DisabledIntegrations {
+			if integrations[name] > 0 {
+				return fmt.Errorf("receiver %q uses the disabled integration %q", rcv.Name, name)
+			}
+		}
+	}
 
 	tiNames := make(map[string]struct{})
 
@@ -651,7 +759,274 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		tiNames[mt.Name] = struct{}{}
 	}
 
-	return checkTimeInterval(c.Route, tiNames)
+	if err := checkTimeInterval(c.Route, tiNames); err != nil {
+		return err
+	}
+	for _, rcv := range c.Receivers {
+		for _, ti := range rcv.QuietTimeIntervals {
+			if _, ok := tiNames[ti]; !ok {
+				return fmt.Errorf("undefined time interval %q used in receiver %q", ti, rcv.Name)
+			}
+		}
+	}
+
+	formats := make(map[string]struct{})
+	for _, ia := range c.IngestAdapters {
+		if _, ok := formats[ia.Format]; ok {
+			return fmt.Errorf("ingest adapter %q is not unique", ia.Format)
+		}
+		formats[ia.Format] = struct{}{}
+	}
+	return nil
+}
+
+var (
+	ingestAdapterFormatsMtx sync.RWMutex
+	// ingestAdapterFormats are the formats of the adapters of the ingest
+	// package, which can't be imported here.
+	ingestAdapterFormats = map[string]struct{}{
+		"aws-cloudwatch": {},
+		"azure-monitor":  {},
+		"grafana":        {},
+	}
+)
+
+// RegisterIngestAdapterFormat allows ingest adapters of the given format in
+// configurations. It is called when an adapter is registered to the ingest
+// package.
+func RegisterIngestAdapterFormat(format string) {
+	ingestAdapterFormatsMtx.Lock()
+	defer ingestAdapterFormatsMtx.Unlock()
+	ingestAdapterFormats[format] = struct{}{}
+}
+
+func validIngestAdapterFormat(format string) error {
+	ingestAdapterFormatsMtx.RLock()
+	defer ingestAdapterFormatsMtx.RUnlock()
+	if _, ok := ingestAdapterFormats[format]; ok {
+		return nil
+	}
+	formats := make([]string, 0, len(ingestAdapterFormats))
+	for f := range ingestAdapterFormats {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return fmt.Errorf("unknown ingest adapter %q, must be one of %s", format, strings.Join(formats, ", "))
+}
+
+// IngestAdapter enables the ingestion of alerts in a third-party format and
+// configures how their labels are mapped.
+type IngestAdapter struct {
+	// Format is the name of the adapter, as found in the ingestion path.
+	Format string `yaml:"format" json:"format"`
+	// LabelMappings renames labels of the translated alerts.
+	LabelMappings map[model.LabelName]model.LabelName `yaml:"label_mappings,omitempty" json:"label_mappings,omitempty"`
+	// Labels are added to the translated alerts unless already set.
+	Labels model.LabelSet `yaml:"labels,omitempty" json:"labels,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for IngestAdapter.
+func (ia *IngestAdapter) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain IngestAdapter
+	if err := unmarshal((*plain)(ia)); err != nil {
+		return err
+	}
+	if ia.Format == "" {
+		return errors.New("missing format in ingest adapter")
+	}
+	return validIngestAdapterFormat(ia.Format)
+}
+
+// GeneratorURLRewrite rewrites the origin, the scheme and the host, of the
+// generator URLs to make them reachable by the recipients of the
+// notifications, e.g. from internal hostnames of Prometheus to external ones.
+type GeneratorURLRewrite struct {
+	// SourceOrigin is matched against the scheme and the host, including the
+	// port, of the generator URL, e.g. http://prometheus-0.monitoring:9090.
+	SourceOrigin Regexp `yaml:"source_origin" json:"source_origin"`
+	// TargetOrigin replaces the matched origin. It can reference the capture
+	// groups of SourceOrigin, e.g. https://prometheus-$1.example.com, and
+	// include a path prefix.
+	TargetOrigin string `yaml:"target_origin" json:"target_origin"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for
+// GeneratorURLRewrite.
+func (r *GeneratorURLRewrite) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain GeneratorURLRewrite
+	if err := unmarshal((*plain)(r)); err != nil {
+		return err
+	}
+	if r.SourceOrigin.Regexp == nil {
+		return errors.New("missing source_origin in generator URL rewrite")
+	}
+	if r.TargetOrigin == "" {
+		return errors.New("missing target_origin in generator URL rewrite")
+	}
+	return nil
+}
+
+// GroupLabelNormalizer normalizes the values of labels before grouping, e.g.
+// to group the alerts of senders using different cases or domain suffixes for
+// the same hosts.
+type GroupLabelNormalizer struct {
+	// Labels are the labels whose values are normalized.
+	Labels []model.LabelName `yaml:"labels" json:"labels"`
+	// Lowercase lowercases the values.
+	Lowercase bool `yaml:"lowercase,omitempty" json:"lowercase,omitempty"`
+	// Regex is matched against the whole value, after lowercasing. Matching
+	// values are replaced by Replacement.
+	Regex Regexp `yaml:"regex,omitempty" json:"regex,omitempty"`
+	// Replacement replaces the values matching Regex. It can reference the
+	// capture groups of Regex and defaults to $1.
+	Replacement string `yaml:"replacement,omitempty" json:"replacement,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for
+// GroupLabelNormalizer.
+func (n *GroupLabelNormalizer) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain GroupLabelNormalizer
+	if err := unmarshal((*plain)(n)); err != nil {
+		return err
+	}
+	if len(n.Labels) == 0 {
+		return errors.New("missing labels in group label normalizer")
+	}
+	if !n.Lowercase && n.Regex.Regexp == nil {
+		return errors.New("group label normalizer must lowercase or have a regex")
+	}
+	if n.Regex.Regexp != nil && n.Replacement == "" {
+		n.Replacement = "$1"
+	}
+	return nil
+}
+
+// Normalize returns the normalized value of the label, which is unchanged if
+// the normalizer does not apply to the label.
+func (n *GroupLabelNormalizer) Normalize(name model.LabelName, value model.LabelValue) model.LabelValue {
+	if !slices.Contains(n.Labels, name) {
+		return value
+	}
+	v := string(value)
+	if n.Lowercase {
+		v = strings.ToLower(v)
+	}
+	if n.Regex.Regexp != nil {
+		if idx := n.Regex.FindStringSubmatchIndex(v); idx != nil {
+			v = string(n.Regex.ExpandString(nil, n.Replacement, v, idx))
+		}
+	}
+	return model.LabelValue(v)
+}
+
+// IngestionRateLimitKey is what the requests posting alerts are rate limited
+// by.
+type IngestionRateLimitKey string
+
+const (
+	// IngestionRateLimitKeyIP limits the requests by the IP address of the
+	// client.
+	IngestionRateLimitKeyIP IngestionRateLimitKey = "ip"
+	// IngestionRateLimitKeyToken limits the requests by the credentials of
+	// their Authorization header.
+	IngestionRateLimitKeyToken IngestionRateLimitKey = "token"
+)
+
+// IngestionRateLimit limits the rate of the requests posting alerts of each
+// client, to protect Alertmanager against runaway senders.
+type IngestionRateLimit struct {
+	// Key is what the requests are limited by.
+	Key IngestionRateLimitKey `yaml:"key,omitempty" json:"key,omitempty"`
+	// Rate is the number of requests per second allowed for each key.
+	Rate float64 `yaml:"rate" json:"rate"`
+	// Burst is the number of requests allowed at once for each key.
+	Burst int `yaml:"burst,omitempty" json:"burst,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for
+// IngestionRateLimit.
+func (l *IngestionRateLimit) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain IngestionRateLimit
+	if err := unmarshal((*plain)(l)); err != nil {
+		return err
+	}
+	switch l.Key {
+	case "":
+		l.Key = IngestionRateLimitKeyIP
+	case IngestionRateLimitKeyIP, IngestionRateLimitKeyToken:
+	default:
+		return fmt.Errorf("unknown key %q in ingestion rate limit, must be ip or token", l.Key)
+	}
+	if l.Rate <= 0 {
+		return errors.New("rate of the ingestion rate limit must be positive")
+	}
+	if l.Burst < 0 {
+		return errors.New("burst of the ingestion rate limit cannot be negative")
+	}
+	if l.Burst == 0 {
+		l.Burst = max(1, int(math.Ceil(l.Rate)))
+	}
+	return nil
+}
+
+// AlertOrigin configures how the origin, i.e. the sender, of the alerts is
+// identified.
+type AlertOrigin struct {
+	// Header is the HTTP header of the requests posting the alerts holding
+	// their origin.
+	Header string `yaml:"header,omitempty" json:"header,omitempty"`
+	// Label is the label of the alerts holding their origin. The label is
+	// removed from the alerts so that the alerts sent by several origins,
+	// e.g. the replicas of a Prometheus HA pair, are deduplicated.
+	Label model.LabelName `yaml:"label,omitempty" json:"label,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for AlertOrigin.
+func (o *AlertOrigin) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain AlertOrigin
+	if err := unmarshal((*plain)(o)); err != nil {
+		return err
+	}
+	if o.Header == "" && o.Label == "" {
+		return errors.New("alert origin requires a header or a label")
+	}
+	return nil
+}
+
+// Rewrite returns the rewritten generator URL and whether the rewrite
+// applied.
+func (r *GeneratorURLRewrite) Rewrite(generatorURL string) (string, bool) {
+	u, err := url.Parse(generatorURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return generatorURL, false
+	}
+	origin := u.Scheme + "://" + u.Host
+	m := r.SourceOrigin.FindStringSubmatchIndex(origin)
+	if m == nil {
+		return generatorURL, false
+	}
+	target, err := url.Parse(string(r.SourceOrigin.ExpandString(nil, r.TargetOrigin, origin, m)))
+	if err != nil || target.Scheme == "" || target.Host == "" {
+		return generatorURL, false
+	}
+	u.Scheme, u.Host = target.Scheme, target.Host
+	if prefix := strings.TrimSuffix(target.Path, "/"); prefix != "" {
+		u.Path = prefix + u.Path
+		u.RawPath = ""
+	}
+	return u.String(), true
+}
+
+// SilencePolicy restricts the silences which can be created or updated.
+type SilencePolicy struct {
+	// MaxDuration is the maximum duration of a silence. Zero means no limit.
+	MaxDuration model.Duration `yaml:"max_duration,omitempty" json:"max_duration,omitempty"`
+	// CommentRegex must match the whole comment of a silence, e.g. to
+	// require a ticket ID.
+	CommentRegex Regexp `yaml:"comment_regex,omitempty" json:"comment_regex,omitempty"`
+	// DeniedMatchers are the matchers of alerts which can't be silenced,
+	// e.g. alertname="Watchdog".
+	DeniedMatchers Matchers `yaml:"denied_matchers,omitempty" json:"denied_matchers,omitempty"`
 }
 
 // checkReceiver returns an error if a node in the routing tree
@@ -662,6 +1037,16 @@ func checkReceiver(r *Route, receivers map[string]struct{}) error {
 			return err
 		}
 	}
+	for _, e := range r.Escalations {
+		if _, ok := receivers[e.Receiver]; !ok {
+			return fmt.Errorf("undefined receiver %q used in escalation", e.Receiver)
+		}
+	}
+	for _, name := range r.AllowedReceivers {
+		if _, ok := receivers[name]; !ok {
+			return fmt.Errorf("undefined receiver %q used in allowed_receivers", name)
+		}
+	}
 	if r.Receiver == "" {
 		return nil
 	}
@@ -802,6 +1187,60 @@ func (hp HostPort) String() string {
 	return fmt.Sprintf("%s:%s", hp.Host, hp.Port)
 }
 
+// TLSCipher is a TLS cipher suite, referenced by its name.
+type TLSCipher uint16
+
+func parseTLSCipher(s string) (TLSCipher, error) {
+	for _, cs := range tls.CipherSuites() {
+		if cs.Name == s {
+			return TLSCipher(cs.ID), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown or insecure TLS cipher suite %q", s)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for TLSCipher.
+func (c *TLSCipher) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	cs, err := parseTLSCipher(s)
+	if err != nil {
+		return err
+	}
+	*c = cs
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for TLSCipher.
+func (c *TLSCipher) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	cs, err := parseTLSCipher(s)
+	if err != nil {
+		return err
+	}
+	*c = cs
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface for TLSCipher.
+func (c TLSCipher) MarshalYAML() (interface{}, error) {
+	return c.String(), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for TLSCipher.
+func (c TLSCipher) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+func (c TLSCipher) String() string {
+	return tls.CipherSuiteName(uint16(c))
+}
+
 // GlobalConfig defines configuration parameters that are valid globally
 // unless overwritten.
 type GlobalConfig struct {
@@ -822,6 +1261,8 @@ type GlobalConfig struct {
 	SMTPAuthIdentity      string               `yaml:"smtp_auth_identity,omitempty" json:"smtp_auth_identity,omitempty"`
 	SMTPRequireTLS        bool                 `yaml:"smtp_require_tls" json:"smtp_require_tls,omitempty"`
 	SMTPTLSConfig         *commoncfg.TLSConfig `yaml:"smtp_tls_config,omitempty" json:"smtp_tls_config,omitempty"`
+	SMTPTLSCipherSuites   []TLSCipher          `yaml:"smtp_tls_cipher_suites,omitempty" json:"smtp_tls_cipher_suites,omitempty"`
+	SMTPForceImplicitTLS  *bool                `yaml:"smtp_force_implicit_tls,omitempty" json:"smtp_force_implicit_tls,omitempty"`
 	SlackAPIURL           *SecretURL           `yaml:"slack_api_url,omitempty" json:"slack_api_url,omitempty"`
 	SlackAPIURLFile       string               `yaml:"slack_api_url_file,omitempty" json:"slack_api_url_file,omitempty"`
 	PagerdutyURL          *URL                 `yaml:"pagerduty_url,omitempty" json:"pagerduty_url,omitempty"`
@@ -841,22 +1282,88 @@ type GlobalConfig struct {
 	RocketchatTokenFile   string               `yaml:"rocketchat_token_file,omitempty" json:"rocketchat_token_file,omitempty"`
 	RocketchatTokenID     *Secret              `yaml:"rocketchat_token_id,omitempty" json:"rocketchat_token_id,omitempty"`
 	RocketchatTokenIDFile string               `yaml:"rocketchat_token_id_file,omitempty" json:"rocketchat_token_id_file,omitempty"`
+
+	// HTTPHeaders are the default headers of the receivers.
+	HTTPHeaders map[string]Secret `yaml:"http_headers,omitempty" json:"http_headers,omitempty"`
+
+	// DeadLetterReceiver is notified when the notification of another
+	// receiver is given up after all retries failed.
+	DeadLetterReceiver string `yaml:"dead_letter_receiver,omitempty" json:"dead_letter_receiver,omitempty"`
+
+	// DisabledIntegrations are the integrations which receivers must not
+	// use, such as email or wechat.
+	DisabledIntegrations []string `yaml:"disabled_integrations,omitempty" json:"disabled_integrations,omitempty"`
+
+	// ClusterLabels identify the Alertmanager cluster or replica in the
+	// notifications, helping to trace duplicates across clusters.
+	ClusterLabels model.LabelSet `yaml:"cluster_labels,omitempty" json:"cluster_labels,omitempty"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface for GlobalConfig.
 func (c *GlobalConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	*c = DefaultGlobalConfig()
 	type plain GlobalConfig
-	return unmarshal((*plain)(c))
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	headers, err := normalizeHTTPHeaders(c.HTTPHeaders)
+	if err != nil {
+		return err
+	}
+	c.HTTPHeaders = headers
+	integrations := (&Receiver{}).integrations()
+	for _, name := range c.DisabledIntegrations {
+		if _, ok := integrations[name]; !ok {
+			return fmt.Errorf("unknown integration %q in disabled_integrations", name)
+		}
+	}
+	return nil
+}
+
+// AlertOrderKeys are the keys the alerts of notifications can be ordered by.
+var AlertOrderKeys = map[string]struct{}{
+	"severity":  {},
+	"startsAt":  {},
+	"alertname": {},
 }
 
 // A Route is a node that contains definitions of how to handle alerts.
 type Route struct {
 	Receiver string `yaml:"receiver,omitempty" json:"receiver,omitempty"`
+	// ReceiverFromLabel is the label whose value selects the receiver of
+	// the alerts among AllowedReceivers. Alerts without the label, or with
+	// a value which isn't allowed, go to Receiver. The alerts are grouped by
+	// the label in addition to GroupBy. Empty means inherited from the
+	// parent route.
+	ReceiverFromLabel string   `yaml:"receiver_from_label,omitempty" json:"receiver_from_label,omitempty"`
+	AllowedReceivers  []string `yaml:"allowed_receivers,omitempty" json:"allowed_receivers,omitempty"`
 
 	GroupByStr []string          `yaml:"group_by,omitempty" json:"group_by,omitempty"`
 	GroupBy    []model.LabelName `yaml:"-" json:"-"`
 	GroupByAll bool              `yaml:"-" json:"-"`
+	// GroupByLimit is the maximum number of aggregation groups of the route.
+	// Alerts which would create more groups are collapsed into a single
+	// overflow group. Zero means inherited from the parent route, or
+	// unlimited for the root route.
+	GroupByLimit int `yaml:"group_by_limit,omitempty" json:"group_by_limit,omitempty"`
+	// GroupAlertLimit is the maximum number of alerts in the notifications of
+	// the groups of the route. The other alerts are left out of the
+	// notifications and counted in the TruncatedAlerts field of the template
+	// data. Zero means inherited from the parent route, or unlimited for the
+	// root route.
+	GroupAlertLimit int `yaml:"group_alert_limit,omitempty" json:"group_alert_limit,omitempty"`
+	// CollapseBy collapses the alerts of the notifications of the route
+	// which have the same alertname and the same values of the given
+	// annotations into a single one. Empty means inherited from the parent
+	// route.
+	CollapseBy []string `yaml:"collapse_by,omitempty" json:"collapse_by,omitempty"`
+	// AlertOrder orders the alerts of the notifications of the route by the
+	// given keys, prefixed with "-" for the descending order. Empty means
+	// inherited from the parent route.
+	AlertOrder []string `yaml:"alert_order,omitempty" json:"alert_order,omitempty"`
+	// SeverityOrder ranks the values of the severity label, from the most
+	// to the least important, when ordering alerts by severity.
+	SeverityOrder []string `yaml:"severity_order,omitempty" json:"severity_order,omitempty"`
 	// Deprecated. Remove before v1.0 release.
 	Match map[string]string `yaml:"match,omitempty" json:"match,omitempty"`
 	// Deprecated. Remove before v1.0 release.
@@ -870,6 +1377,29 @@ type Route struct {
 	GroupWait      *model.Duration `yaml:"group_wait,omitempty" json:"group_wait,omitempty"`
 	GroupInterval  *model.Duration `yaml:"group_interval,omitempty" json:"group_interval,omitempty"`
 	RepeatInterval *model.Duration `yaml:"repeat_interval,omitempty" json:"repeat_interval,omitempty"`
+	// NotificationDeadline is the time within which the firing alerts of the
+	// route should be notified after they were received. Zero means no
+	// deadline.
+	NotificationDeadline *model.Duration `yaml:"notification_deadline,omitempty" json:"notification_deadline,omitempty"`
+	// PeerTimeout overrides the --cluster.peer-timeout flag for the
+	// notifications of the route: each peer of the cluster waits its
+	// position times the timeout before sending them. Nil means inherited
+	// from the parent route.
+	PeerTimeout *model.Duration `yaml:"peer_timeout,omitempty" json:"peer_timeout,omitempty"`
+	// Escalations are the receivers additionally notified about the groups
+	// of the route which are still firing after a while, in increasing order
+	// of their delays. Empty means inherited from the parent route.
+	Escalations []Escalation `yaml:"escalations,omitempty" json:"escalations,omitempty"`
+}
+
+// Escalation is a receiver notified about the groups of a route which have
+// been firing for a while.
+type Escalation struct {
+	// After is how long the group must have been firing, since the start
+	// of its oldest firing alert, before the receiver is notified.
+	After model.Duration `yaml:"after" json:"after"`
+	// Receiver is the name of the receiver to notify.
+	Receiver string `yaml:"receiver" json:"receiver"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface for Route.
@@ -916,6 +1446,65 @@ func (r *Route) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if r.RepeatInterval != nil && time.Duration(*r.RepeatInterval) == time.Duration(0) {
 		return errors.New("repeat_interval cannot be zero")
 	}
+	if r.GroupByLimit < 0 {
+		return errors.New("group_by_limit cannot be negative")
+	}
+	if r.GroupAlertLimit < 0 {
+		return errors.New("group_alert_limit cannot be negative")
+	}
+
+	collapseBy := map[string]struct{}{}
+	for _, a := range r.CollapseBy {
+		if !model.LabelName(a).IsValid() {
+			return fmt.Errorf("invalid annotation name %q in collapse_by", a)
+		}
+		if _, ok := collapseBy[a]; ok {
+			return fmt.Errorf("duplicated annotation %q in collapse_by", a)
+		}
+		collapseBy[a] = struct{}{}
+	}
+
+	orderKeys := map[string]struct{}{}
+	for _, k := range r.AlertOrder {
+		key := strings.TrimPrefix(k, "-")
+		if _, ok := AlertOrderKeys[key]; !ok {
+			return fmt.Errorf("unknown alert_order key %q", k)
+		}
+		if _, ok := orderKeys[key]; ok {
+			return fmt.Errorf("duplicated key %q in alert_order", key)
+		}
+		orderKeys[key] = struct{}{}
+	}
+	severities := map[string]struct{}{}
+	for _, sev := range r.SeverityOrder {
+		if _, ok := severities[sev]; ok {
+			return fmt.Errorf("duplicated severity %q in severity_order", sev)
+		}
+		severities[sev] = struct{}{}
+	}
+
+	if r.ReceiverFromLabel != "" {
+		if !compat.IsValidLabelName(model.LabelName(r.ReceiverFromLabel)) {
+			return fmt.Errorf("invalid label name %q in receiver_from_label", r.ReceiverFromLabel)
+		}
+		if len(r.AllowedReceivers) == 0 {
+			return errors.New("receiver_from_label requires allowed_receivers")
+		}
+	} else if len(r.AllowedReceivers) > 0 {
+		return errors.New("allowed_receivers requires receiver_from_label")
+	}
+
+	for i, e := range r.Escalations {
+		if e.Receiver == "" {
+			return errors.New("missing receiver in escalation")
+		}
+		if e.After <= 0 {
+			return fmt.Errorf("escalation to %q must be after a positive duration", e.Receiver)
+		}
+		if i > 0 && e.After <= r.Escalations[i-1].After {
+			return errors.New("escalations must be in increasing order of their after durations")
+		}
+	}
 
 	return nil
 }
@@ -988,8 +1577,42 @@ type Receiver struct {
 	MSTeamsV2Configs  []*MSTeamsV2Config  `yaml:"msteamsv2_configs,omitempty" json:"msteamsv2_configs,omitempty"`
 	JiraConfigs       []*JiraConfig       `yaml:"jira_configs,omitempty" json:"jira_configs,omitempty"`
 	RocketchatConfigs []*RocketchatConfig `yaml:"rocketchat_configs,omitempty" json:"rocketchat_configs,omitempty"`
+	VoiceCallConfigs  []*VoiceCallConfig  `yaml:"voicecall_configs,omitempty" json:"voicecall_configs,omitempty"`
+	SNMPConfigs       []*SNMPConfig       `yaml:"snmp_configs,omitempty" json:"snmp_configs,omitempty"`
+
+	// DebugLogPayloads records the requests of the failed notifications of
+	// the receiver, with their credentials redacted, for debugging.
+	DebugLogPayloads bool `yaml:"debug_log_payloads,omitempty" json:"debug_log_payloads,omitempty"`
+
+	// Headers are added to the HTTP requests of the integrations of the
+	// receiver. Their values are templates.
+	Headers map[string]Secret `yaml:"headers,omitempty" json:"headers,omitempty"`
+
+	// QuietTimeIntervals are the names of the time intervals during which
+	// the notifications of the receiver are queued, and sent when the
+	// interval ends.
+	QuietTimeIntervals []string `yaml:"quiet_time_intervals,omitempty" json:"quiet_time_intervals,omitempty"`
+
+	// IncludeAnnotations, if set, are the only annotations of the alerts
+	// sent by the integrations of the receiver. ExcludeAnnotations are
+	// removed from them. The annotations of the stored alerts are unchanged.
+	IncludeAnnotations []string `yaml:"include_annotations,omitempty" json:"include_annotations,omitempty"`
+	ExcludeAnnotations []string `yaml:"exclude_annotations,omitempty" json:"exclude_annotations,omitempty"`
+
+	// HAMode selects which peers of the cluster send the notifications of
+	// the receiver.
+	HAMode string `yaml:"ha_mode,omitempty" json:"ha_mode,omitempty"`
 }
 
+const (
+	// HAModeAll makes every peer send the notifications not yet sent by the
+	// peers before it.
+	HAModeAll = "all"
+	// HAModeLeaderOnly makes the first peer of the cluster send all the
+	// notifications, the next one taking over when it leaves or dies.
+	HAModeLeaderOnly = "leader_only"
+)
+
 // UnmarshalYAML implements the yaml.Unmarshaler interface for Receiver.
 func (c *Receiver) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	type plain Receiver
@@ -999,9 +1622,80 @@ func (c *Receiver) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if c.Name == "" {
 		return errors.New("missing name in receiver")
 	}
+	if len(c.IncludeAnnotations) > 0 && len(c.ExcludeAnnotations) > 0 {
+		return fmt.Errorf("receiver %q: include_annotations and exclude_annotations are mutually exclusive", c.Name)
+	}
+	switch c.HAMode {
+	case "", HAModeAll, HAModeLeaderOnly:
+	default:
+		return fmt.Errorf("receiver %q: unknown ha_mode %q", c.Name, c.HAMode)
+	}
+	headers, err := normalizeHTTPHeaders(c.Headers)
+	if err != nil {
+		return fmt.Errorf("receiver %q: %w", c.Name, err)
+	}
+	c.Headers = headers
 	return nil
 }
 
+// integrations returns the number of configurations of each integration of
+// the receiver, by integration name.
+func (c *Receiver) integrations() map[string]int {
+	return map[string]int{
+		"discord":    len(c.DiscordConfigs),
+		"email":      len(c.EmailConfigs),
+		"pagerduty":  len(c.PagerdutyConfigs),
+		"slack":      len(c.SlackConfigs),
+		"webhook":    len(c.WebhookConfigs),
+		"opsgenie":   len(c.OpsGenieConfigs),
+		"wechat":     len(c.WechatConfigs),
+		"pushover":   len(c.PushoverConfigs),
+		"victorops":  len(c.VictorOpsConfigs),
+		"sns":        len(c.SNSConfigs),
+		"telegram":   len(c.TelegramConfigs),
+		"webex":      len(c.WebexConfigs),
+		"msteams":    len(c.MSTeamsConfigs),
+		"msteamsv2":  len(c.MSTeamsV2Configs),
+		"jira":       len(c.JiraConfigs),
+		"rocketchat": len(c.RocketchatConfigs),
+		"voicecall":  len(c.VoiceCallConfigs),
+		"snmp":       len(c.SNMPConfigs),
+	}
+}
+
+// reservedHTTPHeaders are the headers which can't be set with the headers of
+// receivers because they are managed by the HTTP client.
+var reservedHTTPHeaders = map[string]struct{}{
+	"Connection":        {},
+	"Content-Length":    {},
+	"Host":              {},
+	"Transfer-Encoding": {},
+}
+
+// normalizeHTTPHeaders returns the headers with canonical names and checks
+// that the names are valid.
+func normalizeHTTPHeaders(headers map[string]Secret) (map[string]Secret, error) {
+	if len(headers) == 0 {
+		return headers, nil
+	}
+	normalized := make(map[string]Secret, len(headers))
+	for h, v := range headers {
+		if !httpguts.ValidHeaderFieldName(h) {
+			return nil, fmt.Errorf("invalid header name %q", h)
+		}
+		name := http.CanonicalHeaderKey(h)
+		if _, ok := reservedHTTPHeaders[name]; ok {
+			return nil, fmt.Errorf("header %q can't be set", name)
+		}
+		// Header names are case-insensitive, check for collisions.
+		if _, ok := normalized[name]; ok {
+			return nil, fmt.Errorf("duplicate header %q", name)
+		}
+		normalized[name] = v
+	}
+	return normalized, nil
+}
+
 // MatchRegexps represents a map of Regexp.
 type MatchRegexps map[string]Regexp
 
@@ -14,7 +14,10 @@
 package config
 
 import (
+	"crypto/tls"
 	"encoding/json"
+	"fmt"
+	"net/netip"
 	"net/url"
 	"os"
 	"reflect"
@@ -101,6 +104,104 @@ receivers:
 	}
 }
 
+func TestDeadLetterReceiverExists(t *testing.T) {
+	in := `
+global:
+  dead_letter_receiver: dead-letters
+route:
+  receiver: team-X
+receivers:
+- name: 'team-X'
+`
+	_, err := Load(in)
+	require.EqualError(t, err, `undefined dead_letter_receiver "dead-letters"`)
+
+	cfg, err := Load(in + `- name: 'dead-letters'
+`)
+	require.NoError(t, err)
+	require.Equal(t, "dead-letters", cfg.Global.DeadLetterReceiver)
+}
+
+func TestQuietTimeIntervalExists(t *testing.T) {
+	in := `
+route:
+  receiver: team-X
+receivers:
+- name: 'team-X'
+  quiet_time_intervals: ['night']
+time_intervals:
+- name: 'weekend'
+  time_intervals:
+  - weekdays: ['saturday', 'sunday']
+`
+	_, err := Load(in)
+	require.EqualError(t, err, `undefined time interval "night" used in receiver "team-X"`)
+
+	cfg, err := Load(in + `- name: 'night'
+  time_intervals:
+  - times:
+    - start_time: '22:00'
+      end_time: '24:00'
+`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"night"}, cfg.Receivers[0].QuietTimeIntervals)
+}
+
+func TestIncludeAndExcludeAnnotationsAreExclusive(t *testing.T) {
+	in := `
+route:
+  receiver: team-X
+receivers:
+- name: 'team-X'
+  include_annotations: ['summary']
+  exclude_annotations: ['debug']
+`
+	_, err := Load(in)
+	require.EqualError(t, err, `receiver "team-X": include_annotations and exclude_annotations are mutually exclusive`)
+}
+
+func TestReceiverHAMode(t *testing.T) {
+	in := `
+route:
+  receiver: team-X
+receivers:
+- name: 'team-X'
+  ha_mode: %s
+`
+	cfg, err := Load(fmt.Sprintf(in, "leader_only"))
+	require.NoError(t, err)
+	require.Equal(t, HAModeLeaderOnly, cfg.Receivers[0].HAMode)
+
+	_, err = Load(fmt.Sprintf(in, "leader"))
+	require.EqualError(t, err, `receiver "team-X": unknown ha_mode "leader"`)
+}
+
+func TestDisabledIntegrations(t *testing.T) {
+	in := `
+global:
+  disabled_integrations: [%s]
+route:
+  receiver: team-X
+receivers:
+- name: 'team-X'
+  slack_configs:
+  - api_url: 'http://example.com/slack'
+    channel: '#alerts'
+- name: 'team-Y'
+  webhook_configs:
+  - url: 'http://example.com/webhook'
+`
+	cfg, err := Load(fmt.Sprintf(in, "email, wechat"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"email", "wechat"}, cfg.Global.DisabledIntegrations)
+
+	_, err = Load(fmt.Sprintf(in, "email, webhook"))
+	require.EqualError(t, err, `receiver "team-Y" uses the disabled integration "webhook"`)
+
+	_, err = Load(fmt.Sprintf(in, "mail"))
+	require.EqualError(t, err, `unknown integration "mail" in disabled_integrations`)
+}
+
 func TestReceiverExistsForDeepSubRoute(t *testing.T) {
 	in := `
 route:
@@ -266,6 +367,386 @@ route:
 	}
 }
 
+func TestIngestAdapterIsUnique(t *testing.T) {
+	in := `
+route:
+  receiver: team-X
+
+receivers:
+- name: 'team-X'
+
+ingest_adapters:
+- format: grafana
+- format: grafana
+  labels:
+    team: X
+`
+	_, err := Load(in)
+
+	expected := "ingest adapter \"grafana\" is not unique"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%q", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%q\ngot:\n%q", expected, err.Error())
+	}
+}
+
+func TestIngestAdapterUnknownFormat(t *testing.T) {
+	in := `
+route:
+  receiver: team-X
+
+receivers:
+- name: 'team-X'
+
+ingest_adapters:
+- format: nagios
+`
+	_, err := Load(in)
+
+	expected := "unknown ingest adapter \"nagios\", must be one of aws-cloudwatch, azure-monitor, grafana"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%q", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%q\ngot:\n%q", expected, err.Error())
+	}
+
+	RegisterIngestAdapterFormat("nagios")
+	defer func() {
+		ingestAdapterFormatsMtx.Lock()
+		delete(ingestAdapterFormats, "nagios")
+		ingestAdapterFormatsMtx.Unlock()
+	}()
+	_, err = Load(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestIngestAdapterLabelMappings(t *testing.T) {
+	in := `
+route:
+  receiver: team-X
+
+receivers:
+- name: 'team-X'
+
+ingest_adapters:
+- format: azure-monitor
+  label_mappings:
+    signal_type: 'invalid-name'
+`
+	_, err := Load(in)
+
+	expected := "\"invalid-name\" is not a valid label name"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%q", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%q\ngot:\n%q", expected, err.Error())
+	}
+}
+
+func TestGroupLabelNormalizers(t *testing.T) {
+	in := `
+route:
+  receiver: team-X
+
+receivers:
+- name: 'team-X'
+
+group_label_normalizers:
+- labels: [instance, host]
+  lowercase: true
+  regex: '(.+)\.example\.com(:\d+)?'
+- labels: [service]
+  regex: '(.+)-v\d+'
+  replacement: 'svc-$1'
+`
+	conf, err := Load(in)
+	require.NoError(t, err)
+	require.Len(t, conf.GroupLabelNormalizers, 2)
+	require.Equal(t, "$1", conf.GroupLabelNormalizers[0].Replacement)
+
+	normalize := func(ln model.LabelName, lv model.LabelValue) model.LabelValue {
+		for _, n := range conf.GroupLabelNormalizers {
+			lv = n.Normalize(ln, lv)
+		}
+		return lv
+	}
+	require.Equal(t, model.LabelValue("web-1"), normalize("instance", "Web-1.EXAMPLE.com:9100"))
+	require.Equal(t, model.LabelValue("web-1"), normalize("host", "web-1"))
+	require.Equal(t, model.LabelValue("web-1.example.org"), normalize("host", "Web-1.example.org"))
+	require.Equal(t, model.LabelValue("svc-api"), normalize("service", "api-v2"))
+	require.Equal(t, model.LabelValue("Api"), normalize("service", "Api"))
+	require.Equal(t, model.LabelValue("Web-1.example.com"), normalize("job", "Web-1.example.com"))
+}
+
+func TestGroupLabelNormalizersInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		err string
+	}{
+		{
+			in:  "- lowercase: true",
+			err: "missing labels in group label normalizer",
+		},
+		{
+			in:  "- labels: [instance]",
+			err: "group label normalizer must lowercase or have a regex",
+		},
+		{
+			in:  "- labels: ['in-stance']\n  lowercase: true",
+			err: `"in-stance" is not a valid label name`,
+		},
+	} {
+		in := "route:\n  receiver: team-X\nreceivers:\n- name: team-X\ngroup_label_normalizers:\n" + tc.in + "\n"
+		_, err := Load(in)
+		require.EqualError(t, err, tc.err)
+	}
+}
+
+func TestIngestionRateLimit(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected IngestionRateLimit
+		err      string
+	}{
+		{
+			in:       "{rate: 2.5}",
+			expected: IngestionRateLimit{Key: IngestionRateLimitKeyIP, Rate: 2.5, Burst: 3},
+		},
+		{
+			in:       "{key: token, rate: 0.5, burst: 10}",
+			expected: IngestionRateLimit{Key: IngestionRateLimitKeyToken, Rate: 0.5, Burst: 10},
+		},
+		{
+			in:  "{key: header, rate: 1}",
+			err: `unknown key "header" in ingestion rate limit, must be ip or token`,
+		},
+		{
+			in:  "{key: ip}",
+			err: "rate of the ingestion rate limit must be positive",
+		},
+		{
+			in:  "{rate: 1, burst: -1}",
+			err: "burst of the ingestion rate limit cannot be negative",
+		},
+	} {
+		conf, err := Load("route:\n  receiver: team-X\nreceivers:\n- name: team-X\ningestion_rate_limit: " + tc.in + "\n")
+		if tc.err != "" {
+			require.EqualError(t, err, tc.err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, *conf.IngestionRateLimit)
+	}
+}
+
+func TestGeneratorURLRewriteMissingTarget(t *testing.T) {
+	in := `
+route:
+  receiver: team-X
+
+receivers:
+- name: 'team-X'
+
+generator_url_rewrites:
+- source_origin: 'http://prometheus-(\d+)\.monitoring:9090'
+`
+	_, err := Load(in)
+
+	expected := "missing target_origin in generator URL rewrite"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%q", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%q\ngot:\n%q", expected, err.Error())
+	}
+}
+
+func TestGeneratorURLRewrite(t *testing.T) {
+	in := `
+route:
+  receiver: team-X
+
+receivers:
+- name: 'team-X'
+
+generator_url_rewrites:
+- source_origin: 'http://prometheus-(\d+)\.monitoring:9090'
+  target_origin: 'https://prometheus-$1.example.com'
+- source_origin: 'http://thanos-ruler\.monitoring(:\d+)?'
+  target_origin: 'https://example.com/thanos/'
+`
+	conf, err := Load(in)
+	require.NoError(t, err)
+	require.Len(t, conf.GeneratorURLRewrites, 2)
+
+	for _, tc := range []struct {
+		in       string
+		expected string
+		ok       bool
+	}{
+		{
+			in:       "http://prometheus-0.monitoring:9090/graph?g0.expr=up+%3D%3D+0&g0.tab=1",
+			expected: "https://prometheus-0.example.com/graph?g0.expr=up+%3D%3D+0&g0.tab=1",
+			ok:       true,
+		},
+		{
+			in:       "http://thanos-ruler.monitoring:10902/graph?g0.expr=up",
+			expected: "https://example.com/thanos/graph?g0.expr=up",
+			ok:       true,
+		},
+		{
+			// The origin must match entirely.
+			in:       "http://prometheus-0.monitoring.svc:9090/graph",
+			expected: "http://prometheus-0.monitoring.svc:9090/graph",
+		},
+		{
+			in:       "",
+			expected: "",
+		},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			var (
+				res = tc.in
+				ok  bool
+			)
+			for _, r := range conf.GeneratorURLRewrites {
+				if res, ok = r.Rewrite(tc.in); ok {
+					break
+				}
+			}
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, res)
+		})
+	}
+}
+
+func TestAlertOrigin(t *testing.T) {
+	for _, tc := range []struct {
+		in     string
+		err    string
+		origin *AlertOrigin
+	}{
+		{
+			in:     "header: X-Prometheus-Replica\nlabel: prometheus_replica",
+			origin: &AlertOrigin{Header: "X-Prometheus-Replica", Label: "prometheus_replica"},
+		},
+		{
+			in:     "label: prometheus_replica",
+			origin: &AlertOrigin{Label: "prometheus_replica"},
+		},
+		{
+			in:  "{}",
+			err: "alert origin requires a header or a label",
+		},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			in := "route:\n  receiver: team-X\nreceivers:\n- name: team-X\nalert_origin:\n  " + strings.ReplaceAll(tc.in, "\n", "\n  ") + "\n"
+			conf, err := Load(in)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.origin, conf.AlertOrigin)
+		})
+	}
+}
+
+func TestSilencePolicy(t *testing.T) {
+	in := `
+route:
+  receiver: team-X
+
+receivers:
+- name: 'team-X'
+
+silence_policy:
+  max_duration: 7d
+  comment_regex: '.*[A-Z]+-[0-9]+.*'
+  denied_matchers:
+  - alertname="Watchdog"
+`
+	conf, err := Load(in)
+	require.NoError(t, err)
+	require.NotNil(t, conf.SilencePolicy)
+	require.Equal(t, model.Duration(7*24*time.Hour), conf.SilencePolicy.MaxDuration)
+	require.True(t, conf.SilencePolicy.CommentRegex.MatchString("Maintenance, see OPS-123."))
+	require.False(t, conf.SilencePolicy.CommentRegex.MatchString("Maintenance"))
+	require.Len(t, conf.SilencePolicy.DeniedMatchers, 1)
+	require.Equal(t, `alertname="Watchdog"`, conf.SilencePolicy.DeniedMatchers[0].String())
+}
+
+func TestEgressPolicy(t *testing.T) {
+	in := `
+route:
+  receiver: team-X
+
+receivers:
+- name: 'team-X'
+
+egress_policy:
+  allowed_hosts: ['hooks.slack.com', '*.pagerduty.com']
+  allowed_cidrs: ['10.0.0.0/8', '192.168.1.1', '2001:db8::1/64']
+`
+	conf, err := Load(in)
+	require.NoError(t, err)
+	require.NotNil(t, conf.EgressPolicy)
+	require.Equal(t, []string{"hooks.slack.com", "*.pagerduty.com"}, conf.EgressPolicy.AllowedHosts)
+	prefixes, err := conf.EgressPolicy.Prefixes()
+	require.NoError(t, err)
+	require.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.1.1/32"),
+		netip.MustParsePrefix("2001:db8::/64"),
+	}, prefixes)
+
+	for _, policy := range []string{
+		`allowed_hosts: ['https://hooks.slack.com']`,
+		`allowed_hosts: ['hooks.*.com']`,
+		`allowed_cidrs: ['10.0.0.0/33']`,
+		`allowed_cidrs: ['example.com']`,
+	} {
+		_, err := Load("route:\n  receiver: team-X\nreceivers:\n- name: team-X\negress_policy:\n  " + policy + "\n")
+		require.Error(t, err, policy)
+	}
+}
+
+func TestDNSCache(t *testing.T) {
+	conf, err := Load("route:\n  receiver: team-X\nreceivers:\n- name: team-X\n")
+	require.NoError(t, err)
+	require.Nil(t, conf.DNSCache)
+
+	conf, err = Load("route:\n  receiver: team-X\nreceivers:\n- name: team-X\ndns_cache: {}\n")
+	require.NoError(t, err)
+	require.Equal(t, DefaultDNSCache, *conf.DNSCache)
+
+	conf, err = Load("route:\n  receiver: team-X\nreceivers:\n- name: team-X\ndns_cache: {ttl: 5m, stale_ttl: 0s}\n")
+	require.NoError(t, err)
+	require.Equal(t, DNSCache{TTL: model.Duration(5 * time.Minute)}, *conf.DNSCache)
+
+	_, err = Load("route:\n  receiver: team-X\nreceivers:\n- name: team-X\ndns_cache: {ttl: 0s}\n")
+	require.EqualError(t, err, "ttl of the DNS cache must be positive")
+}
+
+func TestClusterLabels(t *testing.T) {
+	conf, err := Load("global:\n  cluster_labels:\n    cluster: eu-1\nroute:\n  receiver: team-X\nreceivers:\n- name: team-X\n")
+	require.NoError(t, err)
+	require.Equal(t, model.LabelSet{"cluster": "eu-1"}, conf.Global.ClusterLabels)
+
+	_, err = Load("global:\n  cluster_labels:\n    0cluster: eu-1\nroute:\n  receiver: team-X\nreceivers:\n- name: team-X\n")
+	require.Error(t, err)
+}
+
 func TestGroupByHasNoDuplicatedLabels(t *testing.T) {
 	in := `
 route:
@@ -326,6 +807,155 @@ receivers:
 	}
 }
 
+func TestGroupByLimitNegative(t *testing.T) {
+	in := `
+route:
+  group_by: ['instance']
+  group_by_limit: -1
+  receiver: team-X-mails
+receivers:
+- name: 'team-X-mails'
+`
+	_, err := Load(in)
+
+	expected := "group_by_limit cannot be negative"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%q", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%q\ngot:\n%q", expected, err.Error())
+	}
+}
+
+func TestCollapseByDuplicated(t *testing.T) {
+	in := `
+route:
+  collapse_by: ['summary', 'summary']
+  receiver: team-X-mails
+receivers:
+- name: 'team-X-mails'
+`
+	_, err := Load(in)
+	require.EqualError(t, err, `duplicated annotation "summary" in collapse_by`)
+}
+
+func TestGroupAlertLimitNegative(t *testing.T) {
+	in := `
+route:
+  group_by: ['instance']
+  group_alert_limit: -1
+  receiver: team-X-mails
+receivers:
+- name: 'team-X-mails'
+`
+	_, err := Load(in)
+
+	expected := "group_alert_limit cannot be negative"
+
+	if err == nil {
+		t.Fatalf("no error returned, expected:\n%q", expected)
+	}
+	if err.Error() != expected {
+		t.Errorf("\nexpected:\n%q\ngot:\n%q", expected, err.Error())
+	}
+}
+
+func TestAlertOrder(t *testing.T) {
+	for _, tc := range []struct {
+		order      string
+		severities string
+		err        string
+	}{
+		{order: "[severity, -startsAt, alertname]", severities: "[P1, P2]"},
+		{order: "[priority]", err: `unknown alert_order key "priority"`},
+		{order: "[startsAt, -startsAt]", err: `duplicated key "startsAt" in alert_order`},
+		{order: "[severity]", severities: "[P1, P1]", err: `duplicated severity "P1" in severity_order`},
+	} {
+		t.Run(tc.order, func(t *testing.T) {
+			in := `
+route:
+  receiver: team-X-mails
+  alert_order: ` + tc.order + `
+`
+			if tc.severities != "" {
+				in += "  severity_order: " + tc.severities + "\n"
+			}
+			in += `receivers:
+- name: 'team-X-mails'
+`
+			_, err := Load(in)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestEscalations(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		escalations string
+		err         string
+	}{
+		{name: "valid", escalations: "[{after: 15m, receiver: team-lead}, {after: 1h, receiver: team-X-mails}]"},
+		{name: "missing receiver", escalations: "[{after: 15m}]", err: "missing receiver in escalation"},
+		{name: "zero delay", escalations: "[{after: 0s, receiver: team-lead}]", err: `escalation to "team-lead" must be after a positive duration`},
+		{name: "unordered", escalations: "[{after: 1h, receiver: team-lead}, {after: 15m, receiver: team-X-mails}]", err: "escalations must be in increasing order of their after durations"},
+		{name: "undefined receiver", escalations: "[{after: 15m, receiver: manager}]", err: `undefined receiver "manager" used in escalation`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			in := `
+route:
+  receiver: team-X-mails
+  escalations: ` + tc.escalations + `
+receivers:
+- name: 'team-X-mails'
+- name: 'team-lead'
+`
+			_, err := Load(in)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestReceiverFromLabel(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		route string
+		err   string
+	}{
+		{name: "valid", route: "receiver_from_label: notify_to\n  allowed_receivers: [team-lead]"},
+		{name: "invalid label", route: "receiver_from_label: notify-to\n  allowed_receivers: [team-lead]", err: `invalid label name "notify-to" in receiver_from_label`},
+		{name: "missing allowlist", route: "receiver_from_label: notify_to", err: "receiver_from_label requires allowed_receivers"},
+		{name: "missing label", route: "allowed_receivers: [team-lead]", err: "allowed_receivers requires receiver_from_label"},
+		{name: "undefined receiver", route: "receiver_from_label: notify_to\n  allowed_receivers: [manager]", err: `undefined receiver "manager" used in allowed_receivers`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			in := `
+route:
+  receiver: team-X-mails
+  ` + tc.route + `
+receivers:
+- name: 'team-X-mails'
+- name: 'team-lead'
+`
+			_, err := Load(in)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
 func TestRootRouteExists(t *testing.T) {
 	in := `
 receivers:
@@ -969,6 +1599,45 @@ func TestGlobalAndLocalHTTPConfig(t *testing.T) {
 	}
 }
 
+func TestGlobalAndLocalHTTPHeaders(t *testing.T) {
+	in := `
+global:
+  http_headers:
+    x-tenant: global
+    X-Gateway-Key: key
+route:
+  receiver: team-X
+receivers:
+- name: team-X
+  headers:
+    X-Tenant: '{{ .GroupLabels.team }}'
+- name: team-Y
+`
+	c, err := Load(in)
+	require.NoError(t, err)
+	require.Equal(t, map[string]Secret{"X-Tenant": "global", "X-Gateway-Key": "key"}, c.Global.HTTPHeaders)
+	require.Equal(t, map[string]Secret{"X-Tenant": "{{ .GroupLabels.team }}", "X-Gateway-Key": "key"}, c.Receivers[0].Headers)
+	require.Equal(t, map[string]Secret{"X-Tenant": "global", "X-Gateway-Key": "key"}, c.Receivers[1].Headers)
+	require.NotContains(t, c.String(), "X-Gateway-Key: key")
+
+	for _, tc := range []struct {
+		headers string
+		err     string
+	}{
+		{headers: "{x-tenant: a, X-Tenant: b}", err: `duplicate header "X-Tenant"`},
+		{headers: "{'X Tenant': a}", err: `invalid header name "X Tenant"`},
+		{headers: "{content-length: 1}", err: `header "Content-Length" can't be set`},
+	} {
+		_, err := Load(`
+route:
+  receiver: team-X
+receivers:
+- name: team-X
+  headers: ` + tc.headers + "\n")
+		require.EqualError(t, err, `receiver "team-X": `+tc.err)
+	}
+}
+
 func TestSMTPHello(t *testing.T) {
 	c, err := LoadFile("testdata/conf.good.yml")
 	if err != nil {
@@ -1015,6 +1684,40 @@ func TestGlobalAndLocalSMTPPassword(t *testing.T) {
 	require.Emptyf(t, config.Receivers[0].EmailConfigs[2].AuthPasswordFile, "file field should be empty when password provided")
 }
 
+func TestGlobalAndLocalSMTPTLS(t *testing.T) {
+	in := `
+global:
+  smtp_smarthost: 'localhost:465'
+  smtp_from: 'alertmanager@example.org'
+  smtp_tls_cipher_suites: [TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256]
+  smtp_force_implicit_tls: false
+route:
+  receiver: 'email-notifications'
+receivers:
+  - name: 'email-notifications'
+    email_configs:
+      - to: 'one@example.org'
+      - to: 'two@example.org'
+        tls_cipher_suites: [TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384]
+        force_implicit_tls: true
+`
+	c, err := Load(in)
+	require.NoError(t, err)
+
+	ecs := c.Receivers[0].EmailConfigs
+	require.Equal(t, []TLSCipher{TLSCipher(tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256)}, ecs[0].TLSCipherSuites)
+	require.False(t, *ecs[0].ForceImplicitTLS)
+	require.Equal(t, []TLSCipher{TLSCipher(tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384)}, ecs[1].TLSCipherSuites)
+	require.True(t, *ecs[1].ForceImplicitTLS)
+
+	out, err := yaml.Marshal(ecs[1])
+	require.NoError(t, err)
+	require.Contains(t, string(out), "tls_cipher_suites:\n- TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384\n")
+
+	_, err = Load(strings.Replace(in, "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_RSA_WITH_RC4_128_SHA", 1))
+	require.EqualError(t, err, `unknown or insecure TLS cipher suite "TLS_RSA_WITH_RC4_128_SHA"`)
+}
+
 func TestGroupByAll(t *testing.T) {
 	c, err := LoadFile("testdata/conf.group-by-all.yml")
 	if err != nil {
@@ -1128,6 +1831,62 @@ func TestOpsGenieNoAPIKey(t *testing.T) {
 	}
 }
 
+func TestReceiverRegions(t *testing.T) {
+	in := `
+global:
+  opsgenie_api_key: key
+route:
+  receiver: team-X
+receivers:
+- name: team-X
+  opsgenie_configs:
+  - region: eu
+  - {}
+  pagerduty_configs:
+  - routing_key: key
+    region: eu
+  victorops_configs:
+  - api_key: key
+    routing_key: key
+    region: us
+`
+	conf, err := Load(in)
+	require.NoError(t, err)
+	rcv := conf.Receivers[0]
+	require.Equal(t, "https://api.eu.opsgenie.com/", rcv.OpsGenieConfigs[0].APIURL.String())
+	require.Equal(t, "https://api.opsgenie.com/", rcv.OpsGenieConfigs[1].APIURL.String())
+	require.Equal(t, "https://events.eu.pagerduty.com/v2/enqueue", rcv.PagerdutyConfigs[0].URL.String())
+	require.Equal(t, "https://alert.victorops.com/integrations/generic/20131114/alert/", rcv.VictorOpsConfigs[0].APIURL.String())
+}
+
+func TestReceiverRegionsInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		err string
+	}{
+		{
+			in:  "opsgenie_configs:\n  - api_key: key\n    region: ap",
+			err: `unknown OpsGenie region "ap", must be one of eu, us`,
+		},
+		{
+			in:  "opsgenie_configs:\n  - api_key: key\n    region: eu\n    api_url: https://api.opsgenie.com/",
+			err: "at most one of api_url & region must be configured",
+		},
+		{
+			in:  "pagerduty_configs:\n  - routing_key: key\n    region: eu\n    url: https://events.pagerduty.com/v2/enqueue",
+			err: "at most one of url & region must be configured",
+		},
+		{
+			in:  "victorops_configs:\n  - api_key: key\n    routing_key: key\n    region: eu",
+			err: `unknown VictorOps region "eu", must be one of us`,
+		},
+	} {
+		in := "route:\n  receiver: team-X\nreceivers:\n- name: team-X\n  " + tc.in + "\n"
+		_, err := Load(in)
+		require.EqualError(t, err, tc.err)
+	}
+}
+
 func TestOpsGenieDeprecatedTeamSpecified(t *testing.T) {
 	_, err := LoadFile("testdata/conf.opsgenie-default-apikey-old-team.yml")
 	if err == nil {
@@ -14,11 +14,17 @@
 package config
 
 import (
+	"context"
 	"crypto/md5"
+	"crypto/sha256"
 	"encoding/binary"
+	"errors"
+	"io/fs"
 	"log/slog"
 	"sync"
+	"time"
 
+	"filippo.io/age"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -28,16 +34,37 @@ type Coordinator struct {
 	configFilePath string
 	logger         *slog.Logger
 
-	// Protects config and subscribers
+	// Protects config, preparers, subscribers, templates, templateHashes,
+	// ageIdentities, lastSuccess and lastErr.
 	mutex       sync.Mutex
 	config      *Config
+	preparers   []PrepareFunc
 	subscribers []func(*Config) error
+	templates   *templateFetcher
+	// templateHashes are the hashes of the remote templates of the current
+	// configuration by URL.
+	templateHashes map[string][sha256.Size]byte
+	// ageIdentities decrypt the values of the configuration encrypted with
+	// age.
+	ageIdentities []age.Identity
+	// useDefault loads the default configuration while the configuration
+	// file doesn't exist.
+	useDefault bool
+	// lastSuccess is the time of the last successful reload and lastErr the
+	// error of the last reload.
+	lastSuccess time.Time
+	lastErr     error
 
 	configHashMetric        prometheus.Gauge
 	configSuccessMetric     prometheus.Gauge
 	configSuccessTimeMetric prometheus.Gauge
+	templateFetchFailures   prometheus.Counter
 }
 
+// templateFetchTimeout is the timeout for fetching all remote templates of a
+// configuration.
+const templateFetchTimeout = time.Minute
+
 // NewCoordinator returns a new coordinator with the given configuration file
 // path. It does not yet load the configuration from file. This is done in
 // `Reload()`.
@@ -45,6 +72,7 @@ func NewCoordinator(configFilePath string, r prometheus.Registerer, l *slog.Logg
 	c := &Coordinator{
 		configFilePath: configFilePath,
 		logger:         l,
+		templates:      newTemplateFetcher(""),
 	}
 
 	c.registerMetrics(r)
@@ -66,14 +94,59 @@ func (c *Coordinator) registerMetrics(r prometheus.Registerer) {
 		Help: "Timestamp of the last successful configuration reload.",
 	})
 
-	r.MustRegister(configHash, configSuccess, configSuccessTime)
+	templateFetchFailures := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "alertmanager_config_template_fetch_failures_total",
+		Help: "Number of times fetching a remote template failed.",
+	})
+
+	r.MustRegister(configHash, configSuccess, configSuccessTime, templateFetchFailures)
 
 	c.configHashMetric = configHash
 	c.configSuccessMetric = configSuccess
 	c.configSuccessTimeMetric = configSuccessTime
+	c.templateFetchFailures = templateFetchFailures
 }
 
+// SetTemplateCacheDir sets the directory in which templates referenced by URL
+// are cached. Remote templates can't be used until it is set. The cached
+// copies are used when a template can't be fetched.
+func (c *Coordinator) SetTemplateCacheDir(dir string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.templates.dir = dir
+}
+
+// SetAgeIdentities sets the identities decrypting the values of the
+// configuration encrypted with age. Configurations with encrypted values fail
+// to load until they are set.
+func (c *Coordinator) SetAgeIdentities(identities []age.Identity) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.ageIdentities = identities
+}
+
+// UseDefaultConfig makes reloads load the default configuration while the
+// configuration file doesn't exist. Once it is created, the file is loaded by
+// the next reload.
+func (c *Coordinator) UseDefaultConfig() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.useDefault = true
+}
+
+// PrepareFunc builds everything needed to apply the given configuration
+// without modifying any running state. If it succeeds, the returned commit
+// function is called to swap the prepared state in. Commit functions must
+// not fail.
+type PrepareFunc func(*Config) (commit func(), err error)
+
 // Subscribe subscribes the given Subscribers to configuration changes.
+// Subscribers are called after all preparers succeeded and before any commit
+// function is called. Changes made by a subscriber are not rolled back if a
+// later subscriber fails.
 func (c *Coordinator) Subscribe(ss ...func(*Config) error) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
@@ -81,24 +154,39 @@ func (c *Coordinator) Subscribe(ss ...func(*Config) error) {
 	c.subscribers = append(c.subscribers, ss...)
 }
 
-func (c *Coordinator) notifySubscribers() error {
-	for _, s := range c.subscribers {
-		if err := s(c.config); err != nil {
+// SubscribePrepare subscribes the given preparers to configuration changes.
+// A configuration is applied in two phases: first all preparers are called,
+// then, if none of them and none of the subscribers failed, all commit
+// functions are called in order. If any step fails, no commit function is
+// called and the previous configuration stays in place.
+func (c *Coordinator) SubscribePrepare(ps ...PrepareFunc) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.preparers = append(c.preparers, ps...)
+}
+
+func (c *Coordinator) notifySubscribers(conf *Config) error {
+	commits := make([]func(), 0, len(c.preparers))
+	for _, p := range c.preparers {
+		commit, err := p(conf)
+		if err != nil {
 			return err
 		}
+		commits = append(commits, commit)
 	}
 
-	return nil
-}
-
-// loadFromFile triggers a configuration load, discarding the old configuration.
-func (c *Coordinator) loadFromFile() error {
-	conf, err := LoadFile(c.configFilePath)
-	if err != nil {
-		return err
+	for _, s := range c.subscribers {
+		if err := s(conf); err != nil {
+			return err
+		}
 	}
 
-	c.config = conf
+	for _, commit := range commits {
+		if commit != nil {
+			commit()
+		}
+	}
 
 	return nil
 }
@@ -113,13 +201,22 @@ func (c *Coordinator) Reload() error {
 		"Loading configuration file",
 		"file", c.configFilePath,
 	)
-	if err := c.loadFromFile(); err != nil {
+	conf, err := LoadEncryptedFile(c.configFilePath, c.ageIdentities)
+	if err != nil && c.useDefault && errors.Is(err, fs.ErrNotExist) {
+		c.logger.Warn(
+			"Configuration file not found, loading the default configuration",
+			"file", c.configFilePath,
+		)
+		conf, err = LoadDefault(c.ageIdentities)
+	}
+	if err != nil {
 		c.logger.Error(
 			"Loading configuration file failed",
 			"file", c.configFilePath,
 			"err", err,
 		)
 		c.configSuccessMetric.Set(0)
+		c.lastErr = err
 		return err
 	}
 	c.logger.Info(
@@ -127,16 +224,35 @@ func (c *Coordinator) Reload() error {
 		"file", c.configFilePath,
 	)
 
-	if err := c.notifySubscribers(); err != nil {
+	templates, err := c.fetchTemplates(conf)
+	if err == nil {
+		err = c.templates.resolve(conf, templates)
+	}
+	if err != nil {
+		c.logger.Error(
+			"Loading remote templates failed",
+			"file", c.configFilePath,
+			"err", err,
+		)
+		c.configSuccessMetric.Set(0)
+		c.lastErr = err
+		return err
+	}
+
+	if err := c.notifySubscribers(conf); err != nil {
 		c.logger.Error(
-			"one or more config change subscribers failed to apply new config",
+			"one or more config change subscribers failed to apply new config, keeping the previous config",
 			"file", c.configFilePath,
 			"err", err,
 		)
 		c.configSuccessMetric.Set(0)
+		c.lastErr = err
 		return err
 	}
+	c.config = conf
+	c.templateHashes = hashTemplates(templates)
 
+	c.lastSuccess, c.lastErr = time.Now(), nil
 	c.configSuccessMetric.Set(1)
 	c.configSuccessTimeMetric.SetToCurrentTime()
 	hash := md5HashAsMetricValue([]byte(c.config.original))
@@ -145,6 +261,98 @@ func (c *Coordinator) Reload() error {
 	return nil
 }
 
+// LastReload returns the time of the last successful reload, zero if none,
+// and the error of the last reload, nil if it succeeded.
+func (c *Coordinator) LastReload() (time.Time, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.lastSuccess, c.lastErr
+}
+
+// fetchTemplates fetches the remote templates of the configuration.
+func (c *Coordinator) fetchTemplates(conf *Config) (map[string][]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), templateFetchTimeout)
+	defer cancel()
+	return c.templates.fetchAll(ctx, conf, func(rt *remoteTemplate, err error) {
+		c.templateFetchFailures.Inc()
+		c.logger.Warn("Failed to fetch remote template, using the cached copy", "url", rt.url, "err", err)
+	})
+}
+
+func hashTemplates(templates map[string][]byte) map[string][sha256.Size]byte {
+	hashes := make(map[string][sha256.Size]byte, len(templates))
+	for u, b := range templates {
+		hashes[u] = sha256.Sum256(b)
+	}
+	return hashes
+}
+
+// RefreshTemplates fetches the remote templates of the current configuration
+// every template_refresh_interval and reloads the configuration if any of
+// them changed, until stopc is closed.
+func (c *Coordinator) RefreshTemplates(stopc <-chan struct{}) {
+	for {
+		c.mutex.Lock()
+		var interval time.Duration
+		if c.config != nil {
+			interval = time.Duration(c.config.TemplateRefreshInterval)
+		}
+		c.mutex.Unlock()
+
+		// Check again for a refresh interval after a while if refreshing
+		// is disabled by the current configuration.
+		enabled := interval > 0
+		if !enabled {
+			interval = time.Minute
+		}
+		t := time.NewTimer(interval)
+		select {
+		case <-stopc:
+			t.Stop()
+			return
+		case <-t.C:
+		}
+		if !enabled {
+			continue
+		}
+
+		if c.templatesChanged() {
+			c.logger.Info("Remote templates changed, reloading configuration")
+			// Errors are logged and reported by Reload.
+			_ = c.Reload()
+		}
+	}
+}
+
+// templatesChanged returns true if any remote template of the current
+// configuration changed since it was loaded.
+func (c *Coordinator) templatesChanged() bool {
+	c.mutex.Lock()
+	conf, hashes := c.config, c.templateHashes
+	c.mutex.Unlock()
+	if len(hashes) == 0 {
+		return false
+	}
+
+	// The templates of the loaded configuration point to the cache, parse
+	// the original configuration to get the URLs.
+	orig, err := Load(conf.original)
+	if err != nil {
+		return false
+	}
+	templates, err := c.fetchTemplates(orig)
+	if err != nil {
+		c.logger.Warn("Failed to refresh remote templates", "err", err)
+		return false
+	}
+	for u, b := range templates {
+		if h, ok := hashes[u]; !ok || h != sha256.Sum256(b) {
+			return true
+		}
+	}
+	return false
+}
+
 func md5HashAsMetricValue(data []byte) float64 {
 	sum := md5.Sum(data)
 	// We only want 48 bits as a float64 only has a 53 bit mantissa.
@@ -15,6 +15,7 @@ package config
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -81,3 +82,99 @@ func TestCoordinatorFailReloadWhenSubscriberFails(t *testing.T) {
 		t.Fatalf("expected error message %q but got %q", errMessage, err)
 	}
 }
+
+func TestCoordinatorCommitsPreparedConfig(t *testing.T) {
+	var calls []string
+	c := NewCoordinator("testdata/conf.good.yml", prometheus.NewRegistry(), promslog.NewNopLogger())
+	c.SubscribePrepare(func(*Config) (func(), error) {
+		calls = append(calls, "prepare")
+		return func() { calls = append(calls, "commit") }, nil
+	})
+	c.Subscribe(func(*Config) error {
+		calls = append(calls, "subscriber")
+		return nil
+	})
+
+	if err := c.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"prepare", "subscriber", "commit"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("expected calls %v but got %v", expected, calls)
+	}
+}
+
+func TestCoordinatorRollsBackWhenPrepareFails(t *testing.T) {
+	c := NewCoordinator("testdata/conf.good.yml", prometheus.NewRegistry(), promslog.NewNopLogger())
+
+	var (
+		committed []*Config
+		fail      bool
+	)
+	c.SubscribePrepare(
+		func(conf *Config) (func(), error) {
+			return func() { committed = append(committed, conf) }, nil
+		},
+		func(*Config) (func(), error) {
+			if fail {
+				return nil, errors.New("bad receiver")
+			}
+			return nil, nil
+		},
+	)
+
+	if err := c.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if len(committed) != 1 {
+		t.Fatalf("expected 1 committed config but got %d", len(committed))
+	}
+	previous := c.config
+
+	fail = true
+	if err := c.Reload(); err == nil {
+		t.Fatal("expected reload to throw an error")
+	}
+	if len(committed) != 1 {
+		t.Fatalf("expected no commit after a failed prepare but got %d commits", len(committed))
+	}
+	if c.config != previous {
+		t.Fatal("expected the previous config to be kept after a failed reload")
+	}
+}
+
+func TestCoordinatorLastReload(t *testing.T) {
+	fail := false
+	c := NewCoordinator("testdata/conf.good.yml", prometheus.NewRegistry(), promslog.NewNopLogger())
+	c.Subscribe(func(*Config) error {
+		if fail {
+			return errors.New("bad template")
+		}
+		return nil
+	})
+
+	if ts, err := c.LastReload(); !ts.IsZero() || err != nil {
+		t.Fatalf("expected no reload but got %v, %v", ts, err)
+	}
+
+	if err := c.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	success, err := c.LastReload()
+	if success.IsZero() || err != nil {
+		t.Fatalf("expected a successful reload but got %v, %v", success, err)
+	}
+
+	fail = true
+	if err := c.Reload(); err == nil {
+		t.Fatal("expected reload to throw an error")
+	}
+	ts, err := c.LastReload()
+	if !ts.Equal(success) {
+		t.Fatalf("expected the time of the last successful reload %v but got %v", success, ts)
+	}
+	if err == nil || err.Error() != "bad template" {
+		t.Fatalf("expected the error of the last reload but got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	_ "embed"
+	"os"
+
+	"filippo.io/age"
+)
+
+//go:embed default.yml
+var embeddedDefault string
+
+// DefaultConfigFile is the path of the default configuration. If empty, the
+// configuration embedded from default.yml is the default. It is meant to be
+// set at build time with
+// -ldflags "-X github.com/prometheus/alertmanager/config.DefaultConfigFile=<path>".
+var DefaultConfigFile string
+
+// DefaultContent returns the content of the default configuration.
+func DefaultContent() (string, error) {
+	if DefaultConfigFile == "" {
+		return embeddedDefault, nil
+	}
+	b, err := os.ReadFile(DefaultConfigFile)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// LoadDefault parses the default configuration.
+func LoadDefault(identities []age.Identity) (*Config, error) {
+	if DefaultConfigFile == "" {
+		return Load(embeddedDefault)
+	}
+	return LoadEncryptedFile(DefaultConfigFile, identities)
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDefault(t *testing.T) {
+	conf, err := LoadDefault(nil)
+	require.NoError(t, err)
+	require.Equal(t, "default", conf.Route.Receiver)
+
+	content, err := DefaultContent()
+	require.NoError(t, err)
+	require.Equal(t, embeddedDefault, content)
+
+	// The default configuration can be replaced at build time.
+	DefaultConfigFile = "testdata/conf.good.yml"
+	defer func() { DefaultConfigFile = "" }()
+	conf, err = LoadDefault(nil)
+	require.NoError(t, err)
+	require.Equal(t, "team-X-mails", conf.Route.Receiver)
+	b, err := os.ReadFile("testdata/conf.good.yml")
+	require.NoError(t, err)
+	content, err = DefaultContent()
+	require.NoError(t, err)
+	require.Equal(t, string(b), content)
+}
+
+func TestCoordinatorUseDefaultConfig(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "alertmanager.yml")
+	c := NewCoordinator(file, prometheus.NewRegistry(), promslog.NewNopLogger())
+	var receiver string
+	c.Subscribe(func(conf *Config) error {
+		receiver = conf.Route.Receiver
+		return nil
+	})
+
+	// Without the default configuration, a missing file fails the reload.
+	require.ErrorIs(t, c.Reload(), os.ErrNotExist)
+
+	c.UseDefaultConfig()
+	require.NoError(t, c.Reload())
+	require.Equal(t, "default", receiver)
+
+	// The file is loaded once it exists.
+	b, err := os.ReadFile("testdata/conf.good.yml")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, b, 0o644))
+	require.NoError(t, c.Reload())
+	require.Equal(t, "team-X-mails", receiver)
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+var egressHostRe = regexp.MustCompile(`^(\*\.)?[a-zA-Z0-9_]([a-zA-Z0-9_-]*[a-zA-Z0-9_])?(\.[a-zA-Z0-9_]([a-zA-Z0-9_-]*[a-zA-Z0-9_])?)*$`)
+
+// EgressPolicy restricts the destinations of the HTTP requests of the
+// integrations, e.g. to prevent templated URLs from reaching internal
+// services.
+type EgressPolicy struct {
+	// AllowedHosts are the host names which can be connected to. A leading
+	// "*." matches any subdomain.
+	AllowedHosts []string `yaml:"allowed_hosts,omitempty" json:"allowed_hosts,omitempty"`
+	// AllowedCIDRs are the networks of the IP addresses which can be
+	// connected to. IP addresses are allowed as well.
+	AllowedCIDRs []string `yaml:"allowed_cidrs,omitempty" json:"allowed_cidrs,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for EgressPolicy.
+func (p *EgressPolicy) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain EgressPolicy
+	if err := unmarshal((*plain)(p)); err != nil {
+		return err
+	}
+	for _, h := range p.AllowedHosts {
+		if !egressHostRe.MatchString(h) {
+			return fmt.Errorf("invalid host %q in allowed_hosts", h)
+		}
+	}
+	_, err := p.Prefixes()
+	return err
+}
+
+// Prefixes returns the allowed networks, IP addresses being converted to
+// single address networks.
+func (p *EgressPolicy) Prefixes() ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(p.AllowedCIDRs))
+	for _, c := range p.AllowedCIDRs {
+		if addr, err := netip.ParseAddr(c); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q in allowed_cidrs", c)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// DNSCache configures the caching of the IP addresses of the host names the
+// integrations connect to, so that a flaky DNS server doesn't fail the
+// notifications.
+type DNSCache struct {
+	// TTL is how long the resolved addresses are reused, regardless of the
+	// TTL of the DNS records.
+	TTL model.Duration `yaml:"ttl,omitempty" json:"ttl,omitempty"`
+	// StaleTTL is how long after the TTL the last resolved addresses are
+	// still used when resolving the host name fails. Zero disables the
+	// fallback.
+	StaleTTL model.Duration `yaml:"stale_ttl,omitempty" json:"stale_ttl,omitempty"`
+}
+
+// DefaultDNSCache is the default DNS cache configuration.
+var DefaultDNSCache = DNSCache{
+	TTL:      model.Duration(time.Minute),
+	StaleTTL: model.Duration(time.Hour),
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface for DNSCache.
+func (c *DNSCache) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultDNSCache
+	type plain DNSCache
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.TTL <= 0 {
+		return errors.New("ttl of the DNS cache must be positive")
+	}
+	return nil
+}
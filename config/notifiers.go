@@ -14,10 +14,13 @@
 package config
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"net/mail"
 	"net/textproto"
 	"regexp"
+	"sort"
 	"strings"
 	"text/template"
 	"time"
@@ -25,6 +28,9 @@ import (
 	commoncfg "github.com/prometheus/common/config"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/common/sigv4"
+
+	"github.com/prometheus/alertmanager/matcher/compat"
+	"github.com/prometheus/alertmanager/timeinterval"
 )
 
 var (
@@ -182,9 +188,10 @@ var (
 		NotifierConfig: NotifierConfig{
 			VSendResolved: true,
 		},
-		Title:   `{{ template "msteams.default.title" . }}`,
-		Summary: `{{ template "msteams.default.summary" . }}`,
-		Text:    `{{ template "msteams.default.text" . }}`,
+		Title:    `{{ template "msteams.default.title" . }}`,
+		Summary:  `{{ template "msteams.default.summary" . }}`,
+		Text:     `{{ template "msteams.default.text" . }}`,
+		CardType: MSTeamsCardMessage,
 	}
 
 	DefaultMSTeamsV2Config = MSTeamsV2Config{
@@ -203,6 +210,25 @@ var (
 		Description: `{{ template "jira.default.description" . }}`,
 		Priority:    `{{ template "jira.default.priority" . }}`,
 	}
+
+	// DefaultVoiceCallConfig defines default values for voice call
+	// configurations.
+	DefaultVoiceCallConfig = VoiceCallConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: false,
+		},
+		Message:         `{{ template "voicecall.default.message" . }}`,
+		MinFiringAlerts: 1,
+	}
+
+	// DefaultSNMPConfig defines default values for SNMP configurations.
+	DefaultSNMPConfig = SNMPConfig{
+		NotifierConfig: NotifierConfig{
+			VSendResolved: true,
+		},
+		Version:   SNMPVersion2c,
+		Community: "public",
+	}
 )
 
 // NotifierConfig contains base options common across all notifier configurations.
@@ -282,7 +308,10 @@ type EmailConfig struct {
 	NotifierConfig `yaml:",inline" json:",inline"`
 
 	// Email address to notify.
-	To               string               `yaml:"to,omitempty" json:"to,omitempty"`
+	To string `yaml:"to,omitempty" json:"to,omitempty"`
+	// Recipients maps the values of a label of the alerts to the email
+	// addresses to notify instead of To.
+	Recipients       *EmailRecipients     `yaml:"recipients,omitempty" json:"recipients,omitempty"`
 	From             string               `yaml:"from,omitempty" json:"from,omitempty"`
 	Hello            string               `yaml:"hello,omitempty" json:"hello,omitempty"`
 	Smarthost        HostPort             `yaml:"smarthost,omitempty" json:"smarthost,omitempty"`
@@ -296,6 +325,17 @@ type EmailConfig struct {
 	Text             string               `yaml:"text,omitempty" json:"text,omitempty"`
 	RequireTLS       *bool                `yaml:"require_tls,omitempty" json:"require_tls,omitempty"`
 	TLSConfig        *commoncfg.TLSConfig `yaml:"tls_config,omitempty" json:"tls_config,omitempty"`
+	// TLSCipherSuites restricts the cipher suites of TLS 1.2 and below.
+	TLSCipherSuites []TLSCipher `yaml:"tls_cipher_suites,omitempty" json:"tls_cipher_suites,omitempty"`
+	// ForceImplicitTLS connects with TLS instead of STARTTLS whatever the
+	// port. By default, implicit TLS is only used on port 465.
+	ForceImplicitTLS *bool `yaml:"force_implicit_tls,omitempty" json:"force_implicit_tls,omitempty"`
+
+	// Templates used instead of the Subject header, HTML and Text when all
+	// alerts of the notification are resolved.
+	SubjectResolved string `yaml:"subject_resolved,omitempty" json:"subject_resolved,omitempty"`
+	HTMLResolved    string `yaml:"html_resolved,omitempty" json:"html_resolved,omitempty"`
+	TextResolved    string `yaml:"text_resolved,omitempty" json:"text_resolved,omitempty"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
@@ -305,7 +345,7 @@ func (c *EmailConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if err := unmarshal((*plain)(c)); err != nil {
 		return err
 	}
-	if c.To == "" {
+	if c.To == "" && c.Recipients == nil {
 		return errors.New("missing to address in email config")
 	}
 	// Header names are case-insensitive, check for collisions.
@@ -322,6 +362,66 @@ func (c *EmailConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// EmailRecipients maps the values of an alert label to email addresses.
+type EmailRecipients struct {
+	Label     model.LabelName     `yaml:"label" json:"label"`
+	Addresses map[string][]string `yaml:"addresses" json:"addresses"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (r *EmailRecipients) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain EmailRecipients
+	if err := unmarshal((*plain)(r)); err != nil {
+		return err
+	}
+	if r.Label == "" {
+		return errors.New("missing label in email recipients")
+	}
+	if !compat.IsValidLabelName(r.Label) {
+		return fmt.Errorf("invalid label name %q in email recipients", r.Label)
+	}
+	if len(r.Addresses) == 0 {
+		return errors.New("missing addresses in email recipients")
+	}
+	for v, addrs := range r.Addresses {
+		if len(addrs) == 0 {
+			return fmt.Errorf("no addresses for %s=%q in email recipients", r.Label, v)
+		}
+		for _, addr := range addrs {
+			if _, err := mail.ParseAddress(addr); err != nil {
+				return fmt.Errorf("invalid address %q for %s=%q in email recipients: %w", addr, r.Label, v, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateRegion checks that the region of an integration is known and that
+// the URL which it selects is not also configured explicitly.
+func validateRegion(integration, region string, regions map[string]string, urlField string, urlSet bool) error {
+	if region == "" {
+		return nil
+	}
+	if _, ok := regions[region]; !ok {
+		names := make([]string, 0, len(regions))
+		for name := range regions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown %s region %q, must be one of %s", integration, region, strings.Join(names, ", "))
+	}
+	if urlSet {
+		return fmt.Errorf("at most one of %s & region must be configured", urlField)
+	}
+	return nil
+}
+
+// PagerdutyRegionHosts are the hosts of the PagerDuty Events API by region.
+var PagerdutyRegionHosts = map[string]string{
+	"us": "events.pagerduty.com",
+	"eu": "events.eu.pagerduty.com",
+}
+
 // PagerdutyConfig configures notifications via PagerDuty.
 type PagerdutyConfig struct {
 	NotifierConfig `yaml:",inline" json:",inline"`
@@ -333,6 +433,7 @@ type PagerdutyConfig struct {
 	RoutingKey     Secret            `yaml:"routing_key,omitempty" json:"routing_key,omitempty"`
 	RoutingKeyFile string            `yaml:"routing_key_file,omitempty" json:"routing_key_file,omitempty"`
 	URL            *URL              `yaml:"url,omitempty" json:"url,omitempty"`
+	Region         string            `yaml:"region,omitempty" json:"region,omitempty"`
 	Client         string            `yaml:"client,omitempty" json:"client,omitempty"`
 	ClientURL      string            `yaml:"client_url,omitempty" json:"client_url,omitempty"`
 	Description    string            `yaml:"description,omitempty" json:"description,omitempty"`
@@ -344,6 +445,9 @@ type PagerdutyConfig struct {
 	Class          string            `yaml:"class,omitempty" json:"class,omitempty"`
 	Component      string            `yaml:"component,omitempty" json:"component,omitempty"`
 	Group          string            `yaml:"group,omitempty" json:"group,omitempty"`
+
+	PerAlert           bool `yaml:"per_alert,omitempty" json:"per_alert,omitempty"`
+	MaxEventsPerMinute int  `yaml:"max_events_per_minute,omitempty" json:"max_events_per_minute,omitempty"`
 }
 
 // PagerdutyLink is a link.
@@ -375,6 +479,12 @@ func (c *PagerdutyConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 	if len(c.ServiceKey) > 0 && len(c.ServiceKeyFile) > 0 {
 		return errors.New("at most one of service_key & service_key_file must be configured")
 	}
+	if c.MaxEventsPerMinute < 0 {
+		return errors.New("max_events_per_minute cannot be negative")
+	}
+	if err := validateRegion("PagerDuty", c.Region, PagerdutyRegionHosts, "url", c.URL != nil); err != nil {
+		return err
+	}
 	if c.Details == nil {
 		c.Details = make(map[string]string)
 	}
@@ -488,6 +598,12 @@ type SlackConfig struct {
 	Username string `yaml:"username,omitempty" json:"username,omitempty"`
 	Color    string `yaml:"color,omitempty" json:"color,omitempty"`
 
+	// AllowedChannels are the channels the rendered Channel may be.
+	// Notifications to other channels are sent to DefaultChannel instead,
+	// with a warning.
+	AllowedChannels []Regexp `yaml:"allowed_channels,omitempty" json:"allowed_channels,omitempty"`
+	DefaultChannel  string   `yaml:"default_channel,omitempty" json:"default_channel,omitempty"`
+
 	Title       string         `yaml:"title,omitempty" json:"title,omitempty"`
 	TitleLink   string         `yaml:"title_link,omitempty" json:"title_link,omitempty"`
 	Pretext     string         `yaml:"pretext,omitempty" json:"pretext,omitempty"`
@@ -504,6 +620,20 @@ type SlackConfig struct {
 	LinkNames   bool           `yaml:"link_names" json:"link_names,omitempty"`
 	MrkdwnIn    []string       `yaml:"mrkdwn_in,omitempty" json:"mrkdwn_in,omitempty"`
 	Actions     []*SlackAction `yaml:"actions,omitempty" json:"actions,omitempty"`
+
+	// Templates used instead of Title and Text when all alerts of the
+	// notification are resolved.
+	TitleResolved string `yaml:"title_resolved,omitempty" json:"title_resolved,omitempty"`
+	TextResolved  string `yaml:"text_resolved,omitempty" json:"text_resolved,omitempty"`
+
+	// UpdateOnResolve updates the message of the group when all its alerts
+	// are resolved instead of posting a new one.
+	UpdateOnResolve bool `yaml:"update_on_resolve,omitempty" json:"update_on_resolve,omitempty"`
+
+	// Interactive adds buttons silencing and acknowledging the group to the
+	// notifications of firing alerts, handled by the Slack app connected
+	// with --slack.app-token-file.
+	Interactive bool `yaml:"interactive,omitempty" json:"interactive,omitempty"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
@@ -518,9 +648,30 @@ func (c *SlackConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return errors.New("at most one of api_url & api_url_file must be configured")
 	}
 
+	if c.DefaultChannel != "" && len(c.AllowedChannels) == 0 {
+		return errors.New("default_channel requires allowed_channels to be configured")
+	}
+
+	if c.UpdateOnResolve && !c.SendResolved() {
+		return errors.New("update_on_resolve requires send_resolved to be enabled")
+	}
+
 	return nil
 }
 
+// ChannelAllowed returns true if notifications can be sent to the channel.
+func (c *SlackConfig) ChannelAllowed(channel string) bool {
+	if len(c.AllowedChannels) == 0 {
+		return true
+	}
+	for _, re := range c.AllowedChannels {
+		if re.MatchString(channel) {
+			return true
+		}
+	}
+	return false
+}
+
 // WebhookConfig configures notifications via a generic webhook.
 type WebhookConfig struct {
 	NotifierConfig `yaml:",inline" json:",inline"`
@@ -539,6 +690,10 @@ type WebhookConfig struct {
 	// Timeout is the maximum time allowed to invoke the webhook. Setting this to 0
 	// does not impose a timeout.
 	Timeout time.Duration `yaml:"timeout" json:"timeout"`
+
+	// SendClusterLabels adds the cluster labels of the global configuration
+	// to the webhook messages.
+	SendClusterLabels bool `yaml:"send_cluster_labels,omitempty" json:"send_cluster_labels,omitempty"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
@@ -606,6 +761,7 @@ type OpsGenieConfig struct {
 	APIKey       Secret                    `yaml:"api_key,omitempty" json:"api_key,omitempty"`
 	APIKeyFile   string                    `yaml:"api_key_file,omitempty" json:"api_key_file,omitempty"`
 	APIURL       *URL                      `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+	Region       string                    `yaml:"region,omitempty" json:"region,omitempty"`
 	Message      string                    `yaml:"message,omitempty" json:"message,omitempty"`
 	Description  string                    `yaml:"description,omitempty" json:"description,omitempty"`
 	Source       string                    `yaml:"source,omitempty" json:"source,omitempty"`
@@ -619,10 +775,25 @@ type OpsGenieConfig struct {
 	UpdateAlerts bool                      `yaml:"update_alerts,omitempty" json:"update_alerts,omitempty"`
 }
 
-const opsgenieValidTypesRe = `^(team|teams|user|escalation|schedule)$`
+// OpsGenieRegionAPIURLs are the API URLs of OpsGenie by region.
+var OpsGenieRegionAPIURLs = map[string]string{
+	"us": "https://api.opsgenie.com/",
+	"eu": "https://api.eu.opsgenie.com/",
+}
+
+const opsgenieValidTypesRe = `^(team|teams|user|users|escalation|escalations|schedule|schedules)$`
 
 var opsgenieTypeMatcher = regexp.MustCompile(opsgenieValidTypesRe)
 
+// OpsGenieResponderListTypes maps the responder types holding comma-separated
+// lists to the type of the individual responders they expand to.
+var OpsGenieResponderListTypes = map[string]string{
+	"teams":       "team",
+	"users":       "user",
+	"escalations": "escalation",
+	"schedules":   "schedule",
+}
+
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
 func (c *OpsGenieConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	*c = DefaultOpsGenieConfig
@@ -634,8 +805,11 @@ func (c *OpsGenieConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 	if c.APIKey != "" && len(c.APIKeyFile) > 0 {
 		return errors.New("at most one of api_key & api_key_file must be configured")
 	}
+	if err := validateRegion("OpsGenie", c.Region, OpsGenieRegionAPIURLs, "api_url", c.APIURL != nil); err != nil {
+		return err
+	}
 
-	for _, r := range c.Responders {
+	for i, r := range c.Responders {
 		if r.ID == "" && r.Username == "" && r.Name == "" {
 			return fmt.Errorf("opsGenieConfig responder %v has to have at least one of id, username or name specified", r)
 		}
@@ -645,11 +819,12 @@ func (c *OpsGenieConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 			if err != nil {
 				return fmt.Errorf("opsGenieConfig responder %v type is not a valid template: %w", r, err)
 			}
-		} else {
-			r.Type = strings.ToLower(r.Type)
-			if !opsgenieTypeMatcher.MatchString(r.Type) {
-				return fmt.Errorf("opsGenieConfig responder %v type does not match valid options %s", r, opsgenieValidTypesRe)
-			}
+			continue
+		}
+
+		c.Responders[i].Type = strings.ToLower(r.Type)
+		if err := c.Responders[i].Validate(); err != nil {
+			return err
 		}
 	}
 
@@ -662,10 +837,36 @@ type OpsGenieConfigResponder struct {
 	Name     string `yaml:"name,omitempty" json:"name,omitempty"`
 	Username string `yaml:"username,omitempty" json:"username,omitempty"`
 
-	// team, user, escalation, schedule etc.
+	// team, user, escalation, schedule or their plural forms holding
+	// comma-separated lists.
 	Type string `yaml:"type,omitempty" json:"type,omitempty"`
 }
 
+// Validate checks that the responder has a valid type and the fields
+// identifying a responder of that type. It is also used by the notifier to
+// validate responders whose fields are templated.
+func (r OpsGenieConfigResponder) Validate() error {
+	if !opsgenieTypeMatcher.MatchString(r.Type) {
+		return fmt.Errorf("opsGenieConfig responder %v type does not match valid options %s", r, opsgenieValidTypesRe)
+	}
+
+	typ := r.Type
+	if t, ok := OpsGenieResponderListTypes[typ]; ok {
+		typ = t
+	}
+	switch typ {
+	case "user":
+		if r.ID == "" && r.Username == "" {
+			return fmt.Errorf("opsGenieConfig responder %v of type %s has to have an id or username specified", r, r.Type)
+		}
+	default:
+		if r.ID == "" && r.Name == "" {
+			return fmt.Errorf("opsGenieConfig responder %v of type %s has to have an id or name specified", r, r.Type)
+		}
+	}
+	return nil
+}
+
 // VictorOpsConfig configures notifications via VictorOps.
 type VictorOpsConfig struct {
 	NotifierConfig `yaml:",inline" json:",inline"`
@@ -675,6 +876,7 @@ type VictorOpsConfig struct {
 	APIKey            Secret            `yaml:"api_key,omitempty" json:"api_key,omitempty"`
 	APIKeyFile        string            `yaml:"api_key_file,omitempty" json:"api_key_file,omitempty"`
 	APIURL            *URL              `yaml:"api_url" json:"api_url"`
+	Region            string            `yaml:"region,omitempty" json:"region,omitempty"`
 	RoutingKey        string            `yaml:"routing_key" json:"routing_key"`
 	MessageType       string            `yaml:"message_type" json:"message_type"`
 	StateMessage      string            `yaml:"state_message" json:"state_message"`
@@ -683,6 +885,11 @@ type VictorOpsConfig struct {
 	CustomFields      map[string]string `yaml:"custom_fields,omitempty" json:"custom_fields,omitempty"`
 }
 
+// VictorOpsRegionAPIURLs are the API URLs of VictorOps by region.
+var VictorOpsRegionAPIURLs = map[string]string{
+	"us": "https://alert.victorops.com/integrations/generic/20131114/alert/",
+}
+
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
 func (c *VictorOpsConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	*c = DefaultVictorOpsConfig
@@ -696,6 +903,9 @@ func (c *VictorOpsConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 	if c.APIKey != "" && len(c.APIKeyFile) > 0 {
 		return errors.New("at most one of api_key & api_key_file must be configured")
 	}
+	if err := validateRegion("VictorOps", c.Region, VictorOpsRegionAPIURLs, "api_url", c.APIURL != nil); err != nil {
+		return err
+	}
 
 	reservedFields := []string{"routing_key", "message_type", "state_message", "entity_display_name", "monitoring_tool", "entity_id", "entity_state"}
 
@@ -800,14 +1010,19 @@ type TelegramConfig struct {
 
 	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
 
-	APIUrl               *URL   `yaml:"api_url" json:"api_url,omitempty"`
-	BotToken             Secret `yaml:"bot_token,omitempty" json:"token,omitempty"`
-	BotTokenFile         string `yaml:"bot_token_file,omitempty" json:"token_file,omitempty"`
-	ChatID               int64  `yaml:"chat_id,omitempty" json:"chat,omitempty"`
-	MessageThreadID      int    `yaml:"message_thread_id,omitempty" json:"message_thread_id,omitempty"`
-	Message              string `yaml:"message,omitempty" json:"message,omitempty"`
-	DisableNotifications bool   `yaml:"disable_notifications,omitempty" json:"disable_notifications,omitempty"`
-	ParseMode            string `yaml:"parse_mode,omitempty" json:"parse_mode,omitempty"`
+	APIUrl               *URL    `yaml:"api_url" json:"api_url,omitempty"`
+	BotToken             Secret  `yaml:"bot_token,omitempty" json:"token,omitempty"`
+	BotTokenFile         string  `yaml:"bot_token_file,omitempty" json:"token_file,omitempty"`
+	ChatID               int64   `yaml:"chat_id,omitempty" json:"chat,omitempty"`
+	ChatIDs              []int64 `yaml:"chat_ids,omitempty" json:"chats,omitempty"`
+	MessageThreadID      int     `yaml:"message_thread_id,omitempty" json:"message_thread_id,omitempty"`
+	Message              string  `yaml:"message,omitempty" json:"message,omitempty"`
+	DisableNotifications bool    `yaml:"disable_notifications,omitempty" json:"disable_notifications,omitempty"`
+	ParseMode            string  `yaml:"parse_mode,omitempty" json:"parse_mode,omitempty"`
+
+	// UpdateOnResolve edits the message of the group when all its alerts are
+	// resolved instead of sending a new one.
+	UpdateOnResolve bool `yaml:"update_on_resolve,omitempty" json:"update_on_resolve,omitempty"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
@@ -823,8 +1038,16 @@ func (c *TelegramConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 	if c.BotToken != "" && c.BotTokenFile != "" {
 		return errors.New("at most one of bot_token & bot_token_file must be configured")
 	}
-	if c.ChatID == 0 {
-		return errors.New("missing chat_id on telegram_config")
+	if c.ChatID == 0 && len(c.ChatIDs) == 0 {
+		return errors.New("missing chat_id or chat_ids on telegram_config")
+	}
+	if c.ChatID != 0 && len(c.ChatIDs) > 0 {
+		return errors.New("at most one of chat_id & chat_ids must be configured")
+	}
+	for _, id := range c.ChatIDs {
+		if id == 0 {
+			return errors.New("invalid chat ID 0 in chat_ids on telegram_config")
+		}
 	}
 	if c.ParseMode != "" &&
 		c.ParseMode != "Markdown" &&
@@ -832,9 +1055,18 @@ func (c *TelegramConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 		c.ParseMode != "HTML" {
 		return errors.New("unknown parse_mode on telegram_config, must be Markdown, MarkdownV2, HTML or empty string")
 	}
+	if c.UpdateOnResolve && !c.SendResolved() {
+		return errors.New("update_on_resolve requires send_resolved to be enabled on telegram_config")
+	}
 	return nil
 }
 
+// Microsoft Teams card types.
+const (
+	MSTeamsCardMessage  = "message"
+	MSTeamsCardAdaptive = "adaptive"
+)
+
 type MSTeamsConfig struct {
 	NotifierConfig `yaml:",inline" json:",inline"`
 	HTTPConfig     *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
@@ -844,6 +1076,30 @@ type MSTeamsConfig struct {
 	Title   string `yaml:"title,omitempty" json:"title,omitempty"`
 	Summary string `yaml:"summary,omitempty" json:"summary,omitempty"`
 	Text    string `yaml:"text,omitempty" json:"text,omitempty"`
+
+	// CardType is the format of the messages, either the legacy message
+	// card or an Adaptive Card.
+	CardType string `yaml:"card_type,omitempty" json:"card_type,omitempty"`
+	// Facts are shown by the Adaptive Cards. The common labels of the
+	// alerts are shown if no facts are configured.
+	Facts []MSTeamsFact `yaml:"facts,omitempty" json:"facts,omitempty"`
+	// Actions are the buttons of the Adaptive Cards. A button linking to
+	// the Alertmanager is shown if no actions are configured.
+	Actions []MSTeamsAction `yaml:"actions,omitempty" json:"actions,omitempty"`
+}
+
+// MSTeamsFact is a fact of a Microsoft Teams Adaptive Card. Facts with an
+// empty value are left out.
+type MSTeamsFact struct {
+	Title string `yaml:"title" json:"title"`
+	Value string `yaml:"value" json:"value"`
+}
+
+// MSTeamsAction is a button of a Microsoft Teams Adaptive Card opening a URL.
+// Actions with an empty URL are left out.
+type MSTeamsAction struct {
+	Title string `yaml:"title" json:"title"`
+	URL   string `yaml:"url" json:"url"`
 }
 
 func (c *MSTeamsConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -853,6 +1109,21 @@ func (c *MSTeamsConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return err
 	}
 
+	switch c.CardType {
+	case MSTeamsCardMessage:
+	case MSTeamsCardAdaptive:
+		for _, a := range c.Actions {
+			if a.Title == "" || a.URL == "" {
+				return errors.New("actions of msteams_config must have a title and a url")
+			}
+		}
+	default:
+		return fmt.Errorf("unknown card_type %q of msteams_config, must be %s or %s", c.CardType, MSTeamsCardMessage, MSTeamsCardAdaptive)
+	}
+	if c.CardType != MSTeamsCardAdaptive && (len(c.Facts) > 0 || len(c.Actions) > 0) {
+		return fmt.Errorf("facts and actions of msteams_config require the %s card_type", MSTeamsCardAdaptive)
+	}
+
 	if c.WebhookURL == nil && c.WebhookURLFile == "" {
 		return errors.New("one of webhook_url or webhook_url_file must be configured")
 	}
@@ -996,3 +1267,334 @@ func (c *RocketchatConfig) UnmarshalYAML(unmarshal func(interface{}) error) erro
 	}
 	return nil
 }
+
+// Voice call providers.
+const (
+	VoiceCallProviderTwilio = "twilio"
+	VoiceCallProviderVonage = "vonage"
+)
+
+// VoiceCallConfig configures notifications via phone calls.
+type VoiceCallConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	HTTPConfig *commoncfg.HTTPClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+
+	// Provider is the service placing the calls, twilio or vonage.
+	Provider string        `yaml:"provider" json:"provider"`
+	Twilio   *TwilioConfig `yaml:"twilio,omitempty" json:"twilio,omitempty"`
+	Vonage   *VonageConfig `yaml:"vonage,omitempty" json:"vonage,omitempty"`
+
+	// From is the phone number the calls are placed from.
+	From string `yaml:"from" json:"from"`
+	// To are the phone numbers called. Exclusive with Rotation.
+	To []string `yaml:"to,omitempty" json:"to,omitempty"`
+	// Rotation calls one person in turn instead.
+	Rotation *VoiceCallRotation `yaml:"rotation,omitempty" json:"rotation,omitempty"`
+
+	// Message is the text read out during the call.
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+
+	// MinFiringAlerts is the number of firing alerts a group must have for
+	// calls to be placed.
+	MinFiringAlerts int `yaml:"min_firing_alerts,omitempty" json:"min_firing_alerts,omitempty"`
+	// QuietHours are the times during which no calls are placed.
+	QuietHours []timeinterval.TimeInterval `yaml:"quiet_hours,omitempty" json:"quiet_hours,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *VoiceCallConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultVoiceCallConfig
+	type plain VoiceCallConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	switch c.Provider {
+	case VoiceCallProviderTwilio:
+		if c.Twilio == nil {
+			return errors.New("missing twilio configuration for the twilio provider")
+		}
+	case VoiceCallProviderVonage:
+		if c.Vonage == nil {
+			return errors.New("missing vonage configuration for the vonage provider")
+		}
+	case "":
+		return errors.New("missing provider in voicecall_config")
+	default:
+		return fmt.Errorf("unknown voice call provider %q", c.Provider)
+	}
+	if c.From == "" {
+		return errors.New("missing from in voicecall_config")
+	}
+	if len(c.To) == 0 && c.Rotation == nil {
+		return errors.New("one of to or rotation must be configured")
+	}
+	if len(c.To) > 0 && c.Rotation != nil {
+		return errors.New("at most one of to & rotation must be configured")
+	}
+	if c.MinFiringAlerts < 0 {
+		return errors.New("min_firing_alerts can't be negative")
+	}
+	return nil
+}
+
+// TwilioConfig configures the calls placed with Twilio.
+type TwilioConfig struct {
+	APIURL        *URL   `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+	AccountSID    string `yaml:"account_sid" json:"account_sid"`
+	AuthToken     Secret `yaml:"auth_token,omitempty" json:"auth_token,omitempty"`
+	AuthTokenFile string `yaml:"auth_token_file,omitempty" json:"auth_token_file,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *TwilioConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = TwilioConfig{APIURL: mustParseURL("https://api.twilio.com/")}
+	type plain TwilioConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.AccountSID == "" {
+		return errors.New("missing account_sid in twilio configuration")
+	}
+	if c.AuthToken == "" && c.AuthTokenFile == "" {
+		return errors.New("one of auth_token or auth_token_file must be configured")
+	}
+	if c.AuthToken != "" && c.AuthTokenFile != "" {
+		return errors.New("at most one of auth_token & auth_token_file must be configured")
+	}
+	return nil
+}
+
+// VonageConfig configures the calls placed with the Vonage Voice API.
+type VonageConfig struct {
+	APIURL         *URL   `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+	ApplicationID  string `yaml:"application_id" json:"application_id"`
+	PrivateKey     Secret `yaml:"private_key,omitempty" json:"private_key,omitempty"`
+	PrivateKeyFile string `yaml:"private_key_file,omitempty" json:"private_key_file,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *VonageConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = VonageConfig{APIURL: mustParseURL("https://api.nexmo.com/")}
+	type plain VonageConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.ApplicationID == "" {
+		return errors.New("missing application_id in vonage configuration")
+	}
+	if c.PrivateKey == "" && c.PrivateKeyFile == "" {
+		return errors.New("one of private_key or private_key_file must be configured")
+	}
+	if c.PrivateKey != "" && c.PrivateKeyFile != "" {
+		return errors.New("at most one of private_key & private_key_file must be configured")
+	}
+	return nil
+}
+
+// VoiceCallRotation calls the participants in turn, each for a period,
+// starting with the first one at the start time.
+type VoiceCallRotation struct {
+	Start        time.Time      `yaml:"start" json:"start"`
+	Period       model.Duration `yaml:"period" json:"period"`
+	Participants []string       `yaml:"participants" json:"participants"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (r *VoiceCallRotation) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain VoiceCallRotation
+	if err := unmarshal((*plain)(r)); err != nil {
+		return err
+	}
+	if r.Start.IsZero() {
+		return errors.New("missing start in rotation")
+	}
+	if r.Period <= 0 {
+		return errors.New("rotation period must be positive")
+	}
+	if len(r.Participants) == 0 {
+		return errors.New("missing participants in rotation")
+	}
+	return nil
+}
+
+// Participant returns the participant on duty at the given time.
+func (r *VoiceCallRotation) Participant(t time.Time) string {
+	d, period := t.Sub(r.Start), time.Duration(r.Period)
+	n := int64(d / period)
+	if d%period < 0 {
+		// The rotation is extended backwards.
+		n--
+	}
+	i := n % int64(len(r.Participants))
+	if i < 0 {
+		i += int64(len(r.Participants))
+	}
+	return r.Participants[i]
+}
+
+// SNMP versions.
+const (
+	SNMPVersion2c = "v2c"
+	SNMPVersion3  = "v3"
+)
+
+// SNMP varbind types.
+const (
+	SNMPTypeString  = "string"
+	SNMPTypeInteger = "integer"
+	SNMPTypeOID     = "oid"
+)
+
+// SNMPv3 authentication and privacy protocols.
+const (
+	SNMPAuthMD5    = "MD5"
+	SNMPAuthSHA    = "SHA"
+	SNMPAuthSHA256 = "SHA256"
+	SNMPPrivAES    = "AES"
+)
+
+var snmpOIDRe = regexp.MustCompile(`^\.?[0-2](\.(0|[1-9][0-9]{0,9}))+$`)
+
+// SNMPConfig configures notifications via SNMP traps, one per alert.
+type SNMPConfig struct {
+	NotifierConfig `yaml:",inline" json:",inline"`
+
+	// Target is the address of the trap receiver.
+	Target HostPort `yaml:"target" json:"target"`
+	// Version is the SNMP version of the traps, v2c or v3.
+	Version   string        `yaml:"version,omitempty" json:"version,omitempty"`
+	Community Secret        `yaml:"community,omitempty" json:"community,omitempty"`
+	V3        *SNMPv3Config `yaml:"v3,omitempty" json:"v3,omitempty"`
+
+	// TrapOID identifies the traps of the firing alerts, and ResolvedTrapOID
+	// the traps of the resolved ones, TrapOID if empty.
+	TrapOID         string `yaml:"trap_oid" json:"trap_oid"`
+	ResolvedTrapOID string `yaml:"resolved_trap_oid,omitempty" json:"resolved_trap_oid,omitempty"`
+
+	// LabelOIDs and AnnotationOIDs map the labels and annotations of the
+	// alerts to the OIDs of the string varbinds holding their values.
+	LabelOIDs      map[model.LabelName]string `yaml:"label_oids,omitempty" json:"label_oids,omitempty"`
+	AnnotationOIDs map[model.LabelName]string `yaml:"annotation_oids,omitempty" json:"annotation_oids,omitempty"`
+	// Varbinds are templated varbinds, rendered for each alert.
+	Varbinds []SNMPVarbind `yaml:"varbinds,omitempty" json:"varbinds,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *SNMPConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultSNMPConfig
+	type plain SNMPConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.Target.Host == "" {
+		return errors.New("missing target in snmp_config")
+	}
+	switch c.Version {
+	case SNMPVersion2c:
+		if c.Community == "" {
+			return errors.New("missing community for SNMP v2c")
+		}
+	case SNMPVersion3:
+		if c.V3 == nil {
+			return errors.New("missing v3 configuration for SNMP v3")
+		}
+	default:
+		return fmt.Errorf("unknown SNMP version %q, must be v2c or v3", c.Version)
+	}
+	if c.TrapOID == "" {
+		return errors.New("missing trap_oid in snmp_config")
+	}
+	oids := map[string]string{"trap_oid": c.TrapOID}
+	if c.ResolvedTrapOID != "" {
+		oids["resolved_trap_oid"] = c.ResolvedTrapOID
+	}
+	for name, oid := range c.LabelOIDs {
+		oids[fmt.Sprintf("label_oids[%s]", name)] = oid
+	}
+	for name, oid := range c.AnnotationOIDs {
+		oids[fmt.Sprintf("annotation_oids[%s]", name)] = oid
+	}
+	for i, v := range c.Varbinds {
+		oids[fmt.Sprintf("varbinds[%d]", i)] = v.OID
+	}
+	for field, oid := range oids {
+		if !snmpOIDRe.MatchString(oid) {
+			return fmt.Errorf("invalid OID %q in %s", oid, field)
+		}
+	}
+	return nil
+}
+
+// SNMPVarbind is a templated varbind of SNMP traps.
+type SNMPVarbind struct {
+	OID string `yaml:"oid" json:"oid"`
+	// Type is the type of the value, string, integer or oid.
+	Type  string `yaml:"type,omitempty" json:"type,omitempty"`
+	Value string `yaml:"value" json:"value"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (v *SNMPVarbind) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*v = SNMPVarbind{Type: SNMPTypeString}
+	type plain SNMPVarbind
+	if err := unmarshal((*plain)(v)); err != nil {
+		return err
+	}
+	switch v.Type {
+	case SNMPTypeString, SNMPTypeInteger, SNMPTypeOID:
+	default:
+		return fmt.Errorf("unknown varbind type %q, must be string, integer or oid", v.Type)
+	}
+	return nil
+}
+
+// SNMPv3Config configures the user-based security model of SNMP v3 traps.
+type SNMPv3Config struct {
+	Username string `yaml:"username" json:"username"`
+	// EngineID is the hex-encoded ID of the SNMP engine of Alertmanager,
+	// which is authoritative for the traps it sends.
+	EngineID     string `yaml:"engine_id" json:"engine_id"`
+	AuthProtocol string `yaml:"auth_protocol,omitempty" json:"auth_protocol,omitempty"`
+	AuthPassword Secret `yaml:"auth_password,omitempty" json:"auth_password,omitempty"`
+	PrivProtocol string `yaml:"priv_protocol,omitempty" json:"priv_protocol,omitempty"`
+	PrivPassword Secret `yaml:"priv_password,omitempty" json:"priv_password,omitempty"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *SNMPv3Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain SNMPv3Config
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.Username == "" {
+		return errors.New("missing username in SNMP v3 configuration")
+	}
+	id, err := hex.DecodeString(strings.TrimPrefix(c.EngineID, "0x"))
+	if err != nil || len(id) < 5 || len(id) > 32 {
+		return fmt.Errorf("invalid engine_id %q, must be 5 to 32 hex-encoded bytes", c.EngineID)
+	}
+	switch c.AuthProtocol {
+	case "":
+		if c.PrivProtocol != "" {
+			return errors.New("priv_protocol requires an auth_protocol")
+		}
+	case SNMPAuthMD5, SNMPAuthSHA, SNMPAuthSHA256:
+		// RFC 3414 requires passwords of at least 8 characters.
+		if len(c.AuthPassword) < 8 {
+			return errors.New("auth_password must be at least 8 characters long")
+		}
+	default:
+		return fmt.Errorf("unknown auth_protocol %q, must be MD5, SHA or SHA256", c.AuthProtocol)
+	}
+	switch c.PrivProtocol {
+	case "":
+	case SNMPPrivAES:
+		if len(c.PrivPassword) < 8 {
+			return errors.New("priv_password must be at least 8 characters long")
+		}
+	default:
+		return fmt.Errorf("unknown priv_protocol %q, must be AES", c.PrivProtocol)
+	}
+	return nil
+}
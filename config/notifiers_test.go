@@ -15,11 +15,14 @@ package config
 
 import (
 	"errors"
+	"net"
 	"net/mail"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 )
@@ -87,6 +90,83 @@ to: 'a@example.com, ,b@example.com,c@example.com'
 	}
 }
 
+func TestEmailRecipients(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   string
+		err  string
+	}{
+		{
+			name: "valid without to",
+			in: `
+recipients:
+  label: team
+  addresses:
+    frontend: ['fe@example.com']
+    backend: ['Backend <be@example.com>', 'oncall@example.com']
+`,
+		},
+		{
+			name: "missing label",
+			in: `
+recipients:
+  addresses:
+    frontend: ['fe@example.com']
+`,
+			err: "missing label in email recipients",
+		},
+		{
+			name: "invalid label",
+			in: `
+recipients:
+  label: 'te am'
+  addresses:
+    frontend: ['fe@example.com']
+`,
+			err: "\"te am\" is not a valid label name",
+		},
+		{
+			name: "missing addresses",
+			in: `
+recipients:
+  label: team
+`,
+			err: "missing addresses in email recipients",
+		},
+		{
+			name: "no address for value",
+			in: `
+recipients:
+  label: team
+  addresses:
+    frontend: []
+`,
+			err: "no addresses for team=\"frontend\" in email recipients",
+		},
+		{
+			name: "malformed address",
+			in: `
+recipients:
+  label: team
+  addresses:
+    frontend: ['fe@']
+`,
+			err: "invalid address \"fe@\" for team=\"frontend\" in email recipients",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg EmailConfig
+			err := yaml.UnmarshalStrict([]byte(tc.in), &cfg)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []string{"Backend <be@example.com>", "oncall@example.com"}, cfg.Recipients.Addresses["backend"])
+		})
+	}
+}
+
 func TestEmailDisallowMalformed(t *testing.T) {
 	in := `
 to: 'a@'
@@ -139,6 +219,17 @@ routing_key_file: 'xyz'
 	})
 }
 
+func TestPagerdutyMaxEventsPerMinute(t *testing.T) {
+	in := `
+routing_key: 'xyz'
+per_alert: true
+max_events_per_minute: -1
+`
+	var cfg PagerdutyConfig
+	err := yaml.UnmarshalStrict([]byte(in), &cfg)
+	require.EqualError(t, err, "max_events_per_minute cannot be negative")
+}
+
 func TestPagerdutyServiceKey(t *testing.T) {
 	t.Run("error if no service key or key file", func(t *testing.T) {
 		in := `
@@ -586,6 +677,29 @@ mrkdwn_in:
 	}
 }
 
+func TestSlackAllowedChannels(t *testing.T) {
+	in := `
+channel: '#{{ .CommonLabels.team }}'
+allowed_channels: ['#team-.+', '#ops']
+default_channel: '#alerts'
+`
+	var cfg SlackConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte(in), &cfg))
+	require.True(t, cfg.ChannelAllowed("#team-a"))
+	require.True(t, cfg.ChannelAllowed("#ops"))
+	require.False(t, cfg.ChannelAllowed("#ops-private"))
+	require.False(t, cfg.ChannelAllowed("#team-"))
+
+	cfg = SlackConfig{}
+	require.True(t, cfg.ChannelAllowed("#anything"))
+
+	in = `
+default_channel: '#alerts'
+`
+	err := yaml.UnmarshalStrict([]byte(in), &cfg)
+	require.EqualError(t, err, "default_channel requires allowed_channels to be configured")
+}
+
 func TestSlackFieldConfigValidation(t *testing.T) {
 	tests := []struct {
 		in       string
@@ -782,14 +896,30 @@ actions:
 	}
 }
 
+func TestSlackUpdateOnResolve(t *testing.T) {
+	in := `
+update_on_resolve: true
+`
+	var cfg SlackConfig
+	err := yaml.UnmarshalStrict([]byte(in), &cfg)
+	require.EqualError(t, err, "update_on_resolve requires send_resolved to be enabled")
+
+	in = `
+send_resolved: true
+update_on_resolve: true
+`
+	require.NoError(t, yaml.UnmarshalStrict([]byte(in), &cfg))
+	require.True(t, cfg.UpdateOnResolve)
+}
+
 func TestOpsgenieTypeMatcher(t *testing.T) {
-	good := []string{"team", "user", "escalation", "schedule"}
+	good := []string{"team", "user", "escalation", "schedule", "teams", "users", "escalations", "schedules"}
 	for _, g := range good {
 		if !opsgenieTypeMatcher.MatchString(g) {
 			t.Fatalf("failed to match with %s", g)
 		}
 	}
-	bad := []string{"0user", "team1", "2escalation3", "sche4dule", "User", "TEAM"}
+	bad := []string{"0user", "team1", "2escalation3", "sche4dule", "User", "TEAM", "userss"}
 	for _, b := range bad {
 		if opsgenieTypeMatcher.MatchString(b) {
 			t.Errorf("mistakenly match with %s", b)
@@ -841,6 +971,41 @@ api_url: http://example.com
 responders:
 - type: schedule
 api_url: http://example.com
+`,
+			err: true,
+		},
+		{
+			name: "valid responder lists",
+			in: `api_key: xyz
+responders:
+- name: "{{ .CommonLabels.teams }}"
+  type: teams
+- username: "{{ .CommonLabels.owners }}"
+  type: Users
+- id: "{{ .CommonLabels.schedules }}"
+  type: schedules
+- name: primary,secondary
+  type: escalations
+api_url: http://example.com
+`,
+		},
+		{
+			name: "user responder without id or username",
+			in: `api_key: xyz
+responders:
+- name: fred
+  type: user
+api_url: http://example.com
+`,
+			err: true,
+		},
+		{
+			name: "team list without id or name",
+			in: `api_key: xyz
+responders:
+- username: fred
+  type: teams
+api_url: http://example.com
 `,
 			err: true,
 		},
@@ -878,6 +1043,11 @@ api_url: http://example.com
 			if err != nil {
 				t.Errorf("expected no error, got %v", err)
 			}
+			for _, r := range cfg.Responders {
+				if !strings.Contains(r.Type, "{{") {
+					require.Equal(t, strings.ToLower(r.Type), r.Type)
+				}
+			}
 		})
 	}
 }
@@ -1067,7 +1237,31 @@ chat_id: 123
 			in: `
 bot_token: xyz
 `,
-			expected: errors.New("missing chat_id on telegram_config"),
+			expected: errors.New("missing chat_id or chat_ids on telegram_config"),
+		},
+		{
+			name: "with chat_ids set - it succeeds",
+			in: `
+bot_token: xyz
+chat_ids: [123, -456]
+`,
+		},
+		{
+			name: "with both chat_id & chat_ids - it fails",
+			in: `
+bot_token: xyz
+chat_id: 123
+chat_ids: [456]
+`,
+			expected: errors.New("at most one of chat_id & chat_ids must be configured"),
+		},
+		{
+			name: "with chat ID 0 in chat_ids - it fails",
+			in: `
+bot_token: xyz
+chat_ids: [123, 0]
+`,
+			expected: errors.New("invalid chat ID 0 in chat_ids on telegram_config"),
 		},
 		{
 			name: "with unknown parse_mode - it fails",
@@ -1078,6 +1272,24 @@ parse_mode: invalid
 `,
 			expected: errors.New("unknown parse_mode on telegram_config, must be Markdown, MarkdownV2, HTML or empty string"),
 		},
+		{
+			name: "with update_on_resolve set - it succeeds",
+			in: `
+bot_token: xyz
+chat_id: 123
+update_on_resolve: true
+`,
+		},
+		{
+			name: "with update_on_resolve and send_resolved disabled - it fails",
+			in: `
+bot_token: xyz
+chat_id: 123
+send_resolved: false
+update_on_resolve: true
+`,
+			expected: errors.New("update_on_resolve requires send_resolved to be enabled on telegram_config"),
+		},
 	}
 
 	for _, tt := range tc {
@@ -1093,3 +1305,314 @@ parse_mode: invalid
 func newBoolPointer(b bool) *bool {
 	return &b
 }
+
+func TestVoiceCallConfiguration(t *testing.T) {
+	tc := []struct {
+		name     string
+		in       string
+		expected error
+	}{
+		{
+			name: "with twilio provider - it succeeds",
+			in: `
+provider: twilio
+twilio:
+  account_sid: AC1
+  auth_token_file: /file
+from: "+15550000000"
+to: ["+15551111111"]
+`,
+		},
+		{
+			name: "with vonage provider and rotation - it succeeds",
+			in: `
+provider: vonage
+vonage:
+  application_id: app
+  private_key_file: /file
+from: "15550000000"
+rotation:
+  start: 2024-01-01T09:00:00Z
+  period: 1w
+  participants: ["15551111111", "15552222222"]
+quiet_hours:
+- times:
+  - start_time: "22:00"
+    end_time: "24:00"
+`,
+		},
+		{
+			name: "with unknown provider - it fails",
+			in: `
+provider: phone
+from: "+15550000000"
+to: ["+15551111111"]
+`,
+			expected: errors.New(`unknown voice call provider "phone"`),
+		},
+		{
+			name: "without provider configuration - it fails",
+			in: `
+provider: twilio
+from: "+15550000000"
+to: ["+15551111111"]
+`,
+			expected: errors.New("missing twilio configuration for the twilio provider"),
+		},
+		{
+			name: "with both auth_token & auth_token_file - it fails",
+			in: `
+provider: twilio
+twilio:
+  account_sid: AC1
+  auth_token: xyz
+  auth_token_file: /file
+from: "+15550000000"
+to: ["+15551111111"]
+`,
+			expected: errors.New("at most one of auth_token & auth_token_file must be configured"),
+		},
+		{
+			name: "with both to & rotation - it fails",
+			in: `
+provider: twilio
+twilio:
+  account_sid: AC1
+  auth_token: xyz
+from: "+15550000000"
+to: ["+15551111111"]
+rotation:
+  start: 2024-01-01T09:00:00Z
+  period: 1w
+  participants: ["+15552222222"]
+`,
+			expected: errors.New("at most one of to & rotation must be configured"),
+		},
+		{
+			name: "with no rotation period - it fails",
+			in: `
+provider: twilio
+twilio:
+  account_sid: AC1
+  auth_token: xyz
+from: "+15550000000"
+rotation:
+  start: 2024-01-01T09:00:00Z
+  participants: ["+15552222222"]
+`,
+			expected: errors.New("rotation period must be positive"),
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg VoiceCallConfig
+			err := yaml.UnmarshalStrict([]byte(tt.in), &cfg)
+
+			require.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestSNMPConfiguration(t *testing.T) {
+	tc := []struct {
+		name     string
+		in       string
+		expected error
+	}{
+		{
+			name: "with v2c - it succeeds",
+			in: `
+target: nms.example.com:162
+trap_oid: 1.3.6.1.4.1.99999.1
+label_oids:
+  alertname: .1.3.6.1.4.1.99999.2.1
+annotation_oids:
+  summary: 1.3.6.1.4.1.99999.2.2
+varbinds:
+- oid: 1.3.6.1.4.1.99999.2.3
+  type: integer
+  value: '{{ len .Alerts }}'
+`,
+		},
+		{
+			name: "with v3 - it succeeds",
+			in: `
+target: nms.example.com:162
+version: v3
+v3:
+  username: alertmanager
+  engine_id: 80001f8880e9bd0c1d12667a5100000000
+  auth_protocol: SHA256
+  auth_password: authpassword
+  priv_protocol: AES
+  priv_password: privpassword
+trap_oid: 1.3.6.1.4.1.99999.1
+`,
+		},
+		{
+			name: "without port - it fails",
+			in: `
+target: nms.example.com
+trap_oid: 1.3.6.1.4.1.99999.1
+`,
+			expected: &net.AddrError{Err: "missing port in address", Addr: "nms.example.com"},
+		},
+		{
+			name: "with unknown version - it fails",
+			in: `
+target: nms.example.com:162
+version: v1
+trap_oid: 1.3.6.1.4.1.99999.1
+`,
+			expected: errors.New(`unknown SNMP version "v1", must be v2c or v3`),
+		},
+		{
+			name: "with invalid OID - it fails",
+			in: `
+target: nms.example.com:162
+trap_oid: 1.3.6.1.4.1.99999.1
+label_oids:
+  alertname: 1.3.six
+`,
+			expected: errors.New(`invalid OID "1.3.six" in label_oids[alertname]`),
+		},
+		{
+			name: "with invalid varbind type - it fails",
+			in: `
+target: nms.example.com:162
+trap_oid: 1.3.6.1.4.1.99999.1
+varbinds:
+- oid: 1.3.6.1.4.1.99999.2.3
+  type: counter
+  value: '1'
+`,
+			expected: errors.New(`unknown varbind type "counter", must be string, integer or oid`),
+		},
+		{
+			name: "without v3 configuration - it fails",
+			in: `
+target: nms.example.com:162
+version: v3
+trap_oid: 1.3.6.1.4.1.99999.1
+`,
+			expected: errors.New("missing v3 configuration for SNMP v3"),
+		},
+		{
+			name: "with invalid engine ID - it fails",
+			in: `
+target: nms.example.com:162
+version: v3
+v3:
+  username: alertmanager
+  engine_id: "0102"
+trap_oid: 1.3.6.1.4.1.99999.1
+`,
+			expected: errors.New(`invalid engine_id "0102", must be 5 to 32 hex-encoded bytes`),
+		},
+		{
+			name: "with short auth password - it fails",
+			in: `
+target: nms.example.com:162
+version: v3
+v3:
+  username: alertmanager
+  engine_id: 80001f8880e9bd0c1d12667a51
+  auth_protocol: MD5
+  auth_password: short
+trap_oid: 1.3.6.1.4.1.99999.1
+`,
+			expected: errors.New("auth_password must be at least 8 characters long"),
+		},
+		{
+			name: "with privacy without authentication - it fails",
+			in: `
+target: nms.example.com:162
+version: v3
+v3:
+  username: alertmanager
+  engine_id: 80001f8880e9bd0c1d12667a51
+  priv_protocol: AES
+  priv_password: privpassword
+trap_oid: 1.3.6.1.4.1.99999.1
+`,
+			expected: errors.New("priv_protocol requires an auth_protocol"),
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg SNMPConfig
+			err := yaml.UnmarshalStrict([]byte(tt.in), &cfg)
+
+			require.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestVoiceCallRotationParticipant(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	r := &VoiceCallRotation{
+		Start:        start,
+		Period:       model.Duration(24 * time.Hour),
+		Participants: []string{"alice", "bob", "carol"},
+	}
+	for _, tc := range []struct {
+		at       time.Time
+		expected string
+	}{
+		{start, "alice"},
+		{start.Add(23 * time.Hour), "alice"},
+		{start.Add(24 * time.Hour), "bob"},
+		{start.Add(3*24*time.Hour + time.Hour), "alice"},
+		// The rotation is extended backwards.
+		{start.Add(-time.Hour), "carol"},
+		{start.Add(-24 * time.Hour), "carol"},
+		{start.Add(-3*24*time.Hour - time.Hour), "carol"},
+	} {
+		require.Equal(t, tc.expected, r.Participant(tc.at), tc.at)
+	}
+}
+
+func TestMSTeamsCardType(t *testing.T) {
+	var cfg MSTeamsConfig
+	require.NoError(t, yaml.UnmarshalStrict([]byte("webhook_url: http://example.com\n"), &cfg))
+	require.Equal(t, MSTeamsCardMessage, cfg.CardType)
+
+	in := `
+webhook_url: http://example.com
+card_type: adaptive
+facts:
+- title: Severity
+  value: '{{ .CommonLabels.severity }}'
+actions:
+- title: Runbook
+  url: '{{ .CommonAnnotations.runbook }}'
+`
+	cfg = MSTeamsConfig{}
+	require.NoError(t, yaml.UnmarshalStrict([]byte(in), &cfg))
+	require.Equal(t, MSTeamsCardAdaptive, cfg.CardType)
+	require.Equal(t, []MSTeamsFact{{Title: "Severity", Value: "{{ .CommonLabels.severity }}"}}, cfg.Facts)
+	require.Equal(t, []MSTeamsAction{{Title: "Runbook", URL: "{{ .CommonAnnotations.runbook }}"}}, cfg.Actions)
+
+	for _, tc := range []struct {
+		in  string
+		err string
+	}{
+		{
+			in:  "webhook_url: http://example.com\ncard_type: hero\n",
+			err: `unknown card_type "hero" of msteams_config, must be message or adaptive`,
+		},
+		{
+			in:  "webhook_url: http://example.com\nfacts:\n- title: a\n  value: b\n",
+			err: "facts and actions of msteams_config require the adaptive card_type",
+		},
+		{
+			in:  "webhook_url: http://example.com\ncard_type: adaptive\nactions:\n- title: a\n",
+			err: "actions of msteams_config must have a title and a url",
+		},
+	} {
+		cfg = MSTeamsConfig{}
+		require.EqualError(t, yaml.UnmarshalStrict([]byte(tc.in), &cfg), tc.err)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	commoncfg "github.com/prometheus/common/config"
+)
+
+// proxySchemes are the schemes of the proxy URLs supported by the HTTP
+// clients of the integrations.
+var proxySchemes = map[string]struct{}{
+	"http":    {},
+	"https":   {},
+	"socks5":  {},
+	"socks5h": {},
+}
+
+var noProxyHostRe = regexp.MustCompile(`^(\*\.|\.)?[a-zA-Z0-9_]([a-zA-Z0-9_-]*[a-zA-Z0-9_])?(\.[a-zA-Z0-9_]([a-zA-Z0-9_-]*[a-zA-Z0-9_])?)*$`)
+
+// validateProxyConfig checks the proxy settings of the HTTP client
+// configuration.
+func validateProxyConfig(c *commoncfg.HTTPClientConfig) error {
+	if c == nil || c.ProxyURL.URL == nil || c.ProxyURL.String() == "" {
+		return nil
+	}
+	u := c.ProxyURL.URL
+	if _, ok := proxySchemes[u.Scheme]; !ok {
+		return fmt.Errorf("unsupported proxy_url scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("missing host in proxy_url")
+	}
+	if strings.HasPrefix(u.Scheme, "socks5") && len(c.ProxyConnectHeader) > 0 {
+		return errors.New("proxy_connect_header can't be used with a SOCKS5 proxy")
+	}
+	for _, e := range strings.Split(c.NoProxy, ",") {
+		if err := validateNoProxyEntry(strings.TrimSpace(e)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateNoProxyEntry checks an entry of no_proxy, which is either "*", an
+// IP address, a CIDR or a domain name, the last two optionally followed by a
+// port. Domain names match their subdomains.
+func validateNoProxyEntry(e string) error {
+	if e == "" || e == "*" || net.ParseIP(e) != nil {
+		return nil
+	}
+	if _, _, err := net.ParseCIDR(e); err == nil {
+		return nil
+	}
+	host := e
+	if h, port, err := net.SplitHostPort(e); err == nil {
+		if p, err := strconv.Atoi(port); err != nil || p <= 0 || p > 65535 {
+			return fmt.Errorf("invalid port in no_proxy entry %q", e)
+		}
+		host = h
+	}
+	if net.ParseIP(host) == nil && !noProxyHostRe.MatchString(host) {
+		return fmt.Errorf("invalid no_proxy entry %q", e)
+	}
+	return nil
+}
+
+// validateProxyConfigs checks the proxy settings of the HTTP client
+// configurations of the integrations.
+func validateProxyConfigs[T any](name string, configs []T, httpConfig func(T) *commoncfg.HTTPClientConfig) error {
+	for i, c := range configs {
+		if err := validateProxyConfig(httpConfig(c)); err != nil {
+			return fmt.Errorf("%s[%d]: %w", name, i, err)
+		}
+	}
+	return nil
+}
+
+// validateReceiverProxies checks the proxy settings of the HTTP client
+// configurations of the integrations of the receiver.
+func validateReceiverProxies(rcv *Receiver) error {
+	err := errors.Join(
+		validateProxyConfigs("discord_configs", rcv.DiscordConfigs, func(c *DiscordConfig) *commoncfg.HTTPClientConfig { return c.HTTPConfig }),
+		validateProxyConfigs("pagerduty_configs", rcv.PagerdutyConfigs, func(c *PagerdutyConfig) *commoncfg.HTTPClientConfig { return c.HTTPConfig }),
+		validateProxyConfigs("slack_configs", rcv.SlackConfigs, func(c *SlackConfig) *commoncfg.HTTPClientConfig { return c.HTTPConfig }),
+		validateProxyConfigs("webhook_configs", rcv.WebhookConfigs, func(c *WebhookConfig) *commoncfg.HTTPClientConfig { return c.HTTPConfig }),
+		validateProxyConfigs("opsgenie_configs", rcv.OpsGenieConfigs, func(c *OpsGenieConfig) *commoncfg.HTTPClientConfig { return c.HTTPConfig }),
+		validateProxyConfigs("wechat_configs", rcv.WechatConfigs, func(c *WechatConfig) *commoncfg.HTTPClientConfig { return c.HTTPConfig }),
+		validateProxyConfigs("pushover_configs", rcv.PushoverConfigs, func(c *PushoverConfig) *commoncfg.HTTPClientConfig { return c.HTTPConfig }),
+		validateProxyConfigs("victorops_configs", rcv.VictorOpsConfigs, func(c *VictorOpsConfig) *commoncfg.HTTPClientConfig { return c.HTTPConfig }),
+		validateProxyConfigs("sns_configs", rcv.SNSConfigs, func(c *SNSConfig) *commoncfg.HTTPClientConfig { return c.HTTPConfig }),
+		validateProxyConfigs("telegram_configs", rcv.TelegramConfigs, func(c *TelegramConfig) *commoncfg.HTTPClientConfig { return c.HTTPConfig }),
+		validateProxyConfigs("webex_configs", rcv.WebexConfigs, func(c *WebexConfig) *commoncfg.HTTPClientConfig { return c.HTTPConfig }),
+		validateProxyConfigs("msteams_configs", rcv.MSTeamsConfigs, func(c *MSTeamsConfig) *commoncfg.HTTPClientConfig { return c.HTTPConfig }),
+		validateProxyConfigs("msteamsv2_configs", rcv.MSTeamsV2Configs, func(c *MSTeamsV2Config) *commoncfg.HTTPClientConfig { return c.HTTPConfig }),
+		validateProxyConfigs("jira_configs", rcv.JiraConfigs, func(c *JiraConfig) *commoncfg.HTTPClientConfig { return c.HTTPConfig }),
+		validateProxyConfigs("rocketchat_configs", rcv.RocketchatConfigs, func(c *RocketchatConfig) *commoncfg.HTTPClientConfig { return c.HTTPConfig }),
+		validateProxyConfigs("voicecall_configs", rcv.VoiceCallConfigs, func(c *VoiceCallConfig) *commoncfg.HTTPClientConfig { return c.HTTPConfig }),
+	)
+	if err != nil {
+		return fmt.Errorf("receiver %q: %w", rcv.Name, err)
+	}
+	return nil
+}
@@ -31,9 +31,11 @@ import (
 	"github.com/prometheus/alertmanager/notify/pushover"
 	"github.com/prometheus/alertmanager/notify/rocketchat"
 	"github.com/prometheus/alertmanager/notify/slack"
+	"github.com/prometheus/alertmanager/notify/snmp"
 	"github.com/prometheus/alertmanager/notify/sns"
 	"github.com/prometheus/alertmanager/notify/telegram"
 	"github.com/prometheus/alertmanager/notify/victorops"
+	"github.com/prometheus/alertmanager/notify/voicecall"
 	"github.com/prometheus/alertmanager/notify/webex"
 	"github.com/prometheus/alertmanager/notify/webhook"
 	"github.com/prometheus/alertmanager/notify/wechat"
@@ -44,20 +46,54 @@ import (
 // BuildReceiverIntegrations builds a list of integration notifiers off of a
 // receiver config.
 func BuildReceiverIntegrations(nc config.Receiver, tmpl *template.Template, logger *slog.Logger, httpOpts ...commoncfg.HTTPClientOption) ([]notify.Integration, error) {
+	return BuildWatchedReceiverIntegrations(nc, tmpl, logger, nil, httpOpts...)
+}
+
+// BuildWatchedReceiverIntegrations is like BuildReceiverIntegrations but
+// records the integrations referencing secret files in secrets, if not nil,
+// to rebuild them when the files change.
+func BuildWatchedReceiverIntegrations(nc config.Receiver, tmpl *template.Template, logger *slog.Logger, secrets *SecretFiles, httpOpts ...commoncfg.HTTPClientOption) ([]notify.Integration, error) {
 	if logger == nil {
 		logger = promslog.NewNopLogger()
 	}
 
+	headers := make(map[string]string, len(nc.Headers))
+	for k, v := range nc.Headers {
+		headers[k] = string(v)
+	}
+
 	var (
 		errs         types.MultiError
 		integrations []notify.Integration
 		add          = func(name string, i int, rs notify.ResolvedSender, f func(l *slog.Logger) (notify.Notifier, error)) {
-			n, err := f(logger.With("integration", name))
+			l := logger.With("integration", name)
+			build := func() (notify.Notifier, error) {
+				n, err := f(l)
+				if err != nil {
+					return nil, err
+				}
+				return notify.NewHeadersNotifier(n, tmpl, headers, l), nil
+			}
+			var (
+				n   notify.Notifier
+				err error
+			)
+			if files := secretFiles(rs); secrets != nil && len(files) > 0 {
+				var sn *secretNotifier
+				if sn, err = newSecretNotifier(nc.Name, name, i, files, build); err == nil {
+					secrets.add(sn)
+					n = sn
+				}
+			} else {
+				n, err = build()
+			}
 			if err != nil {
 				errs.Add(err)
 				return
 			}
-			integrations = append(integrations, notify.NewIntegration(n, rs, name, i, nc.Name))
+			integration := notify.NewIntegration(n, rs, name, i, nc.Name)
+			integration.SetDebugPayloads(nc.DebugLogPayloads)
+			integrations = append(integrations, integration)
 		}
 	)
 
@@ -109,6 +145,12 @@ func BuildReceiverIntegrations(nc config.Receiver, tmpl *template.Template, logg
 	for i, c := range nc.RocketchatConfigs {
 		add("rocketchat", i, c, func(l *slog.Logger) (notify.Notifier, error) { return rocketchat.New(c, tmpl, l, httpOpts...) })
 	}
+	for i, c := range nc.VoiceCallConfigs {
+		add("voicecall", i, c, func(l *slog.Logger) (notify.Notifier, error) { return voicecall.New(c, tmpl, l, httpOpts...) })
+	}
+	for i, c := range nc.SNMPConfigs {
+		add("snmp", i, c, func(l *slog.Logger) (notify.Notifier, error) { return snmp.New(c, tmpl, l) })
+	}
 
 	if errs.Len() > 0 {
 		return nil, &errs
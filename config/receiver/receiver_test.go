@@ -53,6 +53,20 @@ func TestBuildReceiverIntegrations(t *testing.T) {
 				notify.NewIntegration(nil, sendResolved(true), "webhook", 1, "foo"),
 			},
 		},
+		{
+			receiver: config.Receiver{
+				Name: "foo",
+				WebhookConfigs: []*config.WebhookConfig{
+					{
+						HTTPConfig: &commoncfg.HTTPClientConfig{},
+					},
+				},
+				DebugLogPayloads: true,
+			},
+			exp: []notify.Integration{
+				debugPayloads(notify.NewIntegration(nil, sendResolved(false), "webhook", 0, "foo")),
+			},
+		},
 		{
 			receiver: config.Receiver{
 				Name: "foo",
@@ -82,7 +96,13 @@ func TestBuildReceiverIntegrations(t *testing.T) {
 				require.Equal(t, tc.exp[i].SendResolved(), integrations[i].SendResolved())
 				require.Equal(t, tc.exp[i].Name(), integrations[i].Name())
 				require.Equal(t, tc.exp[i].Index(), integrations[i].Index())
+				require.Equal(t, tc.exp[i].DebugPayloads(), integrations[i].DebugPayloads())
 			}
 		})
 	}
 }
+
+func debugPayloads(i notify.Integration) notify.Integration {
+	i.SetDebugPayloads(true)
+	return i
+}
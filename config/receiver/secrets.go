@@ -0,0 +1,263 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receiver
+
+import (
+	"context"
+	"crypto/sha256"
+	"log/slog"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/promslog"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// SecretFiles records the integrations referencing secret files, such as
+// api_key_file or password_file, so that a SecretsWatcher rebuilds them when
+// the files change. The zero value is ready to use.
+type SecretFiles struct {
+	mtx       sync.Mutex
+	notifiers []*secretNotifier
+}
+
+func (s *SecretFiles) add(n *secretNotifier) {
+	s.mtx.Lock()
+	s.notifiers = append(s.notifiers, n)
+	s.mtx.Unlock()
+}
+
+// secretNotifier is a notifier rebuilt when the secret files referenced by
+// its configuration change.
+type secretNotifier struct {
+	receiver    string
+	integration string
+	idx         int
+	files       []string
+	build       func() (notify.Notifier, error)
+
+	mtx      sync.RWMutex
+	notifier notify.Notifier
+	// hashes are the hashes of the files the notifier was built with.
+	hashes map[string][sha256.Size]byte
+}
+
+func newSecretNotifier(receiver, integration string, idx int, files []string, build func() (notify.Notifier, error)) (*secretNotifier, error) {
+	n := &secretNotifier{
+		receiver:    receiver,
+		integration: integration,
+		idx:         idx,
+		files:       files,
+		build:       build,
+	}
+	if err := n.rebuild(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// Notify implements the notify.Notifier interface.
+func (n *secretNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	n.mtx.RLock()
+	notifier := n.notifier
+	n.mtx.RUnlock()
+	return notifier.Notify(ctx, as...)
+}
+
+// rebuild builds the notifier again with the current content of the files.
+func (n *secretNotifier) rebuild() error {
+	hashes := make(map[string][sha256.Size]byte, len(n.files))
+	for _, f := range n.files {
+		if h, ok := hashFile(f); ok {
+			hashes[f] = h
+		}
+	}
+	notifier, err := n.build()
+	if err != nil {
+		return err
+	}
+	n.mtx.Lock()
+	n.notifier, n.hashes = notifier, hashes
+	n.mtx.Unlock()
+	return nil
+}
+
+// changed returns the files whose content differs from the one the notifier
+// was built with. The hashes of the files are cached in seen.
+func (n *secretNotifier) changed(seen map[string]*[sha256.Size]byte) []string {
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+	var res []string
+	for _, f := range n.files {
+		h, ok := seen[f]
+		if !ok {
+			if sum, ok := hashFile(f); ok {
+				h = &sum
+			}
+			seen[f] = h
+		}
+		// A file which can't be read, for instance while it is being
+		// replaced, is considered unchanged.
+		if h == nil {
+			continue
+		}
+		if prev, ok := n.hashes[f]; !ok || prev != *h {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+func hashFile(f string) ([sha256.Size]byte, bool) {
+	b, err := os.ReadFile(f)
+	if err != nil {
+		return [sha256.Size]byte{}, false
+	}
+	return sha256.Sum256(b), true
+}
+
+// secretFiles returns the files referenced by the integration configuration,
+// that is the values of the fields whose YAML key ends with _file, including
+// the ones of the HTTP client configuration.
+func secretFiles(c any) []string {
+	files := map[string]struct{}{}
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return
+		}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			fv := v.Field(i)
+			if fv.Kind() == reflect.String && strings.HasSuffix(key, "_file") {
+				if f := fv.String(); f != "" {
+					files[f] = struct{}{}
+				}
+				continue
+			}
+			walk(fv)
+		}
+	}
+	walk(reflect.ValueOf(c))
+
+	res := make([]string, 0, len(files))
+	for f := range files {
+		res = append(res, f)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// SecretsWatcher rebuilds the integrations whose secret files change, leaving
+// the other integrations and the state of the dispatcher untouched.
+type SecretsWatcher struct {
+	logger   *slog.Logger
+	reloads  *prometheus.CounterVec
+	failures *prometheus.CounterVec
+
+	mtx     sync.Mutex
+	secrets *SecretFiles
+}
+
+// NewSecretsWatcher returns a new SecretsWatcher.
+func NewSecretsWatcher(l *slog.Logger, r prometheus.Registerer) *SecretsWatcher {
+	if l == nil {
+		l = promslog.NewNopLogger()
+	}
+	w := &SecretsWatcher{
+		logger:  l,
+		secrets: &SecretFiles{},
+		reloads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_integration_secrets_reloads_total",
+			Help: "The total number of integrations rebuilt because their secret files changed.",
+		}, []string{"integration"}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_integration_secrets_reload_failures_total",
+			Help: "The total number of failures to rebuild integrations whose secret files changed.",
+		}, []string{"integration"}),
+	}
+	if r != nil {
+		r.MustRegister(w.reloads, w.failures)
+	}
+	return w
+}
+
+// Watch replaces the watched integrations with the ones recorded in s,
+// typically those of the configuration being applied.
+func (w *SecretsWatcher) Watch(s *SecretFiles) {
+	w.mtx.Lock()
+	w.secrets = s
+	w.mtx.Unlock()
+}
+
+// Check rebuilds the watched integrations whose secret files changed since
+// they were built. An integration failing to be rebuilt keeps notifying with
+// the previous secrets and is rebuilt again at the next check.
+func (w *SecretsWatcher) Check() {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	w.secrets.mtx.Lock()
+	notifiers := w.secrets.notifiers
+	w.secrets.mtx.Unlock()
+
+	seen := map[string]*[sha256.Size]byte{}
+	for _, n := range notifiers {
+		changed := n.changed(seen)
+		if len(changed) == 0 {
+			continue
+		}
+		logger := w.logger.With("receiver", n.receiver, "integration", n.integration, "idx", n.idx)
+		if err := n.rebuild(); err != nil {
+			w.failures.WithLabelValues(n.integration).Inc()
+			logger.Error("Failed to rebuild integration after secret files changed", "files", changed, "err", err)
+			continue
+		}
+		w.reloads.WithLabelValues(n.integration).Inc()
+		logger.Info("Rebuilt integration after secret files changed", "files", changed)
+	}
+}
+
+// Run checks the secret files at the given interval until stopc is closed.
+func (w *SecretsWatcher) Run(interval time.Duration, stopc <-chan struct{}) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stopc:
+			return
+		case <-t.C:
+			w.Check()
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receiver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+)
+
+func TestSecretFiles(t *testing.T) {
+	require.Equal(t, []string{"/etc/password", "/etc/url"}, secretFiles(&config.WebhookConfig{
+		URLFile: "/etc/url",
+		HTTPConfig: &commoncfg.HTTPClientConfig{
+			BasicAuth: &commoncfg.BasicAuth{
+				Username:     "alice",
+				PasswordFile: "/etc/password",
+			},
+		},
+	}))
+	require.Equal(t, []string{"/etc/key"}, secretFiles(&config.OpsGenieConfig{
+		APIKeyFile: "/etc/key",
+		HTTPConfig: &commoncfg.HTTPClientConfig{},
+	}))
+	require.Empty(t, secretFiles(&config.WebhookConfig{HTTPConfig: &commoncfg.HTTPClientConfig{}}))
+}
+
+func TestSecretsWatcher(t *testing.T) {
+	dir := t.TempDir()
+	urlFile := filepath.Join(dir, "url")
+	passwordFile := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(urlFile, []byte("http://example.com"), 0o600))
+	require.NoError(t, os.WriteFile(passwordFile, []byte("secret"), 0o600))
+
+	rcv := config.Receiver{
+		Name: "foo",
+		WebhookConfigs: []*config.WebhookConfig{
+			{
+				URLFile: urlFile,
+				HTTPConfig: &commoncfg.HTTPClientConfig{
+					BasicAuth: &commoncfg.BasicAuth{
+						Username:     "alice",
+						PasswordFile: passwordFile,
+					},
+				},
+			},
+			{
+				URL:        &config.SecretURL{},
+				HTTPConfig: &commoncfg.HTTPClientConfig{},
+			},
+		},
+	}
+	secrets := &SecretFiles{}
+	integrations, err := BuildWatchedReceiverIntegrations(rcv, nil, nil, secrets)
+	require.NoError(t, err)
+	require.Len(t, integrations, 2)
+	// Only the integration referencing secret files is watched.
+	require.Len(t, secrets.notifiers, 1)
+	n := secrets.notifiers[0]
+	built := n.notifier
+
+	w := NewSecretsWatcher(nil, prometheus.NewRegistry())
+	w.Watch(secrets)
+
+	// Nothing changed.
+	w.Check()
+	require.Same(t, built, n.notifier)
+
+	// A file which can't be read is considered unchanged.
+	require.NoError(t, os.Remove(passwordFile))
+	w.Check()
+	require.Same(t, built, n.notifier)
+
+	require.NoError(t, os.WriteFile(passwordFile, []byte("new secret"), 0o600))
+	w.Check()
+	require.NotSame(t, built, n.notifier)
+	require.Equal(t, 1.0, testutil.ToFloat64(w.reloads.WithLabelValues("webhook")))
+
+	// The integrations of a previous configuration aren't watched anymore.
+	built = n.notifier
+	w.Watch(&SecretFiles{})
+	require.NoError(t, os.WriteFile(urlFile, []byte("http://example.org"), 0o600))
+	w.Check()
+	require.Same(t, built, n.notifier)
+}
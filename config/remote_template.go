@@ -0,0 +1,230 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	commoncfg "github.com/prometheus/common/config"
+)
+
+// maxRemoteTemplateSize is the maximum size of a remote template.
+const maxRemoteTemplateSize = 4 << 20
+
+// remoteTemplate is a template referenced by an HTTPS URL in the templates
+// section. The URL fragment may pin the checksum of the template, for
+// example https://example.com/slack.tmpl#sha256=<hex digest>.
+type remoteTemplate struct {
+	// url is the URL the template is fetched from, without the fragment.
+	url    string
+	sha256 []byte
+}
+
+// isTemplateURL returns true if the templates entry is a URL rather than a
+// file path glob.
+func isTemplateURL(s string) bool {
+	return strings.Contains(s, "://")
+}
+
+// parseRemoteTemplate parses a templates entry referencing a remote template.
+func parseRemoteTemplate(s string) (*remoteTemplate, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template URL %q: %w", s, err)
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("template URL %q must use https", s)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("template URL %q has no host", s)
+	}
+
+	rt := &remoteTemplate{}
+	if u.Fragment != "" {
+		digest, ok := strings.CutPrefix(u.Fragment, "sha256=")
+		if !ok {
+			return nil, fmt.Errorf("template URL %q: unsupported checksum %q, only sha256 is supported", s, u.Fragment)
+		}
+		if rt.sha256, err = hex.DecodeString(digest); err != nil || len(rt.sha256) != sha256.Size {
+			return nil, fmt.Errorf("template URL %q: invalid sha256 checksum %q", s, digest)
+		}
+	}
+	u.Fragment = ""
+	rt.url = u.String()
+	return rt, nil
+}
+
+// verify checks the template content against the pinned checksum, if any.
+func (rt *remoteTemplate) verify(b []byte) error {
+	if rt.sha256 == nil {
+		return nil
+	}
+	if sum := sha256.Sum256(b); !bytes.Equal(sum[:], rt.sha256) {
+		return fmt.Errorf("checksum mismatch for template %s: got sha256 %x", rt.url, sum)
+	}
+	return nil
+}
+
+// cacheFile returns the path of the file caching the template in dir.
+func (rt *remoteTemplate) cacheFile(dir string) string {
+	sum := sha256.Sum256([]byte(rt.url))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".tmpl")
+}
+
+// fetch downloads the template and verifies its checksum.
+func (rt *remoteTemplate) fetch(ctx context.Context, client *http.Client) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rt.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d fetching template %s", resp.StatusCode, rt.url)
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteTemplateSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) > maxRemoteTemplateSize {
+		return nil, fmt.Errorf("template %s is larger than %d bytes", rt.url, maxRemoteTemplateSize)
+	}
+	if err := rt.verify(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// templateFetcher fetches the remote templates of configurations into a
+// cache directory.
+type templateFetcher struct {
+	dir string
+	// newClient returns the HTTP client used to fetch the templates of the
+	// configuration.
+	newClient func(*Config) (*http.Client, error)
+}
+
+func newTemplateFetcher(dir string) *templateFetcher {
+	return &templateFetcher{
+		dir: dir,
+		newClient: func(conf *Config) (*http.Client, error) {
+			httpConfig := commoncfg.DefaultHTTPClientConfig
+			if conf.Global != nil && conf.Global.HTTPConfig != nil {
+				httpConfig = *conf.Global.HTTPConfig
+			}
+			return commoncfg.NewClientFromConfig(httpConfig, "templates")
+		},
+	}
+}
+
+// remoteTemplates returns the remote templates of the configuration.
+func remoteTemplates(conf *Config) ([]*remoteTemplate, error) {
+	var rts []*remoteTemplate
+	for _, t := range conf.Templates {
+		if !isTemplateURL(t) {
+			continue
+		}
+		rt, err := parseRemoteTemplate(t)
+		if err != nil {
+			return nil, err
+		}
+		rts = append(rts, rt)
+	}
+	return rts, nil
+}
+
+// fetchAll fetches all remote templates of the configuration and returns
+// their content by URL. If a template can't be fetched, its cached copy is
+// used instead and the error is passed to onErr.
+func (f *templateFetcher) fetchAll(ctx context.Context, conf *Config, onErr func(*remoteTemplate, error)) (map[string][]byte, error) {
+	rts, err := remoteTemplates(conf)
+	if err != nil || len(rts) == 0 {
+		return nil, err
+	}
+	client, err := f.newClient(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string][]byte, len(rts))
+	for _, rt := range rts {
+		b, err := rt.fetch(ctx, client)
+		if err != nil {
+			onErr(rt, err)
+			if b, err = os.ReadFile(rt.cacheFile(f.dir)); err != nil {
+				return nil, fmt.Errorf("failed to fetch template %s and no cached copy is available", rt.url)
+			}
+			if err := rt.verify(b); err != nil {
+				return nil, err
+			}
+		}
+		res[rt.url] = b
+	}
+	return res, nil
+}
+
+// resolve replaces the remote templates of the configuration with the files
+// caching the given content.
+func (f *templateFetcher) resolve(conf *Config, content map[string][]byte) error {
+	if len(content) == 0 {
+		return nil
+	}
+	if f.dir == "" {
+		return errors.New("remote templates require a template cache directory")
+	}
+	if err := os.MkdirAll(f.dir, 0o750); err != nil {
+		return err
+	}
+
+	for i, t := range conf.Templates {
+		if !isTemplateURL(t) {
+			continue
+		}
+		rt, err := parseRemoteTemplate(t)
+		if err != nil {
+			return err
+		}
+		file := rt.cacheFile(f.dir)
+		if err := writeFileAtomic(file, content[rt.url]); err != nil {
+			return err
+		}
+		conf.Templates[i] = file
+	}
+	return nil
+}
+
+// writeFileAtomic writes the file through a temporary file so that readers
+// never see a partially written file.
+func writeFileAtomic(file string, b []byte) error {
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o640); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
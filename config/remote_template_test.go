@@ -0,0 +1,143 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRemoteTemplate(t *testing.T) {
+	digest := fmt.Sprintf("%x", sha256.Sum256([]byte("foo")))
+
+	rt, err := parseRemoteTemplate("https://example.com/slack.tmpl")
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/slack.tmpl", rt.url)
+	require.Nil(t, rt.sha256)
+
+	rt, err = parseRemoteTemplate("https://example.com/slack.tmpl#sha256=" + digest)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/slack.tmpl", rt.url)
+	require.NoError(t, rt.verify([]byte("foo")))
+	require.Error(t, rt.verify([]byte("bar")))
+
+	for _, in := range []string{
+		"http://example.com/slack.tmpl",
+		"https:///slack.tmpl",
+		"https://example.com/slack.tmpl#md5=acbd18db4cc2f85cedef654fccc4a4d8",
+		"https://example.com/slack.tmpl#sha256=abc",
+	} {
+		_, err := parseRemoteTemplate(in)
+		require.Error(t, err, in)
+	}
+
+	_, err = Load(`
+route:
+  receiver: team-X
+receivers:
+- name: team-X
+templates:
+- http://example.com/slack.tmpl
+`)
+	require.EqualError(t, err, `template URL "http://example.com/slack.tmpl" must use https`)
+}
+
+func TestCoordinatorRemoteTemplates(t *testing.T) {
+	var (
+		mtx     sync.Mutex
+		content = `{{ define "remote" }}foo{{ end }}`
+		status  = http.StatusOK
+	)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		w.WriteHeader(status)
+		fmt.Fprint(w, content)
+	}))
+	defer srv.Close()
+	set := func(c string, s int) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		content, status = c, s
+	}
+
+	dir := t.TempDir()
+	newCoordinator := func(templateURL string) *Coordinator {
+		file := filepath.Join(dir, "alertmanager.yml")
+		require.NoError(t, os.WriteFile(file, []byte(fmt.Sprintf(`
+route:
+  receiver: team-X
+receivers:
+- name: team-X
+templates:
+- local/*.tmpl
+- %s
+`, templateURL)), 0o600))
+
+		c := NewCoordinator(file, prometheus.NewRegistry(), promslog.NewNopLogger())
+		c.SetTemplateCacheDir(filepath.Join(dir, "cache"))
+		c.templates.newClient = func(*Config) (*http.Client, error) { return srv.Client(), nil }
+		return c
+	}
+
+	c := newCoordinator(srv.URL + "/remote.tmpl")
+	var templates []string
+	c.Subscribe(func(conf *Config) error {
+		templates = conf.Templates
+		return nil
+	})
+	require.NoError(t, c.Reload())
+
+	// The remote template is replaced with its cached copy.
+	require.Len(t, templates, 2)
+	require.Equal(t, filepath.Join(dir, "local/*.tmpl"), templates[0])
+	b, err := os.ReadFile(templates[1])
+	require.NoError(t, err)
+	require.Equal(t, content, string(b))
+	require.False(t, c.templatesChanged())
+
+	set(`{{ define "remote" }}bar{{ end }}`, http.StatusOK)
+	require.True(t, c.templatesChanged())
+	require.NoError(t, c.Reload())
+	require.False(t, c.templatesChanged())
+
+	// The cached copy is used if the template can't be fetched.
+	set("", http.StatusInternalServerError)
+	require.NoError(t, c.Reload())
+	b, err = os.ReadFile(templates[1])
+	require.NoError(t, err)
+	require.Equal(t, `{{ define "remote" }}bar{{ end }}`, string(b))
+	require.Equal(t, 1.0, testutil.ToFloat64(c.templateFetchFailures))
+
+	// Templates not matching the pinned checksum are rejected, even cached.
+	set(`{{ define "remote" }}bar{{ end }}`, http.StatusOK)
+	c = newCoordinator(fmt.Sprintf("%s/remote.tmpl#sha256=%x", srv.URL, sha256.Sum256([]byte("foo"))))
+	require.Error(t, c.Reload())
+
+	// Remote templates require a cache directory.
+	c = newCoordinator(srv.URL + "/remote.tmpl")
+	c.SetTemplateCacheDir("")
+	require.EqualError(t, c.Reload(), "remote templates require a template cache directory")
+}
@@ -0,0 +1,212 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package consistency checks that the peers of a cluster make the same
+// suppression decisions. Each peer periodically gossips a hash of its
+// silenced and inhibited alerts and compares it with the hashes received from
+// the other peers.
+package consistency
+
+import (
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coder/quartz"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// message is gossiped by every peer after each check.
+type message struct {
+	Peer      string    `json:"peer"`
+	Hash      uint64    `json:"hash"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// peerState is the last message received from a peer.
+type peerState struct {
+	message
+	// previous is the hash of the message received before.
+	previous uint64
+	received time.Time
+}
+
+// Checker exchanges the hashes of the suppression decisions with the other
+// peers of the cluster. It implements cluster.State.
+type Checker struct {
+	clock     quartz.Clock
+	self      string
+	hash      func() uint64
+	logger    *slog.Logger
+	broadcast func([]byte)
+
+	mtx sync.RWMutex
+	// The local hashes of the current and the previous check. Peers do not
+	// check at the same time, so a peer is only divergent if none of its
+	// last two hashes matches any of the last two local ones.
+	current, previous uint64
+	// local is the message of the last check, nil before the first one.
+	local     []byte
+	peers     map[string]peerState
+	divergent []string
+
+	checksTotal      prometheus.Counter
+	divergencesTotal prometheus.Counter
+	divergentPeers   prometheus.Gauge
+}
+
+// New returns a new Checker for the peer with the given name. The hash
+// function returns the hash of the local suppression decisions, see
+// types.MemMarker.SuppressionHash.
+func New(self string, hash func() uint64, l *slog.Logger, r prometheus.Registerer) *Checker {
+	c := &Checker{
+		clock:     quartz.NewReal(),
+		self:      self,
+		hash:      hash,
+		logger:    l,
+		broadcast: func([]byte) {},
+		peers:     map[string]peerState{},
+		checksTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_cluster_suppression_checks_total",
+			Help: "Number of cross-peer suppression consistency checks.",
+		}),
+		divergencesTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_cluster_suppression_divergences_total",
+			Help: "Number of times a peer was found making different suppression decisions than the local one.",
+		}),
+		divergentPeers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "alertmanager_cluster_suppression_divergent_peers",
+			Help: "Number of peers whose silenced and inhibited alerts differed from the local ones at the last consistency check.",
+		}),
+	}
+	if r != nil {
+		r.MustRegister(c.checksTotal, c.divergencesTotal, c.divergentPeers)
+	}
+	return c
+}
+
+// SetBroadcast sets the function used to gossip the local hash.
+func (c *Checker) SetBroadcast(f func([]byte)) {
+	c.mtx.Lock()
+	c.broadcast = f
+	c.mtx.Unlock()
+}
+
+// Run checks the consistency with the other peers at the given interval until
+// stopc is closed.
+func (c *Checker) Run(interval time.Duration, stopc <-chan struct{}) {
+	t := c.clock.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stopc:
+			return
+		case <-t.C:
+			c.check(interval)
+		}
+	}
+}
+
+// check computes the local hash, compares it to the hashes of the peers seen
+// within the last two intervals and gossips it.
+func (c *Checker) check(interval time.Duration) {
+	h := c.hash()
+	now := c.clock.Now()
+
+	c.mtx.Lock()
+	c.previous, c.current = c.current, h
+
+	var divergent []string
+	for name, m := range c.peers {
+		if now.Sub(m.received) > 2*interval {
+			// The peer left or stopped gossiping.
+			delete(c.peers, name)
+			continue
+		}
+		if !c.matches(m.Hash) && !c.matches(m.previous) {
+			divergent = append(divergent, name)
+		}
+	}
+	sort.Strings(divergent)
+	c.divergent = divergent
+
+	b, err := json.Marshal(message{Peer: c.self, Hash: h, Timestamp: now})
+	if err != nil {
+		c.mtx.Unlock()
+		c.logger.Error("Failed to encode consistency check message", "err", err)
+		return
+	}
+	c.local = b
+	broadcast := c.broadcast
+	c.mtx.Unlock()
+
+	c.checksTotal.Inc()
+	c.divergencesTotal.Add(float64(len(divergent)))
+	c.divergentPeers.Set(float64(len(divergent)))
+	if len(divergent) > 0 {
+		c.logger.Warn("Peers make different suppression decisions", "peers", divergent)
+	}
+
+	broadcast(b)
+}
+
+func (c *Checker) matches(h uint64) bool {
+	return h == c.current || h == c.previous
+}
+
+// DivergentPeers returns the names of the peers whose suppression decisions
+// differed from the local ones at the last check.
+func (c *Checker) DivergentPeers() []string {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return append([]string(nil), c.divergent...)
+}
+
+// MarshalBinary implements cluster.State. It returns the local hash of the
+// last check, or nothing before the first check.
+func (c *Checker) MarshalBinary() ([]byte, error) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.local, nil
+}
+
+// Merge implements cluster.State. It records the hash sent by a peer.
+func (c *Checker) Merge(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	var m message
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	if m.Peer == c.self {
+		return nil
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	// Timestamps are only compared with other timestamps of the same peer
+	// to ignore reordered messages, so clock skew between peers is harmless.
+	ps := peerState{message: m, previous: m.Hash, received: c.clock.Now()}
+	if prev, ok := c.peers[m.Peer]; ok {
+		if !m.Timestamp.After(prev.Timestamp) {
+			return nil
+		}
+		ps.previous = prev.Hash
+	}
+	c.peers[m.Peer] = ps
+	return nil
+}
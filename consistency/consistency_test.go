@@ -0,0 +1,113 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consistency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coder/quartz"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestChecker(t *testing.T, name string, clock quartz.Clock, hash *uint64) *Checker {
+	c := New(name, func() uint64 { return *hash }, promslog.NewNopLogger(), prometheus.NewRegistry())
+	c.clock = clock
+	return c
+}
+
+func TestChecker(t *testing.T) {
+	const interval = time.Minute
+	clock := quartz.NewMock(t)
+
+	var h1, h2 uint64 = 1, 1
+	c1 := newTestChecker(t, "p1", clock, &h1)
+	c2 := newTestChecker(t, "p2", clock, &h2)
+	c1.SetBroadcast(func(b []byte) { require.NoError(t, c2.Merge(b)) })
+	c2.SetBroadcast(func(b []byte) { require.NoError(t, c1.Merge(b)) })
+
+	// Nothing is gossiped in a full state sync before the first check.
+	b, err := c1.MarshalBinary()
+	require.NoError(t, err)
+	require.Empty(t, b)
+	require.NoError(t, c2.Merge(b))
+
+	round := func() {
+		clock.Advance(interval)
+		c1.check(interval)
+		c2.check(interval)
+	}
+
+	round()
+	round()
+	require.Empty(t, c1.DivergentPeers())
+	require.Empty(t, c2.DivergentPeers())
+
+	// A peer whose decisions changed one check earlier isn't divergent.
+	h1 = 2
+	round()
+	require.Empty(t, c1.DivergentPeers())
+	require.Empty(t, c2.DivergentPeers())
+
+	// The peers keep making different decisions.
+	round()
+	require.Equal(t, []string{"p2"}, c1.DivergentPeers())
+	require.Equal(t, []string{"p1"}, c2.DivergentPeers())
+	require.Equal(t, 1.0, testutil.ToFloat64(c2.divergentPeers))
+	require.Equal(t, 1.0, testutil.ToFloat64(c2.divergencesTotal))
+
+	// They converge again.
+	h2 = 2
+	round()
+	require.Empty(t, c2.DivergentPeers())
+	require.Equal(t, 0.0, testutil.ToFloat64(c2.divergentPeers))
+	require.Equal(t, 5.0, testutil.ToFloat64(c2.checksTotal))
+}
+
+func TestCheckerForgetsSilentPeers(t *testing.T) {
+	const interval = time.Minute
+	clock := quartz.NewMock(t)
+
+	var h1, h2 uint64 = 1, 2
+	c1 := newTestChecker(t, "p1", clock, &h1)
+	c2 := newTestChecker(t, "p2", clock, &h2)
+	c1.SetBroadcast(func(b []byte) { require.NoError(t, c2.Merge(b)) })
+
+	c1.check(interval)
+	c2.check(interval)
+	require.Equal(t, []string{"p1"}, c2.DivergentPeers())
+
+	clock.Advance(2*interval + time.Second)
+	c2.check(interval)
+	require.Empty(t, c2.DivergentPeers())
+}
+
+func TestCheckerMergeIgnoresOutdatedMessages(t *testing.T) {
+	clock := quartz.NewMock(t)
+	var h uint64
+	c := newTestChecker(t, "p1", clock, &h)
+
+	require.NoError(t, c.Merge([]byte(`{"peer":"p2","hash":2,"timestamp":"2024-01-01T00:01:00Z"}`)))
+	require.NoError(t, c.Merge([]byte(`{"peer":"p2","hash":1,"timestamp":"2024-01-01T00:00:00Z"}`)))
+	require.Equal(t, uint64(2), c.peers["p2"].Hash)
+
+	// Messages sent by itself are ignored.
+	require.NoError(t, c.Merge([]byte(`{"peer":"p1","hash":3,"timestamp":"2024-01-01T00:00:00Z"}`)))
+	require.NotContains(t, c.peers, "p1")
+
+	require.Error(t, c.Merge([]byte(`{`)))
+}
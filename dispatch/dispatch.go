@@ -14,17 +14,20 @@
 package dispatch
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/model"
 
+	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/provider"
 	"github.com/prometheus/alertmanager/store"
@@ -36,6 +39,12 @@ type DispatcherMetrics struct {
 	aggrGroups            prometheus.Gauge
 	processingDuration    prometheus.Summary
 	aggrGroupLimitReached prometheus.Counter
+	groupByLimitOverflow  prometheus.Counter
+	notificationLatency   *prometheus.HistogramVec
+	deadlineExceeded      *prometheus.CounterVec
+	flushesQueued         prometheus.Gauge
+	flushesInProgress     prometheus.Gauge
+	flushWaitDuration     prometheus.Histogram
 }
 
 // NewDispatcherMetrics returns a new registered DispatchMetrics.
@@ -59,10 +68,56 @@ func NewDispatcherMetrics(registerLimitMetrics bool, r prometheus.Registerer) *D
 				Help: "Number of times when dispatcher failed to create new aggregation group due to limit.",
 			},
 		),
+		groupByLimitOverflow: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "alertmanager_dispatcher_group_by_limit_overflow_total",
+				Help: "Number of alerts collapsed into an overflow group because their route reached its group_by_limit.",
+			},
+		),
+		notificationLatency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:                            "alertmanager_dispatcher_alert_notification_latency_seconds",
+				Help:                            "Latency from the reception of firing alerts to their first successful notification, by route.",
+				Buckets:                         []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600},
+				NativeHistogramBucketFactor:     1.1,
+				NativeHistogramMaxBucketNumber:  100,
+				NativeHistogramMinResetDuration: 1 * time.Hour,
+			},
+			[]string{"route"},
+		),
+		deadlineExceeded: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "alertmanager_dispatcher_notification_deadline_exceeded_total",
+				Help: "Number of firing alerts not notified within the notification_deadline of their route, by route.",
+			},
+			[]string{"route"},
+		),
+		flushesQueued: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "alertmanager_dispatcher_flushes_queued",
+				Help: "Number of flushes of aggregation groups waiting for the maximum number of concurrent flushes to allow them.",
+			},
+		),
+		flushesInProgress: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "alertmanager_dispatcher_flushes_in_progress",
+				Help: "Number of flushes of aggregation groups in progress, only counted when the number of concurrent flushes is limited.",
+			},
+		),
+		flushWaitDuration: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:                            "alertmanager_dispatcher_flush_wait_duration_seconds",
+				Help:                            "Time flushes of aggregation groups waited for the maximum number of concurrent flushes to allow them.",
+				Buckets:                         []float64{.01, .1, 1, 5, 15, 30, 60, 120, 300},
+				NativeHistogramBucketFactor:     1.1,
+				NativeHistogramMaxBucketNumber:  100,
+				NativeHistogramMinResetDuration: 1 * time.Hour,
+			},
+		),
 	}
 
 	if r != nil {
-		r.MustRegister(m.aggrGroups, m.processingDuration)
+		r.MustRegister(m.aggrGroups, m.processingDuration, m.groupByLimitOverflow, m.notificationLatency, m.deadlineExceeded, m.flushesQueued, m.flushesInProgress, m.flushWaitDuration)
 		if registerLimitMetrics {
 			r.MustRegister(m.aggrGroupLimitReached)
 		}
@@ -81,11 +136,17 @@ type Dispatcher struct {
 	metrics *DispatcherMetrics
 	limits  Limits
 
-	timeout func(time.Duration) time.Duration
+	timeout func(context.Context, time.Duration) time.Duration
+
+	// flushSlots limits the number of concurrent flushes if not nil.
+	flushSlots chan struct{}
+	// normalizers normalize the label values of the alerts before grouping.
+	normalizers []config.GroupLabelNormalizer
 
 	mtx                sync.RWMutex
 	aggrGroupsPerRoute map[*Route]map[model.Fingerprint]*aggrGroup
 	aggrGroupsNum      int
+	draining           bool
 
 	done   chan struct{}
 	ctx    context.Context
@@ -108,7 +169,7 @@ func NewDispatcher(
 	r *Route,
 	s notify.Stage,
 	mk types.GroupMarker,
-	to func(time.Duration) time.Duration,
+	to func(context.Context, time.Duration) time.Duration,
 	lim Limits,
 	l *slog.Logger,
 	m *DispatcherMetrics,
@@ -130,6 +191,47 @@ func NewDispatcher(
 	return disp
 }
 
+// SetMaxConcurrentFlushes limits the number of aggregation groups flushing
+// at once, the other flushes waiting in a queue until the next flush of their
+// group is due. If n is zero or negative, the number is unlimited. It must be
+// called before Run.
+func (d *Dispatcher) SetMaxConcurrentFlushes(n int) {
+	if n <= 0 {
+		d.flushSlots = nil
+		return
+	}
+	d.flushSlots = make(chan struct{}, n)
+}
+
+// SetGroupLabelNormalizers sets the normalizers of the label values of the
+// alerts applied before grouping them. It must be called before Run.
+func (d *Dispatcher) SetGroupLabelNormalizers(normalizers []config.GroupLabelNormalizer) {
+	d.normalizers = normalizers
+}
+
+// acquireFlush waits until a flush can start and returns the function to call
+// once it is done, or an error if ctx is done first.
+func (d *Dispatcher) acquireFlush(ctx context.Context) (func(), error) {
+	if d.flushSlots == nil {
+		return func() {}, nil
+	}
+	start := time.Now()
+	d.metrics.flushesQueued.Inc()
+	select {
+	case d.flushSlots <- struct{}{}:
+		d.metrics.flushesQueued.Dec()
+	case <-ctx.Done():
+		d.metrics.flushesQueued.Dec()
+		return nil, ctx.Err()
+	}
+	d.metrics.flushWaitDuration.Observe(time.Since(start).Seconds())
+	d.metrics.flushesInProgress.Inc()
+	return func() {
+		d.metrics.flushesInProgress.Dec()
+		<-d.flushSlots
+	}, nil
+}
+
 // Run starts dispatching alerts incoming via the updates channel.
 func (d *Dispatcher) Run() {
 	d.done = make(chan struct{})
@@ -137,6 +239,7 @@ func (d *Dispatcher) Run() {
 	d.mtx.Lock()
 	d.aggrGroupsPerRoute = map[*Route]map[model.Fingerprint]*aggrGroup{}
 	d.aggrGroupsNum = 0
+	d.draining = false
 	d.metrics.aggrGroups.Set(0)
 	d.ctx, d.cancel = context.WithCancel(context.Background())
 	d.mtx.Unlock()
@@ -207,6 +310,8 @@ type AlertGroup struct {
 	Receiver string
 	GroupKey string
 	RouteID  string
+	// RouteOpts are the effective options of the route of the group.
+	RouteOpts *RouteOpts
 }
 
 type AlertGroups []*AlertGroup
@@ -239,12 +344,13 @@ func (d *Dispatcher) Groups(routeFilter func(*Route) bool, alertFilter func(*typ
 		}
 
 		for _, ag := range ags {
-			receiver := route.RouteOpts.Receiver
+			receiver := ag.receiver
 			alertGroup := &AlertGroup{
-				Labels:   ag.labels,
-				Receiver: receiver,
-				GroupKey: ag.GroupKey(),
-				RouteID:  ag.routeID,
+				Labels:    ag.labels,
+				Receiver:  receiver,
+				GroupKey:  ag.GroupKey(),
+				RouteID:   ag.routeID,
+				RouteOpts: ag.opts,
 			}
 
 			alerts := ag.alerts.List()
@@ -303,6 +409,49 @@ func (d *Dispatcher) Stop() {
 	<-d.done
 }
 
+// Drain stops all aggregation groups from starting new flushes and waits
+// for the in-flight notifications to finish, or for ctx to be done,
+// before stopping the dispatcher. Notifications still in flight when ctx
+// is done are canceled.
+func (d *Dispatcher) Drain(ctx context.Context) {
+	if d == nil {
+		return
+	}
+	d.mtx.Lock()
+	if d.cancel == nil {
+		d.mtx.Unlock()
+		return
+	}
+	d.draining = true
+	groups := make([]*aggrGroup, 0, d.aggrGroupsNum)
+	for _, routeGroups := range d.aggrGroupsPerRoute {
+		for _, ag := range routeGroups {
+			ag.drain()
+			groups = append(groups, ag)
+		}
+	}
+	d.mtx.Unlock()
+
+	d.logger.Info("Draining aggregation groups", "groups", len(groups))
+
+	done := make(chan struct{})
+	go func() {
+		for _, ag := range groups {
+			<-ag.done
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		d.logger.Info("Aggregation groups drained")
+	case <-ctx.Done():
+		d.logger.Warn("Drain period exceeded, canceling in-flight notifications", "err", ctx.Err())
+	}
+
+	d.Stop()
+}
+
 // notifyFunc is a function that performs notification for the alert
 // with the given fingerprint. It aborts on context cancelation.
 // Returns false iff notifying failed.
@@ -311,7 +460,7 @@ type notifyFunc func(context.Context, ...*types.Alert) bool
 // processAlert determines in which aggregation group the alert falls
 // and inserts it.
 func (d *Dispatcher) processAlert(alert *types.Alert, route *Route) {
-	groupLabels := getGroupLabels(alert, route)
+	groupLabels := getGroupLabels(alert, route, d.normalizers)
 
 	fp := groupLabels.Fingerprint()
 
@@ -330,6 +479,36 @@ func (d *Dispatcher) processAlert(alert *types.Alert, route *Route) {
 		return
 	}
 
+	// Once the route has as many groups as its group_by_limit allows, alerts
+	// which would create another group are collapsed into the overflow group
+	// of the route. Alerts already in the overflow group stay there so that
+	// their updates don't end up in two groups.
+	if limit := route.RouteOpts.GroupByLimit; limit > 0 {
+		groups, overflowed := len(routeGroups), false
+		overflow, ok := routeGroups[overflowGroupFingerprint]
+		if ok {
+			groups--
+			_, err := overflow.alerts.Get(alert.Fingerprint())
+			overflowed = err == nil
+		}
+		if overflowed || groups >= limit {
+			d.metrics.groupByLimitOverflow.Inc()
+			alert = overflowAlert(alert, limit)
+			if ok {
+				overflow.insert(alert)
+				return
+			}
+			d.logger.Warn("Route reached its group_by_limit, collapsing new groups into an overflow group", "route", route.Key(), "limit", limit)
+			groupLabels, fp = overflowGroupLabels, overflowGroupFingerprint
+		}
+	}
+
+	// No new groups are created while draining as they would never flush.
+	if d.draining {
+		d.logger.Debug("Dispatcher is draining, not creating new group for alert", "alert", alert.Name())
+		return
+	}
+
 	// If the group does not exist, create it. But check the limit first.
 	if limit := d.limits.MaxNumberOfAggregationGroups(); limit > 0 && d.aggrGroupsNum >= limit {
 		d.metrics.aggrGroupLimitReached.Inc()
@@ -338,6 +517,7 @@ func (d *Dispatcher) processAlert(alert *types.Alert, route *Route) {
 	}
 
 	ag = newAggrGroup(d.ctx, groupLabels, route, d.timeout, d.logger)
+	ag.metrics = d.metrics
 	routeGroups[fp] = ag
 	d.aggrGroupsNum++
 	d.metrics.aggrGroups.Inc()
@@ -348,7 +528,16 @@ func (d *Dispatcher) processAlert(alert *types.Alert, route *Route) {
 	ag.insert(alert)
 
 	go ag.run(func(ctx context.Context, alerts ...*types.Alert) bool {
-		_, _, err := d.stage.Exec(ctx, d.logger, alerts...)
+		release, err := d.acquireFlush(ctx)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				d.logger.Warn("Too many concurrent flushes, the flush of the group timed out before starting", "aggrGroup", ag, "num_alerts", len(alerts))
+			}
+			return false
+		}
+		defer release()
+
+		_, _, err = d.stage.Exec(ctx, d.logger, alerts...)
 		if err != nil {
 			logger := d.logger.With("num_alerts", len(alerts), "err", err)
 			if errors.Is(ctx.Err(), context.Canceled) {
@@ -364,10 +553,38 @@ func (d *Dispatcher) processAlert(alert *types.Alert, route *Route) {
 	})
 }
 
-func getGroupLabels(alert *types.Alert, route *Route) model.LabelSet {
+// overflowAnnotation is the annotation added to the alerts collapsed into the
+// overflow group of a route.
+const overflowAnnotation = "group_by_limit_exceeded"
+
+// overflowGroupLabels are the group labels of the overflow group of a route.
+// The reserved label name keeps its fingerprint from matching the one of a
+// regular group, such as the group of the alerts without any of the group_by
+// labels.
+var overflowGroupLabels = model.LabelSet{"__overflow__": "true"}
+
+// overflowGroupFingerprint is the fingerprint of the overflow group of a
+// route.
+var overflowGroupFingerprint = overflowGroupLabels.Fingerprint()
+
+// overflowAlert returns a copy of the alert with a warning annotation to be
+// collapsed into the overflow group of a route.
+func overflowAlert(alert *types.Alert, limit int) *types.Alert {
+	a := *alert
+	a.Annotations = alert.Annotations.Clone()
+	a.Annotations[overflowAnnotation] = model.LabelValue(fmt.Sprintf(
+		"The route reached its group_by_limit of %d aggregation groups, this alert was collapsed into the overflow group.", limit,
+	))
+	return &a
+}
+
+func getGroupLabels(alert *types.Alert, route *Route, normalizers []config.GroupLabelNormalizer) model.LabelSet {
 	groupLabels := model.LabelSet{}
 	for ln, lv := range alert.Labels {
-		if _, ok := route.RouteOpts.GroupBy[ln]; ok || route.RouteOpts.GroupByAll {
+		if _, ok := route.RouteOpts.GroupBy[ln]; ok || route.RouteOpts.GroupByAll || ln == route.RouteOpts.ReceiverFromLabel {
+			for _, n := range normalizers {
+				lv = n.Normalize(ln, lv)
+			}
 			groupLabels[ln] = lv
 		}
 	}
@@ -381,6 +598,7 @@ func getGroupLabels(alert *types.Alert, route *Route) model.LabelSet {
 type aggrGroup struct {
 	labels   model.LabelSet
 	opts     *RouteOpts
+	receiver string
 	logger   *slog.Logger
 	routeID  string
 	routeKey string
@@ -389,26 +607,46 @@ type aggrGroup struct {
 	ctx     context.Context
 	cancel  func()
 	done    chan struct{}
+	drainc  chan struct{}
+	drained sync.Once
 	next    *time.Timer
-	timeout func(time.Duration) time.Duration
+	timeout func(context.Context, time.Duration) time.Duration
+
+	// metrics records the notification latency of the alerts if set.
+	metrics *DispatcherMetrics
 
 	mtx        sync.RWMutex
 	hasFlushed bool
+	// latencies tracks the alerts of the group until their first
+	// notification, by fingerprint.
+	latencies map[model.Fingerprint]*alertLatency
+}
+
+// alertLatency tracks a firing alert of a group until its first successful
+// notification.
+type alertLatency struct {
+	received time.Time
+	notified bool
+	exceeded bool
 }
 
 // newAggrGroup returns a new aggregation group.
-func newAggrGroup(ctx context.Context, labels model.LabelSet, r *Route, to func(time.Duration) time.Duration, logger *slog.Logger) *aggrGroup {
+func newAggrGroup(ctx context.Context, labels model.LabelSet, r *Route, to func(context.Context, time.Duration) time.Duration, logger *slog.Logger) *aggrGroup {
 	if to == nil {
-		to = func(d time.Duration) time.Duration { return d }
+		to = func(_ context.Context, d time.Duration) time.Duration { return d }
 	}
 	ag := &aggrGroup{
 		labels:   labels,
 		routeID:  r.ID(),
 		routeKey: r.Key(),
 		opts:     &r.RouteOpts,
+		receiver: r.RouteOpts.ReceiverFor(labels),
 		timeout:  to,
 		alerts:   store.NewAlerts(),
 		done:     make(chan struct{}),
+		drainc:   make(chan struct{}),
+
+		latencies: map[model.Fingerprint]*alertLatency{},
 	}
 	ag.ctx, ag.cancel = context.WithCancel(ctx)
 
@@ -440,24 +678,23 @@ func (ag *aggrGroup) run(nf notifyFunc) {
 	for {
 		select {
 		case now := <-ag.next.C:
-			// Give the notifications time until the next flush to
-			// finish before terminating them.
-			ctx, cancel := context.WithTimeout(ag.ctx, ag.timeout(ag.opts.GroupInterval))
+			// Don't start a new flush once draining has begun, even if
+			// the timer fired at the same time.
+			select {
+			case <-ag.drainc:
+				return
+			default:
+			}
 
 			// The now time we retrieve from the ticker is the only reliable
 			// point of time reference for the subsequent notification pipeline.
 			// Calculating the current time directly is prone to flaky behavior,
 			// which usually only becomes apparent in tests.
-			ctx = notify.WithNow(ctx, now)
+			ctx := ag.notifyContext(ag.ctx, now)
 
-			// Populate context with information needed along the pipeline.
-			ctx = notify.WithGroupKey(ctx, ag.GroupKey())
-			ctx = notify.WithGroupLabels(ctx, ag.labels)
-			ctx = notify.WithReceiverName(ctx, ag.opts.Receiver)
-			ctx = notify.WithRepeatInterval(ctx, ag.opts.RepeatInterval)
-			ctx = notify.WithMuteTimeIntervals(ctx, ag.opts.MuteTimeIntervals)
-			ctx = notify.WithActiveTimeIntervals(ctx, ag.opts.ActiveTimeIntervals)
-			ctx = notify.WithRouteID(ctx, ag.routeID)
+			// Give the notifications time until the next flush to
+			// finish before terminating them.
+			ctx, cancel := context.WithTimeout(ctx, ag.timeout(ctx, ag.opts.GroupInterval))
 
 			// Wait the configured interval before calling flush again.
 			ag.mtx.Lock()
@@ -465,18 +702,78 @@ func (ag *aggrGroup) run(nf notifyFunc) {
 			ag.hasFlushed = true
 			ag.mtx.Unlock()
 
-			ag.flush(func(alerts ...*types.Alert) bool {
-				return nf(ctx, alerts...)
+			ag.flush(now, func(alerts ...*types.Alert) bool {
+				notified := nf(ctx, alerts...)
+				for _, r := range ag.escalations(alerts, now) {
+					// The resolved alerts only reach the receivers
+					// which were notified about them before, as the
+					// notification log doesn't know about the others.
+					if !nf(notify.WithReceiverName(ctx, r), alerts...) {
+						notified = false
+					}
+				}
+				return notified
 			})
 
 			cancel()
 
+		case <-ag.drainc:
+			return
 		case <-ag.ctx.Done():
 			return
 		}
 	}
 }
 
+// escalations returns the receivers of the escalations of the route which are
+// due for the alerts of a flush at now, given the start of the oldest firing
+// alert. Once all alerts resolved, all of them are returned so that the
+// receivers escalated to are notified of the resolution.
+func (ag *aggrGroup) escalations(alerts []*types.Alert, now time.Time) []string {
+	if len(ag.opts.Escalations) == 0 {
+		return nil
+	}
+	var since time.Time
+	for _, a := range alerts {
+		if a.ResolvedAt(now) {
+			continue
+		}
+		if since.IsZero() || a.StartsAt.Before(since) {
+			since = a.StartsAt
+		}
+	}
+	var receivers []string
+	for _, e := range ag.opts.Escalations {
+		if !since.IsZero() && now.Sub(since) < e.After {
+			break
+		}
+		receivers = append(receivers, e.Receiver)
+	}
+	return receivers
+}
+
+// notifyContext populates the context with the information needed along the
+// notification pipeline for a flush at now.
+func (ag *aggrGroup) notifyContext(ctx context.Context, now time.Time) context.Context {
+	ctx = notify.WithNow(ctx, now)
+	ctx = notify.WithGroupKey(ctx, ag.GroupKey())
+	ctx = notify.WithGroupLabels(ctx, ag.labels)
+	ctx = notify.WithReceiverName(ctx, ag.receiver)
+	ctx = notify.WithRepeatInterval(ctx, ag.opts.RepeatInterval)
+	ctx = notify.WithMuteTimeIntervals(ctx, ag.opts.MuteTimeIntervals)
+	ctx = notify.WithActiveTimeIntervals(ctx, ag.opts.ActiveTimeIntervals)
+	if ag.opts.GroupAlertLimit > 0 {
+		ctx = notify.WithAlertLimit(ctx, ag.opts.GroupAlertLimit)
+	}
+	if len(ag.opts.CollapseBy) > 0 {
+		ctx = notify.WithCollapseBy(ctx, ag.opts.CollapseBy)
+	}
+	if ag.opts.PeerTimeout != nil {
+		ctx = notify.WithPeerTimeout(ctx, *ag.opts.PeerTimeout)
+	}
+	return notify.WithRouteID(ctx, ag.routeID)
+}
+
 func (ag *aggrGroup) stop() {
 	// Calling cancel will terminate all in-process notifications
 	// and the run() loop.
@@ -484,6 +781,12 @@ func (ag *aggrGroup) stop() {
 	<-ag.done
 }
 
+// drain prevents the aggregation group from starting new flushes. The
+// run() loop returns once the in-flight flush, if any, has finished.
+func (ag *aggrGroup) drain() {
+	ag.drained.Do(func() { close(ag.drainc) })
+}
+
 // insert inserts the alert into the aggregation group.
 func (ag *aggrGroup) insert(alert *types.Alert) {
 	if err := ag.alerts.Set(alert); err != nil {
@@ -494,6 +797,9 @@ func (ag *aggrGroup) insert(alert *types.Alert) {
 	// alert is already over.
 	ag.mtx.Lock()
 	defer ag.mtx.Unlock()
+	if fp := alert.Fingerprint(); ag.metrics != nil && !alert.Resolved() && ag.latencies[fp] == nil {
+		ag.latencies[fp] = &alertLatency{received: time.Now()}
+	}
 	if !ag.hasFlushed && alert.StartsAt.Add(ag.opts.GroupWait).Before(time.Now()) {
 		ag.next.Reset(0)
 	}
@@ -503,8 +809,9 @@ func (ag *aggrGroup) empty() bool {
 	return ag.alerts.Empty()
 }
 
-// flush sends notifications for all new alerts.
-func (ag *aggrGroup) flush(notify func(...*types.Alert) bool) {
+// flush sends notifications for all new alerts, resolving the alerts which
+// ended before now.
+func (ag *aggrGroup) flush(now time.Time, notify func(...*types.Alert) bool) {
 	if ag.empty() {
 		return
 	}
@@ -513,7 +820,6 @@ func (ag *aggrGroup) flush(notify func(...*types.Alert) bool) {
 		alerts        = ag.alerts.List()
 		alertsSlice   = make(types.AlertSlice, 0, len(alerts))
 		resolvedSlice = make(types.AlertSlice, 0, len(alerts))
-		now           = time.Now()
 	)
 	for _, alert := range alerts {
 		a := *alert
@@ -526,10 +832,16 @@ func (ag *aggrGroup) flush(notify func(...*types.Alert) bool) {
 		alertsSlice = append(alertsSlice, &a)
 	}
 	sort.Stable(alertsSlice)
+	if len(ag.opts.AlertOrder) > 0 {
+		orderAlerts(alertsSlice, ag.opts.AlertOrder, ag.opts.SeverityOrder)
+	}
 
 	ag.logger.Debug("flushing", "alerts", fmt.Sprintf("%v", alertsSlice))
 
-	if notify(alertsSlice...) {
+	notified := notify(alertsSlice...)
+	ag.observeLatencies(alertsSlice, notified)
+
+	if notified {
 		// Delete all resolved alerts as we just sent a notification for them,
 		// and we don't want to send another one. However, we need to make sure
 		// that each resolved alert has not fired again during the flush as then
@@ -540,6 +852,96 @@ func (ag *aggrGroup) flush(notify func(...*types.Alert) bool) {
 	}
 }
 
+// observeLatencies records the latency of the firing alerts of a flush which
+// are notified for the first time, and counts the alerts not yet notified
+// once the notification deadline of the route has passed.
+func (ag *aggrGroup) observeLatencies(alerts []*types.Alert, notified bool) {
+	if ag.metrics == nil {
+		return
+	}
+	now := time.Now()
+
+	ag.mtx.Lock()
+	defer ag.mtx.Unlock()
+
+	flushed := make(map[model.Fingerprint]struct{}, len(alerts))
+	for _, a := range alerts {
+		fp := a.Fingerprint()
+		flushed[fp] = struct{}{}
+		l, ok := ag.latencies[fp]
+		if !ok || l.notified {
+			continue
+		}
+		// Alerts resolving before they were notified aren't tracked further.
+		if a.Resolved() {
+			delete(ag.latencies, fp)
+			continue
+		}
+		latency := now.Sub(l.received)
+		if notified {
+			l.notified = true
+			ag.metrics.notificationLatency.WithLabelValues(ag.routeID).Observe(latency.Seconds())
+		}
+		if d := ag.opts.NotificationDeadline; d > 0 && latency > d && !l.exceeded {
+			l.exceeded = true
+			ag.metrics.deadlineExceeded.WithLabelValues(ag.routeID).Inc()
+		}
+	}
+	// Forget the alerts which were removed from the group.
+	for fp := range ag.latencies {
+		if _, ok := flushed[fp]; ok {
+			continue
+		}
+		if _, err := ag.alerts.Get(fp); err != nil {
+			delete(ag.latencies, fp)
+		}
+	}
+}
+
+// DefaultSeverityOrder is the order of the values of the severity label if
+// a route doesn't configure one.
+var DefaultSeverityOrder = []string{"critical", "error", "warning", "info"}
+
+// orderAlerts sorts the alerts by the keys of the order. Alerts equal for all
+// keys keep their order.
+func orderAlerts(alerts types.AlertSlice, order, severityOrder []string) {
+	if len(severityOrder) == 0 {
+		severityOrder = DefaultSeverityOrder
+	}
+	// Unknown severities rank after the known ones.
+	ranks := make(map[model.LabelValue]int, len(severityOrder))
+	for i, sev := range severityOrder {
+		ranks[model.LabelValue(sev)] = i
+	}
+	rank := func(a *types.Alert) int {
+		if r, ok := ranks[a.Labels["severity"]]; ok {
+			return r
+		}
+		return len(severityOrder)
+	}
+
+	sort.SliceStable(alerts, func(i, j int) bool {
+		a, b := alerts[i], alerts[j]
+		for _, key := range order {
+			var c int
+			desc := strings.HasPrefix(key, "-")
+			switch strings.TrimPrefix(key, "-") {
+			case "severity":
+				c = cmp.Compare(rank(a), rank(b))
+			case "startsAt":
+				c = a.StartsAt.Compare(b.StartsAt)
+			case "alertname":
+				c = cmp.Compare(a.Labels[model.AlertNameLabel], b.Labels[model.AlertNameLabel])
+			}
+			if c == 0 {
+				continue
+			}
+			return (c < 0) != desc
+		}
+		return false
+	})
+}
+
 type nilLimits struct{}
 
 func (n nilLimits) MaxNumberOfAggregationGroups() int { return 0 }
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"log/slog"
 	"reflect"
+	"regexp"
 	"sort"
 	"sync"
 	"testing"
@@ -25,9 +26,11 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/common/promslog"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/notify"
@@ -294,6 +297,112 @@ func TestAggrGroup(t *testing.T) {
 	ag.stop()
 }
 
+func TestAggrGroupNotificationLatency(t *testing.T) {
+	route := &Route{
+		RouteOpts: RouteOpts{
+			Receiver:             "n1",
+			GroupBy:              map[model.LabelName]struct{}{"a": {}},
+			GroupWait:            time.Minute,
+			GroupInterval:        time.Minute,
+			RepeatInterval:       time.Hour,
+			NotificationDeadline: time.Millisecond,
+		},
+	}
+	m := NewDispatcherMetrics(false, prometheus.NewRegistry())
+	ag := newAggrGroup(context.Background(), model.LabelSet{"a": "v1"}, route, nil, promslog.NewNopLogger())
+	ag.metrics = m
+
+	a1 := &types.Alert{Alert: model.Alert{
+		Labels:   model.LabelSet{"a": "v1", "b": "v1"},
+		StartsAt: time.Now(),
+	}}
+	a2 := &types.Alert{Alert: model.Alert{
+		Labels:   model.LabelSet{"a": "v1", "b": "v2"},
+		StartsAt: time.Now(),
+	}}
+	ag.insert(a1)
+	ag.insert(a2)
+	time.Sleep(2 * time.Millisecond)
+
+	// Failed notifications aren't observed, but the alerts exceed the
+	// deadline.
+	ag.flush(time.Now(), func(...*types.Alert) bool { return false })
+	require.Equal(t, 0, testutil.CollectAndCount(m.notificationLatency))
+	require.Equal(t, 2.0, testutil.ToFloat64(m.deadlineExceeded.WithLabelValues(ag.routeID)))
+
+	ag.flush(time.Now(), func(...*types.Alert) bool { return true })
+	require.Equal(t, 2.0, testutil.ToFloat64(m.deadlineExceeded.WithLabelValues(ag.routeID)))
+
+	// Only the first successful notification of the alerts is observed, even
+	// if they are received again.
+	ag.insert(a1)
+	ag.flush(time.Now(), func(...*types.Alert) bool { return true })
+
+	var metric dto.Metric
+	require.NoError(t, m.notificationLatency.WithLabelValues(ag.routeID).(prometheus.Histogram).Write(&metric))
+	require.Equal(t, uint64(2), metric.GetHistogram().GetSampleCount())
+	require.Greater(t, metric.GetHistogram().GetSampleSum(), 0.002)
+}
+
+func TestAggrGroupEscalations(t *testing.T) {
+	route := &Route{
+		RouteOpts: RouteOpts{
+			Receiver:       "team",
+			GroupBy:        map[model.LabelName]struct{}{"a": {}},
+			GroupWait:      time.Millisecond,
+			GroupInterval:  time.Hour,
+			RepeatInterval: time.Hour,
+			Escalations: []Escalation{
+				{After: time.Hour, Receiver: "lead"},
+				{After: 3 * time.Hour, Receiver: "manager"},
+			},
+		},
+	}
+	ag := newAggrGroup(context.Background(), model.LabelSet{"a": "v1"}, route, nil, promslog.NewNopLogger())
+
+	now := time.Now()
+	firing := func(since time.Duration) *types.Alert {
+		return &types.Alert{Alert: model.Alert{
+			Labels:   model.LabelSet{"a": "v1", "since": model.LabelValue(since.String())},
+			StartsAt: now.Add(-since),
+		}}
+	}
+	resolved := &types.Alert{Alert: model.Alert{
+		Labels:   model.LabelSet{"a": "v1"},
+		StartsAt: now.Add(-5 * time.Hour),
+		EndsAt:   now.Add(-time.Minute),
+	}}
+
+	for _, tc := range []struct {
+		name     string
+		alerts   []*types.Alert
+		expected []string
+	}{
+		{"firing for less than the first escalation", []*types.Alert{firing(time.Minute)}, nil},
+		{"oldest firing alert past the first escalation", []*types.Alert{firing(time.Minute), firing(2 * time.Hour)}, []string{"lead"}},
+		{"past all escalations", []*types.Alert{firing(4 * time.Hour)}, []string{"lead", "manager"}},
+		{"resolved alerts are ignored", []*types.Alert{firing(time.Minute), resolved}, nil},
+		{"all alerts resolved", []*types.Alert{resolved}, []string{"lead", "manager"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, ag.escalations(tc.alerts, now))
+		})
+	}
+
+	// The escalation receivers are notified along with the receiver of the
+	// route.
+	ag.insert(firing(2 * time.Hour))
+	receivers := make(chan string, 2)
+	go ag.run(func(ctx context.Context, alerts ...*types.Alert) bool {
+		r, _ := notify.ReceiverName(ctx)
+		receivers <- r
+		return true
+	})
+	defer ag.stop()
+	require.Equal(t, "team", <-receivers)
+	require.Equal(t, "lead", <-receivers)
+}
+
 func TestGroupLabels(t *testing.T) {
 	a := &types.Alert{
 		Alert: model.Alert{
@@ -320,13 +429,91 @@ func TestGroupLabels(t *testing.T) {
 		"b": "v2",
 	}
 
-	ls := getGroupLabels(a, route)
+	ls := getGroupLabels(a, route, nil)
 
 	if !reflect.DeepEqual(ls, expLs) {
 		t.Fatalf("expected labels are %v, but got %v", expLs, ls)
 	}
 }
 
+func TestGroupLabelsNormalized(t *testing.T) {
+	route := &Route{
+		RouteOpts: RouteOpts{
+			GroupBy: map[model.LabelName]struct{}{
+				"alertname": {},
+				"instance":  {},
+			},
+		},
+	}
+	normalizers := []config.GroupLabelNormalizer{
+		{
+			Labels:    []model.LabelName{"instance"},
+			Lowercase: true,
+		},
+	}
+	normalizers[0].Regex.Regexp = regexp.MustCompile(`^(?:(.+)\.example\.com)$`)
+	normalizers[0].Replacement = "$1"
+
+	a := &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "Down", "instance": "Web-1.example.com", "job": "node"}}}
+	b := &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "Down", "instance": "web-1"}}}
+
+	expLs := model.LabelSet{"alertname": "Down", "instance": "web-1"}
+	require.Equal(t, expLs, getGroupLabels(a, route, normalizers))
+	require.Equal(t, expLs, getGroupLabels(b, route, normalizers))
+	// The labels of the alerts are left unchanged.
+	require.Equal(t, model.LabelValue("Web-1.example.com"), a.Labels["instance"])
+}
+
+func TestOrderAlerts(t *testing.T) {
+	now := time.Now()
+	newAlert := func(name, severity string, startsAt time.Time) *types.Alert {
+		return &types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": model.LabelValue(name), "severity": model.LabelValue(severity)},
+				StartsAt: startsAt,
+			},
+		}
+	}
+	var (
+		a = newAlert("A", "warning", now.Add(-time.Minute))
+		b = newAlert("B", "critical", now.Add(-time.Hour))
+		c = newAlert("C", "page", now)
+		d = newAlert("D", "critical", now)
+	)
+
+	for _, tc := range []struct {
+		order      []string
+		severities []string
+		exp        types.AlertSlice
+	}{
+		{
+			order: []string{"severity"},
+			exp:   types.AlertSlice{b, d, a, c},
+		},
+		{
+			order: []string{"severity", "-startsAt"},
+			exp:   types.AlertSlice{d, b, a, c},
+		},
+		{
+			order:      []string{"severity", "alertname"},
+			severities: []string{"page", "critical"},
+			exp:        types.AlertSlice{c, b, d, a},
+		},
+		{
+			order: []string{"startsAt"},
+			exp:   types.AlertSlice{b, a, c, d},
+		},
+		{
+			order: []string{"-alertname"},
+			exp:   types.AlertSlice{d, c, b, a},
+		},
+	} {
+		alerts := types.AlertSlice{a, b, c, d}
+		orderAlerts(alerts, tc.order, tc.severities)
+		require.Equal(t, tc.exp, alerts, "order %v", tc.order)
+	}
+}
+
 func TestGroupByAllLabels(t *testing.T) {
 	a := &types.Alert{
 		Alert: model.Alert{
@@ -351,7 +538,7 @@ func TestGroupByAllLabels(t *testing.T) {
 		"c": "v3",
 	}
 
-	ls := getGroupLabels(a, route)
+	ls := getGroupLabels(a, route, nil)
 
 	if !reflect.DeepEqual(ls, expLs) {
 		t.Fatalf("expected labels are %v, but got %v", expLs, ls)
@@ -397,7 +584,7 @@ route:
 	}
 	defer alerts.Close()
 
-	timeout := func(d time.Duration) time.Duration { return time.Duration(0) }
+	timeout := func(context.Context, time.Duration) time.Duration { return time.Duration(0) }
 	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)}
 	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, NewDispatcherMetrics(false, prometheus.NewRegistry()))
 	go dispatcher.Run()
@@ -439,9 +626,10 @@ route:
 			Labels: model.LabelSet{
 				"alertname": "OtherAlert",
 			},
-			Receiver: "prod",
-			GroupKey: "{}:{alertname=\"OtherAlert\"}",
-			RouteID:  "{}",
+			Receiver:  "prod",
+			GroupKey:  "{}:{alertname=\"OtherAlert\"}",
+			RouteID:   "{}",
+			RouteOpts: &route.RouteOpts,
 		},
 		&AlertGroup{
 			Alerts: []*types.Alert{inputAlerts[1]},
@@ -449,9 +637,10 @@ route:
 				"alertname": "TestingAlert",
 				"service":   "api",
 			},
-			Receiver: "testing",
-			GroupKey: "{}/{env=\"testing\"}:{alertname=\"TestingAlert\", service=\"api\"}",
-			RouteID:  "{}/{env=\"testing\"}/0",
+			Receiver:  "testing",
+			GroupKey:  "{}/{env=\"testing\"}:{alertname=\"TestingAlert\", service=\"api\"}",
+			RouteID:   "{}/{env=\"testing\"}/0",
+			RouteOpts: &route.Routes[0].RouteOpts,
 		},
 		&AlertGroup{
 			Alerts: []*types.Alert{inputAlerts[2], inputAlerts[3]},
@@ -460,9 +649,10 @@ route:
 				"service":   "api",
 				"cluster":   "aa",
 			},
-			Receiver: "prod",
-			GroupKey: "{}/{env=\"prod\"}:{alertname=\"HighErrorRate\", cluster=\"aa\", service=\"api\"}",
-			RouteID:  "{}/{env=\"prod\"}/1",
+			Receiver:  "prod",
+			GroupKey:  "{}/{env=\"prod\"}:{alertname=\"HighErrorRate\", cluster=\"aa\", service=\"api\"}",
+			RouteID:   "{}/{env=\"prod\"}/1",
+			RouteOpts: &route.Routes[1].RouteOpts,
 		},
 		&AlertGroup{
 			Alerts: []*types.Alert{inputAlerts[4]},
@@ -471,9 +661,10 @@ route:
 				"service":   "api",
 				"cluster":   "bb",
 			},
-			Receiver: "prod",
-			GroupKey: "{}/{env=\"prod\"}:{alertname=\"HighErrorRate\", cluster=\"bb\", service=\"api\"}",
-			RouteID:  "{}/{env=\"prod\"}/1",
+			Receiver:  "prod",
+			GroupKey:  "{}/{env=\"prod\"}:{alertname=\"HighErrorRate\", cluster=\"bb\", service=\"api\"}",
+			RouteID:   "{}/{env=\"prod\"}/1",
+			RouteOpts: &route.Routes[1].RouteOpts,
 		},
 		&AlertGroup{
 			Alerts: []*types.Alert{inputAlerts[5]},
@@ -482,9 +673,10 @@ route:
 				"service":   "db",
 				"cluster":   "bb",
 			},
-			Receiver: "kafka",
-			GroupKey: "{}/{kafka=\"yes\"}:{alertname=\"HighLatency\", cluster=\"bb\", service=\"db\"}",
-			RouteID:  "{}/{kafka=\"yes\"}/2",
+			Receiver:  "kafka",
+			GroupKey:  "{}/{kafka=\"yes\"}:{alertname=\"HighLatency\", cluster=\"bb\", service=\"db\"}",
+			RouteID:   "{}/{kafka=\"yes\"}/2",
+			RouteOpts: &route.Routes[2].RouteOpts,
 		},
 		&AlertGroup{
 			Alerts: []*types.Alert{inputAlerts[5]},
@@ -493,9 +685,10 @@ route:
 				"service":   "db",
 				"cluster":   "bb",
 			},
-			Receiver: "prod",
-			GroupKey: "{}/{env=\"prod\"}:{alertname=\"HighLatency\", cluster=\"bb\", service=\"db\"}",
-			RouteID:  "{}/{env=\"prod\"}/1",
+			Receiver:  "prod",
+			GroupKey:  "{}/{env=\"prod\"}:{alertname=\"HighLatency\", cluster=\"bb\", service=\"db\"}",
+			RouteID:   "{}/{env=\"prod\"}/1",
+			RouteOpts: &route.Routes[1].RouteOpts,
 		},
 	}, alertGroups)
 	require.Equal(t, map[model.Fingerprint][]string{
@@ -547,7 +740,7 @@ route:
 	}
 	defer alerts.Close()
 
-	timeout := func(d time.Duration) time.Duration { return time.Duration(0) }
+	timeout := func(context.Context, time.Duration) time.Duration { return time.Duration(0) }
 	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)}
 	lim := limits{groups: 6}
 	m := NewDispatcherMetrics(true, prometheus.NewRegistry())
@@ -605,6 +798,144 @@ route:
 	require.Len(t, alertGroups, 6)
 }
 
+func TestGroupByLimitOverflow(t *testing.T) {
+	confData := `receivers:
+- name: 'prod'
+
+route:
+  group_by: ['instance']
+  group_by_limit: 2
+  group_wait: 10ms
+  group_interval: 10ms
+  receiver: 'prod'`
+	conf, err := config.Load(confData)
+	require.NoError(t, err)
+
+	logger := promslog.NewNopLogger()
+	route := NewRoute(conf.Route, nil)
+	marker := types.NewMarker(prometheus.NewRegistry())
+	alerts, err := mem.NewAlerts(context.Background(), marker, time.Hour, nil, logger, nil)
+	require.NoError(t, err)
+	defer alerts.Close()
+
+	timeout := func(context.Context, time.Duration) time.Duration { return time.Duration(0) }
+	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)}
+	m := NewDispatcherMetrics(false, prometheus.NewRegistry())
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, m)
+	go dispatcher.Run()
+	defer dispatcher.Stop()
+
+	inputAlerts := []*types.Alert{
+		newAlert(model.LabelSet{"alertname": "HighLatency", "instance": "inst1"}),
+		newAlert(model.LabelSet{"alertname": "HighLatency", "instance": "inst2"}),
+		newAlert(model.LabelSet{"alertname": "HighLatency", "instance": "inst3"}),
+		newAlert(model.LabelSet{"alertname": "HighLatency", "instance": "inst4"}),
+	}
+	require.NoError(t, alerts.Put(inputAlerts...))
+
+	// Let alerts get processed.
+	for i := 0; len(recorder.Alerts()) != 4 && i < 10; i++ {
+		time.Sleep(200 * time.Millisecond)
+	}
+	require.Len(t, recorder.Alerts(), 4)
+	require.Equal(t, 2.0, testutil.ToFloat64(m.groupByLimitOverflow))
+
+	routeFilter := func(*Route) bool { return true }
+	alertFilter := func(*types.Alert, time.Time) bool { return true }
+	alertGroups, _ := dispatcher.Groups(routeFilter, alertFilter)
+	require.Len(t, alertGroups, 3)
+
+	// The overflow group has its own group labels and holds the alerts which
+	// exceeded the limit with a warning annotation.
+	var overflow *AlertGroup
+	for _, ag := range alertGroups {
+		if ag.Labels.Equal(overflowGroupLabels) {
+			overflow = ag
+		}
+	}
+	require.NotNil(t, overflow)
+	require.Len(t, overflow.Alerts, 2)
+	for _, a := range overflow.Alerts {
+		require.Contains(t, a.Annotations, model.LabelName(overflowAnnotation))
+	}
+	// The original alerts aren't modified.
+	for _, a := range inputAlerts {
+		require.NotContains(t, a.Annotations, model.LabelName(overflowAnnotation))
+	}
+
+	// Updates of alerts in the overflow group stay in the overflow group.
+	updated := newAlert(overflow.Alerts[0].Labels)
+	updated.UpdatedAt = t0.Add(time.Second)
+	require.NoError(t, alerts.Put(updated))
+	for i := 0; testutil.ToFloat64(m.groupByLimitOverflow) != 3 && i < 10; i++ {
+		time.Sleep(200 * time.Millisecond)
+	}
+	require.Equal(t, 3.0, testutil.ToFloat64(m.groupByLimitOverflow))
+	alertGroups, _ = dispatcher.Groups(routeFilter, alertFilter)
+	require.Len(t, alertGroups, 3)
+}
+
+func TestGroupByLimitOverflowWithoutGroupByLabels(t *testing.T) {
+	confData := `receivers:
+- name: 'prod'
+
+route:
+  group_by: ['instance']
+  group_by_limit: 2
+  group_wait: 10ms
+  group_interval: 10ms
+  receiver: 'prod'`
+	conf, err := config.Load(confData)
+	require.NoError(t, err)
+
+	logger := promslog.NewNopLogger()
+	route := NewRoute(conf.Route, nil)
+	marker := types.NewMarker(prometheus.NewRegistry())
+	alerts, err := mem.NewAlerts(context.Background(), marker, time.Hour, nil, logger, nil)
+	require.NoError(t, err)
+	defer alerts.Close()
+
+	timeout := func(context.Context, time.Duration) time.Duration { return time.Duration(0) }
+	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)}
+	m := NewDispatcherMetrics(false, prometheus.NewRegistry())
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, m)
+	go dispatcher.Run()
+	defer dispatcher.Stop()
+
+	// The alert without the instance label is in a regular group without
+	// group labels, which must not be taken for the overflow group.
+	require.NoError(t, alerts.Put(newAlert(model.LabelSet{"alertname": "HighLatency"})))
+	for i := 0; len(recorder.Alerts()) != 1 && i < 10; i++ {
+		time.Sleep(200 * time.Millisecond)
+	}
+	require.NoError(t, alerts.Put(
+		newAlert(model.LabelSet{"alertname": "HighLatency", "instance": "inst1"}),
+		newAlert(model.LabelSet{"alertname": "HighLatency", "instance": "inst2"}),
+		newAlert(model.LabelSet{"alertname": "HighLatency", "instance": "inst3"}),
+	))
+	for i := 0; len(recorder.Alerts()) != 4 && i < 10; i++ {
+		time.Sleep(200 * time.Millisecond)
+	}
+	require.Len(t, recorder.Alerts(), 4)
+	require.Equal(t, 2.0, testutil.ToFloat64(m.groupByLimitOverflow))
+
+	routeFilter := func(*Route) bool { return true }
+	alertFilter := func(*types.Alert, time.Time) bool { return true }
+	alertGroups, _ := dispatcher.Groups(routeFilter, alertFilter)
+	require.Len(t, alertGroups, 3)
+	for _, ag := range alertGroups {
+		switch {
+		case len(ag.Labels) == 0:
+			require.Len(t, ag.Alerts, 1)
+			require.NotContains(t, ag.Alerts[0].Annotations, model.LabelName(overflowAnnotation))
+		case ag.Labels.Equal(overflowGroupLabels):
+			require.Len(t, ag.Alerts, 2)
+		default:
+			require.Len(t, ag.Alerts, 1)
+		}
+	}
+}
+
 type recordStage struct {
 	mtx    sync.RWMutex
 	alerts map[string]map[model.Fingerprint]*types.Alert
@@ -659,6 +990,132 @@ func newAlert(labels model.LabelSet) *types.Alert {
 	}
 }
 
+func TestReceiverFromLabel(t *testing.T) {
+	confData := `receivers:
+- name: 'default'
+- name: 'team-a'
+- name: 'team-b'
+
+route:
+  group_by: ['alertname']
+  group_wait: 10ms
+  group_interval: 10ms
+  receiver: 'default'
+  receiver_from_label: notify_to
+  allowed_receivers: ['team-a', 'team-b']`
+	conf, err := config.Load(confData)
+	require.NoError(t, err)
+
+	logger := promslog.NewNopLogger()
+	route := NewRoute(conf.Route, nil)
+	marker := types.NewMarker(prometheus.NewRegistry())
+	alerts, err := mem.NewAlerts(context.Background(), marker, time.Hour, nil, logger, nil)
+	require.NoError(t, err)
+	defer alerts.Close()
+
+	timeout := func(context.Context, time.Duration) time.Duration { return time.Duration(0) }
+	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)}
+	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, NewDispatcherMetrics(false, prometheus.NewRegistry()))
+	go dispatcher.Run()
+	defer dispatcher.Stop()
+
+	require.NoError(t, alerts.Put(
+		newAlert(model.LabelSet{"alertname": "HighLatency", "notify_to": "team-a"}),
+		newAlert(model.LabelSet{"alertname": "HighLatency", "notify_to": "team-b"}),
+		newAlert(model.LabelSet{"alertname": "HighLatency", "notify_to": "team-c"}),
+		newAlert(model.LabelSet{"alertname": "HighLatency"}),
+	))
+	for i := 0; len(recorder.Alerts()) != 4 && i < 10; i++ {
+		time.Sleep(200 * time.Millisecond)
+	}
+	require.Len(t, recorder.Alerts(), 4)
+
+	alertGroups, receivers := dispatcher.Groups(
+		func(*Route) bool { return true },
+		func(*types.Alert, time.Time) bool { return true },
+	)
+	got := map[string]string{}
+	for _, ag := range alertGroups {
+		got[string(ag.Labels["notify_to"])] = ag.Receiver
+	}
+	require.Equal(t, map[string]string{
+		"team-a": "team-a",
+		"team-b": "team-b",
+		"team-c": "default",
+		"":       "default",
+	}, got)
+	fp := model.LabelSet{"alertname": "HighLatency", "notify_to": "team-a"}.Fingerprint()
+	require.Equal(t, []string{"team-a"}, receivers[fp])
+}
+
+// concurrencyStage records the maximum number of concurrent executions.
+type concurrencyStage struct {
+	mtx      sync.Mutex
+	current  int
+	max      int
+	executed int
+}
+
+func (s *concurrencyStage) Exec(ctx context.Context, l *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	s.mtx.Lock()
+	s.current++
+	s.max = max(s.max, s.current)
+	s.mtx.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	s.mtx.Lock()
+	s.current--
+	s.executed++
+	s.mtx.Unlock()
+	return ctx, alerts, nil
+}
+
+func TestMaxConcurrentFlushes(t *testing.T) {
+	confData := `receivers:
+- name: 'prod'
+
+route:
+  group_by: ['instance']
+  group_wait: 10ms
+  group_interval: 1h
+  receiver: 'prod'`
+	conf, err := config.Load(confData)
+	require.NoError(t, err)
+
+	logger := promslog.NewNopLogger()
+	route := NewRoute(conf.Route, nil)
+	marker := types.NewMarker(prometheus.NewRegistry())
+	alerts, err := mem.NewAlerts(context.Background(), marker, time.Hour, nil, logger, nil)
+	require.NoError(t, err)
+	defer alerts.Close()
+
+	stage := &concurrencyStage{}
+	m := NewDispatcherMetrics(false, prometheus.NewRegistry())
+	dispatcher := NewDispatcher(alerts, route, stage, marker, nil, nil, logger, m)
+	dispatcher.SetMaxConcurrentFlushes(2)
+	go dispatcher.Run()
+	defer dispatcher.Stop()
+
+	for i := 0; i < 6; i++ {
+		require.NoError(t, alerts.Put(newAlert(model.LabelSet{"alertname": "HighLatency", "instance": model.LabelValue(fmt.Sprintf("inst%d", i))})))
+	}
+	require.Eventually(t, func() bool {
+		stage.mtx.Lock()
+		defer stage.mtx.Unlock()
+		return stage.executed == 6
+	}, 5*time.Second, 10*time.Millisecond)
+
+	stage.mtx.Lock()
+	require.Equal(t, 2, stage.max)
+	stage.mtx.Unlock()
+	require.Equal(t, 0.0, testutil.ToFloat64(m.flushesQueued))
+	require.Equal(t, 0.0, testutil.ToFloat64(m.flushesInProgress))
+	var waits dto.Metric
+	require.NoError(t, m.flushWaitDuration.Write(&waits))
+	require.Equal(t, uint64(6), waits.GetHistogram().GetSampleCount())
+}
+
 func TestDispatcherRace(t *testing.T) {
 	logger := promslog.NewNopLogger()
 	marker := types.NewMarker(prometheus.NewRegistry())
@@ -668,7 +1125,7 @@ func TestDispatcherRace(t *testing.T) {
 	}
 	defer alerts.Close()
 
-	timeout := func(d time.Duration) time.Duration { return time.Duration(0) }
+	timeout := func(context.Context, time.Duration) time.Duration { return time.Duration(0) }
 	dispatcher := NewDispatcher(alerts, nil, nil, marker, timeout, nil, logger, NewDispatcherMetrics(false, prometheus.NewRegistry()))
 	go dispatcher.Run()
 	dispatcher.Stop()
@@ -695,7 +1152,7 @@ func TestDispatcherRaceOnFirstAlertNotDeliveredWhenGroupWaitIsZero(t *testing.T)
 		},
 	}
 
-	timeout := func(d time.Duration) time.Duration { return d }
+	timeout := func(_ context.Context, d time.Duration) time.Duration { return d }
 	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)}
 	dispatcher := NewDispatcher(alerts, route, recorder, marker, timeout, nil, logger, NewDispatcherMetrics(false, prometheus.NewRegistry()))
 	go dispatcher.Run()
@@ -745,7 +1202,7 @@ func TestDispatcher_DoMaintenance(t *testing.T) {
 			GroupInterval: 5 * time.Minute, // Should never hit in this test.
 		},
 	}
-	timeout := func(d time.Duration) time.Duration { return d }
+	timeout := func(_ context.Context, d time.Duration) time.Duration { return d }
 	recorder := &recordStage{alerts: make(map[string]map[model.Fingerprint]*types.Alert)}
 
 	ctx := context.Background()
@@ -773,3 +1230,115 @@ func TestDispatcher_DoMaintenance(t *testing.T) {
 	require.False(t, isMuted)
 	require.Empty(t, mutedBy)
 }
+
+type blockingStage struct {
+	started chan struct{}
+	release chan struct{}
+	calls   atomic.Int32
+	errs    chan error
+}
+
+func (s *blockingStage) Exec(ctx context.Context, _ *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	if s.calls.Add(1) == 1 {
+		close(s.started)
+	}
+	<-s.release
+	s.errs <- ctx.Err()
+	return ctx, alerts, nil
+}
+
+func TestDispatcherDrain(t *testing.T) {
+	logger := promslog.NewNopLogger()
+	marker := types.NewMarker(prometheus.NewRegistry())
+	alerts, err := mem.NewAlerts(context.Background(), marker, time.Hour, nil, logger, nil)
+	require.NoError(t, err)
+	defer alerts.Close()
+
+	route := &Route{
+		RouteOpts: RouteOpts{
+			Receiver:       "default",
+			GroupBy:        map[model.LabelName]struct{}{"alertname": {}},
+			GroupWait:      0,
+			GroupInterval:  10 * time.Millisecond,
+			RepeatInterval: time.Hour,
+		},
+	}
+
+	stage := &blockingStage{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+		errs:    make(chan error, 10),
+	}
+	timeout := func(context.Context, time.Duration) time.Duration { return time.Minute }
+	dispatcher := NewDispatcher(alerts, route, stage, marker, timeout, nil, logger, NewDispatcherMetrics(false, prometheus.NewRegistry()))
+	go dispatcher.Run()
+
+	require.NoError(t, alerts.Put(newAlert(model.LabelSet{"alertname": "a"})))
+	<-stage.started
+
+	drained := make(chan struct{})
+	go func() {
+		dispatcher.Drain(context.Background())
+		close(drained)
+	}()
+
+	// Let the group interval elapse a few times while the flush is in flight.
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case <-drained:
+		t.Fatal("drain returned before the in-flight notification finished")
+	default:
+	}
+
+	close(stage.release)
+	<-drained
+
+	// The in-flight notification completed without being canceled and no
+	// other flush started.
+	require.NoError(t, <-stage.errs)
+	require.Equal(t, int32(1), stage.calls.Load())
+}
+
+func TestDispatcherDrainTimeout(t *testing.T) {
+	logger := promslog.NewNopLogger()
+	marker := types.NewMarker(prometheus.NewRegistry())
+	alerts, err := mem.NewAlerts(context.Background(), marker, time.Hour, nil, logger, nil)
+	require.NoError(t, err)
+	defer alerts.Close()
+
+	route := &Route{
+		RouteOpts: RouteOpts{
+			Receiver:       "default",
+			GroupBy:        map[model.LabelName]struct{}{"alertname": {}},
+			GroupWait:      0,
+			GroupInterval:  time.Hour,
+			RepeatInterval: time.Hour,
+		},
+	}
+
+	stage := &blockingStage{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+		errs:    make(chan error, 10),
+	}
+	timeout := func(_ context.Context, d time.Duration) time.Duration { return d }
+	dispatcher := NewDispatcher(alerts, route, stage, marker, timeout, nil, logger, NewDispatcherMetrics(false, prometheus.NewRegistry()))
+	go dispatcher.Run()
+
+	require.NoError(t, alerts.Put(newAlert(model.LabelSet{"alertname": "a"})))
+	<-stage.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// The stage only returns once released so Drain must give up on its own
+	// and cancel the notification.
+	go func() {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		close(stage.release)
+	}()
+	dispatcher.Drain(ctx)
+
+	require.ErrorIs(t, <-stage.errs, context.Canceled)
+}
@@ -16,6 +16,7 @@ package dispatch
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -67,6 +68,10 @@ func NewRoute(cr *config.Route, parent *Route) *Route {
 	if cr.Receiver != "" {
 		opts.Receiver = cr.Receiver
 	}
+	if cr.ReceiverFromLabel != "" {
+		opts.ReceiverFromLabel = model.LabelName(cr.ReceiverFromLabel)
+		opts.AllowedReceivers = cr.AllowedReceivers
+	}
 
 	if cr.GroupBy != nil {
 		opts.GroupBy = map[model.LabelName]struct{}{}
@@ -80,15 +85,45 @@ func NewRoute(cr *config.Route, parent *Route) *Route {
 		}
 	}
 
+	if cr.GroupByLimit > 0 {
+		opts.GroupByLimit = cr.GroupByLimit
+	}
+	if cr.GroupAlertLimit > 0 {
+		opts.GroupAlertLimit = cr.GroupAlertLimit
+	}
+	if len(cr.CollapseBy) > 0 {
+		opts.CollapseBy = cr.CollapseBy
+	}
+
+	if len(cr.AlertOrder) > 0 {
+		opts.AlertOrder = cr.AlertOrder
+	}
+	if len(cr.SeverityOrder) > 0 {
+		opts.SeverityOrder = cr.SeverityOrder
+	}
+
 	if cr.GroupWait != nil {
 		opts.GroupWait = time.Duration(*cr.GroupWait)
 	}
 	if cr.GroupInterval != nil {
 		opts.GroupInterval = time.Duration(*cr.GroupInterval)
 	}
+	if cr.NotificationDeadline != nil {
+		opts.NotificationDeadline = time.Duration(*cr.NotificationDeadline)
+	}
+	if cr.PeerTimeout != nil {
+		d := time.Duration(*cr.PeerTimeout)
+		opts.PeerTimeout = &d
+	}
 	if cr.RepeatInterval != nil {
 		opts.RepeatInterval = time.Duration(*cr.RepeatInterval)
 	}
+	if len(cr.Escalations) > 0 {
+		opts.Escalations = make([]Escalation, 0, len(cr.Escalations))
+		for _, e := range cr.Escalations {
+			opts.Escalations = append(opts.Escalations, Escalation{After: time.Duration(e.After), Receiver: e.Receiver})
+		}
+	}
 
 	// Build matchers.
 	var matchers labels.Matchers
@@ -133,6 +168,64 @@ func NewRoute(cr *config.Route, parent *Route) *Route {
 	return route
 }
 
+// EffectiveConfig returns a copy of the configuration of the routing tree
+// in which every route explicitly sets the options it inherits from its
+// parent route or from the defaults.
+func EffectiveConfig(cr *config.Route) *config.Route {
+	return effectiveConfig(cr, NewRoute(cr, nil))
+}
+
+func effectiveConfig(cr *config.Route, r *Route) *config.Route {
+	var (
+		res            = *cr
+		groupWait      = model.Duration(r.RouteOpts.GroupWait)
+		groupInterval  = model.Duration(r.RouteOpts.GroupInterval)
+		repeatInterval = model.Duration(r.RouteOpts.RepeatInterval)
+	)
+	res.Receiver = r.RouteOpts.Receiver
+	res.ReceiverFromLabel = string(r.RouteOpts.ReceiverFromLabel)
+	res.AllowedReceivers = r.RouteOpts.AllowedReceivers
+	res.GroupBy, res.GroupByStr = nil, nil
+	res.GroupByAll = r.RouteOpts.GroupByAll
+	if res.GroupByAll {
+		res.GroupByStr = []string{"..."}
+	} else {
+		for ln := range r.RouteOpts.GroupBy {
+			res.GroupBy = append(res.GroupBy, ln)
+		}
+		sort.Slice(res.GroupBy, func(i, j int) bool { return res.GroupBy[i] < res.GroupBy[j] })
+		for _, ln := range res.GroupBy {
+			res.GroupByStr = append(res.GroupByStr, string(ln))
+		}
+	}
+	res.GroupByLimit = r.RouteOpts.GroupByLimit
+	res.GroupAlertLimit = r.RouteOpts.GroupAlertLimit
+	res.CollapseBy = r.RouteOpts.CollapseBy
+	res.AlertOrder = r.RouteOpts.AlertOrder
+	res.SeverityOrder = r.RouteOpts.SeverityOrder
+	res.GroupWait = &groupWait
+	res.GroupInterval = &groupInterval
+	res.RepeatInterval = &repeatInterval
+	if d := r.RouteOpts.NotificationDeadline; d > 0 || cr.NotificationDeadline != nil {
+		deadline := model.Duration(d)
+		res.NotificationDeadline = &deadline
+	}
+	if r.RouteOpts.PeerTimeout != nil {
+		timeout := model.Duration(*r.RouteOpts.PeerTimeout)
+		res.PeerTimeout = &timeout
+	}
+	res.Escalations = nil
+	for _, e := range r.RouteOpts.Escalations {
+		res.Escalations = append(res.Escalations, config.Escalation{After: model.Duration(e.After), Receiver: e.Receiver})
+	}
+
+	res.Routes = make([]*config.Route, 0, len(cr.Routes))
+	for i, child := range cr.Routes {
+		res.Routes = append(res.Routes, effectiveConfig(child, r.Routes[i]))
+	}
+	return &res
+}
+
 // NewRoutes returns a slice of routes.
 func NewRoutes(croutes []*config.Route, parent *Route) []*Route {
 	res := []*Route{}
@@ -219,23 +312,84 @@ type RouteOpts struct {
 	// The identifier of the associated notification configuration.
 	Receiver string
 
+	// The label whose value selects the receiver among AllowedReceivers
+	// instead of Receiver.
+	ReceiverFromLabel model.LabelName
+	AllowedReceivers  []string
+
 	// What labels to group alerts by for notifications.
 	GroupBy map[model.LabelName]struct{}
 
 	// Use all alert labels to group.
 	GroupByAll bool
 
+	// The maximum number of aggregation groups of the route, 0 means
+	// unlimited. Alerts exceeding it are collapsed into an overflow group.
+	GroupByLimit int
+
+	// The maximum number of alerts in the notifications of a group, 0 means
+	// unlimited. The other alerts are only counted in the notifications.
+	GroupAlertLimit int
+
+	// The annotations which, with the alertname, identify the alerts
+	// collapsed into a single one in notifications.
+	CollapseBy []string
+
+	// The keys to order the alerts of notifications by, see
+	// config.AlertOrderKeys. A "-" prefix reverses the order of a key.
+	AlertOrder []string
+
+	// The values of the severity label from the most to the least
+	// important. DefaultSeverityOrder is used if empty.
+	SeverityOrder []string
+
 	// How long to wait to group matching alerts before sending
 	// a notification.
 	GroupWait      time.Duration
 	GroupInterval  time.Duration
 	RepeatInterval time.Duration
 
+	// The time within which firing alerts should be notified after they were
+	// received, 0 means no deadline.
+	NotificationDeadline time.Duration
+
+	// The time to wait between the peers of the cluster to send
+	// notifications, nil means the --cluster.peer-timeout flag.
+	PeerTimeout *time.Duration
+
 	// A list of time intervals for which the route is muted.
 	MuteTimeIntervals []string
 
 	// A list of time intervals for which the route is active.
 	ActiveTimeIntervals []string
+
+	// The receivers additionally notified about groups which are still
+	// firing after a while, in increasing order of their delays.
+	Escalations []Escalation
+}
+
+// Escalation is a receiver notified about the groups which have been firing
+// for longer than After.
+type Escalation struct {
+	After    time.Duration `json:"after"`
+	Receiver string        `json:"receiver"`
+}
+
+// ReceiverFor returns the receiver of the alerts with the given labels.
+func (ro *RouteOpts) ReceiverFor(lset model.LabelSet) string {
+	if ro.ReceiverFromLabel == "" {
+		return ro.Receiver
+	}
+	if v, ok := lset[ro.ReceiverFromLabel]; ok && slices.Contains(ro.AllowedReceivers, string(v)) {
+		return string(v)
+	}
+	return ro.Receiver
+}
+
+// Receivers returns all the receivers the alerts of the route may be sent
+// to, escalations excluded.
+func (ro *RouteOpts) Receivers() []string {
+	return append([]string{ro.Receiver}, ro.AllowedReceivers...)
 }
 
 func (ro *RouteOpts) String() string {
@@ -250,18 +404,38 @@ func (ro *RouteOpts) String() string {
 // MarshalJSON returns a JSON representation of the routing options.
 func (ro *RouteOpts) MarshalJSON() ([]byte, error) {
 	v := struct {
-		Receiver       string           `json:"receiver"`
-		GroupBy        model.LabelNames `json:"groupBy"`
-		GroupByAll     bool             `json:"groupByAll"`
-		GroupWait      time.Duration    `json:"groupWait"`
-		GroupInterval  time.Duration    `json:"groupInterval"`
-		RepeatInterval time.Duration    `json:"repeatInterval"`
+		Receiver             string           `json:"receiver"`
+		ReceiverFromLabel    model.LabelName  `json:"receiverFromLabel,omitempty"`
+		AllowedReceivers     []string         `json:"allowedReceivers,omitempty"`
+		GroupBy              model.LabelNames `json:"groupBy"`
+		GroupByAll           bool             `json:"groupByAll"`
+		GroupWait            time.Duration    `json:"groupWait"`
+		GroupInterval        time.Duration    `json:"groupInterval"`
+		RepeatInterval       time.Duration    `json:"repeatInterval"`
+		GroupByLimit         int              `json:"groupByLimit,omitempty"`
+		GroupAlertLimit      int              `json:"groupAlertLimit,omitempty"`
+		CollapseBy           []string         `json:"collapseBy,omitempty"`
+		NotificationDeadline time.Duration    `json:"notificationDeadline,omitempty"`
+		PeerTimeout          *time.Duration   `json:"peerTimeout,omitempty"`
+		AlertOrder           []string         `json:"alertOrder,omitempty"`
+		SeverityOrder        []string         `json:"severityOrder,omitempty"`
+		Escalations          []Escalation     `json:"escalations,omitempty"`
 	}{
-		Receiver:       ro.Receiver,
-		GroupByAll:     ro.GroupByAll,
-		GroupWait:      ro.GroupWait,
-		GroupInterval:  ro.GroupInterval,
-		RepeatInterval: ro.RepeatInterval,
+		Receiver:             ro.Receiver,
+		ReceiverFromLabel:    ro.ReceiverFromLabel,
+		AllowedReceivers:     ro.AllowedReceivers,
+		GroupByAll:           ro.GroupByAll,
+		GroupWait:            ro.GroupWait,
+		GroupInterval:        ro.GroupInterval,
+		RepeatInterval:       ro.RepeatInterval,
+		GroupByLimit:         ro.GroupByLimit,
+		GroupAlertLimit:      ro.GroupAlertLimit,
+		CollapseBy:           ro.CollapseBy,
+		NotificationDeadline: ro.NotificationDeadline,
+		PeerTimeout:          ro.PeerTimeout,
+		AlertOrder:           ro.AlertOrder,
+		SeverityOrder:        ro.SeverityOrder,
+		Escalations:          ro.Escalations,
 	}
 	for ln := range ro.GroupBy {
 		v.GroupBy = append(v.GroupBy, ln)
@@ -383,6 +383,35 @@ routes:
 	require.False(t, child2.RouteOpts.GroupByAll)
 }
 
+func TestInheritParentPeerTimeout(t *testing.T) {
+	in := `
+routes:
+- match:
+    severity: 'page'
+  peer_timeout: 0s
+
+  routes:
+  - match:
+      env: 'child1'
+
+  - match:
+      env: 'child2'
+    peer_timeout: 1m
+`
+
+	var ctree config.Route
+	require.NoError(t, yaml.UnmarshalStrict([]byte(in), &ctree))
+
+	tree := NewRoute(&ctree, nil)
+	parent := tree.Routes[0]
+	child1 := parent.Routes[0]
+	child2 := parent.Routes[1]
+	require.Nil(t, tree.RouteOpts.PeerTimeout)
+	require.Equal(t, time.Duration(0), *parent.RouteOpts.PeerTimeout)
+	require.Equal(t, time.Duration(0), *child1.RouteOpts.PeerTimeout)
+	require.Equal(t, time.Minute, *child2.RouteOpts.PeerTimeout)
+}
+
 func TestRouteMatchers(t *testing.T) {
 	in := `
 receiver: 'notify-def'
@@ -920,3 +949,84 @@ routes:
 	})
 	require.ElementsMatch(t, actual, expected)
 }
+
+func TestEffectiveConfig(t *testing.T) {
+	in := `
+receiver: 'notify-def'
+group_by: ['cluster', 'alertname']
+
+routes:
+- matchers: ['owner="team-A"']
+  receiver: 'notify-A'
+  group_wait: 1m
+  escalations:
+  - after: 30m
+    receiver: 'notify-lead'
+  routes:
+  - matchers: ['env="testing"']
+    group_by: ['...']
+  - matchers: ['env="production"']
+    repeat_interval: 1h
+    mute_time_intervals: ['offhours']
+`
+	var cr config.Route
+	require.NoError(t, yaml.UnmarshalStrict([]byte(in), &cr))
+
+	out, err := yaml.Marshal(EffectiveConfig(&cr))
+	require.NoError(t, err)
+	require.Equal(t, `receiver: notify-def
+group_by:
+- alertname
+- cluster
+continue: false
+routes:
+- receiver: notify-A
+  group_by:
+  - alertname
+  - cluster
+  matchers:
+  - owner="team-A"
+  continue: false
+  routes:
+  - receiver: notify-A
+    group_by:
+    - '...'
+    matchers:
+    - env="testing"
+    continue: false
+    group_wait: 1m
+    group_interval: 5m
+    repeat_interval: 4h
+    escalations:
+    - after: 30m
+      receiver: notify-lead
+  - receiver: notify-A
+    group_by:
+    - alertname
+    - cluster
+    matchers:
+    - env="production"
+    mute_time_intervals:
+    - offhours
+    continue: false
+    group_wait: 1m
+    group_interval: 5m
+    repeat_interval: 1h
+    escalations:
+    - after: 30m
+      receiver: notify-lead
+  group_wait: 1m
+  group_interval: 5m
+  repeat_interval: 4h
+  escalations:
+  - after: 30m
+    receiver: notify-lead
+group_wait: 30s
+group_interval: 5m
+repeat_interval: 4h
+`, string(out))
+
+	// The configuration of the routing tree isn't modified.
+	require.Nil(t, cr.GroupWait)
+	require.Nil(t, cr.Routes[0].Routes[1].GroupBy)
+}
@@ -0,0 +1,161 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatch
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// Simulator routes alerts into aggregation groups and flushes them through a
+// notification stage like the Dispatcher, but on a simulated clock: groups
+// are only flushed when the clock is advanced past their group_wait or
+// group_interval. This allows to test routing trees deterministically.
+type Simulator struct {
+	route  *Route
+	stage  notify.Stage
+	logger *slog.Logger
+
+	normalizers []config.GroupLabelNormalizer
+
+	now    time.Time
+	groups map[*Route]map[model.Fingerprint]*simulatedGroup
+}
+
+// simulatedGroup is an aggregation group flushed on the simulated clock.
+type simulatedGroup struct {
+	*aggrGroup
+	// next is the time of the next flush.
+	next time.Time
+}
+
+// NewSimulator returns a new Simulator whose clock starts at the given time.
+func NewSimulator(r *Route, s notify.Stage, start time.Time, l *slog.Logger) *Simulator {
+	return &Simulator{
+		route:  r,
+		stage:  s,
+		logger: l.With("component", "simulator"),
+		now:    start,
+		groups: map[*Route]map[model.Fingerprint]*simulatedGroup{},
+	}
+}
+
+// SetGroupLabelNormalizers sets the normalizers of the label values of the
+// alerts applied before grouping them.
+func (s *Simulator) SetGroupLabelNormalizers(normalizers []config.GroupLabelNormalizer) {
+	s.normalizers = normalizers
+}
+
+// Now returns the time of the simulated clock.
+func (s *Simulator) Now() time.Time {
+	return s.now
+}
+
+// Insert routes the alerts into their aggregation groups at the current time
+// of the simulated clock.
+func (s *Simulator) Insert(alerts ...*types.Alert) {
+	for _, a := range alerts {
+		for _, r := range s.route.Match(a.Labels) {
+			s.insert(a, r)
+		}
+	}
+}
+
+func (s *Simulator) insert(alert *types.Alert, route *Route) {
+	groupLabels := getGroupLabels(alert, route, s.normalizers)
+	fp := groupLabels.Fingerprint()
+
+	routeGroups, ok := s.groups[route]
+	if !ok {
+		routeGroups = map[model.Fingerprint]*simulatedGroup{}
+		s.groups[route] = routeGroups
+	}
+	g, ok := routeGroups[fp]
+	if !ok {
+		g = &simulatedGroup{
+			aggrGroup: newAggrGroup(context.Background(), groupLabels, route, nil, s.logger),
+			next:      s.now.Add(route.RouteOpts.GroupWait),
+		}
+		// The group is flushed on the simulated clock, not by its timer.
+		g.aggrGroup.next.Stop()
+		routeGroups[fp] = g
+	}
+
+	if err := g.alerts.Set(alert); err != nil {
+		s.logger.Error("error on set alert", "err", err)
+	}
+	// Immediately flush if the wait duration for this alert is already over.
+	if !g.hasFlushed && alert.StartsAt.Add(route.RouteOpts.GroupWait).Before(s.now) {
+		g.next = s.now
+	}
+}
+
+// Advance moves the simulated clock to the given time, flushing the
+// aggregation groups in the order of their flush times on the way. Groups
+// due at the same time are flushed in the order of their group keys. Groups
+// left empty by a flush are removed.
+func (s *Simulator) Advance(to time.Time) {
+	for {
+		route, fp, g := s.nextFlush(to)
+		if g == nil {
+			break
+		}
+		s.now = g.next
+		g.next = s.now.Add(g.opts.GroupInterval)
+		g.hasFlushed = true
+
+		ctx := g.notifyContext(context.Background(), s.now)
+		g.flush(s.now, func(alerts ...*types.Alert) bool {
+			_, _, err := s.stage.Exec(ctx, s.logger, alerts...)
+			if err != nil {
+				s.logger.Error("Notify for alerts failed", "num_alerts", len(alerts), "err", err)
+			}
+			return err == nil
+		})
+		if g.empty() {
+			delete(s.groups[route], fp)
+		}
+	}
+	if to.After(s.now) {
+		s.now = to
+	}
+}
+
+// nextFlush returns the aggregation group to flush first until the given
+// time, nil if none.
+func (s *Simulator) nextFlush(until time.Time) (*Route, model.Fingerprint, *simulatedGroup) {
+	var (
+		route *Route
+		fp    model.Fingerprint
+		next  *simulatedGroup
+	)
+	for r, groups := range s.groups {
+		for f, g := range groups {
+			if g.next.After(until) {
+				continue
+			}
+			if next == nil || g.next.Before(next.next) || (g.next.Equal(next.next) && g.GroupKey() < next.GroupKey()) {
+				route, fp, next = r, f, g
+			}
+		}
+	}
+	return route, fp, next
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatch
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+type simulatedFlush struct {
+	at       time.Duration
+	receiver string
+	firing   int
+	resolved int
+}
+
+type simulatedFlushes struct {
+	start   time.Time
+	flushes []simulatedFlush
+}
+
+func (s *simulatedFlushes) Exec(ctx context.Context, _ *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	now, _ := notify.Now(ctx)
+	receiver, _ := notify.ReceiverName(ctx)
+	f := simulatedFlush{at: now.Sub(s.start), receiver: receiver}
+	for _, a := range alerts {
+		if a.ResolvedAt(now) {
+			f.resolved++
+		} else {
+			f.firing++
+		}
+	}
+	s.flushes = append(s.flushes, f)
+	return ctx, alerts, nil
+}
+
+func TestSimulator(t *testing.T) {
+	conf, err := config.Load(`
+route:
+  receiver: default
+  group_by: [alertname]
+  group_wait: 30s
+  group_interval: 5m
+  routes:
+  - matchers: [team="db"]
+    receiver: db
+receivers:
+- name: default
+- name: db
+`)
+	require.NoError(t, err)
+
+	start := time.Unix(0, 0).UTC()
+	stage := &simulatedFlushes{start: start}
+	sim := NewSimulator(NewRoute(conf.Route, nil), stage, start, promslog.NewNopLogger())
+
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+	alert := func(labels model.LabelSet, startsAt, endsAt time.Time) *types.Alert {
+		return &types.Alert{Alert: model.Alert{Labels: labels, StartsAt: startsAt, EndsAt: endsAt}, UpdatedAt: startsAt}
+	}
+
+	sim.Insert(alert(model.LabelSet{"alertname": "DatabaseDown", "team": "db"}, at(0), at(7*time.Minute)))
+	sim.Advance(at(time.Minute))
+	require.Equal(t, at(time.Minute), sim.Now())
+	require.Equal(t, []simulatedFlush{
+		{at: 30 * time.Second, receiver: "db", firing: 1},
+	}, stage.flushes)
+
+	// Alerts added to a group are flushed at the next group interval, alerts
+	// which started more than group_wait ago in a new group right away.
+	stage.flushes = nil
+	sim.Insert(alert(model.LabelSet{"alertname": "DatabaseDown", "team": "other"}, at(time.Minute), time.Time{}))
+	sim.Insert(alert(model.LabelSet{"alertname": "DiskFull"}, at(0), time.Time{}))
+	sim.Advance(at(6 * time.Minute))
+	require.Equal(t, []simulatedFlush{
+		{at: time.Minute, receiver: "default", firing: 1},
+		{at: time.Minute + 30*time.Second, receiver: "default", firing: 1},
+		{at: 5*time.Minute + 30*time.Second, receiver: "db", firing: 1},
+		{at: 6 * time.Minute, receiver: "default", firing: 1},
+	}, stage.flushes)
+
+	// Resolved alerts are flushed once and removed, as are the groups left
+	// empty.
+	stage.flushes = nil
+	sim.Advance(at(11 * time.Minute))
+	require.Equal(t, []simulatedFlush{
+		{at: 6*time.Minute + 30*time.Second, receiver: "default", firing: 1},
+		{at: 10*time.Minute + 30*time.Second, receiver: "db", resolved: 1},
+		{at: 11 * time.Minute, receiver: "default", firing: 1},
+	}, stage.flushes)
+	require.Empty(t, sim.groups[sim.route.Routes[0]])
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package encryption implements AES-GCM encryption of data written to disk,
+// such as the silences and notification log snapshots.
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// magic prefixes all encrypted data so that it can be told apart from
+// plaintext data written before encryption was enabled.
+var magic = []byte("AMENC\x01")
+
+// ErrMissingKey is returned when encrypted data is read without a key.
+var ErrMissingKey = errors.New("data is encrypted but no encryption key is configured")
+
+// Cipher encrypts and decrypts data with AES-GCM.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// New returns a Cipher for the given 128, 192 or 256 bit AES key.
+func New(key []byte) (*Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// LoadKeyFile returns a Cipher for the hex-encoded key in the given file.
+func LoadKeyFile(filename string) (*Cipher, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil {
+		return nil, fmt.Errorf("decode key from %s: %w", filename, err)
+	}
+	c, err := New(key)
+	if err != nil {
+		return nil, fmt.Errorf("load key from %s: %w", filename, err)
+	}
+	return c, nil
+}
+
+// Encrypt encrypts the plaintext with a random nonce.
+func (c *Cipher) Encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(magic)+len(nonce)+len(plaintext)+c.aead.Overhead())
+	out = append(out, magic...)
+	out = append(out, nonce...)
+	return c.aead.Seal(out, nonce, plaintext, magic), nil
+}
+
+// Decrypt decrypts data created by Encrypt.
+func (c *Cipher) Decrypt(data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return nil, errors.New("data is not encrypted")
+	}
+	data = data[len(magic):]
+	if len(data) < c.aead.NonceSize() {
+		return nil, errors.New("encrypted data is too short")
+	}
+	nonce, ciphertext := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+	return c.aead.Open(nil, nonce, ciphertext, magic)
+}
+
+// IsEncrypted returns true if the data was created by Encrypt.
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, magic)
+}
+
+// Seal encrypts the data if c is not nil. Otherwise the data is returned
+// unchanged.
+func Seal(c *Cipher, data []byte) ([]byte, error) {
+	if c == nil {
+		return data, nil
+	}
+	return c.Encrypt(data)
+}
+
+// Open reads all data from r and decrypts it if it is encrypted. Plaintext
+// data is returned as is, even if c is not nil, so that encryption can be
+// enabled for existing data.
+func Open(c *Cipher, r io.Reader) (io.Reader, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !IsEncrypted(b) {
+		return bytes.NewReader(b), nil
+	}
+	if c == nil {
+		return nil, ErrMissingKey
+	}
+	b, err = c.Decrypt(b)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt: %w", err)
+	}
+	return bytes.NewReader(b), nil
+}
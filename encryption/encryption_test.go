@@ -0,0 +1,104 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package encryption
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	c, err := New(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+
+	plaintext := []byte("alertname=foo")
+	encrypted, err := c.Encrypt(plaintext)
+	require.NoError(t, err)
+	require.True(t, IsEncrypted(encrypted))
+	require.NotContains(t, string(encrypted), "alertname")
+
+	decrypted, err := c.Decrypt(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+
+	// Tampering is detected.
+	encrypted[len(encrypted)-1] ^= 0xff
+	_, err = c.Decrypt(encrypted)
+	require.Error(t, err)
+
+	// A different key cannot decrypt the data.
+	other, err := New(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	encrypted, err = c.Encrypt(plaintext)
+	require.NoError(t, err)
+	_, err = other.Decrypt(encrypted)
+	require.Error(t, err)
+}
+
+func TestSealOpen(t *testing.T) {
+	c, err := New(bytes.Repeat([]byte{1}, 16))
+	require.NoError(t, err)
+	plaintext := []byte("snapshot")
+
+	for _, tc := range []struct {
+		name    string
+		seal    *Cipher
+		open    *Cipher
+		wantErr error
+	}{
+		{name: "no encryption"},
+		{name: "encrypted", seal: c, open: c},
+		{name: "plaintext read with key", open: c},
+		{name: "encrypted read without key", seal: c, wantErr: ErrMissingKey},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sealed, err := Seal(tc.seal, plaintext)
+			require.NoError(t, err)
+			r, err := Open(tc.open, bytes.NewReader(sealed))
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			b, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, plaintext, b)
+		})
+	}
+}
+
+func TestLoadKeyFile(t *testing.T) {
+	dir := t.TempDir()
+
+	good := filepath.Join(dir, "good")
+	require.NoError(t, os.WriteFile(good, []byte(strings.Repeat("ab", 32)+"\n"), 0o600))
+	_, err := LoadKeyFile(good)
+	require.NoError(t, err)
+
+	notHex := filepath.Join(dir, "not-hex")
+	require.NoError(t, os.WriteFile(notHex, []byte("not a key"), 0o600))
+	_, err = LoadKeyFile(notHex)
+	require.ErrorContains(t, err, "decode key")
+
+	badSize := filepath.Join(dir, "bad-size")
+	require.NoError(t, os.WriteFile(badSize, []byte("abcd"), 0o600))
+	_, err = LoadKeyFile(badSize)
+	require.ErrorContains(t, err, "invalid key size")
+}
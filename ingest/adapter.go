@@ -0,0 +1,246 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingest
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// maxAdapterPayloadSize is the maximum size of a payload received by an
+// adapter over HTTP.
+const maxAdapterPayloadSize = 1024 * 1024
+
+// An Adapter translates payloads of a third-party format into alerts.
+type Adapter interface {
+	// Alerts returns the alerts held by the payload. The returned error
+	// wraps ErrInvalidPayload if the payload can never be translated.
+	Alerts(b []byte) ([]*types.Alert, error)
+}
+
+// AdapterFunc is a function implementing Adapter.
+type AdapterFunc func([]byte) ([]*types.Alert, error)
+
+// Alerts implements Adapter.
+func (f AdapterFunc) Alerts(b []byte) ([]*types.Alert, error) {
+	return f(b)
+}
+
+type registeredAdapter struct {
+	adapter Adapter
+	// sns is true if payloads are delivered by SNS HTTP(S) subscriptions.
+	sns bool
+}
+
+var (
+	adaptersMtx sync.RWMutex
+	adapters    = map[string]registeredAdapter{
+		"grafana":        {adapter: AdapterFunc(grafanaAlerts)},
+		"aws-cloudwatch": {adapter: AdapterFunc(cloudWatchAlerts), sns: true},
+		"azure-monitor":  {adapter: AdapterFunc(azureMonitorAlerts)},
+	}
+)
+
+// RegisterAdapter registers an adapter for the given format. Payloads are
+// accepted at /api/v2/ingest/<format> once the format is enabled in the
+// configuration. It panics if the format is already registered.
+func RegisterAdapter(format string, a Adapter) {
+	adaptersMtx.Lock()
+	defer adaptersMtx.Unlock()
+	if _, ok := adapters[format]; ok {
+		panic(fmt.Sprintf("ingest adapter %q already registered", format))
+	}
+	adapters[format] = registeredAdapter{adapter: a}
+	config.RegisterIngestAdapterFormat(format)
+}
+
+// Formats returns the sorted formats of the registered adapters.
+func Formats() []string {
+	adaptersMtx.RLock()
+	defer adaptersMtx.RUnlock()
+	formats := make([]string, 0, len(adapters))
+	for f := range adapters {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+func lookupAdapter(format string) (registeredAdapter, bool) {
+	adaptersMtx.RLock()
+	defer adaptersMtx.RUnlock()
+	a, ok := adapters[format]
+	return a, ok
+}
+
+// Adapters serves the registered adapters over HTTP. Formats are only
+// accepted if they are enabled in the configuration.
+type Adapters struct {
+	sns     SNSOptions
+	put     AlertsFunc
+	logger  *slog.Logger
+	metrics *Metrics
+
+	mtx     sync.RWMutex
+	configs map[string]*config.IngestAdapter
+}
+
+// NewAdapters returns new Adapters. The payloads of the formats delivered by
+// SNS are received with the given options.
+func NewAdapters(o SNSOptions, put AlertsFunc, l *slog.Logger, m *Metrics) *Adapters {
+	return &Adapters{
+		sns:     o,
+		put:     put,
+		logger:  l,
+		metrics: m,
+		configs: map[string]*config.IngestAdapter{},
+	}
+}
+
+// Prepare validates the ingest adapters of the configuration. The returned
+// function applies them. It can be subscribed to a config.Coordinator.
+func (a *Adapters) Prepare(conf *config.Config) (func(), error) {
+	configs := make(map[string]*config.IngestAdapter, len(conf.IngestAdapters))
+	for i := range conf.IngestAdapters {
+		ia := &conf.IngestAdapters[i]
+		if _, ok := lookupAdapter(ia.Format); !ok {
+			return nil, fmt.Errorf("unknown ingest adapter %q, must be one of %s", ia.Format, strings.Join(Formats(), ", "))
+		}
+		configs[ia.Format] = ia
+	}
+	return func() {
+		a.mtx.Lock()
+		a.configs = configs
+		a.mtx.Unlock()
+	}, nil
+}
+
+func (a *Adapters) config(format string) *config.IngestAdapter {
+	a.mtx.RLock()
+	defer a.mtx.RUnlock()
+	return a.configs[format]
+}
+
+// Handler returns the handler receiving payloads of the given format, or nil
+// if no adapter is registered for it.
+func (a *Adapters) Handler(format string) http.Handler {
+	ra, ok := lookupAdapter(format)
+	if !ok {
+		return nil
+	}
+	decode := func(b []byte) ([]*types.Alert, error) {
+		alerts, err := ra.adapter.Alerts(b)
+		if err != nil {
+			return nil, err
+		}
+		if cfg := a.config(format); cfg != nil {
+			mapLabels(alerts, cfg)
+		}
+		return alerts, nil
+	}
+	l := a.logger.With("source", format)
+
+	var h http.Handler = &adapterHandler{format: format, decode: decode, put: a.put, logger: l, metrics: a.metrics}
+	if ra.sns {
+		h = newSNSHandler(a.sns, format, decode, a.put, l, a.metrics)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.config(format) == nil {
+			http.Error(w, fmt.Sprintf("ingest adapter %q is not enabled", format), http.StatusNotFound)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// mapLabels applies the label mappings of cfg to the alerts.
+func mapLabels(alerts []*types.Alert, cfg *config.IngestAdapter) {
+	for _, a := range alerts {
+		for from, to := range cfg.LabelMappings {
+			v, ok := a.Labels[from]
+			if !ok {
+				continue
+			}
+			delete(a.Labels, from)
+			a.Labels[to] = v
+		}
+		for ln, lv := range cfg.Labels {
+			if _, ok := a.Labels[ln]; !ok {
+				a.Labels[ln] = lv
+			}
+		}
+	}
+}
+
+// adapterHandler receives payloads of a format posted directly by the
+// third-party.
+type adapterHandler struct {
+	format  string
+	decode  DecodeFunc
+	put     AlertsFunc
+	logger  *slog.Logger
+	metrics *Metrics
+}
+
+// ServeHTTP implements http.Handler.
+func (h *adapterHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	b, err := io.ReadAll(io.LimitReader(r.Body, maxAdapterPayloadSize+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(b) > maxAdapterPayloadSize {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	err = ingest(b, h.decode, h.put)
+	h.metrics.observe(h.format, err)
+	if err != nil {
+		h.logger.Error("Failed to ingest payload", "err", err)
+		code := http.StatusInternalServerError
+		if permanent(err) {
+			code = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+}
+
+// sanitizeLabelName replaces the characters of s which are invalid in label
+// names with underscores.
+func sanitizeLabelName(s string) model.LabelName {
+	b := []byte(s)
+	for i, c := range b {
+		if !(c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9' && i > 0)) {
+			b[i] = '_'
+		}
+	}
+	return model.LabelName(b)
+}
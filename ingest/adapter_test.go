@@ -0,0 +1,241 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestGrafanaAlerts(t *testing.T) {
+	alerts, err := grafanaAlerts([]byte(`{
+		"title": "[Alerting] High latency",
+		"ruleId": 7,
+		"ruleName": "High latency",
+		"ruleUrl": "https://grafana.example.com/d/abc?viewPanel=2",
+		"state": "alerting",
+		"message": "p99 is above 1s",
+		"tags": {"team": "web", "service.name": "api"}
+	}`))
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	require.Equal(t, model.LabelSet{
+		"alertname":    "High latency",
+		"rule_id":      "7",
+		"team":         "web",
+		"service_name": "api",
+	}, alerts[0].Labels)
+	require.Equal(t, model.LabelSet{
+		"summary":     "[Alerting] High latency",
+		"description": "p99 is above 1s",
+	}, alerts[0].Annotations)
+	require.Equal(t, "https://grafana.example.com/d/abc?viewPanel=2", alerts[0].GeneratorURL)
+	require.True(t, alerts[0].EndsAt.IsZero())
+
+	alerts, err = grafanaAlerts([]byte(`{"ruleName": "High latency", "state": "ok"}`))
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	require.False(t, alerts[0].EndsAt.IsZero())
+
+	alerts, err = grafanaAlerts([]byte(`{"ruleName": "High latency", "state": "paused"}`))
+	require.NoError(t, err)
+	require.Empty(t, alerts)
+
+	_, err = grafanaAlerts([]byte(`{"state": "alerting"}`))
+	require.ErrorIs(t, err, ErrInvalidPayload)
+}
+
+func TestCloudWatchAlerts(t *testing.T) {
+	payload := func(state string) []byte {
+		return []byte(`{
+			"AlarmName": "cpu-high",
+			"AlarmDescription": "CPU above 90%",
+			"AWSAccountId": "123456789012",
+			"NewStateValue": "` + state + `",
+			"NewStateReason": "Threshold Crossed",
+			"StateChangeTime": "2024-01-01T10:00:00.000+0000",
+			"Region": "US East (N. Virginia)",
+			"AlarmArn": "arn:aws:cloudwatch:us-east-1:123456789012:alarm:cpu-high",
+			"Trigger": {
+				"MetricName": "CPUUtilization",
+				"Namespace": "AWS/EC2",
+				"Dimensions": [{"name": "InstanceId", "value": "i-0123"}]
+			}
+		}`)
+	}
+	changed := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	alerts, err := cloudWatchAlerts(payload("ALARM"))
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	require.Equal(t, model.LabelSet{
+		"alertname":            "cpu-high",
+		"aws_account_id":       "123456789012",
+		"region":               "us-east-1",
+		"namespace":            "AWS/EC2",
+		"metric_name":          "CPUUtilization",
+		"dimension_InstanceId": "i-0123",
+	}, alerts[0].Labels)
+	require.True(t, alerts[0].StartsAt.Equal(changed))
+	require.True(t, alerts[0].EndsAt.IsZero())
+	require.Equal(t, "https://console.aws.amazon.com/cloudwatch/home?region=us-east-1#alarmsV2:alarm/cpu-high", alerts[0].GeneratorURL)
+
+	alerts, err = cloudWatchAlerts(payload("OK"))
+	require.NoError(t, err)
+	require.True(t, alerts[0].EndsAt.Equal(changed))
+
+	alerts, err = cloudWatchAlerts(payload("INSUFFICIENT_DATA"))
+	require.NoError(t, err)
+	require.Empty(t, alerts)
+
+	_, err = cloudWatchAlerts([]byte(`{"AlarmName": "cpu-high", "StateChangeTime": "yesterday"}`))
+	require.ErrorIs(t, err, ErrInvalidPayload)
+}
+
+func TestAzureMonitorAlerts(t *testing.T) {
+	payload := func(condition string) []byte {
+		return []byte(`{
+			"schemaId": "azureMonitorCommonAlertSchema",
+			"data": {
+				"essentials": {
+					"alertId": "/subscriptions/1234/providers/Microsoft.AlertsManagement/alerts/abcd",
+					"alertRule": "disk-full",
+					"severity": "Sev2",
+					"signalType": "Metric",
+					"monitorCondition": "` + condition + `",
+					"monitoringService": "Platform",
+					"alertTargetIDs": ["/subscriptions/1234/resourceGroups/RG/providers/Microsoft.Compute/virtualMachines/VM1"],
+					"firedDateTime": "2024-01-01T10:00:00.000Z",
+					"resolvedDateTime": "2024-01-01T11:00:00.000Z",
+					"description": "Disk is almost full"
+				}
+			}
+		}`)
+	}
+
+	alerts, err := azureMonitorAlerts(payload("Fired"))
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	require.Equal(t, model.LabelSet{
+		"alertname":          "disk-full",
+		"severity":           "sev2",
+		"signal_type":        "Metric",
+		"monitoring_service": "Platform",
+		"resource":           "/subscriptions/1234/resourcegroups/rg/providers/microsoft.compute/virtualmachines/vm1",
+	}, alerts[0].Labels)
+	require.Equal(t, model.LabelValue("Disk is almost full"), alerts[0].Annotations["description"])
+	require.True(t, alerts[0].StartsAt.Equal(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)))
+	require.True(t, alerts[0].EndsAt.IsZero())
+
+	alerts, err = azureMonitorAlerts(payload("Resolved"))
+	require.NoError(t, err)
+	require.True(t, alerts[0].EndsAt.Equal(time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)))
+
+	_, err = azureMonitorAlerts([]byte(`{"schemaId": "Microsoft.Insights/activityLogs"}`))
+	require.ErrorIs(t, err, ErrInvalidPayload)
+}
+
+func TestAdapters(t *testing.T) {
+	var alerts []*types.Alert
+	metrics := NewMetrics(prometheus.NewRegistry())
+	a := NewAdapters(SNSOptions{}, func(as []*types.Alert) error {
+		alerts = append(alerts, as...)
+		return nil
+	}, promslog.NewNopLogger(), metrics)
+	h := a.Handler("grafana")
+	require.NotNil(t, h)
+	require.Nil(t, a.Handler("unknown"))
+
+	post := func(body string) int {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/grafana", strings.NewReader(body)))
+		return w.Code
+	}
+	payload := `{"ruleName": "High latency", "state": "alerting", "tags": {"team": "web"}}`
+
+	// Formats are disabled until configured.
+	require.Equal(t, http.StatusNotFound, post(payload))
+
+	_, err := a.Prepare(&config.Config{IngestAdapters: []config.IngestAdapter{{Format: "unknown"}}})
+	require.Error(t, err)
+
+	commit, err := a.Prepare(&config.Config{IngestAdapters: []config.IngestAdapter{{
+		Format:        "grafana",
+		LabelMappings: map[model.LabelName]model.LabelName{"team": "owner"},
+		Labels:        model.LabelSet{"source": "grafana", "owner": "unused"},
+	}}})
+	require.NoError(t, err)
+	commit()
+
+	require.Equal(t, http.StatusOK, post(payload))
+	require.Len(t, alerts, 1)
+	require.Equal(t, model.LabelSet{
+		"alertname": "High latency",
+		"owner":     "web",
+		"source":    "grafana",
+	}, alerts[0].Labels)
+
+	require.Equal(t, http.StatusBadRequest, post(`{"state": "alerting"}`))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.messagesTotal.WithLabelValues("grafana", resultSuccess)))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.messagesTotal.WithLabelValues("grafana", resultInvalid)))
+}
+
+func TestAdaptersSNSOptions(t *testing.T) {
+	a := NewAdapters(SNSOptions{TopicARNs: []string{"arn:aws:sns:us-east-1:123456789012:alarms"}}, func([]*types.Alert) error {
+		return nil
+	}, promslog.NewNopLogger(), NewMetrics(prometheus.NewRegistry()))
+	commit, err := a.Prepare(&config.Config{IngestAdapters: []config.IngestAdapter{{Format: "aws-cloudwatch"}}})
+	require.NoError(t, err)
+	commit()
+
+	b, err := json.Marshal(snsMessage{Type: snsSubscriptionConfirmation, TopicArn: "arn:aws:sns:us-east-1:123456789012:other"})
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	a.Handler("aws-cloudwatch").ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/aws-cloudwatch", bytes.NewReader(b)))
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Contains(t, w.Body.String(), "unknown topic")
+}
+
+func TestFormatsInConfig(t *testing.T) {
+	// The formats of the built-in adapters are known to the configuration.
+	for _, format := range Formats() {
+		_, err := config.Load("route:\n  receiver: team-X\nreceivers:\n- name: team-X\ningest_adapters:\n- format: " + format + "\n")
+		require.NoError(t, err, format)
+	}
+}
+
+func TestSanitizeLabelName(t *testing.T) {
+	for in, out := range map[string]model.LabelName{
+		"team":         "team",
+		"service.name": "service_name",
+		"0day":         "_day",
+		"a-b c":        "a_b_c",
+	} {
+		require.Equal(t, out, sanitizeLabelName(in), in)
+	}
+}
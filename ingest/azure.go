@@ -0,0 +1,104 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+// azureCommonAlertSchema is the identifier of the Azure Monitor common alert
+// schema.
+const azureCommonAlertSchema = "azureMonitorCommonAlertSchema"
+
+// azureMonitorPayload is the body of Azure Monitor action group webhooks
+// using the common alert schema.
+type azureMonitorPayload struct {
+	SchemaID string `json:"schemaId"`
+	Data     struct {
+		Essentials struct {
+			AlertID           string    `json:"alertId"`
+			AlertRule         string    `json:"alertRule"`
+			Severity          string    `json:"severity"`
+			SignalType        string    `json:"signalType"`
+			MonitorCondition  string    `json:"monitorCondition"`
+			MonitoringService string    `json:"monitoringService"`
+			AlertTargetIDs    []string  `json:"alertTargetIDs"`
+			FiredDateTime     time.Time `json:"firedDateTime"`
+			ResolvedDateTime  time.Time `json:"resolvedDateTime"`
+			Description       string    `json:"description"`
+		} `json:"essentials"`
+	} `json:"data"`
+}
+
+// azureMonitorAlerts translates an Azure Monitor alert in the common alert
+// schema into an alert named after the alert rule and labeled with its
+// severity, signal type, monitoring service and target resource.
+func azureMonitorAlerts(b []byte) ([]*types.Alert, error) {
+	var p azureMonitorPayload
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+	if p.SchemaID != azureCommonAlertSchema {
+		return nil, fmt.Errorf("%w: unsupported schema %q", ErrInvalidPayload, p.SchemaID)
+	}
+	e := p.Data.Essentials
+	if e.AlertRule == "" {
+		return nil, fmt.Errorf("%w: missing alert rule", ErrInvalidPayload)
+	}
+
+	a := &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{model.AlertNameLabel: model.LabelValue(e.AlertRule)},
+			Annotations: model.LabelSet{},
+			StartsAt:    e.FiredDateTime,
+		},
+		UpdatedAt: time.Now(),
+	}
+	switch e.MonitorCondition {
+	case "Fired":
+	case "Resolved":
+		a.EndsAt = e.ResolvedDateTime
+		if a.EndsAt.IsZero() {
+			a.EndsAt = a.UpdatedAt
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown monitor condition %q", ErrInvalidPayload, e.MonitorCondition)
+	}
+
+	for k, v := range map[model.LabelName]string{
+		"severity":           strings.ToLower(e.Severity),
+		"signal_type":        e.SignalType,
+		"monitoring_service": e.MonitoringService,
+		// Resource IDs are case-insensitive.
+		"resource": strings.ToLower(strings.Join(e.AlertTargetIDs, ",")),
+	} {
+		if v != "" {
+			a.Labels[k] = model.LabelValue(v)
+		}
+	}
+	if e.Description != "" {
+		a.Annotations["description"] = model.LabelValue(e.Description)
+	}
+	if e.AlertID != "" {
+		a.Annotations["azure_alert_id"] = model.LabelValue(e.AlertID)
+	}
+	return []*types.Alert{a}, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+// cloudWatchTimeLayout is the layout of timestamps in CloudWatch alarm
+// notifications.
+const cloudWatchTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// cloudWatchAlarm is the message of the SNS notifications sent by CloudWatch
+// alarms.
+type cloudWatchAlarm struct {
+	AlarmName        string
+	AlarmDescription string
+	AWSAccountID     string `json:"AWSAccountId"`
+	NewStateValue    string
+	NewStateReason   string
+	StateChangeTime  string
+	AlarmArn         string
+	Trigger          struct {
+		MetricName string
+		Namespace  string
+		Dimensions []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		}
+	}
+}
+
+// cloudWatchAlerts translates a CloudWatch alarm state change into an alert
+// named after the alarm and labeled with the account, region, metric and
+// dimensions of the alarm. Changes to INSUFFICIENT_DATA are ignored.
+func cloudWatchAlerts(b []byte) ([]*types.Alert, error) {
+	var ca cloudWatchAlarm
+	if err := json.Unmarshal(b, &ca); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+	if ca.AlarmName == "" {
+		return nil, fmt.Errorf("%w: missing alarm name", ErrInvalidPayload)
+	}
+	t, err := time.Parse(cloudWatchTimeLayout, ca.StateChangeTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid state change time: %w", ErrInvalidPayload, err)
+	}
+
+	a := &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{model.AlertNameLabel: model.LabelValue(ca.AlarmName)},
+			Annotations: model.LabelSet{},
+			StartsAt:    t,
+		},
+		UpdatedAt: time.Now(),
+	}
+	switch ca.NewStateValue {
+	case "ALARM":
+	case "OK":
+		a.EndsAt = t
+	case "INSUFFICIENT_DATA":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown state %q", ErrInvalidPayload, ca.NewStateValue)
+	}
+
+	// The region of the notification is a display name, take the code from
+	// the ARN instead: arn:aws:cloudwatch:<region>:<account>:alarm:<name>.
+	region := ""
+	if parts := strings.SplitN(ca.AlarmArn, ":", 6); len(parts) == 6 {
+		region = parts[3]
+	}
+	for k, v := range map[model.LabelName]string{
+		"aws_account_id": ca.AWSAccountID,
+		"region":         region,
+		"namespace":      ca.Trigger.Namespace,
+		"metric_name":    ca.Trigger.MetricName,
+	} {
+		if v != "" {
+			a.Labels[k] = model.LabelValue(v)
+		}
+	}
+	for _, d := range ca.Trigger.Dimensions {
+		a.Labels["dimension_"+sanitizeLabelName(d.Name)] = model.LabelValue(d.Value)
+	}
+
+	if ca.AlarmDescription != "" {
+		a.Annotations["description"] = model.LabelValue(ca.AlarmDescription)
+	}
+	if ca.NewStateReason != "" {
+		a.Annotations["summary"] = model.LabelValue(ca.NewStateReason)
+	}
+	if region != "" {
+		a.GeneratorURL = fmt.Sprintf(
+			"https://console.aws.amazon.com/cloudwatch/home?region=%s#alarmsV2:alarm/%s",
+			url.QueryEscape(region), url.PathEscape(ca.AlarmName),
+		)
+	}
+	return []*types.Alert{a}, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+// grafanaPayload is the body of notifications sent by the webhook notifier
+// of Grafana legacy alerting.
+type grafanaPayload struct {
+	Title    string            `json:"title"`
+	RuleID   int64             `json:"ruleId"`
+	RuleName string            `json:"ruleName"`
+	RuleURL  string            `json:"ruleUrl"`
+	State    string            `json:"state"`
+	ImageURL string            `json:"imageUrl"`
+	Message  string            `json:"message"`
+	Tags     map[string]string `json:"tags"`
+}
+
+// grafanaAlerts translates a Grafana legacy alerting notification into an
+// alert named after the rule and labeled with its tags. Notifications of
+// paused and pending rules are ignored.
+func grafanaAlerts(b []byte) ([]*types.Alert, error) {
+	var p grafanaPayload
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+	if p.RuleName == "" {
+		return nil, fmt.Errorf("%w: missing rule name", ErrInvalidPayload)
+	}
+
+	now := time.Now()
+	a := &types.Alert{
+		Alert: model.Alert{
+			Labels:       model.LabelSet{model.AlertNameLabel: model.LabelValue(p.RuleName)},
+			Annotations:  model.LabelSet{},
+			GeneratorURL: p.RuleURL,
+			StartsAt:     now,
+		},
+		UpdatedAt: now,
+	}
+	switch p.State {
+	case "alerting", "no_data":
+	case "ok":
+		a.EndsAt = now
+	case "paused", "pending":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown state %q", ErrInvalidPayload, p.State)
+	}
+
+	for k, v := range p.Tags {
+		a.Labels[sanitizeLabelName(k)] = model.LabelValue(v)
+	}
+	if p.RuleID != 0 {
+		a.Labels["rule_id"] = model.LabelValue(strconv.FormatInt(p.RuleID, 10))
+	}
+	for k, v := range map[model.LabelName]string{
+		"summary":     p.Title,
+		"description": p.Message,
+		"image_url":   p.ImageURL,
+	} {
+		if v != "" {
+			a.Annotations[k] = model.LabelValue(v)
+		}
+	}
+	return []*types.Alert{a}, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ingest receives alerts from sources other than the alerts API, for
+// environments where the alert senders cannot reach Alertmanager directly.
+package ingest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/client_golang/prometheus"
+
+	apiv2 "github.com/prometheus/alertmanager/api/v2"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// AlertsFunc inserts alerts the same way as alerts posted to the API.
+type AlertsFunc func([]*types.Alert) error
+
+// DecodeFunc decodes the alerts held by a message.
+type DecodeFunc func([]byte) ([]*types.Alert, error)
+
+// ErrInvalidPayload is returned for payloads that can never be ingested.
+var ErrInvalidPayload = errors.New("invalid payload")
+
+// DecodeAlerts decodes a JSON list of alerts in the format of the body of
+// POST /api/v2/alerts requests.
+func DecodeAlerts(b []byte) ([]*types.Alert, error) {
+	var alerts models.PostableAlerts
+	if err := json.Unmarshal(b, &alerts); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+	if err := alerts.Validate(strfmt.Default); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+	return apiv2.OpenAPIAlertsToAlerts(alerts), nil
+}
+
+// Result labels of the ingested messages metric.
+const (
+	resultSuccess = "success"
+	resultInvalid = "invalid"
+	resultError   = "error"
+)
+
+// Metrics are shared by all ingestion sources.
+type Metrics struct {
+	messagesTotal *prometheus.CounterVec
+}
+
+// NewMetrics returns new Metrics registered with r.
+func NewMetrics(r prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		messagesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_ingest_messages_total",
+			Help: "Number of messages received by the ingestion sources, by source and result.",
+		}, []string{"source", "result"}),
+	}
+	if r != nil {
+		r.MustRegister(m.messagesTotal)
+	}
+	return m
+}
+
+// observe counts a message received from source. err is the error returned
+// while decoding or inserting its alerts.
+func (m *Metrics) observe(source string, err error) {
+	result := resultSuccess
+	if err != nil {
+		result = resultError
+		if permanent(err) {
+			result = resultInvalid
+		}
+	}
+	m.messagesTotal.WithLabelValues(source, result).Inc()
+}
+
+// ingest decodes the alerts in b and inserts them.
+func ingest(b []byte, decode DecodeFunc, put AlertsFunc) error {
+	alerts, err := decode(b)
+	if err != nil {
+		return err
+	}
+	return put(alerts)
+}
+
+// permanent returns true if ingesting the message failed and would fail
+// again on retry.
+func permanent(err error) bool {
+	var validationErrors *types.MultiError
+	return errors.Is(err, ErrInvalidPayload) || errors.As(err, &validationErrors)
+}
@@ -0,0 +1,333 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingest
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// maxSNSMessageSize is the maximum size of an SNS message, including the
+// envelope.
+const maxSNSMessageSize = 512 * 1024
+
+// snsFetchTimeout bounds the fetch of a signing certificate or of a
+// subscription confirmation URL.
+const snsFetchTimeout = 10 * time.Second
+
+// maxSNSCerts is the maximum number of signing certificates cached. SNS signs
+// with a single certificate per region, which is rotated from time to time.
+const maxSNSCerts = 32
+
+// SNS message types.
+const (
+	snsNotification             = "Notification"
+	snsSubscriptionConfirmation = "SubscriptionConfirmation"
+	snsUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+// snsHostRe matches the hosts SNS signing certificates and subscription
+// confirmation URLs are served from.
+var snsHostRe = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// snsMessage is the JSON envelope of SNS HTTP deliveries. It is also found in
+// SQS messages of queues subscribed to an SNS topic without raw message
+// delivery.
+type snsMessage struct {
+	Type             string
+	MessageID        string `json:"MessageId"`
+	Token            string
+	TopicArn         string
+	Subject          string
+	Message          string
+	Timestamp        string
+	SignatureVersion string
+	Signature        string
+	SigningCertURL   string
+	SubscribeURL     string
+}
+
+// unwrapSNS returns the message of an SNS notification envelope, or b if it
+// isn't one.
+func unwrapSNS(b []byte) []byte {
+	var m snsMessage
+	if err := json.Unmarshal(b, &m); err != nil || m.Type != snsNotification {
+		return b
+	}
+	return []byte(m.Message)
+}
+
+// stringToSign returns the canonical form of the message which is signed by
+// SNS.
+func (m *snsMessage) stringToSign() string {
+	var sb strings.Builder
+	add := func(k, v string) {
+		sb.WriteString(k)
+		sb.WriteString("\n")
+		sb.WriteString(v)
+		sb.WriteString("\n")
+	}
+	add("Message", m.Message)
+	add("MessageId", m.MessageID)
+	if m.Type == snsNotification {
+		if m.Subject != "" {
+			add("Subject", m.Subject)
+		}
+	} else {
+		add("SubscribeURL", m.SubscribeURL)
+	}
+	add("Timestamp", m.Timestamp)
+	if m.Type != snsNotification {
+		add("Token", m.Token)
+	}
+	add("TopicArn", m.TopicArn)
+	add("Type", m.Type)
+	return sb.String()
+}
+
+// SNSOptions configures an SNSHandler.
+type SNSOptions struct {
+	// TopicARNs lists the topics from which messages are accepted. If empty,
+	// messages from all topics are accepted but subscriptions aren't
+	// confirmed, not to let anyone subscribe the handler to their topics.
+	TopicARNs []string
+	// Client is used to fetch signing certificates and confirm
+	// subscriptions. If nil, http.DefaultClient is used.
+	Client *http.Client
+}
+
+// SNSHandler receives alerts from SNS HTTP(S) subscriptions. The message of
+// each notification holds alerts in the format of the body of
+// POST /api/v2/alerts requests. Subscriptions of the configured topics are
+// confirmed automatically.
+type SNSHandler struct {
+	topics  []string
+	client  *http.Client
+	source  string
+	decode  DecodeFunc
+	put     AlertsFunc
+	logger  *slog.Logger
+	metrics *Metrics
+
+	// validURL reports whether a signing certificate or subscription
+	// confirmation URL may be fetched.
+	validURL func(*url.URL) bool
+
+	certs *lru.Cache[string, *x509.Certificate]
+}
+
+// NewSNSHandler returns a new SNSHandler.
+func NewSNSHandler(o SNSOptions, put AlertsFunc, l *slog.Logger, m *Metrics) *SNSHandler {
+	return newSNSHandler(o, "sns", DecodeAlerts, put, l, m)
+}
+
+// newSNSHandler returns a new SNSHandler decoding the messages of
+// notifications with decode. Received messages are counted for source.
+func newSNSHandler(o SNSOptions, source string, decode DecodeFunc, put AlertsFunc, l *slog.Logger, m *Metrics) *SNSHandler {
+	client := o.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	// lru.New only fails with a non-positive size.
+	certs, _ := lru.New[string, *x509.Certificate](maxSNSCerts)
+	return &SNSHandler{
+		topics:  o.TopicARNs,
+		client:  client,
+		source:  source,
+		decode:  decode,
+		put:     put,
+		logger:  l,
+		metrics: m,
+		validURL: func(u *url.URL) bool {
+			return u.Scheme == "https" && snsHostRe.MatchString(u.Hostname())
+		},
+		certs: certs,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *SNSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	b, err := io.ReadAll(io.LimitReader(r.Body, maxSNSMessageSize+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(b) > maxSNSMessageSize {
+		http.Error(w, "message too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var m snsMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		http.Error(w, fmt.Sprintf("invalid SNS message: %s", err), http.StatusBadRequest)
+		return
+	}
+	if len(h.topics) > 0 && !slices.Contains(h.topics, m.TopicArn) {
+		h.logger.Warn("Rejected SNS message from unknown topic", "topic", m.TopicArn)
+		http.Error(w, "unknown topic", http.StatusForbidden)
+		return
+	}
+	if err := h.verify(r.Context(), &m); err != nil {
+		h.logger.Warn("Rejected SNS message with invalid signature", "topic", m.TopicArn, "err", err)
+		http.Error(w, fmt.Sprintf("invalid signature: %s", err), http.StatusForbidden)
+		return
+	}
+
+	switch m.Type {
+	case snsSubscriptionConfirmation:
+		if len(h.topics) == 0 {
+			h.logger.Warn("Not confirming SNS subscription without configured topics", "topic", m.TopicArn)
+			http.Error(w, "subscriptions are only confirmed for the configured topics", http.StatusForbidden)
+			return
+		}
+		if err := h.confirm(r.Context(), &m); err != nil {
+			h.logger.Error("Failed to confirm SNS subscription", "topic", m.TopicArn, "err", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		h.logger.Info("Confirmed SNS subscription", "topic", m.TopicArn)
+	case snsUnsubscribeConfirmation:
+		h.logger.Info("SNS subscription was removed", "topic", m.TopicArn)
+	case snsNotification:
+		err := ingest([]byte(m.Message), h.decode, h.put)
+		h.metrics.observe(h.source, err)
+		if err != nil {
+			h.logger.Error("Failed to ingest SNS notification", "topic", m.TopicArn, "message_id", m.MessageID, "err", err)
+			code := http.StatusInternalServerError
+			if permanent(err) {
+				// SNS doesn't retry client errors.
+				code = http.StatusBadRequest
+			}
+			http.Error(w, err.Error(), code)
+			return
+		}
+	default:
+		http.Error(w, fmt.Sprintf("unknown SNS message type %q", m.Type), http.StatusBadRequest)
+	}
+}
+
+// verify checks the signature of the message.
+func (h *SNSHandler) verify(ctx context.Context, m *snsMessage) error {
+	var (
+		hash crypto.Hash
+		sum  []byte
+	)
+	switch m.SignatureVersion {
+	case "1":
+		hash = crypto.SHA1
+		s := sha1.Sum([]byte(m.stringToSign()))
+		sum = s[:]
+	case "2":
+		hash = crypto.SHA256
+		s := sha256.Sum256([]byte(m.stringToSign()))
+		sum = s[:]
+	default:
+		return fmt.Errorf("unsupported signature version %q", m.SignatureVersion)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(m.Signature)
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+	cert, err := h.cert(ctx, m.SigningCertURL)
+	if err != nil {
+		return err
+	}
+	pub, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("signing certificate doesn't hold an RSA key")
+	}
+	return rsa.VerifyPKCS1v15(pub, hash, sum, sig)
+}
+
+// cert returns the signing certificate served at the given URL. The
+// certificate is fetched without holding the lock of the cache so that a slow
+// URL doesn't block the other messages.
+func (h *SNSHandler) cert(ctx context.Context, rawURL string) (*x509.Certificate, error) {
+	if c, ok := h.certs.Get(rawURL); ok {
+		return c, nil
+	}
+
+	b, err := h.fetch(ctx, rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetch signing certificate: %w", err)
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("signing certificate isn't PEM encoded")
+	}
+	c, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse signing certificate: %w", err)
+	}
+	h.certs.Add(rawURL, c)
+	return c, nil
+}
+
+// confirm confirms a subscription.
+func (h *SNSHandler) confirm(ctx context.Context, m *snsMessage) error {
+	_, err := h.fetch(ctx, m.SubscribeURL)
+	return err
+}
+
+// fetch returns the body of a GET request to an SNS URL, giving up after
+// snsFetchTimeout.
+func (h *SNSHandler) fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if !h.validURL(u) {
+		return nil, fmt.Errorf("refusing to fetch %q: not an SNS URL", rawURL)
+	}
+	ctx, cancel := context.WithTimeout(ctx, snsFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, u.Host)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxSNSMessageSize))
+}
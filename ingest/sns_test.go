@@ -0,0 +1,226 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+const testAlerts = `[{"labels":{"alertname":"test"}}]`
+
+type snsTest struct {
+	key       *rsa.PrivateKey
+	server    *httptest.Server
+	confirmed bool
+	alerts    []*types.Alert
+	handler   *SNSHandler
+	// hanging receives the requests to /hang, which are answered once
+	// their context is done.
+	hanging chan struct{}
+}
+
+func newSNSTest(t *testing.T, o SNSOptions) *snsTest {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	st := &snsTest{key: key, hanging: make(chan struct{}, 1)}
+	st.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cert.pem":
+			w.Write(certPEM)
+		case "/confirm":
+			st.confirmed = true
+		case "/hang":
+			st.hanging <- struct{}{}
+			<-r.Context().Done()
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(st.server.Close)
+
+	st.handler = NewSNSHandler(o, func(alerts []*types.Alert) error {
+		st.alerts = append(st.alerts, alerts...)
+		return nil
+	}, promslog.NewNopLogger(), NewMetrics(prometheus.NewRegistry()))
+	st.handler.validURL = func(u *url.URL) bool {
+		return u.Host == st.server.Listener.Addr().String()
+	}
+	return st
+}
+
+func (st *snsTest) message(t *testing.T, typ, msg string) *snsMessage {
+	m := &snsMessage{
+		Type:             typ,
+		MessageID:        "id",
+		TopicArn:         "arn:aws:sns:us-east-1:123456789012:alerts",
+		Message:          msg,
+		Timestamp:        "2024-01-01T00:00:00.000Z",
+		SignatureVersion: "2",
+		SigningCertURL:   st.server.URL + "/cert.pem",
+	}
+	if typ == snsSubscriptionConfirmation {
+		m.Token = "token"
+		m.SubscribeURL = st.server.URL + "/confirm"
+	}
+	sum := sha256.Sum256([]byte(m.stringToSign()))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, st.key, crypto.SHA256, sum[:])
+	require.NoError(t, err)
+	m.Signature = base64.StdEncoding.EncodeToString(sig)
+	return m
+}
+
+func (st *snsTest) post(t *testing.T, m *snsMessage) *httptest.ResponseRecorder {
+	return st.postWithContext(t, context.Background(), m)
+}
+
+func (st *snsTest) postWithContext(t *testing.T, ctx context.Context, m *snsMessage) *httptest.ResponseRecorder {
+	b, err := json.Marshal(m)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	st.handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/sns", bytes.NewReader(b)).WithContext(ctx))
+	return w
+}
+
+func TestSNSHandlerNotification(t *testing.T) {
+	st := newSNSTest(t, SNSOptions{})
+
+	w := st.post(t, st.message(t, snsNotification, testAlerts))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Len(t, st.alerts, 1)
+	require.Equal(t, model.LabelSet{"alertname": "test"}, st.alerts[0].Labels)
+	require.Equal(t, 1.0, testutil.ToFloat64(st.handler.metrics.messagesTotal.WithLabelValues("sns", resultSuccess)))
+
+	w = st.post(t, st.message(t, snsNotification, `not alerts`))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, 1.0, testutil.ToFloat64(st.handler.metrics.messagesTotal.WithLabelValues("sns", resultInvalid)))
+}
+
+func TestSNSHandlerSubscriptionConfirmation(t *testing.T) {
+	st := newSNSTest(t, SNSOptions{TopicARNs: []string{"arn:aws:sns:us-east-1:123456789012:alerts"}})
+
+	w := st.post(t, st.message(t, snsSubscriptionConfirmation, "confirm"))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.True(t, st.confirmed)
+
+	// Without configured topics, subscriptions aren't confirmed.
+	st = newSNSTest(t, SNSOptions{})
+	w = st.post(t, st.message(t, snsSubscriptionConfirmation, "confirm"))
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.False(t, st.confirmed)
+}
+
+func TestSNSHandlerRejects(t *testing.T) {
+	st := newSNSTest(t, SNSOptions{TopicARNs: []string{"arn:aws:sns:us-east-1:123456789012:alerts"}})
+
+	// Tampered message.
+	m := st.message(t, snsNotification, testAlerts)
+	m.Message = `[{"labels":{"alertname":"other"}}]`
+	require.Equal(t, http.StatusForbidden, st.post(t, m).Code)
+
+	// Unknown topic.
+	m = st.message(t, snsNotification, testAlerts)
+	m.TopicArn = "arn:aws:sns:us-east-1:123456789012:other"
+	require.Equal(t, http.StatusForbidden, st.post(t, m).Code)
+
+	// Certificate not served by SNS.
+	st.handler.certs.Purge()
+	st.handler.validURL = func(u *url.URL) bool { return false }
+	require.Equal(t, http.StatusForbidden, st.post(t, st.message(t, snsNotification, testAlerts)).Code)
+
+	require.Empty(t, st.alerts)
+}
+
+func TestSNSHandlerHangingCertificate(t *testing.T) {
+	st := newSNSTest(t, SNSOptions{})
+	require.Equal(t, http.StatusOK, st.post(t, st.message(t, snsNotification, testAlerts)).Code)
+
+	// A message whose signing certificate hangs doesn't block the others and
+	// gives up with its request.
+	m := st.message(t, snsNotification, testAlerts)
+	m.SigningCertURL = st.server.URL + "/hang"
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan int)
+	go func() {
+		done <- st.postWithContext(t, ctx, m).Code
+	}()
+	<-st.hanging
+
+	require.Equal(t, http.StatusOK, st.post(t, st.message(t, snsNotification, testAlerts)).Code)
+	cancel()
+	require.Equal(t, http.StatusForbidden, <-done)
+	require.Len(t, st.alerts, 2)
+}
+
+func TestSNSHandlerCertCacheBounded(t *testing.T) {
+	st := newSNSTest(t, SNSOptions{})
+	for i := 0; i <= maxSNSCerts; i++ {
+		m := st.message(t, snsNotification, testAlerts)
+		m.SigningCertURL = fmt.Sprintf("%s/cert.pem?i=%d", st.server.URL, i)
+		require.Equal(t, http.StatusOK, st.post(t, m).Code)
+	}
+	require.Equal(t, maxSNSCerts, st.handler.certs.Len())
+}
+
+func TestSNSValidURL(t *testing.T) {
+	h := NewSNSHandler(SNSOptions{}, nil, promslog.NewNopLogger(), nil)
+	for _, tc := range []struct {
+		url   string
+		valid bool
+	}{
+		{"https://sns.us-east-1.amazonaws.com/SimpleNotificationService-abc.pem", true},
+		{"https://sns.cn-north-1.amazonaws.com.cn/SimpleNotificationService-abc.pem", true},
+		{"http://sns.us-east-1.amazonaws.com/SimpleNotificationService-abc.pem", false},
+		{"https://sns.us-east-1.amazonaws.com.example.com/cert.pem", false},
+		{"https://example.com/cert.pem", false},
+	} {
+		u, err := url.Parse(tc.url)
+		require.NoError(t, err)
+		require.Equal(t, tc.valid, h.validURL(u), tc.url)
+	}
+}
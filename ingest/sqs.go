@@ -0,0 +1,151 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingest
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+)
+
+// maxSQSWaitTime is the longest wait time supported by SQS long polling.
+const maxSQSWaitTime = 20 * time.Second
+
+// SQSOptions configures an SQSReceiver.
+type SQSOptions struct {
+	// QueueURL is the URL of the queue to receive messages from.
+	QueueURL string
+	// Region of the queue. If empty, the region is taken from the
+	// environment or the shared configuration.
+	Region string
+	// Profile is the name of the shared configuration profile to use.
+	Profile string
+	// RoleARN is assumed to receive messages if set.
+	RoleARN string
+	// WaitTime is how long to long-poll for messages. It is capped to 20s.
+	WaitTime time.Duration
+	// RetryInterval is how long to wait after a failed receive.
+	RetryInterval time.Duration
+}
+
+// SQSReceiver long-polls an SQS queue for alerts. Each message holds alerts
+// in the format of the body of POST /api/v2/alerts requests, either directly
+// or wrapped in the envelope of an SNS notification.
+type SQSReceiver struct {
+	client        sqsiface.SQSAPI
+	queueURL      string
+	waitTime      time.Duration
+	retryInterval time.Duration
+
+	put     AlertsFunc
+	logger  *slog.Logger
+	metrics *Metrics
+}
+
+// NewSQSReceiver returns a new SQSReceiver.
+func NewSQSReceiver(o SQSOptions, put AlertsFunc, l *slog.Logger, m *Metrics) (*SQSReceiver, error) {
+	if o.QueueURL == "" {
+		return nil, errors.New("missing queue URL")
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            aws.Config{Region: aws.String(o.Region)},
+		Profile:           o.Profile,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var cfg []*aws.Config
+	if o.RoleARN != "" {
+		cfg = append(cfg, &aws.Config{Credentials: stscreds.NewCredentials(sess, o.RoleARN)})
+	}
+	return newSQSReceiver(sqs.New(sess, cfg...), o, put, l, m), nil
+}
+
+func newSQSReceiver(client sqsiface.SQSAPI, o SQSOptions, put AlertsFunc, l *slog.Logger, m *Metrics) *SQSReceiver {
+	if o.WaitTime <= 0 || o.WaitTime > maxSQSWaitTime {
+		o.WaitTime = maxSQSWaitTime
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = 5 * time.Second
+	}
+	return &SQSReceiver{
+		client:        client,
+		queueURL:      o.QueueURL,
+		waitTime:      o.WaitTime,
+		retryInterval: o.RetryInterval,
+		put:           put,
+		logger:        l,
+		metrics:       m,
+	}
+}
+
+// Run receives messages until ctx is canceled. Messages are deleted from the
+// queue once their alerts were inserted, or if they can never be. Otherwise,
+// they are received again after the visibility timeout of the queue.
+func (r *SQSReceiver) Run(ctx context.Context) {
+	for {
+		if err := r.receive(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			r.logger.Error("Failed to receive SQS messages", "queue", r.queueURL, "err", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(r.retryInterval):
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// receive processes a single batch of messages.
+func (r *SQSReceiver) receive(ctx context.Context) error {
+	out, err := r.client.ReceiveMessageWithContext(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(r.queueURL),
+		MaxNumberOfMessages: aws.Int64(10),
+		WaitTimeSeconds:     aws.Int64(int64(r.waitTime / time.Second)),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range out.Messages {
+		err := ingest(unwrapSNS([]byte(aws.StringValue(msg.Body))), DecodeAlerts, r.put)
+		r.metrics.observe("sqs", err)
+		if err != nil {
+			r.logger.Error("Failed to ingest SQS message", "queue", r.queueURL, "message_id", aws.StringValue(msg.MessageId), "err", err)
+			if !permanent(err) {
+				continue
+			}
+		}
+		if _, err := r.client.DeleteMessageWithContext(ctx, &sqs.DeleteMessageInput{
+			QueueUrl:      aws.String(r.queueURL),
+			ReceiptHandle: msg.ReceiptHandle,
+		}); err != nil {
+			r.logger.Error("Failed to delete SQS message", "queue", r.queueURL, "message_id", aws.StringValue(msg.MessageId), "err", err)
+		}
+	}
+	return nil
+}
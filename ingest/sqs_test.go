@@ -0,0 +1,75 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+type fakeSQS struct {
+	sqsiface.SQSAPI
+	messages []*sqs.Message
+	deleted  []string
+}
+
+func (f *fakeSQS) ReceiveMessageWithContext(_ aws.Context, in *sqs.ReceiveMessageInput, _ ...request.Option) (*sqs.ReceiveMessageOutput, error) {
+	return &sqs.ReceiveMessageOutput{Messages: f.messages}, nil
+}
+
+func (f *fakeSQS) DeleteMessageWithContext(_ aws.Context, in *sqs.DeleteMessageInput, _ ...request.Option) (*sqs.DeleteMessageOutput, error) {
+	f.deleted = append(f.deleted, aws.StringValue(in.ReceiptHandle))
+	return &sqs.DeleteMessageOutput{}, nil
+}
+
+func TestSQSReceiver(t *testing.T) {
+	client := &fakeSQS{
+		messages: []*sqs.Message{
+			{MessageId: aws.String("raw"), ReceiptHandle: aws.String("raw"), Body: aws.String(`[{"labels":{"alertname":"raw"}}]`)},
+			{MessageId: aws.String("sns"), ReceiptHandle: aws.String("sns"), Body: aws.String(`{"Type":"Notification","Message":"[{\"labels\":{\"alertname\":\"sns\"}}]"}`)},
+			{MessageId: aws.String("invalid"), ReceiptHandle: aws.String("invalid"), Body: aws.String(`{}`)},
+			{MessageId: aws.String("failed"), ReceiptHandle: aws.String("failed"), Body: aws.String(`[{"labels":{"alertname":"failed"}}]`)},
+		},
+	}
+
+	var names []model.LabelValue
+	put := func(alerts []*types.Alert) error {
+		for _, a := range alerts {
+			if a.Name() == "failed" {
+				return errors.New("provider unavailable")
+			}
+			names = append(names, a.Labels["alertname"])
+		}
+		return nil
+	}
+	r := newSQSReceiver(client, SQSOptions{QueueURL: "https://sqs.example.com/queue"}, put, promslog.NewNopLogger(), NewMetrics(prometheus.NewRegistry()))
+
+	require.NoError(t, r.receive(context.Background()))
+	require.Equal(t, []model.LabelValue{"raw", "sns"}, names)
+	// Invalid messages are deleted, messages that failed temporarily are
+	// kept to be received again.
+	require.Equal(t, []string{"raw", "sns", "invalid"}, client.deleted)
+}
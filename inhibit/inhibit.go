@@ -16,10 +16,12 @@ package inhibit
 import (
 	"context"
 	"log/slog"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/oklog/run"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/model"
 
 	"github.com/prometheus/alertmanager/config"
@@ -37,6 +39,7 @@ type Inhibitor struct {
 	rules  []*InhibitRule
 	marker types.AlertMarker
 	logger *slog.Logger
+	warmUp *WarmUp
 
 	mtx    sync.RWMutex
 	cancel func()
@@ -56,6 +59,12 @@ func NewInhibitor(ap provider.Alerts, rs []config.InhibitRule, mk types.AlertMar
 	return ih
 }
 
+// SetWarmUp delays the notifications of the target alerts of the rules during
+// the warm-up.
+func (ih *Inhibitor) SetWarmUp(w *WarmUp) {
+	ih.warmUp = w
+}
+
 func (ih *Inhibitor) run(ctx context.Context) {
 	it := ih.alerts.Subscribe()
 	defer it.Close()
@@ -127,11 +136,13 @@ func (ih *Inhibitor) Stop() {
 func (ih *Inhibitor) Mutes(lset model.LabelSet) bool {
 	fp := lset.Fingerprint()
 
+	var target bool
 	for _, r := range ih.rules {
 		if !r.TargetMatchers.Matches(lset) {
 			// If target side of rule doesn't match, we don't need to look any further.
 			continue
 		}
+		target = true
 		// If we are here, the target side matches. If the source side matches, too, we
 		// need to exclude inhibiting alerts for which the same is true.
 		if inhibitedByFP, eq := r.hasEqual(lset, r.SourceMatchers.Matches(lset)); eq {
@@ -141,7 +152,99 @@ func (ih *Inhibitor) Mutes(lset model.LabelSet) bool {
 	}
 	ih.marker.SetInhibited(fp)
 
-	return false
+	// The source alerts may not have been received again since the restart.
+	// The alert isn't marked as inhibited, its notifications are only delayed.
+	return target && ih.warmUp.gate(fp, time.Now())
+}
+
+// RuleExplanation describes the evaluation of an inhibition rule for a label
+// set.
+type RuleExplanation struct {
+	Rule *InhibitRule
+	// Whether the label set matches the target side of the rule. The sources
+	// are only evaluated if it does.
+	TargetMatched bool
+	// Whether the label set matches the source side of the rule, in which case
+	// the sources matching the target side as well are disregarded.
+	SourceMatched bool
+	// Whether the label set is inhibited by the rule, and by which source.
+	Inhibited   bool
+	InhibitedBy model.Fingerprint
+	Sources     []SourceExplanation
+}
+
+// SourceExplanation describes the comparison of a source alert of an
+// inhibition rule with a label set.
+type SourceExplanation struct {
+	Fingerprint model.Fingerprint
+	Labels      model.LabelSet
+	// Whether the source is disregarded as it matches both sides of the rule.
+	TwoSided bool
+	// The equal labels whose values differ between the source and the label
+	// set.
+	Mismatches []EqualMismatch
+}
+
+// EqualMismatch is a failed comparison of an equal label of an inhibition
+// rule.
+type EqualMismatch struct {
+	Name   model.LabelName
+	Source model.LabelValue
+	Target model.LabelValue
+}
+
+// Explain evaluates every inhibition rule for the given label set and reports
+// why it is or isn't inhibited. Unlike Mutes, it has no side effects.
+func (ih *Inhibitor) Explain(lset model.LabelSet) []RuleExplanation {
+	res := make([]RuleExplanation, 0, len(ih.rules))
+	for _, r := range ih.rules {
+		res = append(res, r.explain(lset))
+	}
+	return res
+}
+
+// WarmUp is the period after a restart during which the notifications of
+// the target alerts of the inhibition rules are delayed, as their source
+// alerts may not have been received yet. It outlives the Inhibitors of the
+// successive configurations.
+type WarmUp struct {
+	end   time.Time
+	gated prometheus.Counter
+
+	mtx  sync.Mutex
+	seen map[model.Fingerprint]struct{}
+}
+
+// NewWarmUp returns a WarmUp ending after d. If d is zero, there is no
+// warm-up.
+func NewWarmUp(d time.Duration, r prometheus.Registerer) *WarmUp {
+	w := &WarmUp{
+		end: time.Now().Add(d),
+		gated: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_inhibitor_warm_up_gated_alerts_total",
+			Help: "Number of alerts whose notifications were delayed by the warm-up of the inhibitor.",
+		}),
+		seen: map[model.Fingerprint]struct{}{},
+	}
+	if r != nil {
+		r.MustRegister(w.gated)
+	}
+	return w
+}
+
+// gate returns whether the notifications of the alert are delayed at the
+// given time.
+func (w *WarmUp) gate(fp model.Fingerprint, now time.Time) bool {
+	if w == nil || !now.Before(w.end) {
+		return false
+	}
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if _, ok := w.seen[fp]; !ok {
+		w.seen[fp] = struct{}{}
+		w.gated.Inc()
+	}
+	return true
 }
 
 // An InhibitRule specifies that a class of (source) alerts should inhibit
@@ -248,3 +351,54 @@ Outer:
 	}
 	return model.Fingerprint(0), false
 }
+
+// explain is the counterpart of hasEqual reporting the comparison with every
+// source alert.
+func (r *InhibitRule) explain(lset model.LabelSet) RuleExplanation {
+	e := RuleExplanation{
+		Rule:          r,
+		TargetMatched: r.TargetMatchers.Matches(lset),
+		SourceMatched: r.SourceMatchers.Matches(lset),
+	}
+	if !e.TargetMatched {
+		return e
+	}
+
+	equal := make([]string, 0, len(r.Equal))
+	for n := range r.Equal {
+		equal = append(equal, string(n))
+	}
+	sort.Strings(equal)
+
+	alerts := r.scache.List()
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].Fingerprint() < alerts[j].Fingerprint()
+	})
+	for _, a := range alerts {
+		// The cache might be stale and contain resolved alerts.
+		if a.Resolved() {
+			continue
+		}
+		s := SourceExplanation{
+			Fingerprint: a.Fingerprint(),
+			Labels:      a.Labels,
+			TwoSided:    e.SourceMatched && r.TargetMatchers.Matches(a.Labels),
+		}
+		for _, n := range equal {
+			ln := model.LabelName(n)
+			if a.Labels[ln] != lset[ln] {
+				s.Mismatches = append(s.Mismatches, EqualMismatch{
+					Name:   ln,
+					Source: a.Labels[ln],
+					Target: lset[ln],
+				})
+			}
+		}
+		if !e.Inhibited && !s.TwoSided && len(s.Mismatches) == 0 {
+			e.Inhibited = true
+			e.InhibitedBy = s.Fingerprint
+		}
+		e.Sources = append(e.Sources, s)
+	}
+	return e
+}
@@ -18,8 +18,10 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
 
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/pkg/labels"
@@ -477,3 +479,100 @@ func TestInhibit(t *testing.T) {
 		}
 	}
 }
+
+func TestInhibitWarmUp(t *testing.T) {
+	mk := types.NewMarker(prometheus.NewRegistry())
+	inhibitor := NewInhibitor(newFakeAlerts(nil), []config.InhibitRule{{
+		SourceMatch: map[string]string{"s": "1"},
+		TargetMatch: map[string]string{"t": "1"},
+	}}, mk, nopLogger)
+
+	reg := prometheus.NewRegistry()
+	w := NewWarmUp(time.Hour, reg)
+	inhibitor.SetWarmUp(w)
+
+	target := model.LabelSet{"t": "1"}
+	require.True(t, inhibitor.Mutes(target))
+	require.True(t, inhibitor.Mutes(target))
+	// The gated alerts aren't marked as inhibited.
+	_, inhibited := mk.Inhibited(target.Fingerprint())
+	require.False(t, inhibited)
+	require.False(t, inhibitor.Mutes(model.LabelSet{"t": "2"}))
+	require.Equal(t, 1.0, testutil.ToFloat64(w.gated))
+
+	// The source alerts inhibit the target alerts during the warm-up.
+	require.NoError(t, inhibitor.rules[0].scache.Set(&types.Alert{Alert: model.Alert{
+		Labels: model.LabelSet{"s": "1"},
+		EndsAt: time.Now().Add(time.Hour),
+	}}))
+	require.True(t, inhibitor.Mutes(target))
+	require.Len(t, mk.Status(target.Fingerprint()).InhibitedBy, 1)
+
+	// The notifications aren't delayed after the warm-up.
+	require.False(t, w.gate(target.Fingerprint(), time.Now().Add(2*time.Hour)))
+	require.False(t, NewWarmUp(0, nil).gate(target.Fingerprint(), time.Now()))
+}
+
+func TestInhibitorExplain(t *testing.T) {
+	mk := types.NewMarker(prometheus.NewRegistry())
+	inhibitor := NewInhibitor(newFakeAlerts(nil), []config.InhibitRule{
+		{
+			SourceMatch: map[string]string{"severity": "critical"},
+			TargetMatch: map[string]string{"severity": "warning"},
+			Equal:       model.LabelNames{"job", "cluster"},
+		},
+		{
+			SourceMatch: map[string]string{"alertname": "Maintenance"},
+			TargetMatch: map[string]string{"team": "db"},
+		},
+	}, mk, nopLogger)
+
+	now := time.Now()
+	sources := []*types.Alert{
+		{Alert: model.Alert{
+			Labels: model.LabelSet{"severity": "critical", "job": "api", "cluster": "eu"},
+			EndsAt: now.Add(time.Hour),
+		}},
+		{Alert: model.Alert{
+			Labels: model.LabelSet{"severity": "critical", "job": "api", "cluster": "us"},
+			EndsAt: now.Add(time.Hour),
+		}},
+		// Resolved sources are disregarded.
+		{Alert: model.Alert{
+			Labels: model.LabelSet{"severity": "critical", "job": "db", "cluster": "us"},
+			EndsAt: now.Add(-time.Hour),
+		}},
+	}
+	for _, a := range sources {
+		require.NoError(t, inhibitor.rules[0].scache.Set(a))
+	}
+
+	target := model.LabelSet{"severity": "warning", "job": "api", "cluster": "us"}
+	res := inhibitor.Explain(target)
+	require.Len(t, res, 2)
+
+	require.True(t, res[0].TargetMatched)
+	require.False(t, res[0].SourceMatched)
+	require.True(t, res[0].Inhibited)
+	require.Equal(t, sources[1].Fingerprint(), res[0].InhibitedBy)
+	require.Len(t, res[0].Sources, 2)
+	for _, s := range res[0].Sources {
+		switch s.Fingerprint {
+		case sources[0].Fingerprint():
+			require.Equal(t, []EqualMismatch{{Name: "cluster", Source: "eu", Target: "us"}}, s.Mismatches)
+		case sources[1].Fingerprint():
+			require.Empty(t, s.Mismatches)
+		default:
+			t.Fatalf("unexpected source %v", s.Labels)
+		}
+	}
+
+	require.False(t, res[1].TargetMatched)
+	require.False(t, res[1].Inhibited)
+	require.Empty(t, res[1].Sources)
+
+	// Explain has no side effects.
+	_, inhibited := mk.Inhibited(target.Fingerprint())
+	require.False(t, inhibited)
+	require.Equal(t, inhibitor.Mutes(target), res[0].Inhibited)
+}
@@ -0,0 +1,293 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package maintenance stores the receivers temporarily disabled for
+// maintenance and gossips them to the other peers of the cluster.
+package maintenance
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coder/quartz"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/promslog"
+
+	"github.com/prometheus/alertmanager/cluster"
+)
+
+// ErrNotDisabled is returned when enabling a receiver which isn't disabled.
+var ErrNotDisabled = errors.New("receiver is not disabled")
+
+// Disable is the disabling of a receiver.
+type Disable struct {
+	Receiver   string    `json:"receiver"`
+	CreatedBy  string    `json:"createdBy"`
+	Comment    string    `json:"comment,omitempty"`
+	DisabledAt time.Time `json:"disabledAt"`
+	EndsAt     time.Time `json:"endsAt"`
+	// UpdatedAt is the time of the last change, the receiver being enabled
+	// again before EndsAt included. Of two versions of the disabling of a
+	// receiver, the most recently updated one wins.
+	UpdatedAt time.Time `json:"updatedAt"`
+	// ExpiresAt is the time until which the disabling is kept once ended. It
+	// is the latest end of the versions it replaced, so that they can't be
+	// merged again from the peers which missed the changes.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// expiresAt returns the time until which the disabling is kept.
+func (d *Disable) expiresAt() time.Time {
+	if d.ExpiresAt.After(d.EndsAt) {
+		return d.ExpiresAt
+	}
+	return d.EndsAt
+}
+
+// replace sets the expiry of the disabling replacing prev.
+func (d *Disable) replace(prev *Disable) {
+	if exp := prev.expiresAt(); exp.After(d.expiresAt()) {
+		d.ExpiresAt = exp
+	}
+}
+
+// Options configures Receivers.
+type Options struct {
+	Logger  *slog.Logger
+	Metrics prometheus.Registerer
+}
+
+// Receivers holds the disabled receivers. It implements cluster.State.
+type Receivers struct {
+	clock  quartz.Clock
+	logger *slog.Logger
+
+	mtx       sync.RWMutex
+	st        map[string]*Disable
+	broadcast func([]byte)
+}
+
+// New returns a new Receivers.
+func New(o Options) *Receivers {
+	if o.Logger == nil {
+		o.Logger = promslog.NewNopLogger()
+	}
+	r := &Receivers{
+		clock:     quartz.NewReal(),
+		logger:    o.Logger,
+		st:        map[string]*Disable{},
+		broadcast: func([]byte) {},
+	}
+	if o.Metrics != nil {
+		o.Metrics.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "alertmanager_receivers_disabled",
+			Help: "Number of receivers currently disabled for maintenance.",
+		}, func() float64 {
+			return float64(len(r.List()))
+		}))
+	}
+	return r
+}
+
+// SetBroadcast sets the function used to gossip changes.
+func (r *Receivers) SetBroadcast(f func([]byte)) {
+	r.mtx.Lock()
+	r.broadcast = f
+	r.mtx.Unlock()
+}
+
+// Disable disables the receiver for the given duration, replacing any
+// previous disabling of the receiver.
+func (r *Receivers) Disable(receiver, by, comment string, d time.Duration) (*Disable, error) {
+	if by == "" {
+		return nil, errors.New("missing creator")
+	}
+	if d <= 0 {
+		return nil, errors.New("duration must be positive")
+	}
+	now := r.clock.Now()
+	e := &Disable{
+		Receiver:   receiver,
+		CreatedBy:  by,
+		Comment:    comment,
+		DisabledAt: now,
+		EndsAt:     now.Add(d),
+		UpdatedAt:  now,
+	}
+	if err := r.set(e); err != nil {
+		return nil, err
+	}
+	res := *e
+	return &res, nil
+}
+
+// Enable enables the disabled receiver again.
+func (r *Receivers) Enable(receiver string) error {
+	now := r.clock.Now()
+	r.mtx.RLock()
+	prev, ok := r.st[receiver]
+	r.mtx.RUnlock()
+	if !ok || !prev.EndsAt.After(now) {
+		return ErrNotDisabled
+	}
+	// The ended disabling is kept until the previous version would have
+	// ended so that it overrides it when gossiped by the other peers.
+	e := *prev
+	e.EndsAt, e.UpdatedAt = now, now
+	return r.set(&e)
+}
+
+func (r *Receivers) set(e *Disable) error {
+	r.mtx.Lock()
+	if prev, ok := r.st[e.Receiver]; ok {
+		e.replace(prev)
+	}
+	b, err := json.Marshal([]*Disable{e})
+	if err != nil {
+		r.mtx.Unlock()
+		return err
+	}
+	r.st[e.Receiver] = e
+	broadcast := r.broadcast
+	r.mtx.Unlock()
+
+	broadcast(b)
+	return nil
+}
+
+// Get returns the disabling of the receiver, or nil if the receiver isn't
+// disabled.
+func (r *Receivers) Get(receiver string) *Disable {
+	now := r.clock.Now()
+
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	e, ok := r.st[receiver]
+	if !ok || !e.EndsAt.After(now) {
+		return nil
+	}
+	res := *e
+	return &res
+}
+
+// Disabled returns true if the receiver is disabled.
+func (r *Receivers) Disabled(receiver string) bool {
+	return r.Get(receiver) != nil
+}
+
+// List returns the disabled receivers ordered by name.
+func (r *Receivers) List() []*Disable {
+	now := r.clock.Now()
+
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	var res []*Disable
+	for _, e := range r.st {
+		if e.EndsAt.After(now) {
+			d := *e
+			res = append(res, &d)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Receiver < res[j].Receiver })
+	return res
+}
+
+// GC removes the expired disablings and returns how many were removed.
+func (r *Receivers) GC() int {
+	now := r.clock.Now()
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	var n int
+	for name, e := range r.st {
+		if !e.expiresAt().After(now) {
+			delete(r.st, name)
+			n++
+		}
+	}
+	return n
+}
+
+// Maintenance garbage collects the expired disablings at the given interval
+// until stopc is closed.
+func (r *Receivers) Maintenance(interval time.Duration, stopc <-chan struct{}) {
+	t := r.clock.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stopc:
+			return
+		case <-t.C:
+			if n := r.GC(); n > 0 {
+				r.logger.Debug("Garbage collected ended receiver disablings", "count", n)
+			}
+		}
+	}
+}
+
+// MarshalBinary implements cluster.State.
+func (r *Receivers) MarshalBinary() ([]byte, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	entries := make([]*Disable, 0, len(r.st))
+	for _, e := range r.st {
+		entries = append(entries, e)
+	}
+	return json.Marshal(entries)
+}
+
+// Merge implements cluster.State. Of two versions of the disabling of a
+// receiver, the most recently updated one wins.
+func (r *Receivers) Merge(b []byte) error {
+	var entries []*Disable
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return err
+	}
+	now := r.clock.Now()
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	var merged bool
+	for _, e := range entries {
+		prev, ok := r.st[e.Receiver]
+		if ok && !e.UpdatedAt.After(prev.UpdatedAt) {
+			prev.replace(e)
+			continue
+		}
+		// An ended disabling only matters to end the versions it replaced.
+		if !ok && !e.expiresAt().After(now) {
+			continue
+		}
+		if ok {
+			e.replace(prev)
+		}
+		r.st[e.Receiver] = e
+		merged = true
+		if e.EndsAt.After(now) {
+			r.logger.Info("Receiver disabled by peer", "receiver", e.Receiver, "created_by", e.CreatedBy, "ends_at", e.EndsAt)
+		} else {
+			r.logger.Info("Receiver enabled by peer", "receiver", e.Receiver)
+		}
+	}
+	// Gossip changes seen for the first time to the other peers unless the
+	// message is oversized, in which case it was sent to all peers already.
+	if merged && !cluster.OversizedMessage(b) {
+		r.broadcast(b)
+	}
+	return nil
+}
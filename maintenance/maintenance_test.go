@@ -0,0 +1,175 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coder/quartz"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReceivers(t *testing.T) (*Receivers, *quartz.Mock) {
+	clock := quartz.NewMock(t)
+	r := New(Options{})
+	r.clock = clock
+	return r, clock
+}
+
+func TestDisable(t *testing.T) {
+	r, clock := newTestReceivers(t)
+	var broadcasts int
+	r.SetBroadcast(func([]byte) { broadcasts++ })
+
+	require.False(t, r.Disabled("pager"))
+
+	d, err := r.Disable("pager", "alice", "pager outage", time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, &Disable{
+		Receiver:   "pager",
+		CreatedBy:  "alice",
+		Comment:    "pager outage",
+		DisabledAt: clock.Now(),
+		EndsAt:     clock.Now().Add(time.Hour),
+		UpdatedAt:  clock.Now(),
+	}, d)
+	require.Equal(t, d, r.Get("pager"))
+	require.True(t, r.Disabled("pager"))
+	require.False(t, r.Disabled("email"))
+	require.Equal(t, []*Disable{d}, r.List())
+	require.Equal(t, 1, broadcasts)
+
+	_, err = r.Disable("pager", "", "", time.Hour)
+	require.Error(t, err)
+	_, err = r.Disable("pager", "alice", "", 0)
+	require.Error(t, err)
+
+	// The disabling ends.
+	clock.Advance(time.Hour)
+	require.False(t, r.Disabled("pager"))
+	require.Empty(t, r.List())
+	require.ErrorIs(t, r.Enable("pager"), ErrNotDisabled)
+	require.Equal(t, 1, r.GC())
+	require.Empty(t, r.st)
+}
+
+func TestEnable(t *testing.T) {
+	r, clock := newTestReceivers(t)
+
+	require.ErrorIs(t, r.Enable("pager"), ErrNotDisabled)
+
+	_, err := r.Disable("pager", "alice", "", time.Hour)
+	require.NoError(t, err)
+	clock.Advance(time.Minute)
+	require.NoError(t, r.Enable("pager"))
+	require.False(t, r.Disabled("pager"))
+	require.ErrorIs(t, r.Enable("pager"), ErrNotDisabled)
+
+	// The ended disabling is kept until the previous version would have
+	// ended.
+	require.Equal(t, 0, r.GC())
+	require.Len(t, r.st, 1)
+	clock.Advance(59 * time.Minute)
+	require.Equal(t, 1, r.GC())
+}
+
+func TestReceiversMerge(t *testing.T) {
+	r1, clock := newTestReceivers(t)
+	r2, _ := newTestReceivers(t)
+	r2.clock = clock
+	var broadcasts int
+	r2.SetBroadcast(func([]byte) { broadcasts++ })
+
+	_, err := r1.Disable("pager", "alice", "", time.Hour)
+	require.NoError(t, err)
+	clock.Advance(time.Minute)
+	_, err = r2.Disable("pager", "bob", "", time.Hour)
+	require.NoError(t, err)
+	broadcasts = 0
+
+	// The older disabling of alice is ignored.
+	b, err := r1.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, r2.Merge(b))
+	require.Equal(t, "bob", r2.Get("pager").CreatedBy)
+	require.Equal(t, 0, broadcasts)
+
+	// The newer disabling of bob replaces the one of alice and is gossiped
+	// further.
+	b, err = r2.MarshalBinary()
+	require.NoError(t, err)
+	r1.SetBroadcast(func([]byte) { broadcasts++ })
+	require.NoError(t, r1.Merge(b))
+	require.Equal(t, "bob", r1.Get("pager").CreatedBy)
+	require.Equal(t, 1, broadcasts)
+
+	// Enabling the receiver again is gossiped too.
+	clock.Advance(time.Minute)
+	require.NoError(t, r2.Enable("pager"))
+	b, err = r2.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, r1.Merge(b))
+	require.False(t, r1.Disabled("pager"))
+
+	// Ended disablings of unknown receivers are merged until they expire,
+	// without disabling the receiver.
+	r3, clock3 := newTestReceivers(t)
+	clock3.Set(clock.Now())
+	require.NoError(t, r3.Merge(b))
+	require.False(t, r3.Disabled("pager"))
+	require.Len(t, r3.st, 1)
+
+	r4, clock4 := newTestReceivers(t)
+	clock4.Set(clock.Now().Add(time.Hour))
+	require.NoError(t, r4.Merge(b))
+	require.Empty(t, r4.st)
+}
+
+func TestReceiversMergeAfterGC(t *testing.T) {
+	r1, clock := newTestReceivers(t)
+	r2, _ := newTestReceivers(t)
+	r2.clock = clock
+
+	_, err := r1.Disable("pager", "alice", "", time.Hour)
+	require.NoError(t, err)
+	b, err := r1.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, r2.Merge(b))
+
+	// The receiver is enabled again on the first peer while the second one
+	// misses the change.
+	clock.Advance(time.Minute)
+	require.NoError(t, r1.Enable("pager"))
+
+	// Once garbage collected, the first peer still rejects the disabling
+	// gossiped by the second one.
+	clock.Advance(20 * time.Minute)
+	r1.GC()
+	b, err = r2.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, r1.Merge(b))
+	require.False(t, r1.Disabled("pager"))
+
+	// And enables the receiver on the second peer.
+	b, err = r1.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, r2.Merge(b))
+	require.False(t, r2.Disabled("pager"))
+
+	// Both forget about it once the disabling would have ended.
+	clock.Advance(40 * time.Minute)
+	require.Equal(t, 1, r1.GC())
+	require.Equal(t, 1, r2.GC())
+}
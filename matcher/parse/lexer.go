@@ -307,3 +307,36 @@ func (l *lexer) skip() {
 	l.start = l.pos
 	l.column = l.cols
 }
+
+// Token is a token of the input returned by Tokens.
+type Token struct {
+	Kind        string `json:"kind"`
+	Value       string `json:"value"`
+	ColumnStart int    `json:"columnStart"`
+	ColumnEnd   int    `json:"columnEnd"`
+}
+
+// Tokens scans the tokens of the input. If the input does not conform to the
+// grammar, it returns the tokens scanned before the invalid input and the
+// error.
+func Tokens(input string) ([]Token, error) {
+	var (
+		l   = lexer{input: input}
+		res []Token
+	)
+	for {
+		t, err := l.scan()
+		if err != nil {
+			return res, err
+		}
+		if t.isEOF() {
+			return res, nil
+		}
+		res = append(res, Token{
+			Kind:        t.kind.String(),
+			Value:       t.value,
+			ColumnStart: t.columnStart,
+			ColumnEnd:   t.columnEnd,
+		})
+	}
+}
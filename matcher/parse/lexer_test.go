@@ -650,3 +650,27 @@ func TestLexer_Pos(t *testing.T) {
 	require.True(t, tok.isEOF())
 	require.Equal(t, expected, l.position())
 }
+
+func TestTokens(t *testing.T) {
+	tokens, err := Tokens(`{foo="bar", env=~prod}`)
+	require.NoError(t, err)
+	require.Equal(t, []Token{
+		{Kind: "OpenBrace", Value: "{", ColumnStart: 0, ColumnEnd: 1},
+		{Kind: "Unquoted", Value: "foo", ColumnStart: 1, ColumnEnd: 4},
+		{Kind: "Equals", Value: "=", ColumnStart: 4, ColumnEnd: 5},
+		{Kind: "Quoted", Value: `"bar"`, ColumnStart: 5, ColumnEnd: 10},
+		{Kind: "Comma", Value: ",", ColumnStart: 10, ColumnEnd: 11},
+		{Kind: "Unquoted", Value: "env", ColumnStart: 12, ColumnEnd: 15},
+		{Kind: "Matches", Value: "=~", ColumnStart: 15, ColumnEnd: 17},
+		{Kind: "Unquoted", Value: "prod", ColumnStart: 17, ColumnEnd: 21},
+		{Kind: "CloseBrace", Value: "}", ColumnStart: 21, ColumnEnd: 22},
+	}, tokens)
+
+	// The tokens before the invalid input are returned with the error.
+	tokens, err = Tokens(`foo="bar`)
+	require.EqualError(t, err, `4:8: "bar: missing end "`)
+	require.Equal(t, []Token{
+		{Kind: "Unquoted", Value: "foo", ColumnStart: 0, ColumnEnd: 3},
+		{Kind: "Equals", Value: "=", ColumnStart: 3, ColumnEnd: 4},
+	}, tokens)
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+// The names of the pseudo-matchers.
+const (
+	// PseudoAge matches the duration since the alert started, e.g. @age>30m.
+	PseudoAge = "age"
+	// PseudoStartsAt matches the start time of the alert, e.g.
+	// @startsAt>=2024-01-01T00:00:00Z.
+	PseudoStartsAt = "startsAt"
+	// PseudoUpdatedAt matches the last time the alert was received.
+	PseudoUpdatedAt = "updatedAt"
+	// PseudoState matches the state of the alert, e.g. @state=suppressed.
+	PseudoState = "state"
+)
+
+// pseudoOps are the operators of the pseudo-matchers, the longest first.
+var pseudoOps = []string{">=", "<=", "!=", "=", ">", "<"}
+
+// PseudoMatcher matches a property of an alert rather than one of its
+// labels. It is written as the name of the property prefixed with "@", an
+// operator among =, !=, >, >=, < and <=, and a value. Time ranges are written
+// as two pseudo-matchers, e.g. @age>30m and @age<2h.
+type PseudoMatcher struct {
+	Name  string
+	Op    string
+	Value string
+
+	duration time.Duration
+	time     time.Time
+}
+
+// IsPseudoMatcher returns true if the input is a pseudo-matcher rather than a
+// label matcher.
+func IsPseudoMatcher(input string) bool {
+	return strings.HasPrefix(strings.TrimSpace(input), "@")
+}
+
+// ParsePseudoMatcher parses the pseudo-matcher in the input string. It
+// returns an error if the input is invalid or the property is unknown.
+func ParsePseudoMatcher(input string) (*PseudoMatcher, error) {
+	s, ok := strings.CutPrefix(strings.TrimSpace(input), "@")
+	if !ok {
+		return nil, fmt.Errorf("pseudo-matcher %q must start with '@'", input)
+	}
+	i := strings.IndexAny(s, "=!<>")
+	if i < 0 {
+		return nil, fmt.Errorf("pseudo-matcher %q: %w", input, errNoOperator)
+	}
+	m := &PseudoMatcher{Name: strings.TrimSpace(s[:i])}
+	s = s[i:]
+	for _, op := range pseudoOps {
+		if strings.HasPrefix(s, op) {
+			m.Op = op
+			break
+		}
+	}
+	if m.Op == "" {
+		return nil, fmt.Errorf("pseudo-matcher %q: %w", input, errNoOperator)
+	}
+	m.Value = strings.TrimSpace(s[len(m.Op):])
+	if strings.HasPrefix(m.Value, `"`) {
+		v, err := strconv.Unquote(m.Value)
+		if err != nil {
+			return nil, fmt.Errorf("pseudo-matcher %q: invalid quoted value: %w", input, err)
+		}
+		m.Value = v
+	}
+
+	switch m.Name {
+	case PseudoAge:
+		d, err := model.ParseDuration(m.Value)
+		if err != nil {
+			return nil, fmt.Errorf("pseudo-matcher %q: %w", input, err)
+		}
+		m.duration = time.Duration(d)
+	case PseudoStartsAt, PseudoUpdatedAt:
+		t, err := time.Parse(time.RFC3339, m.Value)
+		if err != nil {
+			return nil, fmt.Errorf("pseudo-matcher %q: invalid time, expected RFC3339: %w", input, err)
+		}
+		m.time = t
+	case PseudoState:
+		if m.Op != "=" && m.Op != "!=" {
+			return nil, fmt.Errorf("pseudo-matcher %q: the state only supports '=' and '!='", input)
+		}
+		switch m.Value {
+		case "active", "suppressed", "unprocessed":
+		default:
+			return nil, fmt.Errorf("pseudo-matcher %q: unknown state %q, expected one of active, suppressed or unprocessed", input, m.Value)
+		}
+	default:
+		return nil, fmt.Errorf("unknown pseudo-matcher %q, expected one of @%s, @%s, @%s or @%s", "@"+m.Name, PseudoAge, PseudoStartsAt, PseudoUpdatedAt, PseudoState)
+	}
+	return m, nil
+}
+
+// MatchesDuration returns true if the duration compares to the value of the
+// matcher as required by its operator.
+func (m *PseudoMatcher) MatchesDuration(d time.Duration) bool {
+	return m.matches(cmp.Compare(d, m.duration))
+}
+
+// MatchesTime returns true if the time compares to the value of the matcher
+// as required by its operator.
+func (m *PseudoMatcher) MatchesTime(t time.Time) bool {
+	return m.matches(t.Compare(m.time))
+}
+
+// MatchesValue returns true if the string compares to the value of the
+// matcher as required by its operator.
+func (m *PseudoMatcher) MatchesValue(v string) bool {
+	return m.matches(strings.Compare(v, m.Value))
+}
+
+func (m *PseudoMatcher) matches(c int) bool {
+	switch m.Op {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	}
+	return false
+}
+
+func (m *PseudoMatcher) String() string {
+	return "@" + m.Name + m.Op + m.Value
+}
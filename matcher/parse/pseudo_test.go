@@ -0,0 +1,88 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePseudoMatcher(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		name  string
+		op    string
+		value string
+		err   string
+	}{
+		{input: "@age>30m", name: "age", op: ">", value: "30m"},
+		{input: " @age <= 1h30m ", name: "age", op: "<=", value: "1h30m"},
+		{input: `@state="suppressed"`, name: "state", op: "=", value: "suppressed"},
+		{input: "@state!=active", name: "state", op: "!=", value: "active"},
+		{input: "@startsAt>=2024-01-01T00:00:00Z", name: "startsAt", op: ">=", value: "2024-01-01T00:00:00Z"},
+		{input: "@updatedAt<2024-01-01T01:00:00+01:00", name: "updatedAt", op: "<", value: "2024-01-01T01:00:00+01:00"},
+		{input: "age>30m", err: `pseudo-matcher "age>30m" must start with '@'`},
+		{input: "@age", err: `pseudo-matcher "@age": expected an operator such as '=', '!=', '=~' or '!~'`},
+		{input: "@age=~30m", err: `pseudo-matcher "@age=~30m": not a valid duration string: "~30m"`},
+		{input: "@age>soon", err: `pseudo-matcher "@age>soon": not a valid duration string: "soon"`},
+		{input: "@startsAt>yesterday", err: `pseudo-matcher "@startsAt>yesterday": invalid time, expected RFC3339`},
+		{input: "@state>active", err: `pseudo-matcher "@state>active": the state only supports '=' and '!='`},
+		{input: "@state=silenced", err: `pseudo-matcher "@state=silenced": unknown state "silenced", expected one of active, suppressed or unprocessed`},
+		{input: "@severity=critical", err: `unknown pseudo-matcher "@severity", expected one of @age, @startsAt, @updatedAt or @state`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			m, err := ParsePseudoMatcher(tc.input)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.name, m.Name)
+			require.Equal(t, tc.op, m.Op)
+			require.Equal(t, tc.value, m.Value)
+		})
+	}
+}
+
+func TestPseudoMatcherMatches(t *testing.T) {
+	mustParse := func(input string) *PseudoMatcher {
+		m, err := ParsePseudoMatcher(input)
+		require.NoError(t, err)
+		return m
+	}
+
+	require.True(t, mustParse("@age>30m").MatchesDuration(time.Hour))
+	require.False(t, mustParse("@age>30m").MatchesDuration(30*time.Minute))
+	require.True(t, mustParse("@age>=30m").MatchesDuration(30*time.Minute))
+	require.True(t, mustParse("@age<1d").MatchesDuration(time.Hour))
+	require.False(t, mustParse("@age<=1h").MatchesDuration(2*time.Hour))
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.True(t, mustParse("@startsAt=2024-01-01T01:00:00+01:00").MatchesTime(start))
+	require.True(t, mustParse("@startsAt<2024-01-01T00:00:01Z").MatchesTime(start))
+	require.False(t, mustParse("@startsAt>2024-01-01T00:00:00Z").MatchesTime(start))
+
+	require.True(t, mustParse("@state=suppressed").MatchesValue("suppressed"))
+	require.False(t, mustParse("@state!=suppressed").MatchesValue("suppressed"))
+	require.True(t, mustParse("@state!=suppressed").MatchesValue("active"))
+}
+
+func TestIsPseudoMatcher(t *testing.T) {
+	require.True(t, IsPseudoMatcher("@age>30m"))
+	require.True(t, IsPseudoMatcher(" @state=active"))
+	require.False(t, IsPseudoMatcher("alertname=foo"))
+	require.False(t, IsPseudoMatcher(`foo="@bar"`))
+}
@@ -34,7 +34,9 @@ import (
 	"github.com/prometheus/common/promslog"
 
 	"github.com/prometheus/alertmanager/cluster"
+	"github.com/prometheus/alertmanager/encryption"
 	pb "github.com/prometheus/alertmanager/nflog/nflogpb"
+	"github.com/prometheus/alertmanager/snapshot"
 )
 
 // ErrNotFound is returned for empty query results.
@@ -87,6 +89,7 @@ type Log struct {
 	mtx       sync.RWMutex
 	st        state
 	broadcast func([]byte)
+	cipher    *encryption.Cipher
 }
 
 // MaintenanceFunc represents the function to run as part of the periodic maintenance for the nflog.
@@ -224,6 +227,27 @@ func decodeState(r io.Reader) (state, error) {
 	return st, nil
 }
 
+// VerifySnapshot checks that all entries of the notification log snapshot
+// read from r can be decoded. If w is not nil, a repaired snapshot without the
+// undecodable entries is written to it.
+func VerifySnapshot(r io.Reader, c *encryption.Cipher, now time.Time, w io.Writer) (snapshot.Report, error) {
+	return snapshot.Verify(r, c, func(b []byte) (snapshot.EntryInfo, error) {
+		var e pb.MeshEntry
+		if err := e.Unmarshal(b); err != nil {
+			return snapshot.EntryInfo{}, err
+		}
+		if e.Entry == nil || e.Entry.Receiver == nil {
+			return snapshot.EntryInfo{}, ErrInvalidState
+		}
+		return snapshot.EntryInfo{
+			// Entries written before the alert hashes were recorded only
+			// contain the group hash.
+			Legacy:  len(e.Entry.FiringAlerts) == 0 && len(e.Entry.ResolvedAlerts) == 0,
+			Expired: !e.ExpiresAt.After(now),
+		}, nil
+	}, w)
+}
+
 func marshalMeshEntry(e *pb.MeshEntry) ([]byte, error) {
 	var buf bytes.Buffer
 	if _, err := pbutil.WriteDelimited(&buf, e); err != nil {
@@ -239,6 +263,10 @@ type Options struct {
 
 	Retention time.Duration
 
+	// Cipher encrypts snapshots if set. Unencrypted snapshots can still be
+	// loaded.
+	Cipher *encryption.Cipher
+
 	Logger  *slog.Logger
 	Metrics prometheus.Registerer
 }
@@ -265,6 +293,7 @@ func New(o Options) (*Log, error) {
 		st:        state{},
 		broadcast: func([]byte) {},
 		metrics:   newMetrics(o.Metrics),
+		cipher:    o.Cipher,
 	}
 
 	if o.Logger != nil {
@@ -377,7 +406,7 @@ func stateKey(k string, r *pb.Receiver) string {
 	return fmt.Sprintf("%s:%s", k, receiverKey(r))
 }
 
-func (l *Log) Log(r *pb.Receiver, gkey string, firingAlerts, resolvedAlerts []uint64, expiry time.Duration) error {
+func (l *Log) Log(r *pb.Receiver, gkey string, firingAlerts, resolvedAlerts []uint64, receiverData map[string]string, origins []string, failureReason string, expiry time.Duration) error {
 	// Write all st with the same timestamp.
 	now := l.now()
 	key := stateKey(gkey, r)
@@ -405,6 +434,9 @@ func (l *Log) Log(r *pb.Receiver, gkey string, firingAlerts, resolvedAlerts []ui
 			Timestamp:      now,
 			FiringAlerts:   firingAlerts,
 			ResolvedAlerts: resolvedAlerts,
+			ReceiverData:   receiverData,
+			Origins:        origins,
+			FailureReason:  failureReason,
 		},
 		ExpiresAt: expiresAt,
 	}
@@ -480,6 +512,10 @@ func (l *Log) Query(params ...QueryParam) ([]*pb.Entry, error) {
 
 // loadSnapshot loads a snapshot generated by Snapshot() into the state.
 func (l *Log) loadSnapshot(r io.Reader) error {
+	r, err := encryption.Open(l.cipher, r)
+	if err != nil {
+		return err
+	}
 	st, err := decodeState(r)
 	if err != nil {
 		return err
@@ -504,6 +540,9 @@ func (l *Log) Snapshot(w io.Writer) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
+	if b, err = encryption.Seal(l.cipher, b); err != nil {
+		return 0, err
+	}
 
 	return io.Copy(w, bytes.NewReader(b))
 }
@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/alertmanager/encryption"
 	pb "github.com/prometheus/alertmanager/nflog/nflogpb"
 
 	"github.com/coder/quartz"
@@ -86,6 +87,12 @@ func TestLogSnapshot(t *testing.T) {
 						GroupHash: []byte("122c2331b9d1bbd07fddc65819a542c3"),
 						Resolved:  true,
 						Timestamp: now,
+						ReceiverData: map[string]string{
+							"channel": "C123ABC456",
+							"ts":      "1503435956.000247",
+						},
+						Origins:       []string{"prometheus-0", "prometheus-1"},
+						FailureReason: "serverError",
 					},
 					ExpiresAt: now,
 				}, {
@@ -131,6 +138,35 @@ func TestLogSnapshot(t *testing.T) {
 	}
 }
 
+func TestLogSnapshotEncrypted(t *testing.T) {
+	c, err := encryption.New(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+
+	now := quartz.NewMock(t).Now().UTC()
+	e := &pb.MeshEntry{
+		Entry: &pb.Entry{
+			GroupKey:  []byte("secret-group-key"),
+			Receiver:  &pb.Receiver{GroupName: "abc", Integration: "test1", Idx: 1},
+			Timestamp: now,
+		},
+		ExpiresAt: now,
+	}
+	l1 := &Log{st: state{}, metrics: newMetrics(nil), cipher: c}
+	l1.st[stateKey(string(e.Entry.GroupKey), e.Entry.Receiver)] = e
+
+	var buf bytes.Buffer
+	_, err = l1.Snapshot(&buf)
+	require.NoError(t, err)
+	require.NotContains(t, buf.String(), "secret-group-key")
+
+	l2 := &Log{}
+	require.ErrorIs(t, l2.loadSnapshot(bytes.NewReader(buf.Bytes())), encryption.ErrMissingKey)
+
+	l3 := &Log{cipher: c}
+	require.NoError(t, l3.loadSnapshot(bytes.NewReader(buf.Bytes())))
+	require.Equal(t, l1.st, l3.st)
+}
+
 func TestWithMaintenance_SupportsCustomCallback(t *testing.T) {
 	f, err := os.CreateTemp("", "snapshot")
 	require.NoError(t, err, "creating temp file failed")
@@ -298,6 +334,10 @@ func TestStateDataCoding(t *testing.T) {
 						GroupHash: []byte("122c2331b9d1bbd07fddc65819a542c3"),
 						Resolved:  true,
 						Timestamp: now,
+						ReceiverData: map[string]string{
+							"channel": "C123ABC456",
+							"ts":      "1503435956.000247",
+						},
 					},
 					ExpiresAt: now,
 				}, {
@@ -355,7 +395,9 @@ func TestQuery(t *testing.T) {
 	firingAlerts := []uint64{1, 2, 3}
 	resolvedAlerts := []uint64{4, 5}
 
-	err = nl.Log(recv, "key", firingAlerts, resolvedAlerts, 0)
+	receiverData := map[string]string{"ts": "1503435956.000247"}
+
+	err = nl.Log(recv, "key", firingAlerts, resolvedAlerts, receiverData, []string{"prometheus-0"}, "serverError", 0)
 	require.NoError(t, err, "logging notification failed")
 
 	entries, err := nl.Query(QGroupKey("key"), QReceiver(recv))
@@ -363,6 +405,9 @@ func TestQuery(t *testing.T) {
 	entry := entries[0]
 	require.EqualValues(t, firingAlerts, entry.FiringAlerts)
 	require.EqualValues(t, resolvedAlerts, entry.ResolvedAlerts)
+	require.Equal(t, receiverData, entry.ReceiverData)
+	require.Equal(t, []string{"prometheus-0"}, entry.Origins)
+	require.Equal(t, "serverError", entry.FailureReason)
 }
 
 func TestStateDecodingError(t *testing.T) {
@@ -92,7 +92,16 @@ type Entry struct {
 	// FiringAlerts list of hashes of firing alerts at the last notification time.
 	FiringAlerts []uint64 `protobuf:"varint,6,rep,packed,name=firing_alerts,json=firingAlerts,proto3" json:"firing_alerts,omitempty"`
 	// ResolvedAlerts list of hashes of resolved alerts at the last notification time.
-	ResolvedAlerts       []uint64 `protobuf:"varint,7,rep,packed,name=resolved_alerts,json=resolvedAlerts,proto3" json:"resolved_alerts,omitempty"`
+	ResolvedAlerts []uint64 `protobuf:"varint,7,rep,packed,name=resolved_alerts,json=resolvedAlerts,proto3" json:"resolved_alerts,omitempty"`
+	// ReceiverData holds data returned by the integration for the notification,
+	// such as the identifier of the sent message, for later notifications of the
+	// group to refer to.
+	ReceiverData map[string]string `protobuf:"bytes,8,rep,name=receiver_data,json=receiverData,proto3" json:"receiver_data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Origins are the senders of the notified alerts.
+	Origins []string `protobuf:"bytes,9,rep,name=origins,proto3" json:"origins,omitempty"`
+	// FailureReason is the reason of the last failed attempt of the
+	// notification before it succeeded, if any.
+	FailureReason        string   `protobuf:"bytes,10,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -180,38 +189,44 @@ var xxx_messageInfo_MeshEntry proto.InternalMessageInfo
 func init() {
 	proto.RegisterType((*Receiver)(nil), "nflogpb.Receiver")
 	proto.RegisterType((*Entry)(nil), "nflogpb.Entry")
+	proto.RegisterMapType((map[string]string)(nil), "nflogpb.Entry.ReceiverDataEntry")
 	proto.RegisterType((*MeshEntry)(nil), "nflogpb.MeshEntry")
 }
 
 func init() { proto.RegisterFile("nflog.proto", fileDescriptor_c2d9785ad9c3e602) }
 
 var fileDescriptor_c2d9785ad9c3e602 = []byte{
-	// 385 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x90, 0xcf, 0x6e, 0xd3, 0x40,
-	0x10, 0xc6, 0xbb, 0x4d, 0xd3, 0xda, 0xe3, 0xb4, 0x94, 0x15, 0x07, 0xcb, 0x08, 0xc7, 0x0a, 0x48,
-	0xf8, 0x82, 0x23, 0x95, 0x27, 0x68, 0x10, 0x12, 0x12, 0x82, 0xc3, 0x8a, 0x2b, 0xb2, 0x36, 0x74,
-	0xb2, 0x5e, 0x61, 0x7b, 0xad, 0xf5, 0x36, 0x6a, 0xde, 0x82, 0x47, 0xe0, 0x71, 0x72, 0xe4, 0x09,
-	0xf8, 0x93, 0x27, 0x41, 0xde, 0xb5, 0x1d, 0x8e, 0xdc, 0x66, 0x7f, 0xf3, 0xcd, 0xcc, 0xb7, 0x1f,
-	0x04, 0xf5, 0xa6, 0x54, 0x22, 0x6b, 0xb4, 0x32, 0x8a, 0x5e, 0xd8, 0x47, 0xb3, 0x8e, 0xe6, 0x42,
-	0x29, 0x51, 0xe2, 0xd2, 0xe2, 0xf5, 0xfd, 0x66, 0x69, 0x64, 0x85, 0xad, 0xe1, 0x55, 0xe3, 0x94,
-	0xd1, 0x13, 0xa1, 0x84, 0xb2, 0xe5, 0xb2, 0xab, 0x1c, 0x5d, 0x7c, 0x06, 0x8f, 0xe1, 0x17, 0x94,
-	0x5b, 0xd4, 0xf4, 0x19, 0x80, 0xd0, 0xea, 0xbe, 0xc9, 0x6b, 0x5e, 0x61, 0x48, 0x12, 0x92, 0xfa,
-	0xcc, 0xb7, 0xe4, 0x23, 0xaf, 0x90, 0x26, 0x10, 0xc8, 0xda, 0xa0, 0xd0, 0xdc, 0x48, 0x55, 0x87,
-	0xa7, 0xb6, 0xff, 0x2f, 0xa2, 0xd7, 0x30, 0x91, 0x77, 0x0f, 0xe1, 0x24, 0x21, 0xe9, 0x25, 0xeb,
-	0xca, 0xc5, 0xf7, 0x53, 0x98, 0xbe, 0xad, 0x8d, 0xde, 0xd1, 0xa7, 0xe0, 0x56, 0xe5, 0x5f, 0x71,
-	0x67, 0x77, 0xcf, 0x98, 0x67, 0xc1, 0x7b, 0xdc, 0xd1, 0x57, 0xe0, 0xe9, 0xde, 0x85, 0xdd, 0x1b,
-	0xdc, 0x3c, 0xce, 0xfa, 0x8f, 0x65, 0x83, 0x3d, 0x36, 0x4a, 0x8e, 0x46, 0x0b, 0xde, 0x16, 0xf6,
-	0xdc, 0xac, 0x37, 0xfa, 0x8e, 0xb7, 0x05, 0x8d, 0xba, 0x6d, 0xad, 0x2a, 0xb7, 0x78, 0x17, 0x9e,
-	0x25, 0x24, 0xf5, 0xd8, 0xf8, 0xa6, 0x2b, 0xf0, 0xc7, 0x60, 0xc2, 0xa9, 0x3d, 0x15, 0x65, 0x2e,
-	0xba, 0x6c, 0x88, 0x2e, 0xfb, 0x34, 0x28, 0x56, 0xde, 0xfe, 0xe7, 0xfc, 0xe4, 0xdb, 0xaf, 0x39,
-	0x61, 0xc7, 0x31, 0xfa, 0x1c, 0x2e, 0x37, 0x52, 0xcb, 0x5a, 0xe4, 0xbc, 0x44, 0x6d, 0xda, 0xf0,
-	0x3c, 0x99, 0xa4, 0x67, 0x6c, 0xe6, 0xe0, 0xad, 0x65, 0xf4, 0x25, 0x3c, 0x1a, 0x8e, 0x0e, 0xb2,
-	0x0b, 0x2b, 0xbb, 0x1a, 0xb0, 0x13, 0x2e, 0xb6, 0xe0, 0x7f, 0xc0, 0xb6, 0x70, 0x29, 0xbd, 0x80,
-	0x29, 0x76, 0x85, 0x4d, 0x28, 0xb8, 0xb9, 0x1a, 0x53, 0xb0, 0x6d, 0xe6, 0x9a, 0xf4, 0x0d, 0x00,
-	0x3e, 0x34, 0x52, 0x63, 0x9b, 0x73, 0xd3, 0x07, 0xf6, 0x9f, 0xbf, 0xe8, 0xe7, 0x6e, 0xcd, 0xea,
-	0x7a, 0xff, 0x27, 0x3e, 0xd9, 0x1f, 0x62, 0xf2, 0xe3, 0x10, 0x93, 0xdf, 0x87, 0x98, 0xac, 0xcf,
-	0xed, 0xe8, 0xeb, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x49, 0xcd, 0xa7, 0x1e, 0x61, 0x02, 0x00,
-	0x00,
+	// 471 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0xcb, 0x6e, 0xd3, 0x40,
+	0x14, 0x86, 0xeb, 0xb8, 0x69, 0xec, 0x93, 0x0b, 0xed, 0xa8, 0x8b, 0x91, 0x11, 0x89, 0x15, 0x40,
+	0x78, 0x83, 0x23, 0x85, 0x0d, 0x62, 0x83, 0x1a, 0xa8, 0x84, 0x84, 0x60, 0x31, 0x62, 0x8b, 0xac,
+	0x09, 0x39, 0x71, 0x46, 0x38, 0x1e, 0x6b, 0x3c, 0x89, 0x9a, 0xb7, 0xe0, 0xb1, 0xb2, 0xe4, 0x09,
+	0xb8, 0x64, 0xc7, 0x5b, 0x20, 0x8f, 0x3d, 0xa1, 0xa8, 0xab, 0xee, 0xce, 0xf9, 0xe6, 0xdc, 0xe6,
+	0xff, 0xa1, 0x9b, 0x2f, 0x33, 0x99, 0xc6, 0x85, 0x92, 0x5a, 0x92, 0x8e, 0x49, 0x8a, 0x79, 0x30,
+	0x4a, 0xa5, 0x4c, 0x33, 0x9c, 0x18, 0x3c, 0xdf, 0x2c, 0x27, 0x5a, 0xac, 0xb1, 0xd4, 0x7c, 0x5d,
+	0xd4, 0x95, 0xc1, 0x65, 0x2a, 0x53, 0x69, 0xc2, 0x49, 0x15, 0xd5, 0x74, 0xfc, 0x19, 0x3c, 0x86,
+	0x5f, 0x50, 0x6c, 0x51, 0x91, 0x47, 0x00, 0xa9, 0x92, 0x9b, 0x22, 0xc9, 0xf9, 0x1a, 0xa9, 0x13,
+	0x3a, 0x91, 0xcf, 0x7c, 0x43, 0x3e, 0xf2, 0x35, 0x92, 0x10, 0xba, 0x22, 0xd7, 0x98, 0x2a, 0xae,
+	0x85, 0xcc, 0x69, 0xcb, 0xbc, 0xdf, 0x46, 0xe4, 0x1c, 0x5c, 0xb1, 0xb8, 0xa1, 0x6e, 0xe8, 0x44,
+	0x7d, 0x56, 0x85, 0xe3, 0x3f, 0x2e, 0xb4, 0xaf, 0x73, 0xad, 0x76, 0xe4, 0x21, 0xd4, 0xa3, 0x92,
+	0xaf, 0xb8, 0x33, 0xb3, 0x7b, 0xcc, 0x33, 0xe0, 0x3d, 0xee, 0xc8, 0x73, 0xf0, 0x54, 0x73, 0x85,
+	0x99, 0xdb, 0x9d, 0x5e, 0xc4, 0xcd, 0xc7, 0x62, 0x7b, 0x1e, 0xf3, 0xd4, 0x9d, 0x43, 0x57, 0xbc,
+	0x5c, 0x99, 0x75, 0xbd, 0xe6, 0xd0, 0x77, 0xbc, 0x5c, 0x91, 0xa0, 0x9a, 0x56, 0xca, 0x6c, 0x8b,
+	0x0b, 0x7a, 0x1a, 0x3a, 0x91, 0xc7, 0x8e, 0x39, 0x99, 0x81, 0x7f, 0x14, 0x86, 0xb6, 0xcd, 0xaa,
+	0x20, 0xae, 0xa5, 0x8b, 0xad, 0x74, 0xf1, 0x27, 0x5b, 0x31, 0xf3, 0xf6, 0x3f, 0x46, 0x27, 0xdf,
+	0x7e, 0x8e, 0x1c, 0xf6, 0xaf, 0x8d, 0x3c, 0x86, 0xfe, 0x52, 0x28, 0x91, 0xa7, 0x09, 0xcf, 0x50,
+	0xe9, 0x92, 0x9e, 0x85, 0x6e, 0x74, 0xca, 0x7a, 0x35, 0xbc, 0x32, 0x8c, 0x3c, 0x83, 0x07, 0x76,
+	0xa9, 0x2d, 0xeb, 0x98, 0xb2, 0x81, 0xc5, 0x4d, 0xe1, 0x35, 0xf4, 0xed, 0xc7, 0x92, 0x05, 0xd7,
+	0x9c, 0x7a, 0xa1, 0x1b, 0x75, 0xa7, 0xe1, 0x51, 0x00, 0xa3, 0xdf, 0x51, 0x86, 0xb7, 0x5c, 0x73,
+	0x43, 0x58, 0x4f, 0xdd, 0x42, 0x84, 0x42, 0x47, 0x2a, 0x91, 0x8a, 0xbc, 0xa4, 0x7e, 0xe8, 0x46,
+	0x3e, 0xb3, 0x29, 0x79, 0x0a, 0x83, 0x25, 0x17, 0xd9, 0x46, 0x61, 0xa2, 0x90, 0x97, 0x32, 0xa7,
+	0x60, 0xac, 0xeb, 0x37, 0x94, 0x19, 0x18, 0xbc, 0x86, 0x8b, 0x3b, 0x3b, 0x2a, 0x47, 0xad, 0x5f,
+	0x3e, 0xab, 0x42, 0x72, 0x09, 0xed, 0x2d, 0xcf, 0x36, 0xd8, 0xf8, 0x5f, 0x27, 0xaf, 0x5a, 0x2f,
+	0x9d, 0xf1, 0x16, 0xfc, 0x0f, 0x58, 0xae, 0xea, 0xc6, 0x27, 0xd0, 0xc6, 0x2a, 0x30, 0xad, 0xdd,
+	0xe9, 0xe0, 0xff, 0xdf, 0xb0, 0xfa, 0x91, 0xbc, 0x01, 0xc0, 0x9b, 0x42, 0x28, 0x2c, 0x13, 0xae,
+	0x69, 0xeb, 0x3e, 0x76, 0x34, 0x7d, 0x57, 0x7a, 0x76, 0xbe, 0xff, 0x3d, 0x3c, 0xd9, 0x1f, 0x86,
+	0xce, 0xf7, 0xc3, 0xd0, 0xf9, 0x75, 0x18, 0x3a, 0xf3, 0x33, 0xd3, 0xfa, 0xe2, 0xef, 0x00, 0x70,
+	0x2e, 0x6d, 0x6c, 0x2a, 0x03, 0x00, 0x00,
 }
 
 func (m *Receiver) Marshal() (dAtA []byte, err error) {
@@ -284,6 +299,41 @@ func (m *Entry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.FailureReason) > 0 {
+		i -= len(m.FailureReason)
+		copy(dAtA[i:], m.FailureReason)
+		i = encodeVarintNflog(dAtA, i, uint64(len(m.FailureReason)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.Origins) > 0 {
+		for iNdEx := len(m.Origins) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Origins[iNdEx])
+			copy(dAtA[i:], m.Origins[iNdEx])
+			i = encodeVarintNflog(dAtA, i, uint64(len(m.Origins[iNdEx])))
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if len(m.ReceiverData) > 0 {
+		for k := range m.ReceiverData {
+			v := m.ReceiverData[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintNflog(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintNflog(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintNflog(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
 	if len(m.ResolvedAlerts) > 0 {
 		dAtA2 := make([]byte, len(m.ResolvedAlerts)*10)
 		var j1 int
@@ -485,6 +535,24 @@ func (m *Entry) Size() (n int) {
 		}
 		n += 1 + sovNflog(uint64(l)) + l
 	}
+	if len(m.ReceiverData) > 0 {
+		for k, v := range m.ReceiverData {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovNflog(uint64(len(k))) + 1 + len(v) + sovNflog(uint64(len(v)))
+			n += mapEntrySize + 1 + sovNflog(uint64(mapEntrySize))
+		}
+	}
+	if len(m.Origins) > 0 {
+		for _, s := range m.Origins {
+			l = len(s)
+			n += 1 + l + sovNflog(uint64(l))
+		}
+	}
+	l = len(m.FailureReason)
+	if l > 0 {
+		n += 1 + l + sovNflog(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -987,6 +1055,197 @@ func (m *Entry) Unmarshal(dAtA []byte) error {
 			} else {
 				return fmt.Errorf("proto: wrong wireType = %d for field ResolvedAlerts", wireType)
 			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReceiverData", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNflog
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthNflog
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthNflog
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReceiverData == nil {
+				m.ReceiverData = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowNflog
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowNflog
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthNflog
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthNflog
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowNflog
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthNflog
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthNflog
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipNflog(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthNflog
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.ReceiverData[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Origins", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNflog
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNflog
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNflog
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Origins = append(m.Origins, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FailureReason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNflog
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthNflog
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNflog
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FailureReason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNflog(dAtA[iNdEx:])
@@ -0,0 +1,79 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+// AnnotationFilter selects the annotations of the alerts sent by the
+// integrations of a receiver.
+type AnnotationFilter struct {
+	// Include are the only annotations kept, if not empty.
+	Include []string
+	// Exclude are the annotations removed.
+	Exclude []string
+}
+
+func (f AnnotationFilter) empty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// AnnotationFilterStage filters the annotations of the alerts passed to the
+// next stages. The alerts are copied, the stored ones keep all their
+// annotations.
+type AnnotationFilterStage struct {
+	include map[model.LabelName]struct{}
+	exclude map[model.LabelName]struct{}
+}
+
+// NewAnnotationFilterStage returns a new AnnotationFilterStage.
+func NewAnnotationFilterStage(f AnnotationFilter) *AnnotationFilterStage {
+	toSet := func(names []string) map[model.LabelName]struct{} {
+		if len(names) == 0 {
+			return nil
+		}
+		res := make(map[model.LabelName]struct{}, len(names))
+		for _, n := range names {
+			res[model.LabelName(n)] = struct{}{}
+		}
+		return res
+	}
+	return &AnnotationFilterStage{include: toSet(f.Include), exclude: toSet(f.Exclude)}
+}
+
+// Exec implements the Stage interface.
+func (s *AnnotationFilterStage) Exec(ctx context.Context, _ *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	res := make([]*types.Alert, 0, len(alerts))
+	for _, a := range alerts {
+		filtered := *a
+		filtered.Annotations = make(model.LabelSet, len(a.Annotations))
+		for ln, lv := range a.Annotations {
+			if _, ok := s.exclude[ln]; ok {
+				continue
+			}
+			if _, ok := s.include[ln]; s.include != nil && !ok {
+				continue
+			}
+			filtered.Annotations[ln] = lv
+		}
+		res = append(res, &filtered)
+	}
+	return ctx, res, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestAnnotationFilterStage(t *testing.T) {
+	newAlert := func() *types.Alert {
+		return &types.Alert{
+			Alert: model.Alert{
+				Labels: model.LabelSet{"alertname": "a"},
+				Annotations: model.LabelSet{
+					"summary":     "disk full",
+					"description": "the disk of db-0 is full",
+					"debug_dump":  "internal state",
+				},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		filter   AnnotationFilter
+		expected model.LabelSet
+	}{
+		{
+			name:   "include",
+			filter: AnnotationFilter{Include: []string{"summary", "runbook_url"}},
+			expected: model.LabelSet{
+				"summary": "disk full",
+			},
+		},
+		{
+			name:   "exclude",
+			filter: AnnotationFilter{Exclude: []string{"debug_dump"}},
+			expected: model.LabelSet{
+				"summary":     "disk full",
+				"description": "the disk of db-0 is full",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newAlert()
+			_, res, err := NewAnnotationFilterStage(tc.filter).Exec(context.Background(), promslog.NewNopLogger(), a)
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			require.Equal(t, tc.expected, res[0].Annotations)
+			require.Equal(t, a.Labels, res[0].Labels)
+			// The original alert is unchanged.
+			require.Equal(t, newAlert(), a)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	// DeadLetterAlertName is the name of the alerts sent to the dead letter
+	// receiver.
+	DeadLetterAlertName = "NotificationUndeliverable"
+
+	// deadLetterTimeout is the time given to the dead letter receiver to
+	// deliver a notification, retries included.
+	deadLetterTimeout = time.Minute
+)
+
+// DeadLetter sends a notification describing an undeliverable notification
+// to the integrations of the dead letter receiver.
+type DeadLetter struct {
+	receiver string
+	stage    FanoutStage
+}
+
+// newDeadLetter returns a DeadLetter sending to the integrations of the
+// receiver.
+func newDeadLetter(receiver string, integrations []Integration, metrics *Metrics) *DeadLetter {
+	dl := &DeadLetter{receiver: receiver}
+	for i := range integrations {
+		dl.stage = append(dl.stage, NewRetryStage(integrations[i], receiver, metrics))
+	}
+	return dl
+}
+
+// deadLetterAlert returns the alert describing the notification of the
+// alerts by the integration of the receiver which failed with err.
+func deadLetterAlert(ctx context.Context, receiver, integration string, alerts []*types.Alert, err error) *types.Alert {
+	now, ok := Now(ctx)
+	if !ok {
+		now = time.Now()
+	}
+	labels := model.LabelSet{}
+	if gl, ok := GroupLabels(ctx); ok {
+		for ln, lv := range gl {
+			labels[ln] = lv
+		}
+	}
+	labels[model.AlertNameLabel] = DeadLetterAlertName
+	labels["receiver"] = model.LabelValue(receiver)
+	labels["integration"] = model.LabelValue(integration)
+
+	groupKey, _ := GroupKey(ctx)
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels: labels,
+			Annotations: model.LabelSet{
+				"summary":     model.LabelValue(fmt.Sprintf("Notification of %d alert(s) to receiver %s via %s failed", len(alerts), receiver, integration)),
+				"description": model.LabelValue(RedactURL(err).Error()),
+				"reason":      model.LabelValue(ReasonFromError(err).String()),
+				"group_key":   model.LabelValue(groupKey),
+				"alerts":      model.LabelValue(strconv.Itoa(len(alerts))),
+			},
+			StartsAt: now,
+		},
+		UpdatedAt: now,
+	}
+}
+
+// Send notifies the dead letter receiver that the notification of the alerts
+// by the integration of the receiver failed with err. It doesn't depend on
+// the cancellation of ctx, which is usually done when the notification gave
+// up.
+func (dl *DeadLetter) Send(ctx context.Context, l *slog.Logger, receiver, integration string, alerts []*types.Alert, err error) {
+	a := deadLetterAlert(ctx, receiver, integration, alerts, err)
+	groupKey, _ := GroupKey(ctx)
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deadLetterTimeout)
+	defer cancel()
+	ctx = WithReceiverName(ctx, dl.receiver)
+	ctx = WithGroupKey(ctx, fmt.Sprintf("%s:%s/%s", dl.receiver, groupKey, integration))
+	ctx = WithGroupLabels(ctx, a.Labels)
+	ctx = WithFiringAlerts(ctx, []uint64{uint64(a.Fingerprint())})
+	ctx = WithResolvedAlerts(ctx, []uint64{})
+
+	l = l.With("dead_letter_receiver", dl.receiver, "receiver", receiver, "integration", integration)
+	if _, _, err := dl.stage.Exec(ctx, l, a); err != nil {
+		l.Error("Notifying dead letter receiver failed", "err", err)
+		return
+	}
+	l.Info("Notified dead letter receiver of undeliverable notification")
+}
+
+// isUndeliverable returns whether the notification failed with err was given
+// up, rather than canceled because Alertmanager is shutting down or
+// reloading its configuration.
+func isUndeliverable(ctx context.Context, err error) bool {
+	return err != nil && !errors.Is(ctx.Err(), context.Canceled)
+}
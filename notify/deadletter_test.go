@@ -0,0 +1,102 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/featurecontrol"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestRetryStageDeadLetter(t *testing.T) {
+	metrics := NewMetrics(prometheus.NewRegistry(), featurecontrol.NoopFlags{})
+
+	var (
+		received []*types.Alert
+		rcvCtx   context.Context
+	)
+	dl := newDeadLetter("dead-letters", []Integration{{
+		notifier: notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+			received = append(received, alerts...)
+			rcvCtx = ctx
+			return false, nil
+		}),
+		rs:   sendResolved(true),
+		name: "webhook",
+	}}, metrics)
+
+	i := Integration{
+		notifier: notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+			return false, errors.New("invalid API key")
+		}),
+		rs:   sendResolved(true),
+		name: "slack",
+	}
+	r := NewRetryStage(i, "team-X", metrics)
+	r.deadLetter = dl
+
+	alerts := []*types.Alert{
+		{Alert: model.Alert{Labels: model.LabelSet{"alertname": "HighLatency"}}},
+		{Alert: model.Alert{Labels: model.LabelSet{"alertname": "HighLatency", "instance": "a"}}},
+	}
+	ctx := WithGroupKey(context.Background(), "{}:{alertname=\"HighLatency\"}")
+	ctx = WithGroupLabels(ctx, model.LabelSet{"alertname": "HighLatency", "team": "X"})
+
+	// An unrecoverable error notifies the dead letter receiver.
+	_, _, err := r.Exec(ctx, promslog.NewNopLogger(), alerts...)
+	require.Error(t, err)
+	require.Len(t, received, 1)
+	require.Equal(t, model.LabelSet{
+		"alertname":   DeadLetterAlertName,
+		"team":        "X",
+		"receiver":    "team-X",
+		"integration": "slack[0]",
+	}, received[0].Labels)
+	require.Equal(t, model.LabelValue("team-X/slack[0]: notify retry canceled due to unrecoverable error (other) after 1 attempts: invalid API key"), received[0].Annotations["description"])
+	require.Equal(t, model.LabelValue("2"), received[0].Annotations["alerts"])
+	require.Equal(t, model.LabelValue(`{}:{alertname="HighLatency"}`), received[0].Annotations["group_key"])
+	rcv, _ := ReceiverName(rcvCtx)
+	require.Equal(t, "dead-letters", rcv)
+
+	// Exceeding the deadline notifies the dead letter receiver.
+	received = nil
+	i.notifier = notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+		return true, errors.New("connection refused")
+	})
+	r = NewRetryStage(i, "team-X", metrics)
+	r.deadLetter = dl
+	dctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, _, err = r.Exec(dctx, promslog.NewNopLogger(), alerts...)
+	require.Error(t, err)
+	require.Len(t, received, 1)
+	require.Contains(t, received[0].Annotations["description"], "connection refused")
+
+	// Canceling the notification doesn't notify the dead letter receiver.
+	received = nil
+	cctx, cancel := context.WithCancel(ctx)
+	time.AfterFunc(100*time.Millisecond, cancel)
+	_, _, err = r.Exec(cctx, promslog.NewNopLogger(), alerts...)
+	require.Error(t, err)
+	require.Empty(t, received)
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+// DisabledReceiverStage skips the notifications of a receiver while it is
+// disabled, for example because its downstream is under maintenance.
+type DisabledReceiverStage struct {
+	receiver string
+	disabled func(receiver string) bool
+	next     Stage
+	metrics  *Metrics
+}
+
+// NewDisabledReceiverStage returns a new DisabledReceiverStage passing the
+// notifications of the receiver to next unless disabled returns true for it.
+func NewDisabledReceiverStage(receiver string, disabled func(string) bool, next Stage, metrics *Metrics) *DisabledReceiverStage {
+	return &DisabledReceiverStage{
+		receiver: receiver,
+		disabled: disabled,
+		next:     next,
+		metrics:  metrics,
+	}
+}
+
+// Exec implements the Stage interface.
+func (ds *DisabledReceiverStage) Exec(ctx context.Context, l *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	if !ds.disabled(ds.receiver) {
+		return ds.next.Exec(ctx, l, alerts...)
+	}
+	// The notification isn't recorded in the notification log, so it is sent
+	// at the first flush after the receiver is enabled again.
+	ds.metrics.numNotificationSuppressedTotal.WithLabelValues(SuppressedReasonDisabledReceiver).Add(float64(len(alerts)))
+	l.Info("Notification skipped, receiver is disabled", "alerts", len(alerts))
+	return ctx, nil, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/featurecontrol"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestDisabledReceiverStage(t *testing.T) {
+	var (
+		metrics  = NewMetrics(prometheus.NewRegistry(), featurecontrol.NoopFlags{})
+		disabled bool
+		notified int
+	)
+	next := StageFunc(func(ctx context.Context, _ *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+		notified++
+		return ctx, alerts, nil
+	})
+	s := NewDisabledReceiverStage("pager", func(receiver string) bool {
+		require.Equal(t, "pager", receiver)
+		return disabled
+	}, next, metrics)
+
+	alerts := []*types.Alert{{Alert: model.Alert{Labels: model.LabelSet{"alertname": "test"}}}}
+	_, res, err := s.Exec(context.Background(), promslog.NewNopLogger(), alerts...)
+	require.NoError(t, err)
+	require.Equal(t, alerts, res)
+	require.Equal(t, 1, notified)
+
+	disabled = true
+	_, res, err = s.Exec(context.Background(), promslog.NewNopLogger(), alerts...)
+	require.NoError(t, err)
+	require.Empty(t, res)
+	require.Equal(t, 1, notified)
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.numNotificationSuppressedTotal.WithLabelValues(SuppressedReasonDisabledReceiver)))
+}
@@ -99,7 +99,7 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 
 	alerts := types.Alerts(as...)
 	data := notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
-	tmpl := notify.TmplText(n.tmpl, data, &err)
+	tmpl := notify.TmplText(ctx, n.tmpl, data, &err)
 	if err != nil {
 		return false, err
 	}
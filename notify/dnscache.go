@@ -0,0 +1,109 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// dnsCache caches the IP addresses of host names for a fixed TTL. If
+// resolving a host name fails, the addresses of its last resolution are used
+// for up to the stale TTL after they expired.
+type dnsCache struct {
+	lookup func(ctx context.Context, network, host string) ([]netip.Addr, error)
+	now    func() time.Time
+
+	mtx      sync.Mutex
+	ttl      time.Duration
+	staleTTL time.Duration
+	entries  map[string]dnsCacheEntry
+
+	lookups *prometheus.CounterVec
+}
+
+type dnsCacheEntry struct {
+	addrs    []netip.Addr
+	resolved time.Time
+}
+
+func newDNSCache(lookup func(ctx context.Context, network, host string) ([]netip.Addr, error)) *dnsCache {
+	return &dnsCache{
+		lookup:  lookup,
+		now:     time.Now,
+		entries: map[string]dnsCacheEntry{},
+		lookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_notifications_dns_cache_lookups_total",
+			Help: "The total number of host names of the integrations looked up in the DNS cache, by result: hit, miss, stale when the addresses of the last resolution were used because resolving failed, and failed.",
+		}, []string{"result"}),
+	}
+}
+
+// setTTLs sets the TTLs of the cache, a zero TTL disabling the cache.
+func (c *dnsCache) setTTLs(ttl, staleTTL time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.ttl, c.staleTTL = ttl, staleTTL
+	if ttl == 0 {
+		clear(c.entries)
+	}
+}
+
+// enabled returns true if the cache is enabled.
+func (c *dnsCache) enabled() bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.ttl > 0
+}
+
+// lookupNetIP returns the IP addresses of the host, from the cache if they
+// haven't expired.
+func (c *dnsCache) lookupNetIP(ctx context.Context, host string) ([]netip.Addr, error) {
+	now := c.now()
+	c.mtx.Lock()
+	e, ok := c.entries[host]
+	ttl, staleTTL := c.ttl, c.staleTTL
+	c.mtx.Unlock()
+
+	if ok && now.Sub(e.resolved) < ttl {
+		c.lookups.WithLabelValues("hit").Inc()
+		return e.addrs, nil
+	}
+	addrs, err := c.lookup(ctx, "ip", host)
+	if err != nil {
+		if ok && now.Sub(e.resolved) < ttl+staleTTL {
+			c.lookups.WithLabelValues("stale").Inc()
+			return e.addrs, nil
+		}
+		c.lookups.WithLabelValues("failed").Inc()
+		return nil, err
+	}
+	c.lookups.WithLabelValues("miss").Inc()
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	// The expired entries are removed so that the cache doesn't grow with
+	// the host names which aren't connected to anymore.
+	for h, e := range c.entries {
+		if now.Sub(e.resolved) >= c.ttl+c.staleTTL {
+			delete(c.entries, h)
+		}
+	}
+	c.entries[host] = dnsCacheEntry{addrs: addrs, resolved: now}
+	return addrs, nil
+}
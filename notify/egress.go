@@ -0,0 +1,178 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ErrEgressBlocked is returned when connecting to a destination not allowed by
+// the egress policy.
+var ErrEgressBlocked = errors.New("destination not allowed by the egress policy")
+
+// EgressPolicy defines the destinations the integrations can connect to.
+type EgressPolicy struct {
+	// Hosts are the allowed host names. A leading "*." matches any
+	// subdomain.
+	Hosts []string
+	// Prefixes are the allowed networks.
+	Prefixes []netip.Prefix
+}
+
+// allowsHost returns true if the host name is allowed.
+func (p *EgressPolicy) allowsHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, h := range p.Hosts {
+		h = strings.ToLower(h)
+		if suffix, ok := strings.CutPrefix(h, "*"); ok {
+			if strings.HasSuffix(host, suffix) {
+				return true
+			}
+			continue
+		}
+		if host == h {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsAddr returns true if the IP address is allowed.
+func (p *EgressPolicy) allowsAddr(addr netip.Addr) bool {
+	for _, prefix := range p.Prefixes {
+		if prefix.Contains(addr.Unmap()) {
+			return true
+		}
+	}
+	return false
+}
+
+// EgressFilter restricts the connections of the HTTP clients of the
+// integrations to the destinations allowed by the egress policy, and caches
+// the resolution of their host names if enabled. Its DialContext method is
+// meant to be used as the dial function of the clients. With a proxy, the
+// connections to the proxy are checked.
+type EgressFilter struct {
+	mtx    sync.RWMutex
+	policy *EgressPolicy
+
+	logger   *slog.Logger
+	dialer   *net.Dialer
+	resolver *net.Resolver
+	cache    *dnsCache
+	blocked  prometheus.Counter
+}
+
+// NewEgressFilter returns a new EgressFilter allowing all the destinations
+// until a policy is set.
+func NewEgressFilter(l *slog.Logger, r prometheus.Registerer) *EgressFilter {
+	f := &EgressFilter{
+		logger: l,
+		// The same settings as the default dialer of the HTTP clients.
+		dialer:   &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second},
+		resolver: net.DefaultResolver,
+		blocked: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_notifications_egress_blocked_total",
+			Help: "The total number of connections to destinations not allowed by the egress policy.",
+		}),
+	}
+	f.cache = newDNSCache(f.resolver.LookupNetIP)
+	if r != nil {
+		r.MustRegister(f.blocked, f.cache.lookups)
+	}
+	return f
+}
+
+// SetPolicy sets the egress policy, nil allowing all the destinations.
+func (f *EgressFilter) SetPolicy(p *EgressPolicy) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.policy = p
+}
+
+// SetDNSCache sets the TTLs of the cache of the resolution of the host names,
+// a zero TTL disabling the cache.
+func (f *EgressFilter) SetDNSCache(ttl, staleTTL time.Duration) {
+	f.cache.setTTLs(ttl, staleTTL)
+}
+
+// DialContext connects to the address if it is allowed by the egress policy.
+// Addresses whose host isn't allowed by name are resolved, and the allowed IP
+// addresses are connected to in turn so that a later resolution can't return
+// another one. With the DNS cache, all host names are resolved through the
+// cache.
+func (f *EgressFilter) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	f.mtx.RLock()
+	p := f.policy
+	f.mtx.RUnlock()
+	cached := f.cache.enabled()
+
+	if p == nil && !cached {
+		return f.dialer.DialContext(ctx, network, addr)
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	allowedByName := p == nil || p.allowsHost(host)
+	if allowedByName && !cached {
+		return f.dialer.DialContext(ctx, network, addr)
+	}
+
+	var ips []netip.Addr
+	switch ip, perr := netip.ParseAddr(host); {
+	case perr == nil:
+		ips = []netip.Addr{ip}
+	case cached:
+		ips, err = f.cache.lookupNetIP(ctx, host)
+	default:
+		ips, err = f.resolver.LookupNetIP(ctx, "ip", host)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var dialErr error
+	for _, ip := range ips {
+		if !allowedByName && !p.allowsAddr(ip) {
+			continue
+		}
+		conn, err := f.dialer.DialContext(ctx, network, net.JoinHostPort(ip.Unmap().String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		dialErr = err
+	}
+	if dialErr != nil {
+		return nil, dialErr
+	}
+	if allowedByName {
+		return nil, fmt.Errorf("no addresses for %s", host)
+	}
+
+	f.blocked.Inc()
+	f.logger.Warn("Blocked connection to destination not allowed by the egress policy", "host", host)
+	return nil, fmt.Errorf("%w: %s", ErrEgressBlocked, host)
+}
@@ -0,0 +1,186 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEgressPolicyAllowsHost(t *testing.T) {
+	p := &EgressPolicy{Hosts: []string{"hooks.slack.com", "*.Example.com"}}
+	for host, allowed := range map[string]bool{
+		"hooks.slack.com":      true,
+		"HOOKS.slack.com.":     true,
+		"slack.com":            false,
+		"api.example.com":      true,
+		"a.b.example.com":      true,
+		"example.com":          false,
+		"evilexample.com":      false,
+		"hooks.slack.com.evil": false,
+	} {
+		require.Equal(t, allowed, p.allowsHost(host), host)
+	}
+}
+
+func TestEgressFilter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	_, port, err := net.SplitHostPort(u.Host)
+	require.NoError(t, err)
+
+	f := NewEgressFilter(promslog.NewNopLogger(), prometheus.NewRegistry())
+	client, err := commoncfg.NewClientFromConfig(commoncfg.HTTPClientConfig{}, "test", commoncfg.WithDialContextFunc(f.DialContext), commoncfg.WithKeepAlivesDisabled())
+	require.NoError(t, err)
+
+	get := func(host string) error {
+		resp, err := client.Get("http://" + net.JoinHostPort(host, port))
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	// All the destinations are allowed without policy.
+	require.NoError(t, get("127.0.0.1"))
+
+	f.SetPolicy(&EgressPolicy{Prefixes: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}})
+	err = get("127.0.0.1")
+	require.ErrorIs(t, err, ErrEgressBlocked)
+	err = get("localhost")
+	require.ErrorIs(t, err, ErrEgressBlocked)
+	require.Equal(t, 2.0, testutil.ToFloat64(f.blocked))
+
+	// Host names are allowed by name or by the addresses they resolve to.
+	f.SetPolicy(&EgressPolicy{Hosts: []string{"localhost"}})
+	require.NoError(t, get("localhost"))
+	f.SetPolicy(&EgressPolicy{Prefixes: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8"), netip.MustParsePrefix("::1/128")}})
+	require.NoError(t, get("localhost"))
+	require.NoError(t, get("127.0.0.1"))
+	require.Equal(t, 2.0, testutil.ToFloat64(f.blocked))
+
+	_, err = f.DialContext(context.Background(), "tcp", "missing-port")
+	require.Error(t, err)
+}
+
+func TestDNSCache(t *testing.T) {
+	var (
+		lookups int
+		err     error
+		addrs   = []netip.Addr{netip.MustParseAddr("10.0.0.1")}
+		now     = time.Now()
+	)
+	c := newDNSCache(func(context.Context, string, string) ([]netip.Addr, error) {
+		lookups++
+		if err != nil {
+			return nil, err
+		}
+		return addrs, nil
+	})
+	c.now = func() time.Time { return now }
+	c.setTTLs(time.Minute, time.Hour)
+
+	lookup := func() ([]netip.Addr, error) { return c.lookupNetIP(context.Background(), "example.com") }
+
+	got, lerr := lookup()
+	require.NoError(t, lerr)
+	require.Equal(t, addrs, got)
+	require.Equal(t, 1, lookups)
+
+	// The addresses are cached for the TTL.
+	addrs = []netip.Addr{netip.MustParseAddr("10.0.0.2")}
+	now = now.Add(30 * time.Second)
+	got, lerr = lookup()
+	require.NoError(t, lerr)
+	require.Equal(t, []netip.Addr{netip.MustParseAddr("10.0.0.1")}, got)
+	require.Equal(t, 1, lookups)
+
+	now = now.Add(time.Minute)
+	got, lerr = lookup()
+	require.NoError(t, lerr)
+	require.Equal(t, addrs, got)
+	require.Equal(t, 2, lookups)
+
+	// The stale addresses are used when resolving fails, up to the stale TTL.
+	err = errors.New("no such host")
+	now = now.Add(30 * time.Minute)
+	got, lerr = lookup()
+	require.NoError(t, lerr)
+	require.Equal(t, addrs, got)
+	require.Equal(t, 3, lookups)
+
+	now = now.Add(time.Hour)
+	_, lerr = lookup()
+	require.ErrorIs(t, lerr, err)
+
+	require.Equal(t, 1.0, testutil.ToFloat64(c.lookups.WithLabelValues("hit")))
+	require.Equal(t, 2.0, testutil.ToFloat64(c.lookups.WithLabelValues("miss")))
+	require.Equal(t, 1.0, testutil.ToFloat64(c.lookups.WithLabelValues("stale")))
+	require.Equal(t, 1.0, testutil.ToFloat64(c.lookups.WithLabelValues("failed")))
+}
+
+func TestEgressFilterDNSCache(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	_, port, err := net.SplitHostPort(u.Host)
+	require.NoError(t, err)
+
+	f := NewEgressFilter(promslog.NewNopLogger(), prometheus.NewRegistry())
+	var lookups int
+	f.cache.lookup = func(context.Context, string, string) ([]netip.Addr, error) {
+		lookups++
+		// The first address can't be connected to over tcp4.
+		return []netip.Addr{netip.MustParseAddr("::1"), netip.MustParseAddr("127.0.0.1")}, nil
+	}
+	f.SetDNSCache(time.Minute, time.Hour)
+
+	for i := 0; i < 2; i++ {
+		conn, err := f.DialContext(context.Background(), "tcp4", net.JoinHostPort("alertmanager.example", port))
+		require.NoError(t, err)
+		conn.Close()
+	}
+	require.Equal(t, 1, lookups)
+
+	// The IP addresses aren't resolved.
+	conn, err := f.DialContext(context.Background(), "tcp", net.JoinHostPort("127.0.0.1", port))
+	require.NoError(t, err)
+	conn.Close()
+	require.Equal(t, 1, lookups)
+
+	// The resolved addresses are checked against the egress policy.
+	f.SetPolicy(&EgressPolicy{Prefixes: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}})
+	_, err = f.DialContext(context.Background(), "tcp", net.JoinHostPort("alertmanager.example", port))
+	require.ErrorIs(t, err, ErrEgressBlocked)
+
+	f.SetDNSCache(0, 0)
+	require.False(t, f.cache.enabled())
+	require.Empty(t, f.cache.entries)
+}
@@ -25,6 +25,7 @@ import (
 	"mime/multipart"
 	"mime/quotedprintable"
 	"net"
+	"net/http"
 	"net/mail"
 	"net/smtp"
 	"net/textproto"
@@ -32,8 +33,10 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	commoncfg "github.com/prometheus/common/config"
+	"golang.org/x/net/idna"
 
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/notify"
@@ -54,7 +57,9 @@ func New(c *config.EmailConfig, t *template.Template, l *slog.Logger) *Email {
 	if _, ok := c.Headers["Subject"]; !ok {
 		c.Headers["Subject"] = config.DefaultEmailSubject
 	}
-	if _, ok := c.Headers["To"]; !ok {
+	// With recipients, the To header is set to the addresses the notification
+	// is sent to.
+	if _, ok := c.Headers["To"]; !ok && c.Recipients == nil {
 		c.Headers["To"] = c.To
 	}
 	if _, ok := c.Headers["From"]; !ok {
@@ -124,19 +129,20 @@ func (n *Email) auth(mechs string) (smtp.Auth, error) {
 
 // Notify implements the Notifier interface.
 func (n *Email) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	if p, ok := notify.PreviewFromContext(ctx); ok {
+		return false, n.preview(ctx, p, as...)
+	}
+
 	var (
 		c       *smtp.Client
 		conn    net.Conn
 		err     error
 		success = false
 	)
-	if n.conf.Smarthost.Port == "465" {
-		tlsConfig, err := commoncfg.NewTLSConfig(n.conf.TLSConfig)
+	if n.implicitTLS() {
+		tlsConfig, err := n.tlsConfig()
 		if err != nil {
-			return false, fmt.Errorf("parse TLS configuration: %w", err)
-		}
-		if tlsConfig.ServerName == "" {
-			tlsConfig.ServerName = n.conf.Smarthost.Host
+			return false, err
 		}
 
 		conn, err = tls.Dial("tcp", n.conf.Smarthost.String(), tlsConfig)
@@ -178,12 +184,9 @@ func (n *Email) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
 			return true, fmt.Errorf("'require_tls' is true (default) but %q does not advertise the STARTTLS extension", n.conf.Smarthost)
 		}
 
-		tlsConf, err := commoncfg.NewTLSConfig(n.conf.TLSConfig)
+		tlsConf, err := n.tlsConfig()
 		if err != nil {
-			return false, fmt.Errorf("parse TLS configuration: %w", err)
-		}
-		if tlsConf.ServerName == "" {
-			tlsConf.ServerName = n.conf.Smarthost.Host
+			return false, err
 		}
 
 		if err := c.StartTLS(tlsConf); err != nil {
@@ -206,7 +209,7 @@ func (n *Email) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
 	var (
 		tmplErr error
 		data    = notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
-		tmpl    = notify.TmplText(n.tmpl, data, &tmplErr)
+		tmpl    = notify.TmplText(ctx, n.tmpl, data, &tmplErr)
 	)
 	from := tmpl(n.conf.From)
 	if tmplErr != nil {
@@ -216,6 +219,14 @@ func (n *Email) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
 	if tmplErr != nil {
 		return false, fmt.Errorf("execute 'to' template: %w", tmplErr)
 	}
+	to = n.recipients(as, to)
+	if to == "" {
+		return false, errors.New("no recipients for the alerts")
+	}
+
+	// Without the SMTPUTF8 extension, the internationalized domains are sent
+	// in their ASCII form.
+	smtputf8, _ := c.Extension("SMTPUTF8")
 
 	addrs, err := mail.ParseAddressList(from)
 	if err != nil {
@@ -224,18 +235,35 @@ func (n *Email) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
 	if len(addrs) != 1 {
 		return false, fmt.Errorf("must be exactly one 'from' address (got: %d)", len(addrs))
 	}
-	if err = c.Mail(addrs[0].Address); err != nil {
+	addr, err := envelopeAddress(addrs[0].Address, smtputf8)
+	if err != nil {
+		return false, err
+	}
+	if err = c.Mail(addr); err != nil {
 		return true, fmt.Errorf("send MAIL command: %w", err)
 	}
 	addrs, err = mail.ParseAddressList(to)
 	if err != nil {
 		return false, fmt.Errorf("parse 'to' addresses: %w", err)
 	}
-	for _, addr := range addrs {
-		if err = c.Rcpt(addr.Address); err != nil {
+	for _, a := range addrs {
+		addr, err := envelopeAddress(a.Address, smtputf8)
+		if err != nil {
+			return false, err
+		}
+		if err = c.Rcpt(addr); err != nil {
 			return true, fmt.Errorf("send RCPT command: %w", err)
 		}
 	}
+	to, err = toHeader(to, addrs, smtputf8)
+	if err != nil {
+		return false, err
+	}
+
+	msg, err := n.renderMessage(ctx, data, to)
+	if err != nil {
+		return false, err
+	}
 
 	// Send the email headers and body.
 	message, err := c.Data()
@@ -252,15 +280,189 @@ func (n *Email) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
 		_ = closeOnce()
 	}()
 
+	_, err = message.Write(msg)
+	if err != nil {
+		return false, fmt.Errorf("write message: %w", err)
+	}
+
+	// Complete the message and await response.
+	if err = closeOnce(); err != nil {
+		return true, fmt.Errorf("delivery failure: %w", err)
+	}
+
+	success = true
+	return false, nil
+}
+
+// implicitTLS returns whether the connection to the smarthost starts with TLS
+// rather than being upgraded with STARTTLS.
+func (n *Email) implicitTLS() bool {
+	if n.conf.ForceImplicitTLS != nil {
+		return *n.conf.ForceImplicitTLS
+	}
+	return n.conf.Smarthost.Port == "465"
+}
+
+// tlsConfig returns the TLS configuration of the connections to the smarthost.
+func (n *Email) tlsConfig() (*tls.Config, error) {
+	tlsConfig, err := commoncfg.NewTLSConfig(n.conf.TLSConfig)
+	if err != nil {
+		return nil, fmt.Errorf("parse TLS configuration: %w", err)
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = n.conf.Smarthost.Host
+	}
+	for _, cs := range n.conf.TLSCipherSuites {
+		tlsConfig.CipherSuites = append(tlsConfig.CipherSuites, uint16(cs))
+	}
+	return tlsConfig, nil
+}
+
+// recipients returns the addresses of the recipients of the label values of
+// the alerts, or to if none of the label values has recipients.
+func (n *Email) recipients(as []*types.Alert, to string) string {
+	r := n.conf.Recipients
+	if r == nil {
+		return to
+	}
+	var (
+		addrs []string
+		seen  = map[string]struct{}{}
+	)
+	for _, a := range as {
+		v, ok := a.Labels[r.Label]
+		if !ok {
+			continue
+		}
+		for _, addr := range r.Addresses[string(v)] {
+			if _, ok := seen[addr]; ok {
+				continue
+			}
+			seen[addr] = struct{}{}
+			addrs = append(addrs, addr)
+		}
+	}
+	if len(addrs) == 0 {
+		return to
+	}
+	return strings.Join(addrs, ", ")
+}
+
+// envelopeAddress returns the address to send in the MAIL and RCPT commands.
+// Without the SMTPUTF8 extension, the domain is converted to its ASCII form and
+// the local part must be ASCII.
+func envelopeAddress(addr string, smtputf8 bool) (string, error) {
+	if smtputf8 || isASCII(addr) {
+		return addr, nil
+	}
+	i := strings.LastIndexByte(addr, '@')
+	local, domain := addr[:i], addr[i+1:]
+	if !isASCII(local) {
+		return "", fmt.Errorf("address %q has a non-ASCII local part but the server does not support SMTPUTF8", addr)
+	}
+	domain, err := idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return "", fmt.Errorf("convert the domain of %q to ASCII: %w", addr, err)
+	}
+	return local + "@" + domain, nil
+}
+
+// toHeader returns the value of the To header of the addresses. With the
+// SMTPUTF8 extension, the headers can contain UTF-8 (RFC 6532), the display
+// names are encoded and the domains converted to their ASCII form otherwise.
+func toHeader(to string, addrs []*mail.Address, smtputf8 bool) (string, error) {
+	if smtputf8 || isASCII(to) {
+		return to, nil
+	}
+	res := make([]string, 0, len(addrs))
+	for _, a := range addrs {
+		addr, err := envelopeAddress(a.Address, false)
+		if err != nil {
+			return "", err
+		}
+		res = append(res, (&mail.Address{Name: a.Name, Address: addr}).String())
+	}
+	return strings.Join(res, ", "), nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// preview records the SMTP envelope and the message of the email instead of
+// sending it, as if the server supported the SMTPUTF8 extension.
+func (n *Email) preview(ctx context.Context, p *notify.Preview, as ...*types.Alert) error {
+	var (
+		tmplErr error
+		data    = notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
+		tmpl    = notify.TmplText(ctx, n.tmpl, data, &tmplErr)
+	)
+	from := tmpl(n.conf.From)
+	if tmplErr != nil {
+		return fmt.Errorf("execute 'from' template: %w", tmplErr)
+	}
+	to := tmpl(n.conf.To)
+	if tmplErr != nil {
+		return fmt.Errorf("execute 'to' template: %w", tmplErr)
+	}
+	to = n.recipients(as, to)
+	if to == "" {
+		return errors.New("no recipients for the alerts")
+	}
+
+	header := http.Header{}
+	addrs, err := mail.ParseAddressList(from)
+	if err != nil {
+		return fmt.Errorf("parse 'from' addresses: %w", err)
+	}
+	if len(addrs) != 1 {
+		return fmt.Errorf("must be exactly one 'from' address (got: %d)", len(addrs))
+	}
+	header.Set("Mail-From", addrs[0].Address)
+	addrs, err = mail.ParseAddressList(to)
+	if err != nil {
+		return fmt.Errorf("parse 'to' addresses: %w", err)
+	}
+	for _, addr := range addrs {
+		header.Add("Rcpt-To", addr.Address)
+	}
+
+	msg, err := n.renderMessage(ctx, data, to)
+	if err != nil {
+		return err
+	}
+	p.Record(notify.PreviewRequest{
+		Method: "SMTP",
+		URL:    "smtp://" + n.conf.Smarthost.String(),
+		Header: header,
+		Body:   string(msg),
+	})
+	return nil
+}
+
+// renderMessage renders the headers and the MIME body of the email.
+func (n *Email) renderMessage(ctx context.Context, data *template.Data, to string) ([]byte, error) {
 	buffer := &bytes.Buffer{}
 	for header, t := range n.conf.Headers {
-		value, err := n.tmpl.ExecuteTextString(t, data)
+		if header == "Subject" {
+			t = notify.TmplForStatus(data, t, n.conf.SubjectResolved)
+		}
+		value, err := n.tmpl.ExecuteTextStringContext(ctx, t, data)
 		if err != nil {
-			return false, fmt.Errorf("execute %q header template: %w", header, err)
+			return nil, fmt.Errorf("execute %q header template: %w", header, err)
 		}
 		fmt.Fprintf(buffer, "%s: %s\r\n", header, mime.QEncoding.Encode("utf-8", value))
 	}
 
+	if _, ok := n.conf.Headers["To"]; !ok {
+		fmt.Fprintf(buffer, "To: %s\r\n", to)
+	}
+
 	if _, ok := n.conf.Headers["Message-Id"]; !ok {
 		fmt.Fprintf(buffer, "Message-Id: %s\r\n", fmt.Sprintf("<%d.%d@%s>", time.Now().UnixNano(), rand.Uint64(), n.hostname))
 	}
@@ -274,36 +476,36 @@ func (n *Email) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
 
 	// TODO: Add some useful headers here, such as URL of the alertmanager
 	// and active/resolved.
-	_, err = message.Write(buffer.Bytes())
-	if err != nil {
-		return false, fmt.Errorf("write headers: %w", err)
-	}
 
-	if len(n.conf.Text) > 0 {
+	var (
+		text = notify.TmplForStatus(data, n.conf.Text, n.conf.TextResolved)
+		html = notify.TmplForStatus(data, n.conf.HTML, n.conf.HTMLResolved)
+	)
+	if len(text) > 0 {
 		// Text template
 		w, err := multipartWriter.CreatePart(textproto.MIMEHeader{
 			"Content-Transfer-Encoding": {"quoted-printable"},
 			"Content-Type":              {"text/plain; charset=UTF-8"},
 		})
 		if err != nil {
-			return false, fmt.Errorf("create part for text template: %w", err)
+			return nil, fmt.Errorf("create part for text template: %w", err)
 		}
-		body, err := n.tmpl.ExecuteTextString(n.conf.Text, data)
+		body, err := n.tmpl.ExecuteTextStringContext(ctx, text, data)
 		if err != nil {
-			return false, fmt.Errorf("execute text template: %w", err)
+			return nil, fmt.Errorf("execute text template: %w", err)
 		}
 		qw := quotedprintable.NewWriter(w)
 		_, err = qw.Write([]byte(body))
 		if err != nil {
-			return true, fmt.Errorf("write text part: %w", err)
+			return nil, fmt.Errorf("write text part: %w", err)
 		}
 		err = qw.Close()
 		if err != nil {
-			return true, fmt.Errorf("close text part: %w", err)
+			return nil, fmt.Errorf("close text part: %w", err)
 		}
 	}
 
-	if len(n.conf.HTML) > 0 {
+	if len(html) > 0 {
 		// Html template
 		// Preferred alternative placed last per section 5.1.4 of RFC 2046
 		// https://www.ietf.org/rfc/rfc2046.txt
@@ -312,40 +514,29 @@ func (n *Email) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
 			"Content-Type":              {"text/html; charset=UTF-8"},
 		})
 		if err != nil {
-			return false, fmt.Errorf("create part for html template: %w", err)
+			return nil, fmt.Errorf("create part for html template: %w", err)
 		}
-		body, err := n.tmpl.ExecuteHTMLString(n.conf.HTML, data)
+		body, err := n.tmpl.ExecuteHTMLStringContext(ctx, html, data)
 		if err != nil {
-			return false, fmt.Errorf("execute html template: %w", err)
+			return nil, fmt.Errorf("execute html template: %w", err)
 		}
 		qw := quotedprintable.NewWriter(w)
 		_, err = qw.Write([]byte(body))
 		if err != nil {
-			return true, fmt.Errorf("write HTML part: %w", err)
+			return nil, fmt.Errorf("write HTML part: %w", err)
 		}
 		err = qw.Close()
 		if err != nil {
-			return true, fmt.Errorf("close HTML part: %w", err)
+			return nil, fmt.Errorf("close HTML part: %w", err)
 		}
 	}
 
-	err = multipartWriter.Close()
-	if err != nil {
-		return false, fmt.Errorf("close multipartWriter: %w", err)
+	if err := multipartWriter.Close(); err != nil {
+		return nil, fmt.Errorf("close multipartWriter: %w", err)
 	}
 
-	_, err = message.Write(multipartBuffer.Bytes())
-	if err != nil {
-		return false, fmt.Errorf("write body buffer: %w", err)
-	}
-
-	// Complete the message and await response.
-	if err = closeOnce(); err != nil {
-		return true, fmt.Errorf("delivery failure: %w", err)
-	}
-
-	success = true
-	return false, nil
+	buffer.Write(multipartBuffer.Bytes())
+	return buffer.Bytes(), nil
 }
 
 type loginAuth struct {
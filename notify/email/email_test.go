@@ -30,10 +30,12 @@ package email
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/mail"
 	"net/url"
 	"os"
 	"strconv"
@@ -52,6 +54,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/template"
 	"github.com/prometheus/alertmanager/types"
 )
@@ -736,6 +739,138 @@ func TestEmailRejected(t *testing.T) {
 	}, time.Second*10, time.Millisecond*100, "mock SMTP server goroutine failed to close in time")
 }
 
+func TestEmailRecipients(t *testing.T) {
+	cfg := &config.EmailConfig{
+		Smarthost: config.HostPort{Host: "localhost", Port: "25"},
+		From:      "alertmanager@example.com",
+		To:        "{{ .CommonLabels.team }}-fallback@example.com",
+		Recipients: &config.EmailRecipients{
+			Label: "team",
+			Addresses: map[string][]string{
+				"frontend": {"fe@example.com"},
+				"backend":  {"Backend <be@example.com>", "fe@example.com"},
+			},
+		},
+	}
+	tmpl, _, err := prepare(cfg)
+	require.NoError(t, err)
+	e := New(cfg, tmpl, promslog.NewNopLogger())
+
+	alert := func(lset model.LabelSet) *types.Alert {
+		return &types.Alert{Alert: model.Alert{Labels: lset, StartsAt: time.Now(), EndsAt: time.Now().Add(time.Hour)}}
+	}
+	for _, tc := range []struct {
+		name   string
+		alerts []*types.Alert
+		rcpts  []string
+		to     string
+	}{
+		{
+			name:   "single team",
+			alerts: []*types.Alert{alert(model.LabelSet{"team": "frontend"})},
+			rcpts:  []string{"fe@example.com"},
+			to:     "To: fe@example.com\r\n",
+		},
+		{
+			name:   "several teams",
+			alerts: []*types.Alert{alert(model.LabelSet{"team": "backend"}), alert(model.LabelSet{"team": "frontend"})},
+			rcpts:  []string{"be@example.com", "fe@example.com"},
+			to:     "To: Backend <be@example.com>, fe@example.com\r\n",
+		},
+		{
+			name:   "unknown team",
+			alerts: []*types.Alert{alert(model.LabelSet{"team": "db"})},
+			rcpts:  []string{"db-fallback@example.com"},
+			to:     "To: db-fallback@example.com\r\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &notify.Preview{}
+			_, err := e.Notify(notify.WithPreview(context.Background(), p), tc.alerts...)
+			require.NoError(t, err)
+			requests := p.Requests()
+			require.Len(t, requests, 1)
+			require.Equal(t, tc.rcpts, requests[0].Header.Values("Rcpt-To"))
+			require.Contains(t, requests[0].Body, tc.to)
+		})
+	}
+}
+
+func TestEmailTLSConfig(t *testing.T) {
+	yes, no := true, false
+	for _, tc := range []struct {
+		port     string
+		force    *bool
+		implicit bool
+	}{
+		{port: "25"},
+		{port: "465", implicit: true},
+		{port: "465", force: &no},
+		{port: "2525", force: &yes, implicit: true},
+	} {
+		e := &Email{conf: &config.EmailConfig{
+			Smarthost:        config.HostPort{Host: "smtp.example.com", Port: tc.port},
+			ForceImplicitTLS: tc.force,
+		}}
+		require.Equal(t, tc.implicit, e.implicitTLS(), "port %s", tc.port)
+	}
+
+	e := &Email{conf: &config.EmailConfig{
+		Smarthost:       config.HostPort{Host: "smtp.example.com", Port: "25"},
+		TLSConfig:       &commoncfg.TLSConfig{MinVersion: commoncfg.TLSVersion(tls.VersionTLS12)},
+		TLSCipherSuites: []config.TLSCipher{config.TLSCipher(tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256)},
+	}}
+	tlsConfig, err := e.tlsConfig()
+	require.NoError(t, err)
+	require.Equal(t, "smtp.example.com", tlsConfig.ServerName)
+	require.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+	require.Equal(t, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}, tlsConfig.CipherSuites)
+
+	e.conf.TLSConfig.ServerName = "mail.example.com"
+	tlsConfig, err = e.tlsConfig()
+	require.NoError(t, err)
+	require.Equal(t, "mail.example.com", tlsConfig.ServerName)
+}
+
+func TestEnvelopeAddress(t *testing.T) {
+	for _, tc := range []struct {
+		addr     string
+		smtputf8 bool
+		exp      string
+		err      string
+	}{
+		{addr: "sre@example.com", exp: "sre@example.com"},
+		{addr: "sre@bücher.example", smtputf8: true, exp: "sre@bücher.example"},
+		{addr: "sre@bücher.example", exp: "sre@xn--bcher-kva.example"},
+		{addr: "jörg@bücher.example", smtputf8: true, exp: "jörg@bücher.example"},
+		{addr: "jörg@bücher.example", err: "non-ASCII local part"},
+	} {
+		t.Run(tc.addr, func(t *testing.T) {
+			addr, err := envelopeAddress(tc.addr, tc.smtputf8)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, addr)
+		})
+	}
+}
+
+func TestToHeader(t *testing.T) {
+	to := "Jörg <sre@bücher.example>, ops@example.com"
+	addrs, err := mail.ParseAddressList(to)
+	require.NoError(t, err)
+
+	h, err := toHeader(to, addrs, true)
+	require.NoError(t, err)
+	require.Equal(t, to, h)
+
+	h, err = toHeader(to, addrs, false)
+	require.NoError(t, err)
+	require.Equal(t, "=?utf-8?q?J=C3=B6rg?= <sre@xn--bcher-kva.example>, <ops@example.com>", h)
+}
+
 func mockSMTPServer(t *testing.T) (*smtp.Server, net.Listener, error) {
 	t.Helper()
 
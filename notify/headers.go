@@ -0,0 +1,68 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// headersNotifier adds custom headers to the HTTP requests of a notifier.
+type headersNotifier struct {
+	Notifier
+	tmpl    *template.Template
+	headers map[string]string
+	logger  *slog.Logger
+}
+
+// NewHeadersNotifier returns a notifier adding the headers to the HTTP
+// requests sent by n with Do, replacing the headers set by n. The values of
+// the headers are templates executed with the data of the notification.
+func NewHeadersNotifier(n Notifier, tmpl *template.Template, headers map[string]string, l *slog.Logger) Notifier {
+	if len(headers) == 0 {
+		return n
+	}
+	return &headersNotifier{Notifier: n, tmpl: tmpl, headers: headers, logger: l}
+}
+
+// Notify implements the Notifier interface.
+func (n *headersNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var (
+		err    error
+		data   = GetTemplateData(ctx, n.tmpl, as, n.logger)
+		tmpl   = TmplText(ctx, n.tmpl, data, &err)
+		header = make(http.Header, len(n.headers))
+	)
+	for k, v := range n.headers {
+		header.Set(k, tmpl(v))
+		if err != nil {
+			return false, fmt.Errorf("template header %q: %w", k, err)
+		}
+	}
+	return n.Notifier.Notify(context.WithValue(ctx, keyHeaders, header), as...)
+}
+
+// setHeaders sets the custom headers of the context to the request.
+func setHeaders(ctx context.Context, req *http.Request) http.Header {
+	header, _ := ctx.Value(keyHeaders).(http.Header)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	return header
+}
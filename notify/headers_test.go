@@ -0,0 +1,88 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+type postNotifier struct {
+	client *http.Client
+	url    string
+}
+
+func (n *postNotifier) Notify(ctx context.Context, _ ...*types.Alert) (bool, error) {
+	resp, err := PostJSON(ctx, n.client, n.url, strings.NewReader(`{}`))
+	if err != nil {
+		return true, err
+	}
+	Drain(resp)
+	return false, nil
+}
+
+func TestHeadersNotifier(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer srv.Close()
+
+	tmpl, err := template.FromGlobs([]string{})
+	require.NoError(t, err)
+	tmpl.ExternalURL, _ = url.Parse("http://am")
+
+	inner := &postNotifier{client: srv.Client(), url: srv.URL}
+	require.Same(t, Notifier(inner), NewHeadersNotifier(inner, tmpl, nil, promslog.NewNopLogger()))
+
+	n := NewHeadersNotifier(inner, tmpl, map[string]string{
+		"X-Tenant":   "{{ .GroupLabels.team }}",
+		"User-Agent": "custom",
+	}, promslog.NewNopLogger())
+
+	ctx := WithReceiverName(context.Background(), "team-X")
+	ctx = WithGroupLabels(ctx, model.LabelSet{"team": "frontend"})
+	alert := &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"team": "frontend"}}}
+
+	_, err = n.Notify(ctx, alert)
+	require.NoError(t, err)
+	require.Equal(t, "frontend", got.Get("X-Tenant"))
+	require.Equal(t, "custom", got.Get("User-Agent"))
+	require.Equal(t, "application/json", got.Get("Content-Type"))
+
+	// The values of the headers are redacted in previews.
+	p := &Preview{}
+	_, err = n.Notify(WithPreview(ctx, p), alert)
+	require.NoError(t, err)
+	requests := p.Requests()
+	require.Len(t, requests, 1)
+	require.Equal(t, "<redacted>", requests[0].Header.Get("X-Tenant"))
+
+	// Invalid templates fail the notification without retrying.
+	n = NewHeadersNotifier(inner, tmpl, map[string]string{"X-Tenant": "{{ .Foo }"}, promslog.NewNopLogger())
+	retry, err := n.Notify(ctx, alert)
+	require.Error(t, err)
+	require.False(t, retry)
+}
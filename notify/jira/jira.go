@@ -78,7 +78,7 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 
 		tmplTextErr  error
 		data         = notify.GetTemplateData(ctx, n.tmpl, as, logger)
-		tmplText     = notify.TmplText(n.tmpl, data, &tmplTextErr)
+		tmplText     = notify.TmplText(ctx, n.tmpl, data, &tmplTextErr)
 		tmplTextFunc = func(tmpl string) (string, error) {
 			return tmplText(tmpl), tmplTextErr
 		}
@@ -322,7 +322,7 @@ func (n *Notifier) doAPIRequest(ctx context.Context, method, path string, reques
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept-Language", "en")
 
-	resp, err := n.client.Do(req)
+	resp, err := notify.Do(ctx, n.client, req)
 	if err != nil {
 		return nil, false, err
 	}
@@ -792,7 +792,7 @@ func TestJiraPriority(t *testing.T) {
 				data = tmpl.Data("jira", model.LabelSet{}, tc.alerts...)
 
 				tmplTextErr  error
-				tmplText     = notify.TmplText(tmpl, data, &tmplTextErr)
+				tmplText     = notify.TmplText(context.Background(), tmpl, data, &tmplTextErr)
 				tmplTextFunc = func(tmpl string) (string, error) {
 					result := tmplText(tmpl)
 					return result, tmplTextErr
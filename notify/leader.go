@@ -0,0 +1,52 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+// LeaderOnlyStage skips the notifications of a receiver unless this peer is
+// the leader of the cluster, for the integrations which can't tolerate the
+// duplicates of the peers notifying after their wait.
+type LeaderOnlyStage struct {
+	isLeader func() bool
+	next     Stage
+	metrics  *Metrics
+}
+
+// NewLeaderOnlyStage returns a new LeaderOnlyStage passing the notifications
+// to next while isLeader returns true.
+func NewLeaderOnlyStage(isLeader func() bool, next Stage, metrics *Metrics) *LeaderOnlyStage {
+	return &LeaderOnlyStage{
+		isLeader: isLeader,
+		next:     next,
+		metrics:  metrics,
+	}
+}
+
+// Exec implements the Stage interface.
+func (ls *LeaderOnlyStage) Exec(ctx context.Context, l *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	if ls.isLeader() {
+		return ls.next.Exec(ctx, l, alerts...)
+	}
+	// The notification isn't recorded in the notification log, so the peer
+	// taking over from the leader sends it if the leader didn't.
+	ls.metrics.numNotificationSuppressedTotal.WithLabelValues(SuppressedReasonNotLeader).Add(float64(len(alerts)))
+	l.Debug("Notification skipped, this peer isn't the leader", "alerts", len(alerts))
+	return ctx, nil, nil
+}
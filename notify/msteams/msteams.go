@@ -37,8 +37,20 @@ const (
 	colorRed   = "8C1A1A"
 	colorGreen = "2DC72D"
 	colorGrey  = "808080"
+
+	adaptiveColorRed   = "Attention"
+	adaptiveColorGreen = "Good"
+	adaptiveColorGrey  = "Warning"
 )
 
+// defaultActions are the buttons of the Adaptive Cards if none is configured.
+var defaultActions = []config.MSTeamsAction{
+	{
+		Title: `{{ template "msteams.default.action_title" . }}`,
+		URL:   `{{ template "msteams.default.action_url" . }}`,
+	},
+}
+
 type Notifier struct {
 	conf         *config.MSTeamsConfig
 	tmpl         *template.Template
@@ -59,6 +71,53 @@ type teamsMessage struct {
 	ThemeColor string `json:"themeColor"`
 }
 
+// Adaptive Card reference can be found at https://adaptivecards.io/explorer/.
+type adaptiveMessage struct {
+	Type        string               `json:"type"`
+	Attachments []adaptiveAttachment `json:"attachments"`
+}
+
+type adaptiveAttachment struct {
+	ContentType string       `json:"contentType"`
+	ContentURL  *string      `json:"contentUrl"`
+	Content     adaptiveCard `json:"content"`
+}
+
+type adaptiveCard struct {
+	Schema  string            `json:"$schema"`
+	Type    string            `json:"type"`
+	Version string            `json:"version"`
+	Body    []adaptiveElement `json:"body"`
+	Actions []adaptiveAction  `json:"actions,omitempty"`
+	MSTeams adaptiveMSTeams   `json:"msteams"`
+}
+
+type adaptiveElement struct {
+	Type   string         `json:"type"`
+	Text   string         `json:"text,omitempty"`
+	Weight string         `json:"weight,omitempty"`
+	Size   string         `json:"size,omitempty"`
+	Wrap   bool           `json:"wrap,omitempty"`
+	Style  string         `json:"style,omitempty"`
+	Color  string         `json:"color,omitempty"`
+	Facts  []adaptiveFact `json:"facts,omitempty"`
+}
+
+type adaptiveFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type adaptiveAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+type adaptiveMSTeams struct {
+	Width string `json:"width"`
+}
+
 // New returns a new notifier that uses the Microsoft Teams Webhook API.
 func New(c *config.MSTeamsConfig, t *template.Template, l *slog.Logger, httpOpts ...commoncfg.HTTPClientOption) (*Notifier, error) {
 	client, err := commoncfg.NewClientFromConfig(*c.HTTPConfig, "msteams", httpOpts...)
@@ -88,7 +147,7 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 	n.logger.Debug("extracted group key", "key", key)
 
 	data := notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
-	tmpl := notify.TmplText(n.tmpl, data, &err)
+	tmpl := notify.TmplText(ctx, n.tmpl, data, &err)
 	if err != nil {
 		return false, err
 	}
@@ -106,15 +165,6 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 		return false, err
 	}
 
-	alerts := types.Alerts(as...)
-	color := colorGrey
-	switch alerts.Status() {
-	case model.AlertFiring:
-		color = colorRed
-	case model.AlertResolved:
-		color = colorGreen
-	}
-
 	var url string
 	if n.conf.WebhookURL != nil {
 		url = n.conf.WebhookURL.String()
@@ -126,17 +176,33 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 		url = strings.TrimSpace(string(content))
 	}
 
-	t := teamsMessage{
-		Context:    "http://schema.org/extensions",
-		Type:       "MessageCard",
-		Title:      title,
-		Summary:    summary,
-		Text:       text,
-		ThemeColor: color,
+	var msg any
+	status := types.Alerts(as...).Status()
+	if n.conf.CardType == config.MSTeamsCardAdaptive {
+		msg = n.adaptiveMessage(tmpl, data, status, title, text)
+		if err != nil {
+			return false, err
+		}
+	} else {
+		color := colorGrey
+		switch status {
+		case model.AlertFiring:
+			color = colorRed
+		case model.AlertResolved:
+			color = colorGreen
+		}
+		msg = teamsMessage{
+			Context:    "http://schema.org/extensions",
+			Type:       "MessageCard",
+			Title:      title,
+			Summary:    summary,
+			Text:       text,
+			ThemeColor: color,
+		}
 	}
 
 	var payload bytes.Buffer
-	if err = json.NewEncoder(&payload).Encode(t); err != nil {
+	if err = json.NewEncoder(&payload).Encode(msg); err != nil {
 		return false, err
 	}
 
@@ -153,3 +219,76 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 	}
 	return shouldRetry, err
 }
+
+// adaptiveMessage returns the message holding the Adaptive Card of the
+// notification. Templating errors are reported through tmpl.
+func (n *Notifier) adaptiveMessage(tmpl func(string) string, data *template.Data, status model.AlertStatus, title, text string) adaptiveMessage {
+	color := adaptiveColorGrey
+	switch status {
+	case model.AlertFiring:
+		color = adaptiveColorRed
+	case model.AlertResolved:
+		color = adaptiveColorGreen
+	}
+
+	var facts []adaptiveFact
+	if len(n.conf.Facts) > 0 {
+		for _, f := range n.conf.Facts {
+			if v := tmpl(f.Value); v != "" {
+				facts = append(facts, adaptiveFact{Title: tmpl(f.Title), Value: v})
+			}
+		}
+	} else {
+		for _, p := range data.CommonLabels.SortedPairs() {
+			facts = append(facts, adaptiveFact{Title: p.Name, Value: p.Value})
+		}
+	}
+
+	actions := n.conf.Actions
+	if len(actions) == 0 {
+		actions = defaultActions
+	}
+	var buttons []adaptiveAction
+	for _, a := range actions {
+		if u := tmpl(a.URL); u != "" {
+			buttons = append(buttons, adaptiveAction{Type: "Action.OpenUrl", Title: tmpl(a.Title), URL: u})
+		}
+	}
+
+	body := []adaptiveElement{
+		{
+			Type:   "TextBlock",
+			Text:   title,
+			Weight: "Bolder",
+			Size:   "Medium",
+			Wrap:   true,
+			Style:  "heading",
+			Color:  color,
+		},
+		{
+			Type: "TextBlock",
+			Text: text,
+			Wrap: true,
+		},
+	}
+	if len(facts) > 0 {
+		body = append(body, adaptiveElement{Type: "FactSet", Facts: facts})
+	}
+
+	return adaptiveMessage{
+		Type: "message",
+		Attachments: []adaptiveAttachment{
+			{
+				ContentType: "application/vnd.microsoft.card.adaptive",
+				Content: adaptiveCard{
+					Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+					Type:    "AdaptiveCard",
+					Version: "1.4",
+					Body:    body,
+					Actions: buttons,
+					MSTeams: adaptiveMSTeams{Width: "Full"},
+				},
+			},
+		},
+	}
+}
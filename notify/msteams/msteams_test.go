@@ -140,6 +140,95 @@ func TestMSTeamsTemplating(t *testing.T) {
 	}
 }
 
+func TestMSTeamsAdaptiveCard(t *testing.T) {
+	var out map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		out = nil
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&out))
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": "HighLatency", "severity": "critical"},
+			Annotations: model.LabelSet{"runbook": "https://runbooks.example.com/latency"},
+			StartsAt:    time.Now(),
+			EndsAt:      time.Now().Add(time.Hour),
+		},
+	}
+	ctx := notify.WithGroupKey(context.Background(), "1")
+
+	for _, tc := range []struct {
+		title   string
+		facts   []config.MSTeamsFact
+		actions []config.MSTeamsAction
+
+		expFacts   []any
+		expActions []any
+	}{
+		{
+			title: "default facts and actions",
+			expFacts: []any{
+				map[string]any{"title": "alertname", "value": "HighLatency"},
+				map[string]any{"title": "severity", "value": "critical"},
+			},
+			expActions: []any{
+				map[string]any{"type": "Action.OpenUrl", "title": "View in Alertmanager", "url": "http://am/#/alerts?receiver="},
+			},
+		},
+		{
+			title: "templated facts and actions",
+			facts: []config.MSTeamsFact{
+				{Title: "Severity", Value: `{{ .CommonLabels.severity }}`},
+				{Title: "Team", Value: `{{ .CommonLabels.team }}`},
+			},
+			actions: []config.MSTeamsAction{
+				{Title: "Runbook", URL: `{{ .CommonAnnotations.runbook }}`},
+				{Title: "Dashboard", URL: `{{ .CommonAnnotations.dashboard }}`},
+			},
+			expFacts: []any{
+				map[string]any{"title": "Severity", "value": "critical"},
+			},
+			expActions: []any{
+				map[string]any{"type": "Action.OpenUrl", "title": "Runbook", "url": "https://runbooks.example.com/latency"},
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			notifier, err := New(
+				&config.MSTeamsConfig{
+					WebhookURL: &config.SecretURL{URL: u},
+					HTTPConfig: &commoncfg.HTTPClientConfig{},
+					Title:      `{{ template "msteams.default.title" . }}`,
+					Text:       `{{ template "msteams.default.text" . }}`,
+					CardType:   config.MSTeamsCardAdaptive,
+					Facts:      tc.facts,
+					Actions:    tc.actions,
+				},
+				test.CreateTmpl(t),
+				promslog.NewNopLogger(),
+			)
+			require.NoError(t, err)
+
+			_, err = notifier.Notify(ctx, alert)
+			require.NoError(t, err)
+
+			require.Equal(t, "message", out["type"])
+			attachment := out["attachments"].([]any)[0].(map[string]any)
+			require.Equal(t, "application/vnd.microsoft.card.adaptive", attachment["contentType"])
+			card := attachment["content"].(map[string]any)
+			require.Equal(t, "AdaptiveCard", card["type"])
+			body := card["body"].([]any)
+			require.Len(t, body, 3)
+			require.Equal(t, "Attention", body[0].(map[string]any)["color"])
+			require.Equal(t, "FactSet", body[2].(map[string]any)["type"])
+			require.Equal(t, tc.expFacts, body[2].(map[string]any)["facts"])
+			require.Equal(t, tc.expActions, card["actions"])
+		})
+	}
+}
+
 func TestNotifier_Notify_WithReason(t *testing.T) {
 	tests := []struct {
 		name            string
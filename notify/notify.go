@@ -26,12 +26,14 @@ import (
 	"github.com/cespare/xxhash/v2"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/model"
+	"go.uber.org/atomic"
 
 	"github.com/prometheus/alertmanager/featurecontrol"
 	"github.com/prometheus/alertmanager/inhibit"
 	"github.com/prometheus/alertmanager/nflog"
 	"github.com/prometheus/alertmanager/nflog/nflogpb"
 	"github.com/prometheus/alertmanager/silence"
+	"github.com/prometheus/alertmanager/template"
 	"github.com/prometheus/alertmanager/timeinterval"
 	"github.com/prometheus/alertmanager/types"
 )
@@ -66,6 +68,8 @@ type Integration struct {
 	name         string
 	idx          int
 	receiverName string
+
+	debugPayloads bool
 }
 
 // NewIntegration returns a new integration.
@@ -79,8 +83,15 @@ func NewIntegration(notifier Notifier, rs ResolvedSender, name string, idx int,
 	}
 }
 
-// Notify implements the Notifier interface.
-func (i *Integration) Notify(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+// Notify implements the Notifier interface. A panic of the notifier, e.g.
+// while executing a user template, is recovered and returned as an error
+// which is not retried, as retrying would panic again.
+func (i *Integration) Notify(ctx context.Context, alerts ...*types.Alert) (retry bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			retry, err = false, fmt.Errorf("%s: panic while notifying: %v", i, r)
+		}
+	}()
 	return i.notifier.Notify(ctx, alerts...)
 }
 
@@ -89,6 +100,19 @@ func (i *Integration) SendResolved() bool {
 	return i.rs.SendResolved()
 }
 
+// SetDebugPayloads sets whether the requests of the failed notification
+// attempts of the integration are recorded in the payload log of the
+// pipeline.
+func (i *Integration) SetDebugPayloads(enabled bool) {
+	i.debugPayloads = enabled
+}
+
+// DebugPayloads returns whether the requests of the failed notification
+// attempts of the integration are recorded.
+func (i *Integration) DebugPayloads() bool {
+	return i.debugPayloads
+}
+
 // Name returns the name of the integration.
 func (i *Integration) Name() string {
 	return i.name
@@ -119,6 +143,15 @@ const (
 	keyMuteTimeIntervals
 	keyActiveTimeIntervals
 	keyRouteID
+	keyPreview
+	keyReceiverData
+	keyPayloadRecorder
+	keyHeaders
+	keyAlertLimit
+	keyCollapseBy
+	keyDelta
+	keyPeerTimeout
+	keyFailureReason
 )
 
 // WithReceiverName populates a context with a receiver name.
@@ -169,6 +202,24 @@ func WithRouteID(ctx context.Context, routeID string) context.Context {
 	return context.WithValue(ctx, keyRouteID, routeID)
 }
 
+// WithAlertLimit populates a context with the maximum number of alerts in the
+// template data of notifications.
+func WithAlertLimit(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, keyAlertLimit, n)
+}
+
+// WithCollapseBy populates a context with the annotations which, with the
+// alertname, identify the alerts collapsed in the template data.
+func WithCollapseBy(ctx context.Context, annotations []string) context.Context {
+	return context.WithValue(ctx, keyCollapseBy, annotations)
+}
+
+// WithPeerTimeout populates a context with the time to wait between the
+// peers of the cluster to send the notifications of the route.
+func WithPeerTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, keyPeerTimeout, d)
+}
+
 // RepeatInterval extracts a repeat interval from the context. Iff none exists, the
 // second argument is false.
 func RepeatInterval(ctx context.Context) (time.Duration, bool) {
@@ -225,6 +276,12 @@ func MuteTimeIntervalNames(ctx context.Context) ([]string, bool) {
 	return v, ok
 }
 
+// WithDelta populates a context with the change of the alerts of the group
+// since the last notification.
+func WithDelta(ctx context.Context, d template.Delta) context.Context {
+	return context.WithValue(ctx, keyDelta, d)
+}
+
 // ActiveTimeIntervalNames extracts a slice of active time names from the context. If none exists, the
 // second argument is false.
 func ActiveTimeIntervalNames(ctx context.Context) ([]string, bool) {
@@ -232,6 +289,50 @@ func ActiveTimeIntervalNames(ctx context.Context) ([]string, bool) {
 	return v, ok
 }
 
+// CollapseBy extracts the annotations identifying the alerts collapsed in the
+// template data from the context. Iff none exists, the second argument is
+// false.
+func CollapseBy(ctx context.Context) ([]string, bool) {
+	v, ok := ctx.Value(keyCollapseBy).([]string)
+	return v, ok
+}
+
+// Delta extracts the change of the alerts of the group since the last
+// notification from the context. Iff none exists, the second argument is
+// false.
+func Delta(ctx context.Context) (template.Delta, bool) {
+	v, ok := ctx.Value(keyDelta).(template.Delta)
+	return v, ok
+}
+
+// AlertLimit extracts the maximum number of alerts in the template data from
+// the context. Iff none exists, the second argument is false.
+func AlertLimit(ctx context.Context) (int, bool) {
+	v, ok := ctx.Value(keyAlertLimit).(int)
+	return v, ok
+}
+
+// PeerTimeout extracts the time to wait between the peers of the cluster from
+// the context. Iff none exists, the second argument is false.
+func PeerTimeout(ctx context.Context) (time.Duration, bool) {
+	v, ok := ctx.Value(keyPeerTimeout).(time.Duration)
+	return v, ok
+}
+
+// WithFailureReason populates a context with the reason of the last failed
+// attempt of a notification which eventually succeeded.
+func WithFailureReason(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, keyFailureReason, reason)
+}
+
+// FailureReason extracts the reason of the last failed attempt of the
+// notification from the context. Iff none exists, the second argument is
+// false.
+func FailureReason(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(keyFailureReason).(string)
+	return v, ok
+}
+
 // RouteID extracts a RouteID from the context. Iff none exists, the
 // // second argument is false.
 func RouteID(ctx context.Context) (string, bool) {
@@ -239,6 +340,65 @@ func RouteID(ctx context.Context) (string, bool) {
 	return v, ok
 }
 
+// ReceiverData holds data returned by an integration for the notifications of
+// a group, such as the identifier of the sent message, so that the following
+// notifications can refer to it. It is stored in the notification log with the
+// notification.
+type ReceiverData struct {
+	mtx  sync.Mutex
+	data map[string]string
+}
+
+// NewReceiverData returns the receiver data of the previous notification.
+func NewReceiverData(prev map[string]string) *ReceiverData {
+	d := &ReceiverData{data: make(map[string]string, len(prev))}
+	for k, v := range prev {
+		d.data[k] = v
+	}
+	return d
+}
+
+// Get returns the value of the key.
+func (d *ReceiverData) Get(key string) (string, bool) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	v, ok := d.data[key]
+	return v, ok
+}
+
+// Set sets the value of the key for the notification being sent.
+func (d *ReceiverData) Set(key, value string) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.data[key] = value
+}
+
+// Data returns a copy of the data, or nil if it's empty.
+func (d *ReceiverData) Data() map[string]string {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if len(d.data) == 0 {
+		return nil
+	}
+	data := make(map[string]string, len(d.data))
+	for k, v := range d.data {
+		data[k] = v
+	}
+	return data
+}
+
+// WithReceiverData populates a context with the receiver data of a group.
+func WithReceiverData(ctx context.Context, d *ReceiverData) context.Context {
+	return context.WithValue(ctx, keyReceiverData, d)
+}
+
+// ReceiverDataFromContext extracts the receiver data from the context. Iff none
+// exists, the second argument is false.
+func ReceiverDataFromContext(ctx context.Context) (*ReceiverData, bool) {
+	v, ok := ctx.Value(keyReceiverData).(*ReceiverData)
+	return v, ok
+}
+
 // A Stage processes alerts under the constraints of the given context.
 type Stage interface {
 	Exec(ctx context.Context, l *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error)
@@ -253,10 +413,21 @@ func (f StageFunc) Exec(ctx context.Context, l *slog.Logger, alerts ...*types.Al
 }
 
 type NotificationLog interface {
-	Log(r *nflogpb.Receiver, gkey string, firingAlerts, resolvedAlerts []uint64, expiry time.Duration) error
+	Log(r *nflogpb.Receiver, gkey string, firingAlerts, resolvedAlerts []uint64, receiverData map[string]string, origins []string, failureReason string, expiry time.Duration) error
 	Query(params ...nflog.QueryParam) ([]*nflogpb.Entry, error)
 }
 
+// RetryQueue persists the notifications which failed and are being retried.
+type RetryQueue interface {
+	// Get returns the number of failed attempts and the time of the next
+	// attempt of the notification of the group to the receiver.
+	Get(gkey string, r *nflogpb.Receiver) (attempts int, next time.Time, ok bool)
+	// Set records a failed attempt of the notification.
+	Set(gkey string, r *nflogpb.Receiver, alerts []*types.Alert, attempts int, next time.Time)
+	// Delete removes the notification once it succeeded or can't be retried.
+	Delete(gkey string, r *nflogpb.Receiver)
+}
+
 type Metrics struct {
 	numNotifications                   *prometheus.CounterVec
 	numTotalFailedNotifications        *prometheus.CounterVec
@@ -379,22 +550,173 @@ func (m *Metrics) InitializeFor(receiver map[string][]Integration) {
 	}
 }
 
+// StagePosition is a point in the notification pipeline at which custom
+// stages can be injected.
+type StagePosition int
+
+const (
+	// StagePositionPreMute is after the gossip has settled and before
+	// inhibitions, time intervals and silences are applied.
+	StagePositionPreMute StagePosition = iota
+	// StagePositionPostMute is after inhibitions, time intervals and silences
+	// are applied and before the integrations of the receiver are executed.
+	StagePositionPostMute
+	// StagePositionPreNotify is after the wait and dedup stages of an
+	// integration and before the notification is sent.
+	StagePositionPreNotify
+	// StagePositionPostNotify is after a notification has been sent
+	// successfully and recorded in the notification log.
+	StagePositionPostNotify
+)
+
+// StageFactory creates a custom stage for the given receiver. The integration
+// is nil for the receiver-level positions StagePositionPreMute and
+// StagePositionPostMute. A factory can return nil to not add a stage.
+type StageFactory func(receiver string, integration *Integration) Stage
+
 type PipelineBuilder struct {
 	metrics *Metrics
 	ff      featurecontrol.Flagger
+
+	mtx          sync.RWMutex
+	customStages map[StagePosition][]StageFactory
+	acked        func(*types.Alert) bool
+	disabled     func(receiver string) bool
+	retries      RetryQueue
+	payloads     *PayloadLog
+	deadLetter   string
+	quiet        map[string][]string
+	annotations  map[string]AnnotationFilter
+	leaderOnly   map[string]struct{}
+	isLeader     func() bool
+	statuses     *integrationStatuses
+	stats        *receiverStats
 }
 
 func NewPipelineBuilder(r prometheus.Registerer, ff featurecontrol.Flagger) *PipelineBuilder {
 	return &PipelineBuilder{
-		metrics: NewMetrics(r, ff),
-		ff:      ff,
+		metrics:      NewMetrics(r, ff),
+		ff:           ff,
+		customStages: map[StagePosition][]StageFactory{},
+		statuses:     newIntegrationStatuses(),
+		stats:        newReceiverStats(),
 	}
 }
 
+// RegisterStage registers a factory for custom stages that are injected at
+// the given position of every pipeline built afterwards. Stages registered
+// for the same position are executed in the order of registration.
+func (pb *PipelineBuilder) RegisterStage(pos StagePosition, f StageFactory) {
+	pb.mtx.Lock()
+	defer pb.mtx.Unlock()
+	pb.customStages[pos] = append(pb.customStages[pos], f)
+}
+
+// SuppressAckedRepeats makes the pipelines built afterwards skip the
+// re-notifications sent every repeat_interval while all firing alerts of a
+// group are acknowledged according to acked.
+func (pb *PipelineBuilder) SuppressAckedRepeats(acked func(*types.Alert) bool) {
+	pb.mtx.Lock()
+	defer pb.mtx.Unlock()
+	pb.acked = acked
+}
+
+// SkipDisabledReceivers makes the pipelines built afterwards skip the
+// notifications of the receivers while disabled returns true for them.
+func (pb *PipelineBuilder) SkipDisabledReceivers(disabled func(receiver string) bool) {
+	pb.mtx.Lock()
+	defer pb.mtx.Unlock()
+	pb.disabled = disabled
+}
+
+// SendFromLeaderOnly makes the pipelines built afterwards skip the
+// notifications of the receivers, given by name, while isLeader returns false.
+func (pb *PipelineBuilder) SendFromLeaderOnly(receivers []string, isLeader func() bool) {
+	pb.mtx.Lock()
+	defer pb.mtx.Unlock()
+	pb.leaderOnly = make(map[string]struct{}, len(receivers))
+	for _, r := range receivers {
+		pb.leaderOnly[r] = struct{}{}
+	}
+	pb.isLeader = isLeader
+}
+
+// PersistRetries makes the pipelines built afterwards record the
+// notifications being retried in the queue, and resume the attempts recorded
+// in it.
+func (pb *PipelineBuilder) PersistRetries(q RetryQueue) {
+	pb.mtx.Lock()
+	defer pb.mtx.Unlock()
+	pb.retries = q
+}
+
+// LogFailedPayloads makes the pipelines built afterwards record in the log
+// the requests of the failed notification attempts of the integrations with
+// debug payloads enabled.
+func (pb *PipelineBuilder) LogFailedPayloads(l *PayloadLog) {
+	pb.mtx.Lock()
+	defer pb.mtx.Unlock()
+	pb.payloads = l
+}
+
+// SendDeadLetters makes the pipelines built afterwards notify the receiver
+// with the given name when a notification of another receiver is given up.
+// An empty name disables it.
+func (pb *PipelineBuilder) SendDeadLetters(receiver string) {
+	pb.mtx.Lock()
+	defer pb.mtx.Unlock()
+	pb.deadLetter = receiver
+}
+
+// QueueDuringQuietTimes makes the pipelines built afterwards queue the
+// notifications of the receivers while the current time is within their
+// quiet time intervals, given by receiver name.
+func (pb *PipelineBuilder) QueueDuringQuietTimes(intervals map[string][]string) {
+	pb.mtx.Lock()
+	defer pb.mtx.Unlock()
+	pb.quiet = intervals
+}
+
+// FilterAnnotations makes the pipelines built afterwards filter the
+// annotations of the alerts sent by the integrations of the receivers, given
+// by receiver name.
+func (pb *PipelineBuilder) FilterAnnotations(filters map[string]AnnotationFilter) {
+	pb.mtx.Lock()
+	defer pb.mtx.Unlock()
+	pb.annotations = filters
+}
+
+// IntegrationStatuses returns the outcome of the last notification attempts
+// of the integrations of the last built pipelines, ordered by receiver and
+// integration.
+func (pb *PipelineBuilder) IntegrationStatuses() []IntegrationStatus {
+	return pb.statuses.list()
+}
+
+// ReceiverStats returns the statistics of the notifications of the receivers
+// of the last built pipelines over ReceiverStatsWindow, ordered by receiver.
+func (pb *PipelineBuilder) ReceiverStats() []ReceiverStats {
+	return pb.stats.list(time.Now())
+}
+
+// customStagesFor returns the custom stages registered at the given position
+// for the receiver and integration.
+func (pb *PipelineBuilder) customStagesFor(pos StagePosition, receiver string, integration *Integration) MultiStage {
+	pb.mtx.RLock()
+	defer pb.mtx.RUnlock()
+	var ms MultiStage
+	for _, f := range pb.customStages[pos] {
+		if s := f(receiver, integration); s != nil {
+			ms = append(ms, s)
+		}
+	}
+	return ms
+}
+
 // New returns a map of receivers to Stages.
 func (pb *PipelineBuilder) New(
 	receivers map[string][]Integration,
-	wait func() time.Duration,
+	wait func(context.Context) time.Duration,
 	inhibitor *inhibit.Inhibitor,
 	silencer *silence.Silencer,
 	intervener *timeinterval.Intervener,
@@ -410,24 +732,58 @@ func (pb *PipelineBuilder) New(
 	tms := NewTimeMuteStage(intervener, marker, pb.metrics)
 	ss := NewMuteStage(silencer, pb.metrics)
 
+	pb.mtx.RLock()
+	var dl *DeadLetter
+	if integrations, ok := receivers[pb.deadLetter]; ok {
+		dl = newDeadLetter(pb.deadLetter, integrations, pb.metrics)
+	}
+	quiet, annotations, disabled := pb.quiet, pb.annotations, pb.disabled
+	leaderOnly, isLeader := pb.leaderOnly, pb.isLeader
+	pb.mtx.RUnlock()
+
 	for name := range receivers {
-		st := createReceiverStage(name, receivers[name], wait, notificationLog, pb.metrics)
-		rs[name] = MultiStage{ms, is, tas, tms, ss, st}
+		st := pb.createReceiverStage(name, receivers[name], wait, notificationLog, dl)
+		if f, ok := annotations[name]; ok && !f.empty() {
+			st = MultiStage{NewAnnotationFilterStage(f), st}
+		}
+		if len(quiet[name]) > 0 {
+			st = NewQuietStage(intervener, quiet[name], st)
+		}
+		if _, ok := leaderOnly[name]; ok && isLeader != nil {
+			st = NewLeaderOnlyStage(isLeader, st, pb.metrics)
+		}
+		if disabled != nil {
+			st = NewDisabledReceiverStage(name, disabled, st, pb.metrics)
+		}
+
+		var s MultiStage
+		s = append(s, ms)
+		s = append(s, pb.customStagesFor(StagePositionPreMute, name, nil)...)
+		s = append(s, is, tas, tms, ss)
+		s = append(s, pb.customStagesFor(StagePositionPostMute, name, nil)...)
+		s = append(s, st)
+		rs[name] = s
 	}
 
 	pb.metrics.InitializeFor(receivers)
+	pb.statuses.retain(receivers)
+	pb.stats.retain(receivers)
 
 	return rs
 }
 
 // createReceiverStage creates a pipeline of stages for a receiver.
-func createReceiverStage(
+func (pb *PipelineBuilder) createReceiverStage(
 	name string,
 	integrations []Integration,
-	wait func() time.Duration,
+	wait func(context.Context) time.Duration,
 	notificationLog NotificationLog,
-	metrics *Metrics,
+	deadLetter *DeadLetter,
 ) Stage {
+	pb.mtx.RLock()
+	acked, retries, payloads := pb.acked, pb.retries, pb.payloads
+	pb.mtx.RUnlock()
+
 	var fs FanoutStage
 	for i := range integrations {
 		recv := &nflogpb.Receiver{
@@ -437,9 +793,24 @@ func createReceiverStage(
 		}
 		var s MultiStage
 		s = append(s, NewWaitStage(wait))
-		s = append(s, NewDedupStage(&integrations[i], notificationLog, recv))
-		s = append(s, NewRetryStage(integrations[i], name, metrics))
+		ds := NewDedupStage(&integrations[i], notificationLog, recv)
+		ds.acked = acked
+		s = append(s, ds)
+		s = append(s, pb.customStagesFor(StagePositionPreNotify, name, &integrations[i])...)
+		rs := NewRetryStage(integrations[i], name, pb.metrics)
+		rs.recv, rs.queue = recv, retries
+		rs.statuses = pb.statuses
+		rs.stats = pb.stats
+		// The dead letter receiver doesn't notify itself.
+		if deadLetter != nil && deadLetter.receiver != name {
+			rs.deadLetter = deadLetter
+		}
+		if integrations[i].DebugPayloads() {
+			rs.payloads = payloads
+		}
+		s = append(s, rs)
 		s = append(s, NewSetNotifiesStage(notificationLog, recv))
+		s = append(s, pb.customStagesFor(StagePositionPostNotify, name, &integrations[i])...)
 
 		fs = append(fs, s)
 	}
@@ -536,6 +907,8 @@ const (
 	SuppressedReasonInhibition         = "inhibition"
 	SuppressedReasonMuteTimeInterval   = "mute_time_interval"
 	SuppressedReasonActiveTimeInterval = "active_time_interval"
+	SuppressedReasonDisabledReceiver   = "disabled_receiver"
+	SuppressedReasonNotLeader          = "not_leader"
 )
 
 // MuteStage filters alerts through a Muter.
@@ -585,11 +958,12 @@ func (n *MuteStage) Exec(ctx context.Context, logger *slog.Logger, alerts ...*ty
 // WaitStage waits for a certain amount of time before continuing or until the
 // context is done.
 type WaitStage struct {
-	wait func() time.Duration
+	wait func(context.Context) time.Duration
 }
 
-// NewWaitStage returns a new WaitStage.
-func NewWaitStage(wait func() time.Duration) *WaitStage {
+// NewWaitStage returns a new WaitStage. The wait function is given the context
+// of the notification, which may carry the peer timeout of the route.
+func NewWaitStage(wait func(context.Context) time.Duration) *WaitStage {
 	return &WaitStage{
 		wait: wait,
 	}
@@ -598,7 +972,7 @@ func NewWaitStage(wait func() time.Duration) *WaitStage {
 // Exec implements the Stage interface.
 func (ws *WaitStage) Exec(ctx context.Context, _ *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
 	select {
-	case <-time.After(ws.wait()):
+	case <-time.After(ws.wait(ctx)):
 	case <-ctx.Done():
 		return ctx, nil, ctx.Err()
 	}
@@ -614,6 +988,9 @@ type DedupStage struct {
 
 	now  func() time.Time
 	hash func(*types.Alert) uint64
+	// acked returns whether an alert is acknowledged. If nil, acknowledged
+	// alerts are re-notified like any other alert.
+	acked func(*types.Alert) bool
 }
 
 // NewDedupStage wraps a DedupStage that runs against the given notification log.
@@ -666,7 +1043,7 @@ func hashAlert(a *types.Alert) uint64 {
 	return hash
 }
 
-func (n *DedupStage) needsUpdate(entry *nflogpb.Entry, firing, resolved map[uint64]struct{}, repeat time.Duration) bool {
+func (n *DedupStage) needsUpdate(entry *nflogpb.Entry, firing, resolved map[uint64]struct{}, repeat time.Duration, acked bool) bool {
 	// If we haven't notified about the alert group before, notify right away
 	// unless we only have resolved alerts.
 	if entry == nil {
@@ -692,6 +1069,12 @@ func (n *DedupStage) needsUpdate(entry *nflogpb.Entry, firing, resolved map[uint
 		return true
 	}
 
+	// Nothing changed and all firing alerts are acknowledged, there is no
+	// need to remind the receiver about them.
+	if acked {
+		return false
+	}
+
 	// Nothing changed, only notify if the repeat interval has passed.
 	return entry.Timestamp.Before(n.now().Add(-repeat))
 }
@@ -714,6 +1097,7 @@ func (n *DedupStage) Exec(ctx context.Context, _ *slog.Logger, alerts ...*types.
 	resolved := []uint64{}
 
 	var hash uint64
+	acked := n.acked != nil
 	for _, a := range alerts {
 		hash = n.hash(a)
 		if a.Resolved() {
@@ -722,6 +1106,7 @@ func (n *DedupStage) Exec(ctx context.Context, _ *slog.Logger, alerts ...*types.
 		} else {
 			firing = append(firing, hash)
 			firingSet[hash] = struct{}{}
+			acked = acked && n.acked(a)
 		}
 	}
 
@@ -742,12 +1127,41 @@ func (n *DedupStage) Exec(ctx context.Context, _ *slog.Logger, alerts ...*types.
 		return ctx, nil, fmt.Errorf("unexpected entry result size %d", len(entries))
 	}
 
-	if n.needsUpdate(entry, firingSet, resolvedSet, repeatInterval) {
-		return ctx, alerts, nil
+	if n.needsUpdate(entry, firingSet, resolvedSet, repeatInterval, acked) {
+		var prev map[string]string
+		if entry != nil {
+			prev = entry.ReceiverData
+		}
+		ctx = WithDelta(ctx, groupDelta(entry, firing, resolved))
+		return WithReceiverData(ctx, NewReceiverData(prev)), alerts, nil
 	}
 	return ctx, nil, nil
 }
 
+// groupDelta returns the change of the alerts of the group since the
+// notification recorded in entry, given the hashes of the firing and resolved
+// alerts.
+func groupDelta(entry *nflogpb.Entry, firing, resolved []uint64) template.Delta {
+	prev := map[uint64]struct{}{}
+	if entry != nil {
+		for _, h := range entry.FiringAlerts {
+			prev[h] = struct{}{}
+		}
+	}
+	var d template.Delta
+	for _, h := range firing {
+		if _, ok := prev[h]; !ok {
+			d.Added++
+		}
+	}
+	for _, h := range resolved {
+		if _, ok := prev[h]; ok {
+			d.Resolved++
+		}
+	}
+	return d
+}
+
 // RetryStage notifies via passed integration with exponential backoff until it
 // succeeds. It aborts if the context is canceled or timed out.
 type RetryStage struct {
@@ -755,6 +1169,24 @@ type RetryStage struct {
 	groupName   string
 	metrics     *Metrics
 	labelValues []string
+
+	// The failed attempts are recorded in the queue, if set, under the
+	// receiver.
+	recv  *nflogpb.Receiver
+	queue RetryQueue
+
+	// The requests of the failed attempts are recorded in the log, if set.
+	payloads *PayloadLog
+
+	// The notifications given up are sent to the dead letter receiver, if
+	// set.
+	deadLetter *DeadLetter
+
+	// The outcome of the attempts is recorded in the statuses, if set.
+	statuses *integrationStatuses
+
+	// The outcome of the notifications is recorded in the stats, if set.
+	stats *receiverStats
 }
 
 // NewRetryStage returns a new instance of a RetryStage.
@@ -775,15 +1207,16 @@ func NewRetryStage(i Integration, groupName string, metrics *Metrics) *RetryStag
 
 func (r RetryStage) Exec(ctx context.Context, l *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
 	r.metrics.numNotifications.WithLabelValues(r.labelValues...).Inc()
+	failed := alerts
 	ctx, alerts, err := r.exec(ctx, l, alerts...)
+	r.stats.record(r.groupName, time.Now(), err)
 
-	failureReason := DefaultReason.String()
 	if err != nil {
-		var e *ErrorWithReason
-		if errors.As(err, &e) {
-			failureReason = e.Reason.String()
-		}
+		failureReason := ReasonFromError(err).String()
 		r.metrics.numTotalFailedNotifications.WithLabelValues(append(r.labelValues, failureReason)...).Inc()
+		if r.deadLetter != nil && isUndeliverable(ctx, err) {
+			r.deadLetter.Send(ctx, l, r.groupName, r.integration.String(), failed, err)
+		}
 	}
 	return ctx, alerts, err
 }
@@ -800,6 +1233,7 @@ func (r RetryStage) exec(ctx context.Context, l *slog.Logger, alerts ...*types.A
 			return ctx, nil, errors.New("firing alerts missing")
 		}
 		if len(firing) == 0 {
+			r.done(ctx)
 			return ctx, alerts, nil
 		}
 		for _, a := range alerts {
@@ -811,11 +1245,9 @@ func (r RetryStage) exec(ctx context.Context, l *slog.Logger, alerts ...*types.A
 		sent = alerts
 	}
 
-	b := backoff.NewExponentialBackOff()
-	b.MaxElapsedTime = 0 // Always retry.
-
-	tick := backoff.NewTicker(b)
-	defer tick.Stop()
+	eb := backoff.NewExponentialBackOff()
+	eb.MaxElapsedTime = 0 // Always retry.
+	b := &recordingBackOff{BackOff: eb}
 
 	var (
 		i    = 0
@@ -823,10 +1255,31 @@ func (r RetryStage) exec(ctx context.Context, l *slog.Logger, alerts ...*types.A
 	)
 
 	l = l.With("receiver", r.groupName, "integration", r.integration.String())
-	if groupKey, ok := GroupKey(ctx); ok {
+	groupKey, ok := GroupKey(ctx)
+	if ok {
 		l = l.With("aggrGroup", groupKey)
 	}
 
+	// Resume the attempts of a notification which failed during a previous
+	// flush or before a restart.
+	if r.queue != nil && ok {
+		if attempts, next, found := r.queue.Get(groupKey, r.recv); found {
+			i = attempts
+			if wait := time.Until(next); wait > 0 {
+				l.Debug("Resuming notify retries", "attempts", i, "wait", wait)
+				t := time.NewTimer(wait)
+				select {
+				case <-t.C:
+				case <-ctx.Done():
+					t.Stop()
+				}
+			}
+		}
+	}
+
+	tick := backoff.NewTicker(b)
+	defer tick.Stop()
+
 	for {
 		i++
 		// Always check the context first to not notify again.
@@ -850,26 +1303,44 @@ func (r RetryStage) exec(ctx context.Context, l *slog.Logger, alerts ...*types.A
 
 		select {
 		case <-tick.C:
+			var (
+				nctx = ctx
+				rec  *payloadRecorder
+			)
+			if r.payloads != nil {
+				rec = &payloadRecorder{}
+				nctx = withPayloadRecorder(ctx, rec)
+			}
 			now := time.Now()
-			retry, err := r.integration.Notify(ctx, sent...)
+			retry, err := r.integration.Notify(nctx, sent...)
 			dur := time.Since(now)
+			r.statuses.record(r.groupName, r.integration.String(), now, err)
 			r.metrics.notificationLatencySeconds.WithLabelValues(r.labelValues...).Observe(dur.Seconds())
 			r.metrics.numNotificationRequestsTotal.WithLabelValues(r.labelValues...).Inc()
 			if err != nil {
 				r.metrics.numNotificationRequestsFailedTotal.WithLabelValues(r.labelValues...).Inc()
+				if rec != nil {
+					r.logPayloads(now, groupKey, i, rec, err)
+				}
+				reason := ReasonFromError(err)
 				if !retry {
-					return ctx, alerts, fmt.Errorf("%s/%s: notify retry canceled due to unrecoverable error after %d attempts: %w", r.groupName, r.integration.String(), i, err)
+					r.done(ctx)
+					return ctx, alerts, fmt.Errorf("%s/%s: notify retry canceled due to unrecoverable error (%s) after %d attempts: %w", r.groupName, r.integration.String(), reason, i, err)
 				}
 				if ctx.Err() == nil {
 					if iErr == nil || err.Error() != iErr.Error() {
 						// Log the error if the context isn't done and the error isn't the same as before.
-						l.Warn("Notify attempt failed, will retry later", "attempts", i, "err", err)
+						l.Warn("Notify attempt failed, will retry later", "attempts", i, "reason", reason, "err", err)
 					}
 					// Save this error to be able to return the last seen error by an
 					// integration upon context timeout.
 					iErr = err
 				}
+				if r.queue != nil && groupKey != "" {
+					r.queue.Set(groupKey, r.recv, alerts, i, time.Now().Add(b.next.Load()))
+				}
 			} else {
+				r.done(ctx)
 				l := l.With("attempts", i, "duration", dur)
 				if i <= 1 {
 					l = l.With("alerts", fmt.Sprintf("%v", alerts))
@@ -878,6 +1349,11 @@ func (r RetryStage) exec(ctx context.Context, l *slog.Logger, alerts ...*types.A
 					l.Info("Notify success")
 				}
 
+				if iErr != nil {
+					// Record why the previous attempts failed in the
+					// notification log.
+					ctx = WithFailureReason(ctx, ReasonFromError(iErr).String())
+				}
 				return ctx, alerts, nil
 			}
 		case <-ctx.Done():
@@ -897,6 +1373,52 @@ func (r RetryStage) exec(ctx context.Context, l *slog.Logger, alerts ...*types.A
 	}
 }
 
+// logPayloads records the requests of a failed notification attempt in the
+// payload log.
+func (r RetryStage) logPayloads(t time.Time, groupKey string, attempt int, rec *payloadRecorder, err error) {
+	e := PayloadLogEntry{
+		Time:        t,
+		Receiver:    r.groupName,
+		Integration: r.integration.String(),
+		GroupKey:    groupKey,
+		Attempt:     attempt,
+		Error:       RedactURL(err).Error(),
+	}
+	exchanges := rec.recorded()
+	if len(exchanges) == 0 {
+		r.payloads.Add(e)
+		return
+	}
+	for _, x := range exchanges {
+		e.Method, e.URL, e.RequestBody = x.method, x.url, x.requestBody
+		e.StatusCode, e.ResponseBody = x.statusCode, x.responseBody
+		r.payloads.Add(e)
+	}
+}
+
+// done removes the notification from the retry queue.
+func (r RetryStage) done(ctx context.Context) {
+	if r.queue == nil {
+		return
+	}
+	if groupKey, ok := GroupKey(ctx); ok {
+		r.queue.Delete(groupKey, r.recv)
+	}
+}
+
+// recordingBackOff records the last backoff returned by the wrapped BackOff,
+// which is the time until the next attempt.
+type recordingBackOff struct {
+	backoff.BackOff
+	next atomic.Duration
+}
+
+func (b *recordingBackOff) NextBackOff() time.Duration {
+	d := b.BackOff.NextBackOff()
+	b.next.Store(d)
+	return d
+}
+
 // SetNotifiesStage sets the notification information about passed alerts. The
 // passed alerts should have already been sent to the receivers.
 type SetNotifiesStage struct {
@@ -935,7 +1457,19 @@ func (n SetNotifiesStage) Exec(ctx context.Context, l *slog.Logger, alerts ...*t
 	}
 	expiry := 2 * repeat
 
-	return ctx, alerts, n.nflog.Log(n.recv, gkey, firing, resolved, expiry)
+	var data map[string]string
+	if d, ok := ReceiverDataFromContext(ctx); ok {
+		data = d.Data()
+	}
+
+	var origins [][]string
+	for _, a := range alerts {
+		origins = append(origins, a.Origins)
+	}
+
+	reason, _ := FailureReason(ctx)
+
+	return ctx, alerts, n.nflog.Log(n.recv, gkey, firing, resolved, data, types.MergeOrigins(origins...), reason, expiry)
 }
 
 type timeStage struct {
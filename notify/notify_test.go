@@ -34,8 +34,10 @@ import (
 	"github.com/prometheus/alertmanager/featurecontrol"
 	"github.com/prometheus/alertmanager/nflog"
 	"github.com/prometheus/alertmanager/nflog/nflogpb"
+	"github.com/prometheus/alertmanager/retry"
 	"github.com/prometheus/alertmanager/silence"
 	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/template"
 	"github.com/prometheus/alertmanager/timeinterval"
 	"github.com/prometheus/alertmanager/types"
 )
@@ -62,15 +64,15 @@ type testNflog struct {
 	qres []*nflogpb.Entry
 	qerr error
 
-	logFunc func(r *nflogpb.Receiver, gkey string, firingAlerts, resolvedAlerts []uint64, expiry time.Duration) error
+	logFunc func(r *nflogpb.Receiver, gkey string, firingAlerts, resolvedAlerts []uint64, receiverData map[string]string, origins []string, failureReason string, expiry time.Duration) error
 }
 
 func (l *testNflog) Query(p ...nflog.QueryParam) ([]*nflogpb.Entry, error) {
 	return l.qres, l.qerr
 }
 
-func (l *testNflog) Log(r *nflogpb.Receiver, gkey string, firingAlerts, resolvedAlerts []uint64, expiry time.Duration) error {
-	return l.logFunc(r, gkey, firingAlerts, resolvedAlerts, expiry)
+func (l *testNflog) Log(r *nflogpb.Receiver, gkey string, firingAlerts, resolvedAlerts []uint64, receiverData map[string]string, origins []string, failureReason string, expiry time.Duration) error {
+	return l.logFunc(r, gkey, firingAlerts, resolvedAlerts, receiverData, origins, failureReason, expiry)
 }
 
 func (l *testNflog) GC() (int, error) {
@@ -100,6 +102,7 @@ func TestDedupStageNeedsUpdate(t *testing.T) {
 		resolvedAlerts map[uint64]struct{}
 		repeat         time.Duration
 		resolve        bool
+		acked          bool
 
 		res bool
 	}{
@@ -144,6 +147,22 @@ func TestDedupStageNeedsUpdate(t *testing.T) {
 			repeat:       10 * time.Minute,
 			firingAlerts: alertHashSet(1, 2, 3),
 			res:          true,
+		}, {
+			// Identical sets of acknowledged alerts shouldn't update after repeat_interval.
+			entry: &nflogpb.Entry{
+				FiringAlerts: []uint64{1, 2, 3},
+				Timestamp:    now.Add(-11 * time.Minute),
+			},
+			repeat:       10 * time.Minute,
+			firingAlerts: alertHashSet(1, 2, 3),
+			acked:        true,
+			res:          false,
+		}, {
+			// Different sets of acknowledged alerts should update.
+			entry:        &nflogpb.Entry{FiringAlerts: []uint64{1, 2}},
+			firingAlerts: alertHashSet(1, 2, 3),
+			acked:        true,
+			res:          true,
 		}, {
 			// Different sets of resolved alerts without firing alerts shouldn't update after repeat_interval.
 			entry: &nflogpb.Entry{
@@ -211,11 +230,39 @@ func TestDedupStageNeedsUpdate(t *testing.T) {
 			now: func() time.Time { return now },
 			rs:  sendResolved(c.resolve),
 		}
-		res := s.needsUpdate(c.entry, c.firingAlerts, c.resolvedAlerts, c.repeat)
+		res := s.needsUpdate(c.entry, c.firingAlerts, c.resolvedAlerts, c.repeat, c.acked)
 		require.Equal(t, c.res, res)
 	}
 }
 
+func TestWaitStage(t *testing.T) {
+	var got []time.Duration
+	ws := NewWaitStage(func(ctx context.Context) time.Duration {
+		d, ok := PeerTimeout(ctx)
+		if !ok {
+			d = -1
+		}
+		got = append(got, d)
+		return 0
+	})
+	alerts := []*types.Alert{{}, {}}
+
+	_, res, err := ws.Exec(context.Background(), promslog.NewNopLogger(), alerts...)
+	require.NoError(t, err)
+	require.Equal(t, alerts, res)
+
+	_, _, err = ws.Exec(WithPeerTimeout(context.Background(), time.Second), promslog.NewNopLogger(), alerts...)
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{-1, time.Second}, got)
+
+	// The stage returns when the context is done.
+	ws = NewWaitStage(func(context.Context) time.Duration { return time.Hour })
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = ws.Exec(ctx, promslog.NewNopLogger(), alerts...)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 func TestDedupStage(t *testing.T) {
 	i := 0
 	now := utcNow()
@@ -293,12 +340,38 @@ func TestDedupStage(t *testing.T) {
 			{
 				FiringAlerts: []uint64{1, 2, 3, 4},
 				Timestamp:    now,
+				ReceiverData: map[string]string{"ts": "1"},
 			},
 		},
 	}
-	_, res, err = s.Exec(ctx, promslog.NewNopLogger(), alerts...)
+	resctx, res, err := s.Exec(ctx, promslog.NewNopLogger(), alerts...)
 	require.NoError(t, err)
 	require.Equal(t, alerts, res, "unexpected alerts returned")
+
+	// The receiver data of the previous notification is passed on.
+	d, ok := ReceiverDataFromContext(resctx)
+	require.True(t, ok)
+	ts, ok := d.Get("ts")
+	require.True(t, ok)
+	require.Equal(t, "1", ts)
+
+	// So is the change of the alerts since the previous notification.
+	delta, ok := Delta(resctx)
+	require.True(t, ok)
+	require.Equal(t, template.Delta{Added: 1}, delta)
+}
+
+func TestGroupDelta(t *testing.T) {
+	// All firing alerts are new without previous notification.
+	require.Equal(t, template.Delta{Added: 2}, groupDelta(nil, []uint64{1, 2}, []uint64{3}))
+
+	entry := &nflogpb.Entry{
+		FiringAlerts:   []uint64{1, 2, 3},
+		ResolvedAlerts: []uint64{4},
+	}
+	require.Equal(t, template.Delta{}, groupDelta(entry, []uint64{1, 2, 3}, nil))
+	// Alert 4 was already resolved at the previous notification.
+	require.Equal(t, template.Delta{Added: 2, Resolved: 2}, groupDelta(entry, []uint64{1, 5, 6}, []uint64{2, 3, 4}))
 }
 
 func TestMultiStage(t *testing.T) {
@@ -380,6 +453,68 @@ func TestRoutingStage(t *testing.T) {
 	}
 }
 
+type namedStage string
+
+func (s namedStage) Exec(ctx context.Context, l *slog.Logger, as ...*types.Alert) (context.Context, []*types.Alert, error) {
+	return ctx, as, nil
+}
+
+func TestPipelineBuilderCustomStages(t *testing.T) {
+	pb := NewPipelineBuilder(prometheus.NewRegistry(), featurecontrol.NoopFlags{})
+	for pos, name := range map[StagePosition]string{
+		StagePositionPreMute:    "pre-mute",
+		StagePositionPostMute:   "post-mute",
+		StagePositionPreNotify:  "pre-notify",
+		StagePositionPostNotify: "post-notify",
+	} {
+		pb.RegisterStage(pos, func(receiver string, integration *Integration) Stage {
+			if integration != nil {
+				return namedStage(name + "/" + receiver + "/" + integration.Name())
+			}
+			return namedStage(name + "/" + receiver)
+		})
+	}
+	// A factory returning nil does not add a stage.
+	pb.RegisterStage(StagePositionPreMute, func(string, *Integration) Stage { return nil })
+
+	receivers := map[string][]Integration{
+		"foo": {NewIntegration(nil, sendResolved(false), "slack", 0, "foo")},
+	}
+	rs := pb.New(receivers, func(context.Context) time.Duration { return 0 }, nil, nil, nil, nil, &testNflog{}, nil)
+
+	ms, ok := rs["foo"].(MultiStage)
+	require.True(t, ok)
+	require.Len(t, ms, 8)
+	require.IsType(t, &GossipSettleStage{}, ms[0])
+	require.Equal(t, namedStage("pre-mute/foo"), ms[1])
+	require.IsType(t, &MuteStage{}, ms[2])
+	require.IsType(t, &MuteStage{}, ms[5])
+	require.Equal(t, namedStage("post-mute/foo"), ms[6])
+
+	fs, ok := ms[7].(FanoutStage)
+	require.True(t, ok)
+	require.Len(t, fs, 1)
+	is, ok := fs[0].(MultiStage)
+	require.True(t, ok)
+	require.Len(t, is, 6)
+	require.IsType(t, &DedupStage{}, is[1])
+	require.Equal(t, namedStage("pre-notify/foo/slack"), is[2])
+	require.IsType(t, &RetryStage{}, is[3])
+	require.Equal(t, namedStage("post-notify/foo/slack"), is[5])
+}
+
+func TestIntegrationNotifyRecoversPanic(t *testing.T) {
+	i := NewIntegration(notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+		var m map[string]string
+		m["foo"] = "bar"
+		return true, nil
+	}), sendResolved(false), "webhook", 1, "foo")
+
+	retry, err := i.Notify(context.Background(), &types.Alert{})
+	require.False(t, retry)
+	require.ErrorContains(t, err, "webhook[1]: panic while notifying: assignment to entry in nil map")
+}
+
 func TestRetryStageWithError(t *testing.T) {
 	fail, retry := true, true
 	sent := []*types.Alert{}
@@ -414,6 +549,11 @@ func TestRetryStageWithError(t *testing.T) {
 	require.Equal(t, alerts, sent)
 	require.NotNil(t, resctx)
 
+	// The reason of the failed attempt is passed on to the notification log.
+	reason, ok := FailureReason(resctx)
+	require.True(t, ok)
+	require.Equal(t, DefaultReason.String(), reason)
+
 	// Notify with an unrecoverable error should fail.
 	sent = sent[:0]
 	fail = true
@@ -504,6 +644,69 @@ func TestRetryStageWithContextCanceled(t *testing.T) {
 	require.NotNil(t, resctx)
 }
 
+func TestRetryStagePersistRetries(t *testing.T) {
+	queue, err := retry.New(retry.Options{Retention: time.Hour})
+	require.NoError(t, err)
+	recv := &nflogpb.Receiver{GroupName: "test", Integration: "test", Idx: 0}
+
+	var (
+		attempts int
+		fail     = true
+		retried  bool
+	)
+	i := Integration{
+		name: "test",
+		notifier: notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+			attempts++
+			if attempts == 2 {
+				// The failed first attempt was recorded.
+				n, _, ok := queue.Get("1", recv)
+				retried = ok && n == 1
+			}
+			if fail && attempts == 1 {
+				return true, errors.New("fail to deliver notification")
+			}
+			if fail {
+				return false, errors.New("unrecoverable error")
+			}
+			return false, nil
+		}),
+		rs: sendResolved(false),
+	}
+	r := NewRetryStage(i, "test", NewMetrics(prometheus.NewRegistry(), featurecontrol.NoopFlags{}))
+	r.recv, r.queue = recv, queue
+
+	alerts := []*types.Alert{
+		{
+			Alert: model.Alert{
+				EndsAt: time.Now().Add(time.Hour),
+			},
+		},
+	}
+
+	ctx := context.Background()
+	ctx = WithFiringAlerts(ctx, []uint64{0})
+	ctx = WithGroupKey(ctx, "1")
+
+	// The failed attempts are recorded until the notification can't be
+	// retried.
+	_, _, err = r.Exec(ctx, promslog.NewNopLogger(), alerts...)
+	require.Error(t, err)
+	require.True(t, retried)
+	_, _, ok := queue.Get("1", recv)
+	require.False(t, ok)
+
+	// A notification recorded in the queue, for instance before a restart,
+	// resumes its attempts and is removed once it succeeded.
+	queue.Set("1", recv, alerts, 3, time.Now().Add(-time.Second))
+	attempts, fail = 2, false
+	_, res, err := r.Exec(ctx, promslog.NewNopLogger(), alerts...)
+	require.NoError(t, err)
+	require.Equal(t, alerts, res)
+	_, _, ok = queue.Get("1", recv)
+	require.False(t, ok)
+}
+
 func TestRetryStageNoResolved(t *testing.T) {
 	sent := []*types.Alert{}
 	i := Integration{
@@ -631,7 +834,7 @@ func TestSetNotifiesStage(t *testing.T) {
 	ctx = WithResolvedAlerts(ctx, []uint64{})
 	ctx = WithRepeatInterval(ctx, time.Hour)
 
-	tnflog.logFunc = func(r *nflogpb.Receiver, gkey string, firingAlerts, resolvedAlerts []uint64, expiry time.Duration) error {
+	tnflog.logFunc = func(r *nflogpb.Receiver, gkey string, firingAlerts, resolvedAlerts []uint64, receiverData map[string]string, origins []string, failureReason string, expiry time.Duration) error {
 		require.Equal(t, s.recv, r)
 		require.Equal(t, "1", gkey)
 		require.Equal(t, []uint64{0, 1, 2}, firingAlerts)
@@ -647,11 +850,12 @@ func TestSetNotifiesStage(t *testing.T) {
 	ctx = WithFiringAlerts(ctx, []uint64{})
 	ctx = WithResolvedAlerts(ctx, []uint64{0, 1, 2})
 
-	tnflog.logFunc = func(r *nflogpb.Receiver, gkey string, firingAlerts, resolvedAlerts []uint64, expiry time.Duration) error {
+	tnflog.logFunc = func(r *nflogpb.Receiver, gkey string, firingAlerts, resolvedAlerts []uint64, receiverData map[string]string, origins []string, failureReason string, expiry time.Duration) error {
 		require.Equal(t, s.recv, r)
 		require.Equal(t, "1", gkey)
 		require.Equal(t, []uint64{}, firingAlerts)
 		require.Equal(t, []uint64{0, 1, 2}, resolvedAlerts)
+		require.Nil(t, receiverData)
 		require.Equal(t, 2*time.Hour, expiry)
 		return nil
 	}
@@ -659,6 +863,41 @@ func TestSetNotifiesStage(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, alerts, res)
 	require.NotNil(t, resctx)
+
+	// The receiver data set by the integration is logged with the previous
+	// values it didn't override.
+	d := NewReceiverData(map[string]string{"channel": "C1", "ts": "1"})
+	d.Set("ts", "2")
+	ctx = WithReceiverData(ctx, d)
+
+	tnflog.logFunc = func(r *nflogpb.Receiver, gkey string, firingAlerts, resolvedAlerts []uint64, receiverData map[string]string, origins []string, failureReason string, expiry time.Duration) error {
+		require.Equal(t, map[string]string{"channel": "C1", "ts": "2"}, receiverData)
+		return nil
+	}
+	_, _, err = s.Exec(ctx, promslog.NewNopLogger(), alerts...)
+	require.NoError(t, err)
+
+	// The origins of the alerts are logged.
+	originAlerts := []*types.Alert{
+		{Origins: []string{"prometheus-1"}},
+		{Origins: []string{"prometheus-0", "prometheus-1"}},
+		{},
+	}
+	tnflog.logFunc = func(r *nflogpb.Receiver, gkey string, firingAlerts, resolvedAlerts []uint64, receiverData map[string]string, origins []string, failureReason string, expiry time.Duration) error {
+		require.Equal(t, []string{"prometheus-0", "prometheus-1"}, origins)
+		return nil
+	}
+	_, _, err = s.Exec(ctx, promslog.NewNopLogger(), originAlerts...)
+	require.NoError(t, err)
+
+	// The reason of the failed attempts is logged.
+	ctx = WithFailureReason(ctx, ServerErrorReason.String())
+	tnflog.logFunc = func(r *nflogpb.Receiver, gkey string, firingAlerts, resolvedAlerts []uint64, receiverData map[string]string, origins []string, failureReason string, expiry time.Duration) error {
+		require.Equal(t, "serverError", failureReason)
+		return nil
+	}
+	_, _, err = s.Exec(ctx, promslog.NewNopLogger(), alerts...)
+	require.NoError(t, err)
 }
 
 func TestMuteStage(t *testing.T) {
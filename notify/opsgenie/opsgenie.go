@@ -101,7 +101,7 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 
 	for _, req := range requests {
 		req.Header.Set("User-Agent", notify.UserAgentHeader)
-		resp, err := n.client.Do(req)
+		resp, err := notify.Do(ctx, n.client, req)
 		if err != nil {
 			return true, err
 		}
@@ -136,7 +136,7 @@ func (n *Notifier) createRequests(ctx context.Context, as ...*types.Alert) ([]*h
 
 	n.logger.Debug("extracted group key", "key", key)
 
-	tmpl := notify.TmplText(n.tmpl, data, &err)
+	tmpl := notify.TmplText(ctx, n.tmpl, data, &err)
 
 	details := make(map[string]string)
 
@@ -189,25 +189,36 @@ func (n *Notifier) createRequests(ctx context.Context, as ...*types.Alert) ([]*h
 				Type:     tmpl(r.Type),
 			}
 
-			if responder == (opsGenieCreateMessageResponder{}) {
+			if responder.ID == "" && responder.Name == "" && responder.Username == "" {
 				// Filter out empty responders. This is useful if you want to fill
 				// responders dynamically from alert's common labels.
 				continue
 			}
 
-			if responder.Type == "teams" {
-				teams := safeSplit(responder.Name, ",")
-				for _, team := range teams {
-					newResponder := opsGenieCreateMessageResponder{
-						Name: tmpl(team),
-						Type: tmpl("team"),
-					}
-					responders = append(responders, newResponder)
-				}
+			// Templated responders can only be validated once rendered. Invalid
+			// responders are dropped rather than failing the whole alert.
+			responder.Type = strings.ToLower(responder.Type)
+			if err := config.OpsGenieConfigResponder(responder).Validate(); err != nil {
+				n.logger.Warn("Dropping invalid responder", "alert", key, "err", err)
 				continue
 			}
 
-			responders = append(responders, responder)
+			typ, ok := config.OpsGenieResponderListTypes[responder.Type]
+			if !ok {
+				responders = append(responders, responder)
+				continue
+			}
+			// Responder lists expand to one responder per item so that the
+			// responders can be derived from the alerts' labels.
+			for _, id := range safeSplit(responder.ID, ",") {
+				responders = append(responders, opsGenieCreateMessageResponder{ID: strings.TrimSpace(id), Type: typ})
+			}
+			for _, name := range safeSplit(responder.Name, ",") {
+				responders = append(responders, opsGenieCreateMessageResponder{Name: strings.TrimSpace(name), Type: typ})
+			}
+			for _, username := range safeSplit(responder.Username, ",") {
+				responders = append(responders, opsGenieCreateMessageResponder{Username: strings.TrimSpace(username), Type: typ})
+			}
 		}
 
 		msg := &opsGenieCreateMessage{
@@ -15,6 +15,7 @@ package opsgenie
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -273,6 +274,53 @@ func TestOpsGenie(t *testing.T) {
 	}
 }
 
+func TestOpsGenieResponderLists(t *testing.T) {
+	u, err := url.Parse("https://opsgenie/api")
+	require.NoError(t, err)
+	conf := &config.OpsGenieConfig{
+		Responders: []config.OpsGenieConfigResponder{
+			{Name: `{{ .CommonLabels.teams }}`, Type: "teams"},
+			{Username: `{{ .CommonLabels.owners }}`, Type: "users"},
+			{ID: `{{ .CommonLabels.schedule }}`, Type: `{{ .CommonLabels.schedule_type }}`},
+			{Name: "EscalationA", Type: `{{ .CommonLabels.missing }}`},
+			{Name: "fred", Type: "user"},
+			{Name: `{{ .CommonLabels.missing }}`, Type: "team"},
+		},
+		APIKey:     "key",
+		APIURL:     &config.URL{URL: u},
+		HTTPConfig: &commoncfg.HTTPClientConfig{},
+	}
+	notifier, err := New(conf, test.CreateTmpl(t), promslog.NewNopLogger())
+	require.NoError(t, err)
+
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels: model.LabelSet{
+				"teams":         "TeamA, TeamB",
+				"owners":        "fred,wilma",
+				"schedule":      "4513b7ea-3b91-438f-b7e4-e3e54af9147c",
+				"schedule_type": "Schedule",
+			},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	req, _, err := notifier.createRequests(ctx, alert)
+	require.NoError(t, err)
+	require.Len(t, req, 1)
+
+	var msg opsGenieCreateMessage
+	require.NoError(t, json.Unmarshal([]byte(readBody(t, req[0])), &msg))
+	require.Equal(t, []opsGenieCreateMessageResponder{
+		{Name: "TeamA", Type: "team"},
+		{Name: "TeamB", Type: "team"},
+		{Username: "fred", Type: "user"},
+		{Username: "wilma", Type: "user"},
+		{ID: "4513b7ea-3b91-438f-b7e4-e3e54af9147c", Type: "schedule"},
+	}, msg.Responders)
+}
+
 func TestOpsGenieWithUpdate(t *testing.T) {
 	u, err := url.Parse("https://test-opsgenie-url")
 	require.NoError(t, err)
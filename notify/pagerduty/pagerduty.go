@@ -24,6 +24,8 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/alecthomas/units"
 	commoncfg "github.com/prometheus/common/config"
@@ -51,6 +53,9 @@ type Notifier struct {
 	apiV1   string // for tests.
 	client  *http.Client
 	retrier *notify.Retrier
+
+	mtx  sync.Mutex
+	next time.Time // earliest time the next per-alert event may be sent.
 }
 
 // New returns a new PagerDuty notifier.
@@ -61,7 +66,11 @@ func New(c *config.PagerdutyConfig, t *template.Template, l *slog.Logger, httpOp
 	}
 	n := &Notifier{conf: c, tmpl: t, logger: l, client: client}
 	if c.ServiceKey != "" || c.ServiceKeyFile != "" {
-		n.apiV1 = "https://events.pagerduty.com/generic/2010-04-15/create_event.json"
+		host := config.PagerdutyRegionHosts["us"]
+		if c.Region != "" {
+			host = config.PagerdutyRegionHosts[c.Region]
+		}
+		n.apiV1 = "https://" + host + "/generic/2010-04-15/create_event.json"
 		// Retrying can solve the issue on 403 (rate limiting) and 5xx response codes.
 		// https://v2.developer.pagerduty.com/docs/trigger-events
 		n.retrier = &notify.Retrier{RetryCodes: []int{http.StatusForbidden}, CustomDetailsFunc: errDetails}
@@ -147,12 +156,13 @@ func (n *Notifier) notifyV1(
 	ctx context.Context,
 	eventType string,
 	key notify.Key,
+	dedupKey string,
 	data *template.Data,
 	details map[string]string,
 	as ...*types.Alert,
 ) (bool, error) {
 	var tmplErr error
-	tmpl := notify.TmplText(n.tmpl, data, &tmplErr)
+	tmpl := notify.TmplText(ctx, n.tmpl, data, &tmplErr)
 
 	description, truncated := notify.TruncateInRunes(tmpl(n.conf.Description), maxV1DescriptionLenRunes)
 	if truncated {
@@ -171,7 +181,7 @@ func (n *Notifier) notifyV1(
 	msg := &pagerDutyMessage{
 		ServiceKey:  tmpl(serviceKey),
 		EventType:   eventType,
-		IncidentKey: key.Hash(),
+		IncidentKey: dedupKey,
 		Description: description,
 		Details:     details,
 	}
@@ -208,12 +218,13 @@ func (n *Notifier) notifyV2(
 	ctx context.Context,
 	eventType string,
 	key notify.Key,
+	dedupKey string,
 	data *template.Data,
 	details map[string]string,
 	as ...*types.Alert,
 ) (bool, error) {
 	var tmplErr error
-	tmpl := notify.TmplText(n.tmpl, data, &tmplErr)
+	tmpl := notify.TmplText(ctx, n.tmpl, data, &tmplErr)
 
 	if n.conf.Severity == "" {
 		n.conf.Severity = "error"
@@ -238,7 +249,7 @@ func (n *Notifier) notifyV2(
 		ClientURL:   tmpl(n.conf.ClientURL),
 		RoutingKey:  tmpl(routingKey),
 		EventAction: eventType,
-		DedupKey:    key.Hash(),
+		DedupKey:    dedupKey,
 		Images:      make([]pagerDutyImage, 0, len(n.conf.Images)),
 		Links:       make([]pagerDutyLink, 0, len(n.conf.Links)),
 		Payload: &pagerDutyPayload{
@@ -309,6 +320,55 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 		return false, err
 	}
 
+	if !n.conf.PerAlert {
+		return n.notify(ctx, key, key.Hash(), as...)
+	}
+
+	// Send one event per alert, deduplicated by the alert's fingerprint, so
+	// that PagerDuty manages an incident per alert instead of per group.
+	for _, a := range as {
+		if err := n.wait(ctx); err != nil {
+			return true, err
+		}
+		if retry, err := n.notify(ctx, key, a.Fingerprint().String(), a); err != nil {
+			return retry, err
+		}
+	}
+	return false, nil
+}
+
+// wait blocks until the next event may be sent without exceeding the
+// configured maximum number of events per minute. The limit is shared by all
+// the groups notified through the integration.
+func (n *Notifier) wait(ctx context.Context) error {
+	if n.conf.MaxEventsPerMinute <= 0 {
+		return nil
+	}
+
+	n.mtx.Lock()
+	now := time.Now()
+	at := n.next
+	if at.Before(now) {
+		at = now
+	}
+	n.next = at.Add(time.Minute / time.Duration(n.conf.MaxEventsPerMinute))
+	n.mtx.Unlock()
+
+	d := at.Sub(now)
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func (n *Notifier) notify(ctx context.Context, key notify.Key, dedupKey string, as ...*types.Alert) (bool, error) {
 	var (
 		alerts    = types.Alerts(as...)
 		data      = notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
@@ -322,7 +382,7 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 
 	details := make(map[string]string, len(n.conf.Details))
 	for k, v := range n.conf.Details {
-		detail, err := n.tmpl.ExecuteTextString(v, data)
+		detail, err := n.tmpl.ExecuteTextStringContext(ctx, v, data)
 		if err != nil {
 			return false, fmt.Errorf("%q: failed to template %q: %w", k, v, err)
 		}
@@ -330,9 +390,9 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 	}
 
 	if n.apiV1 != "" {
-		return n.notifyV1(ctx, eventType, key, data, details, as...)
+		return n.notifyV1(ctx, eventType, key, dedupKey, data, details, as...)
 	}
-	return n.notifyV2(ctx, eventType, key, data, details, as...)
+	return n.notifyV2(ctx, eventType, key, dedupKey, data, details, as...)
 }
 
 func errDetails(status int, body io.Reader) string {
@@ -56,6 +56,20 @@ func TestPagerDutyRetryV1(t *testing.T) {
 	}
 }
 
+func TestPagerDutyRegionV1(t *testing.T) {
+	notifier, err := New(
+		&config.PagerdutyConfig{
+			ServiceKey: config.Secret("01234567890123456789012345678901"),
+			Region:     "eu",
+			HTTPConfig: &commoncfg.HTTPClientConfig{},
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "https://events.eu.pagerduty.com/generic/2010-04-15/create_event.json", notifier.apiV1)
+}
+
 func TestPagerDutyRetryV2(t *testing.T) {
 	notifier, err := New(
 		&config.PagerdutyConfig{
@@ -497,3 +511,69 @@ func TestPagerDutyEmptySrcHref(t *testing.T) {
 	}...)
 	require.NoError(t, err)
 }
+
+func TestPagerDutyPerAlert(t *testing.T) {
+	var (
+		events []pagerDutyMessage
+		times  []time.Time
+	)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var event pagerDutyMessage
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+			events = append(events, event)
+			times = append(times, time.Now())
+		},
+	))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	pagerDuty, err := New(&config.PagerdutyConfig{
+		HTTPConfig:         &commoncfg.HTTPClientConfig{},
+		RoutingKey:         config.Secret("01234567890123456789012345678901"),
+		URL:                &config.URL{URL: u},
+		Description:        `{{ .CommonLabels.alertname }}`,
+		PerAlert:           true,
+		MaxEventsPerMinute: 600,
+	}, test.CreateTmpl(t), promslog.NewNopLogger())
+	require.NoError(t, err)
+
+	firing := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "a"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+	resolved := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "b"},
+			StartsAt: time.Now().Add(-time.Hour),
+			EndsAt:   time.Now().Add(-time.Minute),
+		},
+	}
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	retry, err := pagerDuty.Notify(ctx, firing, resolved)
+	require.NoError(t, err)
+	require.False(t, retry)
+
+	require.Len(t, events, 2)
+	require.Equal(t, firing.Fingerprint().String(), events[0].DedupKey)
+	require.Equal(t, pagerDutyEventTrigger, events[0].EventAction)
+	require.Equal(t, "a", events[0].Payload.Summary)
+	require.Equal(t, resolved.Fingerprint().String(), events[1].DedupKey)
+	require.Equal(t, pagerDutyEventResolve, events[1].EventAction)
+	require.Equal(t, "b", events[1].Payload.Summary)
+	// The events are spaced according to the rate limit.
+	require.GreaterOrEqual(t, times[1].Sub(times[0]), 90*time.Millisecond)
+
+	// The rate limit is enforced until the context is canceled.
+	pagerDuty.conf.MaxEventsPerMinute = 1
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	retry, err = pagerDuty.Notify(ctx, firing, resolved)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.True(t, retry)
+}
@@ -0,0 +1,266 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPayloadLogSize is the number of failed notification requests
+	// kept by default.
+	DefaultPayloadLogSize = 100
+
+	// maxPayloadLogBody is the maximum number of bytes of the request and
+	// response bodies kept.
+	maxPayloadLogBody = 16 << 10
+
+	redacted = "<redacted>"
+)
+
+// PayloadLogEntry is a request of a failed notification attempt.
+type PayloadLogEntry struct {
+	Time        time.Time
+	Receiver    string
+	Integration string
+	GroupKey    string
+	Attempt     int
+
+	// Method and URL of the request. Only the scheme and host of the URL
+	// are kept as integrations hold credentials in the path or query.
+	Method string
+	URL    string
+	// RequestBody is the body of the request with the values of the fields
+	// holding credentials redacted.
+	RequestBody string
+	// StatusCode and ResponseBody are the status code and the beginning of
+	// the body of the response, if any.
+	StatusCode   int
+	ResponseBody string
+	// Error is the error of the notification attempt.
+	Error string
+}
+
+// PayloadLog keeps the last requests of the failed notification attempts of
+// the receivers with debug_log_payloads enabled.
+type PayloadLog struct {
+	mtx     sync.Mutex
+	entries []PayloadLogEntry
+	next    int
+	full    bool
+}
+
+// NewPayloadLog returns a new PayloadLog keeping the given number of entries.
+func NewPayloadLog(size int) *PayloadLog {
+	if size <= 0 {
+		size = DefaultPayloadLogSize
+	}
+	return &PayloadLog{entries: make([]PayloadLogEntry, size)}
+}
+
+// Add adds an entry, replacing the oldest one if the log is full.
+func (l *PayloadLog) Add(e PayloadLogEntry) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.entries[l.next] = e
+	l.next = (l.next + 1) % len(l.entries)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// Entries returns the entries, most recent first.
+func (l *PayloadLog) Entries() []PayloadLogEntry {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	n := l.next
+	if l.full {
+		n = len(l.entries)
+	}
+	res := make([]PayloadLogEntry, 0, n)
+	for i := 1; i <= n; i++ {
+		res = append(res, l.entries[(l.next-i+len(l.entries))%len(l.entries)])
+	}
+	return res
+}
+
+// payloadExchange is an HTTP request sent by a notifier and its response.
+type payloadExchange struct {
+	method       string
+	url          string
+	requestBody  string
+	statusCode   int
+	responseBody string
+}
+
+// payloadRecorder records the HTTP requests of a notification attempt.
+type payloadRecorder struct {
+	mtx       sync.Mutex
+	exchanges []payloadExchange
+}
+
+func withPayloadRecorder(ctx context.Context, r *payloadRecorder) context.Context {
+	return context.WithValue(ctx, keyPayloadRecorder, r)
+}
+
+func (r *payloadRecorder) add(e payloadExchange) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.exchanges = append(r.exchanges, e)
+}
+
+func (r *payloadRecorder) recorded() []payloadExchange {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return append([]payloadExchange(nil), r.exchanges...)
+}
+
+// do sends the request with the client and records it with the beginning of
+// the response body, which is still entirely readable from the returned
+// response.
+func (r *payloadRecorder) do(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	e := payloadExchange{
+		method:      req.Method,
+		url:         redactPayloadURL(req.URL),
+		requestBody: truncatePayload(redactPayload(req.Header.Get("Content-Type"), body)),
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		r.add(e)
+		return nil, err
+	}
+
+	excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxPayloadLogBody))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(excerpt), resp.Body), resp.Body}
+	e.statusCode = resp.StatusCode
+	e.responseBody = string(excerpt)
+	r.add(e)
+	return resp, nil
+}
+
+// redactPayloadURL returns the scheme and host of the URL.
+func redactPayloadURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	s := u.Scheme + "://" + u.Host
+	if u.Path != "" || u.RawQuery != "" {
+		s += "/" + redacted
+	}
+	return s
+}
+
+// sensitiveFields are the names of the fields of request bodies holding
+// credentials.
+var sensitiveFields = map[string]struct{}{
+	"api_key":         {},
+	"apikey":          {},
+	"authorization":   {},
+	"integration_key": {},
+	"password":        {},
+	"routing_key":     {},
+	"service_key":     {},
+	"user":            {},
+}
+
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	if _, ok := sensitiveFields[name]; ok {
+		return true
+	}
+	return strings.Contains(name, "token") || strings.Contains(name, "secret") || strings.Contains(name, "password")
+}
+
+// redactPayload returns the body with the values of the fields holding
+// credentials redacted, for JSON and form bodies.
+func redactPayload(contentType string, body []byte) string {
+	mt, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mt == "application/x-www-form-urlencoded":
+		v, err := url.ParseQuery(string(body))
+		if err != nil {
+			return string(body)
+		}
+		for k := range v {
+			if isSensitiveField(k) {
+				v.Set(k, redacted)
+			}
+		}
+		return v.Encode()
+	case mt == "application/json" || strings.HasSuffix(mt, "+json"):
+		var v interface{}
+		if err := json.Unmarshal(body, &v); err != nil {
+			return string(body)
+		}
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(redactJSON(v)); err != nil {
+			return string(body)
+		}
+		return strings.TrimSuffix(buf.String(), "\n")
+	}
+	return string(body)
+}
+
+func redactJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, vv := range v {
+			if _, ok := vv.(string); ok && isSensitiveField(k) {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactJSON(vv)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactJSON(v[i])
+		}
+	}
+	return v
+}
+
+func truncatePayload(s string) string {
+	if len(s) > maxPayloadLogBody {
+		return s[:maxPayloadLogBody]
+	}
+	return s
+}
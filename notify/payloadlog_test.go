@@ -0,0 +1,133 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/featurecontrol"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestPayloadLog(t *testing.T) {
+	l := NewPayloadLog(3)
+	require.Empty(t, l.Entries())
+
+	for i := 1; i <= 2; i++ {
+		l.Add(PayloadLogEntry{Attempt: i})
+	}
+	require.Equal(t, []PayloadLogEntry{{Attempt: 2}, {Attempt: 1}}, l.Entries())
+
+	for i := 3; i <= 5; i++ {
+		l.Add(PayloadLogEntry{Attempt: i})
+	}
+	require.Equal(t, []PayloadLogEntry{{Attempt: 5}, {Attempt: 4}, {Attempt: 3}}, l.Entries())
+}
+
+func TestRedactPayload(t *testing.T) {
+	for _, tc := range []struct {
+		contentType string
+		body        string
+		exp         string
+	}{
+		{
+			contentType: "application/json",
+			body:        `{"routing_key":"abc","payload":{"summary":"down"},"images":[{"access_token":"xyz"}],"count":1}`,
+			exp:         `{"count":1,"images":[{"access_token":"<redacted>"}],"payload":{"summary":"down"},"routing_key":"<redacted>"}`,
+		},
+		{
+			contentType: "application/x-www-form-urlencoded",
+			body:        "message=down&token=abc&user=def",
+			exp:         "message=down&token=%3Credacted%3E&user=%3Credacted%3E",
+		},
+		{
+			contentType: "text/plain",
+			body:        "token=abc",
+			exp:         "token=abc",
+		},
+		{
+			contentType: "application/json; charset=utf-8",
+			body:        `{"token":`,
+			exp:         `{"token":`,
+		},
+	} {
+		require.Equal(t, tc.exp, redactPayload(tc.contentType, []byte(tc.body)))
+	}
+}
+
+func TestRetryStageLogsPayloads(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"invalid blocks"}`)
+	}))
+	defer srv.Close()
+
+	var body string
+	i := Integration{
+		notifier: notifierFunc(func(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+			resp, err := PostJSON(ctx, srv.Client(), srv.URL+"/services/T0/B0/secret?token=abc", strings.NewReader(`{"text":"down","token":"abc"}`))
+			if err != nil {
+				return false, err
+			}
+			defer Drain(resp)
+			// The notifier reads the full response.
+			b, _ := io.ReadAll(resp.Body)
+			body = string(b)
+			return false, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}),
+		rs:   sendResolved(true),
+		name: "slack",
+	}
+	l := NewPayloadLog(10)
+	r := NewRetryStage(i, "team-X", NewMetrics(prometheus.NewRegistry(), featurecontrol.NoopFlags{}))
+	r.payloads = l
+
+	alerts := []*types.Alert{{Alert: model.Alert{EndsAt: time.Now().Add(time.Hour)}}}
+	ctx := WithGroupKey(context.Background(), "{}:{}")
+	_, _, err := r.Exec(ctx, promslog.NewNopLogger(), alerts...)
+	require.Error(t, err)
+	require.Equal(t, `{"error":"invalid blocks"}`, body)
+
+	entries := l.Entries()
+	require.Len(t, entries, 1)
+	e := entries[0]
+	require.Equal(t, "team-X", e.Receiver)
+	require.Equal(t, "slack[0]", e.Integration)
+	require.Equal(t, "{}:{}", e.GroupKey)
+	require.Equal(t, 1, e.Attempt)
+	require.Equal(t, http.MethodPost, e.Method)
+	require.Equal(t, srv.URL+"/<redacted>", e.URL)
+	require.Equal(t, `{"text":"down","token":"<redacted>"}`, e.RequestBody)
+	require.Equal(t, http.StatusBadRequest, e.StatusCode)
+	require.Equal(t, `{"error":"invalid blocks"}`, e.ResponseBody)
+	require.Equal(t, "unexpected status code 400", e.Error)
+
+	// Nothing is logged without payload log.
+	r.payloads = nil
+	_, _, err = r.Exec(ctx, promslog.NewNopLogger(), alerts...)
+	require.Error(t, err)
+	require.Len(t, l.Entries(), 1)
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// previewResponseBody is the body of the responses returned to notifiers in
+// preview mode. It is understood as a successful response by the APIs which
+// report errors in the body of 200 responses (Slack and Rocket.Chat).
+const previewResponseBody = `{"ok":true,"success":true}`
+
+// PreviewRequest is a notification recorded instead of being sent.
+type PreviewRequest struct {
+	Method string
+	// URL is the URL of HTTP requests. Only the scheme and host are kept as
+	// integrations hold credentials in the path or query.
+	URL string
+	// Header and Body are the header and body of the request, with the
+	// values holding credentials redacted.
+	Header http.Header
+	Body   string
+}
+
+// Preview records the notifications of notifiers running in preview mode.
+type Preview struct {
+	mtx      sync.Mutex
+	requests []PreviewRequest
+}
+
+// Record records a notification.
+func (p *Preview) Record(r PreviewRequest) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.requests = append(p.requests, r)
+}
+
+// Requests returns the recorded notifications.
+func (p *Preview) Requests() []PreviewRequest {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return append([]PreviewRequest(nil), p.requests...)
+}
+
+// WithPreview populates a context with a preview recorder. Notifiers called
+// with the context record their notifications instead of sending them.
+func WithPreview(ctx context.Context, p *Preview) context.Context {
+	return context.WithValue(ctx, keyPreview, p)
+}
+
+// PreviewFromContext extracts the preview recorder from the context.
+func PreviewFromContext(ctx context.Context) (*Preview, bool) {
+	v, ok := ctx.Value(keyPreview).(*Preview)
+	return v, ok
+}
+
+// Do sends the HTTP request with the client, with the custom headers of the
+// receiver. If the context holds a preview recorder, the request is recorded
+// with its credentials redacted instead and a successful response is returned.
+func Do(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, error) {
+	custom := setHeaders(ctx, req)
+	p, ok := PreviewFromContext(ctx)
+	if !ok {
+		if r, ok := ctx.Value(keyPayloadRecorder).(*payloadRecorder); ok {
+			return r.do(ctx, client, req)
+		}
+		return client.Do(req.WithContext(ctx))
+	}
+
+	var body []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+	p.Record(PreviewRequest{
+		Method: req.Method,
+		URL:    redactPayloadURL(req.URL),
+		Header: redactHeader(req.Header, custom),
+		Body:   redactPayload(req.Header.Get("Content-Type"), body),
+	})
+
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewBufferString(previewResponseBody)),
+		Request:    req,
+	}, nil
+}
+
+// redactHeader returns a copy of the header without the credentials and the
+// values of the custom headers, which are secrets.
+func redactHeader(h, custom http.Header) http.Header {
+	h = h.Clone()
+	for _, k := range []string{"Authorization", "Proxy-Authorization"} {
+		if h.Get(k) != "" {
+			h.Set(k, redacted)
+		}
+	}
+	for k := range custom {
+		h.Set(k, redacted)
+	}
+	return h
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoPreview(t *testing.T) {
+	var sent int
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		sent++
+	}))
+	defer srv.Close()
+
+	p := &Preview{}
+	ctx := WithPreview(context.Background(), p)
+	req, err := http.NewRequest(http.MethodPost, strings.Replace(srv.URL, "http://", "http://user:secret@", 1)+"/hook?api_key=secret", strings.NewReader(`{"foo":"bar","token":"secret"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := Do(ctx, srv.Client(), req)
+	require.NoError(t, err)
+	defer Drain(resp)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 0, sent)
+
+	requests := p.Requests()
+	require.Len(t, requests, 1)
+	require.Equal(t, http.MethodPost, requests[0].Method)
+	require.Equal(t, srv.URL+"/<redacted>", requests[0].URL)
+	require.Equal(t, "<redacted>", requests[0].Header.Get("Authorization"))
+	require.Equal(t, "application/json", requests[0].Header.Get("Content-Type"))
+	require.Equal(t, `{"foo":"bar","token":"<redacted>"}`, requests[0].Body)
+	// The header of the request isn't modified.
+	require.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+
+	// Without preview recorder, the request is sent.
+	resp, err = PostJSON(context.Background(), srv.Client(), srv.URL, strings.NewReader(`{}`))
+	require.NoError(t, err)
+	defer Drain(resp)
+	require.Equal(t, 1, sent)
+}
@@ -81,8 +81,8 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 		err     error
 		message string
 	)
-	tmpl := notify.TmplText(n.tmpl, data, &err)
-	tmplHTML := notify.TmplHTML(n.tmpl, data, &err)
+	tmpl := notify.TmplText(ctx, n.tmpl, data, &err)
+	tmplHTML := notify.TmplHTML(ctx, n.tmpl, data, &err)
 
 	var (
 		token   string
@@ -0,0 +1,181 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+// quietCheckInterval is the interval at which a QuietStage checks whether
+// the quiet time intervals ended while notifications are queued.
+const quietCheckInterval = time.Minute
+
+// quietGroup is the notification of an aggregation group queued during quiet
+// time intervals.
+type quietGroup struct {
+	// ctx is the context of the last flush of the group.
+	ctx     context.Context
+	timeout time.Duration
+	logger  *slog.Logger
+	// alerts is the last state of the alerts of the group, by fingerprint.
+	// Alerts resolved during the quiet time intervals are kept.
+	alerts map[model.Fingerprint]*types.Alert
+}
+
+// QuietStage queues the notifications of a receiver while the current time is
+// within one of its quiet time intervals. The queued notification of an
+// aggregation group is sent at its next flush after the intervals end, or
+// when the stage notices the end of the intervals if the group isn't flushed
+// anymore, with all the alerts of the group seen in the meantime.
+type QuietStage struct {
+	muter     types.TimeMuter
+	intervals []string
+	next      Stage
+	// checkInterval is the interval at which the end of the time intervals
+	// is checked while notifications are queued.
+	checkInterval time.Duration
+
+	mtx      sync.Mutex
+	groups   map[string]*quietGroup
+	checking bool
+}
+
+// NewQuietStage returns a new QuietStage queuing the notifications to next
+// during the time intervals with the given names.
+func NewQuietStage(muter types.TimeMuter, intervals []string, next Stage) *QuietStage {
+	return &QuietStage{
+		muter:         muter,
+		intervals:     intervals,
+		next:          next,
+		checkInterval: quietCheckInterval,
+		groups:        map[string]*quietGroup{},
+	}
+}
+
+// Exec implements the Stage interface.
+func (qs *QuietStage) Exec(ctx context.Context, l *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	gkey, ok := GroupKey(ctx)
+	if !ok {
+		return qs.next.Exec(ctx, l, alerts...)
+	}
+	now, ok := Now(ctx)
+	if !ok {
+		now = time.Now()
+	}
+	quiet, _, err := qs.muter.Mutes(qs.intervals, now)
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	qs.mtx.Lock()
+	g := qs.groups[gkey]
+	if quiet {
+		if g == nil {
+			g = &quietGroup{alerts: map[model.Fingerprint]*types.Alert{}}
+			qs.groups[gkey] = g
+		}
+		g.ctx, g.logger = context.WithoutCancel(ctx), l
+		g.timeout = time.Minute
+		if deadline, ok := ctx.Deadline(); ok {
+			g.timeout = time.Until(deadline)
+		}
+		for _, a := range alerts {
+			g.alerts[a.Fingerprint()] = a
+		}
+		if !qs.checking {
+			qs.checking = true
+			go qs.check()
+		}
+		qs.mtx.Unlock()
+		l.Debug("Notification queued, receiver is within quiet time", "alerts", len(alerts))
+		// The alerts resolved during the quiet time are kept in the queue,
+		// so they can be deleted from the aggregation group.
+		return ctx, nil, nil
+	}
+	delete(qs.groups, gkey)
+	qs.mtx.Unlock()
+
+	if g == nil {
+		return qs.next.Exec(ctx, l, alerts...)
+	}
+	for _, a := range alerts {
+		g.alerts[a.Fingerprint()] = a
+	}
+	ctx, queued := withQueuedAlerts(ctx, g.alerts, now)
+	l.Debug("Sending notification queued during quiet time", "alerts", len(queued))
+	return qs.next.Exec(ctx, l, queued...)
+}
+
+// check flushes the queued notifications of the groups not flushed since the
+// end of the quiet time intervals.
+func (qs *QuietStage) check() {
+	t := time.NewTicker(qs.checkInterval)
+	defer t.Stop()
+	for range t.C {
+		now := time.Now()
+		// The queued notifications are sent rather than held if the time
+		// intervals can't be checked.
+		if quiet, _, err := qs.muter.Mutes(qs.intervals, now); err == nil && quiet {
+			continue
+		}
+
+		qs.mtx.Lock()
+		groups := qs.groups
+		qs.groups = map[string]*quietGroup{}
+		qs.checking = false
+		qs.mtx.Unlock()
+
+		for _, g := range groups {
+			ctx, alerts := withQueuedAlerts(g.ctx, g.alerts, now)
+			ctx, cancel := context.WithTimeout(ctx, g.timeout)
+			g.logger.Debug("Sending notification queued during quiet time", "alerts", len(alerts))
+			if _, _, err := qs.next.Exec(ctx, g.logger, alerts...); err != nil {
+				g.logger.Error("Sending notification queued during quiet time failed", "err", err)
+			}
+			cancel()
+		}
+		return
+	}
+}
+
+// withQueuedAlerts returns the context and the alerts of the notification of
+// the queued alerts.
+func withQueuedAlerts(ctx context.Context, queued map[model.Fingerprint]*types.Alert, now time.Time) (context.Context, []*types.Alert) {
+	var (
+		alerts   = make(types.AlertSlice, 0, len(queued))
+		firing   = []uint64{}
+		resolved = []uint64{}
+	)
+	for _, a := range queued {
+		alerts = append(alerts, a)
+		if a.ResolvedAt(now) {
+			resolved = append(resolved, uint64(a.Fingerprint()))
+		} else {
+			firing = append(firing, uint64(a.Fingerprint()))
+		}
+	}
+	sort.Stable(alerts)
+	ctx = WithNow(ctx, now)
+	ctx = WithFiringAlerts(ctx, firing)
+	ctx = WithResolvedAlerts(ctx, resolved)
+	return ctx, alerts
+}
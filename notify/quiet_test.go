@@ -0,0 +1,134 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+type quietMuter struct {
+	quiet atomic.Bool
+}
+
+func (m *quietMuter) Mutes(_ []string, _ time.Time) (bool, []string, error) {
+	return m.quiet.Load(), nil, nil
+}
+
+// recordingStage records the notifications it receives.
+type recordingStage struct {
+	mtx      sync.Mutex
+	received [][]*types.Alert
+	resolved [][]uint64
+}
+
+func (s *recordingStage) Exec(ctx context.Context, _ *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	resolved, _ := ResolvedAlerts(ctx)
+	s.received = append(s.received, alerts)
+	s.resolved = append(s.resolved, resolved)
+	return ctx, alerts, nil
+}
+
+func (s *recordingStage) notifications() [][]*types.Alert {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return append([][]*types.Alert(nil), s.received...)
+}
+
+func TestQuietStage(t *testing.T) {
+	var (
+		muter = &quietMuter{}
+		next  = &recordingStage{}
+		qs    = NewQuietStage(muter, []string{"night"}, next)
+		now   = time.Now()
+		a1    = &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "a1"}, StartsAt: now}}
+		a2    = &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "a2"}, StartsAt: now}}
+	)
+	ctx := WithGroupKey(context.Background(), "group")
+	ctx = WithNow(ctx, now)
+
+	// Notifications are sent outside of quiet time.
+	_, res, err := qs.Exec(ctx, promslog.NewNopLogger(), a1)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a1}, res)
+	require.Len(t, next.notifications(), 1)
+
+	// Notifications are queued during quiet time, including the alerts
+	// resolved in the meantime.
+	muter.quiet.Store(true)
+	_, res, err = qs.Exec(ctx, promslog.NewNopLogger(), a1, a2)
+	require.NoError(t, err)
+	require.Empty(t, res)
+	a2Resolved := *a2
+	a2Resolved.EndsAt = now.Add(-time.Minute)
+	_, res, err = qs.Exec(ctx, promslog.NewNopLogger(), a1, &a2Resolved)
+	require.NoError(t, err)
+	require.Empty(t, res)
+	_, res, err = qs.Exec(ctx, promslog.NewNopLogger(), a1)
+	require.NoError(t, err)
+	require.Empty(t, res)
+	require.Len(t, next.notifications(), 1)
+
+	// The next flush after the quiet time sends all the queued alerts.
+	muter.quiet.Store(false)
+	_, res, err = qs.Exec(ctx, promslog.NewNopLogger(), a1)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a1, &a2Resolved}, res)
+	require.Len(t, next.notifications(), 2)
+	require.Equal(t, []uint64{uint64(a2.Fingerprint())}, next.resolved[1])
+
+	// The queue is empty afterwards.
+	_, res, err = qs.Exec(ctx, promslog.NewNopLogger(), a1)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Alert{a1}, res)
+}
+
+func TestQuietStageGroupNotFlushed(t *testing.T) {
+	const checkInterval = 10 * time.Millisecond
+
+	var (
+		muter = &quietMuter{}
+		next  = &recordingStage{}
+		qs    = NewQuietStage(muter, []string{"night"}, next)
+		a     = &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "a"}, EndsAt: time.Now().Add(-time.Minute)}}
+	)
+	qs.checkInterval = checkInterval
+	muter.quiet.Store(true)
+	ctx, cancel := context.WithCancel(WithGroupKey(context.Background(), "group"))
+	_, _, err := qs.Exec(ctx, promslog.NewNopLogger(), a)
+	require.NoError(t, err)
+	// The flush is done, the queued notification doesn't depend on it.
+	cancel()
+
+	time.Sleep(5 * checkInterval)
+	require.Empty(t, next.notifications())
+
+	// The queued notification is sent once the quiet time ends, even if the
+	// group is never flushed again.
+	muter.quiet.Store(false)
+	require.Eventually(t, func() bool { return len(next.notifications()) == 1 }, time.Second, checkInterval)
+	require.Equal(t, []*types.Alert{a}, next.notifications()[0])
+}
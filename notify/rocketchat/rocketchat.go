@@ -141,7 +141,7 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 	var err error
 
 	data := notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
-	tmplText := notify.TmplText(n.tmpl, data, &err)
+	tmplText := notify.TmplText(ctx, n.tmpl, data, &err)
 	if err != nil {
 		return false, err
 	}
@@ -25,9 +25,11 @@ import (
 	"strings"
 
 	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
 
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/slackapp"
 	"github.com/prometheus/alertmanager/template"
 	"github.com/prometheus/alertmanager/types"
 )
@@ -66,6 +68,7 @@ func New(c *config.SlackConfig, t *template.Template, l *slog.Logger, httpOpts .
 // request is the request for sending a slack notification.
 type request struct {
 	Channel     string       `json:"channel,omitempty"`
+	TS          string       `json:"ts,omitempty"`
 	Username    string       `json:"username,omitempty"`
 	IconEmoji   string       `json:"icon_emoji,omitempty"`
 	IconURL     string       `json:"icon_url,omitempty"`
@@ -95,7 +98,7 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 	var err error
 	var (
 		data     = notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
-		tmplText = notify.TmplText(n.tmpl, data, &err)
+		tmplText = notify.TmplText(ctx, n.tmpl, data, &err)
 	)
 	var markdownIn []string
 
@@ -105,7 +108,7 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 		markdownIn = n.conf.MrkdwnIn
 	}
 
-	title, truncated := notify.TruncateInRunes(tmplText(n.conf.Title), maxTitleLenRunes)
+	title, truncated := notify.TruncateInRunes(tmplText(notify.TmplForStatus(data, n.conf.Title, n.conf.TitleResolved)), maxTitleLenRunes)
 	if truncated {
 		key, err := notify.ExtractGroupKey(ctx)
 		if err != nil {
@@ -117,7 +120,7 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 		Title:      title,
 		TitleLink:  tmplText(n.conf.TitleLink),
 		Pretext:    tmplText(n.conf.Pretext),
-		Text:       tmplText(n.conf.Text),
+		Text:       tmplText(notify.TmplForStatus(data, n.conf.Text, n.conf.TextResolved)),
 		Fallback:   tmplText(n.conf.Fallback),
 		CallbackID: tmplText(n.conf.CallbackID),
 		ImageURL:   tmplText(n.conf.ImageURL),
@@ -176,8 +179,36 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 		att.Actions = actions
 	}
 
+	if n.conf.Interactive && data.Status == string(model.AlertFiring) {
+		groupLabels, _ := notify.GroupLabels(ctx)
+		value, err := slackapp.EncodeGroup(groupLabels)
+		if err != nil {
+			return false, err
+		}
+		att.Actions = append(att.Actions,
+			config.SlackAction{Type: "button", Text: "Silence", Name: slackapp.ActionSilence, Value: value},
+			config.SlackAction{Type: "button", Text: "Acknowledge", Name: slackapp.ActionAck, Value: value},
+		)
+		if att.CallbackID == "" {
+			// Interactive attachments require a callback ID.
+			att.CallbackID = "alertmanager"
+		}
+	}
+
+	channel := tmplText(n.conf.Channel)
+	if channel != "" && !n.conf.ChannelAllowed(channel) {
+		n.logger.Warn("Channel not allowed, notifying the default channel", "channel", channel, "default_channel", n.conf.DefaultChannel)
+		short := false
+		att.Fields = append(att.Fields, config.SlackField{
+			Title: "Warning",
+			Value: fmt.Sprintf("This notification was meant for channel %s, which isn't allowed.", channel),
+			Short: &short,
+		})
+		channel = n.conf.DefaultChannel
+	}
+
 	req := &request{
-		Channel:     tmplText(n.conf.Channel),
+		Channel:     channel,
 		Username:    tmplText(n.conf.Username),
 		IconEmoji:   tmplText(n.conf.IconEmoji),
 		IconURL:     tmplText(n.conf.IconURL),
@@ -188,11 +219,6 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 		return false, err
 	}
 
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(req); err != nil {
-		return false, err
-	}
-
 	var u string
 	if n.conf.APIURL != nil {
 		u = n.conf.APIURL.String()
@@ -204,6 +230,23 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 		u = strings.TrimSpace(string(content))
 	}
 
+	// The message of the group can only be updated through the Web API,
+	// incoming webhooks don't return the message timestamp.
+	rd, hasReceiverData := notify.ReceiverDataFromContext(ctx)
+	if n.conf.UpdateOnResolve && hasReceiverData && data.Status == string(model.AlertResolved) {
+		ts, ok := rd.Get("ts")
+		channel, _ := rd.Get("channel")
+		if ok && strings.HasSuffix(u, "/chat.postMessage") {
+			req.Channel, req.TS = channel, ts
+			u = strings.TrimSuffix(u, "chat.postMessage") + "chat.update"
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(req); err != nil {
+		return false, err
+	}
+
 	resp, err := n.postJSONFunc(ctx, n.client, u, &buf)
 	if err != nil {
 		return true, notify.RedactURL(err)
@@ -220,26 +263,41 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 
 	// Slack web API might return errors with a 200 response code.
 	// https://slack.dev/node-slack-sdk/web-api#handle-errors
-	retry, err = checkResponseError(resp)
+	msg, retry, err := checkResponseError(resp)
 	if err != nil {
-		err = fmt.Errorf("channel %q: %w", req.Channel, err)
-		return retry, notify.NewErrorWithReason(notify.ClientErrorReason, err)
+		return retry, fmt.Errorf("channel %q: %w", req.Channel, err)
+	}
+	if n.conf.UpdateOnResolve && hasReceiverData && msg.TS != "" {
+		rd.Set("channel", msg.Channel)
+		rd.Set("ts", msg.TS)
 	}
 
 	return retry, nil
 }
 
+// response is the JSON response of the Slack Web API.
+type response struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+	// Channel and TS identify the posted message.
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
+}
+
 // checkResponseError parses out the error message from Slack API response.
-func checkResponseError(resp *http.Response) (bool, error) {
+// The returned errors are *notify.ErrorWithReason.
+// The response is returned for JSON responses.
+func checkResponseError(resp *http.Response) (response, bool, error) {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return true, fmt.Errorf("could not read response body: %w", err)
+		return response{}, true, notify.NewErrorWithReason(notify.NetworkErrorReason, fmt.Errorf("could not read response body: %w", err))
 	}
 
 	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
 		return checkJSONResponseError(body)
 	}
-	return checkTextResponseError(body)
+	retry, err := checkTextResponseError(body)
+	return response{}, retry, err
 }
 
 // checkTextResponseError classifies plaintext responses from Slack.
@@ -248,25 +306,36 @@ func checkResponseError(resp *http.Response) (bool, error) {
 // (https://api.slack.com/messaging/webhooks#handling_errors)
 func checkTextResponseError(body []byte) (bool, error) {
 	if !bytes.Equal(body, []byte("ok")) {
-		return false, fmt.Errorf("received an error response from Slack: %s", string(body))
+		return false, notify.NewErrorWithReason(errorReason(string(body)), fmt.Errorf("received an error response from Slack: %s", string(body)))
 	}
 	return false, nil
 }
 
 // checkJSONResponseError classifies JSON responses from Slack.
-func checkJSONResponseError(body []byte) (bool, error) {
-	// response is for parsing out errors from the JSON response.
-	type response struct {
-		OK    bool   `json:"ok"`
-		Error string `json:"error"`
-	}
-
+func checkJSONResponseError(body []byte) (response, bool, error) {
 	var data response
 	if err := json.Unmarshal(body, &data); err != nil {
-		return true, fmt.Errorf("could not unmarshal JSON response %q: %w", string(body), err)
+		return data, true, notify.NewErrorWithReason(notify.ClientErrorReason, fmt.Errorf("could not unmarshal JSON response %q: %w", string(body), err))
 	}
 	if !data.OK {
-		return false, fmt.Errorf("error response from Slack: %s", data.Error)
+		return data, false, notify.NewErrorWithReason(errorReason(data.Error), fmt.Errorf("error response from Slack: %s", data.Error))
+	}
+	return data, false, nil
+}
+
+// errorReason classifies the error codes returned by Slack.
+// https://api.slack.com/methods/chat.postMessage#errors
+func errorReason(code string) notify.Reason {
+	switch code {
+	case "invalid_auth", "not_authed", "invalid_token", "token_revoked", "token_expired",
+		"account_inactive", "no_permission", "missing_scope", "action_prohibited":
+		return notify.AuthFailureReason
+	case "ratelimited", "rate_limited":
+		return notify.RateLimitedReason
+	case "invalid_payload", "invalid_blocks", "invalid_attachments", "invalid_arguments",
+		"msg_too_long", "no_text", "too_many_attachments", "invalid_json":
+		return notify.InvalidPayloadReason
+	default:
+		return notify.ClientErrorReason
 	}
-	return false, nil
 }
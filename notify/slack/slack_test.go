@@ -15,12 +15,14 @@ package slack
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -33,6 +35,7 @@ import (
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/notify/test"
+	"github.com/prometheus/alertmanager/slackapp"
 	"github.com/prometheus/alertmanager/types"
 )
 
@@ -126,10 +129,17 @@ func TestNotifier_Notify_WithReason(t *testing.T) {
 		{
 			name:           "with a 4xx status code",
 			statusCode:     http.StatusUnauthorized,
-			expectedReason: notify.ClientErrorReason,
+			expectedReason: notify.AuthFailureReason,
 			expectedRetry:  false,
 			expectedErr:    "unexpected status code 401",
 		},
+		{
+			name:           "with a 404 status code",
+			statusCode:     http.StatusNotFound,
+			expectedReason: notify.ClientErrorReason,
+			expectedRetry:  false,
+			expectedErr:    "unexpected status code 404",
+		},
 		{
 			name:           "with a 5xx status code",
 			statusCode:     http.StatusInternalServerError,
@@ -167,6 +177,22 @@ func TestNotifier_Notify_WithReason(t *testing.T) {
 			expectedRetry:  false,
 			expectedErr:    "error response from Slack: error_message",
 		},
+		{
+			name:           "2xx response with a revoked token",
+			statusCode:     http.StatusOK,
+			responseBody:   `{"ok":false,"error":"token_revoked"}`,
+			expectedReason: notify.AuthFailureReason,
+			expectedRetry:  false,
+			expectedErr:    "error response from Slack: token_revoked",
+		},
+		{
+			name:           "2xx response with a rate limit error",
+			statusCode:     http.StatusOK,
+			responseBody:   `{"ok":false,"error":"ratelimited"}`,
+			expectedReason: notify.RateLimitedReason,
+			expectedRetry:  false,
+			expectedErr:    "error response from Slack: ratelimited",
+		},
 		{
 			name:           "2xx response with a plaintext error",
 			statusCode:     http.StatusOK,
@@ -235,3 +261,211 @@ func TestNotifier_Notify_WithReason(t *testing.T) {
 		})
 	}
 }
+
+func TestSlackResolvedTemplates(t *testing.T) {
+	apiurl, _ := url.Parse("https://slack.com/post.Message")
+	notifier, err := New(
+		&config.SlackConfig{
+			HTTPConfig:    &commoncfg.HTTPClientConfig{},
+			APIURL:        &config.SecretURL{URL: apiurl},
+			Title:         "firing title",
+			Text:          "firing text",
+			TitleResolved: "resolved title",
+			TextResolved:  "resolved text",
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	var req request
+	notifier.postJSONFunc = func(ctx context.Context, client *http.Client, url string, body io.Reader) (*http.Response, error) {
+		require.NoError(t, json.NewDecoder(body).Decode(&req))
+		resp := httptest.NewRecorder()
+		resp.WriteString("ok")
+		return resp.Result(), nil
+	}
+	ctx := notify.WithGroupKey(context.Background(), "1")
+
+	firing := &types.Alert{
+		Alert: model.Alert{
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+	resolved := &types.Alert{
+		Alert: model.Alert{
+			StartsAt: time.Now().Add(-time.Hour),
+			EndsAt:   time.Now().Add(-time.Minute),
+		},
+	}
+
+	_, err = notifier.Notify(ctx, firing, resolved)
+	require.NoError(t, err)
+	require.Equal(t, "firing title", req.Attachments[0].Title)
+	require.Equal(t, "firing text", req.Attachments[0].Text)
+
+	_, err = notifier.Notify(ctx, resolved)
+	require.NoError(t, err)
+	require.Equal(t, "resolved title", req.Attachments[0].Title)
+	require.Equal(t, "resolved text", req.Attachments[0].Text)
+}
+
+func TestSlackAllowedChannels(t *testing.T) {
+	apiurl, _ := url.Parse("https://slack.com/post.Message")
+	notifier, err := New(
+		&config.SlackConfig{
+			HTTPConfig:      &commoncfg.HTTPClientConfig{},
+			APIURL:          &config.SecretURL{URL: apiurl},
+			Channel:         `#{{ .CommonLabels.team }}`,
+			AllowedChannels: []config.Regexp{{Regexp: regexp.MustCompile(`^(?:#team-.+)$`)}},
+			DefaultChannel:  "#alerts",
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	var req request
+	notifier.postJSONFunc = func(ctx context.Context, client *http.Client, url string, body io.Reader) (*http.Response, error) {
+		require.NoError(t, json.NewDecoder(body).Decode(&req))
+		resp := httptest.NewRecorder()
+		resp.WriteString("ok")
+		return resp.Result(), nil
+	}
+	ctx := notify.WithGroupKey(context.Background(), "1")
+
+	for _, tc := range []struct {
+		team    string
+		channel string
+		warning bool
+	}{
+		{team: "team-a", channel: "#team-a"},
+		{team: "general", channel: "#alerts", warning: true},
+	} {
+		t.Run(tc.team, func(t *testing.T) {
+			req = request{}
+			_, err = notifier.Notify(ctx, &types.Alert{
+				Alert: model.Alert{
+					Labels:   model.LabelSet{"team": model.LabelValue(tc.team)},
+					StartsAt: time.Now(),
+					EndsAt:   time.Now().Add(time.Hour),
+				},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.channel, req.Channel)
+			if !tc.warning {
+				require.Empty(t, req.Attachments[0].Fields)
+				return
+			}
+			require.Len(t, req.Attachments[0].Fields, 1)
+			require.Equal(t, "Warning", req.Attachments[0].Fields[0].Title)
+			require.Contains(t, req.Attachments[0].Fields[0].Value, "#general")
+		})
+	}
+}
+
+func TestSlackUpdateOnResolve(t *testing.T) {
+	apiurl, _ := url.Parse("https://slack.com/api/chat.postMessage")
+	notifier, err := New(
+		&config.SlackConfig{
+			HTTPConfig:      &commoncfg.HTTPClientConfig{},
+			APIURL:          &config.SecretURL{URL: apiurl},
+			Channel:         "#alerts",
+			Title:           "{{ .Status }}",
+			NotifierConfig:  config.NotifierConfig{VSendResolved: true},
+			UpdateOnResolve: true,
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	var (
+		reqURL string
+		req    request
+	)
+	notifier.postJSONFunc = func(ctx context.Context, client *http.Client, url string, body io.Reader) (*http.Response, error) {
+		reqURL = url
+		req = request{}
+		require.NoError(t, json.NewDecoder(body).Decode(&req))
+		resp := httptest.NewRecorder()
+		resp.Header().Set("Content-Type", "application/json; charset=utf-8")
+		resp.WriteString(`{"ok":true,"channel":"C123","ts":"1503435956.000247"}`)
+		return resp.Result(), nil
+	}
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	rd := notify.NewReceiverData(nil)
+	ctx = notify.WithReceiverData(ctx, rd)
+
+	alert := &types.Alert{
+		Alert: model.Alert{
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+	_, err = notifier.Notify(ctx, alert)
+	require.NoError(t, err)
+	require.Equal(t, "https://slack.com/api/chat.postMessage", reqURL)
+	require.Equal(t, "#alerts", req.Channel)
+	require.Empty(t, req.TS)
+	require.Equal(t, map[string]string{"channel": "C123", "ts": "1503435956.000247"}, rd.Data())
+
+	alert.EndsAt = time.Now().Add(-time.Minute)
+	_, err = notifier.Notify(ctx, alert)
+	require.NoError(t, err)
+	require.Equal(t, "https://slack.com/api/chat.update", reqURL)
+	require.Equal(t, "C123", req.Channel)
+	require.Equal(t, "1503435956.000247", req.TS)
+	require.Equal(t, "resolved", req.Attachments[0].Title)
+}
+
+func TestSlackInteractive(t *testing.T) {
+	apiurl, _ := url.Parse("https://slack.com/post.Message")
+	notifier, err := New(
+		&config.SlackConfig{
+			HTTPConfig:  &commoncfg.HTTPClientConfig{},
+			APIURL:      &config.SecretURL{URL: apiurl},
+			Interactive: true,
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	var req request
+	notifier.postJSONFunc = func(ctx context.Context, client *http.Client, url string, body io.Reader) (*http.Response, error) {
+		require.NoError(t, json.NewDecoder(body).Decode(&req))
+		resp := httptest.NewRecorder()
+		resp.WriteString("ok")
+		return resp.Result(), nil
+	}
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": "DiskFull"})
+
+	_, err = notifier.Notify(ctx, &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "DiskFull"},
+			StartsAt: time.Now(),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "alertmanager", req.Attachments[0].CallbackID)
+	require.Equal(t, []config.SlackAction{
+		{Type: "button", Text: "Silence", Name: slackapp.ActionSilence, Value: `{"alertname":"DiskFull"}`},
+		{Type: "button", Text: "Acknowledge", Name: slackapp.ActionAck, Value: `{"alertname":"DiskFull"}`},
+	}, req.Attachments[0].Actions)
+
+	// No buttons for resolved alerts.
+	req = request{}
+	_, err = notifier.Notify(ctx, &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "DiskFull"},
+			StartsAt: time.Now().Add(-time.Hour),
+			EndsAt:   time.Now().Add(-time.Minute),
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, req.Attachments[0].Actions)
+}
@@ -0,0 +1,179 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BER tags of the SNMP messages.
+const (
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagOID         = 0x06
+	tagSequence    = 0x30
+	tagTimeTicks   = 0x43
+	tagTrapV2      = 0xa7
+)
+
+// tlv encodes the value with its tag and length.
+func tlv(tag byte, value ...[]byte) []byte {
+	var n int
+	for _, v := range value {
+		n += len(v)
+	}
+	b := append([]byte{tag}, encodeLength(n)...)
+	for _, v := range value {
+		b = append(b, v...)
+	}
+	return b
+}
+
+func encodeLength(n int) []byte {
+	if n < 0x80 {
+		return []byte{byte(n)}
+	}
+	var b []byte
+	for ; n > 0; n >>= 8 {
+		b = append([]byte{byte(n)}, b...)
+	}
+	return append([]byte{0x80 | byte(len(b))}, b...)
+}
+
+// encodeInteger returns the shortest two's complement encoding of v.
+func encodeInteger(v int64) []byte {
+	n := 1
+	for i := v; i > 127 || i < -128; i >>= 8 {
+		n++
+	}
+	b := make([]byte, n)
+	for i := n - 1; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+	return b
+}
+
+func integer(v int64) []byte {
+	return tlv(tagInteger, encodeInteger(v))
+}
+
+func octetString(s []byte) []byte {
+	return tlv(tagOctetString, s)
+}
+
+// parseOID parses an OID in dotted notation, with an optional leading dot.
+func parseOID(s string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimPrefix(s, "."), ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid OID %q: at least two arcs are required", s)
+	}
+	oid := make([]uint32, len(parts))
+	for i, p := range parts {
+		v, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OID %q: %w", s, err)
+		}
+		oid[i] = uint32(v)
+	}
+	if oid[0] > 2 || (oid[0] < 2 && oid[1] >= 40) {
+		return nil, fmt.Errorf("invalid OID %q: invalid first arcs", s)
+	}
+	return oid, nil
+}
+
+func formatOID(oid []uint32) string {
+	var sb strings.Builder
+	for _, a := range oid {
+		sb.WriteByte('.')
+		sb.WriteString(strconv.FormatUint(uint64(a), 10))
+	}
+	return sb.String()
+}
+
+func encodeOID(oid []uint32) []byte {
+	b := appendBase128(nil, uint64(oid[0])*40+uint64(oid[1]))
+	for _, a := range oid[2:] {
+		b = appendBase128(b, uint64(a))
+	}
+	return b
+}
+
+func appendBase128(b []byte, v uint64) []byte {
+	var tmp [10]byte
+	i := len(tmp) - 1
+	tmp[i] = byte(v & 0x7f)
+	for v >>= 7; v > 0; v >>= 7 {
+		i--
+		tmp[i] = byte(v&0x7f) | 0x80
+	}
+	return append(b, tmp[i:]...)
+}
+
+// varbind is a variable binding of a trap.
+type varbind struct {
+	oid []uint32
+	// value is the encoded value, with its tag.
+	value []byte
+	// text describes the value in the notification previews.
+	text string
+}
+
+func stringVarbind(oid []uint32, s string) varbind {
+	return varbind{oid: oid, value: octetString([]byte(s)), text: "STRING: " + strconv.Quote(s)}
+}
+
+func integerVarbind(oid []uint32, s string) (varbind, error) {
+	v, err := strconv.ParseInt(strings.TrimSpace(s), 10, 32)
+	if err != nil {
+		return varbind{}, fmt.Errorf("invalid integer %q: %w", s, err)
+	}
+	return varbind{oid: oid, value: integer(v), text: "INTEGER: " + strconv.FormatInt(v, 10)}, nil
+}
+
+func oidVarbind(oid []uint32, s string) (varbind, error) {
+	v, err := parseOID(strings.TrimSpace(s))
+	if err != nil {
+		return varbind{}, err
+	}
+	return varbind{oid: oid, value: tlv(tagOID, encodeOID(v)), text: "OID: " + formatOID(v)}, nil
+}
+
+func timeTicksVarbind(oid []uint32, ticks uint32) varbind {
+	return varbind{oid: oid, value: tlv(tagTimeTicks, encodeInteger(int64(ticks))), text: "Timeticks: " + strconv.FormatUint(uint64(ticks), 10)}
+}
+
+func (v varbind) encode() []byte {
+	return tlv(tagSequence, tlv(tagOID, encodeOID(v.oid)), v.value)
+}
+
+// trapPDU encodes an SNMPv2-Trap-PDU.
+func trapPDU(requestID int32, varbinds []varbind) []byte {
+	encoded := make([][]byte, len(varbinds))
+	for i, v := range varbinds {
+		encoded[i] = v.encode()
+	}
+	return tlv(tagTrapV2,
+		integer(int64(requestID)),
+		integer(0), // error-status
+		integer(0), // error-index
+		tlv(tagSequence, encoded...),
+	)
+}
+
+// errMessageTooLarge is returned for traps which don't fit in a UDP datagram.
+var errMessageTooLarge = errors.New("trap exceeds the maximum size of a UDP datagram")
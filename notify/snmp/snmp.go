@@ -0,0 +1,263 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"go.uber.org/atomic"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+var (
+	// sysUpTimeOID and snmpTrapOID are the OIDs of the first two varbinds
+	// of every trap.
+	sysUpTimeOID   = []uint32{1, 3, 6, 1, 2, 1, 1, 3, 0}
+	snmpTrapOIDOID = []uint32{1, 3, 6, 1, 6, 3, 1, 1, 4, 1, 0}
+)
+
+// Notifier implements a Notifier for SNMP traps.
+type Notifier struct {
+	conf   *config.SNMPConfig
+	tmpl   *template.Template
+	logger *slog.Logger
+	dialer net.Dialer
+	usm    *usm
+	start  time.Time
+
+	trapOID         []uint32
+	resolvedTrapOID []uint32
+	labelOIDs       []namedOID
+	annotationOIDs  []namedOID
+	varbindOIDs     [][]uint32
+
+	requestID atomic.Int32
+	salt      atomic.Uint64
+}
+
+type namedOID struct {
+	name model.LabelName
+	oid  []uint32
+}
+
+// New returns a new SNMP notifier.
+func New(c *config.SNMPConfig, t *template.Template, l *slog.Logger) (*Notifier, error) {
+	n := &Notifier{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		start:  time.Now(),
+	}
+	n.requestID.Store(rand.Int32())
+	n.salt.Store(rand.Uint64())
+
+	var err error
+	if n.trapOID, err = parseOID(c.TrapOID); err != nil {
+		return nil, err
+	}
+	n.resolvedTrapOID = n.trapOID
+	if c.ResolvedTrapOID != "" {
+		if n.resolvedTrapOID, err = parseOID(c.ResolvedTrapOID); err != nil {
+			return nil, err
+		}
+	}
+	if n.labelOIDs, err = parseNamedOIDs(c.LabelOIDs); err != nil {
+		return nil, err
+	}
+	if n.annotationOIDs, err = parseNamedOIDs(c.AnnotationOIDs); err != nil {
+		return nil, err
+	}
+	for _, v := range c.Varbinds {
+		oid, err := parseOID(v.OID)
+		if err != nil {
+			return nil, err
+		}
+		n.varbindOIDs = append(n.varbindOIDs, oid)
+	}
+	if c.Version == config.SNMPVersion3 {
+		if n.usm, err = newUSM(c.V3); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+// parseNamedOIDs parses the OIDs of the map, sorted by name so that the
+// varbinds have a stable order.
+func parseNamedOIDs(m map[model.LabelName]string) ([]namedOID, error) {
+	res := make([]namedOID, 0, len(m))
+	for name, s := range m {
+		oid, err := parseOID(s)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, namedOID{name: name, oid: oid})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].name < res[j].name })
+	return res, nil
+}
+
+// Notify sends one trap per alert.
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	traps := make([][]varbind, 0, len(as))
+	for _, a := range as {
+		vbs, err := n.varbinds(ctx, a)
+		if err != nil {
+			return false, err
+		}
+		traps = append(traps, vbs)
+	}
+
+	if p, ok := notify.PreviewFromContext(ctx); ok {
+		n.preview(p, traps)
+		return false, nil
+	}
+
+	conn, err := n.dialer.DialContext(ctx, "udp", n.conf.Target.String())
+	if err != nil {
+		return true, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetWriteDeadline(deadline); err != nil {
+			return true, err
+		}
+	}
+	for _, vbs := range traps {
+		msg, err := n.message(vbs)
+		if err != nil {
+			return false, err
+		}
+		if _, err := conn.Write(msg); err != nil {
+			return true, fmt.Errorf("send trap to %s: %w", n.conf.Target, err)
+		}
+	}
+	n.logger.Debug("SNMP traps sent", "target", n.conf.Target.String(), "traps", len(traps))
+	return false, nil
+}
+
+// varbinds returns the varbinds of the trap of the alert: the uptime, the
+// trap OID, the labels, the annotations and the templated varbinds.
+func (n *Notifier) varbinds(ctx context.Context, a *types.Alert) ([]varbind, error) {
+	trapOID := n.trapOID
+	if a.Resolved() {
+		trapOID = n.resolvedTrapOID
+	}
+	vbs := []varbind{
+		timeTicksVarbind(sysUpTimeOID, n.uptime()),
+		{oid: snmpTrapOIDOID, value: tlv(tagOID, encodeOID(trapOID)), text: "OID: " + formatOID(trapOID)},
+	}
+	for _, l := range n.labelOIDs {
+		if v, ok := a.Labels[l.name]; ok {
+			vbs = append(vbs, stringVarbind(l.oid, string(v)))
+		}
+	}
+	for _, l := range n.annotationOIDs {
+		if v, ok := a.Annotations[l.name]; ok {
+			vbs = append(vbs, stringVarbind(l.oid, string(v)))
+		}
+	}
+	if len(n.conf.Varbinds) == 0 {
+		return vbs, nil
+	}
+
+	var (
+		tmplErr error
+		data    = notify.GetTemplateData(ctx, n.tmpl, []*types.Alert{a}, n.logger)
+		tmpl    = notify.TmplText(ctx, n.tmpl, data, &tmplErr)
+	)
+	for i, c := range n.conf.Varbinds {
+		value := tmpl(c.Value)
+		if tmplErr != nil {
+			return nil, fmt.Errorf("execute template of varbind %s: %w", c.OID, tmplErr)
+		}
+		var (
+			vb  varbind
+			err error
+		)
+		switch c.Type {
+		case config.SNMPTypeInteger:
+			vb, err = integerVarbind(n.varbindOIDs[i], value)
+		case config.SNMPTypeOID:
+			vb, err = oidVarbind(n.varbindOIDs[i], value)
+		default:
+			vb = stringVarbind(n.varbindOIDs[i], value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("varbind %s: %w", c.OID, err)
+		}
+		vbs = append(vbs, vb)
+	}
+	return vbs, nil
+}
+
+// message encodes the trap with the varbinds in an SNMP message.
+func (n *Notifier) message(vbs []varbind) ([]byte, error) {
+	requestID := n.requestID.Add(1)
+	pdu := trapPDU(requestID, vbs)
+	if n.usm == nil {
+		msg := tlv(tagSequence,
+			integer(1), // SNMPv2c
+			octetString([]byte(n.conf.Community)),
+			pdu,
+		)
+		if len(msg) > maxMessageSize {
+			return nil, errMessageTooLarge
+		}
+		return msg, nil
+	}
+	// The engine boots are the start time of the process so that they
+	// increase with every restart, as the engine time restarts from 0.
+	boots := int32(min(n.start.Unix(), math.MaxInt32-1))
+	engineTime := int32(time.Since(n.start) / time.Second)
+	return n.usm.message(requestID, boots, engineTime, n.salt.Add(1), pdu)
+}
+
+// uptime returns the time since the start of the notifier in hundredths of
+// seconds.
+func (n *Notifier) uptime() uint32 {
+	return uint32(time.Since(n.start) / (10 * time.Millisecond))
+}
+
+// preview records the varbinds of the traps instead of sending them.
+func (n *Notifier) preview(p *notify.Preview, traps [][]varbind) {
+	header := http.Header{}
+	header.Set("Snmp-Version", n.conf.Version)
+	for _, vbs := range traps {
+		var sb strings.Builder
+		for _, vb := range vbs {
+			fmt.Fprintf(&sb, "%s = %s\n", formatOID(vb.oid), vb.text)
+		}
+		p.Record(notify.PreviewRequest{
+			Method: "SNMP",
+			URL:    "snmp://" + n.conf.Target.String(),
+			Header: header,
+			Body:   sb.String(),
+		})
+	}
+}
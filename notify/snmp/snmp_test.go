@@ -0,0 +1,406 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmp
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/test"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// decode returns the tag and the value of the first BER element of b, and
+// the elements following it.
+func decode(t *testing.T, b []byte) (byte, []byte, []byte) {
+	t.Helper()
+	require.GreaterOrEqual(t, len(b), 2)
+	tag, n := b[0], int(b[1])
+	b = b[2:]
+	if n&0x80 != 0 {
+		l := n & 0x7f
+		n = 0
+		for _, c := range b[:l] {
+			n = n<<8 | int(c)
+		}
+		b = b[l:]
+	}
+	require.GreaterOrEqual(t, len(b), n)
+	return tag, b[:n], b[n:]
+}
+
+// elements decodes the values of the elements of a sequence, checking their
+// tags.
+func elements(t *testing.T, b []byte, tags ...byte) [][]byte {
+	t.Helper()
+	var res [][]byte
+	for _, expected := range tags {
+		var (
+			tag   byte
+			value []byte
+		)
+		tag, value, b = decode(t, b)
+		require.Equal(t, expected, tag)
+		res = append(res, value)
+	}
+	require.Empty(t, b)
+	return res
+}
+
+func decodeInteger(b []byte) int64 {
+	v := int64(int8(b[0]))
+	for _, c := range b[1:] {
+		v = v<<8 | int64(c)
+	}
+	return v
+}
+
+func decodeOID(b []byte) string {
+	var (
+		arcs []uint64
+		v    uint64
+	)
+	for _, c := range b {
+		v = v<<7 | uint64(c&0x7f)
+		if c&0x80 == 0 {
+			arcs = append(arcs, v)
+			v = 0
+		}
+	}
+	s := "." + strconv.FormatUint(arcs[0]/40, 10) + "." + strconv.FormatUint(arcs[0]%40, 10)
+	for _, a := range arcs[1:] {
+		s += "." + strconv.FormatUint(a, 10)
+	}
+	return s
+}
+
+// decodeTrap decodes the varbinds of a trap PDU, except the uptime, into the
+// textual values of their OIDs.
+func decodeTrap(t *testing.T, pdu []byte) map[string]string {
+	t.Helper()
+	tag, pdu, rest := decode(t, pdu)
+	require.Equal(t, byte(tagTrapV2), tag)
+	require.Empty(t, rest)
+	fields := elements(t, pdu, tagInteger, tagInteger, tagInteger, tagSequence)
+	require.Equal(t, int64(0), decodeInteger(fields[1]))
+
+	res := map[string]string{}
+	for b := fields[3]; len(b) > 0; {
+		var vb []byte
+		tag, vb, b = decode(t, b)
+		require.Equal(t, byte(tagSequence), tag)
+		oid, v, rest := decode(t, vb)
+		require.Equal(t, byte(tagOID), oid)
+		tag, value, rest := decode(t, rest)
+		require.Empty(t, rest)
+		switch tag {
+		case tagOctetString:
+			res[decodeOID(v)] = string(value)
+		case tagInteger:
+			res[decodeOID(v)] = strconv.FormatInt(decodeInteger(value), 10)
+		case tagOID:
+			res[decodeOID(v)] = decodeOID(value)
+		case tagTimeTicks:
+			require.Equal(t, ".1.3.6.1.2.1.1.3.0", decodeOID(v))
+		default:
+			t.Fatalf("unexpected tag %x", tag)
+		}
+	}
+	return res
+}
+
+func TestEncoding(t *testing.T) {
+	for v, expected := range map[int64]string{
+		0:    "00",
+		127:  "7f",
+		128:  "0080",
+		256:  "0100",
+		-1:   "ff",
+		-128: "80",
+		-129: "ff7f",
+	} {
+		require.Equal(t, expected, hex.EncodeToString(encodeInteger(v)), v)
+	}
+
+	oid, err := parseOID(".1.3.6.1.4.1.2021")
+	require.NoError(t, err)
+	require.Equal(t, "2b060104018f65", hex.EncodeToString(encodeOID(oid)))
+	require.Equal(t, ".1.3.6.1.4.1.2021", formatOID(oid))
+	for _, s := range []string{"1", "3.1", "1.40", "1.3.x", "1.3.4294967296"} {
+		_, err := parseOID(s)
+		require.Error(t, err, s)
+	}
+
+	require.Equal(t, []byte{0x04, 0x81, 0xc8}, octetString(make([]byte, 200))[:3])
+}
+
+// TestLocalizedKey checks the localized keys against the sample results of
+// RFC 3414, A.3.
+func TestLocalizedKey(t *testing.T) {
+	engineID, err := hex.DecodeString("000000000000000000000002")
+	require.NoError(t, err)
+	require.Equal(t, "526f5eed9fcce26f8964c2930787d82b", hex.EncodeToString(localizedKey(md5.New, "maplesyrup", engineID)))
+	require.Equal(t, "6695febc9288e36282235fc7151f128497b38f3f", hex.EncodeToString(localizedKey(sha1.New, "maplesyrup", engineID)))
+}
+
+func newAlert(resolved bool) *types.Alert {
+	a := &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": "DiskFull", "instance": "db1"},
+			Annotations: model.LabelSet{"summary": "Disk full"},
+			StartsAt:    time.Now().Add(-time.Hour),
+		},
+	}
+	if resolved {
+		a.EndsAt = time.Now().Add(-time.Minute)
+	}
+	return a
+}
+
+func newContext() context.Context {
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	ctx = notify.WithReceiverName(ctx, "noc")
+	return notify.WithGroupLabels(ctx, model.LabelSet{"alertname": "DiskFull"})
+}
+
+// listen returns the address of a UDP listener and a function receiving a
+// datagram.
+func listen(t *testing.T) (config.HostPort, func() []byte) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	host, port, err := net.SplitHostPort(conn.LocalAddr().String())
+	require.NoError(t, err)
+	return config.HostPort{Host: host, Port: port}, func() []byte {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		b := make([]byte, maxMessageSize)
+		n, _, err := conn.ReadFrom(b)
+		require.NoError(t, err)
+		return b[:n]
+	}
+}
+
+func TestNotifyV2c(t *testing.T) {
+	target, receive := listen(t)
+	n, err := New(&config.SNMPConfig{
+		Target:          target,
+		Version:         config.SNMPVersion2c,
+		Community:       "secret",
+		TrapOID:         "1.3.6.1.4.1.99999.1",
+		ResolvedTrapOID: "1.3.6.1.4.1.99999.2",
+		LabelOIDs:       map[model.LabelName]string{"instance": "1.3.6.1.4.1.99999.3.1", "missing": "1.3.6.1.4.1.99999.3.2"},
+		AnnotationOIDs:  map[model.LabelName]string{"summary": "1.3.6.1.4.1.99999.3.3"},
+		Varbinds: []config.SNMPVarbind{
+			{OID: "1.3.6.1.4.1.99999.3.4", Type: config.SNMPTypeString, Value: "{{ .Receiver }}/{{ .Status }}"},
+			{OID: "1.3.6.1.4.1.99999.3.5", Type: config.SNMPTypeInteger, Value: `{{ if eq .Status "firing" }}1{{ else }}0{{ end }}`},
+			{OID: "1.3.6.1.4.1.99999.3.6", Type: config.SNMPTypeOID, Value: "1.3.6.1.4.1.99999.4"},
+		},
+	}, test.CreateTmpl(t), promslog.NewNopLogger())
+	require.NoError(t, err)
+
+	retry, err := n.Notify(newContext(), newAlert(false), newAlert(true))
+	require.NoError(t, err)
+	require.False(t, retry)
+
+	for _, resolved := range []bool{false, true} {
+		tag, msg, rest := decode(t, receive())
+		require.Equal(t, byte(tagSequence), tag)
+		require.Empty(t, rest)
+		tag, version, rest := decode(t, msg)
+		require.Equal(t, byte(tagInteger), tag)
+		require.Equal(t, int64(1), decodeInteger(version))
+		tag, community, pdu := decode(t, rest)
+		require.Equal(t, byte(tagOctetString), tag)
+		require.Equal(t, "secret", string(community))
+
+		expected := map[string]string{
+			".1.3.6.1.6.3.1.1.4.1.0": ".1.3.6.1.4.1.99999.1",
+			".1.3.6.1.4.1.99999.3.1": "db1",
+			".1.3.6.1.4.1.99999.3.3": "Disk full",
+			".1.3.6.1.4.1.99999.3.4": "noc/firing",
+			".1.3.6.1.4.1.99999.3.5": "1",
+			".1.3.6.1.4.1.99999.3.6": ".1.3.6.1.4.1.99999.4",
+		}
+		if resolved {
+			expected[".1.3.6.1.6.3.1.1.4.1.0"] = ".1.3.6.1.4.1.99999.2"
+			expected[".1.3.6.1.4.1.99999.3.4"] = "noc/resolved"
+			expected[".1.3.6.1.4.1.99999.3.5"] = "0"
+		}
+		require.Equal(t, expected, decodeTrap(t, pdu))
+	}
+}
+
+func TestNotifyV3(t *testing.T) {
+	for _, tc := range []struct {
+		auth, priv string
+	}{
+		{},
+		{auth: config.SNMPAuthMD5},
+		{auth: config.SNMPAuthSHA, priv: config.SNMPPrivAES},
+		{auth: config.SNMPAuthSHA256, priv: config.SNMPPrivAES},
+	} {
+		t.Run(tc.auth+tc.priv, func(t *testing.T) {
+			target, receive := listen(t)
+			v3 := &config.SNMPv3Config{
+				Username:     "alertmanager",
+				EngineID:     "80001f8880e9bd0c1d12667a51",
+				AuthProtocol: tc.auth,
+				AuthPassword: "authpassword",
+				PrivProtocol: tc.priv,
+				PrivPassword: "privpassword",
+			}
+			n, err := New(&config.SNMPConfig{
+				Target:    target,
+				Version:   config.SNMPVersion3,
+				V3:        v3,
+				TrapOID:   "1.3.6.1.4.1.99999.1",
+				LabelOIDs: map[model.LabelName]string{"instance": "1.3.6.1.4.1.99999.3.1"},
+			}, test.CreateTmpl(t), promslog.NewNopLogger())
+			require.NoError(t, err)
+
+			_, err = n.Notify(newContext(), newAlert(false))
+			require.NoError(t, err)
+			datagram := receive()
+
+			_, msg, _ := decode(t, datagram)
+			_, version, rest := decode(t, msg)
+			require.Equal(t, int64(3), decodeInteger(version))
+			_, headerData, rest := decode(t, rest)
+			header := elements(t, headerData, tagInteger, tagInteger, tagOctetString, tagInteger)
+			require.Equal(t, int64(securityModelUSM), decodeInteger(header[3]))
+			_, securityParams, rest := decode(t, rest)
+			_, usmParams, _ := decode(t, securityParams)
+			params := elements(t, usmParams, tagOctetString, tagInteger, tagInteger, tagOctetString, tagOctetString, tagOctetString)
+			require.Equal(t, v3.EngineID, hex.EncodeToString(params[0]))
+			require.Equal(t, "alertmanager", string(params[3]))
+
+			engineID, _ := hex.DecodeString(v3.EngineID)
+			var flags byte
+			switch tc.auth {
+			case config.SNMPAuthMD5:
+				flags |= flagAuth
+				checkMAC(t, datagram, params[4], hmac.New(md5.New, localizedKey(md5.New, "authpassword", engineID)))
+			case config.SNMPAuthSHA:
+				flags |= flagAuth
+				checkMAC(t, datagram, params[4], hmac.New(sha1.New, localizedKey(sha1.New, "authpassword", engineID)))
+			case config.SNMPAuthSHA256:
+				flags |= flagAuth
+				require.Len(t, params[4], 24)
+				checkMAC(t, datagram, params[4], hmac.New(sha256.New, localizedKey(sha256.New, "authpassword", engineID)))
+			}
+
+			// The scoped PDU is sent encrypted with privacy.
+			tag, data, rest := decode(t, rest)
+			require.Empty(t, rest)
+			if tc.priv != "" {
+				flags |= flagPriv
+				require.Equal(t, byte(tagOctetString), tag)
+				h := sha1.New
+				if tc.auth == config.SNMPAuthSHA256 {
+					h = sha256.New
+				}
+				block, err := aes.NewCipher(localizedKey(h, "privpassword", engineID)[:16])
+				require.NoError(t, err)
+				iv := append(append(append([]byte{}, pad4(params[1])...), pad4(params[2])...), params[5]...)
+				decrypted := make([]byte, len(data))
+				//nolint:staticcheck // RFC 3826 mandates CFB mode.
+				cipher.NewCFBDecrypter(block, iv).XORKeyStream(decrypted, data)
+				tag, data, _ = decode(t, decrypted)
+			}
+			require.Equal(t, []byte{flags}, header[2])
+			require.Equal(t, byte(tagSequence), tag)
+
+			_, contextEngineID, pdu := decode(t, data)
+			require.Equal(t, engineID, contextEngineID)
+			_, _, pdu = decode(t, pdu)
+			require.Equal(t, map[string]string{
+				".1.3.6.1.6.3.1.1.4.1.0": ".1.3.6.1.4.1.99999.1",
+				".1.3.6.1.4.1.99999.3.1": "db1",
+			}, decodeTrap(t, pdu))
+		})
+	}
+}
+
+// checkMAC checks the authentication parameters of the message.
+func checkMAC(t *testing.T, msg, params []byte, mac interface {
+	Write([]byte) (int, error)
+	Sum([]byte) []byte
+},
+) {
+	t.Helper()
+	i := strings.Index(string(msg), string(params))
+	require.Positive(t, i)
+	zeroed := append([]byte{}, msg...)
+	copy(zeroed[i:], make([]byte, len(params)))
+	mac.Write(zeroed)
+	require.Equal(t, mac.Sum(nil)[:len(params)], params)
+}
+
+// pad4 returns the 4 bytes big-endian encoding of a BER integer.
+func pad4(b []byte) []byte {
+	v := uint32(decodeInteger(b))
+	return []byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+}
+
+func TestNotifyPreview(t *testing.T) {
+	n, err := New(&config.SNMPConfig{
+		Target:    config.HostPort{Host: "nms.example.com", Port: "162"},
+		Version:   config.SNMPVersion2c,
+		Community: "public",
+		TrapOID:   "1.3.6.1.4.1.99999.1",
+		LabelOIDs: map[model.LabelName]string{"instance": "1.3.6.1.4.1.99999.3.1"},
+	}, test.CreateTmpl(t), promslog.NewNopLogger())
+	require.NoError(t, err)
+
+	p := &notify.Preview{}
+	_, err = n.Notify(notify.WithPreview(newContext(), p), newAlert(false))
+	require.NoError(t, err)
+	requests := p.Requests()
+	require.Len(t, requests, 1)
+	require.Equal(t, "snmp://nms.example.com:162", requests[0].URL)
+	require.Contains(t, requests[0].Body, ".1.3.6.1.6.3.1.1.4.1.0 = OID: .1.3.6.1.4.1.99999.1\n")
+	require.Contains(t, requests[0].Body, ".1.3.6.1.4.1.99999.3.1 = STRING: \"db1\"\n")
+}
+
+func TestNotifyInvalidInteger(t *testing.T) {
+	n, err := New(&config.SNMPConfig{
+		Target:    config.HostPort{Host: "127.0.0.1", Port: "162"},
+		Version:   config.SNMPVersion2c,
+		Community: "public",
+		TrapOID:   "1.3.6.1.4.1.99999.1",
+		Varbinds:  []config.SNMPVarbind{{OID: "1.3.6.1.4.1.99999.3.1", Type: config.SNMPTypeInteger, Value: "{{ .Status }}"}},
+	}, test.CreateTmpl(t), promslog.NewNopLogger())
+	require.NoError(t, err)
+
+	retry, err := n.Notify(newContext(), newAlert(false))
+	require.ErrorContains(t, err, `varbind 1.3.6.1.4.1.99999.3.1: invalid integer "firing"`)
+	require.False(t, retry)
+}
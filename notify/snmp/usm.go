@@ -0,0 +1,164 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"strings"
+
+	"github.com/prometheus/alertmanager/config"
+)
+
+const (
+	// maxMessageSize is the maximum size of an SNMP message over UDP.
+	maxMessageSize = 65507
+	// securityModelUSM identifies the user-based security model.
+	securityModelUSM = 3
+
+	flagAuth = 0x01
+	flagPriv = 0x02
+)
+
+// usm secures SNMP v3 messages with the user-based security model of RFC
+// 3414, HMAC-SHA-2 authentication of RFC 7860 and AES encryption of RFC 3826.
+type usm struct {
+	username string
+	engineID []byte
+
+	hash    func() hash.Hash
+	macLen  int
+	authKey []byte
+	privKey []byte
+}
+
+func newUSM(c *config.SNMPv3Config) (*usm, error) {
+	engineID, err := hex.DecodeString(strings.TrimPrefix(c.EngineID, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	u := &usm{username: c.Username, engineID: engineID}
+	switch c.AuthProtocol {
+	case config.SNMPAuthMD5:
+		u.hash, u.macLen = md5.New, 12
+	case config.SNMPAuthSHA:
+		u.hash, u.macLen = sha1.New, 12
+	case config.SNMPAuthSHA256:
+		u.hash, u.macLen = sha256.New, 24
+	default:
+		return u, nil
+	}
+	u.authKey = localizedKey(u.hash, string(c.AuthPassword), engineID)
+	if c.PrivProtocol == config.SNMPPrivAES {
+		// AES-128 uses the first 16 bytes of the localized key.
+		u.privKey = localizedKey(u.hash, string(c.PrivPassword), engineID)[:16]
+	}
+	return u, nil
+}
+
+// localizedKey derives the key of the password localized to the engine ID
+// with the password to key algorithm of RFC 3414, A.2.
+func localizedKey(h func() hash.Hash, password string, engineID []byte) []byte {
+	d := h()
+	buf := make([]byte, 64)
+	for i := 0; i < 1048576; i += len(buf) {
+		for j := range buf {
+			buf[j] = password[(i+j)%len(password)]
+		}
+		d.Write(buf)
+	}
+	ku := d.Sum(nil)
+
+	d.Reset()
+	d.Write(ku)
+	d.Write(engineID)
+	d.Write(ku)
+	return d.Sum(nil)
+}
+
+// message encodes the PDU in an SNMP v3 message. As the sender of traps is
+// the authoritative engine, the engine boots and time are its own. The salt
+// must be unique for each encrypted message.
+func (u *usm) message(msgID, boots, engineTime int32, salt uint64, pdu []byte) ([]byte, error) {
+	var flags byte
+	if u.authKey != nil {
+		flags |= flagAuth
+	}
+	if u.privKey != nil {
+		flags |= flagPriv
+	}
+
+	msgData := tlv(tagSequence,
+		octetString(u.engineID), // contextEngineID
+		octetString(nil),        // contextName
+		pdu,
+	)
+	var privParams []byte
+	if u.privKey != nil {
+		privParams = binary.BigEndian.AppendUint64(nil, salt)
+		iv := binary.BigEndian.AppendUint32(nil, uint32(boots))
+		iv = binary.BigEndian.AppendUint32(iv, uint32(engineTime))
+		iv = append(iv, privParams...)
+		block, err := aes.NewCipher(u.privKey)
+		if err != nil {
+			return nil, err
+		}
+		encrypted := make([]byte, len(msgData))
+		//nolint:staticcheck // RFC 3826 mandates CFB mode, the message is authenticated.
+		cipher.NewCFBEncrypter(block, iv).XORKeyStream(encrypted, msgData)
+		msgData = octetString(encrypted)
+	}
+
+	privTLV := octetString(privParams)
+	securityParams := octetString(tlv(tagSequence,
+		octetString(u.engineID),
+		integer(int64(boots)),
+		integer(int64(engineTime)),
+		octetString([]byte(u.username)),
+		// The authentication parameters are zeroed to compute the MAC.
+		octetString(make([]byte, u.macLen)),
+		privTLV,
+	))
+	msg := tlv(tagSequence,
+		integer(3),
+		tlv(tagSequence,
+			integer(int64(msgID)),
+			integer(maxMessageSize),
+			octetString([]byte{flags}),
+			integer(securityModelUSM),
+		),
+		securityParams,
+		msgData,
+	)
+	if len(msg) > maxMessageSize {
+		return nil, errMessageTooLarge
+	}
+
+	if u.authKey != nil {
+		// The authentication parameters precede the privacy parameters,
+		// which precede the data.
+		offset := len(msg) - len(msgData) - len(privTLV) - u.macLen
+		mac := hmac.New(u.hash, u.authKey)
+		mac.Write(msg)
+		copy(msg[offset:], mac.Sum(nil)[:u.macLen])
+	}
+	return msg, nil
+}
@@ -15,6 +15,7 @@ package sns
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -64,9 +65,13 @@ func (n *Notifier) Notify(ctx context.Context, alert ...*types.Alert) (bool, err
 	var (
 		tmplErr error
 		data    = notify.GetTemplateData(ctx, n.tmpl, alert, n.logger)
-		tmpl    = notify.TmplText(n.tmpl, data, &tmplErr)
+		tmpl    = notify.TmplText(ctx, n.tmpl, data, &tmplErr)
 	)
 
+	if p, ok := notify.PreviewFromContext(ctx); ok {
+		return false, n.preview(ctx, p, tmpl, &tmplErr)
+	}
+
 	client, err := n.createSNSClient(tmpl, &tmplErr)
 	if err != nil {
 		var e awserr.RequestFailure
@@ -98,6 +103,29 @@ func (n *Notifier) Notify(ctx context.Context, alert ...*types.Alert) (bool, err
 	return false, nil
 }
 
+// preview records the publish request instead of sending it.
+func (n *Notifier) preview(ctx context.Context, p *notify.Preview, tmpl func(string) string, tmplErr *error) error {
+	publishInput, err := n.createPublishInput(ctx, tmpl, tmplErr)
+	if err != nil {
+		return err
+	}
+	apiURL := tmpl(n.conf.APIUrl)
+	if *tmplErr != nil {
+		return fmt.Errorf("execute 'api_url' template: %w", *tmplErr)
+	}
+	b, err := json.Marshal(publishInput)
+	if err != nil {
+		return err
+	}
+	p.Record(notify.PreviewRequest{
+		Method: http.MethodPost,
+		URL:    apiURL,
+		Header: http.Header{"Content-Type": []string{"application/json"}},
+		Body:   string(b),
+	})
+	return nil
+}
+
 func (n *Notifier) createSNSClient(tmpl func(string) string, tmplErr *error) (*sns.SNS, error) {
 	var creds *credentials.Credentials
 	// If there are provided sigV4 credentials we want to use those to create a session.
@@ -0,0 +1,133 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// ReceiverStatsWindow is the rolling window of the statistics of the
+	// receivers.
+	ReceiverStatsWindow = 24 * time.Hour
+	// receiverStatsBucket is the granularity of the window.
+	receiverStatsBucket = time.Hour
+)
+
+// ReceiverStats are the statistics of the notifications of a receiver within
+// ReceiverStatsWindow. A notification is counted once per integration, after
+// its retries.
+type ReceiverStats struct {
+	Receiver      string
+	Notifications int
+	Failed        int
+	// LastSent is the time of the last successful notification, zero if none
+	// since the start.
+	LastSent time.Time
+}
+
+type statsBucket struct {
+	start         time.Time
+	notifications int
+	failed        int
+}
+
+type receiverCounters struct {
+	// buckets are ordered by start time.
+	buckets  []statsBucket
+	lastSent time.Time
+}
+
+// prune drops the buckets which ended before the window starting at from.
+func (c *receiverCounters) prune(from time.Time) {
+	i := 0
+	for i < len(c.buckets) && !c.buckets[i].start.Add(receiverStatsBucket).After(from) {
+		i++
+	}
+	c.buckets = c.buckets[i:]
+}
+
+// receiverStats keeps the statistics of the notifications by receiver.
+type receiverStats struct {
+	mtx        sync.Mutex
+	byReceiver map[string]*receiverCounters
+}
+
+func newReceiverStats() *receiverStats {
+	return &receiverStats{byReceiver: map[string]*receiverCounters{}}
+}
+
+// record records the outcome of a notification.
+func (s *receiverStats) record(receiver string, t time.Time, err error) {
+	if s == nil {
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	c, ok := s.byReceiver[receiver]
+	if !ok {
+		c = &receiverCounters{}
+		s.byReceiver[receiver] = c
+	}
+	c.prune(t.Add(-ReceiverStatsWindow))
+
+	start := t.Truncate(receiverStatsBucket)
+	if n := len(c.buckets); n == 0 || c.buckets[n-1].start.Before(start) {
+		c.buckets = append(c.buckets, statsBucket{start: start})
+	}
+	b := &c.buckets[len(c.buckets)-1]
+	b.notifications++
+	if err != nil {
+		b.failed++
+		return
+	}
+	c.lastSent = t
+}
+
+// retain drops the statistics of the receivers which aren't in receivers
+// anymore, and adds the new ones.
+func (s *receiverStats) retain(receivers map[string][]Integration) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	byReceiver := make(map[string]*receiverCounters, len(receivers))
+	for name := range receivers {
+		c, ok := s.byReceiver[name]
+		if !ok {
+			c = &receiverCounters{}
+		}
+		byReceiver[name] = c
+	}
+	s.byReceiver = byReceiver
+}
+
+// list returns the statistics of the window ending at now, ordered by
+// receiver.
+func (s *receiverStats) list(now time.Time) []ReceiverStats {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	res := make([]ReceiverStats, 0, len(s.byReceiver))
+	for name, c := range s.byReceiver {
+		c.prune(now.Add(-ReceiverStatsWindow))
+		st := ReceiverStats{Receiver: name, LastSent: c.lastSent}
+		for _, b := range c.buckets {
+			st.Notifications += b.notifications
+			st.Failed += b.failed
+		}
+		res = append(res, st)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Receiver < res[j].Receiver })
+	return res
+}
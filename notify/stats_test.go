@@ -0,0 +1,57 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiverStats(t *testing.T) {
+	var (
+		s     = newReceiverStats()
+		start = time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+		err   = errors.New("unexpected status code 500")
+	)
+	s.retain(map[string][]Integration{"team-X": nil, "unused": nil})
+
+	s.record("team-X", start, nil)
+	s.record("team-X", start.Add(time.Minute), err)
+	s.record("team-X", start.Add(2*time.Hour), nil)
+	require.Equal(t, []ReceiverStats{
+		{Receiver: "team-X", Notifications: 3, Failed: 1, LastSent: start.Add(2 * time.Hour)},
+		{Receiver: "unused"},
+	}, s.list(start.Add(2*time.Hour)))
+
+	// The notifications older than the window aren't counted anymore, the
+	// time of the last one sent is kept.
+	require.Equal(t, []ReceiverStats{
+		{Receiver: "team-X", Notifications: 1, LastSent: start.Add(2 * time.Hour)},
+		{Receiver: "unused"},
+	}, s.list(start.Add(ReceiverStatsWindow+time.Hour)))
+	require.Equal(t, []ReceiverStats{
+		{Receiver: "team-X", LastSent: start.Add(2 * time.Hour)},
+		{Receiver: "unused"},
+	}, s.list(start.Add(ReceiverStatsWindow+3*time.Hour)))
+
+	// The statistics of the receivers which are kept are preserved.
+	s.record("team-X", start.Add(ReceiverStatsWindow+3*time.Hour), err)
+	s.retain(map[string][]Integration{"team-X": nil})
+	require.Equal(t, []ReceiverStats{
+		{Receiver: "team-X", Notifications: 1, Failed: 1, LastSent: start.Add(2 * time.Hour)},
+	}, s.list(start.Add(ReceiverStatsWindow+3*time.Hour)))
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// IntegrationStatus is the outcome of the last notification attempts of an
+// integration.
+type IntegrationStatus struct {
+	Receiver    string
+	Integration string
+	// LastSuccess and LastFailure are the times of the last successful and
+	// failed attempts, zero if none.
+	LastSuccess time.Time
+	LastFailure time.Time
+	// LastError is the error of the last failed attempt.
+	LastError string
+}
+
+// integrationStatuses keeps the status of the integrations by receiver and
+// integration.
+type integrationStatuses struct {
+	mtx      sync.RWMutex
+	statuses map[string]map[string]*IntegrationStatus
+}
+
+func newIntegrationStatuses() *integrationStatuses {
+	return &integrationStatuses{statuses: map[string]map[string]*IntegrationStatus{}}
+}
+
+// record records the outcome of a notification attempt.
+func (s *integrationStatuses) record(receiver, integration string, t time.Time, err error) {
+	if s == nil {
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	byIntegration, ok := s.statuses[receiver]
+	if !ok {
+		byIntegration = map[string]*IntegrationStatus{}
+		s.statuses[receiver] = byIntegration
+	}
+	st, ok := byIntegration[integration]
+	if !ok {
+		st = &IntegrationStatus{Receiver: receiver, Integration: integration}
+		byIntegration[integration] = st
+	}
+	if err != nil {
+		st.LastFailure, st.LastError = t, RedactURL(err).Error()
+		return
+	}
+	st.LastSuccess = t
+}
+
+// retain drops the status of the integrations which aren't in the receivers
+// anymore, and adds the new ones.
+func (s *integrationStatuses) retain(receivers map[string][]Integration) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	statuses := make(map[string]map[string]*IntegrationStatus, len(receivers))
+	for name, integrations := range receivers {
+		statuses[name] = make(map[string]*IntegrationStatus, len(integrations))
+		for _, i := range integrations {
+			st, ok := s.statuses[name][i.String()]
+			if !ok {
+				st = &IntegrationStatus{Receiver: name, Integration: i.String()}
+			}
+			statuses[name][i.String()] = st
+		}
+	}
+	s.statuses = statuses
+}
+
+// list returns the statuses ordered by receiver and integration.
+func (s *integrationStatuses) list() []IntegrationStatus {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	var res []IntegrationStatus
+	for _, byIntegration := range s.statuses {
+		for _, st := range byIntegration {
+			res = append(res, *st)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Receiver != res[j].Receiver {
+			return res[i].Receiver < res[j].Receiver
+		}
+		return res[i].Integration < res[j].Integration
+	})
+	return res
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationStatuses(t *testing.T) {
+	var (
+		s       = newIntegrationStatuses()
+		now     = time.Now()
+		webhook = NewIntegration(nil, sendResolved(false), "webhook", 0, "b")
+		slack   = NewIntegration(nil, sendResolved(false), "slack", 0, "a")
+	)
+	s.retain(map[string][]Integration{"b": {webhook}, "a": {slack}})
+	require.Equal(t, []IntegrationStatus{
+		{Receiver: "a", Integration: "slack[0]"},
+		{Receiver: "b", Integration: "webhook[0]"},
+	}, s.list())
+
+	s.record("a", "slack[0]", now, nil)
+	s.record("a", "slack[0]", now.Add(time.Minute), errors.New("unexpected status code 500"))
+	s.record("b", "webhook[0]", now, nil)
+	require.Equal(t, []IntegrationStatus{
+		{Receiver: "a", Integration: "slack[0]", LastSuccess: now, LastFailure: now.Add(time.Minute), LastError: "unexpected status code 500"},
+		{Receiver: "b", Integration: "webhook[0]", LastSuccess: now},
+	}, s.list())
+
+	// The statuses of the integrations which are kept are preserved.
+	s.retain(map[string][]Integration{"b": {webhook}})
+	require.Equal(t, []IntegrationStatus{
+		{Receiver: "b", Integration: "webhook[0]", LastSuccess: now},
+	}, s.list())
+
+	// Recording in a nil set of statuses is a no-op.
+	var none *integrationStatuses
+	none.record("a", "slack[0]", now, nil)
+}
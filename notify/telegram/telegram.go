@@ -14,14 +14,19 @@
 package telegram
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 
 	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
 	"gopkg.in/telebot.v3"
 
 	"github.com/prometheus/alertmanager/config"
@@ -35,11 +40,12 @@ const maxMessageLenRunes = 4096
 
 // Notifier implements a Notifier for telegram notifications.
 type Notifier struct {
-	conf    *config.TelegramConfig
-	tmpl    *template.Template
-	logger  *slog.Logger
-	client  *telebot.Bot
-	retrier *notify.Retrier
+	conf       *config.TelegramConfig
+	tmpl       *template.Template
+	logger     *slog.Logger
+	client     *telebot.Bot
+	httpClient *http.Client
+	retrier    *notify.Retrier
 }
 
 // New returns a new Telegram notification handler.
@@ -55,11 +61,12 @@ func New(conf *config.TelegramConfig, t *template.Template, l *slog.Logger, http
 	}
 
 	return &Notifier{
-		conf:    conf,
-		tmpl:    t,
-		logger:  l,
-		client:  client,
-		retrier: &notify.Retrier{},
+		conf:       conf,
+		tmpl:       t,
+		logger:     l,
+		client:     client,
+		httpClient: httpclient,
+		retrier:    &notify.Retrier{},
 	}, nil
 }
 
@@ -67,11 +74,11 @@ func (n *Notifier) Notify(ctx context.Context, alert ...*types.Alert) (bool, err
 	var (
 		err  error
 		data = notify.GetTemplateData(ctx, n.tmpl, alert, n.logger)
-		tmpl = notify.TmplText(n.tmpl, data, &err)
+		tmpl = notify.TmplText(ctx, n.tmpl, data, &err)
 	)
 
 	if n.conf.ParseMode == "HTML" {
-		tmpl = notify.TmplHTML(n.tmpl, data, &err)
+		tmpl = notify.TmplHTML(ctx, n.tmpl, data, &err)
 	}
 
 	key, ok := notify.GroupKey(ctx)
@@ -79,30 +86,165 @@ func (n *Notifier) Notify(ctx context.Context, alert ...*types.Alert) (bool, err
 		return false, fmt.Errorf("group key missing")
 	}
 
-	messageText, truncated := notify.TruncateInRunes(tmpl(n.conf.Message), maxMessageLenRunes)
-	if truncated {
-		n.logger.Warn("Truncated message", "alert", key, "max_runes", maxMessageLenRunes)
+	parts := splitMessage(tmpl(n.conf.Message), maxMessageLenRunes)
+	if len(parts) > 1 {
+		n.logger.Debug("Split message", "alert", key, "parts", len(parts), "max_runes", maxMessageLenRunes)
 	}
 
-	n.client.Token, err = n.getBotToken()
-	if err != nil {
-		return true, err
+	if _, ok := notify.PreviewFromContext(ctx); ok {
+		for _, chat := range n.chats() {
+			for _, part := range parts {
+				if err := n.preview(ctx, chat, part); err != nil {
+					return false, err
+				}
+			}
+		}
+		return false, nil
 	}
 
-	message, err := n.client.Send(telebot.ChatID(n.conf.ChatID), messageText, &telebot.SendOptions{
-		DisableNotification:   n.conf.DisableNotifications,
-		DisableWebPagePreview: true,
-		ThreadID:              n.conf.MessageThreadID,
-		ParseMode:             n.conf.ParseMode,
-	})
+	n.client.Token, err = n.getBotToken()
 	if err != nil {
 		return true, err
 	}
-	n.logger.Debug("Telegram message successfully published", "message_id", message.ID, "chat_id", message.Chat.ID)
 
+	rd, hasReceiverData := notify.ReceiverDataFromContext(ctx)
+	update := n.conf.UpdateOnResolve && hasReceiverData && data.Status == string(model.AlertResolved)
+
+	// A failure to notify one chat doesn't prevent notifying the others.
+	var errs []error
+	for _, chat := range n.chats() {
+		if err := n.send(chat, parts, rd, update); err != nil {
+			errs = append(errs, fmt.Errorf("chat %d: %w", chat, err))
+		}
+	}
+	if len(errs) > 0 {
+		return true, errors.Join(errs...)
+	}
 	return false, nil
 }
 
+// send sends the message parts to the chat. When update is true and the
+// receiver data holds the ID of the chat's message, the message is edited with
+// the first part instead.
+func (n *Notifier) send(chat int64, parts []string, rd *notify.ReceiverData, update bool) error {
+	key := n.messageIDKey(chat)
+	if update {
+		if id, ok := rd.Get(key); ok {
+			message, err := n.client.Edit(telebot.StoredMessage{MessageID: id, ChatID: chat}, parts[0], &telebot.SendOptions{
+				DisableWebPagePreview: true,
+				ParseMode:             n.conf.ParseMode,
+			})
+			if err != nil {
+				return err
+			}
+			n.logger.Debug("Telegram message successfully edited", "message_id", message.ID, "chat_id", message.Chat.ID)
+			parts = parts[1:]
+			rd = nil
+		}
+	}
+
+	for i, part := range parts {
+		message, err := n.client.Send(telebot.ChatID(chat), part, &telebot.SendOptions{
+			DisableNotification:   n.conf.DisableNotifications,
+			DisableWebPagePreview: true,
+			ThreadID:              n.conf.MessageThreadID,
+			ParseMode:             n.conf.ParseMode,
+		})
+		if err != nil {
+			return err
+		}
+		n.logger.Debug("Telegram message successfully published", "message_id", message.ID, "chat_id", message.Chat.ID)
+		if i == 0 && n.conf.UpdateOnResolve && rd != nil {
+			rd.Set(key, strconv.Itoa(message.ID))
+		}
+	}
+	return nil
+}
+
+// chats returns the IDs of the chats to notify.
+func (n *Notifier) chats() []int64 {
+	if len(n.conf.ChatIDs) > 0 {
+		return n.conf.ChatIDs
+	}
+	return []int64{n.conf.ChatID}
+}
+
+// messageIDKey returns the receiver data key holding the ID of the message
+// sent to the chat. The key of configurations with a single chat_id is kept
+// unchanged so that the messages sent before chat_ids existed are updated.
+func (n *Notifier) messageIDKey(chat int64) string {
+	if len(n.conf.ChatIDs) == 0 {
+		return "message_id"
+	}
+	return "message_id_" + strconv.FormatInt(chat, 10)
+}
+
+// splitMessage splits the text into parts of at most maxRunes runes. The
+// parts are split at the last line break that fits, if any, to avoid breaking
+// lines and the formatting they contain.
+func splitMessage(text string, maxRunes int) []string {
+	var parts []string
+	r := []rune(text)
+	for len(r) > maxRunes {
+		i := maxRunes
+		if j := lastIndexRune(r[:maxRunes], '\n'); j > 0 {
+			i = j + 1
+		}
+		parts = append(parts, string(r[:i]))
+		r = r[i:]
+	}
+	return append(parts, string(r))
+}
+
+func lastIndexRune(r []rune, c rune) int {
+	for i := len(r) - 1; i >= 0; i-- {
+		if r[i] == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// preview records the sendMessage request of the Bot API instead of sending
+// it.
+func (n *Notifier) preview(ctx context.Context, chat int64, text string) error {
+	params := map[string]string{
+		"chat_id": strconv.FormatInt(chat, 10),
+		"text":    text,
+	}
+	if n.conf.ParseMode != "" {
+		params["parse_mode"] = n.conf.ParseMode
+	}
+	if n.conf.MessageThreadID != 0 {
+		params["message_thread_id"] = strconv.Itoa(n.conf.MessageThreadID)
+	}
+	if n.conf.DisableNotifications {
+		params["disable_notification"] = "true"
+	}
+	params["disable_web_page_preview"] = "true"
+
+	b, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	token, err := n.getBotToken()
+	if err != nil {
+		return err
+	}
+	u := strings.TrimSuffix(n.conf.APIUrl.String(), "/") + "/bot" + token + "/sendMessage"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := notify.Do(ctx, n.httpClient, req)
+	if err != nil {
+		return err
+	}
+	notify.Drain(resp)
+	return nil
+}
+
 func createTelegramClient(apiURL, parseMode string, httpClient *http.Client) (*telebot.Bot, error) {
 	bot, err := telebot.NewBot(telebot.Settings{
 		URL:       apiURL,
@@ -22,6 +22,8 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -172,3 +174,138 @@ func TestTelegramNotify(t *testing.T) {
 		})
 	}
 }
+
+func TestTelegramUpdateOnResolve(t *testing.T) {
+	token := "secret"
+
+	var (
+		paths []string
+		reqs  []map[string]string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		req := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		reqs = append(reqs, req)
+		w.Write([]byte(`{"ok":true,"result":{"message_id":42,"chat":{"id":1234}}}`))
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	notifier, err := New(&config.TelegramConfig{
+		Message:         `{{ .Status }}`,
+		HTTPConfig:      &commoncfg.HTTPClientConfig{},
+		BotToken:        config.Secret(token),
+		ChatID:          1234,
+		APIUrl:          &config.URL{URL: u},
+		UpdateOnResolve: true,
+	}, test.CreateTmpl(t), promslog.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	rd := notify.NewReceiverData(nil)
+	ctx = notify.WithReceiverData(ctx, rd)
+
+	alert := &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"lbl1": "val1"},
+			StartsAt: time.Now().Add(-time.Hour),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+	_, err = notifier.Notify(ctx, alert)
+	require.NoError(t, err)
+	id, ok := rd.Get("message_id")
+	require.True(t, ok)
+	require.Equal(t, "42", id)
+
+	alert.EndsAt = time.Now().Add(-time.Minute)
+	_, err = notifier.Notify(ctx, alert)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"/bot" + token + "/sendMessage", "/bot" + token + "/editMessageText"}, paths)
+	require.Equal(t, "firing", reqs[0]["text"])
+	require.Equal(t, "resolved", reqs[1]["text"])
+	require.Equal(t, "42", reqs[1]["message_id"])
+	require.Equal(t, "1234", reqs[1]["chat_id"])
+}
+
+func TestTelegramChatIDsAndSplit(t *testing.T) {
+	token := "secret"
+
+	var reqs []map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := map[string]string{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		reqs = append(reqs, req)
+		if req["chat_id"] == "-100" {
+			w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"result":{"message_id":` + strconv.Itoa(len(reqs)) + `,"chat":{"id":` + req["chat_id"] + `}}}`))
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	notifier, err := New(&config.TelegramConfig{
+		Message:         `{{ range .Alerts }}{{ .Labels.lbl1 }}{{ "\n" }}{{ end }}`,
+		HTTPConfig:      &commoncfg.HTTPClientConfig{},
+		BotToken:        config.Secret(token),
+		ChatIDs:         []int64{1234, 5678},
+		APIUrl:          &config.URL{URL: u},
+		UpdateOnResolve: true,
+	}, test.CreateTmpl(t), promslog.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	rd := notify.NewReceiverData(nil)
+	ctx = notify.WithReceiverData(ctx, rd)
+
+	line := strings.Repeat("x", 3000)
+	alerts := []*types.Alert{
+		{Alert: model.Alert{Labels: model.LabelSet{"lbl1": model.LabelValue(line), "n": "1"}, StartsAt: time.Now(), EndsAt: time.Now().Add(time.Hour)}},
+		{Alert: model.Alert{Labels: model.LabelSet{"lbl1": model.LabelValue(line), "n": "2"}, StartsAt: time.Now(), EndsAt: time.Now().Add(time.Hour)}},
+	}
+	retry, err := notifier.Notify(ctx, alerts...)
+	require.NoError(t, err)
+	require.False(t, retry)
+
+	// The message is split at the line break and sent to both chats.
+	require.Len(t, reqs, 4)
+	for i, chat := range []string{"1234", "1234", "5678", "5678"} {
+		require.Equal(t, chat, reqs[i]["chat_id"])
+		require.Equal(t, line+"\n", reqs[i]["text"])
+	}
+	id, ok := rd.Get("message_id_1234")
+	require.True(t, ok)
+	require.Equal(t, "1", id)
+	id, ok = rd.Get("message_id_5678")
+	require.True(t, ok)
+	require.Equal(t, "3", id)
+
+	// A failing chat doesn't prevent notifying the others.
+	reqs = nil
+	notifier.conf.ChatIDs = []int64{-100, 1234}
+	notifier.conf.Message = "test"
+	retry, err = notifier.Notify(ctx, alerts...)
+	require.ErrorContains(t, err, "chat -100")
+	require.True(t, retry)
+	require.Len(t, reqs, 2)
+	require.Equal(t, "1234", reqs[1]["chat_id"])
+}
+
+func TestSplitMessage(t *testing.T) {
+	for _, tc := range []struct {
+		text string
+		exp  []string
+	}{
+		{text: "", exp: []string{""}},
+		{text: "abc", exp: []string{"abc"}},
+		{text: "abcdef", exp: []string{"abcd", "ef"}},
+		{text: "ab\ncdef", exp: []string{"ab\n", "cdef"}},
+		{text: "äöü\nß", exp: []string{"äöü\n", "ß"}},
+		{text: "\nabcdef", exp: []string{"\nabc", "def"}},
+	} {
+		require.Equal(t, tc.exp, splitMessage(tc.text, 4), tc.text)
+	}
+}
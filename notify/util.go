@@ -20,10 +20,12 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
 
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/common/version"
 
 	"github.com/prometheus/alertmanager/template"
@@ -74,7 +76,7 @@ func request(ctx context.Context, client *http.Client, method, url, bodyType str
 	if bodyType != "" {
 		req.Header.Set("Content-Type", bodyType)
 	}
-	return client.Do(req.WithContext(ctx))
+	return Do(ctx, client, req)
 }
 
 // Drain consumes and closes the response's body to make sure that the
@@ -130,28 +132,37 @@ func TruncateInBytes(s string, n int) (string, bool) {
 
 // TmplText is using monadic error handling in order to make string templating
 // less verbose. Use with care as the final error checking is easily missed.
-func TmplText(tmpl *template.Template, data *template.Data, err *error) func(string) string {
+func TmplText(ctx context.Context, tmpl *template.Template, data *template.Data, err *error) func(string) string {
 	return func(name string) (s string) {
 		if *err != nil {
 			return
 		}
-		s, *err = tmpl.ExecuteTextString(name, data)
+		s, *err = tmpl.ExecuteTextStringContext(ctx, name, data)
 		return s
 	}
 }
 
 // TmplHTML is using monadic error handling in order to make string templating
 // less verbose. Use with care as the final error checking is easily missed.
-func TmplHTML(tmpl *template.Template, data *template.Data, err *error) func(string) string {
+func TmplHTML(ctx context.Context, tmpl *template.Template, data *template.Data, err *error) func(string) string {
 	return func(name string) (s string) {
 		if *err != nil {
 			return
 		}
-		s, *err = tmpl.ExecuteHTMLString(name, data)
+		s, *err = tmpl.ExecuteHTMLStringContext(ctx, name, data)
 		return s
 	}
 }
 
+// TmplForStatus returns the resolved template if it is set and all alerts of
+// the notification are resolved. Otherwise it returns the default template.
+func TmplForStatus(data *template.Data, tmpl, resolved string) string {
+	if resolved != "" && data.Status == string(model.AlertResolved) {
+		return resolved
+	}
+	return tmpl
+}
+
 // Key is a string that can be hashed.
 type Key string
 
@@ -179,6 +190,9 @@ func (k Key) String() string {
 }
 
 // GetTemplateData creates the template data from the context and the alerts.
+// If the context holds annotations to collapse alerts by, the identical
+// alerts of the data are collapsed. If it holds an alert limit, the alerts of
+// the data are then truncated to it.
 func GetTemplateData(ctx context.Context, tmpl *template.Template, alerts []*types.Alert, l *slog.Logger) *template.Data {
 	recv, ok := ReceiverName(ctx)
 	if !ok {
@@ -188,7 +202,17 @@ func GetTemplateData(ctx context.Context, tmpl *template.Template, alerts []*typ
 	if !ok {
 		l.Error("Missing group labels")
 	}
-	return tmpl.Data(recv, groupLabels, alerts...)
+	data := tmpl.Data(recv, groupLabels, alerts...)
+	if d, ok := Delta(ctx); ok {
+		data.Delta = d
+	}
+	if annotations, ok := CollapseBy(ctx); ok {
+		data.Collapse(annotations)
+	}
+	if limit, ok := AlertLimit(ctx); ok {
+		data.Truncate(limit)
+	}
+	return data
 }
 
 func readAll(r io.Reader) string {
@@ -262,6 +286,10 @@ func (e *ErrorWithReason) Error() string {
 	return e.Err.Error()
 }
 
+func (e *ErrorWithReason) Unwrap() error {
+	return e.Err
+}
+
 // Reason is the failure reason.
 type Reason int
 
@@ -271,6 +299,18 @@ const (
 	ServerErrorReason
 	ContextCanceledReason
 	ContextDeadlineExceededReason
+	// AuthFailureReason is used when the credentials of the integration are
+	// rejected, for example because a token was revoked.
+	AuthFailureReason
+	// RateLimitedReason is used when the integration's API rate limits the
+	// notifications.
+	RateLimitedReason
+	// InvalidPayloadReason is used when the integration's API rejects the
+	// notification as malformed or too large.
+	InvalidPayloadReason
+	// NetworkErrorReason is used when the integration's API can't be
+	// reached.
+	NetworkErrorReason
 )
 
 func (s Reason) String() string {
@@ -285,16 +325,42 @@ func (s Reason) String() string {
 		return "contextCanceled"
 	case ContextDeadlineExceededReason:
 		return "contextDeadlineExceeded"
+	case AuthFailureReason:
+		return "authFailure"
+	case RateLimitedReason:
+		return "rateLimited"
+	case InvalidPayloadReason:
+		return "invalidPayload"
+	case NetworkErrorReason:
+		return "networkError"
 	default:
 		panic(fmt.Sprintf("unknown Reason: %d", s))
 	}
 }
 
 // possibleFailureReasonCategory is a list of possible failure reason.
-var possibleFailureReasonCategory = []string{DefaultReason.String(), ClientErrorReason.String(), ServerErrorReason.String(), ContextCanceledReason.String(), ContextDeadlineExceededReason.String()}
+var possibleFailureReasonCategory = []string{
+	DefaultReason.String(),
+	ClientErrorReason.String(),
+	ServerErrorReason.String(),
+	ContextCanceledReason.String(),
+	ContextDeadlineExceededReason.String(),
+	AuthFailureReason.String(),
+	RateLimitedReason.String(),
+	InvalidPayloadReason.String(),
+	NetworkErrorReason.String(),
+}
 
 // GetFailureReasonFromStatusCode returns the reason for the failure based on the status code provided.
 func GetFailureReasonFromStatusCode(statusCode int) Reason {
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return AuthFailureReason
+	case http.StatusTooManyRequests:
+		return RateLimitedReason
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+		return InvalidPayloadReason
+	}
 	if statusCode/100 == 4 {
 		return ClientErrorReason
 	}
@@ -304,3 +370,24 @@ func GetFailureReasonFromStatusCode(statusCode int) Reason {
 
 	return DefaultReason
 }
+
+// ReasonFromError returns the reason for the failure of a notification
+// attempt returning the error. Errors without a reason that are caused by the
+// network are classified as network errors.
+func ReasonFromError(err error) Reason {
+	var e *ErrorWithReason
+	if errors.As(err, &e) {
+		return e.Reason
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ContextCanceledReason
+	case errors.Is(err, context.DeadlineExceeded):
+		return ContextDeadlineExceededReason
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return NetworkErrorReason
+	}
+	return DefaultReason
+}
@@ -15,15 +15,24 @@ package notify
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"path"
 	"reflect"
 	"runtime"
 	"testing"
 
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
 	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
 )
 
 func TestTruncate(t *testing.T) {
@@ -130,6 +139,68 @@ func (b brokenReader) Read([]byte) (int, error) {
 	return 0, fmt.Errorf("some error")
 }
 
+func TestGetTemplateDataAlertLimit(t *testing.T) {
+	tmpl, err := template.FromGlobs([]string{})
+	require.NoError(t, err)
+	tmpl.ExternalURL, _ = url.Parse("http://am")
+
+	alerts := []*types.Alert{
+		{Alert: model.Alert{Labels: model.LabelSet{"alertname": "a"}}},
+		{Alert: model.Alert{Labels: model.LabelSet{"alertname": "b"}}},
+		{Alert: model.Alert{Labels: model.LabelSet{"alertname": "c"}}},
+	}
+	ctx := WithReceiverName(context.Background(), "name")
+	ctx = WithGroupLabels(ctx, model.LabelSet{})
+
+	data := GetTemplateData(ctx, tmpl, alerts, promslog.NewNopLogger())
+	require.Len(t, data.Alerts, 3)
+	require.Zero(t, data.TruncatedAlerts)
+
+	data = GetTemplateData(WithAlertLimit(ctx, 2), tmpl, alerts, promslog.NewNopLogger())
+	require.Len(t, data.Alerts, 2)
+	require.Equal(t, 1, data.TruncatedAlerts)
+}
+
+func TestGetTemplateDataCollapseBy(t *testing.T) {
+	tmpl, err := template.FromGlobs([]string{})
+	require.NoError(t, err)
+	tmpl.ExternalURL, _ = url.Parse("http://am")
+
+	alert := func(instance, summary string) *types.Alert {
+		return &types.Alert{Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": "DiskFull", "instance": model.LabelValue(instance)},
+			Annotations: model.LabelSet{"summary": model.LabelValue(summary)},
+		}}
+	}
+	alerts := []*types.Alert{
+		alert("a", "disk full"),
+		alert("b", "disk full"),
+		alert("c", "disk almost full"),
+	}
+	ctx := WithReceiverName(context.Background(), "name")
+	ctx = WithGroupLabels(ctx, model.LabelSet{})
+
+	data := GetTemplateData(WithCollapseBy(ctx, []string{"summary"}), tmpl, alerts, promslog.NewNopLogger())
+	require.Len(t, data.Alerts, 2)
+	require.Equal(t, 1, data.Alerts[0].Collapsed)
+	require.Zero(t, data.Alerts[1].Collapsed)
+
+	// Collapsing happens before truncating.
+	ctx = WithCollapseBy(WithAlertLimit(ctx, 1), []string{"summary"})
+	data = GetTemplateData(ctx, tmpl, alerts, promslog.NewNopLogger())
+	require.Len(t, data.Alerts, 1)
+	require.Equal(t, 1, data.TruncatedAlerts)
+}
+
+func TestTmplForStatus(t *testing.T) {
+	firing := &template.Data{Status: string(model.AlertFiring)}
+	resolved := &template.Data{Status: string(model.AlertResolved)}
+
+	require.Equal(t, "default", TmplForStatus(firing, "default", "resolved"))
+	require.Equal(t, "resolved", TmplForStatus(resolved, "default", "resolved"))
+	require.Equal(t, "default", TmplForStatus(resolved, "default", ""))
+}
+
 func TestRetrierCheck(t *testing.T) {
 	for _, tc := range []struct {
 		retrier Retrier
@@ -216,3 +287,33 @@ func TestRetrierCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFailureReasonFromStatusCode(t *testing.T) {
+	for code, reason := range map[int]Reason{
+		http.StatusBadRequest:            InvalidPayloadReason,
+		http.StatusUnauthorized:          AuthFailureReason,
+		http.StatusForbidden:             AuthFailureReason,
+		http.StatusNotFound:              ClientErrorReason,
+		http.StatusRequestEntityTooLarge: InvalidPayloadReason,
+		http.StatusTooManyRequests:       RateLimitedReason,
+		http.StatusBadGateway:            ServerErrorReason,
+		http.StatusTemporaryRedirect:     DefaultReason,
+	} {
+		require.Equal(t, reason, GetFailureReasonFromStatusCode(code), "status code %d", code)
+	}
+}
+
+func TestReasonFromError(t *testing.T) {
+	for _, tc := range []struct {
+		err    error
+		reason Reason
+	}{
+		{errors.New("failed"), DefaultReason},
+		{fmt.Errorf("wrapped: %w", NewErrorWithReason(AuthFailureReason, errors.New("token revoked"))), AuthFailureReason},
+		{&url.Error{Op: "Post", URL: "<redacted>", Err: context.Canceled}, ContextCanceledReason},
+		{&url.Error{Op: "Post", URL: "<redacted>", Err: context.DeadlineExceeded}, ContextDeadlineExceededReason},
+		{&url.Error{Op: "Post", URL: "<redacted>", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}, NetworkErrorReason},
+	} {
+		require.Equal(t, tc.reason, ReasonFromError(tc.err), tc.err.Error())
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package voicecall
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+)
+
+// twilio places calls with the Twilio Voice API.
+// See https://www.twilio.com/docs/voice/api/call-resource.
+type twilio struct {
+	conf    *config.TwilioConfig
+	client  *http.Client
+	retrier *notify.Retrier
+}
+
+type twilioCall struct {
+	SID    string `json:"sid"`
+	Status string `json:"status"`
+}
+
+func (t *twilio) callsURL() string {
+	return t.conf.APIURL.JoinPath("2010-04-01", "Accounts", t.conf.AccountSID, "Calls").String()
+}
+
+func (t *twilio) request(ctx context.Context, method, u string, form url.Values) (*http.Request, error) {
+	token, err := secret(t.conf.AuthToken, t.conf.AuthTokenFile)
+	if err != nil {
+		return nil, err
+	}
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.Header.Set("User-Agent", notify.UserAgentHeader)
+	req.SetBasicAuth(t.conf.AccountSID, token)
+	return req, nil
+}
+
+func (t *twilio) call(ctx context.Context, from, to, message string) (string, bool, error) {
+	var say strings.Builder
+	if err := xml.EscapeText(&say, []byte(message)); err != nil {
+		return "", false, err
+	}
+	form := url.Values{}
+	form.Set("From", from)
+	form.Set("To", to)
+	form.Set("Twiml", "<Response><Say>"+say.String()+"</Say></Response>")
+
+	req, err := t.request(ctx, http.MethodPost, t.callsURL()+".json", form)
+	if err != nil {
+		return "", false, err
+	}
+	var c twilioCall
+	if retry, err := do(ctx, t.client, t.retrier, req, &c); err != nil {
+		return "", retry, err
+	}
+	return c.SID, false, nil
+}
+
+func (t *twilio) active(ctx context.Context, id string) (bool, error) {
+	req, err := t.request(ctx, http.MethodGet, t.callsURL()+"/"+url.PathEscape(id)+".json", nil)
+	if err != nil {
+		return false, err
+	}
+	var c twilioCall
+	if _, err := do(ctx, t.client, t.retrier, req, &c); err != nil {
+		return false, err
+	}
+	switch c.Status {
+	case "queued", "ringing", "in-progress":
+		return true, nil
+	}
+	return false, nil
+}
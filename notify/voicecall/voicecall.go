@@ -0,0 +1,196 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package voicecall notifies via phone calls placed with a provider such as
+// Twilio or Vonage.
+package voicecall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	commoncfg "github.com/prometheus/common/config"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// maxMessageLenRunes is the maximum length of the text read out during a
+// call, long messages making calls unbearable.
+const maxMessageLenRunes = 1000
+
+// receiverDataCalls is the key of the receiver data holding the IDs of the
+// last calls placed for the group.
+const receiverDataCalls = "calls"
+
+// driver places calls with a provider.
+type driver interface {
+	// call places a call and returns its ID. On failure, it returns
+	// whether placing the call should be retried.
+	call(ctx context.Context, from, to, message string) (string, bool, error)
+	// active returns true if the call is still queued, ringing or in
+	// progress.
+	active(ctx context.Context, id string) (bool, error)
+}
+
+// Notifier implements a Notifier for phone calls.
+type Notifier struct {
+	conf   *config.VoiceCallConfig
+	tmpl   *template.Template
+	logger *slog.Logger
+	driver driver
+	now    func() time.Time
+}
+
+// New returns a new voice call notifier.
+func New(c *config.VoiceCallConfig, t *template.Template, l *slog.Logger, httpOpts ...commoncfg.HTTPClientOption) (*Notifier, error) {
+	client, err := commoncfg.NewClientFromConfig(*c.HTTPConfig, "voicecall", httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+	n := &Notifier{
+		conf:   c,
+		tmpl:   t,
+		logger: l,
+		now:    time.Now,
+	}
+	switch c.Provider {
+	case config.VoiceCallProviderTwilio:
+		n.driver = &twilio{conf: c.Twilio, client: client, retrier: &notify.Retrier{}}
+	case config.VoiceCallProviderVonage:
+		n.driver = &vonage{conf: c.Vonage, client: client, retrier: &notify.Retrier{}, now: time.Now}
+	default:
+		return nil, fmt.Errorf("unknown voice call provider %q", c.Provider)
+	}
+	return n, nil
+}
+
+// Notify implements the Notifier interface.
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	key, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		return false, err
+	}
+	logger := n.logger.With("group_key", key)
+
+	var firing int
+	for _, a := range as {
+		if !a.Resolved() {
+			firing++
+		}
+	}
+	if firing > 0 && firing < n.conf.MinFiringAlerts {
+		logger.Debug("Not calling, too few firing alerts", "firing", firing, "min_firing_alerts", n.conf.MinFiringAlerts)
+		return false, nil
+	}
+	now := n.now()
+	for _, qh := range n.conf.QuietHours {
+		if qh.ContainsTime(now) {
+			logger.Debug("Not calling during quiet hours")
+			return false, nil
+		}
+	}
+
+	// Don't call again while the previous calls are still going on.
+	rd, hasReceiverData := notify.ReceiverDataFromContext(ctx)
+	if hasReceiverData {
+		if prev, ok := rd.Get(receiverDataCalls); ok && prev != "" {
+			for _, id := range strings.Split(prev, ",") {
+				active, err := n.driver.active(ctx, id)
+				if err != nil {
+					logger.Warn("Failed to get the status of the previous call", "call", id, "err", err)
+					continue
+				}
+				if active {
+					logger.Debug("Not calling, the previous call is still active", "call", id)
+					return false, nil
+				}
+			}
+		}
+	}
+
+	data := notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
+	tmpl := notify.TmplText(ctx, n.tmpl, data, &err)
+	message := strings.TrimSpace(tmpl(n.conf.Message))
+	if err != nil {
+		return false, err
+	}
+	message, truncated := notify.TruncateInRunes(message, maxMessageLenRunes)
+	if truncated {
+		logger.Warn("Truncated message", "max_runes", maxMessageLenRunes)
+	}
+
+	to := n.conf.To
+	if n.conf.Rotation != nil {
+		to = []string{n.conf.Rotation.Participant(now)}
+	}
+	var (
+		calls []string
+		errs  []error
+		retry bool
+	)
+	for _, number := range to {
+		id, shouldRetry, err := n.driver.call(ctx, n.conf.From, number, message)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("call %s: %w", number, err))
+			retry = retry || shouldRetry
+			continue
+		}
+		logger.Info("Call placed", "call", id, "provider", n.conf.Provider)
+		calls = append(calls, id)
+	}
+	if hasReceiverData && len(calls) > 0 {
+		rd.Set(receiverDataCalls, strings.Join(calls, ","))
+	}
+	// Retry only if no one was called, not to call people twice.
+	return retry && len(calls) == 0, errors.Join(errs...)
+}
+
+// secret returns the inline secret or the content of the secret file.
+func secret(s config.Secret, file string) (string, error) {
+	if file == "" {
+		return string(s), nil
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("could not read %s: %w", file, err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// do sends the request and decodes the JSON response into v. On failure, it
+// returns whether the request should be retried.
+func do(ctx context.Context, client *http.Client, retrier *notify.Retrier, req *http.Request, v any) (bool, error) {
+	resp, err := notify.Do(ctx, client, req)
+	if err != nil {
+		return true, notify.RedactURL(err)
+	}
+	defer notify.Drain(resp)
+
+	if shouldRetry, err := retrier.Check(resp.StatusCode, resp.Body); err != nil {
+		return shouldRetry, notify.NewErrorWithReason(notify.GetFailureReasonFromStatusCode(resp.StatusCode), err)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return false, fmt.Errorf("decode response: %w", err)
+	}
+	return false, nil
+}
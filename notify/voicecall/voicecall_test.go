@@ -0,0 +1,299 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package voicecall
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/notify/test"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func mustURL(t *testing.T, s string) *config.URL {
+	u, err := url.Parse(s)
+	require.NoError(t, err)
+	return &config.URL{URL: u}
+}
+
+func firingAlert(name string) *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": model.LabelValue(name)},
+			Annotations: model.LabelSet{"summary": "Disk full"},
+			StartsAt:    time.Now(),
+		},
+	}
+}
+
+func notifyContext(rd *notify.ReceiverData) context.Context {
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	ctx = notify.WithReceiverName(ctx, "oncall")
+	ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": "DiskFull"})
+	return notify.WithReceiverData(ctx, rd)
+}
+
+// twilioServer fakes the Twilio Voice API, the calls having the given
+// status.
+type twilioServer struct {
+	mtx    sync.Mutex
+	calls  []url.Values
+	status string
+}
+
+func (s *twilioServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if user, pass, ok := r.BasicAuth(); !ok || user != "AC1" || pass != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/2010-04-01/Accounts/AC1/Calls.json":
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.calls = append(s.calls, r.PostForm)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(twilioCall{SID: "CA1", Status: "queued"})
+	case r.Method == http.MethodGet && r.URL.Path == "/2010-04-01/Accounts/AC1/Calls/CA1.json":
+		json.NewEncoder(w).Encode(twilioCall{SID: "CA1", Status: s.status})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *twilioServer) numCalls() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return len(s.calls)
+}
+
+func newTwilioNotifier(t *testing.T, u string, c *config.VoiceCallConfig) *Notifier {
+	c.Provider = config.VoiceCallProviderTwilio
+	c.Twilio = &config.TwilioConfig{
+		APIURL:     mustURL(t, u),
+		AccountSID: "AC1",
+		AuthToken:  "secret",
+	}
+	c.From = "+15550000000"
+	c.HTTPConfig = &commoncfg.HTTPClientConfig{}
+	if c.Message == "" {
+		c.Message = config.DefaultVoiceCallConfig.Message
+	}
+	n, err := New(c, test.CreateTmpl(t), promslog.NewNopLogger())
+	require.NoError(t, err)
+	return n
+}
+
+func TestTwilio(t *testing.T) {
+	srv := &twilioServer{status: "ringing"}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	n := newTwilioNotifier(t, ts.URL, &config.VoiceCallConfig{To: []string{"+15551111111"}, MinFiringAlerts: 1})
+	rd := notify.NewReceiverData(nil)
+	retry, err := n.Notify(notifyContext(rd), firingAlert("DiskFull"))
+	require.NoError(t, err)
+	require.False(t, retry)
+	require.Equal(t, 1, srv.numCalls())
+	require.Equal(t, "+15551111111", srv.calls[0].Get("To"))
+	require.Equal(t, "+15550000000", srv.calls[0].Get("From"))
+	require.Equal(t, "<Response><Say>1 firing alert for DiskFull. Disk full</Say></Response>", srv.calls[0].Get("Twiml"))
+	calls, ok := rd.Get(receiverDataCalls)
+	require.True(t, ok)
+	require.Equal(t, "CA1", calls)
+
+	// No call is placed while the previous one is ringing.
+	_, err = n.Notify(notifyContext(rd), firingAlert("DiskFull"))
+	require.NoError(t, err)
+	require.Equal(t, 1, srv.numCalls())
+
+	srv.mtx.Lock()
+	srv.status = "completed"
+	srv.mtx.Unlock()
+	_, err = n.Notify(notifyContext(rd), firingAlert("DiskFull"))
+	require.NoError(t, err)
+	require.Equal(t, 2, srv.numCalls())
+}
+
+func TestTwilioGating(t *testing.T) {
+	srv := &twilioServer{status: "completed"}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	// Too few firing alerts.
+	n := newTwilioNotifier(t, ts.URL, &config.VoiceCallConfig{To: []string{"+15551111111"}, MinFiringAlerts: 2})
+	_, err := n.Notify(notifyContext(notify.NewReceiverData(nil)), firingAlert("DiskFull"))
+	require.NoError(t, err)
+	require.Equal(t, 0, srv.numCalls())
+	_, err = n.Notify(notifyContext(notify.NewReceiverData(nil)), firingAlert("DiskFull"), firingAlert("NodeDown"))
+	require.NoError(t, err)
+	require.Equal(t, 1, srv.numCalls())
+
+	// Quiet hours, here all the time.
+	n = newTwilioNotifier(t, ts.URL, &config.VoiceCallConfig{
+		To:              []string{"+15551111111"},
+		MinFiringAlerts: 1,
+		QuietHours:      []timeinterval.TimeInterval{{}},
+	})
+	_, err = n.Notify(notifyContext(notify.NewReceiverData(nil)), firingAlert("DiskFull"))
+	require.NoError(t, err)
+	require.Equal(t, 1, srv.numCalls())
+}
+
+func TestTwilioRotation(t *testing.T) {
+	srv := &twilioServer{status: "completed"}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	n := newTwilioNotifier(t, ts.URL, &config.VoiceCallConfig{
+		MinFiringAlerts: 1,
+		Rotation: &config.VoiceCallRotation{
+			Start:        start,
+			Period:       model.Duration(7 * 24 * time.Hour),
+			Participants: []string{"+15551111111", "+15552222222"},
+		},
+	})
+	n.now = func() time.Time { return start.Add(8 * 24 * time.Hour) }
+	_, err := n.Notify(notifyContext(notify.NewReceiverData(nil)), firingAlert("DiskFull"))
+	require.NoError(t, err)
+	require.Equal(t, 1, srv.numCalls())
+	require.Equal(t, "+15552222222", srv.calls[0].Get("To"))
+}
+
+func TestTwilioPreview(t *testing.T) {
+	srv := &twilioServer{status: "completed"}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	n := newTwilioNotifier(t, ts.URL, &config.VoiceCallConfig{To: []string{"+15551111111"}, MinFiringAlerts: 1})
+	p := &notify.Preview{}
+	_, err := n.Notify(notify.WithPreview(notifyContext(notify.NewReceiverData(nil)), p), firingAlert("DiskFull"))
+	require.NoError(t, err)
+	require.Equal(t, 0, srv.numCalls())
+
+	// The call is recorded without the credentials.
+	requests := p.Requests()
+	require.Len(t, requests, 1)
+	require.Equal(t, http.MethodPost, requests[0].Method)
+	require.Equal(t, ts.URL+"/<redacted>", requests[0].URL)
+	require.Equal(t, "<redacted>", requests[0].Header.Get("Authorization"))
+	require.Contains(t, requests[0].Body, "To=%2B15551111111")
+}
+
+func TestTwilioRedactedURL(t *testing.T) {
+	ctx, u, fn := test.GetContextWithCancelingURL()
+	defer fn()
+
+	n := newTwilioNotifier(t, u.String(), &config.VoiceCallConfig{To: []string{"+15551111111"}, MinFiringAlerts: 1})
+	test.AssertNotifyLeaksNoSecret(ctx, t, n, "secret")
+}
+
+func TestVonage(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	var (
+		mtx  sync.Mutex
+		reqs []vonageCallRequest
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		// Verify the signature and the claims of the token.
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		require.True(t, ok)
+		parts := strings.Split(token, ".")
+		require.Len(t, parts, 3)
+		sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+		require.NoError(t, err)
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], sig))
+		b, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+		var claims map[string]any
+		require.NoError(t, json.Unmarshal(b, &claims))
+		require.Equal(t, "app", claims["application_id"])
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/calls":
+			var req vonageCallRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			reqs = append(reqs, req)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(vonageCall{UUID: "u1", Status: "started"})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/calls/u1":
+			json.NewEncoder(w).Encode(vonageCall{UUID: "u1", Status: "answered"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	n, err := New(&config.VoiceCallConfig{
+		HTTPConfig: &commoncfg.HTTPClientConfig{},
+		Provider:   config.VoiceCallProviderVonage,
+		Vonage: &config.VonageConfig{
+			APIURL:        mustURL(t, ts.URL),
+			ApplicationID: "app",
+			PrivateKey:    config.Secret(keyPEM),
+		},
+		From:            "15550000000",
+		To:              []string{"15551111111"},
+		Message:         config.DefaultVoiceCallConfig.Message,
+		MinFiringAlerts: 1,
+	}, test.CreateTmpl(t), promslog.NewNopLogger())
+	require.NoError(t, err)
+
+	rd := notify.NewReceiverData(nil)
+	_, err = n.Notify(notifyContext(rd), firingAlert("DiskFull"))
+	require.NoError(t, err)
+	require.Equal(t, []vonageCallRequest{{
+		To:   []vonageEndpoint{{Type: "phone", Number: "15551111111"}},
+		From: vonageEndpoint{Type: "phone", Number: "15550000000"},
+		NCCO: []vonageAction{{Action: "talk", Text: "1 firing alert for DiskFull. Disk full"}},
+	}}, reqs)
+
+	// No call is placed while the previous one is answered.
+	_, err = n.Notify(notifyContext(rd), firingAlert("DiskFull"))
+	require.NoError(t, err)
+	require.Len(t, reqs, 1)
+}
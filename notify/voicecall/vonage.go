@@ -0,0 +1,169 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package voicecall
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+)
+
+// vonage places calls with the Vonage Voice API.
+// See https://developer.vonage.com/en/api/voice.
+type vonage struct {
+	conf    *config.VonageConfig
+	client  *http.Client
+	retrier *notify.Retrier
+	now     func() time.Time
+}
+
+type vonageEndpoint struct {
+	Type   string `json:"type"`
+	Number string `json:"number"`
+}
+
+type vonageAction struct {
+	Action string `json:"action"`
+	Text   string `json:"text"`
+}
+
+type vonageCallRequest struct {
+	To   []vonageEndpoint `json:"to"`
+	From vonageEndpoint   `json:"from"`
+	NCCO []vonageAction   `json:"ncco"`
+}
+
+type vonageCall struct {
+	UUID   string `json:"uuid"`
+	Status string `json:"status"`
+}
+
+func (v *vonage) request(ctx context.Context, method, u string, body io.Reader) (*http.Request, error) {
+	token, err := v.token()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("User-Agent", notify.UserAgentHeader)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req, nil
+}
+
+// token returns the JSON Web Token authenticating the application, signed
+// with its private key.
+func (v *vonage) token() (string, error) {
+	key, err := secret(v.conf.PrivateKey, v.conf.PrivateKeyFile)
+	if err != nil {
+		return "", err
+	}
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return "", errors.New("invalid private key: no PEM data found")
+	}
+	var pk *rsa.PrivateKey
+	if k, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		var ok bool
+		if pk, ok = k.(*rsa.PrivateKey); !ok {
+			return "", errors.New("invalid private key: not an RSA key")
+		}
+	} else if pk, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+		return "", errors.New("invalid private key: not a PKCS #1 or PKCS #8 key")
+	}
+
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	now := v.now()
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"application_id": v.conf.ApplicationID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Minute).Unix(),
+		"jti":            hex.EncodeToString(jti),
+	})
+	if err != nil {
+		return "", err
+	}
+	enc := base64.RawURLEncoding
+	signed := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, pk, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signed + "." + enc.EncodeToString(sig), nil
+}
+
+func (v *vonage) call(ctx context.Context, from, to, message string) (string, bool, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(vonageCallRequest{
+		To:   []vonageEndpoint{{Type: "phone", Number: to}},
+		From: vonageEndpoint{Type: "phone", Number: from},
+		NCCO: []vonageAction{{Action: "talk", Text: message}},
+	}); err != nil {
+		return "", false, err
+	}
+
+	req, err := v.request(ctx, http.MethodPost, v.conf.APIURL.JoinPath("v1", "calls").String(), &buf)
+	if err != nil {
+		return "", false, err
+	}
+	var c vonageCall
+	if retry, err := do(ctx, v.client, v.retrier, req, &c); err != nil {
+		return "", retry, err
+	}
+	return c.UUID, false, nil
+}
+
+func (v *vonage) active(ctx context.Context, id string) (bool, error) {
+	req, err := v.request(ctx, http.MethodGet, v.conf.APIURL.JoinPath("v1", "calls", url.PathEscape(id)).String(), nil)
+	if err != nil {
+		return false, err
+	}
+	var c vonageCall
+	if _, err := do(ctx, v.client, v.retrier, req, &c); err != nil {
+		return false, err
+	}
+	switch c.Status {
+	case "started", "ringing", "answered":
+		return true, nil
+	}
+	return false, nil
+}
@@ -62,9 +62,14 @@ type Message struct {
 	*template.Data
 
 	// The protocol version.
-	Version         string `json:"version"`
-	GroupKey        string `json:"groupKey"`
+	Version  string `json:"version"`
+	GroupKey string `json:"groupKey"`
+	// TruncatedAlerts shadows the truncated alerts of the template data. It
+	// counts the alerts left out by both max_alerts and group_alert_limit.
 	TruncatedAlerts uint64 `json:"truncatedAlerts"`
+	// ClusterLabels shadows the cluster labels of the template data, which
+	// are only sent when enabled in the configuration.
+	ClusterLabels template.KV `json:"clusterLabels,omitempty"`
 }
 
 func truncateAlerts(maxAlerts uint64, alerts []*types.Alert) ([]*types.Alert, uint64) {
@@ -93,11 +98,17 @@ func (n *Notifier) Notify(ctx context.Context, alerts ...*types.Alert) (bool, er
 		Version:         "4",
 		Data:            data,
 		GroupKey:        groupKey.String(),
-		TruncatedAlerts: numTruncated,
+		TruncatedAlerts: numTruncated + uint64(data.TruncatedAlerts),
+	}
+	if n.conf.SendClusterLabels {
+		msg.ClusterLabels = data.ClusterLabels
 	}
 
 	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(msg); err != nil {
+	err = json.NewEncoder(&buf).Encode(msg)
+	// The data isn't used once encoded.
+	template.ReleaseData(data)
+	if err != nil {
 		return false, err
 	}
 
@@ -15,18 +15,34 @@ package webhook
 
 import (
 	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 
 	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/common/promslog"
 	"github.com/stretchr/testify/require"
 
 	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
 	"github.com/prometheus/alertmanager/notify/test"
 	"github.com/prometheus/alertmanager/types"
 )
@@ -100,6 +116,39 @@ func TestWebhookTruncateAlerts(t *testing.T) {
 	require.EqualValues(t, 0, numTruncated)
 }
 
+func TestWebhookTruncatedAlertsWithGroupAlertLimit(t *testing.T) {
+	var msg Message
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+	}))
+	defer srv.Close()
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	notifier, err := New(
+		&config.WebhookConfig{
+			URL:        &config.SecretURL{URL: u},
+			HTTPConfig: &commoncfg.HTTPClientConfig{},
+			MaxAlerts:  4,
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	alerts := make([]*types.Alert, 10)
+	for i := range alerts {
+		alerts[i] = &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": model.LabelValue(strconv.Itoa(i))}}}
+	}
+	// max_alerts leaves out 6 alerts and group_alert_limit 2 more.
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	ctx = notify.WithAlertLimit(ctx, 2)
+	_, err = notifier.Notify(ctx, alerts...)
+	require.NoError(t, err)
+	require.Len(t, msg.Alerts, 2)
+	require.Equal(t, uint64(8), msg.TruncatedAlerts)
+}
+
 func TestWebhookRedactedURL(t *testing.T) {
 	ctx, u, fn := test.GetContextWithCancelingURL()
 	defer fn()
@@ -139,3 +188,103 @@ func TestWebhookReadingURLFromFile(t *testing.T) {
 
 	test.AssertNotifyLeaksNoSecret(ctx, t, notifier, u.String())
 }
+
+func writeClientCert(t *testing.T, dir, cn string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "client.crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "client.key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+}
+
+func TestWebhookClusterLabels(t *testing.T) {
+	var msg map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg = nil
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+	}))
+	defer srv.Close()
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	tmpl := test.CreateTmpl(t)
+	tmpl.ClusterLabels = model.LabelSet{"cluster": "eu-1"}
+	ctx := notify.WithGroupKey(context.Background(), "1")
+
+	for _, send := range []bool{false, true} {
+		notifier, err := New(
+			&config.WebhookConfig{
+				URL:               &config.SecretURL{URL: u},
+				HTTPConfig:        &commoncfg.HTTPClientConfig{},
+				SendClusterLabels: send,
+			},
+			tmpl,
+			promslog.NewNopLogger(),
+		)
+		require.NoError(t, err)
+
+		_, err = notifier.Notify(ctx, &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "a"}}})
+		require.NoError(t, err)
+		if send {
+			require.Equal(t, map[string]any{"cluster": "eu-1"}, msg["clusterLabels"])
+		} else {
+			require.NotContains(t, msg, "clusterLabels")
+		}
+	}
+}
+
+func TestWebhookClientCertificateRotation(t *testing.T) {
+	var cn string
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cn = r.TLS.PeerCertificates[0].Subject.CommonName
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0o600))
+	writeClientCert(t, dir, "first")
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	notifier, err := New(
+		&config.WebhookConfig{
+			URL: &config.SecretURL{URL: u},
+			HTTPConfig: &commoncfg.HTTPClientConfig{
+				TLSConfig: commoncfg.TLSConfig{
+					CAFile:   caFile,
+					CertFile: filepath.Join(dir, "client.crt"),
+					KeyFile:  filepath.Join(dir, "client.key"),
+				},
+			},
+		},
+		test.CreateTmpl(t),
+		promslog.NewNopLogger(),
+	)
+	require.NoError(t, err)
+
+	ctx := notify.WithGroupKey(context.Background(), "1")
+	_, err = notifier.Notify(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "first", cn)
+
+	// The rotated certificate is used without recreating the notifier,
+	// including over kept-alive connections.
+	writeClientCert(t, dir, "second")
+	_, err = notifier.Notify(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "second", cn)
+}
@@ -89,13 +89,13 @@ func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error)
 	n.logger.Debug("extracted group key", "key", key)
 	data := notify.GetTemplateData(ctx, n.tmpl, as, n.logger)
 
-	tmpl := notify.TmplText(n.tmpl, data, &err)
+	tmpl := notify.TmplText(ctx, n.tmpl, data, &err)
 	if err != nil {
 		return false, err
 	}
 
-	// Refresh AccessToken over 2 hours
-	if n.accessToken == "" || time.Since(n.accessTokenAt) > 2*time.Hour {
+	// Refresh AccessToken over 2 hours. Previews don't request an AccessToken.
+	if _, preview := notify.PreviewFromContext(ctx); !preview && (n.accessToken == "" || time.Since(n.accessTokenAt) > 2*time.Hour) {
 		parameters := url.Values{}
 		parameters.Add("corpsecret", tmpl(string(n.conf.APISecret)))
 		parameters.Add("corpid", tmpl(string(n.conf.CorpID)))
@@ -43,6 +43,15 @@ type Alerts struct {
 
 	callback AlertStoreCallback
 
+	// dedupWindow is how long identical re-posts of an alert are ignored.
+	dedupWindow  time.Duration
+	deduplicated prometheus.Counter
+
+	// resolved holds the garbage collected alerts which resolved less than
+	// resolvedRetention ago.
+	resolved          map[model.Fingerprint]*types.Alert
+	resolvedRetention time.Duration
+
 	logger *slog.Logger
 }
 
@@ -82,6 +91,7 @@ func (a *Alerts) registerMetrics(r prometheus.Registerer) {
 	r.MustRegister(newMemAlertByStatus(types.AlertStateActive))
 	r.MustRegister(newMemAlertByStatus(types.AlertStateSuppressed))
 	r.MustRegister(newMemAlertByStatus(types.AlertStateUnprocessed))
+	r.MustRegister(a.deduplicated)
 }
 
 // NewAlerts returns a new alert provider.
@@ -96,9 +106,14 @@ func NewAlerts(ctx context.Context, m types.AlertMarker, intervalGC time.Duratio
 		alerts:    store.NewAlerts(),
 		cancel:    cancel,
 		listeners: map[int]listeningAlerts{},
+		resolved:  map[model.Fingerprint]*types.Alert{},
 		next:      0,
 		logger:    l.With("component", "provider"),
 		callback:  alertCallback,
+		deduplicated: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_alerts_deduplicated_total",
+			Help: "Number of received alerts ignored because they were identical to an alert received within the deduplication window.",
+		}),
 	}
 
 	if r != nil {
@@ -134,6 +149,15 @@ func (a *Alerts) gc() {
 		// held in memory in aggregation groups redundantly.
 		a.marker.Delete(alert.Fingerprint())
 		a.callback.PostDelete(&alert)
+		if a.resolvedRetention > 0 {
+			a.resolved[alert.Fingerprint()] = &alert
+		}
+	}
+	cutoff := time.Now().Add(-a.resolvedRetention)
+	for fp, alert := range a.resolved {
+		if alert.EndsAt.Before(cutoff) {
+			delete(a.resolved, fp)
+		}
 	}
 
 	for i, l := range a.listeners {
@@ -215,6 +239,67 @@ func (a *Alerts) Get(fp model.Fingerprint) (*types.Alert, error) {
 	return a.alerts.Get(fp)
 }
 
+// SetDedupWindow sets how long identical re-posts of an alert are ignored. An
+// alert is identical if it has the same fingerprint, end time and annotations
+// as the stored alert, and doesn't start earlier. This avoids processing the
+// same alert sent by each replica of an HA pair of Prometheus servers. A zero
+// window disables deduplication.
+func (a *Alerts) SetDedupWindow(d time.Duration) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.dedupWindow = d
+}
+
+// SetResolvedRetention sets how long resolved alerts are kept in memory after
+// they are garbage collected, to be returned by GetResolved. A zero retention
+// drops them with the garbage collection.
+func (a *Alerts) SetResolvedRetention(d time.Duration) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.resolvedRetention = d
+}
+
+// GetResolved returns the alerts which resolved at or after since and before
+// now, whether they are still in the store or were garbage collected less
+// than the resolved retention ago. A resolved alert which fired again is
+// returned as it was when it resolved.
+func (a *Alerts) GetResolved(since, now time.Time) []*types.Alert {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	var res []*types.Alert
+	seen := map[model.Fingerprint]struct{}{}
+	for _, alert := range a.alerts.List() {
+		if alert.ResolvedAt(now) && !alert.EndsAt.Before(since) {
+			res = append(res, alert)
+			seen[alert.Fingerprint()] = struct{}{}
+		}
+	}
+	for fp, alert := range a.resolved {
+		if _, ok := seen[fp]; ok || alert.EndsAt.Before(since) {
+			continue
+		}
+		res = append(res, alert)
+	}
+	return res
+}
+
+// duplicate returns true if alert is an identical re-post of old within the
+// deduplication window.
+func (a *Alerts) duplicate(old, alert *types.Alert) bool {
+	if a.dedupWindow <= 0 {
+		return false
+	}
+	if d := alert.UpdatedAt.Sub(old.UpdatedAt); d < 0 || d >= a.dedupWindow {
+		return false
+	}
+	return alert.EndsAt.Equal(old.EndsAt) &&
+		!alert.StartsAt.Before(old.StartsAt) &&
+		alert.Annotations.Equal(old.Annotations) &&
+		// An alert sent by a new origin isn't a duplicate.
+		len(types.MergeOrigins(old.Origins, alert.Origins)) == len(old.Origins)
+}
+
 // Put adds the given alert to the set.
 func (a *Alerts) Put(alerts ...*types.Alert) error {
 	a.mtx.Lock()
@@ -230,6 +315,11 @@ func (a *Alerts) Put(alerts ...*types.Alert) error {
 		if old, err := a.alerts.Get(fp); err == nil {
 			existing = true
 
+			if a.duplicate(old, alert) {
+				a.deduplicated.Inc()
+				continue
+			}
+
 			// Merge alerts if there is an overlap in activity range.
 			if (alert.EndsAt.After(old.StartsAt) && alert.EndsAt.Before(old.EndsAt)) ||
 				(alert.StartsAt.After(old.StartsAt) && alert.StartsAt.Before(old.EndsAt)) {
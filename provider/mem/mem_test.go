@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"sync"
 	"testing"
@@ -25,6 +26,7 @@ import (
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/common/promslog"
 	"github.com/stretchr/testify/require"
@@ -218,6 +220,52 @@ func TestAlertsPut(t *testing.T) {
 	}
 }
 
+func TestAlertsPutDedupWindow(t *testing.T) {
+	marker := types.NewMarker(prometheus.NewRegistry())
+	alerts, err := NewAlerts(context.Background(), marker, 30*time.Minute, noopCallback{}, promslog.NewNopLogger(), nil)
+	require.NoError(t, err)
+	alerts.SetDedupWindow(time.Minute)
+
+	it := alerts.Subscribe()
+	defer it.Close()
+
+	repost := func(d time.Duration, mod func(*types.Alert)) *types.Alert {
+		a := *alert1
+		a.UpdatedAt = alert1.UpdatedAt.Add(d)
+		if mod != nil {
+			mod(&a)
+		}
+		return &a
+	}
+
+	require.NoError(t, alerts.Put(alert1))
+	// Identical re-post within the window.
+	require.NoError(t, alerts.Put(repost(10*time.Second, nil)))
+	require.Equal(t, 1.0, testutil.ToFloat64(alerts.deduplicated))
+	// Changed annotations.
+	require.NoError(t, alerts.Put(repost(20*time.Second, func(a *types.Alert) {
+		a.Annotations = model.LabelSet{"foo": "changed"}
+	})))
+	// Identical re-post after the window.
+	require.NoError(t, alerts.Put(repost(2*time.Minute, func(a *types.Alert) {
+		a.Annotations = model.LabelSet{"foo": "changed"}
+	})))
+	require.Equal(t, 1.0, testutil.ToFloat64(alerts.deduplicated))
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-it.Next():
+		case <-time.After(time.Second):
+			t.Fatalf("expected 3 alerts to be sent to subscribers, got %d", i)
+		}
+	}
+	select {
+	case a := <-it.Next():
+		t.Fatalf("unexpected alert %v", a)
+	default:
+	}
+}
+
 func TestAlertsSubscribe(t *testing.T) {
 	marker := types.NewMarker(prometheus.NewRegistry())
 
@@ -621,3 +669,60 @@ func TestAlertsConcurrently(t *testing.T) {
 	}, 2*expire, expire)
 	require.Equal(t, int32(0), callback.alerts.Load())
 }
+
+func TestAlertsGetResolved(t *testing.T) {
+	marker := types.NewMarker(prometheus.NewRegistry())
+	alerts, err := NewAlerts(context.Background(), marker, time.Hour, noopCallback{}, promslog.NewNopLogger(), nil)
+	require.NoError(t, err)
+	defer alerts.Close()
+	alerts.SetResolvedRetention(time.Hour)
+
+	now := time.Now()
+	newAlert := func(name string, endsAt time.Time) *types.Alert {
+		return &types.Alert{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": model.LabelValue(name)},
+				StartsAt: now.Add(-3 * time.Hour),
+				EndsAt:   endsAt,
+			},
+			UpdatedAt: now,
+		}
+	}
+	firing := newAlert("firing", now.Add(time.Hour))
+	recent := newAlert("recent", now.Add(-10*time.Minute))
+	older := newAlert("older", now.Add(-30*time.Minute))
+	expired := newAlert("expired", now.Add(-2*time.Hour))
+	require.NoError(t, alerts.Put(firing, recent, older, expired))
+
+	names := func(as []*types.Alert) []string {
+		var res []string
+		for _, a := range as {
+			res = append(res, string(a.Labels["alertname"]))
+		}
+		sort.Strings(res)
+		return res
+	}
+
+	// Resolved alerts are returned before the GC...
+	require.Equal(t, []string{"expired", "older", "recent"}, names(alerts.GetResolved(time.Time{}, now)))
+
+	// ...and after it within the retention.
+	alerts.gc()
+	_, err = alerts.Get(recent.Fingerprint())
+	require.Equal(t, store.ErrNotFound, err)
+	require.Equal(t, []string{"older", "recent"}, names(alerts.GetResolved(time.Time{}, now)))
+	require.Equal(t, []string{"recent"}, names(alerts.GetResolved(now.Add(-20*time.Minute), now)))
+
+	// Alerts which fire again are returned as they resolved.
+	refiring := newAlert("recent", now.Add(time.Hour))
+	refiring.StartsAt = now
+	require.NoError(t, alerts.Put(refiring))
+	resolved := alerts.GetResolved(now.Add(-20*time.Minute), now)
+	require.Len(t, resolved, 1)
+	require.Equal(t, recent.EndsAt, resolved[0].EndsAt)
+
+	// Without retention, they are dropped by the GC.
+	alerts.SetResolvedRetention(0)
+	alerts.gc()
+	require.Empty(t, alerts.GetResolved(time.Time{}, now))
+}
@@ -14,6 +14,8 @@
 package provider
 
 import (
+	"time"
+
 	"fmt"
 
 	"github.com/prometheus/common/model"
@@ -84,3 +86,11 @@ type Alerts interface {
 	// Put adds the given set of alerts to the set.
 	Put(...*types.Alert) error
 }
+
+// ResolvedAlerts is implemented by the alert providers which keep the alerts
+// for a while after they resolved.
+type ResolvedAlerts interface {
+	// GetResolved returns the alerts kept in memory which resolved at or
+	// after since and before now.
+	GetResolved(since, now time.Time) []*types.Alert
+}
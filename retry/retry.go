@@ -0,0 +1,300 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retry persists the notifications which failed and are being
+// retried, so that they can be resumed after a restart.
+package retry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coder/quartz"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/promslog"
+
+	"github.com/prometheus/alertmanager/encryption"
+	"github.com/prometheus/alertmanager/nflog/nflogpb"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// Entry is a notification of an aggregation group to an integration which
+// is being retried.
+type Entry struct {
+	GroupKey    string `json:"groupKey"`
+	Receiver    string `json:"receiver"`
+	Integration string `json:"integration"`
+	Idx         uint32 `json:"idx"`
+	// FailedAt is the time of the first failed attempt.
+	FailedAt time.Time `json:"failedAt"`
+	// Attempts is the number of failed attempts.
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"nextAttempt"`
+	// Alerts are the alerts of the notification.
+	Alerts []*types.Alert `json:"alerts"`
+}
+
+func (e *Entry) key() string {
+	return stateKey(e.GroupKey, &nflogpb.Receiver{GroupName: e.Receiver, Integration: e.Integration, Idx: e.Idx})
+}
+
+// stateKey returns the key of the entry of the group key and receiver.
+func stateKey(gkey string, r *nflogpb.Receiver) string {
+	return fmt.Sprintf("%s:%s/%s/%d", gkey, r.GroupName, r.Integration, r.Idx)
+}
+
+// Options configures a Queue.
+type Options struct {
+	SnapshotFile string
+
+	// Retention is how long a notification is retried for after its first
+	// failed attempt before it is dropped.
+	Retention time.Duration
+
+	// Cipher encrypts snapshots if set. Unencrypted snapshots can still be
+	// loaded.
+	Cipher *encryption.Cipher
+
+	Logger  *slog.Logger
+	Metrics prometheus.Registerer
+}
+
+// Queue holds the notifications which are being retried.
+type Queue struct {
+	clock     quartz.Clock
+	retention time.Duration
+	cipher    *encryption.Cipher
+	logger    *slog.Logger
+
+	mtx sync.RWMutex
+	st  map[string]*Entry
+}
+
+// New returns a new Queue. The snapshot file is loaded if it exists, without
+// the entries past their retention.
+func New(o Options) (*Queue, error) {
+	q := &Queue{
+		clock:     quartz.NewReal(),
+		retention: o.Retention,
+		cipher:    o.Cipher,
+		logger:    o.Logger,
+		st:        map[string]*Entry{},
+	}
+	if q.logger == nil {
+		q.logger = promslog.NewNopLogger()
+	}
+	if o.Metrics != nil {
+		o.Metrics.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "alertmanager_notification_retries_pending",
+			Help: "Number of notifications which failed and are being retried.",
+		}, func() float64 {
+			q.mtx.RLock()
+			defer q.mtx.RUnlock()
+			return float64(len(q.st))
+		}))
+	}
+
+	if o.SnapshotFile != "" {
+		f, err := os.Open(o.SnapshotFile)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				return nil, err
+			}
+			q.logger.Debug("retry queue snapshot file doesn't exist", "err", err)
+			return q, nil
+		}
+		defer f.Close()
+		if err := q.loadSnapshot(f); err != nil {
+			return q, err
+		}
+		q.GC()
+	}
+	return q, nil
+}
+
+// Get returns the number of failed attempts and the time of the next attempt
+// of the notification of the group to the receiver.
+func (q *Queue) Get(gkey string, r *nflogpb.Receiver) (int, time.Time, bool) {
+	q.mtx.RLock()
+	defer q.mtx.RUnlock()
+	e, ok := q.st[stateKey(gkey, r)]
+	if !ok {
+		return 0, time.Time{}, false
+	}
+	return e.Attempts, e.NextAttempt, true
+}
+
+// Set records a failed attempt of the notification of the alerts of the
+// group to the receiver.
+func (q *Queue) Set(gkey string, r *nflogpb.Receiver, alerts []*types.Alert, attempts int, next time.Time) {
+	now := q.clock.Now()
+	key := stateKey(gkey, r)
+
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	failedAt := now
+	if prev, ok := q.st[key]; ok {
+		failedAt = prev.FailedAt
+	}
+	q.st[key] = &Entry{
+		GroupKey:    gkey,
+		Receiver:    r.GroupName,
+		Integration: r.Integration,
+		Idx:         r.Idx,
+		FailedAt:    failedAt,
+		Attempts:    attempts,
+		NextAttempt: next,
+		Alerts:      alerts,
+	}
+}
+
+// Delete removes the notification of the group to the receiver once it
+// succeeded or can't be retried.
+func (q *Queue) Delete(gkey string, r *nflogpb.Receiver) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	delete(q.st, stateKey(gkey, r))
+}
+
+// Pending returns the notifications being retried ordered by their next
+// attempt.
+func (q *Queue) Pending() []*Entry {
+	q.mtx.RLock()
+	defer q.mtx.RUnlock()
+	entries := make([]*Entry, 0, len(q.st))
+	for _, e := range q.st {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].NextAttempt.Before(entries[j].NextAttempt)
+	})
+	return entries
+}
+
+// GC removes the notifications which are retried for longer than the
+// retention and returns how many were removed.
+func (q *Queue) GC() int {
+	now := q.clock.Now()
+
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	var n int
+	for k, e := range q.st {
+		if !e.FailedAt.Add(q.retention).After(now) {
+			delete(q.st, k)
+			n++
+		}
+	}
+	return n
+}
+
+// Maintenance garbage collects the queue at the given interval and writes a
+// snapshot to snapf, if set, until stopc is closed. A last snapshot is written
+// when stopping.
+func (q *Queue) Maintenance(interval time.Duration, snapf string, stopc <-chan struct{}) {
+	t := q.clock.NewTicker(interval)
+	defer t.Stop()
+
+	maintenance := func() error {
+		if n := q.GC(); n > 0 {
+			q.logger.Debug("Garbage collected expired retries", "count", n)
+		}
+		if snapf == "" {
+			return nil
+		}
+		return q.snapshotFile(snapf)
+	}
+
+	for {
+		select {
+		case <-stopc:
+			if err := maintenance(); err != nil {
+				q.logger.Error("Creating shutdown snapshot failed", "err", err)
+			}
+			return
+		case <-t.C:
+			if err := maintenance(); err != nil {
+				q.logger.Error("Running maintenance failed", "err", err)
+			}
+		}
+	}
+}
+
+// Snapshot writes the queue to w.
+func (q *Queue) Snapshot(w io.Writer) (int64, error) {
+	q.mtx.RLock()
+	entries := make([]*Entry, 0, len(q.st))
+	for _, e := range q.st {
+		entries = append(entries, e)
+	}
+	b, err := json.Marshal(entries)
+	q.mtx.RUnlock()
+	if err != nil {
+		return 0, err
+	}
+	if b, err = encryption.Seal(q.cipher, b); err != nil {
+		return 0, err
+	}
+	return io.Copy(w, bytes.NewReader(b))
+}
+
+// snapshotFile atomically replaces the file with a snapshot of the queue.
+func (q *Queue) snapshotFile(filename string) error {
+	tmp := fmt.Sprintf("%s.%x", filename, uint64(rand.Int63()))
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := q.Snapshot(f); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, filename)
+}
+
+func (q *Queue) loadSnapshot(r io.Reader) error {
+	r, err := encryption.Open(q.cipher, r)
+	if err != nil {
+		return err
+	}
+	var entries []*Entry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	for _, e := range entries {
+		q.st[e.key()] = e
+	}
+	return nil
+}
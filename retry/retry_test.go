@@ -0,0 +1,130 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coder/quartz"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/encryption"
+	"github.com/prometheus/alertmanager/nflog/nflogpb"
+	"github.com/prometheus/alertmanager/types"
+)
+
+var testReceiver = &nflogpb.Receiver{GroupName: "team-X", Integration: "webhook", Idx: 1}
+
+func testAlerts(now time.Time) []*types.Alert {
+	return []*types.Alert{
+		{
+			Alert: model.Alert{
+				Labels:   model.LabelSet{"alertname": "test"},
+				StartsAt: now.Add(-time.Minute).UTC(),
+			},
+			UpdatedAt: now.UTC(),
+		},
+	}
+}
+
+func TestQueue(t *testing.T) {
+	clock := quartz.NewMock(t)
+	q, err := New(Options{Retention: time.Hour})
+	require.NoError(t, err)
+	q.clock = clock
+
+	_, _, ok := q.Get("{}:{}", testReceiver)
+	require.False(t, ok)
+
+	alerts := testAlerts(clock.Now())
+	q.Set("{}:{}", testReceiver, alerts, 1, clock.Now().Add(time.Second))
+	clock.Advance(30 * time.Minute)
+	q.Set("{}:{}", testReceiver, alerts, 2, clock.Now().Add(2*time.Second))
+
+	attempts, next, ok := q.Get("{}:{}", testReceiver)
+	require.True(t, ok)
+	require.Equal(t, 2, attempts)
+	require.Equal(t, clock.Now().Add(2*time.Second), next)
+	_, _, ok = q.Get("{}:{}", &nflogpb.Receiver{GroupName: "team-X", Integration: "webhook", Idx: 0})
+	require.False(t, ok)
+
+	// The retention starts with the first failed attempt.
+	require.Equal(t, 0, q.GC())
+	clock.Advance(30 * time.Minute)
+	require.Equal(t, 1, q.GC())
+	require.Empty(t, q.Pending())
+
+	q.Set("{}:{}", testReceiver, alerts, 1, clock.Now())
+	q.Delete("{}:{}", testReceiver)
+	require.Empty(t, q.Pending())
+}
+
+func TestQueueSnapshot(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		cipher bool
+	}{
+		{name: "plaintext"},
+		{name: "encrypted", cipher: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var c *encryption.Cipher
+			if tc.cipher {
+				var err error
+				c, err = encryption.New(make([]byte, 16))
+				require.NoError(t, err)
+			}
+			f := filepath.Join(t.TempDir(), "retries")
+
+			q, err := New(Options{SnapshotFile: f, Retention: time.Hour, Cipher: c})
+			require.NoError(t, err)
+
+			now := time.Now()
+			alerts := testAlerts(now)
+			q.Set("{}:{a=\"1\"}", testReceiver, alerts, 3, now.Add(time.Minute))
+			q.Set("{}:{a=\"2\"}", testReceiver, alerts, 1, now.Add(time.Second))
+			require.NoError(t, q.snapshotFile(f))
+
+			q2, err := New(Options{SnapshotFile: f, Retention: time.Hour, Cipher: c})
+			require.NoError(t, err)
+			pending := q2.Pending()
+			require.Len(t, pending, 2)
+			require.Equal(t, "{}:{a=\"2\"}", pending[0].GroupKey)
+			require.Equal(t, "{}:{a=\"1\"}", pending[1].GroupKey)
+			require.Equal(t, 3, pending[1].Attempts)
+			require.Equal(t, "team-X", pending[1].Receiver)
+			require.Equal(t, "webhook", pending[1].Integration)
+			require.Equal(t, uint32(1), pending[1].Idx)
+			require.Equal(t, alerts, pending[1].Alerts)
+
+			// Entries past the retention aren't loaded.
+			q3, err := New(Options{SnapshotFile: f, Retention: time.Nanosecond, Cipher: c})
+			require.NoError(t, err)
+			require.Empty(t, q3.Pending())
+		})
+	}
+}
+
+func TestQueueEmptySnapshot(t *testing.T) {
+	f := filepath.Join(t.TempDir(), "retries")
+	require.NoError(t, os.WriteFile(f, nil, 0o644))
+
+	q, err := New(Options{SnapshotFile: f, Retention: time.Hour})
+	require.NoError(t, err)
+	require.Empty(t, q.Pending())
+}
@@ -0,0 +1,345 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package selfmonitor generates alerts about the health of Alertmanager
+// itself. The conditions are evaluated periodically from the metrics of
+// Alertmanager and the alerts are sent through the normal notification
+// pipeline, labeled with source="alertmanager".
+package selfmonitor
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coder/quartz"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/provider"
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	// SourceLabel is the label set to SourceValue on the alerts generated by
+	// the monitor.
+	SourceLabel = "source"
+	// SourceValue is the value of SourceLabel.
+	SourceValue = "alertmanager"
+
+	// The names of the generated alerts.
+	ConfigReloadFailed          = "AlertmanagerConfigReloadFailed"
+	NotificationFailureRateHigh = "AlertmanagerNotificationFailureRateHigh"
+	ClusterPartitionSuspected   = "AlertmanagerClusterPartitionSuspected"
+	SnapshotWriteFailed         = "AlertmanagerSnapshotWriteFailed"
+)
+
+// condition is an alert generated by a check.
+type condition struct {
+	labels      model.LabelSet
+	summary     string
+	description string
+}
+
+// sample is a metric value, identified by its name and labels.
+type sample struct {
+	labels model.LabelSet
+	value  float64
+}
+
+// Monitor evaluates the health of Alertmanager from its metrics and puts
+// alerts for the failing conditions.
+type Monitor struct {
+	clock    quartz.Clock
+	gatherer prometheus.Gatherer
+	alerts   provider.Alerts
+	labels   model.LabelSet
+	logger   *slog.Logger
+
+	// failureThreshold is the ratio of failed notifications of an
+	// integration above which NotificationFailureRateHigh fires.
+	failureThreshold float64
+
+	mtx sync.Mutex
+	// previous are the counters of the previous evaluation, by metric name
+	// and labels fingerprint.
+	previous map[string]map[model.Fingerprint]float64
+	// firing are the alerts currently firing, by fingerprint.
+	firing map[model.Fingerprint]*types.Alert
+
+	evaluationFailures prometheus.Counter
+}
+
+// New returns a new Monitor. The labels are added to the generated alerts,
+// typically to identify the instance of Alertmanager.
+func New(g prometheus.Gatherer, alerts provider.Alerts, labels model.LabelSet, failureThreshold float64, l *slog.Logger, r prometheus.Registerer) *Monitor {
+	m := &Monitor{
+		clock:            quartz.NewReal(),
+		gatherer:         g,
+		alerts:           alerts,
+		labels:           labels,
+		logger:           l,
+		failureThreshold: failureThreshold,
+		previous:         map[string]map[model.Fingerprint]float64{},
+		firing:           map[model.Fingerprint]*types.Alert{},
+		evaluationFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_self_monitoring_evaluation_failures_total",
+			Help: "Number of self-monitoring evaluations which failed.",
+		}),
+	}
+	if r != nil {
+		r.MustRegister(m.evaluationFailures)
+	}
+	return m
+}
+
+// Run evaluates the conditions at the given interval until stopc is closed.
+func (m *Monitor) Run(interval time.Duration, stopc <-chan struct{}) {
+	t := m.clock.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stopc:
+			return
+		case <-t.C:
+			if err := m.evaluate(interval); err != nil {
+				m.evaluationFailures.Inc()
+				m.logger.Error("Self-monitoring evaluation failed", "err", err)
+			}
+		}
+	}
+}
+
+// evaluate checks the conditions and puts the alerts which started or
+// stopped firing. Firing alerts are refreshed to not be resolved by the
+// resolve timeout.
+func (m *Monitor) evaluate(interval time.Duration) error {
+	mfs, err := m.gatherer.Gather()
+	if err != nil {
+		return fmt.Errorf("gather metrics: %w", err)
+	}
+	metrics := make(map[string][]sample, len(mfs))
+	for _, mf := range mfs {
+		metrics[mf.GetName()] = samples(mf)
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	var conditions []condition
+	conditions = append(conditions, m.checkConfigReload(metrics)...)
+	conditions = append(conditions, m.checkNotificationFailures(metrics)...)
+	conditions = append(conditions, m.checkCluster(metrics)...)
+	conditions = append(conditions, m.checkSnapshots(metrics)...)
+
+	now := m.clock.Now()
+	var (
+		put    []*types.Alert
+		firing = make(map[model.Fingerprint]*types.Alert, len(conditions))
+	)
+	for _, c := range conditions {
+		a := m.alert(c, now)
+		if prev, ok := m.firing[a.Fingerprint()]; ok {
+			a.StartsAt = prev.StartsAt
+		}
+		// Firing alerts are refreshed at every evaluation, let them expire
+		// if Alertmanager stops evaluating.
+		a.EndsAt = now.Add(3 * interval)
+		firing[a.Fingerprint()] = a
+		put = append(put, a)
+	}
+	for fp, a := range m.firing {
+		if _, ok := firing[fp]; ok {
+			continue
+		}
+		resolved := *a
+		resolved.EndsAt = now
+		resolved.UpdatedAt = now
+		put = append(put, &resolved)
+	}
+	m.firing = firing
+
+	if len(put) == 0 {
+		return nil
+	}
+	return m.alerts.Put(put...)
+}
+
+func (m *Monitor) alert(c condition, now time.Time) *types.Alert {
+	labels := model.LabelSet{}
+	for ln, lv := range m.labels {
+		labels[ln] = lv
+	}
+	for ln, lv := range c.labels {
+		labels[ln] = lv
+	}
+	labels[SourceLabel] = SourceValue
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels: labels,
+			Annotations: model.LabelSet{
+				"summary":     model.LabelValue(c.summary),
+				"description": model.LabelValue(c.description),
+			},
+			StartsAt: now,
+		},
+		UpdatedAt: now,
+	}
+}
+
+// checkConfigReload fires if the last reload of the configuration failed.
+func (m *Monitor) checkConfigReload(metrics map[string][]sample) []condition {
+	for _, s := range metrics["alertmanager_config_last_reload_successful"] {
+		if s.value == 0 {
+			return []condition{{
+				labels:      model.LabelSet{model.AlertNameLabel: ConfigReloadFailed},
+				summary:     "Reloading the Alertmanager configuration failed",
+				description: "The last reload of the configuration failed, Alertmanager runs with the previous configuration.",
+			}}
+		}
+	}
+	return nil
+}
+
+// checkNotificationFailures fires for every integration whose ratio of
+// failed notifications since the previous evaluation is above the threshold.
+func (m *Monitor) checkNotificationFailures(metrics map[string][]sample) []condition {
+	total := m.increases("alertmanager_notifications_total", sumBy(metrics["alertmanager_notifications_total"], "integration"))
+	failed := m.increases("alertmanager_notifications_failed_total", sumBy(metrics["alertmanager_notifications_failed_total"], "integration"))
+
+	var res []condition
+	for _, t := range total {
+		if t.value <= 0 {
+			continue
+		}
+		var f float64
+		for _, s := range failed {
+			if s.labels.Equal(t.labels) {
+				f = s.value
+			}
+		}
+		ratio := f / t.value
+		if ratio <= m.failureThreshold {
+			continue
+		}
+		integration := t.labels["integration"]
+		res = append(res, condition{
+			labels: model.LabelSet{
+				model.AlertNameLabel: NotificationFailureRateHigh,
+				"integration":        integration,
+			},
+			summary:     fmt.Sprintf("Notifications via %s are failing", integration),
+			description: fmt.Sprintf("%.0f%% of the notifications via %s failed since the last evaluation.", ratio*100, integration),
+		})
+	}
+	return res
+}
+
+// checkCluster fires if peers of the cluster are unreachable.
+func (m *Monitor) checkCluster(metrics map[string][]sample) []condition {
+	for _, s := range metrics["alertmanager_cluster_failed_peers"] {
+		if s.value > 0 {
+			return []condition{{
+				labels:      model.LabelSet{model.AlertNameLabel: ClusterPartitionSuspected},
+				summary:     "Alertmanager cluster partition suspected",
+				description: fmt.Sprintf("%.0f peer(s) of the cluster are unreachable.", s.value),
+			}}
+		}
+	}
+	return nil
+}
+
+// checkSnapshots fires for every kind of state whose snapshot failed to be
+// written since the previous evaluation.
+func (m *Monitor) checkSnapshots(metrics map[string][]sample) []condition {
+	var res []condition
+	for _, state := range []string{"nflog", "silences"} {
+		name := "alertmanager_" + state + "_maintenance_errors_total"
+		for _, s := range m.increases(name, metrics[name]) {
+			if s.value <= 0 {
+				continue
+			}
+			res = append(res, condition{
+				labels: model.LabelSet{
+					model.AlertNameLabel: SnapshotWriteFailed,
+					"state":              model.LabelValue(state),
+				},
+				summary:     fmt.Sprintf("Writing the %s snapshot failed", state),
+				description: fmt.Sprintf("%.0f maintenance(s) of the %s failed to write the snapshot to disk since the last evaluation.", s.value, state),
+			})
+		}
+	}
+	return res
+}
+
+// increases returns the increases of the counters since the previous
+// evaluation and records their current values. Counters seen for the first
+// time are compared to zero, reset counters to their current value.
+func (m *Monitor) increases(name string, current []sample) []sample {
+	prev := m.previous[name]
+	next := make(map[model.Fingerprint]float64, len(current))
+	res := make([]sample, 0, len(current))
+	for _, s := range current {
+		fp := s.labels.Fingerprint()
+		next[fp] = s.value
+		inc := s.value
+		if p, ok := prev[fp]; ok && p <= s.value {
+			inc = s.value - p
+		}
+		res = append(res, sample{labels: s.labels, value: inc})
+	}
+	m.previous[name] = next
+	return res
+}
+
+// samples returns the values of the counters and gauges of the family.
+func samples(mf *dto.MetricFamily) []sample {
+	res := make([]sample, 0, len(mf.GetMetric()))
+	for _, metric := range mf.GetMetric() {
+		labels := make(model.LabelSet, len(metric.GetLabel()))
+		for _, lp := range metric.GetLabel() {
+			labels[model.LabelName(lp.GetName())] = model.LabelValue(lp.GetValue())
+		}
+		var v float64
+		switch {
+		case metric.GetCounter() != nil:
+			v = metric.GetCounter().GetValue()
+		case metric.GetGauge() != nil:
+			v = metric.GetGauge().GetValue()
+		case metric.GetUntyped() != nil:
+			v = metric.GetUntyped().GetValue()
+		default:
+			continue
+		}
+		res = append(res, sample{labels: labels, value: v})
+	}
+	return res
+}
+
+// sumBy sums the samples with the same value of the label.
+func sumBy(samples []sample, label model.LabelName) []sample {
+	sums := map[model.LabelValue]float64{}
+	for _, s := range samples {
+		sums[s.labels[label]] += s.value
+	}
+	res := make([]sample, 0, len(sums))
+	for lv, v := range sums {
+		res = append(res, sample{labels: model.LabelSet{label: lv}, value: v})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].labels[label] < res[j].labels[label] })
+	return res
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfmonitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coder/quartz"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/provider"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// fakeAlerts records the alerts put in the provider.
+type fakeAlerts struct {
+	provider.Alerts
+	put []*types.Alert
+}
+
+func (f *fakeAlerts) Put(alerts ...*types.Alert) error {
+	f.put = append(f.put, alerts...)
+	return nil
+}
+
+// byName returns the alerts put since the last call, by name.
+func (f *fakeAlerts) byName() map[string][]*types.Alert {
+	res := map[string][]*types.Alert{}
+	for _, a := range f.put {
+		name := string(a.Labels[model.AlertNameLabel])
+		res[name] = append(res[name], a)
+	}
+	f.put = nil
+	return res
+}
+
+func TestMonitor(t *testing.T) {
+	const interval = time.Minute
+
+	var (
+		reg          = prometheus.NewRegistry()
+		reloadOK     = prometheus.NewGauge(prometheus.GaugeOpts{Name: "alertmanager_config_last_reload_successful"})
+		failedPeers  = prometheus.NewGauge(prometheus.GaugeOpts{Name: "alertmanager_cluster_failed_peers"})
+		nflogErrors  = prometheus.NewCounter(prometheus.CounterOpts{Name: "alertmanager_nflog_maintenance_errors_total"})
+		notifTotal   = prometheus.NewCounterVec(prometheus.CounterOpts{Name: "alertmanager_notifications_total"}, []string{"integration"})
+		notifFailed  = prometheus.NewCounterVec(prometheus.CounterOpts{Name: "alertmanager_notifications_failed_total"}, []string{"integration", "reason"})
+		alerts       = &fakeAlerts{}
+		clock        = quartz.NewMock(t)
+		instanceName = model.LabelSet{"instance": "am-0:9093"}
+	)
+	reg.MustRegister(reloadOK, failedPeers, nflogErrors, notifTotal, notifFailed)
+
+	m := New(reg, alerts, instanceName, 0.5, promslog.NewNopLogger(), nil)
+	m.clock = clock
+
+	// Everything is healthy.
+	reloadOK.Set(1)
+	notifTotal.WithLabelValues("slack").Add(10)
+	notifFailed.WithLabelValues("slack", "serverError").Add(1)
+	require.NoError(t, m.evaluate(interval))
+	require.Empty(t, alerts.byName())
+
+	// All the conditions fail.
+	clock.Advance(interval)
+	start := clock.Now()
+	reloadOK.Set(0)
+	failedPeers.Set(2)
+	nflogErrors.Inc()
+	notifTotal.WithLabelValues("slack").Add(4)
+	notifFailed.WithLabelValues("slack", "serverError").Add(2)
+	notifFailed.WithLabelValues("slack", "clientError").Add(1)
+	notifTotal.WithLabelValues("webhook").Add(4)
+	require.NoError(t, m.evaluate(interval))
+
+	put := alerts.byName()
+	require.Len(t, put, 4)
+	for _, name := range []string{ConfigReloadFailed, ClusterPartitionSuspected, SnapshotWriteFailed, NotificationFailureRateHigh} {
+		require.Len(t, put[name], 1, name)
+		a := put[name][0]
+		require.Equal(t, model.LabelValue(SourceValue), a.Labels[SourceLabel])
+		require.Equal(t, model.LabelValue("am-0:9093"), a.Labels["instance"])
+		require.Equal(t, start, a.StartsAt)
+		require.Equal(t, start.Add(3*interval), a.EndsAt)
+	}
+	require.Equal(t, model.LabelValue("slack"), put[NotificationFailureRateHigh][0].Labels["integration"])
+	require.Equal(t, model.LabelValue("75% of the notifications via slack failed since the last evaluation."), put[NotificationFailureRateHigh][0].Annotations["description"])
+	require.Equal(t, model.LabelValue("nflog"), put[SnapshotWriteFailed][0].Labels["state"])
+
+	// The conditions which still fail are refreshed, the others are
+	// resolved.
+	clock.Advance(interval)
+	now := clock.Now()
+	notifTotal.WithLabelValues("slack").Add(4)
+	require.NoError(t, m.evaluate(interval))
+
+	put = alerts.byName()
+	require.Len(t, put, 4)
+	for _, name := range []string{ConfigReloadFailed, ClusterPartitionSuspected} {
+		a := put[name][0]
+		require.Equal(t, start, a.StartsAt)
+		require.Equal(t, now.Add(3*interval), a.EndsAt)
+	}
+	for _, name := range []string{SnapshotWriteFailed, NotificationFailureRateHigh} {
+		a := put[name][0]
+		require.Equal(t, start, a.StartsAt)
+		require.Equal(t, now, a.EndsAt)
+	}
+
+	// Resolved alerts aren't put again.
+	clock.Advance(interval)
+	reloadOK.Set(1)
+	failedPeers.Set(0)
+	require.NoError(t, m.evaluate(interval))
+	put = alerts.byName()
+	require.Len(t, put, 2)
+	require.Len(t, put[ConfigReloadFailed], 1)
+	require.Len(t, put[ClusterPartitionSuspected], 1)
+
+	clock.Advance(interval)
+	require.NoError(t, m.evaluate(interval))
+	require.Empty(t, alerts.byName())
+}
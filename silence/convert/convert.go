@@ -0,0 +1,221 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package convert converts the silences exported by other alerting systems
+// into Alertmanager silences.
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+)
+
+// Formats of the exported silences.
+const (
+	FormatGrafana = "grafana"
+	FormatKarma   = "karma"
+)
+
+// Result is the conversion of an exported silence.
+type Result struct {
+	// Source identifies the silence in the exported data, usually by its ID.
+	Source string
+	// Silence is the converted silence, without ID. It is nil if the
+	// silence couldn't be converted.
+	Silence *pb.Silence
+	// Err is the reason why the silence couldn't be converted.
+	Err error
+	// Lossy describes the differences between the exported silence and the
+	// converted one, empty if the conversion is exact.
+	Lossy []string
+}
+
+// A Converter converts the silences exported in a format. The silences
+// expired at the given time are left out.
+type Converter func(data []byte, now time.Time) ([]Result, error)
+
+// Converters are the converters by format.
+var Converters = map[string]Converter{
+	FormatGrafana: Grafana,
+	FormatKarma:   Karma,
+}
+
+// matcher is a matcher of the exported silences, in the format of the
+// Alertmanager API which both Grafana and karma follow.
+type matcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	// IsEqual is missing from the exports of old versions, which only
+	// support positive matchers.
+	IsEqual *bool `json:"isEqual"`
+}
+
+// silence is an exported silence.
+type silence struct {
+	ID        string    `json:"id"`
+	Matchers  []matcher `json:"matchers"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	CreatedBy string    `json:"createdBy"`
+	Comment   string    `json:"comment"`
+}
+
+// convert returns the Alertmanager silence of an exported silence.
+func (s *silence) convert() (*pb.Silence, error) {
+	if len(s.Matchers) == 0 {
+		return nil, errors.New("the silence has no matchers")
+	}
+	sil := &pb.Silence{
+		StartsAt:  s.StartsAt,
+		EndsAt:    s.EndsAt,
+		CreatedBy: s.CreatedBy,
+		Comment:   s.Comment,
+		Matchers:  make([]*pb.Matcher, 0, len(s.Matchers)),
+	}
+	for _, m := range s.Matchers {
+		isEqual := m.IsEqual == nil || *m.IsEqual
+		var typ pb.Matcher_Type
+		switch {
+		case isEqual && !m.IsRegex:
+			typ = pb.Matcher_EQUAL
+		case !isEqual && !m.IsRegex:
+			typ = pb.Matcher_NOT_EQUAL
+		case isEqual && m.IsRegex:
+			typ = pb.Matcher_REGEXP
+		default:
+			typ = pb.Matcher_NOT_REGEXP
+		}
+		if m.IsRegex {
+			if _, err := regexp.Compile(m.Value); err != nil {
+				return nil, fmt.Errorf("invalid regular expression %q of matcher %s: %w", m.Value, m.Name, err)
+			}
+		}
+		sil.Matchers = append(sil.Matchers, &pb.Matcher{Type: typ, Name: m.Name, Pattern: m.Value})
+	}
+	return sil, nil
+}
+
+// grafanaSilence is a silence exported by the Alertmanager API of Grafana
+// Alerting.
+type grafanaSilence struct {
+	silence
+	Metadata *struct {
+		RuleTitle string `json:"rule_title"`
+	} `json:"metadata"`
+}
+
+// Grafana converts the silences exported by Grafana Alerting, as returned by
+// its /api/alertmanager/grafana/api/v2/silences endpoint.
+//
+// The matchers of the internal labels of Grafana, such as
+// __alert_rule_uid__, are kept but reported as lossy as they only match the
+// alerts of Grafana-managed rules.
+func Grafana(data []byte, now time.Time) ([]Result, error) {
+	var silences []grafanaSilence
+	if err := json.Unmarshal(data, &silences); err != nil {
+		return nil, fmt.Errorf("invalid Grafana silences: %w", err)
+	}
+
+	res := make([]Result, 0, len(silences))
+	for _, s := range silences {
+		if !s.EndsAt.After(now) {
+			continue
+		}
+		r := Result{Source: s.ID}
+		r.Silence, r.Err = s.convert()
+		if r.Err == nil {
+			for _, m := range s.Matchers {
+				if !strings.HasPrefix(m.Name, "__") {
+					continue
+				}
+				msg := fmt.Sprintf("matcher of the Grafana internal label %s only matches the alerts of Grafana-managed rules", m.Name)
+				if m.Name == "__alert_rule_uid__" && s.Metadata != nil && s.Metadata.RuleTitle != "" {
+					msg += fmt.Sprintf(", here the rule %q", s.Metadata.RuleTitle)
+				}
+				r.Lossy = append(r.Lossy, msg)
+			}
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+// karmaAckPrefix starts the comments of the silences created by the
+// acknowledgements of karma.
+const karmaAckPrefix = "ACK!"
+
+// Karma converts the silences exported by karma, as returned in the silences
+// field of its /alerts.json endpoint, by cluster of Alertmanagers and ID.
+//
+// A silence found in several clusters is converted once. The acknowledgements
+// of karma, which are silences whose comment starts with ACK!, are converted
+// to plain silences and reported as lossy.
+func Karma(data []byte, now time.Time) ([]Result, error) {
+	var export struct {
+		Silences map[string]map[string]silence `json:"silences"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("invalid karma silences: %w", err)
+	}
+	if export.Silences == nil {
+		return nil, errors.New("invalid karma silences: missing silences field")
+	}
+
+	var (
+		clusters = make(map[string][]string)
+		byID     = make(map[string]silence)
+	)
+	for cluster, silences := range export.Silences {
+		for id, s := range silences {
+			if s.ID == "" {
+				s.ID = id
+			}
+			clusters[s.ID] = append(clusters[s.ID], cluster)
+			byID[s.ID] = s
+		}
+	}
+	ids := make([]string, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	res := make([]Result, 0, len(ids))
+	for _, id := range ids {
+		s := byID[id]
+		if !s.EndsAt.After(now) {
+			continue
+		}
+		r := Result{Source: id}
+		r.Silence, r.Err = s.convert()
+		if r.Err == nil {
+			if cs := clusters[id]; len(cs) > 1 {
+				slices.Sort(cs)
+				r.Lossy = append(r.Lossy, fmt.Sprintf("silence of the clusters %s imported once", strings.Join(cs, ", ")))
+			}
+			if strings.HasPrefix(s.Comment, karmaAckPrefix) {
+				r.Lossy = append(r.Lossy, "karma acknowledgement imported as a plain silence")
+			}
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+)
+
+var (
+	now      = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	startsAt = now.Add(-time.Hour)
+	endsAt   = now.Add(time.Hour)
+)
+
+func TestGrafana(t *testing.T) {
+	data := `[
+  {
+    "id": "a",
+    "matchers": [
+      {"name": "alertname", "value": "HighLatency", "isRegex": false, "isEqual": true},
+      {"name": "__alert_rule_uid__", "value": "abc", "isRegex": false, "isEqual": true},
+      {"name": "env", "value": "dev|test", "isRegex": true, "isEqual": false}
+    ],
+    "startsAt": "2024-06-01T11:00:00Z",
+    "endsAt": "2024-06-01T13:00:00Z",
+    "createdBy": "alice",
+    "comment": "maintenance",
+    "status": {"state": "active"},
+    "metadata": {"rule_uid": "abc", "rule_title": "High latency"}
+  },
+  {
+    "id": "b",
+    "matchers": [{"name": "job", "value": "api", "isRegex": false}],
+    "startsAt": "2024-06-01T11:00:00Z",
+    "endsAt": "2024-06-01T13:00:00Z",
+    "createdBy": "bob",
+    "comment": "old Grafana"
+  },
+  {
+    "id": "c",
+    "matchers": [{"name": "job", "value": "api", "isRegex": false, "isEqual": true}],
+    "startsAt": "2024-06-01T10:00:00Z",
+    "endsAt": "2024-06-01T11:00:00Z",
+    "createdBy": "carol",
+    "comment": "expired",
+    "status": {"state": "expired"}
+  },
+  {
+    "id": "d",
+    "matchers": [{"name": "job", "value": "(", "isRegex": true, "isEqual": true}],
+    "startsAt": "2024-06-01T11:00:00Z",
+    "endsAt": "2024-06-01T13:00:00Z",
+    "createdBy": "dave",
+    "comment": "invalid"
+  }
+]`
+	res, err := Grafana([]byte(data), now)
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+
+	require.Equal(t, Result{
+		Source: "a",
+		Silence: &pb.Silence{
+			Matchers: []*pb.Matcher{
+				{Type: pb.Matcher_EQUAL, Name: "alertname", Pattern: "HighLatency"},
+				{Type: pb.Matcher_EQUAL, Name: "__alert_rule_uid__", Pattern: "abc"},
+				{Type: pb.Matcher_NOT_REGEXP, Name: "env", Pattern: "dev|test"},
+			},
+			StartsAt:  startsAt,
+			EndsAt:    endsAt,
+			CreatedBy: "alice",
+			Comment:   "maintenance",
+		},
+		Lossy: []string{`matcher of the Grafana internal label __alert_rule_uid__ only matches the alerts of Grafana-managed rules, here the rule "High latency"`},
+	}, res[0])
+
+	// Matchers without isEqual are equality matchers.
+	require.NoError(t, res[1].Err)
+	require.Equal(t, []*pb.Matcher{{Type: pb.Matcher_EQUAL, Name: "job", Pattern: "api"}}, res[1].Silence.Matchers)
+	require.Empty(t, res[1].Lossy)
+
+	// The expired silence c is left out.
+	require.Equal(t, "d", res[2].Source)
+	require.Nil(t, res[2].Silence)
+	require.ErrorContains(t, res[2].Err, `invalid regular expression "(" of matcher job`)
+
+	_, err = Grafana([]byte(`{"id": "a"}`), now)
+	require.ErrorContains(t, err, "invalid Grafana silences")
+}
+
+func TestKarma(t *testing.T) {
+	data := `{
+  "status": "success",
+  "silences": {
+    "prod": {
+      "s1": {
+        "id": "s1",
+        "matchers": [{"name": "alertname", "value": "HighLatency", "isRegex": false, "isEqual": true}],
+        "startsAt": "2024-06-01T11:00:00Z",
+        "endsAt": "2024-06-01T13:00:00Z",
+        "createdBy": "alice",
+        "comment": "ACK! This alert was acknowledged using karma",
+        "ticketID": "",
+        "ticketURL": ""
+      },
+      "s2": {
+        "id": "s2",
+        "matchers": [{"name": "job", "value": "api.*", "isRegex": true, "isEqual": true}],
+        "startsAt": "2024-06-01T11:00:00Z",
+        "endsAt": "2024-06-01T13:00:00Z",
+        "createdBy": "bob",
+        "comment": "PROJ-1 deploy"
+      }
+    },
+    "staging": {
+      "s2": {
+        "id": "s2",
+        "matchers": [{"name": "job", "value": "api.*", "isRegex": true, "isEqual": true}],
+        "startsAt": "2024-06-01T11:00:00Z",
+        "endsAt": "2024-06-01T13:00:00Z",
+        "createdBy": "bob",
+        "comment": "PROJ-1 deploy"
+      }
+    }
+  }
+}`
+	res, err := Karma([]byte(data), now)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	require.Equal(t, "s1", res[0].Source)
+	require.NoError(t, res[0].Err)
+	require.Equal(t, "ACK! This alert was acknowledged using karma", res[0].Silence.Comment)
+	require.Equal(t, []string{"karma acknowledgement imported as a plain silence"}, res[0].Lossy)
+
+	require.Equal(t, Result{
+		Source: "s2",
+		Silence: &pb.Silence{
+			Matchers:  []*pb.Matcher{{Type: pb.Matcher_REGEXP, Name: "job", Pattern: "api.*"}},
+			StartsAt:  startsAt,
+			EndsAt:    endsAt,
+			CreatedBy: "bob",
+			Comment:   "PROJ-1 deploy",
+		},
+		Lossy: []string{"silence of the clusters prod, staging imported once"},
+	}, res[1])
+
+	_, err = Karma([]byte(`[]`), now)
+	require.ErrorContains(t, err, "invalid karma silences")
+	_, err = Karma([]byte(`{"status": "success"}`), now)
+	require.EqualError(t, err, "invalid karma silences: missing silences field")
+}
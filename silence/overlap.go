@@ -0,0 +1,131 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+)
+
+// QIntersects returns silences that match the given label set or whose
+// matchers overlap the given matchers. A nil label set or empty matchers are
+// ignored. This finds the silences duplicating or overlapping a new silence.
+func QIntersects(set model.LabelSet, ms labels.Matchers) QueryParam {
+	return func(q *query) error {
+		f := func(sil *pb.Silence, s *Silences, _ time.Time) (bool, error) {
+			m, err := s.mc.Get(sil)
+			if err != nil {
+				return true, err
+			}
+			if set != nil && m.Matches(set) {
+				return true, nil
+			}
+			return len(ms) > 0 && Overlaps(m, ms), nil
+		}
+		q.filters = append(q.filters, f)
+		return nil
+	}
+}
+
+// Overlaps returns true if a label set may be matched by both sets of
+// matchers. It is exact for equality matchers and regular expressions
+// matching a single value. For other regular expressions only their literal
+// prefixes are compared, so they may be reported as overlapping while no
+// value matches them all.
+func Overlaps(a, b labels.Matchers) bool {
+	byName := map[string][]*labels.Matcher{}
+	for _, m := range b {
+		byName[m.Name] = append(byName[m.Name], m)
+	}
+	names := map[string]struct{}{}
+	for _, m := range a {
+		if _, ok := byName[m.Name]; ok {
+			byName[m.Name] = append(byName[m.Name], m)
+			names[m.Name] = struct{}{}
+		}
+	}
+	// Labels constrained by only one of the sets don't prevent the overlap.
+	for name := range names {
+		if !overlapsLabel(byName[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// overlapsLabel returns true if a value of a single label may be matched by
+// all the matchers.
+func overlapsLabel(ms []*labels.Matcher) bool {
+	var (
+		// The empty string stands for the label being absent.
+		candidates = []string{""}
+		prefixes   []string
+		// exhaustive is true if the value is bound to one of the candidates.
+		exhaustive bool
+	)
+	for _, m := range ms {
+		switch m.Type {
+		case labels.MatchEqual:
+			candidates = append(candidates, m.Value)
+			exhaustive = true
+		case labels.MatchRegexp:
+			re, err := regexp.Compile(m.Value)
+			if err != nil {
+				// Compiled matchers have valid regular expressions.
+				continue
+			}
+			prefix, complete := re.LiteralPrefix()
+			candidates = append(candidates, prefix)
+			if complete {
+				exhaustive = true
+			} else {
+				prefixes = append(prefixes, prefix)
+			}
+		}
+	}
+
+	for _, c := range candidates {
+		if matchesAll(ms, c) {
+			return true
+		}
+	}
+	if exhaustive {
+		return false
+	}
+	// The values matching the regular expressions start with their literal
+	// prefixes.
+	for i, p1 := range prefixes {
+		for _, p2 := range prefixes[i+1:] {
+			if !strings.HasPrefix(p1, p2) && !strings.HasPrefix(p2, p1) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func matchesAll(ms []*labels.Matcher, v string) bool {
+	for _, m := range ms {
+		if !m.Matches(v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coder/quartz"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/matcher/compat"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+)
+
+func parseMatchers(t *testing.T, ss ...string) labels.Matchers {
+	t.Helper()
+	var ms labels.Matchers
+	for _, s := range ss {
+		m, err := compat.Matcher(s, "test")
+		require.NoError(t, err)
+		ms = append(ms, m)
+	}
+	return ms
+}
+
+func TestOverlaps(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     []string
+		overlaps bool
+	}{
+		{a: []string{`a="x"`}, b: []string{`a="x"`}, overlaps: true},
+		{a: []string{`a="x"`}, b: []string{`a="y"`}, overlaps: false},
+		{a: []string{`a="x"`}, b: []string{`b="y"`}, overlaps: true},
+		{a: []string{`a="x"`, `b="y"`}, b: []string{`b="z"`}, overlaps: false},
+		{a: []string{`a="x"`}, b: []string{`a=~"x|y"`}, overlaps: true},
+		{a: []string{`a="z"`}, b: []string{`a=~"x|y"`}, overlaps: false},
+		{a: []string{`a=~"x"`}, b: []string{`a="y"`}, overlaps: false},
+		{a: []string{`a!="x"`}, b: []string{`a="x"`}, overlaps: false},
+		{a: []string{`a!="x"`}, b: []string{`a="y"`}, overlaps: true},
+		{a: []string{`a!="x"`}, b: []string{`a!="y"`}, overlaps: true},
+		{a: []string{`a=~"foo.*"`}, b: []string{`a=~"fo.+"`}, overlaps: true},
+		{a: []string{`a=~"foo.*"`}, b: []string{`a=~"bar.*"`}, overlaps: false},
+		{a: []string{`a=~"foo.*"`}, b: []string{`a="foobar"`}, overlaps: true},
+		{a: []string{`a=~"foo.*"`}, b: []string{`a="bar"`}, overlaps: false},
+		// Regular expressions are only compared by their literal prefixes.
+		{a: []string{`a=~"foo.*"`}, b: []string{`a!~"foo.*"`}, overlaps: true},
+	} {
+		a, b := parseMatchers(t, tc.a...), parseMatchers(t, tc.b...)
+		require.Equal(t, tc.overlaps, Overlaps(a, b), "%v %v", tc.a, tc.b)
+		require.Equal(t, tc.overlaps, Overlaps(b, a), "%v %v", tc.b, tc.a)
+	}
+}
+
+func TestQIntersects(t *testing.T) {
+	s, err := New(Options{})
+	require.NoError(t, err)
+	clock := quartz.NewMock(t)
+	s.clock = clock
+	now := clock.Now()
+
+	for _, sil := range []*pb.Silence{
+		{Id: "1", Matchers: []*pb.Matcher{{Type: pb.Matcher_EQUAL, Name: "job", Pattern: "node"}}},
+		{Id: "2", Matchers: []*pb.Matcher{{Type: pb.Matcher_REGEXP, Name: "job", Pattern: "api.*"}}},
+		{Id: "3", Matchers: []*pb.Matcher{{Type: pb.Matcher_EQUAL, Name: "job", Pattern: "db"}}},
+	} {
+		sil.StartsAt = now
+		sil.EndsAt = now.Add(time.Hour)
+		sil.UpdatedAt = now
+		s.st.(state)[sil.Id] = &pb.MeshSilence{Silence: sil, ExpiresAt: sil.EndsAt}
+	}
+
+	ids := func(params ...QueryParam) []string {
+		sils, _, err := s.Query(params...)
+		require.NoError(t, err)
+		var res []string
+		for _, sil := range sils {
+			res = append(res, sil.Id)
+		}
+		return res
+	}
+
+	require.ElementsMatch(t, []string{"1"}, ids(QIntersects(model.LabelSet{"job": "node"}, nil)))
+	require.ElementsMatch(t, []string{"2"}, ids(QIntersects(nil, parseMatchers(t, `job=~"api-.*"`))))
+	require.ElementsMatch(t, []string{"1", "2"}, ids(QIntersects(model.LabelSet{"job": "node"}, parseMatchers(t, `job="api-1"`))))
+	require.Empty(t, ids(QIntersects(model.LabelSet{"job": "web"}, parseMatchers(t, `job="web"`))))
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+)
+
+// ErrPolicyViolation is returned when setting a silence which violates the
+// policy.
+var ErrPolicyViolation = errors.New("silence violates policy")
+
+// Policy restricts the silences which can be created or updated. Silences
+// received from peers or loaded from snapshots are not checked.
+type Policy struct {
+	// MaxDuration is the maximum duration of a silence, unlimited if zero.
+	MaxDuration time.Duration
+	// Comment must match the comment of a silence, if set.
+	Comment *regexp.Regexp
+	// DeniedMatchers are the matchers of alerts which can't be silenced. A
+	// silence is denied if it has a matcher on the label of a denied matcher
+	// which is equal to it, matches its value or whose value it matches.
+	DeniedMatchers labels.Matchers
+}
+
+// SetPolicy sets the policy of the silences created or updated from now on,
+// nil to remove it.
+func (s *Silences) SetPolicy(p *Policy) {
+	s.mtx.Lock()
+	s.policy = p
+	s.mtx.Unlock()
+}
+
+// check returns an error wrapping ErrPolicyViolation if the silence violates
+// the policy.
+func (p *Policy) check(sil *pb.Silence) error {
+	if p == nil {
+		return nil
+	}
+	if d := sil.EndsAt.Sub(sil.StartsAt); p.MaxDuration > 0 && d > p.MaxDuration {
+		return fmt.Errorf("%w: duration %s exceeds the maximum of %s", ErrPolicyViolation, model.Duration(d), model.Duration(p.MaxDuration))
+	}
+	if p.Comment != nil && !p.Comment.MatchString(sil.Comment) {
+		return fmt.Errorf("%w: comment must match %q", ErrPolicyViolation, p.Comment.String())
+	}
+	for _, m := range sil.Matchers {
+		sm, err := compileMatcher(m)
+		if err != nil {
+			return err
+		}
+		for _, dm := range p.DeniedMatchers {
+			if denies(dm, sm) {
+				return fmt.Errorf("%w: matcher %s is denied by %s", ErrPolicyViolation, sm, dm)
+			}
+		}
+	}
+	return nil
+}
+
+// denies returns true if the silence matcher targets the alerts of the denied
+// matcher.
+func denies(dm, sm *labels.Matcher) bool {
+	switch {
+	case dm.Name != sm.Name:
+		return false
+	case dm.Type == sm.Type && dm.Value == sm.Value:
+		return true
+	case sm.Type == labels.MatchEqual && dm.Matches(sm.Value):
+		return true
+	case dm.Type == labels.MatchEqual && sm.Matches(dm.Value):
+		return true
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/coder/quartz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/pkg/labels"
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+)
+
+func TestSilencesSetPolicy(t *testing.T) {
+	s, err := New(Options{})
+	require.NoError(t, err)
+
+	clock := quartz.NewMock(t)
+	s.clock = clock
+	now := clock.Now()
+
+	denied, err := labels.NewMatcher(labels.MatchRegexp, "alertname", "Watchdog|DeadMansSwitch")
+	require.NoError(t, err)
+	s.SetPolicy(&Policy{
+		MaxDuration:    24 * time.Hour,
+		Comment:        regexp.MustCompile("^.*[A-Z]+-[0-9]+.*$"),
+		DeniedMatchers: labels.Matchers{denied},
+	})
+
+	cases := []struct {
+		s   *pb.Silence
+		err string
+	}{
+		{
+			s: &pb.Silence{
+				Matchers: []*pb.Matcher{{Name: "a", Pattern: "b"}},
+				Comment:  "OPS-123",
+				StartsAt: now,
+				EndsAt:   now.Add(24 * time.Hour),
+			},
+		}, {
+			s: &pb.Silence{
+				Matchers: []*pb.Matcher{{Name: "a", Pattern: "b"}},
+				Comment:  "OPS-123",
+				StartsAt: now,
+				EndsAt:   now.Add(25 * time.Hour),
+			},
+			err: "silence violates policy: duration 1d1h exceeds the maximum of 1d",
+		}, {
+			s: &pb.Silence{
+				Matchers: []*pb.Matcher{{Name: "a", Pattern: "b"}},
+				Comment:  "no ticket",
+				StartsAt: now,
+				EndsAt:   now.Add(time.Hour),
+			},
+			err: `silence violates policy: comment must match "^.*[A-Z]+-[0-9]+.*$"`,
+		}, {
+			s: &pb.Silence{
+				Matchers: []*pb.Matcher{{Name: "alertname", Pattern: "Watchdog"}},
+				Comment:  "OPS-123",
+				StartsAt: now,
+				EndsAt:   now.Add(time.Hour),
+			},
+			err: `silence violates policy: matcher alertname="Watchdog" is denied by alertname=~"Watchdog|DeadMansSwitch"`,
+		},
+	}
+	for _, c := range cases {
+		err := s.Set(c.s)
+		if c.err == "" {
+			require.NoError(t, err)
+			continue
+		}
+		require.ErrorIs(t, err, ErrPolicyViolation)
+		require.EqualError(t, err, c.err)
+	}
+
+	// Without policy, all silences are allowed.
+	s.SetPolicy(nil)
+	require.NoError(t, s.Set(&pb.Silence{
+		Matchers: []*pb.Matcher{{Name: "alertname", Pattern: "Watchdog"}},
+		StartsAt: now,
+		EndsAt:   now.Add(48 * time.Hour),
+	}))
+}
+
+func TestPolicyDenies(t *testing.T) {
+	matcher := func(s string) *labels.Matcher {
+		m, err := labels.ParseMatcher(s)
+		require.NoError(t, err)
+		return m
+	}
+
+	cases := []struct {
+		denied, silence string
+		want            bool
+	}{
+		{denied: `alertname="Watchdog"`, silence: `alertname="Watchdog"`, want: true},
+		{denied: `alertname="Watchdog"`, silence: `alertname="Other"`, want: false},
+		{denied: `alertname="Watchdog"`, silence: `severity="Watchdog"`, want: false},
+		{denied: `alertname="Watchdog"`, silence: `alertname=~"Watch.*"`, want: true},
+		{denied: `alertname="Watchdog"`, silence: `alertname!="Other"`, want: true},
+		{denied: `alertname=~"Watch.*"`, silence: `alertname="Watchdog"`, want: true},
+		{denied: `alertname=~"Watch.*"`, silence: `alertname=~"Watch.*"`, want: true},
+		{denied: `alertname=~"Watch.*"`, silence: `alertname=~"Other.*"`, want: false},
+		{denied: `team!="ops"`, silence: `team="dev"`, want: true},
+		{denied: `team!="ops"`, silence: `team="ops"`, want: false},
+	}
+	for _, c := range cases {
+		t.Run(c.denied+" "+c.silence, func(t *testing.T) {
+			require.Equal(t, c.want, denies(matcher(c.denied), matcher(c.silence)))
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// ExpireResolved expires the active silences with ExpireOnResolve set which
+// haven't matched any of the firing alerts for the grace period, and returns
+// their IDs. The time since a silence doesn't match is tracked across calls,
+// starting at the first call it didn't match at, so ExpireResolved must be
+// called periodically.
+func (s *Silences) ExpireResolved(firing []model.LabelSet, grace time.Duration) ([]string, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	now := s.nowUTC()
+	var sils []*pb.Silence
+	err := s.st.Range(func(msil *pb.MeshSilence) bool {
+		if msil.Silence.ExpireOnResolve && getState(msil.Silence, now) == types.SilenceStateActive {
+			sils = append(sils, msil.Silence)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		expired        []string
+		unmatchedSince = make(map[string]time.Time, len(s.unmatchedSince))
+	)
+	for _, sil := range sils {
+		ms, err := s.mc.Get(sil)
+		if err != nil {
+			return nil, err
+		}
+		matched := false
+		for _, lset := range firing {
+			if ms.Matches(lset) {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		since, ok := s.unmatchedSince[sil.Id]
+		if !ok {
+			since = now
+		}
+		if now.Sub(since) < grace {
+			unmatchedSince[sil.Id] = since
+			continue
+		}
+		if err := s.expire(sil.Id); err != nil {
+			return expired, err
+		}
+		s.metrics.expiredOnResolveTotal.Inc()
+		expired = append(expired, sil.Id)
+	}
+	s.unmatchedSince = unmatchedSince
+
+	return expired, nil
+}
+
+// ExpireOnResolve expires the silences with ExpireOnResolve set once none of
+// the alerts returned by firing have matched them for the grace period, until
+// stopc is closed.
+func (s *Silences) ExpireOnResolve(grace time.Duration, firing func() []model.LabelSet, stopc <-chan struct{}) {
+	interval := min(grace, time.Minute)
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	t := s.clock.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stopc:
+			return
+		case <-t.C:
+			ids, err := s.ExpireResolved(firing(), grace)
+			if err != nil {
+				s.logger.Error("Expiring resolved silences failed", "err", err)
+			}
+			for _, id := range ids {
+				s.logger.Info("Expired silence whose alerts resolved", "id", id)
+			}
+		}
+	}
+}
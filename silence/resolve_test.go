@@ -0,0 +1,97 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package silence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/coder/quartz"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/types"
+)
+
+func TestSilencesExpireResolved(t *testing.T) {
+	s, err := New(Options{Retention: time.Hour})
+	require.NoError(t, err)
+
+	clock := quartz.NewMock(t)
+	s.clock = clock
+	now := s.nowUTC()
+
+	sil1 := &pb.Silence{
+		Matchers:        []*pb.Matcher{{Name: "a", Pattern: "1"}},
+		StartsAt:        now,
+		EndsAt:          now.Add(time.Hour),
+		ExpireOnResolve: true,
+	}
+	require.NoError(t, s.Set(sil1))
+	// Silences without ExpireOnResolve are left alone.
+	sil2 := &pb.Silence{
+		Matchers: []*pb.Matcher{{Name: "a", Pattern: "2"}},
+		StartsAt: now,
+		EndsAt:   now.Add(time.Hour),
+	}
+	require.NoError(t, s.Set(sil2))
+
+	state := func(id string) types.SilenceState {
+		sil, err := s.QueryOne(QIDs(id))
+		require.NoError(t, err)
+		return getState(sil, s.nowUTC())
+	}
+
+	grace := 5 * time.Minute
+	firing := []model.LabelSet{{"a": "1"}}
+
+	// The silence is kept while it matches firing alerts.
+	ids, err := s.ExpireResolved(firing, grace)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	// The grace period starts once it doesn't match anymore.
+	clock.Advance(time.Minute)
+	ids, err = s.ExpireResolved(nil, grace)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	// Matching again resets the grace period.
+	clock.Advance(4 * time.Minute)
+	ids, err = s.ExpireResolved(firing, grace)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	clock.Advance(time.Minute)
+	ids, err = s.ExpireResolved(nil, grace)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	clock.Advance(4 * time.Minute)
+	ids, err = s.ExpireResolved(nil, grace)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+	require.Equal(t, types.SilenceStateActive, state(sil1.Id))
+
+	clock.Advance(time.Minute)
+	ids, err = s.ExpireResolved(nil, grace)
+	require.NoError(t, err)
+	require.Equal(t, []string{sil1.Id}, ids)
+
+	clock.Advance(time.Second)
+	require.Equal(t, types.SilenceStateExpired, state(sil1.Id))
+	require.Equal(t, types.SilenceStateActive, state(sil2.Id))
+	require.Empty(t, s.unmatchedSince)
+}
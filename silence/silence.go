@@ -37,9 +37,11 @@ import (
 	"github.com/prometheus/common/promslog"
 
 	"github.com/prometheus/alertmanager/cluster"
+	"github.com/prometheus/alertmanager/encryption"
 	"github.com/prometheus/alertmanager/matcher/compat"
 	"github.com/prometheus/alertmanager/pkg/labels"
 	pb "github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/snapshot"
 	"github.com/prometheus/alertmanager/types"
 )
 
@@ -67,20 +69,7 @@ func (c matcherCache) add(s *pb.Silence) (labels.Matchers, error) {
 	ms := make(labels.Matchers, len(s.Matchers))
 
 	for i, m := range s.Matchers {
-		var mt labels.MatchType
-		switch m.Type {
-		case pb.Matcher_EQUAL:
-			mt = labels.MatchEqual
-		case pb.Matcher_NOT_EQUAL:
-			mt = labels.MatchNotEqual
-		case pb.Matcher_REGEXP:
-			mt = labels.MatchRegexp
-		case pb.Matcher_NOT_REGEXP:
-			mt = labels.MatchNotRegexp
-		default:
-			return nil, fmt.Errorf("unknown matcher type %q", m.Type)
-		}
-		matcher, err := labels.NewMatcher(mt, m.Name, m.Pattern)
+		matcher, err := compileMatcher(m)
 		if err != nil {
 			return nil, err
 		}
@@ -92,6 +81,24 @@ func (c matcherCache) add(s *pb.Silence) (labels.Matchers, error) {
 	return ms, nil
 }
 
+// compileMatcher returns the label matcher of a silence matcher.
+func compileMatcher(m *pb.Matcher) (*labels.Matcher, error) {
+	var mt labels.MatchType
+	switch m.Type {
+	case pb.Matcher_EQUAL:
+		mt = labels.MatchEqual
+	case pb.Matcher_NOT_EQUAL:
+		mt = labels.MatchNotEqual
+	case pb.Matcher_REGEXP:
+		mt = labels.MatchRegexp
+	case pb.Matcher_NOT_REGEXP:
+		mt = labels.MatchNotRegexp
+	default:
+		return nil, fmt.Errorf("unknown matcher type %q", m.Type)
+	}
+	return labels.NewMatcher(mt, m.Name, m.Pattern)
+}
+
 // Silencer binds together a AlertMarker and a Silences to implement the Muter
 // interface.
 type Silencer struct {
@@ -196,10 +203,45 @@ type Silences struct {
 	limits    Limits
 
 	mtx       sync.RWMutex
-	st        state
+	policy    *Policy
+	st        Store
 	version   int // Increments whenever silences are added.
 	broadcast func([]byte)
 	mc        matcherCache
+	cipher    *encryption.Cipher
+	// reallocRatio is the fraction of the silences a garbage collection must
+	// remove for the indexes to be reallocated, see Options.ReallocRatio.
+	reallocRatio float64
+
+	// hybridClock makes local silence versions causally ordered after every
+	// version seen so far, see Options.HybridClock.
+	hybridClock bool
+	lastVersion time.Time
+	// localVersions holds the UpdatedAt timestamp of the last local edit of
+	// each silence to detect when a peer's version discards it.
+	localVersions map[string]time.Time
+	conflicts     []Conflict
+	// unmatchedSince holds the time since which the silences with
+	// ExpireOnResolve set haven't matched firing alerts, see ExpireResolved.
+	unmatchedSince map[string]time.Time
+}
+
+// maxConflicts is the number of most recent conflicts kept by Silences.
+const maxConflicts = 100
+
+// Conflict describes a local edit of a silence that was discarded in favor of
+// a peer's version whose UpdatedAt timestamp is ahead of the local clock. As
+// the peer's version cannot have been written after it was received, its
+// timestamp is the result of clock skew and the local edit may well have been
+// the more recent one.
+type Conflict struct {
+	SilenceID       string    `json:"silenceID"`
+	LocalUpdatedAt  time.Time `json:"localUpdatedAt"`
+	RemoteUpdatedAt time.Time `json:"remoteUpdatedAt"`
+	ObservedAt      time.Time `json:"observedAt"`
+	// SkewSeconds is how far the peer's timestamp was ahead of the local
+	// clock when the version was received.
+	SkewSeconds float64 `json:"skewSeconds"`
 }
 
 // Limits contains the limits for silences.
@@ -227,8 +269,11 @@ type metrics struct {
 	silencesPending         prometheus.GaugeFunc
 	silencesExpired         prometheus.GaugeFunc
 	propagatedMessagesTotal prometheus.Counter
+	mergeConflictsTotal     prometheus.Counter
 	maintenanceTotal        prometheus.Counter
 	maintenanceErrorsTotal  prometheus.Counter
+	expiredOnResolveTotal   prometheus.Counter
+	indexRebuildDuration    *prometheus.HistogramVec
 }
 
 func newSilenceMetricByState(s *Silences, st types.SilenceState) prometheus.GaugeFunc {
@@ -293,6 +338,22 @@ func newMetrics(r prometheus.Registerer, s *Silences) *metrics {
 		Name: "alertmanager_silences_gossip_messages_propagated_total",
 		Help: "Number of received gossip messages that have been further gossiped.",
 	})
+	m.mergeConflictsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "alertmanager_silences_merge_conflicts_total",
+		Help: "Number of local silence edits discarded in favor of a peer's version timestamped ahead of the local clock.",
+	})
+	m.expiredOnResolveTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "alertmanager_silences_expired_on_resolve_total",
+		Help: "Number of silences expired because no firing alerts matched them for the grace period.",
+	})
+	m.indexRebuildDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:                            "alertmanager_silences_index_rebuild_duration_seconds",
+		Help:                            "Duration of the rebuilds of the silence indexes, by the garbage collection or the loading of a snapshot.",
+		Buckets:                         prometheus.DefBuckets,
+		NativeHistogramBucketFactor:     1.1,
+		NativeHistogramMaxBucketNumber:  100,
+		NativeHistogramMinResetDuration: 1 * time.Hour,
+	}, []string{"reason"})
 	if s != nil {
 		m.silencesActive = newSilenceMetricByState(s, types.SilenceStateActive)
 		m.silencesPending = newSilenceMetricByState(s, types.SilenceStatePending)
@@ -311,8 +372,11 @@ func newMetrics(r prometheus.Registerer, s *Silences) *metrics {
 			m.silencesPending,
 			m.silencesExpired,
 			m.propagatedMessagesTotal,
+			m.mergeConflictsTotal,
 			m.maintenanceTotal,
 			m.maintenanceErrorsTotal,
+			m.expiredOnResolveTotal,
+			m.indexRebuildDuration,
 		)
 	}
 	return m
@@ -331,6 +395,27 @@ type Options struct {
 	Retention time.Duration
 	Limits    Limits
 
+	// Store holds the silence state. If nil, silences are kept in memory and
+	// can be persisted with snapshots. A custom store cannot be combined with
+	// SnapshotFile or SnapshotReader.
+	Store Store
+
+	// Cipher encrypts snapshots if set. Unencrypted snapshots can still be
+	// loaded.
+	Cipher *encryption.Cipher
+
+	// HybridClock versions local edits with a hybrid logical clock: the
+	// UpdatedAt timestamp of an edit is always after the UpdatedAt timestamp
+	// of every version seen so far, locally or from peers, even if the local
+	// clock is behind. This makes merges tolerant to clock skew between peers.
+	HybridClock bool
+
+	// ReallocRatio is the fraction of the silences a garbage collection must
+	// remove for the in-memory state and the matcher cache to be rebuilt into
+	// new maps, as Go maps never release the memory of their removed entries.
+	// Zero disables the reallocation. Custom stores are never reallocated.
+	ReallocRatio float64
+
 	// A logger used by background processing.
 	Logger  *slog.Logger
 	Metrics prometheus.Registerer
@@ -340,6 +425,12 @@ func (o *Options) validate() error {
 	if o.SnapshotFile != "" && o.SnapshotReader != nil {
 		return errors.New("only one of SnapshotFile and SnapshotReader must be set")
 	}
+	if o.Store != nil && (o.SnapshotFile != "" || o.SnapshotReader != nil) {
+		return errors.New("SnapshotFile and SnapshotReader cannot be used with a custom Store")
+	}
+	if o.ReallocRatio < 0 || o.ReallocRatio > 1 {
+		return errors.New("ReallocRatio must be between 0 and 1")
+	}
 	return nil
 }
 
@@ -357,6 +448,14 @@ func New(o Options) (*Silences, error) {
 		limits:    o.Limits,
 		broadcast: func([]byte) {},
 		st:        state{},
+		cipher:    o.Cipher,
+
+		reallocRatio:  o.ReallocRatio,
+		hybridClock:   o.HybridClock,
+		localVersions: map[string]time.Time{},
+	}
+	if o.Store != nil {
+		s.st = o.Store
 	}
 	s.metrics = newMetrics(o.Metrics, s)
 
@@ -474,18 +573,52 @@ func (s *Silences) GC() (int, error) {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
-	for id, sil := range s.st {
+	var (
+		expired []string
+		total   int
+		gcErr   error
+	)
+	err := s.st.Range(func(sil *pb.MeshSilence) bool {
+		total++
 		if sil.ExpiresAt.IsZero() {
-			return n, errors.New("unexpected zero expiration timestamp")
+			gcErr = errors.New("unexpected zero expiration timestamp")
+			return false
 		}
 		if !sil.ExpiresAt.After(now) {
-			delete(s.st, id)
-			delete(s.mc, sil.Silence.Id)
-			n++
+			expired = append(expired, sil.Silence.Id)
 		}
+		return true
+	})
+	if err != nil {
+		return n, err
+	}
+	if err := s.st.Delete(expired...); err != nil {
+		return n, err
+	}
+	for _, id := range expired {
+		delete(s.mc, id)
+		delete(s.localVersions, id)
+		n++
+	}
+
+	if st, ok := s.st.(state); ok && n > 0 && s.reallocRatio > 0 && float64(n) >= s.reallocRatio*float64(total) {
+		start := time.Now()
+		s.st = realloc(st)
+		s.mc = realloc(s.mc)
+		s.metrics.indexRebuildDuration.WithLabelValues("gc").Observe(time.Since(start).Seconds())
 	}
 
-	return n, nil
+	return n, gcErr
+}
+
+// realloc returns a copy of the map sized for its current entries, unlike
+// maps.Clone which keeps the size of the original map.
+func realloc[M ~map[K]V, K comparable, V any](m M) M {
+	r := make(M, len(m))
+	for k, v := range m {
+		r[k] = v
+	}
+	return r
 }
 
 func validateMatcher(m *pb.Matcher) error {
@@ -562,12 +695,12 @@ func (s *Silences) checkSizeLimits(msil *pb.MeshSilence) error {
 	return nil
 }
 
-func (s *Silences) getSilence(id string) (*pb.Silence, bool) {
-	msil, ok := s.st[id]
-	if !ok {
-		return nil, false
+func (s *Silences) getSilence(id string) (*pb.Silence, error) {
+	msil, err := s.st.Get(id)
+	if err != nil {
+		return nil, err
 	}
-	return msil.Silence, true
+	return msil.Silence, nil
 }
 
 func (s *Silences) toMeshSilence(sil *pb.Silence) *pb.MeshSilence {
@@ -582,14 +715,73 @@ func (s *Silences) setSilence(msil *pb.MeshSilence, now time.Time) error {
 	if err != nil {
 		return err
 	}
-	_, added := s.st.merge(msil, now)
+	changed, added, err := s.st.Merge(msil, now)
+	if err != nil {
+		return err
+	}
 	if added {
 		s.version++
 	}
+	if changed {
+		s.localVersions[msil.Silence.Id] = msil.Silence.UpdatedAt
+		s.observeVersion(msil.Silence.UpdatedAt)
+	}
 	s.broadcast(b)
 	return nil
 }
 
+// versionAt returns the UpdatedAt timestamp for a local edit at the given
+// time. With the hybrid clock enabled, it is moved past the latest version
+// seen so far.
+func (s *Silences) versionAt(now time.Time) time.Time {
+	if s.hybridClock && !now.After(s.lastVersion) {
+		return s.lastVersion.Add(time.Nanosecond)
+	}
+	return now
+}
+
+func (s *Silences) observeVersion(t time.Time) {
+	if t.After(s.lastVersion) {
+		s.lastVersion = t
+	}
+}
+
+// Conflicts returns the most recent local edits that were discarded in favor
+// of a peer's version timestamped ahead of the local clock, oldest first.
+func (s *Silences) Conflicts() []Conflict {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return append([]Conflict(nil), s.conflicts...)
+}
+
+// checkConflict records a conflict if merging e would discard the last local
+// edit of the silence in favor of a version timestamped ahead of now.
+func (s *Silences) checkConflict(e *pb.MeshSilence, now time.Time) {
+	id := e.Silence.Id
+	local, ok := s.localVersions[id]
+	if !ok || !e.Silence.UpdatedAt.After(now) {
+		return
+	}
+	prev, err := s.st.Get(id)
+	if err != nil || !prev.Silence.UpdatedAt.Equal(local) || !prev.Silence.UpdatedAt.Before(e.Silence.UpdatedAt) {
+		return
+	}
+
+	c := Conflict{
+		SilenceID:       id,
+		LocalUpdatedAt:  local,
+		RemoteUpdatedAt: e.Silence.UpdatedAt,
+		ObservedAt:      now,
+		SkewSeconds:     e.Silence.UpdatedAt.Sub(now).Seconds(),
+	}
+	s.logger.Warn("Local silence edit discarded for peer version timestamped ahead of local clock", "silence", id, "skew", e.Silence.UpdatedAt.Sub(now))
+	s.metrics.mergeConflictsTotal.Inc()
+	if len(s.conflicts) >= maxConflicts {
+		s.conflicts = s.conflicts[1:]
+	}
+	s.conflicts = append(s.conflicts, c)
+}
+
 // Set the specified silence. If a silence with the ID already exists and the modification
 // modifies history, the old silence gets expired and a new one is created.
 func (s *Silences) Set(sil *pb.Silence) error {
@@ -604,14 +796,22 @@ func (s *Silences) Set(sil *pb.Silence) error {
 	if err := validateSilence(sil); err != nil {
 		return fmt.Errorf("invalid silence: %w", err)
 	}
+	if err := s.policy.check(sil); err != nil {
+		return err
+	}
 
-	prev, ok := s.getSilence(sil.Id)
+	prev, err := s.getSilence(sil.Id)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	ok := err == nil
 	if sil.Id != "" && !ok {
 		return ErrNotFound
 	}
 
 	if ok && canUpdate(prev, sil, now) {
-		sil.UpdatedAt = now
+		s.observeVersion(prev.UpdatedAt)
+		sil.UpdatedAt = s.versionAt(now)
 		msil := s.toMeshSilence(sil)
 		if err := s.checkSizeLimits(msil); err != nil {
 			return err
@@ -623,8 +823,14 @@ func (s *Silences) Set(sil *pb.Silence) error {
 	// also create a new silence) so we need to make sure we have capacity for
 	// the new silence.
 	if s.limits.MaxSilences != nil {
-		if m := s.limits.MaxSilences(); m > 0 && len(s.st)+1 > m {
-			return fmt.Errorf("exceeded maximum number of silences: %d (limit: %d)", len(s.st), m)
+		if m := s.limits.MaxSilences(); m > 0 {
+			n, err := s.st.Len()
+			if err != nil {
+				return err
+			}
+			if n+1 > m {
+				return fmt.Errorf("exceeded maximum number of silences: %d (limit: %d)", n, m)
+			}
 		}
 	}
 
@@ -637,7 +843,7 @@ func (s *Silences) Set(sil *pb.Silence) error {
 	if sil.StartsAt.Before(now) {
 		sil.StartsAt = now
 	}
-	sil.UpdatedAt = now
+	sil.UpdatedAt = s.versionAt(now)
 
 	msil := s.toMeshSilence(sil)
 	if err := s.checkSizeLimits(msil); err != nil {
@@ -693,9 +899,9 @@ func (s *Silences) Expire(id string) error {
 // It is idempotent, nil is returned if the silence already expired before it is GC'd.
 // If the silence is not found an error is returned.
 func (s *Silences) expire(id string) error {
-	sil, ok := s.getSilence(id)
-	if !ok {
-		return ErrNotFound
+	sil, err := s.getSilence(id)
+	if err != nil {
+		return err
 	}
 	sil = cloneSilence(sil)
 	now := s.nowUTC()
@@ -710,7 +916,7 @@ func (s *Silences) expire(id string) error {
 		sil.StartsAt = now
 		sil.EndsAt = now
 	}
-	sil.UpdatedAt = now
+	sil.UpdatedAt = s.versionAt(now)
 	return s.setSilence(s.toMeshSilence(sil), now)
 }
 
@@ -838,13 +1044,22 @@ func (s *Silences) query(q *query, now time.Time) ([]*pb.Silence, int, error) {
 
 	if q.ids != nil {
 		for _, id := range q.ids {
-			if s, ok := s.st[id]; ok {
-				res = append(res, s.Silence)
+			msil, err := s.st.Get(id)
+			if errors.Is(err, ErrNotFound) {
+				continue
 			}
+			if err != nil {
+				return nil, s.version, err
+			}
+			res = append(res, msil.Silence)
 		}
 	} else {
-		for _, sil := range s.st {
-			res = append(res, sil.Silence)
+		err := s.st.Range(func(msil *pb.MeshSilence) bool {
+			res = append(res, msil.Silence)
+			return true
+		})
+		if err != nil {
+			return nil, s.version, err
 		}
 	}
 
@@ -872,10 +1087,15 @@ func (s *Silences) query(q *query, now time.Time) ([]*pb.Silence, int, error) {
 // loadSnapshot loads a snapshot generated by Snapshot() into the state.
 // Any previous state is wiped.
 func (s *Silences) loadSnapshot(r io.Reader) error {
+	r, err := encryption.Open(s.cipher, r)
+	if err != nil {
+		return err
+	}
 	st, err := decodeState(r)
 	if err != nil {
 		return err
 	}
+	sils := make([]*pb.MeshSilence, 0, len(st))
 	for _, e := range st {
 		// Comments list was moved to a single comment. Upgrade on loading the snapshot.
 		if len(e.Silence.Comments) > 0 {
@@ -883,12 +1103,23 @@ func (s *Silences) loadSnapshot(r io.Reader) error {
 			e.Silence.CreatedBy = e.Silence.Comments[0].Author
 			e.Silence.Comments = nil
 		}
-		st[e.Silence.Id] = e
+		sils = append(sils, e)
 	}
 	s.mtx.Lock()
-	s.st = st
+	defer s.mtx.Unlock()
+	if err := s.st.Replace(sils); err != nil {
+		return err
+	}
 	s.version++
-	s.mtx.Unlock()
+
+	// Compile the matchers upfront rather than on the first queries. Invalid
+	// matchers are reported by these queries.
+	start := time.Now()
+	s.mc = make(matcherCache, len(sils))
+	for _, e := range sils {
+		_, _ = s.mc.add(e.Silence)
+	}
+	s.metrics.indexRebuildDuration.WithLabelValues("snapshot_load").Observe(time.Since(start).Seconds())
 
 	return nil
 }
@@ -902,10 +1133,13 @@ func (s *Silences) Snapshot(w io.Writer) (int64, error) {
 	s.mtx.RLock()
 	defer s.mtx.RUnlock()
 
-	b, err := s.st.MarshalBinary()
+	b, err := marshalStore(s.st)
 	if err != nil {
 		return 0, err
 	}
+	if b, err = encryption.Seal(s.cipher, b); err != nil {
+		return 0, err
+	}
 
 	return io.Copy(w, bytes.NewReader(b))
 }
@@ -915,7 +1149,7 @@ func (s *Silences) MarshalBinary() ([]byte, error) {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
-	return s.st.MarshalBinary()
+	return marshalStore(s.st)
 }
 
 // Merge merges silence state received from the cluster with the local state.
@@ -930,8 +1164,14 @@ func (s *Silences) Merge(b []byte) error {
 	now := s.nowUTC()
 
 	for _, e := range st {
-		merged, added := s.st.merge(e, now)
+		s.checkConflict(e, now)
+		merged, added, err := s.st.Merge(e, now)
+		if err != nil {
+			return err
+		}
 		if merged {
+			delete(s.localVersions, e.Silence.Id)
+			s.observeVersion(e.Silence.UpdatedAt)
 			if added {
 				s.version++
 			}
@@ -957,8 +1197,98 @@ func (s *Silences) SetBroadcast(f func([]byte)) {
 	s.mtx.Unlock()
 }
 
+// Store holds the silence state of a Silences instance. The default
+// implementation keeps all silences in memory. Alternative implementations
+// can keep them in a durable backend shared between several instances.
+//
+// Silences guards all calls with its own mutex. Read-only methods may still be
+// called concurrently with each other.
+type Store interface {
+	// Get returns the silence with the given ID or ErrNotFound.
+	Get(id string) (*pb.MeshSilence, error)
+	// Merge stores the silence if it is not expired at the given time and
+	// is newer than the stored silence with the same ID. It reports whether
+	// the state changed and whether a silence with a new ID was added.
+	Merge(e *pb.MeshSilence, now time.Time) (changed, added bool, err error)
+	// Delete removes the silences with the given IDs.
+	Delete(ids ...string) error
+	// Len returns the number of stored silences.
+	Len() (int, error)
+	// Range calls f for each stored silence until f returns false.
+	Range(f func(*pb.MeshSilence) bool) error
+	// Replace replaces all stored silences with the given ones.
+	Replace(sils []*pb.MeshSilence) error
+}
+
+// marshalStore serializes all silences of the store.
+func marshalStore(st Store) ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		err error
+	)
+	rerr := st.Range(func(e *pb.MeshSilence) bool {
+		_, err = pbutil.WriteDelimited(&buf, e)
+		return err == nil
+	})
+	if rerr != nil {
+		return nil, rerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// state is the default in-memory Store.
 type state map[string]*pb.MeshSilence
 
+// Get implements the Store interface.
+func (s state) Get(id string) (*pb.MeshSilence, error) {
+	e, ok := s[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return e, nil
+}
+
+// Merge implements the Store interface.
+func (s state) Merge(e *pb.MeshSilence, now time.Time) (bool, bool, error) {
+	changed, added := s.merge(e, now)
+	return changed, added, nil
+}
+
+// Delete implements the Store interface.
+func (s state) Delete(ids ...string) error {
+	for _, id := range ids {
+		delete(s, id)
+	}
+	return nil
+}
+
+// Len implements the Store interface.
+func (s state) Len() (int, error) {
+	return len(s), nil
+}
+
+// Range implements the Store interface.
+func (s state) Range(f func(*pb.MeshSilence) bool) error {
+	for _, e := range s {
+		if !f(e) {
+			break
+		}
+	}
+	return nil
+}
+
+// Replace implements the Store interface.
+func (s state) Replace(sils []*pb.MeshSilence) error {
+	clear(s)
+	for _, e := range sils {
+		s[e.Silence.Id] = e
+	}
+	return nil
+}
+
 // merge returns two bools: the first is true when merge caused a state change. The second
 // is true if that state change added a new silence. In other words, the second return is
 // true whenever a silence with a new ID has been added to the state as a result of merge.
@@ -1014,6 +1344,25 @@ func decodeState(r io.Reader) (state, error) {
 	return st, nil
 }
 
+// VerifySnapshot checks that all silences of the snapshot read from r can be
+// decoded. If w is not nil, a repaired snapshot without the undecodable
+// silences is written to it.
+func VerifySnapshot(r io.Reader, c *encryption.Cipher, now time.Time, w io.Writer) (snapshot.Report, error) {
+	return snapshot.Verify(r, c, func(b []byte) (snapshot.EntryInfo, error) {
+		var e pb.MeshSilence
+		if err := e.Unmarshal(b); err != nil {
+			return snapshot.EntryInfo{}, err
+		}
+		if e.Silence == nil || e.Silence.Id == "" {
+			return snapshot.EntryInfo{}, ErrInvalidState
+		}
+		return snapshot.EntryInfo{
+			Legacy:  len(e.Silence.Comments) > 0,
+			Expired: !e.ExpiresAt.After(now),
+		}, nil
+	}, w)
+}
+
 func marshalMeshSilence(e *pb.MeshSilence) ([]byte, error) {
 	var buf bytes.Buffer
 	if _, err := pbutil.WriteDelimited(&buf, e); err != nil {
@@ -14,6 +14,8 @@
 package silence
 
 import (
+	"bytes"
+	"fmt"
 	"strconv"
 	"testing"
 	"time"
@@ -114,7 +116,7 @@ func benchmarkQuery(b *testing.B, numSilences int) {
 
 	lset := model.LabelSet{"aaaa": "AAAA", "bbbb": "BBBB", "cccc": "CCCC"}
 
-	s.st = state{}
+	st := state{}
 	for i := 0; i < numSilences; i++ {
 		id := strconv.Itoa(i)
 		// Include an offset to avoid optimizations.
@@ -125,7 +127,7 @@ func benchmarkQuery(b *testing.B, numSilences int) {
 			patB = "B(B|C)B.|" + id
 		}
 
-		s.st[id] = &silencepb.MeshSilence{Silence: &silencepb.Silence{
+		st[id] = &silencepb.MeshSilence{Silence: &silencepb.Silence{
 			Id: id,
 			Matchers: []*silencepb.Matcher{
 				{Type: silencepb.Matcher_REGEXP, Name: "aaaa", Pattern: patA},
@@ -136,6 +138,7 @@ func benchmarkQuery(b *testing.B, numSilences int) {
 			UpdatedAt: now.Add(-time.Hour),
 		}}
 	}
+	s.st = st
 
 	// Run things once to populate the matcherCache.
 	sils, _, err := s.Query(
@@ -155,3 +158,91 @@ func benchmarkQuery(b *testing.B, numSilences int) {
 		require.Len(b, sils, numSilences/10)
 	}
 }
+
+// BenchmarkGC benchmarks the garbage collection of 10% of the silences for
+// different numbers of retained silences, with and without reallocating the
+// indexes.
+func BenchmarkGC(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000, 500000} {
+		for _, ratio := range []float64{0, 0.1} {
+			b.Run(fmt.Sprintf("%d silences, realloc ratio %v", n, ratio), func(b *testing.B) {
+				benchmarkGC(b, n, ratio)
+			})
+		}
+	}
+}
+
+func benchmarkGC(b *testing.B, n int, ratio float64) {
+	s, err := New(Options{ReallocRatio: ratio})
+	require.NoError(b, err)
+	clock := quartz.NewMock(b)
+	s.clock = clock
+	now := clock.Now()
+
+	sils := benchmarkSilences(n, now)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		require.NoError(b, s.st.Replace(sils))
+		for _, e := range sils {
+			_, err := s.mc.add(e.Silence)
+			require.NoError(b, err)
+		}
+		b.StartTimer()
+
+		removed, err := s.GC()
+		require.NoError(b, err)
+		require.Equal(b, n/10, removed)
+	}
+}
+
+// BenchmarkLoadSnapshot benchmarks the loading of snapshots for different
+// numbers of retained silences.
+func BenchmarkLoadSnapshot(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000, 500000} {
+		b.Run(fmt.Sprintf("%d silences", n), func(b *testing.B) {
+			benchmarkLoadSnapshot(b, n)
+		})
+	}
+}
+
+func benchmarkLoadSnapshot(b *testing.B, n int) {
+	now := quartz.NewMock(b).Now()
+	st := state{}
+	require.NoError(b, st.Replace(benchmarkSilences(n, now)))
+	snap, err := st.MarshalBinary()
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s, err := New(Options{})
+		require.NoError(b, err)
+		require.NoError(b, s.loadSnapshot(bytes.NewReader(snap)))
+	}
+}
+
+// benchmarkSilences returns n silences of which one in ten has expired.
+func benchmarkSilences(n int, now time.Time) []*silencepb.MeshSilence {
+	sils := make([]*silencepb.MeshSilence, 0, n)
+	for i := 0; i < n; i++ {
+		id := strconv.Itoa(i)
+		expiresAt := now.Add(time.Hour)
+		if i%10 == 0 {
+			expiresAt = now.Add(-time.Hour)
+		}
+		sils = append(sils, &silencepb.MeshSilence{
+			Silence: &silencepb.Silence{
+				Id: id,
+				Matchers: []*silencepb.Matcher{
+					{Type: silencepb.Matcher_EQUAL, Name: "instance", Pattern: id},
+					{Type: silencepb.Matcher_REGEXP, Name: "job", Pattern: "node|" + id},
+				},
+				StartsAt:  now.Add(-time.Hour),
+				EndsAt:    now.Add(time.Hour),
+				UpdatedAt: now.Add(-time.Hour),
+			},
+			ExpiresAt: expiresAt,
+		})
+	}
+	return sils
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
 
+	"github.com/prometheus/alertmanager/encryption"
 	"github.com/prometheus/alertmanager/featurecontrol"
 	"github.com/prometheus/alertmanager/matcher/compat"
 	pb "github.com/prometheus/alertmanager/silence/silencepb"
@@ -77,6 +78,24 @@ func TestOptionsValidate(t *testing.T) {
 			},
 			err: "only one of SnapshotFile and SnapshotReader must be set",
 		},
+		{
+			options: &Options{
+				Store: state{},
+			},
+		},
+		{
+			options: &Options{
+				SnapshotFile: "test.bkp",
+				Store:        state{},
+			},
+			err: "SnapshotFile and SnapshotReader cannot be used with a custom Store",
+		},
+		{
+			options: &Options{
+				ReallocRatio: 1.5,
+			},
+			err: "ReallocRatio must be between 0 and 1",
+		},
 	}
 
 	for _, c := range cases {
@@ -84,6 +103,38 @@ func TestOptionsValidate(t *testing.T) {
 	}
 }
 
+// recordingStore is a Store that records which silences were merged.
+type recordingStore struct {
+	state
+	merged []string
+}
+
+func (s *recordingStore) Merge(e *pb.MeshSilence, now time.Time) (bool, bool, error) {
+	s.merged = append(s.merged, e.Silence.Id)
+	return s.state.Merge(e, now)
+}
+
+func TestSilencesCustomStore(t *testing.T) {
+	st := &recordingStore{state: state{}}
+	s, err := New(Options{Store: st, Retention: time.Hour})
+	require.NoError(t, err)
+
+	sil := &pb.Silence{
+		Matchers: []*pb.Matcher{{Name: "a", Pattern: "b"}},
+		EndsAt:   s.nowUTC().Add(time.Hour),
+	}
+	require.NoError(t, s.Set(sil))
+	require.Equal(t, []string{sil.Id}, st.merged)
+	require.Len(t, st.state, 1)
+
+	sils, _, err := s.Query(QMatches(model.LabelSet{"a": "b"}))
+	require.NoError(t, err)
+	require.Len(t, sils, 1)
+
+	require.NoError(t, s.Expire(sil.Id))
+	require.Equal(t, []string{sil.Id, sil.Id}, st.merged)
+}
+
 func TestSilenceGCOverTime(t *testing.T) {
 	t.Run("GC does not remove active silences", func(t *testing.T) {
 		s, err := New(Options{})
@@ -133,6 +184,38 @@ func TestSilenceGCOverTime(t *testing.T) {
 		require.Empty(t, s.mc)
 	})
 
+	t.Run("GC reallocates the indexes past the ratio", func(t *testing.T) {
+		for _, tc := range []struct {
+			ratio   float64
+			realloc bool
+		}{
+			{ratio: 0, realloc: false},
+			{ratio: 0.5, realloc: true},
+			{ratio: 0.75, realloc: false},
+		} {
+			s, err := New(Options{ReallocRatio: tc.ratio})
+			require.NoError(t, err)
+			s.clock = quartz.NewMock(t)
+			now := s.nowUTC()
+			s.st = state{
+				"1": &pb.MeshSilence{Silence: &pb.Silence{Id: "1"}, ExpiresAt: now},
+				"2": &pb.MeshSilence{Silence: &pb.Silence{Id: "2"}, ExpiresAt: now.Add(-time.Second)},
+				"3": &pb.MeshSilence{Silence: &pb.Silence{Id: "3"}, ExpiresAt: now.Add(time.Second)},
+				"4": &pb.MeshSilence{Silence: &pb.Silence{Id: "4"}, ExpiresAt: now.Add(time.Second)},
+			}
+			n, err := s.GC()
+			require.NoError(t, err)
+			require.Equal(t, 2, n)
+			require.Len(t, s.st, 2)
+			rebuilds := testutil.CollectAndCount(s.metrics.indexRebuildDuration)
+			if tc.realloc {
+				require.Equal(t, 1, rebuilds, "ratio %v", tc.ratio)
+			} else {
+				require.Equal(t, 0, rebuilds, "ratio %v", tc.ratio)
+			}
+		}
+	})
+
 	t.Run("replacing a silences does not leak cache entries", func(t *testing.T) {
 		s, err := New(Options{})
 		require.NoError(t, err)
@@ -191,7 +274,7 @@ func TestSilenceGCOverTime(t *testing.T) {
 			StartsAt: clock.Now(),
 			EndsAt:   clock.Now().Add(time.Minute),
 		}
-		s.st["1"] = &pb.MeshSilence{Silence: sil1, ExpiresAt: clock.Now().Add(time.Minute)}
+		s.st.(state)["1"] = &pb.MeshSilence{Silence: sil1, ExpiresAt: clock.Now().Add(time.Minute)}
 		// Need to query the silence to populate the matcher cache.
 		s.Query(QMatches(model.LabelSet{"foo": "bar"}))
 		require.Len(t, s.mc, 1)
@@ -273,7 +356,7 @@ func TestSilencesSnapshot(t *testing.T) {
 		s1 := &Silences{st: state{}, metrics: newMetrics(nil, nil)}
 		// Setup internal state manually.
 		for _, e := range c.entries {
-			s1.st[e.Silence.Id] = e
+			s1.st.(state)[e.Silence.Id] = e
 		}
 		_, err = s1.Snapshot(f)
 		require.NoError(t, err, "creating snapshot failed")
@@ -284,15 +367,46 @@ func TestSilencesSnapshot(t *testing.T) {
 		require.NoError(t, err, "opening snapshot file failed")
 
 		// Check again against new nlog instance.
-		s2 := &Silences{mc: matcherCache{}, st: state{}}
+		s2 := &Silences{mc: matcherCache{}, st: state{}, metrics: newMetrics(nil, nil)}
 		err = s2.loadSnapshot(f)
 		require.NoError(t, err, "error loading snapshot")
 		require.Equal(t, s1.st, s2.st, "state after loading snapshot did not match snapshotted state")
+		require.Len(t, s2.mc, len(c.entries), "matchers were not compiled when loading the snapshot")
 
 		require.NoError(t, f.Close(), "closing snapshot file failed")
 	}
 }
 
+func TestSilencesSnapshotEncrypted(t *testing.T) {
+	c, err := encryption.New(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+
+	now := quartz.NewMock(t).Now().UTC()
+	s1 := &Silences{st: state{}, metrics: newMetrics(nil, nil), cipher: c}
+	s1.st.(state)["a"] = &pb.MeshSilence{
+		Silence: &pb.Silence{
+			Id:        "a",
+			Matchers:  []*pb.Matcher{{Name: "label1", Pattern: "secret-value", Type: pb.Matcher_EQUAL}},
+			StartsAt:  now,
+			EndsAt:    now.Add(time.Hour),
+			UpdatedAt: now,
+		},
+		ExpiresAt: now.Add(24 * time.Hour),
+	}
+
+	var buf bytes.Buffer
+	_, err = s1.Snapshot(&buf)
+	require.NoError(t, err)
+	require.NotContains(t, buf.String(), "secret-value")
+
+	s2 := &Silences{mc: matcherCache{}, st: state{}, metrics: newMetrics(nil, nil)}
+	require.ErrorIs(t, s2.loadSnapshot(bytes.NewReader(buf.Bytes())), encryption.ErrMissingKey)
+
+	s3 := &Silences{mc: matcherCache{}, st: state{}, cipher: c, metrics: newMetrics(nil, nil)}
+	require.NoError(t, s3.loadSnapshot(bytes.NewReader(buf.Bytes())))
+	require.Equal(t, s1.st, s3.st)
+}
+
 // This tests a regression introduced by https://github.com/prometheus/alertmanager/pull/2689.
 func TestSilences_Maintenance_DefaultMaintenanceFuncDoesntCrash(t *testing.T) {
 	f, err := os.CreateTemp("", "snapshot")
@@ -1780,6 +1894,103 @@ func TestStateMerge(t *testing.T) {
 	}
 }
 
+func TestSilencesMergeConflict(t *testing.T) {
+	s, err := New(Options{Retention: time.Hour})
+	require.NoError(t, err)
+	clock := quartz.NewMock(t)
+	s.clock = clock
+	now := s.nowUTC()
+
+	sil := &pb.Silence{
+		Matchers:  []*pb.Matcher{{Name: "a", Pattern: "b"}},
+		StartsAt:  now.Add(time.Minute),
+		EndsAt:    now.Add(time.Hour),
+		CreatedBy: "local",
+	}
+	require.NoError(t, s.Set(sil))
+
+	merge := func(updatedAt time.Time, comment string) {
+		remote := cloneSilence(sil)
+		remote.UpdatedAt = updatedAt
+		remote.Comment = comment
+		b, err := marshalMeshSilence(s.toMeshSilence(remote))
+		require.NoError(t, err)
+		require.NoError(t, s.Merge(b))
+	}
+
+	// A peer version written before the local edit is discarded without
+	// conflict.
+	merge(now.Add(-time.Second), "older")
+	require.Empty(t, s.Conflicts())
+
+	// A peer version timestamped ahead of the local clock discards the local
+	// edit.
+	clock.Advance(time.Second)
+	merge(now.Add(time.Hour), "skewed")
+	require.Equal(t, []Conflict{{
+		SilenceID:       sil.Id,
+		LocalUpdatedAt:  now,
+		RemoteUpdatedAt: now.Add(time.Hour),
+		ObservedAt:      now.Add(time.Second),
+		SkewSeconds:     (time.Hour - time.Second).Seconds(),
+	}}, s.Conflicts())
+	require.Equal(t, 1.0, testutil.ToFloat64(s.metrics.mergeConflictsTotal))
+
+	got, err := s.getSilence(sil.Id)
+	require.NoError(t, err)
+	require.Equal(t, "skewed", got.Comment)
+
+	// Further peer versions only replace the peer's version and aren't
+	// reported.
+	merge(now.Add(2*time.Hour), "skewed again")
+	require.Len(t, s.Conflicts(), 1)
+}
+
+func TestSilencesHybridClock(t *testing.T) {
+	for _, hybrid := range []bool{false, true} {
+		t.Run(fmt.Sprintf("hybrid=%t", hybrid), func(t *testing.T) {
+			s, err := New(Options{Retention: time.Hour, HybridClock: hybrid})
+			require.NoError(t, err)
+			clock := quartz.NewMock(t)
+			s.clock = clock
+			now := s.nowUTC()
+
+			sil := &pb.Silence{
+				Matchers:  []*pb.Matcher{{Name: "a", Pattern: "b"}},
+				StartsAt:  now.Add(time.Minute),
+				EndsAt:    now.Add(time.Hour),
+				CreatedBy: "local",
+			}
+			require.NoError(t, s.Set(sil))
+
+			// A peer with its clock an hour ahead updates the silence.
+			remote := cloneSilence(sil)
+			remote.UpdatedAt = now.Add(time.Hour)
+			remote.Comment = "remote"
+			b, err := marshalMeshSilence(s.toMeshSilence(remote))
+			require.NoError(t, err)
+			require.NoError(t, s.Merge(b))
+
+			// Edit the silence locally after having seen the peer's version.
+			clock.Advance(time.Second)
+			sil = cloneSilence(remote)
+			sil.Comment = "local"
+			require.NoError(t, s.Set(sil))
+
+			// Without the hybrid clock, the local edit is timestamped before
+			// the peer's version and gets lost.
+			got, err := s.getSilence(sil.Id)
+			require.NoError(t, err)
+			if hybrid {
+				require.Equal(t, "local", got.Comment)
+				require.Equal(t, remote.UpdatedAt.Add(time.Nanosecond), got.UpdatedAt)
+			} else {
+				require.Equal(t, "remote", got.Comment)
+			}
+		})
+	}
+}
+
 func TestStateCoding(t *testing.T) {
 	// Check whether encoding and decoding the data is symmetric.
 	now := time.Now().UTC()
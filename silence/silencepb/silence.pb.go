@@ -166,8 +166,10 @@ type Silence struct {
 	// DEPRECATED: A set of comments made on the silence.
 	Comments []*Comment `protobuf:"bytes,7,rep,name=comments,proto3" json:"comments,omitempty"`
 	// Comment for the silence.
-	CreatedBy            string   `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
-	Comment              string   `protobuf:"bytes,9,opt,name=comment,proto3" json:"comment,omitempty"`
+	CreatedBy string `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	Comment   string `protobuf:"bytes,9,opt,name=comment,proto3" json:"comment,omitempty"`
+	// Expire the silence once no firing alerts match it for a grace period.
+	ExpireOnResolve      bool     `protobuf:"varint,10,opt,name=expire_on_resolve,json=expireOnResolve,proto3" json:"expire_on_resolve,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -260,36 +262,38 @@ func init() {
 func init() { proto.RegisterFile("silence.proto", fileDescriptor_7fc56058cf68dbd8) }
 
 var fileDescriptor_7fc56058cf68dbd8 = []byte{
-	// 461 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0xcd, 0x8e, 0xd2, 0x50,
-	0x14, 0xc7, 0xb9, 0x85, 0xa1, 0xdc, 0x43, 0x86, 0x90, 0x13, 0xa3, 0x0d, 0x89, 0x40, 0xba, 0x22,
-	0xd1, 0x94, 0x04, 0xb7, 0xba, 0x28, 0x13, 0xe2, 0xc6, 0xf1, 0xa3, 0x62, 0xe2, 0x6e, 0x52, 0xe8,
-	0x11, 0x9a, 0x4c, 0x3f, 0xd2, 0x1e, 0x12, 0x59, 0xe9, 0x23, 0xf8, 0x0c, 0x3e, 0x0d, 0x4b, 0x9f,
-	0xc0, 0x0f, 0xde, 0xc2, 0x9d, 0xe9, 0xed, 0x2d, 0xce, 0x84, 0x55, 0x77, 0xf7, 0xdc, 0xf3, 0xff,
-	0x9f, 0x8f, 0xdf, 0x81, 0xcb, 0x3c, 0xbc, 0xa5, 0x78, 0x4d, 0x4e, 0x9a, 0x25, 0x9c, 0xa0, 0xd4,
-	0x61, 0xba, 0x1a, 0x8c, 0x36, 0x49, 0xb2, 0xb9, 0xa5, 0xa9, 0x4a, 0xac, 0x76, 0x9f, 0xa6, 0x1c,
-	0x46, 0x94, 0xb3, 0x1f, 0xa5, 0xa5, 0x76, 0xf0, 0x60, 0x93, 0x6c, 0x12, 0xf5, 0x9c, 0x16, 0xaf,
-	0xf2, 0xd7, 0xfe, 0x2e, 0xc0, 0xbc, 0xf6, 0x79, 0xbd, 0xa5, 0x0c, 0x9f, 0x40, 0x8b, 0xf7, 0x29,
-	0x59, 0x62, 0x2c, 0x26, 0xbd, 0xd9, 0x23, 0xe7, 0x54, 0xdc, 0xd1, 0x0a, 0x67, 0xb9, 0x4f, 0xc9,
-	0x53, 0x22, 0x44, 0x68, 0xc5, 0x7e, 0x44, 0x96, 0x31, 0x16, 0x13, 0xe9, 0xa9, 0x37, 0x5a, 0x60,
-	0xa6, 0x3e, 0x33, 0x65, 0xb1, 0xd5, 0x54, 0xdf, 0x55, 0x68, 0x3f, 0x87, 0x56, 0xe1, 0x45, 0x09,
-	0x17, 0x8b, 0x77, 0x1f, 0xdc, 0x57, 0xfd, 0x06, 0x02, 0xb4, 0xbd, 0xc5, 0xcb, 0xc5, 0xc7, 0xb7,
-	0x7d, 0x81, 0x97, 0x20, 0x5f, 0xbf, 0x59, 0xde, 0x94, 0x29, 0x03, 0x7b, 0x00, 0x45, 0xa8, 0xd3,
-	0x4d, 0xfb, 0x0b, 0x98, 0x57, 0x49, 0x14, 0x51, 0xcc, 0xf8, 0x10, 0xda, 0xfe, 0x8e, 0xb7, 0x49,
-	0xa6, 0xa6, 0x94, 0x9e, 0x8e, 0x8a, 0xd6, 0xeb, 0x52, 0xa2, 0x27, 0xaa, 0x42, 0x9c, 0x83, 0x3c,
-	0xa1, 0x50, 0x63, 0x75, 0x67, 0x03, 0xa7, 0x84, 0xe5, 0x54, 0xb0, 0x9c, 0x65, 0xa5, 0x98, 0x77,
-	0x0e, 0x3f, 0x47, 0x8d, 0x6f, 0xbf, 0x46, 0xc2, 0xfb, 0x6f, 0xb3, 0xff, 0x1a, 0x60, 0xbe, 0x2f,
-	0x69, 0x60, 0x0f, 0x8c, 0x30, 0xd0, 0xdd, 0x8d, 0x30, 0x40, 0x07, 0x3a, 0x51, 0x89, 0x27, 0xb7,
-	0x8c, 0x71, 0x73, 0xd2, 0x9d, 0xe1, 0x39, 0x39, 0xef, 0xa4, 0x41, 0x17, 0x64, 0xce, 0x7e, 0xc6,
-	0xf9, 0x8d, 0xcf, 0xb5, 0xe6, 0xe9, 0x94, 0x36, 0x97, 0xf1, 0x05, 0x98, 0x14, 0x07, 0xaa, 0x40,
-	0xab, 0x46, 0x81, 0x76, 0x61, 0x72, 0x19, 0xaf, 0x00, 0x76, 0x69, 0xe0, 0x33, 0x05, 0x45, 0x85,
-	0x8b, 0x3a, 0x48, 0xb4, 0xcf, 0xe5, 0x62, 0x6d, 0x4d, 0x38, 0xb7, 0xcc, 0xb3, 0xb5, 0xf5, 0xb9,
-	0xbc, 0x93, 0x06, 0x1f, 0x03, 0xac, 0x33, 0x52, 0x4d, 0x57, 0x7b, 0xab, 0xa3, 0xf0, 0x49, 0xfd,
-	0x33, 0xdf, 0xdf, 0xbd, 0x9f, 0xbc, 0x77, 0x3f, 0xfb, 0xab, 0x80, 0xee, 0x35, 0xe5, 0xdb, 0x8a,
-	0xff, 0x53, 0x30, 0x75, 0x1f, 0x75, 0x84, 0xfb, 0x7d, 0xb5, 0xc8, 0xab, 0x24, 0xc5, 0xae, 0xf4,
-	0x39, 0x0d, 0x33, 0x52, 0xb4, 0x8c, 0x3a, 0xbb, 0x6a, 0x9f, 0xcb, 0xf3, 0xfe, 0xe1, 0xcf, 0xb0,
-	0x71, 0x38, 0x0e, 0xc5, 0x8f, 0xe3, 0x50, 0xfc, 0x3e, 0x0e, 0xc5, 0xaa, 0xad, 0xac, 0xcf, 0xfe,
-	0x05, 0x00, 0x00, 0xff, 0xff, 0xad, 0x09, 0x3a, 0xe9, 0x90, 0x03, 0x00, 0x00,
+	// 483 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0xcf, 0xae, 0xd2, 0x40,
+	0x14, 0xc6, 0x99, 0xc2, 0xa5, 0x9d, 0x43, 0x2e, 0xe2, 0xc4, 0x68, 0x43, 0x22, 0x90, 0xae, 0x88,
+	0x9a, 0x92, 0xe0, 0x56, 0x17, 0xe5, 0x86, 0xb8, 0xf1, 0x7a, 0x75, 0xc4, 0xc4, 0x1d, 0x29, 0xf4,
+	0x08, 0x4d, 0x68, 0xa7, 0x69, 0x07, 0x23, 0x2b, 0x7d, 0x04, 0x9f, 0xc1, 0xc4, 0x77, 0x61, 0xe9,
+	0x13, 0xf8, 0x87, 0x27, 0x31, 0x9d, 0x99, 0xa2, 0x37, 0xac, 0xd8, 0xcd, 0x39, 0xe7, 0xfb, 0xce,
+	0x39, 0xf3, 0x3b, 0x70, 0x59, 0xc4, 0x1b, 0x4c, 0x97, 0xe8, 0x67, 0xb9, 0x90, 0x82, 0x51, 0x13,
+	0x66, 0x8b, 0x6e, 0x7f, 0x25, 0xc4, 0x6a, 0x83, 0x23, 0x55, 0x58, 0x6c, 0x3f, 0x8c, 0x64, 0x9c,
+	0x60, 0x21, 0xc3, 0x24, 0xd3, 0xda, 0xee, 0xbd, 0x95, 0x58, 0x09, 0xf5, 0x1c, 0x95, 0x2f, 0x9d,
+	0xf5, 0xbe, 0x11, 0xb0, 0xaf, 0x43, 0xb9, 0x5c, 0x63, 0xce, 0x1e, 0x43, 0x43, 0xee, 0x32, 0x74,
+	0xc9, 0x80, 0x0c, 0xdb, 0xe3, 0x07, 0xfe, 0xb1, 0xb9, 0x6f, 0x14, 0xfe, 0x6c, 0x97, 0x21, 0x57,
+	0x22, 0xc6, 0xa0, 0x91, 0x86, 0x09, 0xba, 0xd6, 0x80, 0x0c, 0x29, 0x57, 0x6f, 0xe6, 0x82, 0x9d,
+	0x85, 0x52, 0x62, 0x9e, 0xba, 0x75, 0x95, 0xae, 0x42, 0xef, 0x19, 0x34, 0x4a, 0x2f, 0xa3, 0x70,
+	0x31, 0x7d, 0xf3, 0x2e, 0x78, 0xd9, 0xa9, 0x31, 0x80, 0x26, 0x9f, 0xbe, 0x98, 0xbe, 0x7f, 0xdd,
+	0x21, 0xec, 0x12, 0xe8, 0xab, 0x9b, 0xd9, 0x5c, 0x97, 0x2c, 0xd6, 0x06, 0x28, 0x43, 0x53, 0xae,
+	0x7b, 0x9f, 0xc1, 0xbe, 0x12, 0x49, 0x82, 0xa9, 0x64, 0xf7, 0xa1, 0x19, 0x6e, 0xe5, 0x5a, 0xe4,
+	0x6a, 0x4b, 0xca, 0x4d, 0x54, 0x8e, 0x5e, 0x6a, 0x89, 0xd9, 0xa8, 0x0a, 0xd9, 0x04, 0xe8, 0x11,
+	0x85, 0x5a, 0xab, 0x35, 0xee, 0xfa, 0x1a, 0x96, 0x5f, 0xc1, 0xf2, 0x67, 0x95, 0x62, 0xe2, 0xec,
+	0x7f, 0xf6, 0x6b, 0x5f, 0x7f, 0xf5, 0x09, 0xff, 0x67, 0xf3, 0xbe, 0xd7, 0xc1, 0x7e, 0xab, 0x69,
+	0xb0, 0x36, 0x58, 0x71, 0x64, 0xa6, 0x5b, 0x71, 0xc4, 0x7c, 0x70, 0x12, 0x8d, 0xa7, 0x70, 0xad,
+	0x41, 0x7d, 0xd8, 0x1a, 0xb3, 0x53, 0x72, 0xfc, 0xa8, 0x61, 0x01, 0xd0, 0x42, 0x86, 0xb9, 0x2c,
+	0xe6, 0xa1, 0x3c, 0x6b, 0x1f, 0x47, 0xdb, 0x02, 0xc9, 0x9e, 0x83, 0x8d, 0x69, 0xa4, 0x1a, 0x34,
+	0xce, 0x68, 0xd0, 0x2c, 0x4d, 0x81, 0x64, 0x57, 0x00, 0xdb, 0x2c, 0x0a, 0x25, 0x46, 0x65, 0x87,
+	0x8b, 0x73, 0x90, 0x18, 0x5f, 0x20, 0xcb, 0x6f, 0x1b, 0xc2, 0x85, 0x6b, 0x9f, 0x7c, 0xdb, 0x9c,
+	0x8b, 0x1f, 0x35, 0xec, 0x21, 0xc0, 0x32, 0x47, 0x35, 0x74, 0xb1, 0x73, 0x1d, 0x85, 0x8f, 0x9a,
+	0xcc, 0x64, 0xf7, 0xff, 0xfd, 0xe8, 0xed, 0xfb, 0x3d, 0x82, 0xbb, 0xf8, 0x29, 0x8b, 0x73, 0x9c,
+	0x8b, 0x74, 0x9e, 0x63, 0x21, 0x36, 0x1f, 0xd1, 0x85, 0x01, 0x19, 0x3a, 0xfc, 0x8e, 0x2e, 0xdc,
+	0xa4, 0x5c, 0xa7, 0xbd, 0x2f, 0x04, 0x5a, 0xd7, 0x58, 0xac, 0xab, 0x5b, 0x3d, 0x01, 0xdb, 0xec,
+	0xa4, 0x0e, 0x76, 0x7b, 0x47, 0x23, 0xe2, 0x95, 0xa4, 0xe4, 0xa2, 0x1b, 0x2a, 0xb2, 0xd6, 0x39,
+	0x5c, 0x8c, 0x2f, 0x90, 0x93, 0xce, 0xfe, 0x4f, 0xaf, 0xb6, 0x3f, 0xf4, 0xc8, 0x8f, 0x43, 0x8f,
+	0xfc, 0x3e, 0xf4, 0xc8, 0xa2, 0xa9, 0xac, 0x4f, 0xff, 0x0e, 0x00, 0xb6, 0xfc, 0x67, 0x31, 0xbc,
+	0x03, 0x00, 0x00,
 }
 
 func (m *Matcher) Marshal() (dAtA []byte, err error) {
@@ -411,6 +415,16 @@ func (m *Silence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.ExpireOnResolve {
+		i--
+		if m.ExpireOnResolve {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x50
+	}
 	if len(m.Comment) > 0 {
 		i -= len(m.Comment)
 		copy(dAtA[i:], m.Comment)
@@ -626,6 +640,9 @@ func (m *Silence) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovSilence(uint64(l))
 	}
+	if m.ExpireOnResolve {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1230,6 +1247,26 @@ func (m *Silence) Unmarshal(dAtA []byte) error {
 			}
 			m.Comment = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpireOnResolve", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSilence
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ExpireOnResolve = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSilence(dAtA[iNdEx:])
@@ -0,0 +1,258 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package simulation replays timelines of alerts through the routing tree and
+// the notification timing of a configuration on a simulated clock.
+package simulation
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"gopkg.in/yaml.v2"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/config/receiver"
+	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/alertmanager/featurecontrol"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// Scenario is a timeline of alerts sent to the Alertmanager.
+type Scenario struct {
+	// StartTime is the time the scenario starts at, which matters to the
+	// time intervals. It defaults to the Unix epoch.
+	StartTime time.Time `yaml:"start_time,omitempty"`
+	// Duration is the minimum duration of the scenario, which otherwise ends
+	// at the last time of its alerts.
+	Duration model.Duration `yaml:"duration,omitempty"`
+	Alerts   []Alert        `yaml:"alerts"`
+}
+
+// Alert is an alert of a scenario, sent at a time relative to the start of
+// the scenario. It keeps firing until the end of the scenario unless it
+// resolves before.
+type Alert struct {
+	Labels      model.LabelSet  `yaml:"labels"`
+	Annotations model.LabelSet  `yaml:"annotations,omitempty"`
+	At          model.Duration  `yaml:"at"`
+	ResolveAt   *model.Duration `yaml:"resolve_at,omitempty"`
+}
+
+// LoadScenarioFile parses the given YAML file into a Scenario.
+func LoadScenarioFile(filename string) (*Scenario, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	s := &Scenario{}
+	if err := yaml.UnmarshalStrict(b, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Start returns the time the scenario starts at.
+func (s *Scenario) Start() time.Time {
+	if s.StartTime.IsZero() {
+		return time.Unix(0, 0).UTC()
+	}
+	return s.StartTime
+}
+
+// Notification is a notification sent to a receiver during a simulation.
+type Notification struct {
+	Time        time.Time        `json:"time"`
+	Receiver    string           `json:"receiver"`
+	GroupKey    string           `json:"groupKey"`
+	GroupLabels model.LabelSet   `json:"groupLabels"`
+	Firing      []model.LabelSet `json:"firing"`
+	Resolved    []model.LabelSet `json:"resolved"`
+}
+
+// Run replays the scenario through the routing tree of the configuration and
+// returns the notifications sent to the receivers, in order.
+//
+// The alerts are routed by an in-process dispatcher on a simulated clock,
+// honoring group_wait, group_interval, repeat_interval, send_resolved and the
+// time intervals of the routes. Inhibition rules and silences are not
+// applied: the alerts the Alertmanager would inhibit or silence are notified
+// like the others, so the simulated notifications differ from the real ones
+// for them.
+func Run(cfg *config.Config, s *Scenario) ([]*Notification, error) {
+	sendResolved, err := receiversSendResolved(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		start    = s.Start()
+		end      = start.Add(time.Duration(s.Duration))
+		recorder = &recorder{sendResolved: sendResolved, last: map[string]*Notification{}}
+		muter    = timeinterval.NewIntervener(cfg.TimeIntervalsByName())
+		marker   = types.NewMarker(prometheus.NewRegistry())
+		metrics  = notify.NewMetrics(prometheus.NewRegistry(), featurecontrol.NoopFlags{})
+		stage    = notify.MultiStage{
+			notify.NewTimeActiveStage(muter, marker, metrics),
+			notify.NewTimeMuteStage(muter, marker, metrics),
+			recorder,
+		}
+		sim = dispatch.NewSimulator(dispatch.NewRoute(cfg.Route, nil), stage, start, promslog.NewNopLogger())
+	)
+	sim.SetGroupLabelNormalizers(cfg.GroupLabelNormalizers)
+	at := func(d model.Duration) time.Time {
+		t := start.Add(time.Duration(d))
+		if t.After(end) {
+			end = t
+		}
+		return t
+	}
+
+	alerts := make([]*types.Alert, 0, len(s.Alerts))
+	for _, sa := range s.Alerts {
+		a := &types.Alert{
+			Alert: model.Alert{
+				Labels:      sa.Labels,
+				Annotations: sa.Annotations,
+				StartsAt:    at(sa.At),
+			},
+			UpdatedAt: at(sa.At),
+		}
+		if sa.ResolveAt != nil {
+			a.EndsAt = at(*sa.ResolveAt)
+		}
+		alerts = append(alerts, a)
+	}
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return alerts[i].StartsAt.Before(alerts[j].StartsAt)
+	})
+
+	for _, a := range alerts {
+		sim.Advance(a.StartsAt)
+		sim.Insert(a)
+	}
+	sim.Advance(end)
+
+	return recorder.notifications, nil
+}
+
+// receiversSendResolved returns whether any integration of the receivers
+// sends resolved notifications, by receiver name.
+func receiversSendResolved(cfg *config.Config) (map[string]bool, error) {
+	tmpl, err := template.FromGlobs(nil)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]bool, len(cfg.Receivers))
+	for _, rcv := range cfg.Receivers {
+		integrations, err := receiver.BuildReceiverIntegrations(rcv, tmpl, promslog.NewNopLogger())
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range integrations {
+			res[rcv.Name] = res[rcv.Name] || i.SendResolved()
+		}
+	}
+	return res, nil
+}
+
+// recorder records the notifications of the aggregation groups. Like the
+// notification log, it only lets through the notifications of groups whose
+// alerts changed since the last notification, or whose last notification is
+// older than the repeat interval. It replaces the DedupStage, which judges
+// the alerts resolved by the wall clock rather than the simulated one, and
+// must be kept in line with it.
+type recorder struct {
+	sendResolved  map[string]bool
+	last          map[string]*Notification
+	notifications []*Notification
+}
+
+// Exec implements the Stage interface.
+func (r *recorder) Exec(ctx context.Context, _ *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	now, _ := notify.Now(ctx)
+	gkey, _ := notify.GroupKey(ctx)
+	receiverName, _ := notify.ReceiverName(ctx)
+	groupLabels, _ := notify.GroupLabels(ctx)
+	repeatInterval, _ := notify.RepeatInterval(ctx)
+
+	n := &Notification{Time: now, Receiver: receiverName, GroupKey: gkey, GroupLabels: groupLabels}
+	for _, a := range alerts {
+		if a.ResolvedAt(now) {
+			n.Resolved = append(n.Resolved, a.Labels)
+		} else {
+			n.Firing = append(n.Firing, a.Labels)
+		}
+	}
+
+	sendResolved := r.sendResolved[receiverName]
+	if !needsUpdate(r.last[gkey], n, sendResolved, repeatInterval) {
+		return ctx, nil, nil
+	}
+	r.last[gkey] = n
+
+	// Integrations not sending resolved notifications only send the firing
+	// alerts, if any.
+	if !sendResolved {
+		if len(n.Firing) == 0 {
+			return ctx, alerts, nil
+		}
+		sent := *n
+		sent.Resolved = nil
+		n = &sent
+	}
+	r.notifications = append(r.notifications, n)
+	return ctx, alerts, nil
+}
+
+// needsUpdate mirrors the decision of the DedupStage. As the notification log
+// records the time of the previous notification, reminders are sent at the
+// first flush after the repeat interval has passed.
+func needsUpdate(prev, n *Notification, sendResolved bool, repeat time.Duration) bool {
+	if prev == nil {
+		return len(n.Firing) > 0
+	}
+	if !isSubset(n.Firing, prev.Firing) {
+		return true
+	}
+	if len(n.Firing) == 0 {
+		return len(prev.Firing) > 0
+	}
+	if sendResolved && !isSubset(n.Resolved, prev.Resolved) {
+		return true
+	}
+	return prev.Time.Before(n.Time.Add(-repeat))
+}
+
+// isSubset returns true if all label sets of a are in b.
+func isSubset(a, b []model.LabelSet) bool {
+	set := make(map[model.Fingerprint]struct{}, len(b))
+	for _, ls := range b {
+		set[ls.Fingerprint()] = struct{}{}
+	}
+	for _, ls := range a {
+		if _, ok := set[ls.Fingerprint()]; !ok {
+			return false
+		}
+	}
+	return true
+}
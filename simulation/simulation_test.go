@@ -0,0 +1,113 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/config"
+)
+
+func TestRun(t *testing.T) {
+	cfg, err := config.Load(`
+route:
+  receiver: default
+  group_by: [alertname]
+  group_wait: 30s
+  group_interval: 5m
+  repeat_interval: 1h
+  routes:
+  - matchers: [team="db"]
+    receiver: db
+receivers:
+- name: default
+  webhook_configs:
+  - url: http://localhost:8080/
+    send_resolved: false
+- name: db
+  webhook_configs:
+  - url: http://localhost:8080/
+`)
+	require.NoError(t, err)
+
+	resolveAt := model.Duration(10 * time.Minute)
+	s := &Scenario{
+		Duration: model.Duration(90 * time.Minute),
+		Alerts: []Alert{
+			{Labels: model.LabelSet{"alertname": "DatabaseDown", "team": "db"}, ResolveAt: &resolveAt},
+			{Labels: model.LabelSet{"alertname": "DiskFull"}, ResolveAt: &resolveAt},
+			{Labels: model.LabelSet{"alertname": "HighLatency"}, At: model.Duration(time.Minute)},
+		},
+	}
+	notifications, err := Run(cfg, s)
+	require.NoError(t, err)
+
+	type sent struct {
+		at       time.Duration
+		receiver string
+		firing   int
+		resolved int
+	}
+	var got []sent
+	for _, n := range notifications {
+		got = append(got, sent{n.Time.Sub(s.Start()), n.Receiver, len(n.Firing), len(n.Resolved)})
+	}
+	require.Equal(t, []sent{
+		{30 * time.Second, "db", 1, 0},
+		{30 * time.Second, "default", 1, 0},
+		{90 * time.Second, "default", 1, 0},
+		// Resolved notifications are only sent to the receivers sending
+		// them.
+		{10*time.Minute + 30*time.Second, "db", 0, 1},
+		// Reminders are sent at the first flush after the repeat interval.
+		{66*time.Minute + 30*time.Second, "default", 1, 0},
+	}, got)
+}
+
+func TestRunIgnoresInhibitRules(t *testing.T) {
+	cfg, err := config.Load(`
+route:
+  receiver: default
+  group_by: [alertname]
+  group_wait: 30s
+receivers:
+- name: default
+inhibit_rules:
+- source_matchers: [severity="critical"]
+  target_matchers: [severity="warning"]
+`)
+	require.NoError(t, err)
+
+	s := &Scenario{
+		Duration: model.Duration(time.Minute),
+		Alerts: []Alert{
+			{Labels: model.LabelSet{"alertname": "DatabaseDown", "severity": "critical"}},
+			{Labels: model.LabelSet{"alertname": "HighLatency", "severity": "warning"}},
+		},
+	}
+	notifications, err := Run(cfg, s)
+	require.NoError(t, err)
+
+	// The Alertmanager would inhibit HighLatency, but the simulation notifies
+	// it like the other alerts.
+	var alertnames []model.LabelValue
+	for _, n := range notifications {
+		alertnames = append(alertnames, n.GroupLabels["alertname"])
+	}
+	require.Equal(t, []model.LabelValue{"DatabaseDown", "HighLatency"}, alertnames)
+}
@@ -0,0 +1,454 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package slackapp connects Alertmanager to a Slack app in socket mode, so
+// that the buttons of Slack notifications can silence or acknowledge the
+// alerts of their group without exposing Alertmanager to Slack.
+// See https://api.slack.com/apis/socket-mode.
+package slackapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/websocket"
+
+	"github.com/prometheus/alertmanager/ack"
+	"github.com/prometheus/alertmanager/provider"
+	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	// ActionSilence is the name of the buttons silencing the group of the
+	// notification.
+	ActionSilence = "alertmanager_silence"
+	// ActionAck is the name of the buttons acknowledging the firing alerts
+	// of the group of the notification.
+	ActionAck = "alertmanager_ack"
+)
+
+// DefaultAPIURL is the URL of the Slack Web API.
+const DefaultAPIURL = "https://slack.com/api/"
+
+// EncodeGroup returns the value of the buttons acting on the group with the
+// given labels.
+func EncodeGroup(groupLabels model.LabelSet) (string, error) {
+	b, err := json.Marshal(groupLabels)
+	return string(b), err
+}
+
+func decodeGroup(value string) (model.LabelSet, error) {
+	var ls model.LabelSet
+	if err := json.Unmarshal([]byte(value), &ls); err != nil {
+		return nil, fmt.Errorf("invalid group: %w", err)
+	}
+	if len(ls) == 0 {
+		return nil, errors.New("the group has no labels")
+	}
+	return ls, ls.Validate()
+}
+
+// Silencer creates silences.
+type Silencer interface {
+	Set(*silencepb.Silence) error
+}
+
+// Options configures an App.
+type Options struct {
+	// Token is the app-level token of the app, with the
+	// connections:write scope.
+	Token string
+	// APIURL is the URL of the Slack Web API. Defaults to DefaultAPIURL.
+	APIURL string
+	// SilenceDuration is how long the silences created from Slack last.
+	SilenceDuration time.Duration
+	// ExternalURL is the URL of Alertmanager, linked from the replies to
+	// the buttons.
+	ExternalURL *url.URL
+	// RetryInterval is how long to wait before reconnecting after a
+	// failure.
+	RetryInterval time.Duration
+}
+
+// App receives the interactions with the buttons of the notifications from
+// Slack. The silences and acknowledgements are recorded as created by the
+// Slack user who clicked the button.
+type App struct {
+	token           string
+	apiURL          string
+	silenceDuration time.Duration
+	externalURL     *url.URL
+	retryInterval   time.Duration
+	client          *http.Client
+
+	silences Silencer
+	acks     *ack.Acks
+	alerts   provider.Alerts
+	logger   *slog.Logger
+
+	actions       *prometheus.CounterVec
+	actionsFailed *prometheus.CounterVec
+}
+
+// New returns a new App. If acks is nil, the acknowledge buttons are
+// rejected.
+func New(o Options, silences Silencer, acks *ack.Acks, alerts provider.Alerts, l *slog.Logger, r prometheus.Registerer) (*App, error) {
+	if o.Token == "" {
+		return nil, errors.New("missing app-level token")
+	}
+	if o.APIURL == "" {
+		o.APIURL = DefaultAPIURL
+	}
+	if o.SilenceDuration <= 0 {
+		return nil, errors.New("silence duration must be positive")
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = 5 * time.Second
+	}
+	a := &App{
+		token:           o.Token,
+		apiURL:          strings.TrimSuffix(o.APIURL, "/") + "/",
+		silenceDuration: o.SilenceDuration,
+		externalURL:     o.ExternalURL,
+		retryInterval:   o.RetryInterval,
+		client:          &http.Client{Timeout: 30 * time.Second},
+		silences:        silences,
+		acks:            acks,
+		alerts:          alerts,
+		logger:          l,
+		actions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_slack_app_actions_total",
+			Help: "The total number of button clicks received from the Slack app.",
+		}, []string{"action"}),
+		actionsFailed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "alertmanager_slack_app_actions_failed_total",
+			Help: "The total number of button clicks received from the Slack app which failed to be handled.",
+		}, []string{"action"}),
+	}
+	for _, action := range []string{ActionSilence, ActionAck} {
+		a.actions.WithLabelValues(action)
+		a.actionsFailed.WithLabelValues(action)
+	}
+	if r != nil {
+		r.MustRegister(a.actions, a.actionsFailed)
+	}
+	return a, nil
+}
+
+// Run connects to Slack and handles the interactions until ctx is
+// canceled, reconnecting whenever Slack asks to or the connection fails.
+func (a *App) Run(ctx context.Context) {
+	for {
+		if err := a.connect(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			a.logger.Error("Slack app connection failed", "err", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(a.retryInterval):
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// openConnection returns the URL of a new WebSocket connection.
+func (a *App) openConnection(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.apiURL+"apps.connections.open", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+		URL   string `json:"url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return "", fmt.Errorf("unexpected response to apps.connections.open (status %d): %w", resp.StatusCode, err)
+	}
+	if !res.OK {
+		return "", fmt.Errorf("apps.connections.open failed: %s", res.Error)
+	}
+	return res.URL, nil
+}
+
+// envelope is a message of the socket mode connection.
+type envelope struct {
+	Type       string          `json:"type"`
+	EnvelopeID string          `json:"envelope_id"`
+	Reason     string          `json:"reason"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// connect handles the envelopes of a single connection until Slack asks to
+// disconnect.
+func (a *App) connect(ctx context.Context) error {
+	u, err := a.openConnection(ctx)
+	if err != nil {
+		return err
+	}
+	cfg, err := websocket.NewConfig(u, a.apiURL)
+	if err != nil {
+		return err
+	}
+	conn, err := cfg.DialContext(ctx)
+	if err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		conn.Close()
+	}()
+
+	for {
+		var env envelope
+		if err := websocket.JSON.Receive(conn, &env); err != nil {
+			return err
+		}
+		if env.EnvelopeID != "" {
+			// Slack retries the envelopes which aren't acknowledged
+			// within 3 seconds.
+			if err := websocket.JSON.Send(conn, struct {
+				EnvelopeID string `json:"envelope_id"`
+			}{env.EnvelopeID}); err != nil {
+				return err
+			}
+		}
+		switch env.Type {
+		case "hello":
+			a.logger.Debug("Connected to Slack")
+		case "disconnect":
+			a.logger.Debug("Slack asked to reconnect", "reason", env.Reason)
+			return nil
+		case "interactive":
+			go a.interact(ctx, env.Payload)
+		}
+	}
+}
+
+// interaction is the payload of a click on a button, either of a message
+// attachment or of a block.
+type interaction struct {
+	Type    string `json:"type"`
+	Actions []struct {
+		Name     string `json:"name"`
+		ActionID string `json:"action_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+	User struct {
+		ID       string `json:"id"`
+		Name     string `json:"name"`
+		Username string `json:"username"`
+	} `json:"user"`
+	ResponseURL string `json:"response_url"`
+}
+
+// interact handles the clicks of the buttons and replies to them in the
+// channel of the notification.
+func (a *App) interact(ctx context.Context, payload json.RawMessage) {
+	var in interaction
+	if err := json.Unmarshal(payload, &in); err != nil {
+		a.logger.Warn("Failed to decode Slack interaction", "err", err)
+		return
+	}
+	user := in.User.Username
+	if user == "" {
+		user = in.User.Name
+	}
+	if user == "" {
+		user = in.User.ID
+	}
+	by := "slack:" + user
+
+	for _, action := range in.Actions {
+		name := action.Name
+		if name == "" {
+			name = action.ActionID
+		}
+		var (
+			reply string
+			err   error
+		)
+		switch name {
+		case ActionSilence:
+			reply, err = a.silence(action.Value, by)
+		case ActionAck:
+			reply, err = a.ack(action.Value, by)
+		default:
+			// The buttons of other apps or templates.
+			continue
+		}
+		a.actions.WithLabelValues(name).Inc()
+		if err != nil {
+			a.actionsFailed.WithLabelValues(name).Inc()
+			a.logger.Warn("Failed to handle Slack action", "action", name, "user", by, "err", err)
+			reply = fmt.Sprintf("<@%s> failed to %s: %s", in.User.ID, actionVerb(name), err)
+		} else {
+			a.logger.Info("Handled Slack action", "action", name, "user", by)
+			reply = fmt.Sprintf("<@%s> %s", in.User.ID, reply)
+		}
+		if in.ResponseURL != "" {
+			if err := a.respond(ctx, in.ResponseURL, reply); err != nil {
+				a.logger.Warn("Failed to reply to Slack action", "action", name, "err", err)
+			}
+		}
+	}
+}
+
+func actionVerb(action string) string {
+	if action == ActionAck {
+		return "acknowledge the alerts"
+	}
+	return "silence the alerts"
+}
+
+// silence creates a silence matching the labels of the group.
+func (a *App) silence(value, by string) (string, error) {
+	group, err := decodeGroup(value)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	sil := &silencepb.Silence{
+		StartsAt:  now,
+		EndsAt:    now.Add(a.silenceDuration),
+		CreatedBy: by,
+		Comment:   "Created from Slack.",
+	}
+	names := make([]string, 0, len(group))
+	for name := range group {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sil.Matchers = append(sil.Matchers, &silencepb.Matcher{
+			Type:    silencepb.Matcher_EQUAL,
+			Name:    name,
+			Pattern: string(group[model.LabelName(name)]),
+		})
+	}
+	if err := a.silences.Set(sil); err != nil {
+		return "", err
+	}
+
+	reply := fmt.Sprintf("silenced %s for %s", group, model.Duration(a.silenceDuration))
+	if a.externalURL != nil {
+		reply += fmt.Sprintf(" (<%s/#/silences/%s|silence>)", strings.TrimSuffix(a.externalURL.String(), "/"), sil.Id)
+	}
+	return reply + ".", nil
+}
+
+// ack acknowledges the firing alerts of the group.
+func (a *App) ack(value, by string) (string, error) {
+	if a.acks == nil {
+		return "", errors.New("acknowledgements are not enabled")
+	}
+	group, err := decodeGroup(value)
+	if err != nil {
+		return "", err
+	}
+
+	var firing []*types.Alert
+	it := a.alerts.GetPending()
+	now := time.Now()
+	for alert := range it.Next() {
+		if !alert.ResolvedAt(now) && matches(alert.Labels, group) {
+			firing = append(firing, alert)
+		}
+	}
+	err = it.Err()
+	it.Close()
+	if err != nil {
+		return "", err
+	}
+
+	var n int
+	for _, alert := range firing {
+		if _, err := a.acks.Ack(alert, by, "Acknowledged from Slack.", time.Time{}); err != nil {
+			if errors.Is(err, ack.ErrNotFiring) {
+				continue
+			}
+			return "", err
+		}
+		n++
+	}
+	if n == 0 {
+		return "", fmt.Errorf("no firing alerts in %s", group)
+	}
+	return fmt.Sprintf("acknowledged %d firing alerts of %s.", n, group), nil
+}
+
+func matches(ls, group model.LabelSet) bool {
+	for name, value := range group {
+		if ls[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// respond posts the reply to the interaction in the channel, keeping the
+// original message.
+func (a *App) respond(ctx context.Context, responseURL, text string) error {
+	b, err := json.Marshal(map[string]any{
+		"response_type":    "in_channel",
+		"replace_original": false,
+		"text":             text,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, responseURL, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
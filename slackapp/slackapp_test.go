@@ -0,0 +1,210 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slackapp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"github.com/prometheus/alertmanager/ack"
+	"github.com/prometheus/alertmanager/provider/mem"
+	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/prometheus/alertmanager/types"
+)
+
+type fakeSilences struct {
+	mtx      sync.Mutex
+	silences []*silencepb.Silence
+}
+
+func (s *fakeSilences) Set(sil *silencepb.Silence) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	sil.Id = "s1"
+	s.silences = append(s.silences, sil)
+	return nil
+}
+
+// fakeSlack serves apps.connections.open and a socket mode connection
+// sending the given payloads as interactions.
+type fakeSlack struct {
+	t        *testing.T
+	srv      *httptest.Server
+	payloads []string
+
+	acked   chan string
+	replies chan string
+	// replied orders the interactions, which are handled concurrently.
+	replied chan struct{}
+}
+
+func newFakeSlack(t *testing.T, payloads ...string) *fakeSlack {
+	s := &fakeSlack{
+		t:        t,
+		payloads: payloads,
+		acked:    make(chan string, len(payloads)),
+		replies:  make(chan string, len(payloads)),
+		replied:  make(chan struct{}, len(payloads)),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/apps.connections.open", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer xapp-1" {
+			json.NewEncoder(w).Encode(map[string]any{"ok": false, "error": "invalid_auth"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "url": "ws" + strings.TrimPrefix(s.srv.URL, "http") + "/link"})
+	})
+	mux.Handle("/link", websocket.Handler(s.serve))
+	mux.HandleFunc("/response", func(w http.ResponseWriter, r *http.Request) {
+		var reply struct {
+			Text string `json:"text"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&reply))
+		s.replies <- reply.Text
+		s.replied <- struct{}{}
+	})
+	s.srv = httptest.NewServer(mux)
+	return s
+}
+
+func (s *fakeSlack) serve(conn *websocket.Conn) {
+	require.NoError(s.t, websocket.JSON.Send(conn, map[string]any{"type": "hello"}))
+	for i, p := range s.payloads {
+		p = strings.ReplaceAll(p, "RESPONSE_URL", s.srv.URL+"/response")
+		id := string(rune('a' + i))
+		require.NoError(s.t, websocket.JSON.Send(conn, map[string]any{
+			"type":        "interactive",
+			"envelope_id": id,
+			"payload":     json.RawMessage(p),
+		}))
+		var ack struct {
+			EnvelopeID string `json:"envelope_id"`
+		}
+		if err := websocket.JSON.Receive(conn, &ack); err != nil {
+			return
+		}
+		s.acked <- ack.EnvelopeID
+		<-s.replied
+	}
+	// Wait for the client to close the connection.
+	var v any
+	websocket.JSON.Receive(conn, &v)
+}
+
+func TestSilenceAndAck(t *testing.T) {
+	slack := newFakeSlack(t,
+		`{"type":"interactive_message","actions":[{"name":"alertmanager_silence","value":"{\"alertname\":\"DiskFull\"}"}],"user":{"id":"U1","name":"alice"},"response_url":"RESPONSE_URL"}`,
+		`{"type":"block_actions","actions":[{"action_id":"alertmanager_ack","value":"{\"alertname\":\"DiskFull\"}"}],"user":{"id":"U2","username":"bob"},"response_url":"RESPONSE_URL"}`,
+	)
+	defer slack.srv.Close()
+
+	alerts, err := mem.NewAlerts(context.Background(), types.NewMarker(prometheus.NewRegistry()), time.Hour, nil, promslog.NewNopLogger(), nil)
+	require.NoError(t, err)
+	defer alerts.Close()
+	firing := &types.Alert{Alert: model.Alert{
+		Labels:   model.LabelSet{"alertname": "DiskFull", "instance": "a"},
+		StartsAt: time.Now().Add(-time.Minute),
+		EndsAt:   time.Now().Add(time.Hour),
+	}}
+	other := &types.Alert{Alert: model.Alert{
+		Labels:   model.LabelSet{"alertname": "NodeDown"},
+		StartsAt: time.Now().Add(-time.Minute),
+		EndsAt:   time.Now().Add(time.Hour),
+	}}
+	require.NoError(t, alerts.Put(firing, other))
+
+	silences := &fakeSilences{}
+	acks := ack.New(ack.Options{DefaultDuration: time.Hour})
+	app, err := New(Options{
+		Token:           "xapp-1",
+		APIURL:          slack.srv.URL + "/api",
+		SilenceDuration: 2 * time.Hour,
+	}, silences, acks, alerts, promslog.NewNopLogger(), prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go app.Run(ctx)
+
+	require.Equal(t, "a", <-slack.acked)
+	require.Equal(t, "<@U1> silenced {alertname=\"DiskFull\"} for 2h.", <-slack.replies)
+	silences.mtx.Lock()
+	require.Len(t, silences.silences, 1)
+	sil := silences.silences[0]
+	silences.mtx.Unlock()
+	require.Equal(t, "slack:alice", sil.CreatedBy)
+	require.Equal(t, []*silencepb.Matcher{{Type: silencepb.Matcher_EQUAL, Name: "alertname", Pattern: "DiskFull"}}, sil.Matchers)
+	require.Equal(t, 2*time.Hour, sil.EndsAt.Sub(sil.StartsAt))
+
+	require.Equal(t, "b", <-slack.acked)
+	require.Equal(t, "<@U2> acknowledged 1 firing alerts of {alertname=\"DiskFull\"}.", <-slack.replies)
+	a := acks.Get(firing)
+	require.NotNil(t, a)
+	require.Equal(t, "slack:bob", a.AcknowledgedBy)
+	require.Nil(t, acks.Get(other))
+}
+
+func TestInvalidActions(t *testing.T) {
+	slack := newFakeSlack(t,
+		`{"type":"interactive_message","actions":[{"name":"alertmanager_silence","value":"{}"}],"user":{"id":"U1","name":"alice"},"response_url":"RESPONSE_URL"}`,
+		`{"type":"interactive_message","actions":[{"name":"alertmanager_ack","value":"{\"alertname\":\"DiskFull\"}"}],"user":{"id":"U1","name":"alice"},"response_url":"RESPONSE_URL"}`,
+	)
+	defer slack.srv.Close()
+
+	alerts, err := mem.NewAlerts(context.Background(), types.NewMarker(prometheus.NewRegistry()), time.Hour, nil, promslog.NewNopLogger(), nil)
+	require.NoError(t, err)
+	defer alerts.Close()
+
+	silences := &fakeSilences{}
+	app, err := New(Options{
+		Token:           "xapp-1",
+		APIURL:          slack.srv.URL + "/api",
+		SilenceDuration: time.Hour,
+	}, silences, nil, alerts, promslog.NewNopLogger(), nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go app.Run(ctx)
+
+	require.Equal(t, "<@U1> failed to silence the alerts: the group has no labels", <-slack.replies)
+	require.Equal(t, "<@U1> failed to acknowledge the alerts: acknowledgements are not enabled", <-slack.replies)
+	require.Empty(t, silences.silences)
+}
+
+func TestInvalidToken(t *testing.T) {
+	slack := newFakeSlack(t)
+	defer slack.srv.Close()
+
+	app, err := New(Options{
+		Token:           "xapp-2",
+		APIURL:          slack.srv.URL + "/api",
+		SilenceDuration: time.Hour,
+	}, &fakeSilences{}, nil, nil, promslog.NewNopLogger(), nil)
+	require.NoError(t, err)
+	_, err = app.openConnection(context.Background())
+	require.EqualError(t, err, "apps.connections.open failed: invalid_auth")
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package snapshot verifies and repairs the snapshot files of the silences
+// and the notification log. Both are written as a sequence of
+// length-delimited protobuf messages.
+package snapshot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/prometheus/alertmanager/encryption"
+)
+
+// maxEntrySize is the largest entry that is considered valid. Larger length
+// prefixes are treated as corruption.
+const maxEntrySize = 64 << 20
+
+// EntryInfo describes a successfully decoded entry.
+type EntryInfo struct {
+	// Legacy is true if the entry was written in an older format that is
+	// upgraded when it is loaded.
+	Legacy bool
+	// Expired is true if the entry is past its retention and will be
+	// removed by the next garbage collection.
+	Expired bool
+}
+
+// DecodeFunc decodes a single entry.
+type DecodeFunc func([]byte) (EntryInfo, error)
+
+// Report summarizes the verification of a snapshot.
+type Report struct {
+	// Encrypted is true if the snapshot was encrypted.
+	Encrypted bool
+	// Entries is the number of entries that could be decoded.
+	Entries int
+	// Legacy is the number of decoded entries in an older format.
+	Legacy int
+	// Expired is the number of decoded entries past their retention.
+	Expired int
+	// Dropped is the number of entries that could not be decoded.
+	Dropped int
+	// TruncatedBytes is the number of trailing bytes that could not be
+	// split into entries.
+	TruncatedBytes int
+	// Errors holds the decoding errors of the dropped entries and of the
+	// truncated data.
+	Errors []error
+}
+
+// OK returns true if the snapshot can be loaded without data loss.
+func (r Report) OK() bool {
+	return r.Dropped == 0 && r.TruncatedBytes == 0
+}
+
+// String implements the fmt.Stringer interface.
+func (r Report) String() string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "entries=%d legacy=%d expired=%d dropped=%d truncated_bytes=%d encrypted=%t",
+		r.Entries, r.Legacy, r.Expired, r.Dropped, r.TruncatedBytes, r.Encrypted)
+	return buf.String()
+}
+
+// Verify reads all entries of the snapshot from r and decodes them. Entries
+// that cannot be decoded are counted as dropped. If w is not nil, a repaired
+// snapshot that contains only the decodable entries is written to it,
+// encrypted with c if c is not nil.
+func Verify(r io.Reader, c *encryption.Cipher, decode DecodeFunc, w io.Writer) (Report, error) {
+	var rep Report
+
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return rep, err
+	}
+	rep.Encrypted = encryption.IsEncrypted(raw)
+	plain, err := encryption.Open(c, bytes.NewReader(raw))
+	if err != nil {
+		return rep, err
+	}
+
+	data, err := io.ReadAll(plain)
+	if err != nil {
+		return rep, err
+	}
+
+	var (
+		out bytes.Buffer
+		pos int
+	)
+	for pos < len(data) {
+		size, n := binary.Uvarint(data[pos:])
+		var err error
+		switch {
+		case n <= 0:
+			err = errors.New("invalid entry length")
+		case size > maxEntrySize:
+			err = fmt.Errorf("entry length %d exceeds limit", size)
+		case uint64(len(data)-pos-n) < size:
+			err = fmt.Errorf("entry length %d exceeds remaining data", size)
+		}
+		if err != nil {
+			rep.TruncatedBytes = len(data) - pos
+			rep.Errors = append(rep.Errors, fmt.Errorf("offset %d: %w", pos, err))
+			break
+		}
+		entry := data[pos : pos+n+int(size)]
+		pos += len(entry)
+
+		info, err := decode(entry[n:])
+		if err != nil {
+			rep.Dropped++
+			rep.Errors = append(rep.Errors, fmt.Errorf("entry %d: %w", rep.Entries+rep.Dropped, err))
+			continue
+		}
+		rep.Entries++
+		if info.Legacy {
+			rep.Legacy++
+		}
+		if info.Expired {
+			rep.Expired++
+		}
+		out.Write(entry)
+	}
+
+	if w == nil {
+		return rep, nil
+	}
+	b, err := encryption.Seal(c, out.Bytes())
+	if err != nil {
+		return rep, err
+	}
+	_, err = w.Write(b)
+	return rep, err
+}
+
+// VerifyFunc verifies a snapshot read from r and writes a repaired snapshot
+// to w if w is not nil.
+type VerifyFunc func(r io.Reader, w io.Writer) (Report, error)
+
+// VerifyFile verifies the given snapshot file. If repair is true and the
+// snapshot is not OK, the file is replaced with the repaired snapshot and the
+// original file is kept with a ".bak" suffix.
+func VerifyFile(filename string, repair bool, verify VerifyFunc) (Report, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return Report{}, err
+	}
+	defer f.Close()
+
+	rep, err := verify(f, nil)
+	if err != nil || rep.OK() || !repair {
+		return rep, err
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return rep, err
+	}
+	tmp := filename + ".repair"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return rep, err
+	}
+	if _, err := verify(f, out); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return rep, err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return rep, err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmp)
+		return rep, err
+	}
+	if err := os.Rename(filename, filename+".bak"); err != nil {
+		os.Remove(tmp)
+		return rep, err
+	}
+	return rep, os.Rename(tmp, filename)
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/encryption"
+)
+
+func entry(s string) []byte {
+	return append(binary.AppendUvarint(nil, uint64(len(s))), s...)
+}
+
+// decode accepts entries starting with "ok" and marks entries ending with
+// "old" as legacy.
+func decode(b []byte) (EntryInfo, error) {
+	if !bytes.HasPrefix(b, []byte("ok")) {
+		return EntryInfo{}, errors.New("bad entry")
+	}
+	return EntryInfo{Legacy: bytes.HasSuffix(b, []byte("old"))}, nil
+}
+
+func TestVerify(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		data     []byte
+		report   Report
+		repaired []byte
+	}{
+		{
+			name:   "empty",
+			report: Report{},
+		},
+		{
+			name:     "valid",
+			data:     bytes.Join([][]byte{entry("ok1"), entry("ok2-old")}, nil),
+			report:   Report{Entries: 2, Legacy: 1},
+			repaired: bytes.Join([][]byte{entry("ok1"), entry("ok2-old")}, nil),
+		},
+		{
+			name:     "undecodable entry",
+			data:     bytes.Join([][]byte{entry("ok1"), entry("bad"), entry("ok3")}, nil),
+			report:   Report{Entries: 2, Dropped: 1},
+			repaired: bytes.Join([][]byte{entry("ok1"), entry("ok3")}, nil),
+		},
+		{
+			name:     "truncated",
+			data:     bytes.Join([][]byte{entry("ok1"), entry("ok2")[:3]}, nil),
+			report:   Report{Entries: 1, TruncatedBytes: 3},
+			repaired: entry("ok1"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			rep, err := Verify(bytes.NewReader(tc.data), nil, decode, &out)
+			require.NoError(t, err)
+			require.Equal(t, tc.report.OK(), rep.OK())
+			rep.Errors = nil
+			require.Equal(t, tc.report, rep)
+			require.Equal(t, tc.repaired, out.Bytes())
+		})
+	}
+}
+
+func TestVerifyEncrypted(t *testing.T) {
+	c, err := encryption.New(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	data, err := c.Encrypt(bytes.Join([][]byte{entry("ok1"), entry("bad")}, nil))
+	require.NoError(t, err)
+
+	_, err = Verify(bytes.NewReader(data), nil, decode, nil)
+	require.ErrorIs(t, err, encryption.ErrMissingKey)
+
+	var out bytes.Buffer
+	rep, err := Verify(bytes.NewReader(data), c, decode, &out)
+	require.NoError(t, err)
+	require.True(t, rep.Encrypted)
+	require.Equal(t, 1, rep.Entries)
+	require.Equal(t, 1, rep.Dropped)
+
+	repaired, err := c.Decrypt(out.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, entry("ok1"), repaired)
+}
+
+func TestVerifyFileRepair(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "silences")
+	data := bytes.Join([][]byte{entry("ok1"), entry("bad")}, nil)
+	require.NoError(t, os.WriteFile(filename, data, 0o600))
+
+	verify := func(r io.Reader, w io.Writer) (Report, error) {
+		return Verify(r, nil, decode, w)
+	}
+
+	rep, err := VerifyFile(filename, false, verify)
+	require.NoError(t, err)
+	require.False(t, rep.OK())
+	b, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.Equal(t, data, b, "file must not change without repair")
+
+	_, err = VerifyFile(filename, true, verify)
+	require.NoError(t, err)
+	b, err = os.ReadFile(filename)
+	require.NoError(t, err)
+	require.Equal(t, entry("ok1"), b)
+	b, err = os.ReadFile(filename + ".bak")
+	require.NoError(t, err)
+	require.Equal(t, data, b)
+
+	rep, err = VerifyFile(filename, true, verify)
+	require.NoError(t, err)
+	require.True(t, rep.OK())
+}
@@ -0,0 +1,564 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template/parse"
+
+	"github.com/prometheus/alertmanager/asset"
+)
+
+// Severities of the diagnostics reported by Lint.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// LintFile is a template file to be linted.
+type LintFile struct {
+	Name    string
+	Content string
+}
+
+// Diagnostic is a problem found by Lint in a template file.
+type Diagnostic struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s", d.File, d.Line, d.Column, d.Severity, d.Message)
+}
+
+// Lint parses template files the way they are loaded after the default
+// templates and reports, sorted by file and position:
+//   - the syntax errors,
+//   - the calls of unknown functions,
+//   - the fields which don't exist in the data they are evaluated on, the
+//     templates not called by other templates being given Data,
+//   - the calls of undefined templates,
+//   - the templates redefined by another file,
+//   - if entrypoints are given, the templates reachable neither from them
+//     nor from the default templates.
+func Lint(files []LintFile, entrypoints []string) ([]Diagnostic, error) {
+	l := &linter{
+		defs: map[string]*lintTree{},
+		dots: map[string]reflect.Type{},
+	}
+	for _, name := range defaultTemplates {
+		f, err := asset.Assets.Open(path.Join("/templates", name))
+		if err != nil {
+			return nil, err
+		}
+		b, err := io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		l.parse(LintFile{Content: string(b)}, true)
+	}
+	for _, f := range files {
+		l.parse(f, false)
+	}
+	l.infer(entrypoints)
+
+	l.report = true
+	for _, name := range l.names() {
+		if lt := l.defs[name]; !lt.isDefault {
+			l.walkTree(lt, l.dots[name])
+		}
+	}
+	if len(entrypoints) > 0 {
+		reachable := l.reachable(entrypoints)
+		for _, name := range l.names() {
+			if lt := l.defs[name]; !lt.isDefault && !lt.isFile && !reachable[name] {
+				l.add(lt, lt.tree.Root, SeverityWarning, "template %q is never used", name)
+			}
+		}
+	}
+
+	sort.SliceStable(l.diags, func(i, j int) bool {
+		a, b := l.diags[i], l.diags[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return l.diags, nil
+}
+
+var calledRegexp = regexp.MustCompile(`{{-?\s*template\s+\\?"([^"\\]+)\\?"`)
+
+// Called returns the names of the templates called by a text, such as the
+// configuration of Alertmanager, to be given as entrypoints to Lint.
+func Called(text string) []string {
+	var (
+		names []string
+		seen  = map[string]struct{}{}
+	)
+	for _, m := range calledRegexp.FindAllStringSubmatch(text, -1) {
+		if _, ok := seen[m[1]]; ok {
+			continue
+		}
+		seen[m[1]] = struct{}{}
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// lintTree is a template defined by a file.
+type lintTree struct {
+	tree      *parse.Tree
+	file      string
+	text      string
+	isDefault bool
+	// isFile is true for the template of the text of a file outside of its
+	// definitions.
+	isFile bool
+}
+
+type linter struct {
+	defs map[string]*lintTree
+	// dots holds the type of dot of the templates, nil if unknown.
+	dots  map[string]reflect.Type
+	diags []Diagnostic
+	// report is true once the types of dot are inferred, to report the
+	// problems found.
+	report bool
+	// calls is called with the templates called and their type of dot.
+	calls func(name string, dot reflect.Type)
+	cur   *lintTree
+}
+
+var parseErrorRegexp = regexp.MustCompile(`^template: (?:.*?):(\d+):\s*(.*)$`)
+
+func (l *linter) parse(f LintFile, isDefault bool) {
+	name := ""
+	if !isDefault {
+		name = filepath.Base(f.Name)
+	}
+	trees := map[string]*parse.Tree{}
+	t := parse.New(name)
+	t.Mode = parse.SkipFuncCheck
+	if _, err := t.Parse(f.Content, "", "", trees); err != nil {
+		d := Diagnostic{File: f.Name, Line: 1, Column: 1, Severity: SeverityError, Message: err.Error()}
+		if m := parseErrorRegexp.FindStringSubmatch(err.Error()); m != nil {
+			d.Line, _ = strconv.Atoi(m[1])
+			d.Message = m[2]
+		}
+		l.diags = append(l.diags, d)
+	}
+
+	names := make([]string, 0, len(trees))
+	for n := range trees {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		tree := trees[n]
+		if parse.IsEmptyTree(tree.Root) {
+			// Empty templates don't replace the defined ones.
+			if _, ok := l.defs[n]; ok {
+				continue
+			}
+		}
+		lt := &lintTree{tree: tree, file: f.Name, text: f.Content, isDefault: isDefault, isFile: n == name}
+		if prev, ok := l.defs[n]; ok && !prev.isDefault && prev.file != f.Name {
+			l.add(lt, tree.Root, SeverityWarning, "template %q redefines the one of %s", n, prev.file)
+		}
+		l.defs[n] = lt
+	}
+}
+
+// names returns the names of the defined templates in order.
+func (l *linter) names() []string {
+	names := make([]string, 0, len(l.defs))
+	for n := range l.defs {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// callees calls f with the name of each template called by a tree.
+func callees(n parse.Node, f func(string)) {
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			callees(c, f)
+		}
+	case *parse.IfNode:
+		callees(n.List, f)
+		callees(n.ElseList, f)
+	case *parse.RangeNode:
+		callees(n.List, f)
+		callees(n.ElseList, f)
+	case *parse.WithNode:
+		callees(n.List, f)
+		callees(n.ElseList, f)
+	case *parse.TemplateNode:
+		f(n.Name)
+	}
+}
+
+var dataType = reflect.TypeOf(Data{})
+
+// infer infers the type of dot of the templates from their calls, the
+// templates not called by other templates being given Data.
+func (l *linter) infer(entrypoints []string) {
+	called := map[string]bool{}
+	for _, lt := range l.defs {
+		callees(lt.tree.Root, func(name string) { called[name] = true })
+	}
+
+	var queue []string
+	known := map[string]bool{}
+	set := func(name string, dot reflect.Type) {
+		if _, ok := l.defs[name]; !ok {
+			return
+		}
+		if !known[name] {
+			known[name] = true
+			l.dots[name] = dot
+			queue = append(queue, name)
+			return
+		}
+		if merged := mergeTypes(l.dots[name], dot); merged != l.dots[name] {
+			l.dots[name] = merged
+			queue = append(queue, name)
+		}
+	}
+	for _, name := range entrypoints {
+		set(name, dataType)
+	}
+	for _, name := range l.names() {
+		if !called[name] {
+			set(name, dataType)
+		}
+	}
+
+	l.calls = set
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		l.walkTree(l.defs[name], l.dots[name])
+	}
+	l.calls = nil
+}
+
+// mergeTypes returns the type of dot of a template called with dot of types
+// a and b, nil if unknown.
+func mergeTypes(a, b reflect.Type) reflect.Type {
+	switch {
+	case a == b:
+		return a
+	case a == nil || b == nil:
+		return nil
+	case a.Kind() == reflect.Slice && b.Kind() == reflect.Slice && a.Elem() == b.Elem():
+		// For instance Alerts and []Alert, only the common methods of
+		// which can be used.
+		return reflect.SliceOf(a.Elem())
+	}
+	return nil
+}
+
+// reachable returns the templates reachable from the entrypoints, the
+// templates of the text of the files and the default templates.
+func (l *linter) reachable(entrypoints []string) map[string]bool {
+	reachable := map[string]bool{}
+	var visit func(string)
+	visit = func(name string) {
+		lt, ok := l.defs[name]
+		if !ok || reachable[name] {
+			return
+		}
+		reachable[name] = true
+		callees(lt.tree.Root, visit)
+	}
+	for _, name := range entrypoints {
+		visit(name)
+	}
+	for _, name := range l.names() {
+		if lt := l.defs[name]; lt.isDefault || lt.isFile {
+			visit(name)
+		}
+	}
+	return reachable
+}
+
+// errorf reports a problem found walking the current template, once the
+// types of dot are inferred.
+func (l *linter) errorf(n parse.Node, format string, args ...interface{}) {
+	if l.report {
+		l.add(l.cur, n, SeverityError, format, args...)
+	}
+}
+
+func (l *linter) add(lt *lintTree, n parse.Node, severity, format string, args ...interface{}) {
+	pos := int(n.Position())
+	if pos > len(lt.text) {
+		pos = len(lt.text)
+	}
+	line := 1 + strings.Count(lt.text[:pos], "\n")
+	col := pos - strings.LastIndex(lt.text[:pos], "\n")
+	l.diags = append(l.diags, Diagnostic{
+		File:     lt.file,
+		Line:     line,
+		Column:   col,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) walkTree(lt *lintTree, dot reflect.Type) {
+	l.cur = lt
+	l.walk(dot, map[string]reflect.Type{"$": dot}, lt.tree.Root)
+}
+
+func copyVars(vars map[string]reflect.Type) map[string]reflect.Type {
+	c := make(map[string]reflect.Type, len(vars))
+	for k, v := range vars {
+		c[k] = v
+	}
+	return c
+}
+
+func (l *linter) walk(dot reflect.Type, vars map[string]reflect.Type, n parse.Node) {
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			l.walk(dot, vars, c)
+		}
+	case *parse.ActionNode:
+		l.pipe(dot, vars, n.Pipe)
+	case *parse.IfNode:
+		inner := copyVars(vars)
+		l.pipe(dot, inner, n.Pipe)
+		l.walk(dot, copyVars(inner), n.List)
+		l.walk(dot, copyVars(inner), n.ElseList)
+	case *parse.WithNode:
+		inner := copyVars(vars)
+		t := l.pipe(dot, inner, n.Pipe)
+		l.walk(t, copyVars(inner), n.List)
+		l.walk(dot, copyVars(inner), n.ElseList)
+	case *parse.RangeNode:
+		inner := copyVars(vars)
+		t := l.cmds(dot, inner, n.Pipe.Cmds)
+		key, elem := l.rangeTypes(n, t)
+		switch len(n.Pipe.Decl) {
+		case 1:
+			inner[n.Pipe.Decl[0].Ident[0]] = elem
+		case 2:
+			inner[n.Pipe.Decl[0].Ident[0]] = key
+			inner[n.Pipe.Decl[1].Ident[0]] = elem
+		}
+		l.walk(elem, copyVars(inner), n.List)
+		l.walk(dot, copyVars(inner), n.ElseList)
+	case *parse.TemplateNode:
+		var t reflect.Type
+		if n.Pipe != nil {
+			t = l.pipe(dot, copyVars(vars), n.Pipe)
+		}
+		if _, ok := l.defs[n.Name]; !ok {
+			l.errorf(n, "template %q not defined", n.Name)
+		}
+		if l.calls != nil {
+			l.calls(n.Name, t)
+		}
+	}
+}
+
+// rangeTypes returns the types of the keys and elements ranged over.
+func (l *linter) rangeTypes(n *parse.RangeNode, t reflect.Type) (reflect.Type, reflect.Type) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return nil, nil
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return reflect.TypeOf(0), t.Elem()
+	case reflect.Map:
+		return t.Key(), t.Elem()
+	case reflect.Chan:
+		return t.Elem(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return t, t
+	case reflect.Interface:
+		return nil, nil
+	}
+	l.errorf(n, "range can't iterate over %s", t)
+	return nil, nil
+}
+
+func (l *linter) pipe(dot reflect.Type, vars map[string]reflect.Type, p *parse.PipeNode) reflect.Type {
+	if p == nil {
+		return nil
+	}
+	t := l.cmds(dot, vars, p.Cmds)
+	for _, v := range p.Decl {
+		vars[v.Ident[0]] = t
+	}
+	return t
+}
+
+func (l *linter) cmds(dot reflect.Type, vars map[string]reflect.Type, cmds []*parse.CommandNode) reflect.Type {
+	var t reflect.Type
+	for _, c := range cmds {
+		t = l.cmd(dot, vars, c)
+	}
+	return t
+}
+
+func (l *linter) cmd(dot reflect.Type, vars map[string]reflect.Type, c *parse.CommandNode) reflect.Type {
+	for _, a := range c.Args[1:] {
+		l.operand(dot, vars, a)
+	}
+	if id, ok := c.Args[0].(*parse.IdentifierNode); ok {
+		fn, ok := lintFuncs[id.Ident]
+		if !ok {
+			l.errorf(id, "function %q not defined", id.Ident)
+		}
+		return fn
+	}
+	return l.operand(dot, vars, c.Args[0])
+}
+
+// lintFuncs holds the result types of the functions available to templates,
+// nil if unknown.
+var lintFuncs = func() map[string]reflect.Type {
+	fns := map[string]reflect.Type{}
+	for _, name := range []string{"and", "or", "call", "index", "slice"} {
+		fns[name] = nil
+	}
+	for _, name := range []string{"not", "eq", "ne", "lt", "le", "gt", "ge"} {
+		fns[name] = reflect.TypeOf(false)
+	}
+	for _, name := range []string{"html", "js", "print", "printf", "println", "urlquery"} {
+		fns[name] = reflect.TypeOf("")
+	}
+	fns["len"] = reflect.TypeOf(0)
+
+	funcs := FuncMap{
+		"relatedAlerts": (*Template)(nil).relatedAlerts,
+		"silenceURL":    (*Template)(nil).silenceURL,
+	}
+	for name, fn := range DefaultFuncs {
+		funcs[name] = fn
+	}
+	for name, fn := range funcs {
+		t := reflect.TypeOf(fn)
+		fns[name] = nil
+		if t.NumOut() > 0 {
+			fns[name] = t.Out(0)
+		}
+	}
+	return fns
+}()
+
+func (l *linter) operand(dot reflect.Type, vars map[string]reflect.Type, n parse.Node) reflect.Type {
+	switch n := n.(type) {
+	case *parse.DotNode:
+		return dot
+	case *parse.FieldNode:
+		return l.fields(dot, n.Ident, n)
+	case *parse.VariableNode:
+		return l.fields(vars[n.Ident[0]], n.Ident[1:], n)
+	case *parse.ChainNode:
+		return l.fields(l.operand(dot, vars, n.Node), n.Field, n)
+	case *parse.PipeNode:
+		return l.pipe(dot, vars, n)
+	case *parse.IdentifierNode:
+		fn, ok := lintFuncs[n.Ident]
+		if !ok {
+			l.errorf(n, "function %q not defined", n.Ident)
+		}
+		return fn
+	case *parse.StringNode:
+		return reflect.TypeOf("")
+	case *parse.BoolNode:
+		return reflect.TypeOf(false)
+	}
+	return nil
+}
+
+// fields returns the type of the chain of fields evaluated on t.
+func (l *linter) fields(t reflect.Type, idents []string, n parse.Node) reflect.Type {
+	for _, id := range idents {
+		if t == nil {
+			return nil
+		}
+		next, ok := lookupField(t, id)
+		if !ok {
+			l.errorf(n, "can't evaluate field %s in type %s", id, t)
+			return nil
+		}
+		t = next
+	}
+	return t
+}
+
+// lookupField returns the type of the field or method of the given name of
+// t, nil if unknown, and false if there is none.
+func lookupField(t reflect.Type, name string) (reflect.Type, bool) {
+	ptr := t
+	if t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface {
+		ptr = reflect.PointerTo(t)
+	}
+	if m, ok := ptr.MethodByName(name); ok {
+		if m.Type.NumOut() == 0 {
+			return nil, true
+		}
+		return m.Type.Out(0), true
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		return nil, true
+	case reflect.Struct:
+		if f, ok := t.FieldByName(name); ok && f.IsExported() {
+			return f.Type, true
+		}
+	case reflect.Map:
+		if t.Key().Kind() == reflect.String {
+			return t.Elem(), true
+		}
+	}
+	return nil, false
+}
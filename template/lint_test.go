@@ -0,0 +1,125 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintDefaultTemplates(t *testing.T) {
+	for _, name := range defaultTemplates {
+		b, err := os.ReadFile(name)
+		require.NoError(t, err)
+		diags, err := Lint([]LintFile{{Name: name, Content: string(b)}}, nil)
+		require.NoError(t, err)
+		require.Empty(t, diags)
+	}
+}
+
+func TestLint(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		files       []LintFile
+		entrypoints []string
+		exp         []Diagnostic
+	}{
+		{
+			name: "valid",
+			files: []LintFile{{Name: "a.tmpl", Content: `
+{{ define "slack.text" }}{{ range .Alerts.Firing }}{{ template "alert" . }}{{ end }}{{ end }}
+{{ define "alert" }}{{ .Labels.alertname }} {{ .StartsAt.Format "15:04" }} {{ .Ack.AcknowledgedBy }}{{ end }}
+{{ define "vars" }}{{ $root := . }}{{ range $i, $a := .Alerts }}{{ $i }} {{ $a.Fingerprint }} {{ $root.Receiver }} {{ $.ExternalURL }}{{ end }}{{ end }}
+{{ define "funcs" }}{{ .CommonLabels.SortedPairs.Names | join ", " | toUpper }} {{ (relatedAlerts "a=b" 5).TruncatedAlerts }}{{ end }}
+{{ define "with" }}{{ with .Delta }}{{ .Added }}{{ else }}{{ .Receiver }}{{ end }}{{ end }}
+`}},
+		},
+		{
+			name: "syntax error",
+			files: []LintFile{{Name: "a.tmpl", Content: `
+{{ define "a" }}{{ .Receiver }{{ end }}
+`}},
+			exp: []Diagnostic{{File: "a.tmpl", Line: 2, Column: 1, Severity: SeverityError, Message: `unexpected "}" in operand`}},
+		},
+		{
+			name: "unknown field and function",
+			files: []LintFile{{Name: "a.tmpl", Content: `
+{{ define "a" }}{{ .Reciever }}
+{{ range .Alerts }}{{ .Labels.severity | capitalize }}{{ .Status.Foo }}{{ end }}{{ end }}
+`}},
+			exp: []Diagnostic{
+				{File: "a.tmpl", Line: 2, Column: 20, Severity: SeverityError, Message: "can't evaluate field Reciever in type template.Data"},
+				{File: "a.tmpl", Line: 3, Column: 42, Severity: SeverityError, Message: `function "capitalize" not defined`},
+				{File: "a.tmpl", Line: 3, Column: 65, Severity: SeverityError, Message: "can't evaluate field Foo in type string"},
+			},
+		},
+		{
+			name: "dot inferred from the calls",
+			files: []LintFile{{Name: "a.tmpl", Content: `
+{{ define "a" }}{{ template "list" .Alerts }}{{ template "list" .Alerts.Firing }}{{ end }}
+{{ define "list" }}{{ range . }}{{ .Labels.alertname }}{{ end }}{{ .Firing }}{{ end }}
+`}},
+			exp: []Diagnostic{
+				{File: "a.tmpl", Line: 3, Column: 68, Severity: SeverityError, Message: "can't evaluate field Firing in type []template.Alert"},
+			},
+		},
+		{
+			name: "overridden default template",
+			files: []LintFile{{Name: "a.tmpl", Content: `
+{{ define "__text_alert_list_markdown" }}{{ range . }}{{ .Labels.alertname }}{{ .Receiver }}{{ end }}{{ end }}
+`}},
+			exp: []Diagnostic{
+				{File: "a.tmpl", Line: 2, Column: 81, Severity: SeverityError, Message: "can't evaluate field Receiver in type template.Alert"},
+			},
+		},
+		{
+			name: "undefined and unused templates",
+			files: []LintFile{
+				{Name: "a.tmpl", Content: `{{ define "a" }}{{ template "b" . }}{{ template "c" . }}{{ end }}`},
+				{Name: "b.tmpl", Content: `{{ define "b" }}b{{ end }}{{ define "unused" }}{{ end }}`},
+			},
+			entrypoints: []string{"a"},
+			exp: []Diagnostic{
+				{File: "a.tmpl", Line: 1, Column: 49, Severity: SeverityError, Message: `template "c" not defined`},
+				{File: "b.tmpl", Line: 1, Column: 48, Severity: SeverityWarning, Message: `template "unused" is never used`},
+			},
+		},
+		{
+			name: "redefined template",
+			files: []LintFile{
+				{Name: "a.tmpl", Content: `{{ define "a" }}a{{ end }}`},
+				{Name: "b.tmpl", Content: `{{ define "a" }}b{{ end }}`},
+			},
+			exp: []Diagnostic{
+				{File: "b.tmpl", Line: 1, Column: 17, Severity: SeverityWarning, Message: `template "a" redefines the one of a.tmpl`},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			diags, err := Lint(tc.files, tc.entrypoints)
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, diags)
+		})
+	}
+}
+
+func TestCalled(t *testing.T) {
+	require.Equal(t, []string{"slack.title", "slack.text", "email.subject"}, Called(`
+title: '{{ template "slack.title" . }}'
+text: "{{- template \"slack.text\" . }} {{ template \"slack.title\" . }}"
+subject: '{{template "email.subject" .}}'
+`))
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/matcher/compat"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// MaxRelatedAlerts is the maximum number of alerts returned by the
+// relatedAlerts template function, whatever the requested limit.
+const MaxRelatedAlerts = 100
+
+// RelatedAlerts holds the firing alerts returned by the relatedAlerts
+// template function.
+type RelatedAlerts struct {
+	Alerts Alerts `json:"alerts"`
+	// TruncatedAlerts is the number of matching alerts left out because of
+	// the limit.
+	TruncatedAlerts int `json:"truncatedAlerts"`
+}
+
+// relatedAlerts returns the most recent firing alerts matching the matchers,
+// at most limit or MaxRelatedAlerts of them. No alerts are returned if the
+// template has no access to the firing alerts.
+func (t *Template) relatedAlerts(matchers string, limit int) (*RelatedAlerts, error) {
+	ms, err := compat.Matchers(matchers, "template")
+	if err != nil {
+		return nil, fmt.Errorf("invalid matchers %q: %w", matchers, err)
+	}
+	if limit <= 0 || limit > MaxRelatedAlerts {
+		limit = MaxRelatedAlerts
+	}
+
+	var alerts []*types.Alert
+	if t.FiringAlerts != nil {
+		t.FiringAlerts(func(a *types.Alert) {
+			if ms.Matches(a.Labels) {
+				alerts = append(alerts, a)
+			}
+		})
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		if !alerts[i].StartsAt.Equal(alerts[j].StartsAt) {
+			return alerts[i].StartsAt.After(alerts[j].StartsAt)
+		}
+		return alerts[i].Fingerprint() < alerts[j].Fingerprint()
+	})
+
+	res := &RelatedAlerts{Alerts: Alerts{}}
+	if len(alerts) > limit {
+		res.TruncatedAlerts = len(alerts) - limit
+		alerts = alerts[:limit]
+	}
+	for _, a := range alerts {
+		alert := Alert{
+			Status:       string(model.AlertFiring),
+			Labels:       make(KV, len(a.Labels)),
+			Annotations:  make(KV, len(a.Annotations)),
+			StartsAt:     a.StartsAt,
+			GeneratorURL: a.GeneratorURL,
+			Fingerprint:  a.Fingerprint().String(),
+		}
+		for k, v := range a.Labels {
+			alert.Labels[string(k)] = string(v)
+		}
+		for k, v := range a.Annotations {
+			alert.Annotations[string(k)] = string(v)
+		}
+		if t.Acks != nil {
+			alert.Ack = t.Acks(a)
+		}
+		res.Alerts = append(res.Alerts, alert)
+	}
+	return res, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/alertmanager/matcher/compat"
+	"github.com/prometheus/alertmanager/pkg/labels"
+)
+
+// silenceURL returns the link to the form of the UI creating a silence with
+// the matchers, given as a label set or a string, ending after the duration.
+func (t *Template) silenceURL(matchers interface{}, duration string) (string, error) {
+	var ms labels.Matchers
+	switch m := matchers.(type) {
+	case KV:
+		for _, p := range m.SortedPairs() {
+			matcher, err := labels.NewMatcher(labels.MatchEqual, p.Name, p.Value)
+			if err != nil {
+				return "", err
+			}
+			ms = append(ms, matcher)
+		}
+	case string:
+		var err error
+		ms, err = compat.Matchers(m, "template")
+		if err != nil {
+			return "", fmt.Errorf("invalid matchers %q: %w", m, err)
+		}
+	default:
+		return "", fmt.Errorf("invalid matchers of type %T, must be a label set or a string", matchers)
+	}
+	if len(ms) == 0 {
+		return "", errors.New("no matchers")
+	}
+	d, err := model.ParseDuration(duration)
+	if err != nil {
+		return "", fmt.Errorf("invalid duration %q: %w", duration, err)
+	}
+
+	filter := make([]string, 0, len(ms))
+	for _, m := range ms {
+		filter = append(filter, m.String())
+	}
+	var base string
+	if t.ExternalURL != nil {
+		base = strings.TrimSuffix(t.ExternalURL.String(), "/")
+	}
+	// The UI decodes the query without turning + into spaces.
+	escape := func(s string) string {
+		return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+	}
+	return base + "/#/silences/new?filter=" + escape("{"+strings.Join(filter, ", ")+"}") + "&duration=" + escape(d.String()), nil
+}
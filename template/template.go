@@ -15,14 +15,18 @@ package template
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	tmplhtml "html/template"
 	"io"
 	"net/url"
 	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 	tmpltext "text/template"
 	"time"
 
@@ -41,6 +45,19 @@ type Template struct {
 	html *tmplhtml.Template
 
 	ExternalURL *url.URL
+	// Acks returns the acknowledgement of an alert, or nil if it isn't
+	// acknowledged. If nil, no acknowledgements are exposed to templates.
+	Acks func(*types.Alert) *types.Ack
+	// FiringAlerts calls f for each firing alert. If nil, the relatedAlerts
+	// function returns no alerts.
+	FiringAlerts func(f func(*types.Alert))
+
+	// ClusterLabels identify the Alertmanager cluster or replica which sends
+	// the notifications.
+	ClusterLabels model.LabelSet
+
+	// renders limits the number of concurrent executions if not nil.
+	renders chan struct{}
 }
 
 // Option is generic modifier of the text and html templates used by a Template.
@@ -61,10 +78,16 @@ func New(options ...Option) (*Template, error) {
 
 	t.text.Funcs(tmpltext.FuncMap(DefaultFuncs))
 	t.html.Funcs(tmplhtml.FuncMap(DefaultFuncs))
+	t.text.Funcs(tmpltext.FuncMap{"relatedAlerts": t.relatedAlerts, "silenceURL": t.silenceURL})
+	t.html.Funcs(tmplhtml.FuncMap{"relatedAlerts": t.relatedAlerts, "silenceURL": t.silenceURL})
 
 	return t, nil
 }
 
+// defaultTemplates are the files of the default templates, parsed before the
+// other templates.
+var defaultTemplates = []string{"default.tmpl", "email.tmpl"}
+
 // FromGlobs calls ParseGlob on all path globs provided and returns the
 // resulting Template.
 func FromGlobs(paths []string, options ...Option) (*Template, error) {
@@ -73,8 +96,6 @@ func FromGlobs(paths []string, options ...Option) (*Template, error) {
 		return nil, err
 	}
 
-	defaultTemplates := []string{"default.tmpl", "email.tmpl"}
-
 	for _, file := range defaultTemplates {
 		f, err := asset.Assets.Open(path.Join("/templates", file))
 		if err != nil {
@@ -132,6 +153,12 @@ func (t *Template) FromGlob(path string) error {
 
 // ExecuteTextString needs a meaningful doc comment (TODO(fabxc)).
 func (t *Template) ExecuteTextString(text string, data interface{}) (string, error) {
+	return t.ExecuteTextStringContext(context.Background(), text, data)
+}
+
+// ExecuteTextStringContext is like ExecuteTextString but gives up waiting for
+// its turn to render when the context is done.
+func (t *Template) ExecuteTextStringContext(ctx context.Context, text string, data interface{}) (string, error) {
 	if text == "" {
 		return "", nil
 	}
@@ -143,13 +170,17 @@ func (t *Template) ExecuteTextString(text string, data interface{}) (string, err
 	if err != nil {
 		return "", err
 	}
-	var buf bytes.Buffer
-	err = tmpl.Execute(&buf, data)
-	return buf.String(), err
+	return t.execute(ctx, tmpl, data)
 }
 
 // ExecuteHTMLString needs a meaningful doc comment (TODO(fabxc)).
 func (t *Template) ExecuteHTMLString(html string, data interface{}) (string, error) {
+	return t.ExecuteHTMLStringContext(context.Background(), html, data)
+}
+
+// ExecuteHTMLStringContext is like ExecuteHTMLString but gives up waiting for
+// its turn to render when the context is done.
+func (t *Template) ExecuteHTMLStringContext(ctx context.Context, html string, data interface{}) (string, error) {
 	if html == "" {
 		return "", nil
 	}
@@ -161,6 +192,41 @@ func (t *Template) ExecuteHTMLString(html string, data interface{}) (string, err
 	if err != nil {
 		return "", err
 	}
+	return t.execute(ctx, tmpl, data)
+}
+
+// SetMaxConcurrentRenders limits the number of templates executed at once,
+// the other executions waiting for their turn. If n is zero or negative, the
+// number is unlimited. It must be called before the templates are executed.
+func (t *Template) SetMaxConcurrentRenders(n int) {
+	if n <= 0 {
+		t.renders = nil
+		return
+	}
+	t.renders = make(chan struct{}, n)
+}
+
+// execute executes a text or html template. A panic during the execution,
+// e.g. in a function or a method called by the template, is recovered and
+// returned as an error so that it does not crash the caller. If the context
+// is done while waiting for the turn to render, its error is returned.
+func (t *Template) execute(ctx context.Context, tmpl interface {
+	Execute(io.Writer, any) error
+}, data interface{},
+) (s string, err error) {
+	if t.renders != nil {
+		select {
+		case t.renders <- struct{}{}:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		defer func() { <-t.renders }()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			s, err = "", fmt.Errorf("panic while executing template: %v", r)
+		}
+	}()
 	var buf bytes.Buffer
 	err = tmpl.Execute(&buf, data)
 	return buf.String(), err
@@ -318,17 +384,91 @@ type Data struct {
 	CommonAnnotations KV `json:"commonAnnotations"`
 
 	ExternalURL string `json:"externalURL"`
+
+	// TruncatedAlerts is the number of alerts of the group left out of
+	// Alerts because the group has more alerts than its route allows in
+	// notifications.
+	TruncatedAlerts int `json:"truncatedAlerts,omitempty"`
+
+	// Delta is the change of the alerts of the group since the last
+	// notification sent to the receiver.
+	Delta Delta `json:"delta"`
+
+	// Origins are the senders of the alerts, if configured.
+	Origins []string `json:"origins,omitempty"`
+
+	// ClusterLabels identify the Alertmanager cluster or replica which sends
+	// the notification, if configured.
+	ClusterLabels KV `json:"clusterLabels,omitempty"`
+}
+
+// Delta is the change of the alerts of a group since the last notification.
+type Delta struct {
+	// Added is the number of firing alerts which weren't firing at the last
+	// notification, all the firing alerts for the first notification.
+	Added int `json:"added"`
+	// Resolved is the number of alerts firing at the last notification
+	// which are resolved since.
+	Resolved int `json:"resolved"`
+}
+
+// Truncate keeps at most n alerts, the firing ones first, and adds the number
+// of the other ones to TruncatedAlerts. The status and the common labels and
+// annotations still reflect all the alerts.
+func (d *Data) Truncate(n int) {
+	if n <= 0 || len(d.Alerts) <= n {
+		return
+	}
+	alerts := make(Alerts, 0, len(d.Alerts))
+	alerts = append(alerts, d.Alerts.Firing()...)
+	alerts = append(alerts, d.Alerts.Resolved()...)
+	d.TruncatedAlerts += len(alerts) - n
+	d.Alerts = alerts[:n]
+}
+
+// Collapse collapses the alerts with the same status, alertname and values of
+// the given annotations into the first one of them, counting the other ones
+// in its Collapsed field. The common labels and annotations still reflect all
+// the alerts.
+func (d *Data) Collapse(annotations []string) {
+	var (
+		alerts = make(Alerts, 0, len(d.Alerts))
+		index  = map[string]int{}
+		key    strings.Builder
+	)
+	for _, a := range d.Alerts {
+		key.Reset()
+		key.WriteString(a.Status)
+		key.WriteByte(0)
+		key.WriteString(a.Labels[string(model.AlertNameLabel)])
+		for _, name := range annotations {
+			key.WriteByte(0)
+			key.WriteString(a.Annotations[name])
+		}
+		if i, ok := index[key.String()]; ok {
+			alerts[i].Collapsed++
+			continue
+		}
+		index[key.String()] = len(alerts)
+		alerts = append(alerts, a)
+	}
+	d.Alerts = alerts
 }
 
 // Alert holds one alert for notification templates.
 type Alert struct {
-	Status       string    `json:"status"`
-	Labels       KV        `json:"labels"`
-	Annotations  KV        `json:"annotations"`
-	StartsAt     time.Time `json:"startsAt"`
-	EndsAt       time.Time `json:"endsAt"`
-	GeneratorURL string    `json:"generatorURL"`
-	Fingerprint  string    `json:"fingerprint"`
+	Status       string     `json:"status"`
+	Labels       KV         `json:"labels"`
+	Annotations  KV         `json:"annotations"`
+	StartsAt     time.Time  `json:"startsAt"`
+	EndsAt       time.Time  `json:"endsAt"`
+	GeneratorURL string     `json:"generatorURL"`
+	Fingerprint  string     `json:"fingerprint"`
+	Ack          *types.Ack `json:"ack,omitempty"`
+	// Collapsed is the number of identical alerts collapsed into this one.
+	Collapsed int `json:"collapsed,omitempty"`
+	// Origins are the senders of the alert, if configured.
+	Origins []string `json:"origins,omitempty"`
 }
 
 // Alerts is a list of Alert objects.
@@ -356,29 +496,56 @@ func (as Alerts) Resolved() []Alert {
 	return res
 }
 
-// Data assembles data for template expansion.
+// dataPool holds the released Data to build new ones from.
+var dataPool = sync.Pool{
+	New: func() interface{} { return &Data{} },
+}
+
+// Data assembles data for template expansion. The data can be released with
+// ReleaseData once it isn't used anymore to reuse its allocations.
 func (t *Template) Data(recv string, groupLabels model.LabelSet, alerts ...*types.Alert) *Data {
-	data := &Data{
-		Receiver:          regexp.QuoteMeta(recv),
-		Status:            string(types.Alerts(alerts...).Status()),
-		Alerts:            make(Alerts, 0, len(alerts)),
-		GroupLabels:       KV{},
-		CommonLabels:      KV{},
-		CommonAnnotations: KV{},
-		ExternalURL:       t.ExternalURL.String(),
-	}
-
-	// The call to types.Alert is necessary to correctly resolve the internal
-	// representation to the user representation.
-	for _, a := range types.Alerts(alerts...) {
-		alert := Alert{
-			Status:       string(a.Status()),
-			Labels:       make(KV, len(a.Labels)),
-			Annotations:  make(KV, len(a.Annotations)),
+	data := dataPool.Get().(*Data)
+	data.Receiver = regexp.QuoteMeta(recv)
+	data.Status = string(model.AlertResolved)
+	data.GroupLabels = resetKV(data.GroupLabels, len(groupLabels))
+	data.CommonLabels = resetKV(data.CommonLabels, 0)
+	data.CommonAnnotations = resetKV(data.CommonAnnotations, 0)
+	data.ExternalURL = t.ExternalURL.String()
+	data.Origins = data.Origins[:0]
+	data.TruncatedAlerts = 0
+	data.Delta = Delta{}
+	data.ClusterLabels = nil
+	if len(t.ClusterLabels) > 0 {
+		data.ClusterLabels = make(KV, len(t.ClusterLabels))
+		for k, v := range t.ClusterLabels {
+			data.ClusterLabels[string(k)] = string(v)
+		}
+	}
+
+	if data.Alerts == nil || cap(data.Alerts) < len(alerts) {
+		data.Alerts = make(Alerts, 0, len(alerts))
+	}
+	data.Alerts = data.Alerts[:len(alerts)]
+
+	for i, a := range alerts {
+		// Like types.Alerts, the end of alerts which aren't resolved yet is
+		// not exposed.
+		alert := &data.Alerts[i]
+		*alert = Alert{
+			Status:       string(model.AlertResolved),
+			Labels:       resetKV(alert.Labels, len(a.Labels)),
+			Annotations:  resetKV(alert.Annotations, len(a.Annotations)),
 			StartsAt:     a.StartsAt,
 			EndsAt:       a.EndsAt,
 			GeneratorURL: a.GeneratorURL,
 			Fingerprint:  a.Fingerprint().String(),
+			Origins:      a.Origins,
+		}
+		data.Origins = append(data.Origins, a.Origins...)
+		if !a.Resolved() {
+			alert.Status = string(model.AlertFiring)
+			alert.EndsAt = time.Time{}
+			data.Status = string(model.AlertFiring)
 		}
 		for k, v := range a.Labels {
 			alert.Labels[string(k)] = string(v)
@@ -386,40 +553,70 @@ func (t *Template) Data(recv string, groupLabels model.LabelSet, alerts ...*type
 		for k, v := range a.Annotations {
 			alert.Annotations[string(k)] = string(v)
 		}
-		data.Alerts = append(data.Alerts, alert)
+		if t.Acks != nil {
+			alert.Ack = t.Acks(a)
+		}
+	}
+
+	if len(data.Origins) == 0 {
+		data.Origins = nil
+	} else {
+		slices.Sort(data.Origins)
+		data.Origins = slices.Compact(data.Origins)
 	}
 
 	for k, v := range groupLabels {
 		data.GroupLabels[string(k)] = string(v)
 	}
 
-	if len(alerts) >= 1 {
-		var (
-			commonLabels      = alerts[0].Labels.Clone()
-			commonAnnotations = alerts[0].Annotations.Clone()
-		)
-		for _, a := range alerts[1:] {
-			if len(commonLabels) == 0 && len(commonAnnotations) == 0 {
-				break
-			}
-			for ln, lv := range commonLabels {
-				if a.Labels[ln] != lv {
-					delete(commonLabels, ln)
-				}
-			}
-			for an, av := range commonAnnotations {
-				if a.Annotations[an] != av {
-					delete(commonAnnotations, an)
-				}
-			}
+	// The common labels and annotations are computed from the ones of the
+	// first alert, without copying the label sets of the alerts.
+	if len(data.Alerts) >= 1 {
+		for k, v := range data.Alerts[0].Labels {
+			data.CommonLabels[k] = v
 		}
-		for k, v := range commonLabels {
-			data.CommonLabels[string(k)] = string(v)
+		for k, v := range data.Alerts[0].Annotations {
+			data.CommonAnnotations[k] = v
 		}
-		for k, v := range commonAnnotations {
-			data.CommonAnnotations[string(k)] = string(v)
+		for _, a := range data.Alerts[1:] {
+			if len(data.CommonLabels) == 0 && len(data.CommonAnnotations) == 0 {
+				break
+			}
+			intersectKV(data.CommonLabels, a.Labels)
+			intersectKV(data.CommonAnnotations, a.Annotations)
 		}
 	}
 
 	return data
 }
+
+// ReleaseData puts the data back into the pool used by Template.Data. Neither
+// the data nor any of its alerts and key/value sets may be used once
+// released. It is safe to call ReleaseData concurrently.
+func ReleaseData(d *Data) {
+	if d == nil {
+		return
+	}
+	for i := range d.Alerts {
+		d.Alerts[i].Ack = nil
+	}
+	dataPool.Put(d)
+}
+
+// resetKV returns kv emptied, or a new KV if kv is nil.
+func resetKV(kv KV, size int) KV {
+	if kv == nil {
+		return make(KV, size)
+	}
+	clear(kv)
+	return kv
+}
+
+// intersectKV removes the keys of kv which don't have the same value in o.
+func intersectKV(kv, o KV) {
+	for k, v := range kv {
+		if ov, ok := o[k]; !ok || ov != v {
+			delete(kv, k)
+		}
+	}
+}
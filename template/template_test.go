@@ -14,8 +14,13 @@
 package template
 
 import (
+	"context"
+	"fmt"
 	tmplhtml "html/template"
+	"io"
 	"net/url"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	tmpltext "text/template"
@@ -286,6 +291,400 @@ func TestData(t *testing.T) {
 	}
 }
 
+func TestDataAck(t *testing.T) {
+	u, err := url.Parse("http://example.com/")
+	require.NoError(t, err)
+	ack := &types.Ack{
+		AcknowledgedBy: "alice",
+		Note:           "looking into it",
+		AcknowledgedAt: time.Time{}.Add(1 * time.Second),
+		ExpiresAt:      time.Time{}.Add(2 * time.Second),
+	}
+	tmpl, err := FromGlobs([]string{})
+	require.NoError(t, err)
+	tmpl.ExternalURL = u
+	tmpl.Acks = func(a *types.Alert) *types.Ack {
+		if a.Labels["alertname"] == "acked" {
+			return ack
+		}
+		return nil
+	}
+
+	data := tmpl.Data("webhook", nil,
+		&types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "acked"}}},
+		&types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "other"}}},
+	)
+	require.Equal(t, ack, data.Alerts[0].Ack)
+	require.Nil(t, data.Alerts[1].Ack)
+
+	out, err := tmpl.ExecuteTextString(`{{ range .Alerts }}{{ with .Ack }}{{ .AcknowledgedBy }}: {{ .Note }}{{ end }}{{ end }}`, data)
+	require.NoError(t, err)
+	require.Equal(t, "alice: looking into it", out)
+}
+
+func TestDataOrigins(t *testing.T) {
+	tmpl, err := FromGlobs([]string{})
+	require.NoError(t, err)
+	tmpl.ExternalURL, err = url.Parse("http://example.com/")
+	require.NoError(t, err)
+
+	data := tmpl.Data("webhook", nil,
+		&types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "a"}}, Origins: []string{"prometheus-1"}},
+		&types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "b"}}, Origins: []string{"prometheus-0", "prometheus-1"}},
+		&types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "c"}}},
+	)
+	require.Equal(t, []string{"prometheus-1"}, data.Alerts[0].Origins)
+	require.Nil(t, data.Alerts[2].Origins)
+	require.Equal(t, []string{"prometheus-0", "prometheus-1"}, data.Origins)
+
+	out, err := tmpl.ExecuteTextString(`{{ .Origins | join "," }}`, data)
+	require.NoError(t, err)
+	require.Equal(t, "prometheus-0,prometheus-1", out)
+	ReleaseData(data)
+
+	data = tmpl.Data("webhook", nil, &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "c"}}})
+	require.Nil(t, data.Origins)
+}
+
+func TestDataClusterLabels(t *testing.T) {
+	tmpl, err := FromGlobs([]string{})
+	require.NoError(t, err)
+	tmpl.ExternalURL, err = url.Parse("http://example.com/")
+	require.NoError(t, err)
+
+	data := tmpl.Data("webhook", nil, &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "a"}}})
+	require.Nil(t, data.ClusterLabels)
+	ReleaseData(data)
+
+	tmpl.ClusterLabels = model.LabelSet{"cluster": "eu-1", "replica": "0"}
+	data = tmpl.Data("webhook", nil, &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "a"}}})
+	require.Equal(t, KV{"cluster": "eu-1", "replica": "0"}, data.ClusterLabels)
+
+	out, err := tmpl.ExecuteTextString(`{{ .ClusterLabels.cluster }}/{{ .ClusterLabels.replica }}`, data)
+	require.NoError(t, err)
+	require.Equal(t, "eu-1/0", out)
+}
+
+func TestSilenceURL(t *testing.T) {
+	tmpl, err := FromGlobs([]string{})
+	require.NoError(t, err)
+	tmpl.ExternalURL, _ = url.Parse("http://am.example.com/alertmanager/")
+
+	data := &Data{CommonLabels: KV{"alertname": "HighLatency", "job": "api server"}}
+	// The links are parsed by the UI in ui/app/tests/SilenceForm.elm.
+	for _, tc := range []struct {
+		in  string
+		out string
+		err string
+	}{
+		{
+			in:  `{{ silenceURL .CommonLabels "2h" }}`,
+			out: `http://am.example.com/alertmanager/#/silences/new?filter=%7Balertname%3D%22HighLatency%22%2C%20job%3D%22api%20server%22%7D&duration=2h`,
+		},
+		{
+			in:  `{{ silenceURL "{instance=~\"web-.*\"}" "1d" }}`,
+			out: `http://am.example.com/alertmanager/#/silences/new?filter=%7Binstance%3D~%22web-.%2A%22%7D&duration=1d`,
+		},
+		{
+			in:  `{{ silenceURL .CommonLabels "2x" }}`,
+			err: `invalid duration "2x"`,
+		},
+		{
+			in:  `{{ silenceURL "a=~(" "2h" }}`,
+			err: `invalid matchers "a=~("`,
+		},
+		{
+			in:  `{{ silenceURL "{}" "2h" }}`,
+			err: "no matchers",
+		},
+		{
+			in:  `{{ silenceURL 1 "2h" }}`,
+			err: "invalid matchers of type int",
+		},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			out, err := tmpl.ExecuteTextString(tc.in, data)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.out, out)
+		})
+	}
+}
+
+func TestRelatedAlerts(t *testing.T) {
+	tmpl, err := FromGlobs([]string{})
+	require.NoError(t, err)
+
+	// Without access to the alerts, no alerts are returned.
+	out, err := tmpl.ExecuteTextString(`{{ len (relatedAlerts "{cluster=\"a\"}" 5).Alerts }}`, nil)
+	require.NoError(t, err)
+	require.Equal(t, "0", out)
+
+	alert := func(name, cluster string, startsAt int64) *types.Alert {
+		return &types.Alert{Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": model.LabelValue(name), "cluster": model.LabelValue(cluster)},
+			StartsAt: time.Unix(startsAt, 0),
+		}}
+	}
+	tmpl.FiringAlerts = func(f func(*types.Alert)) {
+		for _, a := range []*types.Alert{
+			alert("a", "eu", 1),
+			alert("b", "eu", 3),
+			alert("c", "us", 4),
+			alert("d", "eu", 2),
+		} {
+			f(a)
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		in       string
+		exp      string
+		expError string
+	}{
+		{
+			name: "matching alerts",
+			in:   `{{ range (relatedAlerts "{cluster=\"eu\"}" 10).Alerts }}{{ .Labels.alertname }} {{ end }}`,
+			exp:  "b d a ",
+		},
+		{
+			name: "truncated alerts",
+			in:   `{{ with relatedAlerts "{cluster=\"eu\"}" 2 }}{{ range .Alerts }}{{ .Labels.alertname }} {{ end }}+{{ .TruncatedAlerts }}{{ end }}`,
+			exp:  "b d +1",
+		},
+		{
+			name: "no matching alerts",
+			in:   `{{ len (relatedAlerts "{cluster=\"ap\"}" 10).Alerts }}`,
+			exp:  "0",
+		},
+		{
+			name:     "invalid matchers",
+			in:       `{{ relatedAlerts "{cluster=~\"(\"}" 10 }}`,
+			expError: "invalid matchers",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := tmpl.ExecuteTextString(tc.in, nil)
+			if tc.expError != "" {
+				require.ErrorContains(t, err, tc.expError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, out)
+		})
+	}
+
+	// The number of alerts is bounded whatever the limit.
+	tmpl.FiringAlerts = func(f func(*types.Alert)) {
+		for i := 0; i < MaxRelatedAlerts+10; i++ {
+			f(alert(strconv.Itoa(i), "eu", int64(i)))
+		}
+	}
+	out, err = tmpl.ExecuteTextString(`{{ with relatedAlerts "{}" 1000 }}{{ len .Alerts }} {{ .TruncatedAlerts }}{{ end }}`, nil)
+	require.NoError(t, err)
+	require.Equal(t, "100 10", out)
+}
+
+func TestDataTruncate(t *testing.T) {
+	tmpl, err := FromGlobs([]string{})
+	require.NoError(t, err)
+	tmpl.ExternalURL, err = url.Parse("http://example.com/")
+	require.NoError(t, err)
+
+	alert := func(name string, resolved bool) *types.Alert {
+		a := &types.Alert{Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": model.LabelValue(name), "job": "test"},
+			StartsAt: time.Unix(0, 0),
+		}}
+		if resolved {
+			a.EndsAt = time.Unix(1, 0)
+		}
+		return a
+	}
+	data := tmpl.Data("webhook", model.LabelSet{"job": "test"},
+		alert("a", true),
+		alert("b", false),
+		alert("c", false),
+		alert("d", true),
+	)
+
+	// Nothing is truncated within the limit.
+	data.Truncate(4)
+	require.Len(t, data.Alerts, 4)
+	require.Zero(t, data.TruncatedAlerts)
+
+	data.Truncate(3)
+	require.Equal(t, 1, data.TruncatedAlerts)
+	require.Equal(t, []string{"b", "c", "a"}, []string{
+		data.Alerts[0].Labels["alertname"],
+		data.Alerts[1].Labels["alertname"],
+		data.Alerts[2].Labels["alertname"],
+	})
+	require.Equal(t, string(model.AlertFiring), data.Status)
+
+	data.Truncate(1)
+	require.Equal(t, 3, data.TruncatedAlerts)
+	require.Len(t, data.Alerts, 1)
+
+	out, err := tmpl.ExecuteTextString(`{{ template "__subject" . }}`, data)
+	require.NoError(t, err)
+	require.Equal(t, "[FIRING:1] test  (+3 alerts not shown)", out)
+}
+
+func TestDataCollapse(t *testing.T) {
+	tmpl, err := FromGlobs([]string{})
+	require.NoError(t, err)
+	tmpl.ExternalURL, err = url.Parse("http://example.com/")
+	require.NoError(t, err)
+
+	alert := func(name, instance, summary string, resolved bool) *types.Alert {
+		a := &types.Alert{Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": model.LabelValue(name), "instance": model.LabelValue(instance)},
+			Annotations: model.LabelSet{"summary": model.LabelValue(summary)},
+			StartsAt:    time.Unix(0, 0),
+		}}
+		if resolved {
+			a.EndsAt = time.Unix(1, 0)
+		}
+		return a
+	}
+	data := tmpl.Data("webhook", nil,
+		alert("DiskFull", "a", "disk full", false),
+		alert("DiskFull", "b", "disk full", false),
+		alert("DiskFull", "c", "disk full", true),
+		alert("DiskFull", "d", "disk almost full", false),
+		alert("DiskFull", "e", "disk full", false),
+		alert("NodeDown", "a", "disk full", false),
+	)
+	data.Collapse([]string{"summary"})
+	require.Equal(t, []string{"a", "c", "d", "a"}, []string{
+		data.Alerts[0].Labels["instance"],
+		data.Alerts[1].Labels["instance"],
+		data.Alerts[2].Labels["instance"],
+		data.Alerts[3].Labels["instance"],
+	})
+	require.Equal(t, []int{2, 0, 0, 0}, []int{
+		data.Alerts[0].Collapsed,
+		data.Alerts[1].Collapsed,
+		data.Alerts[2].Collapsed,
+		data.Alerts[3].Collapsed,
+	})
+	// The common annotations still reflect the collapsed alerts.
+	require.Equal(t, KV{}, data.CommonAnnotations)
+
+	// Without annotations, alerts are collapsed by alertname only.
+	data.Collapse(nil)
+	require.Len(t, data.Alerts, 3)
+
+	out, err := tmpl.ExecuteTextString(`{{ template "__text_alert_list" .Alerts.Firing }}`, data)
+	require.NoError(t, err)
+	require.Contains(t, out, "Identical alerts: 3\n")
+}
+
+func TestReleaseData(t *testing.T) {
+	u, err := url.Parse("http://example.com/")
+	require.NoError(t, err)
+	tmpl, err := FromGlobs([]string{})
+	require.NoError(t, err)
+	tmpl.ExternalURL = u
+
+	alerts := func(n int, lbls model.LabelSet) []*types.Alert {
+		as := make([]*types.Alert, 0, n)
+		for i := 0; i < n; i++ {
+			ls := lbls.Clone()
+			ls["instance"] = model.LabelValue(fmt.Sprintf("host-%d", i))
+			as = append(as, &types.Alert{Alert: model.Alert{Labels: ls}})
+		}
+		return as
+	}
+
+	// Data built from released data must not keep anything from it.
+	data := tmpl.Data("webhook", model.LabelSet{"job": "a"}, alerts(10, model.LabelSet{"job": "a", "env": "prod"})...)
+	ReleaseData(data)
+	exp := tmpl.Data("webhook", nil, alerts(3, model.LabelSet{"job": "b"})...)
+	for i := 0; i < 5; i++ {
+		ReleaseData(tmpl.Data("webhook", model.LabelSet{"job": "a"}, alerts(10, model.LabelSet{"job": "a", "env": "prod"})...))
+		got := tmpl.Data("webhook", nil, alerts(3, model.LabelSet{"job": "b"})...)
+		require.Equal(t, exp, got)
+		require.Equal(t, KV{"job": "b"}, got.CommonLabels)
+		require.Empty(t, got.GroupLabels)
+	}
+	ReleaseData(nil)
+
+	// Nor the alerts left out of truncated data, nor the delta.
+	for i := 0; i < 5; i++ {
+		data = tmpl.Data("webhook", nil, alerts(4, model.LabelSet{"job": "a"})...)
+		data.Truncate(1)
+		data.Delta = Delta{Added: 3, Resolved: 1}
+		require.Equal(t, 3, data.TruncatedAlerts)
+		ReleaseData(data)
+		data = tmpl.Data("webhook", nil, alerts(1, model.LabelSet{"job": "b"})...)
+		require.Zero(t, data.TruncatedAlerts)
+		require.Equal(t, Delta{}, data.Delta)
+		ReleaseData(data)
+	}
+
+	// Data can be built and released concurrently.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				job := model.LabelValue(fmt.Sprintf("job-%d", i))
+				d := tmpl.Data("webhook", nil, alerts(j%10+1, model.LabelSet{"job": job})...)
+				if len(d.Alerts) != j%10+1 || d.CommonLabels["job"] != string(job) {
+					t.Errorf("unexpected data: %v", d)
+				}
+				ReleaseData(d)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func BenchmarkData(b *testing.B) {
+	tmpl, err := FromGlobs([]string{})
+	require.NoError(b, err)
+	tmpl.ExternalURL, err = url.Parse("http://example.com/")
+	require.NoError(b, err)
+
+	for _, n := range []int{1, 100, 10000} {
+		alerts := make([]*types.Alert, 0, n)
+		for i := 0; i < n; i++ {
+			alerts = append(alerts, &types.Alert{
+				Alert: model.Alert{
+					Labels: model.LabelSet{
+						"alertname": "HighLatency",
+						"job":       "api",
+						"instance":  model.LabelValue(fmt.Sprintf("host-%d", i)),
+					},
+					Annotations: model.LabelSet{"summary": "Latency is high"},
+					StartsAt:    time.Now(),
+				},
+			})
+		}
+		groupLabels := model.LabelSet{"alertname": "HighLatency"}
+
+		b.Run(fmt.Sprintf("%d alerts", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				tmpl.Data("webhook", groupLabels, alerts...)
+			}
+		})
+		b.Run(fmt.Sprintf("%d alerts released", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				ReleaseData(tmpl.Data("webhook", groupLabels, alerts...))
+			}
+		})
+	}
+}
+
 func TestTemplateExpansion(t *testing.T) {
 	tmpl, err := FromGlobs([]string{})
 	require.NoError(t, err)
@@ -468,6 +867,126 @@ func TestTemplateExpansionWithOptions(t *testing.T) {
 }
 
 // This test asserts that template functions are thread-safe.
+func TestTemplateAdversarial(t *testing.T) {
+	panicking := func(text *tmpltext.Template, html *tmplhtml.Template) {
+		funcs := FuncMap{
+			"panic":      func() string { panic("boom") },
+			"nilDeref":   func() string { var p *Data; return p.Receiver },
+			"outOfRange": func(i int) int { return []int{}[i] },
+		}
+		text.Funcs(tmpltext.FuncMap(funcs))
+		html.Funcs(tmplhtml.FuncMap(funcs))
+	}
+	tmpl, err := New(panicking)
+	require.NoError(t, err)
+	require.NoError(t, tmpl.Parse(strings.NewReader(`{{ define "loop" }}{{ template "loop" . }}{{ end }}`)))
+
+	for _, in := range []string{
+		`{{ panic }}`,
+		`{{ nilDeref }}`,
+		`{{ outOfRange 1 }}`,
+		`{{ index .Alerts 5 }}`,
+		`{{ template "loop" . }}`,
+		`{{ .Receiver.Foo }}`,
+	} {
+		t.Run(in, func(t *testing.T) {
+			data := &Data{Receiver: "foo"}
+			_, err := tmpl.ExecuteTextString(in, data)
+			require.Error(t, err)
+			_, err = tmpl.ExecuteHTMLString(in, data)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestTemplateRecoversPanic(t *testing.T) {
+	tmpl, err := New()
+	require.NoError(t, err)
+	// Panics in the functions called by templates are already recovered by
+	// the template packages, the others escape their execution.
+	s, err := tmpl.execute(context.Background(), panickingTemplate{}, nil)
+	require.EqualError(t, err, "panic while executing template: boom")
+	require.Empty(t, s)
+}
+
+type panickingTemplate struct{}
+
+func (panickingTemplate) Execute(io.Writer, any) error { panic("boom") }
+
+func TestTemplateMaxConcurrentRenders(t *testing.T) {
+	var (
+		mtx              sync.Mutex
+		current, highest int
+	)
+	render := func() string {
+		mtx.Lock()
+		current++
+		highest = max(highest, current)
+		mtx.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mtx.Lock()
+		current--
+		mtx.Unlock()
+		return ""
+	}
+	tmpl, err := New(func(text *tmpltext.Template, html *tmplhtml.Template) {
+		text.Funcs(tmpltext.FuncMap{"render": render})
+		html.Funcs(tmplhtml.FuncMap{"render": render})
+	})
+	require.NoError(t, err)
+	tmpl.SetMaxConcurrentRenders(2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := tmpl.ExecuteTextString(`{{ render }}`, nil)
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	require.LessOrEqual(t, highest, 2)
+}
+
+func TestTemplateMaxConcurrentRendersCanceled(t *testing.T) {
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	tmpl, err := New(func(text *tmpltext.Template, html *tmplhtml.Template) {
+		text.Funcs(tmpltext.FuncMap{"wait": func() string {
+			close(started)
+			<-release
+			return ""
+		}})
+	})
+	require.NoError(t, err)
+	tmpl.SetMaxConcurrentRenders(1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := tmpl.ExecuteTextString(`{{ wait }}`, nil)
+		require.NoError(t, err)
+	}()
+	<-started
+
+	// The render slot is taken, a canceled render gives up waiting for it.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = tmpl.ExecuteTextStringContext(ctx, `text`, nil)
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = tmpl.ExecuteHTMLStringContext(ctx, `html`, nil)
+	require.ErrorIs(t, err, context.Canceled)
+
+	close(release)
+	<-done
+	s, err := tmpl.ExecuteTextStringContext(context.Background(), `text`, nil)
+	require.NoError(t, err)
+	require.Equal(t, "text", s)
+}
+
 func TestTemplateFuncs(t *testing.T) {
 	tmpl, err := FromGlobs([]string{})
 	require.NoError(t, err)
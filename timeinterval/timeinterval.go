@@ -58,6 +58,71 @@ func NewIntervener(ti map[string][]TimeInterval) *Intervener {
 	}
 }
 
+// NextTransition returns the first time after now, and not after limit, at
+// which the named time interval becomes active or inactive. It returns false
+// if the time interval doesn't change before limit.
+func (i *Intervener) NextTransition(name string, now, limit time.Time) (time.Time, bool, error) {
+	interval, ok := i.intervals[name]
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("time interval %s doesn't exist in config", name)
+	}
+
+	t := now.UTC()
+	active := containsTime(interval, t)
+	for t.Before(limit) {
+		// None of the time intervals change before their next boundary, so
+		// jump from boundary to boundary instead of checking every minute.
+		next := limit
+		for _, ti := range interval {
+			if b := ti.nextBoundary(t); b.Before(next) {
+				next = b
+			}
+		}
+		t = next.UTC()
+		if containsTime(interval, t) != active {
+			return t, true, nil
+		}
+	}
+	return time.Time{}, false, nil
+}
+
+func containsTime(intervals []TimeInterval, t time.Time) bool {
+	for _, ti := range intervals {
+		if ti.ContainsTime(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextBoundary returns the first time after t at which the time interval may
+// change from containing the time to not containing it or inversely.
+func (tp TimeInterval) nextBoundary(t time.Time) time.Time {
+	if tp.Location != nil {
+		t = t.In(tp.Location.Location)
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+
+	minute := t.Hour()*60 + t.Minute()
+	next := 24 * 60
+	for _, tr := range tp.Times {
+		for _, m := range []int{tr.StartMinute, tr.EndMinute} {
+			if m > minute && m < next {
+				next = m
+			}
+		}
+	}
+	if next == 24*60 {
+		return midnight
+	}
+	b := time.Date(t.Year(), t.Month(), t.Day(), next/60, next%60, 0, 0, t.Location())
+	if !b.After(t) {
+		// The wall clock went back because of a daylight saving time change.
+		return t.Truncate(time.Minute).Add(time.Minute)
+	}
+	return b
+}
+
 // TimeInterval describes intervals of time. ContainsTime will tell you if a golang time is contained
 // within the interval.
 type TimeInterval struct {
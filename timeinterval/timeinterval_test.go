@@ -750,3 +750,75 @@ func TestIntervener_Mutes(t *testing.T) {
 		})
 	}
 }
+
+func TestIntervener_NextTransition(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	require.NoError(t, err)
+	intervener := NewIntervener(map[string][]TimeInterval{
+		"business-hours": {{
+			Times:    []TimeRange{{StartMinute: 540, EndMinute: 1020}}, // 09:00-17:00
+			Weekdays: []WeekdayRange{{InclusiveRange: InclusiveRange{Begin: 1, End: 5}}},
+			Location: &Location{Location: sydney},
+		}},
+		"new-year": {{
+			Months:      []MonthRange{{InclusiveRange: InclusiveRange{Begin: 1, End: 1}}},
+			DaysOfMonth: []DayOfMonthRange{{InclusiveRange: InclusiveRange{Begin: 1, End: 1}}},
+			Years:       []YearRange{{InclusiveRange: InclusiveRange{Begin: 2025, End: 2025}}},
+		}},
+		"always": {{}},
+	})
+
+	tests := []struct {
+		name     string
+		interval string
+		now      time.Time
+		next     time.Time
+		ok       bool
+	}{{
+		name:     "Should activate at the start of the next business day",
+		interval: "business-hours",
+		now:      time.Date(2024, 1, 5, 18, 0, 0, 0, sydney), // Friday
+		next:     time.Date(2024, 1, 8, 9, 0, 0, 0, sydney),
+		ok:       true,
+	}, {
+		name:     "Should deactivate at the end of the day",
+		interval: "business-hours",
+		now:      time.Date(2024, 1, 8, 12, 30, 15, 0, sydney),
+		next:     time.Date(2024, 1, 8, 17, 0, 0, 0, sydney),
+		ok:       true,
+	}, {
+		name:     "Should activate in the future year",
+		interval: "new-year",
+		now:      time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		next:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		ok:       true,
+	}, {
+		name:     "Should deactivate at the end of the day",
+		interval: "new-year",
+		now:      time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		next:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		ok:       true,
+	}, {
+		name:     "Should not change after the last year",
+		interval: "new-year",
+		now:      time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		name:     "Should never change",
+		interval: "always",
+		now:      time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next, ok, err := intervener.NextTransition(test.interval, test.now, test.now.AddDate(2, 0, 0))
+			require.NoError(t, err)
+			require.Equal(t, test.ok, ok)
+			if test.ok {
+				require.True(t, test.next.Equal(next), "expected %s, got %s", test.next, next)
+			}
+		})
+	}
+
+	_, _, err = intervener.NextTransition("unknown", time.Now(), time.Now().Add(time.Hour))
+	require.Error(t, err)
+}
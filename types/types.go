@@ -14,11 +14,14 @@
 package types
 
 import (
+	"encoding/binary"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/cespare/xxhash/v2"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/model"
 
@@ -52,6 +55,15 @@ type AlertStatus struct {
 	silencesVersion int
 }
 
+// Ack is the acknowledgement of a firing alert. It applies until the alert
+// is resolved or the acknowledgement expires.
+type Ack struct {
+	AcknowledgedBy string    `json:"acknowledgedBy"`
+	Note           string    `json:"note,omitempty"`
+	AcknowledgedAt time.Time `json:"acknowledgedAt"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+}
+
 // groupStatus stores the state of the group, and, as applicable, the names
 // of all active and mute time intervals that are muting it.
 type groupStatus struct {
@@ -307,6 +319,45 @@ func (m *MemMarker) Silenced(alert model.Fingerprint) (activeIDs, pendingIDs []s
 		s.State == AlertStateSuppressed && len(s.SilencedBy) > 0
 }
 
+// SuppressionHash returns a hash of the suppression decisions of all alerts,
+// that is which alerts are silenced by which silences and which alerts are
+// inhibited. As InhibitedBy may only contain a subset of the inhibiting alerts,
+// only whether an alert is inhibited contributes to the hash.
+func (m *MemMarker) SuppressionHash() uint64 {
+	m.mtx.RLock()
+	fps := make([]model.Fingerprint, 0, len(m.alerts))
+	for fp, s := range m.alerts {
+		if s.State == AlertStateSuppressed {
+			fps = append(fps, fp)
+		}
+	}
+	slices.Sort(fps)
+
+	var (
+		h   = xxhash.New()
+		buf [8]byte
+	)
+	for _, fp := range fps {
+		s := m.alerts[fp]
+		binary.BigEndian.PutUint64(buf[:], uint64(fp))
+		h.Write(buf[:])
+		silencedBy := slices.Clone(s.SilencedBy)
+		slices.Sort(silencedBy)
+		for _, id := range silencedBy {
+			h.WriteString(id)
+			h.Write([]byte{0})
+		}
+		if len(s.InhibitedBy) > 0 {
+			h.Write([]byte{1})
+		} else {
+			h.Write([]byte{0})
+		}
+	}
+	m.mtx.RUnlock()
+
+	return h.Sum64()
+}
+
 // MultiError contains multiple errors and implements the error interface. Its
 // zero value is ready to use. All its methods are goroutine safe.
 type MultiError struct {
@@ -360,6 +411,9 @@ type Alert struct {
 	// The authoritative timestamp.
 	UpdatedAt time.Time
 	Timeout   bool
+	// Origins identifies the senders of the alert, sorted and without
+	// duplicates.
+	Origins []string
 }
 
 func validateLs(ls model.LabelSet) error {
@@ -464,9 +518,24 @@ func (a *Alert) Merge(o *Alert) *Alert {
 		}
 	}
 
+	res.Origins = MergeOrigins(a.Origins, o.Origins)
+
 	return &res
 }
 
+// MergeOrigins returns the sorted union of the given origins.
+func MergeOrigins(origins ...[]string) []string {
+	var res []string
+	for _, o := range origins {
+		res = append(res, o...)
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
 // A Muter determines whether a given label set is muted. Implementers that
 // maintain an underlying AlertMarker are expected to update it during a call of
 // Mutes.
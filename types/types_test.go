@@ -138,6 +138,45 @@ func TestMemMarker_Count(t *testing.T) {
 	require.Equal(t, 3, countTotal())
 }
 
+func TestMemMarker_SuppressionHash(t *testing.T) {
+	m1 := NewMarker(prometheus.NewRegistry())
+	m2 := NewMarker(prometheus.NewRegistry())
+	require.Equal(t, m1.SuppressionHash(), m2.SuppressionHash())
+
+	// Active alerts don't contribute to the hash.
+	m1.SetActiveOrSilenced(1, 1, nil, nil)
+	require.Equal(t, m1.SuppressionHash(), m2.SuppressionHash())
+
+	// The order of silence IDs and the silences version don't matter.
+	m1.SetActiveOrSilenced(2, 1, []string{"a", "b"}, nil)
+	m2.SetActiveOrSilenced(2, 2, []string{"b", "a"}, nil)
+	require.Equal(t, m1.SuppressionHash(), m2.SuppressionHash())
+
+	// Only whether an alert is inhibited matters, not by which alert.
+	m1.SetInhibited(3, "x")
+	m2.SetInhibited(3, "y")
+	require.Equal(t, m1.SuppressionHash(), m2.SuppressionHash())
+
+	// Different suppression decisions result in different hashes.
+	m2.SetActiveOrSilenced(2, 2, []string{"a"}, nil)
+	require.NotEqual(t, m1.SuppressionHash(), m2.SuppressionHash())
+	m2.SetActiveOrSilenced(2, 2, []string{"a", "b"}, nil)
+	m2.SetInhibited(3)
+	require.NotEqual(t, m1.SuppressionHash(), m2.SuppressionHash())
+}
+
+func TestAlertMergeOrigins(t *testing.T) {
+	now := time.Now()
+	a := &Alert{UpdatedAt: now, Origins: []string{"prometheus-1"}}
+	b := &Alert{UpdatedAt: now.Add(time.Minute), Origins: []string{"prometheus-0", "prometheus-1"}}
+
+	require.Equal(t, []string{"prometheus-0", "prometheus-1"}, a.Merge(b).Origins)
+	require.Equal(t, []string{"prometheus-0", "prometheus-1"}, b.Merge(a).Origins)
+	require.Nil(t, (&Alert{}).Merge(&Alert{}).Origins)
+	// The origins of the merged alerts aren't modified.
+	require.Equal(t, []string{"prometheus-1"}, a.Origins)
+}
+
 func TestAlertMerge(t *testing.T) {
 	now := time.Now()
 
@@ -0,0 +1,46 @@
+{-
+   Alertmanager API
+   API of the Prometheus Alertmanager (https://github.com/prometheus/alertmanager)
+
+   OpenAPI spec version: 0.0.1
+
+   NOTE: This file is auto generated by the openapi-generator.
+   https://github.com/openapitools/openapi-generator.git
+   Do not edit this file manually.
+-}
+
+
+module Data.Acknowledgement exposing (Acknowledgement, decoder, encoder)
+
+import DateTime exposing (DateTime)
+import Dict exposing (Dict)
+import Json.Decode as Decode exposing (Decoder)
+import Json.Decode.Pipeline exposing (optional, required)
+import Json.Encode as Encode
+
+
+type alias Acknowledgement =
+    { acknowledgedBy : String
+    , note : Maybe String
+    , acknowledgedAt : DateTime
+    , expiresAt : DateTime
+    }
+
+
+decoder : Decoder Acknowledgement
+decoder =
+    Decode.succeed Acknowledgement
+        |> required "acknowledgedBy" Decode.string
+        |> optional "note" (Decode.nullable Decode.string) Nothing
+        |> required "acknowledgedAt" DateTime.decoder
+        |> required "expiresAt" DateTime.decoder
+
+
+encoder : Acknowledgement -> Encode.Value
+encoder model =
+    Encode.object
+        [ ( "acknowledgedBy", Encode.string model.acknowledgedBy )
+        , ( "note", Maybe.withDefault Encode.null (Maybe.map Encode.string model.note) )
+        , ( "acknowledgedAt", DateTime.encoder model.acknowledgedAt )
+        , ( "expiresAt", DateTime.encoder model.expiresAt )
+        ]
@@ -12,6 +12,7 @@
 
 module Data.AlertStatus exposing (AlertStatus, State(..), decoder, encoder)
 
+import Data.Acknowledgement as Acknowledgement exposing (Acknowledgement)
 import Dict exposing (Dict)
 import Json.Decode as Decode exposing (Decoder)
 import Json.Decode.Pipeline exposing (optional, required)
@@ -23,6 +24,7 @@ type alias AlertStatus =
     , silencedBy : List String
     , inhibitedBy : List String
     , mutedBy : List String
+    , acknowledgement : Maybe Acknowledgement
     }
 
 
@@ -39,6 +41,7 @@ decoder =
         |> required "silencedBy" (Decode.list Decode.string)
         |> required "inhibitedBy" (Decode.list Decode.string)
         |> required "mutedBy" (Decode.list Decode.string)
+        |> optional "acknowledgement" (Decode.nullable Acknowledgement.decoder) Nothing
 
 
 encoder : AlertStatus -> Encode.Value
@@ -48,6 +51,7 @@ encoder model =
         , ( "silencedBy", Encode.list Encode.string model.silencedBy )
         , ( "inhibitedBy", Encode.list Encode.string model.inhibitedBy )
         , ( "mutedBy", Encode.list Encode.string model.mutedBy )
+        , ( "acknowledgement", Maybe.withDefault Encode.null (Maybe.map Acknowledgement.encoder model.acknowledgement) )
         ]
 
 
@@ -36,6 +36,7 @@ type alias Filter =
     , showInhibited : Maybe Bool
     , showMuted : Maybe Bool
     , showActive : Maybe Bool
+    , showResolved : Maybe Bool
     }
 
 
@@ -49,6 +50,7 @@ nullFilter =
     , showInhibited = Nothing
     , showMuted = Nothing
     , showActive = Nothing
+    , showResolved = Nothing
     }
 
 
@@ -58,7 +60,7 @@ generateQueryParam name =
 
 
 toUrl : String -> Filter -> String
-toUrl baseUrl { receiver, customGrouping, showSilenced, showInhibited, showMuted, showActive, text, group } =
+toUrl baseUrl { receiver, customGrouping, showSilenced, showInhibited, showMuted, showActive, showResolved, text, group } =
     let
         parts =
             [ ( "silenced", Maybe.withDefault False showSilenced |> boolToString |> Just )
@@ -69,6 +71,7 @@ toUrl baseUrl { receiver, customGrouping, showSilenced, showInhibited, showMuted
             , ( "receiver", emptyToNothing receiver )
             , ( "group", group )
             , ( "customGrouping", boolToMaybeString customGrouping )
+            , ( "resolved", Maybe.withDefault False showResolved |> boolToMaybeString )
             ]
                 |> List.filterMap (\( a, b ) -> generateQueryParam a b)
     in
@@ -84,7 +87,7 @@ toUrl baseUrl { receiver, customGrouping, showSilenced, showInhibited, showMuted
 
 
 generateAPIQueryString : Filter -> String
-generateAPIQueryString { receiver, showSilenced, showInhibited, showMuted, showActive, text, group } =
+generateAPIQueryString { receiver, showSilenced, showInhibited, showMuted, showActive, showResolved, text, group } =
     let
         filter_ =
             case parseFilter (Maybe.withDefault "" text) of
@@ -102,6 +105,13 @@ generateAPIQueryString { receiver, showSilenced, showInhibited, showMuted, showA
                    , ( "active", Maybe.withDefault True showActive |> boolToString |> Just )
                    , ( "receiver", emptyToNothing receiver )
                    , ( "group", group )
+                   , ( "state"
+                     , if Maybe.withDefault False showResolved then
+                        Just "resolved"
+
+                       else
+                        Nothing
+                     )
                    ]
                 |> List.filterMap (\( a, b ) -> generateQueryParam a b)
     in
@@ -387,6 +397,7 @@ silencePreviewFilter apiMatchers =
 type alias SilenceFormGetParams =
     { matchers : List Matcher
     , comment : String
+    , duration : String
     }
 
 
@@ -394,4 +405,5 @@ emptySilenceFormGetParams : SilenceFormGetParams
 emptySilenceFormGetParams =
     { matchers = []
     , comment = ""
+    , duration = ""
     }
@@ -49,6 +49,7 @@ view labels maybeActiveId alert =
             , silenceButton alert
             , inhibitedIcon alert
             , mutedIcon alert
+            , ackedIcon alert
             , linkButton alert
             ]
         , if maybeActiveId == Just alert.fingerprint then
@@ -157,6 +158,22 @@ inhibitedIcon alert =
             text ""
 
 
+ackedIcon : GettableAlert -> Html Msg
+ackedIcon alert =
+    case alert.status.acknowledgement of
+        Just ack ->
+            span
+                [ class "btn btn-outline-success border-0"
+                , title (ack.acknowledgedBy ++ Maybe.withDefault "" (Maybe.map ((++) ": ") ack.note))
+                ]
+                [ i [ class "fa fa-check mr-2" ] []
+                , text "Acknowledged"
+                ]
+
+        Nothing ->
+            text ""
+
+
 mutedIcon : GettableAlert -> Html Msg
 mutedIcon alert =
     case List.head alert.status.mutedBy of
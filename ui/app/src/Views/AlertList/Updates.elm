@@ -81,16 +81,21 @@ update msg ({ groupBar, alerts, filterBar, receiverBar, alertGroups } as model)
 
                 newFilterBar =
                     FilterBar.setMatchers filter filterBar
+
+                -- Resolved alerts aren't part of the alert groups, they are
+                -- listed and grouped like with custom grouping.
+                listAlerts =
+                    filter.customGrouping || Maybe.withDefault False filter.showResolved
             in
             ( { model
                 | alerts =
-                    if filter.customGrouping then
+                    if listAlerts then
                         Loading
 
                     else
                         alerts
                 , alertGroups =
-                    if filter.customGrouping then
+                    if listAlerts then
                         alertGroups
 
                     else
@@ -101,7 +106,7 @@ update msg ({ groupBar, alerts, filterBar, receiverBar, alertGroups } as model)
                 , activeGroups = Set.empty
               }
             , Cmd.batch
-                [ if filter.customGrouping then
+                [ if listAlerts then
                     Api.fetchAlerts apiUrl filter |> Cmd.map (AlertsFetched >> MsgForAlertList)
 
                   else
@@ -125,6 +130,11 @@ update msg ({ groupBar, alerts, filterBar, receiverBar, alertGroups } as model)
             , Navigation.pushUrl model.key (filteredUrl { filter | showMuted = Just showMuted })
             )
 
+        ToggleResolved showResolved ->
+            ( model
+            , Navigation.pushUrl model.key (filteredUrl { filter | showResolved = Just showResolved })
+            )
+
         SetTab tab ->
             ( { model | tab = tab }, Cmd.none )
 
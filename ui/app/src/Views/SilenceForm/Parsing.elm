@@ -16,10 +16,11 @@ newSilenceFromAlertLabels labels =
         |> encodeMatchers
 
 
-parseGetParams : Maybe String -> Maybe String -> SilenceFormGetParams
-parseGetParams filter comment =
+parseGetParams : Maybe String -> Maybe String -> Maybe String -> SilenceFormGetParams
+parseGetParams filter comment duration =
     { matchers = filter |> Maybe.andThen parseFilter >> Maybe.withDefault []
     , comment = comment |> Maybe.withDefault ""
+    , duration = duration |> Maybe.withDefault ""
     }
 
 
@@ -27,7 +28,7 @@ silenceFormNewParser : Parser (SilenceFormGetParams -> a) a
 silenceFormNewParser =
     s "silences"
         </> s "new"
-        <?> Query.map2 parseGetParams (Query.string "filter") (Query.string "comment")
+        <?> Query.map3 parseGetParams (Query.string "filter") (Query.string "comment") (Query.string "duration")
 
 
 silenceFormEditParser : Parser (String -> a) a
@@ -69,7 +69,7 @@ type SilenceFormMsg
     | SetActiveAlert (Maybe String)
     | FetchSilence String
     | NewSilenceFromMatchersAndComment String Utils.Filter.SilenceFormGetParams
-    | NewSilenceFromMatchersAndCommentAndTime String (List Utils.Filter.Matcher) String Posix
+    | NewSilenceFromMatchersAndCommentAndTime String (List Utils.Filter.Matcher) String String Posix
     | SilenceFetch (ApiData GettableSilence)
     | SilenceCreate (ApiData String)
     | UpdateDateTimePicker Utils.DateTimePicker.Types.Msg
@@ -217,15 +217,28 @@ defaultDuration =
     2 * 60 * 60 * 1000
 
 
-fromMatchersAndCommentAndTime : String -> String -> Posix -> FirstDayOfWeek -> SilenceForm
-fromMatchersAndCommentAndTime defaultCreator comment now firstDayOfWeek =
+fromMatchersAndCommentAndTime : String -> String -> String -> Posix -> FirstDayOfWeek -> SilenceForm
+fromMatchersAndCommentAndTime defaultCreator comment duration now firstDayOfWeek =
+    let
+        silenceDuration =
+            case parseDuration duration of
+                Ok parsed ->
+                    if parsed > 0 then
+                        parsed
+
+                    else
+                        defaultDuration
+
+                Err _ ->
+                    defaultDuration
+    in
     { id = Nothing
     , startsAt = initialField (timeToString now)
-    , endsAt = initialField (timeToString (addDuration defaultDuration now))
-    , duration = initialField (durationFormat defaultDuration |> Maybe.withDefault "")
+    , endsAt = initialField (timeToString (addDuration silenceDuration now))
+    , duration = initialField (durationFormat silenceDuration |> Maybe.withDefault "")
     , createdBy = initialField defaultCreator
     , comment = initialField comment
-    , dateTimePicker = initFromStartAndEndTime (Just now) (Just (addDuration defaultDuration now)) firstDayOfWeek
+    , dateTimePicker = initFromStartAndEndTime (Just now) (Just (addDuration silenceDuration now)) firstDayOfWeek
     , viewDateTimePicker = False
     }
 
@@ -206,10 +206,10 @@ update msg model basePath apiUrl =
             ( { model | silenceId = silenceId }, cmd )
 
         NewSilenceFromMatchersAndComment defaultCreator params ->
-            ( model, Task.perform (NewSilenceFromMatchersAndCommentAndTime defaultCreator params.matchers params.comment >> MsgForSilenceForm) Time.now )
+            ( model, Task.perform (NewSilenceFromMatchersAndCommentAndTime defaultCreator params.matchers params.comment params.duration >> MsgForSilenceForm) Time.now )
 
-        NewSilenceFromMatchersAndCommentAndTime defaultCreator matchers comment time ->
-            ( { form = fromMatchersAndCommentAndTime defaultCreator comment time model.firstDayOfWeek
+        NewSilenceFromMatchersAndCommentAndTime defaultCreator matchers comment duration time ->
+            ( { form = fromMatchersAndCommentAndTime defaultCreator comment duration time model.firstDayOfWeek
               , alerts = Initial
               , activeAlertId = Nothing
               , silenceId = Initial
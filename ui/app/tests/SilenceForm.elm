@@ -0,0 +1,53 @@
+module SilenceForm exposing (parseSilenceURL)
+
+import Expect
+import Parsing exposing (urlParser)
+import Test exposing (..)
+import Types exposing (Route(..))
+import Url
+import Utils.Date exposing (parseDuration)
+import Utils.Filter exposing (MatchOperator(..))
+
+
+-- The links are the ones generated by the silenceURL template function in
+-- template/template_test.go.
+parseSilenceURL : Test
+parseSilenceURL =
+    describe "silenceURL links"
+        [ test "should pre-fill the matchers and the duration from labels" <|
+            \() ->
+                Expect.equal
+                    (Just
+                        (SilenceFormNewRoute
+                            { matchers =
+                                [ { key = "alertname", op = Eq, value = "HighLatency" }
+                                , { key = "job", op = Eq, value = "api server" }
+                                ]
+                            , comment = ""
+                            , duration = "2h"
+                            }
+                        )
+                    )
+                    (Url.fromString "http://am.example.com/alertmanager/#/silences/new?filter=%7Balertname%3D%22HighLatency%22%2C%20job%3D%22api%20server%22%7D&duration=2h"
+                        |> Maybe.map urlParser
+                    )
+        , test "should pre-fill the matchers and the duration from a string" <|
+            \() ->
+                Expect.equal
+                    (Just
+                        (SilenceFormNewRoute
+                            { matchers = [ { key = "instance", op = RegexMatch, value = "web-.*" } ]
+                            , comment = ""
+                            , duration = "1d"
+                            }
+                        )
+                    )
+                    (Url.fromString "http://am.example.com/alertmanager/#/silences/new?filter=%7Binstance%3D~%22web-.%2A%22%7D&duration=1d"
+                        |> Maybe.map urlParser
+                    )
+        , test "should parse the durations of the links" <|
+            \() ->
+                Expect.equal
+                    [ Ok 7200000, Ok 86400000, Ok 5400000 ]
+                    (List.map parseDuration [ "2h", "1d", "1h30m" ])
+        ]
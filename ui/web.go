@@ -26,8 +26,9 @@ import (
 	"github.com/prometheus/alertmanager/asset"
 )
 
-// Register registers handlers to serve files for the web interface.
-func Register(r *route.Router, reloadCh chan<- chan error, logger *slog.Logger) {
+// Register registers handlers to serve files for the web interface. The
+// readiness endpoint returns 503 while ready returns an error.
+func Register(r *route.Router, reloadCh chan<- chan error, ready func() error, logger *slog.Logger) {
 	r.Get("/metrics", promhttp.Handler().ServeHTTP)
 
 	r.Get("/", func(w http.ResponseWriter, req *http.Request) {
@@ -80,10 +81,18 @@ func Register(r *route.Router, reloadCh chan<- chan error, logger *slog.Logger)
 		w.WriteHeader(http.StatusOK)
 	})
 	r.Get("/-/ready", func(w http.ResponseWriter, _ *http.Request) {
+		if err := ready(); err != nil {
+			http.Error(w, fmt.Sprintf("Not ready: %s", err), http.StatusServiceUnavailable)
+			return
+		}
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprintf(w, "OK")
 	})
 	r.Head("/-/ready", func(w http.ResponseWriter, _ *http.Request) {
+		if ready() != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
 		w.WriteHeader(http.StatusOK)
 	})
 
@@ -91,6 +100,21 @@ func Register(r *route.Router, reloadCh chan<- chan error, logger *slog.Logger)
 	r.Post("/debug/*subpath", http.DefaultServeMux.ServeHTTP)
 }
 
+// RegisterQuit registers the /-/quit endpoint which requests a graceful
+// shutdown by sending on quitCh.
+func RegisterQuit(r *route.Router, quitCh chan<- struct{}) {
+	quit := func(w http.ResponseWriter, _ *http.Request) {
+		select {
+		case quitCh <- struct{}{}:
+		default:
+			// A shutdown has already been requested.
+		}
+		fmt.Fprintf(w, "Requesting termination... Goodbye!")
+	}
+	r.Post("/-/quit", quit)
+	r.Put("/-/quit", quit)
+}
+
 func disableCaching(w http.ResponseWriter) {
 	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
 	w.Header().Set("Pragma", "no-cache")
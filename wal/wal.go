@@ -0,0 +1,361 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wal implements a write-ahead log of the alerts received by
+// Alertmanager, bounded in size and time, from which the alerts can be
+// replayed into the pipeline.
+package wal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coder/quartz"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/promslog"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	segmentSuffix = ".jsonl"
+	// segmentsPerLog is the number of segments the log is split into at its
+	// maximum size or retention, which is the granularity of truncation.
+	segmentsPerLog = 8
+)
+
+// record is a line of a segment, holding the alerts received at once.
+type record struct {
+	Time   time.Time `json:"time"`
+	Alerts []*alert  `json:"alerts"`
+}
+
+type alert struct {
+	model.Alert
+	Timeout bool     `json:"timeout,omitempty"`
+	Origins []string `json:"origins,omitempty"`
+}
+
+// Options configures a Log.
+type Options struct {
+	// Dir is the directory of the segments of the log.
+	Dir string
+	// MaxSize is the maximum size of the log in bytes. If zero, the size is
+	// unlimited.
+	MaxSize int64
+	// Retention is how long the alerts are kept in the log.
+	Retention time.Duration
+
+	Logger  *slog.Logger
+	Metrics prometheus.Registerer
+}
+
+// segment is a file of the log, named after the time of its first record.
+type segment struct {
+	start time.Time
+	size  int64
+}
+
+func (s segment) name() string {
+	return fmt.Sprintf("%020d%s", s.start.UnixNano(), segmentSuffix)
+}
+
+// Log is the write-ahead log of the received alerts.
+type Log struct {
+	dir       string
+	maxSize   int64
+	retention time.Duration
+	clock     quartz.Clock
+	logger    *slog.Logger
+
+	records  prometheus.Counter
+	failures prometheus.Counter
+
+	mtx      sync.Mutex
+	segments []segment
+	f        *os.File
+}
+
+// New opens the log in the directory of the options, creating it if needed.
+func New(o Options) (*Log, error) {
+	if o.Retention <= 0 {
+		return nil, errors.New("retention must be positive")
+	}
+	if o.Logger == nil {
+		o.Logger = promslog.NewNopLogger()
+	}
+	if err := os.MkdirAll(o.Dir, 0o750); err != nil {
+		return nil, err
+	}
+	l := &Log{
+		dir:       o.Dir,
+		maxSize:   o.MaxSize,
+		retention: o.Retention,
+		clock:     quartz.NewReal(),
+		logger:    o.Logger,
+		records: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_alerts_wal_records_total",
+			Help: "The total number of records of received alerts written to the write-ahead log.",
+		}),
+		failures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "alertmanager_alerts_wal_write_failures_total",
+			Help: "The total number of records of received alerts which failed to be written to the write-ahead log.",
+		}),
+	}
+
+	entries, err := os.ReadDir(o.Dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), segmentSuffix)
+		if !ok || e.IsDir() {
+			continue
+		}
+		ns, err := strconv.ParseInt(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		l.segments = append(l.segments, segment{start: time.Unix(0, ns), size: info.Size()})
+	}
+	sort.Slice(l.segments, func(i, j int) bool { return l.segments[i].start.Before(l.segments[j].start) })
+
+	if o.Metrics != nil {
+		o.Metrics.MustRegister(l.records, l.failures, prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "alertmanager_alerts_wal_size_bytes",
+			Help: "Size of the write-ahead log of the received alerts.",
+		}, func() float64 {
+			l.mtx.Lock()
+			defer l.mtx.Unlock()
+			return float64(l.size())
+		}))
+	}
+	return l, nil
+}
+
+func (l *Log) size() int64 {
+	var n int64
+	for _, s := range l.segments {
+		n += s.size
+	}
+	return n
+}
+
+// Append writes the received alerts to the log. The failures are logged, not
+// to fail the reception of the alerts.
+func (l *Log) Append(alerts []*types.Alert) {
+	if len(alerts) == 0 {
+		return
+	}
+	if err := l.append(alerts); err != nil {
+		l.failures.Inc()
+		l.logger.Error("Failed to write alerts to the write-ahead log", "err", err)
+		return
+	}
+	l.records.Inc()
+}
+
+func (l *Log) append(alerts []*types.Alert) error {
+	r := record{Time: l.clock.Now(), Alerts: make([]*alert, 0, len(alerts))}
+	for _, a := range alerts {
+		r.Alerts = append(r.Alerts, &alert{Alert: a.Alert, Timeout: a.Timeout, Origins: a.Origins})
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if l.f == nil || l.full(r.Time) {
+		if err := l.rotate(r.Time); err != nil {
+			return err
+		}
+	}
+	n, err := l.f.Write(b)
+	l.segments[len(l.segments)-1].size += int64(n)
+	return err
+}
+
+// full returns true if the current segment is due for rotation.
+func (l *Log) full(now time.Time) bool {
+	cur := l.segments[len(l.segments)-1]
+	if l.maxSize > 0 && cur.size >= l.maxSize/segmentsPerLog {
+		return true
+	}
+	return now.Sub(cur.start) >= l.retention/segmentsPerLog
+}
+
+// rotate starts a new segment and truncates the log.
+func (l *Log) rotate(now time.Time) error {
+	if l.f != nil {
+		if err := l.f.Close(); err != nil {
+			l.logger.Warn("Failed to close write-ahead log segment", "err", err)
+		}
+		l.f = nil
+	}
+	s := segment{start: now}
+	if n := len(l.segments); n > 0 && !s.start.After(l.segments[n-1].start) {
+		// Keep the segments ordered despite clock adjustments.
+		s.start = l.segments[n-1].start.Add(time.Nanosecond)
+	}
+	f, err := os.OpenFile(filepath.Join(l.dir, s.name()), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return err
+	}
+	l.f = f
+	l.segments = append(l.segments, s)
+	l.truncate(now)
+	return nil
+}
+
+// truncate removes the oldest segments beyond the retention or the maximum
+// size. The current segment is never removed.
+func (l *Log) truncate(now time.Time) {
+	size := l.size()
+	for len(l.segments) > 1 {
+		// The records of a segment are older than the start of the next one.
+		expired := now.Sub(l.segments[1].start) > l.retention
+		if !expired && (l.maxSize <= 0 || size <= l.maxSize) {
+			break
+		}
+		s := l.segments[0]
+		if err := os.Remove(filepath.Join(l.dir, s.name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			l.logger.Warn("Failed to remove write-ahead log segment", "segment", s.name(), "err", err)
+			break
+		}
+		size -= s.size
+		l.segments = l.segments[1:]
+	}
+}
+
+// Truncate removes the segments whose alerts are all beyond the retention.
+func (l *Log) Truncate() {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.truncate(l.clock.Now())
+}
+
+// Maintenance truncates the log at the given interval until stopc is closed.
+func (l *Log) Maintenance(interval time.Duration, stopc <-chan struct{}) {
+	t := l.clock.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stopc:
+			return
+		case <-t.C:
+			l.Truncate()
+		}
+	}
+}
+
+// Close closes the current segment.
+func (l *Log) Close() error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
+
+// ReplayResult sums up a replay.
+type ReplayResult struct {
+	// Records is the number of records replayed.
+	Records int `json:"records"`
+	// Alerts is the number of alerts replayed.
+	Alerts int `json:"alerts"`
+}
+
+// Replay calls f with the alerts of the records received between from and to
+// included, in the order they were received. The alerts are updated at the
+// time they were received.
+func (l *Log) Replay(from, to time.Time, f func([]*types.Alert) error) (ReplayResult, error) {
+	l.mtx.Lock()
+	segments := append([]segment(nil), l.segments...)
+	l.mtx.Unlock()
+
+	var res ReplayResult
+	for i, s := range segments {
+		if s.start.After(to) {
+			break
+		}
+		if i+1 < len(segments) && !segments[i+1].start.After(from) {
+			continue
+		}
+		if err := l.replaySegment(s, from, to, f, &res); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+func (l *Log) replaySegment(s segment, from, to time.Time, f func([]*types.Alert) error, res *ReplayResult) error {
+	file, err := os.Open(filepath.Join(l.dir, s.name()))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// The segment was truncated in the meantime.
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			// A last line without newline is being written or was torn
+			// by a crash.
+			return nil
+		}
+		var rec record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			l.logger.Warn("Skipping corrupted write-ahead log record", "segment", s.name(), "err", err)
+			continue
+		}
+		if rec.Time.Before(from) || rec.Time.After(to) {
+			continue
+		}
+		alerts := make([]*types.Alert, 0, len(rec.Alerts))
+		for _, a := range rec.Alerts {
+			alerts = append(alerts, &types.Alert{Alert: a.Alert, UpdatedAt: rec.Time, Timeout: a.Timeout, Origins: a.Origins})
+		}
+		if err := f(alerts); err != nil {
+			return err
+		}
+		res.Records++
+		res.Alerts += len(alerts)
+	}
+}
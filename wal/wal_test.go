@@ -0,0 +1,157 @@
+// Copyright 2024 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coder/quartz"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/prometheus/alertmanager/types"
+)
+
+func newTestLog(t *testing.T, dir string, maxSize int64, retention time.Duration) (*Log, *quartz.Mock) {
+	t.Helper()
+	l, err := New(Options{Dir: dir, MaxSize: maxSize, Retention: retention})
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	clock := quartz.NewMock(t)
+	l.clock = clock
+	return l, clock
+}
+
+func testAlert(name string) *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": model.LabelValue(name)},
+			Annotations: model.LabelSet{"summary": "test"},
+			StartsAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:      time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC),
+		},
+		Timeout: true,
+		Origins: []string{"prometheus-0"},
+	}
+}
+
+// replayAll returns the names of the alerts replayed between from and to.
+func replayAll(t *testing.T, l *Log, from, to time.Time) []string {
+	t.Helper()
+	var names []string
+	_, err := l.Replay(from, to, func(alerts []*types.Alert) error {
+		for _, a := range alerts {
+			names = append(names, string(a.Labels["alertname"]))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return names
+}
+
+func TestReplay(t *testing.T) {
+	l, clock := newTestLog(t, t.TempDir(), 0, time.Hour)
+
+	t0 := clock.Now()
+	l.Append([]*types.Alert{testAlert("a"), testAlert("b")})
+	clock.Advance(10 * time.Minute)
+	l.Append([]*types.Alert{testAlert("c")})
+	clock.Advance(10 * time.Minute)
+	l.Append([]*types.Alert{testAlert("d")})
+	l.Append(nil)
+
+	var got []*types.Alert
+	res, err := l.Replay(t0, clock.Now(), func(alerts []*types.Alert) error {
+		got = append(got, alerts...)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, ReplayResult{Records: 3, Alerts: 4}, res)
+	a := testAlert("a")
+	require.Equal(t, a.Labels, got[0].Labels)
+	require.Equal(t, a.Annotations, got[0].Annotations)
+	require.True(t, a.StartsAt.Equal(got[0].StartsAt))
+	require.True(t, a.EndsAt.Equal(got[0].EndsAt))
+	require.True(t, got[0].Timeout)
+	require.Equal(t, a.Origins, got[0].Origins)
+
+	require.Equal(t, []string{"c"}, replayAll(t, l, t0.Add(5*time.Minute), t0.Add(15*time.Minute)))
+	require.Equal(t, []string{"c", "d"}, replayAll(t, l, t0.Add(10*time.Minute), clock.Now()))
+	require.Empty(t, replayAll(t, l, clock.Now().Add(time.Second), clock.Now().Add(time.Hour)))
+
+	// Errors of the callback stop the replay.
+	errReplay := errors.New("replay")
+	res, err = l.Replay(t0, clock.Now(), func([]*types.Alert) error { return errReplay })
+	require.ErrorIs(t, err, errReplay)
+	require.Equal(t, ReplayResult{}, res)
+}
+
+func TestRetention(t *testing.T) {
+	l, clock := newTestLog(t, t.TempDir(), 0, 8*time.Hour)
+
+	t0 := clock.Now()
+	for i := 0; i < 24; i++ {
+		l.Append([]*types.Alert{testAlert("a")})
+		clock.Advance(time.Hour)
+	}
+	l.Truncate()
+
+	// Only the segments holding alerts beyond the retention are removed.
+	names := replayAll(t, l, t0, clock.Now())
+	require.GreaterOrEqual(t, len(names), 8)
+	require.LessOrEqual(t, len(names), 9)
+	require.LessOrEqual(t, len(l.segments), 9)
+}
+
+func TestMaxSize(t *testing.T) {
+	l, clock := newTestLog(t, t.TempDir(), 4096, time.Hour)
+
+	for i := 0; i < 200; i++ {
+		l.Append([]*types.Alert{testAlert("a")})
+		clock.Advance(time.Millisecond)
+	}
+
+	l.mtx.Lock()
+	size := l.size()
+	l.mtx.Unlock()
+	// The log exceeds its maximum size by the current segment at most.
+	require.LessOrEqual(t, size, int64(4096+4096/segmentsPerLog+200))
+	require.NotEmpty(t, replayAll(t, l, clock.Now().Add(-time.Hour), clock.Now()))
+}
+
+func TestReopen(t *testing.T) {
+	dir := t.TempDir()
+	l, clock := newTestLog(t, dir, 0, time.Hour)
+	t0 := clock.Now()
+	l.Append([]*types.Alert{testAlert("a")})
+	l.Append([]*types.Alert{testAlert("b")})
+	require.NoError(t, l.Close())
+
+	// Simulate a record torn by a crash.
+	f, err := os.OpenFile(filepath.Join(dir, l.segments[0].name()), os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"time":"`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	l, clock = newTestLog(t, dir, 0, time.Hour)
+	clock.Set(t0.Add(time.Minute))
+	l.Append([]*types.Alert{testAlert("c")})
+	require.Len(t, l.segments, 2)
+	require.Equal(t, []string{"a", "b", "c"}, replayAll(t, l, t0, clock.Now()))
+}